@@ -0,0 +1,265 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merkletree
+
+import (
+	"bytes"
+	"errors"
+	"hash"
+	"math/bits"
+	"sync"
+)
+
+var (
+	ErrLeafIndex    = errors.New("leaf index out of range")
+	ErrLeafMismatch = errors.New("the data does not match the leaf")
+	ErrInvalidMMR   = errors.New("the store does not contain a valid MMR")
+	ErrNodeNotFound = errors.New("node not found in store")
+)
+
+// Store persists the nodes of an MMR. Nodes are identified by their position in the
+// post-order traversal of the mountains, and are only ever appended: an MMR backed by a
+// persistent Store can be reopened with OpenMMR.
+//
+// MemoryStore keeps the nodes in memory. For trees too large for it, FileStore writes
+// them to a file, MMapStore memory-maps a complete file to build proofs, and KVStore
+// keeps them in a key-value database.
+type Store interface {
+	// Append stores node at position Size().
+	Append(node []byte) error
+
+	// Get returns the node at position pos < Size().
+	Get(pos uint64) ([]byte, error)
+
+	// Size returns the number of stored nodes.
+	Size() uint64
+}
+
+// MemoryStore is a Store keeping all the nodes in memory.
+type MemoryStore struct {
+	lock  sync.RWMutex
+	nodes [][]byte
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// Append implements Store.
+func (s *MemoryStore) Append(node []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.nodes = append(s.nodes, append(node[:0:0], node...))
+	return nil
+}
+
+// Get implements Store.
+func (s *MemoryStore) Get(pos uint64) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if pos >= uint64(len(s.nodes)) {
+		return nil, ErrNodeNotFound
+	}
+	return s.nodes[pos], nil
+}
+
+// Size implements Store.
+func (s *MemoryStore) Size() uint64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return uint64(len(s.nodes))
+}
+
+// MMR is an append-only Merkle tree (Merkle Mountain Range). Contrary to Tree, leaves
+// can be appended at any time and an inclusion proof can be built for any leaf, without
+// rebuilding the tree: all the nodes are kept in a Store, and only the roots of the
+// perfect subtrees (the peaks) are cached.
+//
+// Leaves and nodes are hashed as in Tree, and the peaks are bagged from right to left as
+// Tree joins its orphan subtrees: for the same leaves, an MMR and a Tree have the same
+// root, and the proofs of an MMR are verified by VerifyProof.
+//
+// An MMR is not safe for concurrent use.
+type MMR struct {
+	hash      hash.Hash
+	store     Store
+	numLeaves uint64
+	peaks     [][]byte // from the highest to the lowest mountain
+}
+
+// NewMMR returns an empty MMR hashing with h and storing its nodes in store, which must
+// be empty.
+func NewMMR(h hash.Hash, store Store) (*MMR, error) {
+	if store.Size() != 0 {
+		return nil, ErrInvalidMMR
+	}
+	return &MMR{hash: h, store: store}, nil
+}
+
+// OpenMMR returns the MMR whose nodes are in store, hashing with h.
+func OpenMMR(h hash.Hash, store Store) (*MMR, error) {
+	numLeaves, ok := mmrNumLeaves(store.Size())
+	if !ok {
+		return nil, ErrInvalidMMR
+	}
+	m := &MMR{hash: h, store: store, numLeaves: numLeaves}
+	for _, p := range mmrPeaks(numLeaves) {
+		peak, err := store.Get(p.pos)
+		if err != nil {
+			return nil, err
+		}
+		m.peaks = append(m.peaks, peak)
+	}
+	return m, nil
+}
+
+// NumLeaves returns the number of leaves appended to the MMR.
+func (m *MMR) NumLeaves() uint64 {
+	return m.numLeaves
+}
+
+// Append adds data as a new leaf, and returns its index. If the store fails, the MMR
+// must be reopened from the store.
+func (m *MMR) Append(data []byte) (uint64, error) {
+	node := leafSum(m.hash, data)
+	if err := m.store.Append(node); err != nil {
+		return 0, err
+	}
+	m.peaks = append(m.peaks, node)
+
+	// the new leaf merges with the mountains of the same height: there are as many as
+	// trailing ones in the index of the leaf.
+	for i := bits.TrailingZeros64(^m.numLeaves); i > 0; i-- {
+		n := len(m.peaks)
+		node = nodeSum(m.hash, m.peaks[n-2], m.peaks[n-1])
+		if err := m.store.Append(node); err != nil {
+			return 0, err
+		}
+		m.peaks = append(m.peaks[:n-2], node)
+	}
+
+	m.numLeaves++
+	return m.numLeaves - 1, nil
+}
+
+// Root returns the Merkle root of the MMR, or nil if it is empty.
+func (m *MMR) Root() []byte {
+	if len(m.peaks) == 0 {
+		return nil
+	}
+	root := m.peaks[len(m.peaks)-1]
+	for i := len(m.peaks) - 2; i >= 0; i-- {
+		root = nodeSum(m.hash, m.peaks[i], root)
+	}
+	// Return a copy to prevent leaking a pointer to internal data.
+	return append(root[:0:0], root...)
+}
+
+// Prove returns a proof that data is the leaf at index, in the format of Tree.Prove:
+// it is verified with VerifyProof(h, merkleRoot, proofSet, index, numLeaves).
+func (m *MMR) Prove(index uint64, data []byte) (merkleRoot []byte, proofSet [][]byte, numLeaves uint64, err error) {
+	if index >= m.numLeaves {
+		return nil, nil, 0, ErrLeafIndex
+	}
+
+	// find the mountain containing the leaf
+	peaks := mmrPeaks(m.numLeaves)
+	k := 0
+	for index >= peaks[k].firstLeaf+1<<peaks[k].height {
+		k++
+	}
+
+	// walk down from the peak to the leaf, collecting the siblings
+	siblings := make([][]byte, peaks[k].height)
+	pos, first := peaks[k].pos, peaks[k].firstLeaf
+	for h := peaks[k].height; h > 0; h-- {
+		// the right child is at pos-1, the left child at pos-2ʰ
+		left, right := pos-(1<<h), pos-1
+		var sibling uint64
+		if index < first+1<<(h-1) {
+			pos, sibling = left, right
+		} else {
+			pos, sibling = right, left
+			first += 1 << (h - 1)
+		}
+		if siblings[h-1], err = m.store.Get(sibling); err != nil {
+			return nil, nil, 0, err
+		}
+	}
+	leaf, err := m.store.Get(pos)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if !bytes.Equal(leaf, leafSum(m.hash, data)) {
+		return nil, nil, 0, ErrLeafMismatch
+	}
+
+	proofSet = make([][]byte, 0, 1+len(siblings)+len(m.peaks))
+	proofSet = append(proofSet, data)
+	proofSet = append(proofSet, siblings...)
+
+	// the lower mountains are bagged into a single right sibling, the higher ones are
+	// left siblings.
+	if k < len(m.peaks)-1 {
+		bag := m.peaks[len(m.peaks)-1]
+		for i := len(m.peaks) - 2; i > k; i-- {
+			bag = nodeSum(m.hash, m.peaks[i], bag)
+		}
+		proofSet = append(proofSet, bag)
+	}
+	for i := k - 1; i >= 0; i-- {
+		proofSet = append(proofSet, m.peaks[i])
+	}
+
+	return m.Root(), proofSet, m.numLeaves, nil
+}
+
+// mmrPeak locates the root of a mountain.
+type mmrPeak struct {
+	pos       uint64 // position of the root in the store
+	height    int
+	firstLeaf uint64 // index of the leftmost leaf of the mountain
+}
+
+// mmrPeaks returns the peaks of an MMR with numLeaves leaves, from the highest to the
+// lowest: there is one mountain of height h for each bit h set in numLeaves.
+func mmrPeaks(numLeaves uint64) []mmrPeak {
+	peaks := make([]mmrPeak, 0, bits.OnesCount64(numLeaves))
+	var pos, firstLeaf uint64
+	for h := bits.Len64(numLeaves) - 1; h >= 0; h-- {
+		if numLeaves&(1<<h) == 0 {
+			continue
+		}
+		// a mountain of height h has 2ʰ⁺¹-1 nodes, the root being the last one
+		pos += 1<<(h+1) - 1
+		peaks = append(peaks, mmrPeak{pos: pos - 1, height: h, firstLeaf: firstLeaf})
+		firstLeaf += 1 << h
+	}
+	return peaks
+}
+
+// mmrNumLeaves returns the number of leaves of an MMR with size nodes, and false if
+// no MMR has this size.
+func mmrNumLeaves(size uint64) (uint64, bool) {
+	var numLeaves uint64
+	for h := 62; h >= 0; h-- {
+		if n := uint64(1)<<(h+1) - 1; size >= n {
+			size -= n
+			numLeaves |= 1 << h
+		}
+	}
+	return numLeaves, size == 0
+}
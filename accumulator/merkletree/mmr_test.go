@@ -0,0 +1,141 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merkletree
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"math/bits"
+	"testing"
+)
+
+func TestMMR(t *testing.T) {
+	h := sha256.New()
+	store := NewMemoryStore()
+	mmr, err := NewMMR(h, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mmr.Root() != nil {
+		t.Fatal("root of an empty MMR should be nil")
+	}
+
+	const nbLeaves = 37
+	leaves := make([][]byte, nbLeaves)
+	for n := 0; n < nbLeaves; n++ {
+		leaves[n] = []byte(fmt.Sprintf("leaf %d", n))
+		index, err := mmr.Append(leaves[n])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if index != uint64(n) {
+			t.Fatalf("unexpected leaf index %d, expected %d", index, n)
+		}
+
+		// the MMR and the tree with the same leaves agree
+		tree := New(h)
+		if err := tree.SetIndex(uint64(n / 2)); err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i <= n; i++ {
+			tree.Push(leaves[i])
+		}
+		root, proofSet, _, _ := tree.Prove()
+		if !bytes.Equal(mmr.Root(), root) {
+			t.Fatalf("%d leaves: MMR and tree roots differ", n+1)
+		}
+		mmrRoot, mmrProofSet, numLeaves, err := mmr.Prove(uint64(n/2), leaves[n/2])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(mmrRoot, root) || numLeaves != uint64(n+1) || len(mmrProofSet) != len(proofSet) {
+			t.Fatalf("%d leaves: MMR and tree proofs differ", n+1)
+		}
+		for i := range proofSet {
+			if !bytes.Equal(mmrProofSet[i], proofSet[i]) {
+				t.Fatalf("%d leaves: MMR and tree proofs differ", n+1)
+			}
+		}
+
+		// every leaf can be proven
+		for i := 0; i <= n; i++ {
+			root, proofSet, numLeaves, err := mmr.Prove(uint64(i), leaves[i])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !VerifyProof(h, root, proofSet, uint64(i), numLeaves) {
+				t.Fatalf("%d leaves: invalid proof for leaf %d", n+1, i)
+			}
+		}
+	}
+
+	if _, _, _, err := mmr.Prove(3, leaves[4]); err != ErrLeafMismatch {
+		t.Fatal("expected ErrLeafMismatch")
+	}
+	if _, _, _, err := mmr.Prove(nbLeaves, leaves[0]); err != ErrLeafIndex {
+		t.Fatal("expected ErrLeafIndex")
+	}
+
+	// reopen the MMR from its store and keep appending
+	if _, err := NewMMR(h, store); err != ErrInvalidMMR {
+		t.Fatal("expected ErrInvalidMMR on a non empty store")
+	}
+	reopened, err := OpenMMR(h, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reopened.NumLeaves() != nbLeaves || !bytes.Equal(reopened.Root(), mmr.Root()) {
+		t.Fatal("reopened MMR differs")
+	}
+	leaf := []byte("one more leaf")
+	if _, err := reopened.Append(leaf); err != nil {
+		t.Fatal(err)
+	}
+	root, proofSet, numLeaves, err := reopened.Prove(nbLeaves, leaf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyProof(h, root, proofSet, nbLeaves, numLeaves) {
+		t.Fatal("invalid proof after reopening")
+	}
+}
+
+func TestMMRNumLeaves(t *testing.T) {
+	var size uint64
+	for n := uint64(0); n < 1000; n++ {
+		numLeaves, ok := mmrNumLeaves(size)
+		if !ok || numLeaves != n {
+			t.Fatalf("size %d: expected %d leaves, got %d", size, n, numLeaves)
+		}
+		// sizes strictly between two MMRs are invalid
+		next := size + uint64(bits.TrailingZeros64(^n)) + 1
+		for s := size + 1; s < next; s++ {
+			if _, ok := mmrNumLeaves(s); ok {
+				t.Fatalf("size %d should be invalid", s)
+			}
+		}
+		size = next
+	}
+}
+
+func BenchmarkMMRAppend(b *testing.B) {
+	mmr, _ := NewMMR(sha256.New(), NewMemoryStore())
+	data := make([]byte, 32)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mmr.Append(data)
+	}
+}
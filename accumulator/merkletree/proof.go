@@ -0,0 +1,204 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merkletree
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+)
+
+var (
+	ErrInvalidProof            = errors.New("invalid merkle proof encoding")
+	ErrUnsupportedProofVersion = errors.New("unsupported merkle proof version")
+)
+
+// HashID identifies the hash function of a tree in a serialized Proof, so that a
+// verifier can check it uses the right one.
+type HashID uint16
+
+const (
+	// HashUnspecified means the hash function is agreed upon out of band.
+	HashUnspecified HashID = 0
+	HashSHA256      HashID = 1
+	HashSHA512      HashID = 2
+	HashKeccak256   HashID = 3
+	HashSHA3_256    HashID = 4
+	HashBLAKE2b256  HashID = 5
+
+	// HashMiMC is the identifier of the MiMC hash function hash.MIMC_BN254 of
+	// gnark-crypto/hash; the one of hash.Hash(i) is HashMiMC+i.
+	HashMiMC HashID = 0x100
+)
+
+// ProofVersion is the version of the format written by Proof.WriteTo.
+const ProofVersion = 1
+
+// proofArity is the arity of the trees of this package, written in the proofs so that
+// the format can be shared with trees of higher arity.
+const proofArity = 2
+
+// limits of the decoded proofs, so that reading an untrusted proof can't allocate
+// unbounded memory
+const (
+	maxProofDataSize = 1 << 24
+	maxProofPathLen  = 64
+)
+
+// Proof is an inclusion proof of Data, the leaf at Index in a tree of NumLeaves leaves,
+// with the serialization (WriteTo and ReadFrom):
+//
+//	version    uint8   ProofVersion
+//	hash id    uint16  HashID
+//	arity      uint8   2
+//	index      uint64
+//	numLeaves  uint64
+//	data       uint32 length, then the bytes of the leaf
+//	path       uint8 number of nodes, uint16 node size, then the nodes
+//
+// all integers being big endian. Readers reject the versions they don't know, and
+// later versions only add fields after the ones of the previous versions.
+type Proof struct {
+	HashID    HashID
+	Index     uint64
+	NumLeaves uint64
+	// Data is the leaf, the first element of the proof sets of Tree.Prove and
+	// MMR.Prove.
+	Data []byte
+	// Path are the sibling nodes, from the leaf to the root.
+	Path [][]byte
+}
+
+// NewProof returns the proof of a proof set built by Tree.Prove or MMR.Prove.
+func NewProof(hashID HashID, proofSet [][]byte, index, numLeaves uint64) (*Proof, error) {
+	if len(proofSet) == 0 {
+		return nil, fmt.Errorf("%w: empty proof set", ErrInvalidProof)
+	}
+	return &Proof{
+		HashID:    hashID,
+		Index:     index,
+		NumLeaves: numLeaves,
+		Data:      proofSet[0],
+		Path:      proofSet[1:],
+	}, nil
+}
+
+// ProofSet returns the proof set of p, as expected by VerifyProof.
+func (p *Proof) ProofSet() [][]byte {
+	res := make([][]byte, 0, 1+len(p.Path))
+	res = append(res, p.Data)
+	return append(res, p.Path...)
+}
+
+// Verify returns true if p proves that Data is a leaf of the tree of root merkleRoot,
+// hashed with h, which must be the function identified by p.HashID.
+func (p *Proof) Verify(h hash.Hash, merkleRoot []byte) bool {
+	return VerifyProof(h, merkleRoot, p.ProofSet(), p.Index, p.NumLeaves)
+}
+
+// WriteTo writes the serialization of p to w.
+func (p *Proof) WriteTo(w io.Writer) (int64, error) {
+	if len(p.Data) > maxProofDataSize {
+		return 0, fmt.Errorf("%w: leaf of %d bytes", ErrInvalidProof, len(p.Data))
+	}
+	if len(p.Path) > maxProofPathLen {
+		return 0, fmt.Errorf("%w: path of %d nodes", ErrInvalidProof, len(p.Path))
+	}
+	nodeSize := 0
+	if len(p.Path) > 0 {
+		nodeSize = len(p.Path[0])
+	}
+	for _, node := range p.Path {
+		if len(node) != nodeSize || nodeSize > 0xffff {
+			return 0, fmt.Errorf("%w: nodes of different sizes", ErrInvalidProof)
+		}
+	}
+
+	buf := make([]byte, 0, 28+len(p.Data)+len(p.Path)*nodeSize)
+	buf = append(buf, ProofVersion)
+	buf = binary.BigEndian.AppendUint16(buf, uint16(p.HashID))
+	buf = append(buf, proofArity)
+	buf = binary.BigEndian.AppendUint64(buf, p.Index)
+	buf = binary.BigEndian.AppendUint64(buf, p.NumLeaves)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(p.Data)))
+	buf = append(buf, p.Data...)
+	buf = append(buf, uint8(len(p.Path)))
+	buf = binary.BigEndian.AppendUint16(buf, uint16(nodeSize))
+	for _, node := range p.Path {
+		buf = append(buf, node...)
+	}
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// ReadFrom reads the serialization of a proof from r into p.
+func (p *Proof) ReadFrom(r io.Reader) (int64, error) {
+	var n int64
+	read := func(buf []byte) error {
+		m, err := io.ReadFull(r, buf)
+		n += int64(m)
+		if err == io.ErrUnexpectedEOF || (err == io.EOF && n > 0) {
+			err = fmt.Errorf("%w: truncated", ErrInvalidProof)
+		}
+		return err
+	}
+
+	var header [24]byte
+	if err := read(header[:1]); err != nil {
+		return n, err
+	}
+	if header[0] != ProofVersion {
+		return n, fmt.Errorf("%w: %d", ErrUnsupportedProofVersion, header[0])
+	}
+	if err := read(header[1:24]); err != nil {
+		return n, err
+	}
+	if header[3] != proofArity {
+		return n, fmt.Errorf("%w: arity %d", ErrInvalidProof, header[3])
+	}
+	var res Proof
+	res.HashID = HashID(binary.BigEndian.Uint16(header[1:3]))
+	res.Index = binary.BigEndian.Uint64(header[4:12])
+	res.NumLeaves = binary.BigEndian.Uint64(header[12:20])
+	dataSize := binary.BigEndian.Uint32(header[20:24])
+	if dataSize > maxProofDataSize {
+		return n, fmt.Errorf("%w: leaf of %d bytes", ErrInvalidProof, dataSize)
+	}
+	res.Data = make([]byte, dataSize)
+	if err := read(res.Data); err != nil {
+		return n, err
+	}
+
+	if err := read(header[:3]); err != nil {
+		return n, err
+	}
+	pathLen, nodeSize := int(header[0]), int(binary.BigEndian.Uint16(header[1:3]))
+	if pathLen > maxProofPathLen {
+		return n, fmt.Errorf("%w: path of %d nodes", ErrInvalidProof, pathLen)
+	}
+	nodes := make([]byte, pathLen*nodeSize)
+	if err := read(nodes); err != nil {
+		return n, err
+	}
+	res.Path = make([][]byte, pathLen)
+	for i := range res.Path {
+		res.Path[i] = nodes[i*nodeSize : (i+1)*nodeSize : (i+1)*nodeSize]
+	}
+
+	*p = res
+	return n, nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merkletree
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestProofSerialization(t *testing.T) {
+	mmr, leaves := buildMMR(t, NewMemoryStore(), 13)
+
+	for i := range leaves {
+		root, proofSet, numLeaves, err := mmr.Prove(uint64(i), leaves[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		proof, err := NewProof(HashSHA256, proofSet, uint64(i), numLeaves)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(proof.ProofSet(), proofSet) {
+			t.Fatal("proof set differs")
+		}
+
+		var buf bytes.Buffer
+		written, err := proof.WriteTo(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if written != int64(buf.Len()) {
+			t.Fatalf("WriteTo returned %d, wrote %d bytes", written, buf.Len())
+		}
+		encoded := buf.Bytes()
+
+		var decoded Proof
+		read, err := decoded.ReadFrom(bytes.NewReader(encoded))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if read != written {
+			t.Fatalf("ReadFrom returned %d, expected %d", read, written)
+		}
+		if !reflect.DeepEqual(&decoded, proof) {
+			t.Fatal("decoded proof differs")
+		}
+		if !decoded.Verify(sha256.New(), root) {
+			t.Fatalf("invalid decoded proof for leaf %d", i)
+		}
+
+		// truncated encodings are rejected
+		for l := 1; l < len(encoded); l++ {
+			if _, err := decoded.ReadFrom(bytes.NewReader(encoded[:l])); !errors.Is(err, ErrInvalidProof) {
+				t.Fatalf("truncated to %d bytes: expected ErrInvalidProof, got %v", l, err)
+			}
+		}
+	}
+}
+
+func TestProofFormat(t *testing.T) {
+	// the format is stable: this encoding must remain readable
+	proof := Proof{
+		HashID:    HashKeccak256,
+		Index:     5,
+		NumLeaves: 6,
+		Data:      []byte("leaf"),
+		Path:      [][]byte{{0xaa, 0xbb}, {0xcc, 0xdd}},
+	}
+	const expected = "01" + "0003" + "02" + "0000000000000005" + "0000000000000006" +
+		"00000004" + "6c656166" + "02" + "0002" + "aabb" + "ccdd"
+	var buf bytes.Buffer
+	if _, err := proof.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(buf.Bytes()) != expected {
+		t.Fatalf("unexpected encoding %x", buf.Bytes())
+	}
+
+	encoded, _ := hex.DecodeString(expected)
+	var decoded Proof
+
+	// unknown versions and arities are rejected
+	encoded[0] = ProofVersion + 1
+	if _, err := decoded.ReadFrom(bytes.NewReader(encoded)); !errors.Is(err, ErrUnsupportedProofVersion) {
+		t.Fatalf("expected ErrUnsupportedProofVersion, got %v", err)
+	}
+	encoded[0] = ProofVersion
+	encoded[3] = 4
+	if _, err := decoded.ReadFrom(bytes.NewReader(encoded)); !errors.Is(err, ErrInvalidProof) {
+		t.Fatalf("expected ErrInvalidProof, got %v", err)
+	}
+
+	// nodes of different sizes can't be encoded
+	proof.Path[1] = []byte{0xcc}
+	if _, err := proof.WriteTo(&buf); !errors.Is(err, ErrInvalidProof) {
+		t.Fatalf("expected ErrInvalidProof, got %v", err)
+	}
+
+	if _, err := NewProof(HashSHA256, nil, 0, 1); !errors.Is(err, ErrInvalidProof) {
+		t.Fatalf("expected ErrInvalidProof, got %v", err)
+	}
+}
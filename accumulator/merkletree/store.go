@@ -0,0 +1,302 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merkletree
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/utils/mmap"
+)
+
+var (
+	ErrNodeSize      = errors.New("node size doesn't match the store")
+	ErrReadOnlyStore = errors.New("the store is read-only")
+)
+
+// fileStoreBufferSize is the size of the write buffer of a FileStore.
+const fileStoreBufferSize = 1 << 20
+
+// FileStore is a Store keeping the nodes in a file, one after the other. The nodes
+// must all have the same size, the size of the hash function of the MMR.
+//
+// Only the pending writes are kept in memory, so that an MMR backed by a FileStore
+// can hold billions of leaves with a memory footprint in O(log(n)). The file can be
+// reopened with OpenFileStore, or memory-mapped with OpenMMapStore once complete.
+//
+// It is safe for concurrent use.
+type FileStore struct {
+	lock     sync.Mutex
+	file     *os.File
+	w        *bufio.Writer
+	nodeSize int
+	size     uint64 // number of nodes, including the pending ones
+	flushed  uint64 // number of nodes written to the file
+}
+
+// NewFileStore creates (or truncates) the file at path and returns an empty store for
+// nodes of nodeSize bytes. The caller must call Close once done.
+func NewFileStore(path string, nodeSize int) (*FileStore, error) {
+	if nodeSize <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrNodeSize, nodeSize)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return newFileStore(file, nodeSize, 0), nil
+}
+
+// OpenFileStore opens the store written at path by a FileStore for nodes of nodeSize
+// bytes, to append more nodes or build proofs. The caller must call Close once done.
+func OpenFileStore(path string, nodeSize int) (*FileStore, error) {
+	if nodeSize <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrNodeSize, nodeSize)
+	}
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if info.Size()%int64(nodeSize) != 0 {
+		file.Close()
+		return nil, fmt.Errorf("%w: file of %d bytes, nodes of %d bytes", ErrInvalidMMR, info.Size(), nodeSize)
+	}
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return newFileStore(file, nodeSize, uint64(info.Size())/uint64(nodeSize)), nil
+}
+
+func newFileStore(file *os.File, nodeSize int, size uint64) *FileStore {
+	return &FileStore{
+		file:     file,
+		w:        bufio.NewWriterSize(file, fileStoreBufferSize),
+		nodeSize: nodeSize,
+		size:     size,
+		flushed:  size,
+	}
+}
+
+// Append implements Store.
+func (s *FileStore) Append(node []byte) error {
+	if len(node) != s.nodeSize {
+		return fmt.Errorf("%w: %d bytes, expected %d", ErrNodeSize, len(node), s.nodeSize)
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, err := s.w.Write(node); err != nil {
+		return err
+	}
+	s.size++
+	return nil
+}
+
+// Get implements Store. Getting a node which is still buffered flushes the buffer.
+func (s *FileStore) Get(pos uint64) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if pos >= s.size {
+		return nil, ErrNodeNotFound
+	}
+	if pos >= s.flushed {
+		if err := s.flush(); err != nil {
+			return nil, err
+		}
+	}
+	res := make([]byte, s.nodeSize)
+	if _, err := s.file.ReadAt(res, int64(pos)*int64(s.nodeSize)); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Size implements Store.
+func (s *FileStore) Size() uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.size
+}
+
+// Sync writes the pending nodes to the file and commits it to stable storage.
+func (s *FileStore) Sync() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if err := s.flush(); err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+// Close writes the pending nodes and closes the file.
+func (s *FileStore) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	err := s.flush()
+	if errClose := s.file.Close(); err == nil {
+		err = errClose
+	}
+	return err
+}
+
+func (s *FileStore) flush() error {
+	if err := s.w.Flush(); err != nil {
+		return err
+	}
+	s.flushed = s.size
+	return nil
+}
+
+// MMapStore is a read-only Store backed by a memory-mapped file written by a
+// FileStore. The operating system pages the nodes in and out, so that proofs can be
+// built for trees larger than the memory.
+//
+// It is safe for concurrent use.
+type MMapStore struct {
+	file     *mmap.File
+	data     []byte
+	nodeSize int
+}
+
+// OpenMMapStore memory-maps the store written at path by a FileStore for nodes of
+// nodeSize bytes. The caller must call Close once done.
+func OpenMMapStore(path string, nodeSize int) (*MMapStore, error) {
+	if nodeSize <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrNodeSize, nodeSize)
+	}
+	file, err := mmap.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if file.Len()%nodeSize != 0 {
+		file.Close()
+		return nil, fmt.Errorf("%w: file of %d bytes, nodes of %d bytes", ErrInvalidMMR, file.Len(), nodeSize)
+	}
+	return &MMapStore{file: file, data: file.Bytes(), nodeSize: nodeSize}, nil
+}
+
+// Append implements Store. It always fails, the store being read-only.
+func (s *MMapStore) Append(node []byte) error {
+	return ErrReadOnlyStore
+}
+
+// Get implements Store. The returned node must not be modified, nor used after Close.
+func (s *MMapStore) Get(pos uint64) ([]byte, error) {
+	if pos >= s.Size() {
+		return nil, ErrNodeNotFound
+	}
+	start := pos * uint64(s.nodeSize)
+	return s.data[start : start+uint64(s.nodeSize) : start+uint64(s.nodeSize)], nil
+}
+
+// Size implements Store.
+func (s *MMapStore) Size() uint64 {
+	return uint64(len(s.data) / s.nodeSize)
+}
+
+// Close unmaps the file.
+func (s *MMapStore) Close() error {
+	s.data = nil
+	return s.file.Close()
+}
+
+// KeyValue is a minimal key-value database, such as an LSM tree (pebble, badger,
+// leveldb, …) wrapped by the caller.
+type KeyValue interface {
+	// Get returns the value of key, or an error if the key is not found.
+	Get(key []byte) ([]byte, error)
+
+	// Put sets the value of key. It must copy key and value if it retains them.
+	Put(key, value []byte) error
+}
+
+// kvStoreSizeKey is the key of the number of nodes of a KVStore. Node keys are the
+// 8-byte big-endian positions, so they never collide with it.
+var kvStoreSizeKey = []byte("merkletree/size")
+
+// KVStore is a Store keeping the nodes in a key-value database, the node at position
+// pos under the key pos as 8 big-endian bytes.
+//
+// It is safe for concurrent use if the database is.
+type KVStore struct {
+	lock sync.Mutex
+	db   KeyValue
+	size uint64
+}
+
+// NewKVStore returns a store in db, with the nodes already stored in db by a KVStore
+// if there are some: an empty store can be used by NewMMR, a non empty one by OpenMMR.
+// The keys not found are reported by db.Get with an error; for the size of the store,
+// any error means an empty store.
+func NewKVStore(db KeyValue) (*KVStore, error) {
+	s := &KVStore{db: db}
+	if b, err := db.Get(kvStoreSizeKey); err == nil {
+		if len(b) != 8 {
+			return nil, fmt.Errorf("%w: invalid size", ErrInvalidMMR)
+		}
+		s.size = binary.BigEndian.Uint64(b)
+	}
+	return s, nil
+}
+
+// Append implements Store. The node is stored before the size of the store, so that
+// an interrupted Append leaves the store in its previous state.
+func (s *KVStore) Append(node []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var key [8]byte
+	binary.BigEndian.PutUint64(key[:], s.size)
+	if err := s.db.Put(key[:], node); err != nil {
+		return err
+	}
+	var size [8]byte
+	binary.BigEndian.PutUint64(size[:], s.size+1)
+	if err := s.db.Put(kvStoreSizeKey, size[:]); err != nil {
+		return err
+	}
+	s.size++
+	return nil
+}
+
+// Get implements Store.
+func (s *KVStore) Get(pos uint64) ([]byte, error) {
+	if pos >= s.Size() {
+		return nil, ErrNodeNotFound
+	}
+	var key [8]byte
+	binary.BigEndian.PutUint64(key[:], pos)
+	node, err := s.db.Get(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNodeNotFound, err)
+	}
+	return node, nil
+}
+
+// Size implements Store.
+func (s *KVStore) Size() uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.size
+}
@@ -0,0 +1,188 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merkletree
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// mapKeyValue is an in-memory KeyValue.
+type mapKeyValue struct {
+	lock sync.Mutex
+	m    map[string][]byte
+}
+
+func (kv *mapKeyValue) Get(key []byte) ([]byte, error) {
+	kv.lock.Lock()
+	defer kv.lock.Unlock()
+	v, ok := kv.m[string(key)]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return v, nil
+}
+
+func (kv *mapKeyValue) Put(key, value []byte) error {
+	kv.lock.Lock()
+	defer kv.lock.Unlock()
+	kv.m[string(key)] = append([]byte{}, value...)
+	return nil
+}
+
+// buildMMR appends nbLeaves leaves to a new MMR in store, and returns it with the leaves.
+func buildMMR(t *testing.T, store Store, nbLeaves int) (*MMR, [][]byte) {
+	mmr, err := NewMMR(sha256.New(), store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaves := make([][]byte, nbLeaves)
+	for i := range leaves {
+		leaves[i] = []byte(fmt.Sprintf("leaf %d", i))
+		if _, err := mmr.Append(leaves[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return mmr, leaves
+}
+
+// checkMMR checks that mmr has the expected root, and that all its leaves can be proven.
+func checkMMR(t *testing.T, mmr *MMR, leaves [][]byte, root []byte) {
+	if !bytes.Equal(mmr.Root(), root) {
+		t.Fatal("unexpected root")
+	}
+	for i := range leaves {
+		root, proofSet, numLeaves, err := mmr.Prove(uint64(i), leaves[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !VerifyProof(sha256.New(), root, proofSet, uint64(i), numLeaves) {
+			t.Fatalf("invalid proof for leaf %d", i)
+		}
+	}
+}
+
+func TestStores(t *testing.T) {
+	const nbLeaves = 100
+	expected, leaves := buildMMR(t, NewMemoryStore(), nbLeaves)
+	dir := t.TempDir()
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(dir, "file")
+		store, err := NewFileStore(path, sha256.Size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mmr, _ := buildMMR(t, store, nbLeaves/2)
+		checkMMR(t, mmr, leaves[:nbLeaves/2], mmr.Root())
+		if err := store.Append([]byte("too short")); !errors.Is(err, ErrNodeSize) {
+			t.Fatalf("expected ErrNodeSize, got %v", err)
+		}
+		if err := store.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		// reopen and append the other half
+		store, err = OpenFileStore(path, sha256.Size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer store.Close()
+		mmr, err = OpenMMR(sha256.New(), store)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, leaf := range leaves[nbLeaves/2:] {
+			if _, err := mmr.Append(leaf); err != nil {
+				t.Fatal(err)
+			}
+		}
+		checkMMR(t, mmr, leaves, expected.Root())
+		if err := store.Sync(); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := OpenFileStore(path, sha256.Size+1); !errors.Is(err, ErrInvalidMMR) {
+			t.Fatalf("expected ErrInvalidMMR, got %v", err)
+		}
+	})
+
+	t.Run("mmap", func(t *testing.T) {
+		path := filepath.Join(dir, "mmap")
+		store, err := NewFileStore(path, sha256.Size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buildMMR(t, store, nbLeaves)
+		if err := store.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		mstore, err := OpenMMapStore(path, sha256.Size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mstore.Close()
+		mmr, err := OpenMMR(sha256.New(), mstore)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkMMR(t, mmr, leaves, expected.Root())
+		if _, err := mmr.Append(leaves[0]); !errors.Is(err, ErrReadOnlyStore) {
+			t.Fatalf("expected ErrReadOnlyStore, got %v", err)
+		}
+	})
+
+	t.Run("key-value", func(t *testing.T) {
+		db := &mapKeyValue{m: make(map[string][]byte)}
+		store, err := NewKVStore(db)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buildMMR(t, store, nbLeaves)
+
+		store, err = NewKVStore(db)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mmr, err := OpenMMR(sha256.New(), store)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkMMR(t, mmr, leaves, expected.Root())
+		if _, err := store.Get(store.Size()); !errors.Is(err, ErrNodeNotFound) {
+			t.Fatalf("expected ErrNodeNotFound, got %v", err)
+		}
+	})
+}
+
+func BenchmarkMMRAppendFileStore(b *testing.B) {
+	store, err := NewFileStore(filepath.Join(b.TempDir(), "store"), sha256.Size)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer store.Close()
+	mmr, _ := NewMMR(sha256.New(), store)
+	data := make([]byte, 32)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mmr.Append(data)
+	}
+}
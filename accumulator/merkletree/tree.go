@@ -26,6 +26,8 @@ import (
 	"errors"
 	"fmt"
 	"hash"
+
+	"github.com/consensys/gnark-crypto/internal/parallel"
 )
 
 // A Tree takes data as leaves and returns the Merkle root. Each call to 'Push'
@@ -204,24 +206,52 @@ func (t *Tree) Prove() (merkleRoot []byte, proofSet [][]byte, proofIndex uint64,
 // log(n) elements necessary to build a proof that a piece of data is in the
 // Merkle tree.
 func (t *Tree) Push(data []byte) {
+	// Hash the data to create a subtree of height 0. The sum of the new node
+	// is going to be the data for cached trees, and is going to be the result
+	// of calling leafSum() on the data for standard trees. Doing a check here
+	// prevents needing to duplicate the entire 'Push' function for the trees.
+	if t.cachedTree {
+		t.pushLeafSum(data, data)
+	} else {
+		t.pushLeafSum(data, leafSum(t.hash, data))
+	}
+}
+
+// PushBatch pushes the leaves one after the other, as Push does, but hashes them
+// in parallel beforehand. Each goroutine hashes with its own instance of newHash,
+// which must build the hash function the Tree was created with.
+func (t *Tree) PushBatch(newHash func() hash.Hash, leaves [][]byte) {
+	if t.cachedTree {
+		for _, data := range leaves {
+			t.pushLeafSum(data, data)
+		}
+		return
+	}
+
+	sums := make([][]byte, len(leaves))
+	parallel.Execute(len(leaves), func(start, end int) {
+		h := newHash()
+		for i := start; i < end; i++ {
+			sums[i] = leafSum(h, leaves[i])
+		}
+	})
+	for i, data := range leaves {
+		t.pushLeafSum(data, sums[i])
+	}
+}
+
+// pushLeafSum inserts a subtree of height 0 of sum leaf, the leaf sum of data.
+func (t *Tree) pushLeafSum(data, leaf []byte) {
 	// The first element of a proof is the data at the proof index. If this
 	// data is being inserted at the proof index, it is added to the proof set.
 	if t.currentIndex == t.proofIndex {
 		t.proofSet = append(t.proofSet, data)
 	}
 
-	// Hash the data to create a subtree of height 0. The sum of the new node
-	// is going to be the data for cached trees, and is going to be the result
-	// of calling leafSum() on the data for standard trees. Doing a check here
-	// prevents needing to duplicate the entire 'Push' function for the trees.
 	t.head = &subTree{
 		next:   t.head,
 		height: 0,
-	}
-	if t.cachedTree {
-		t.head.sum = data
-	} else {
-		t.head.sum = leafSum(t.hash, data)
+		sum:    leaf,
 	}
 
 	// Join subTrees if possible.
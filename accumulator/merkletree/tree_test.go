@@ -0,0 +1,90 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merkletree
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestPushBatch(t *testing.T) {
+	for _, nbLeaves := range []int{1, 2, 7, 64, 100} {
+		leaves := make([][]byte, nbLeaves)
+		for i := range leaves {
+			leaves[i] = []byte(fmt.Sprintf("leaf %d", i))
+		}
+
+		for _, proofIndex := range []uint64{0, uint64(nbLeaves / 2), uint64(nbLeaves - 1)} {
+			expected := New(sha256.New())
+			if err := expected.SetIndex(proofIndex); err != nil {
+				t.Fatal(err)
+			}
+			for _, leaf := range leaves {
+				expected.Push(leaf)
+			}
+			expectedRoot, expectedProofSet, _, _ := expected.Prove()
+
+			// push a first leaf alone, then the other ones in batches
+			tree := New(sha256.New())
+			if err := tree.SetIndex(proofIndex); err != nil {
+				t.Fatal(err)
+			}
+			tree.Push(leaves[0])
+			tree.PushBatch(sha256.New, leaves[1:nbLeaves/2+1])
+			tree.PushBatch(sha256.New, leaves[nbLeaves/2+1:])
+			root, proofSet, index, numLeaves := tree.Prove()
+
+			if !bytes.Equal(root, expectedRoot) {
+				t.Fatalf("%d leaves: unexpected root", nbLeaves)
+			}
+			if !reflect.DeepEqual(proofSet, expectedProofSet) {
+				t.Fatalf("%d leaves: unexpected proof set for leaf %d", nbLeaves, proofIndex)
+			}
+			if !VerifyProof(sha256.New(), root, proofSet, index, numLeaves) {
+				t.Fatalf("%d leaves: invalid proof for leaf %d", nbLeaves, proofIndex)
+			}
+		}
+	}
+}
+
+func BenchmarkTreePush(b *testing.B) {
+	const nbLeaves = 1 << 12
+	leaves := make([][]byte, nbLeaves)
+	for i := range leaves {
+		leaves[i] = make([]byte, 32)
+		leaves[i][0] = byte(i)
+	}
+
+	b.Run("serial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			tree := New(sha256.New())
+			for _, leaf := range leaves {
+				tree.Push(leaf)
+			}
+			tree.Root()
+		}
+	})
+
+	b.Run("batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			tree := New(sha256.New())
+			tree.PushBatch(sha256.New, leaves)
+			tree.Root()
+		}
+	})
+}
@@ -0,0 +1,166 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unknownorder
+
+import (
+	"errors"
+	"math/big"
+)
+
+// domainElement separates the primes representing accumulated elements.
+const domainElement = "unknownorder/element"
+
+var (
+	ErrNotMember   = errors.New("the witness does not prove the membership of the element")
+	ErrSameElement = errors.New("the witness of a deleted element cannot be updated")
+	ErrNoElement   = errors.New("no element to add")
+)
+
+// Accumulator is the accumulator of a set of elements, of constant size: it is
+// g^∏ₓ H(x), with H = HashToPrime. Elements are byte slices, and must be distinct.
+//
+// The membership witness of x is the accumulator of the set without x. The accumulator
+// does not keep the set: a witness is returned when an element is added, and is needed
+// to delete it.
+type Accumulator[E any] struct {
+	group Group[E]
+	value E
+}
+
+// New returns the accumulator of the empty set.
+func New[E any](group Group[E]) *Accumulator[E] {
+	return &Accumulator[E]{group: group, value: group.Generator()}
+}
+
+// Value returns the current value of the accumulator.
+func (acc *Accumulator[E]) Value() E {
+	return acc.value
+}
+
+// Add adds elems to the accumulated set. It returns their membership witnesses, and a
+// proof that the new value is the previous one raised to the product of their primes,
+// to be checked with VerifyAdd.
+func (acc *Accumulator[E]) Add(elems ...[]byte) (witnesses []E, proof PoE[E], err error) {
+	if len(elems) == 0 {
+		return nil, proof, ErrNoElement
+	}
+	primes := elementPrimes(elems)
+	x := product(primes)
+
+	before := acc.value
+	acc.value = acc.group.Exp(before, x)
+	return rootFactor(acc.group, before, primes), ProvePoE(acc.group, before, x, acc.value), nil
+}
+
+// Delete removes elem from the accumulated set. witness is its current membership
+// witness, which becomes the new value of the accumulator.
+func (acc *Accumulator[E]) Delete(elem []byte, witness E) error {
+	if !VerifyMembership(acc.group, acc.value, elem, witness) {
+		return ErrNotMember
+	}
+	acc.value = witness
+	return nil
+}
+
+// VerifyAdd returns true if proof proves that after is the accumulator obtained by
+// adding elems to before.
+func VerifyAdd[E any](group Group[E], before, after E, elems [][]byte, proof PoE[E]) bool {
+	if len(elems) == 0 {
+		return false
+	}
+	return VerifyPoE(group, before, product(elementPrimes(elems)), after, proof)
+}
+
+// VerifyMembership returns true if witness proves that elem is in the set accumulated
+// in value, that is if witness^H(elem) = value.
+func VerifyMembership[E any](group Group[E], value E, elem []byte, witness E) bool {
+	return group.Equal(group.Exp(witness, elementPrime(elem)), value)
+}
+
+// UpdateWitnessOnAdd returns the witness of an element after added have been added to
+// the accumulator.
+func UpdateWitnessOnAdd[E any](group Group[E], witness E, added ...[]byte) E {
+	return group.Exp(witness, product(elementPrimes(added)))
+}
+
+// UpdateWitnessOnDelete returns the witness of elem after deleted has been removed from
+// the accumulator, whose value is now after.
+//
+// With a·H(elem) + b·H(deleted) = 1, the new witness is afterᵃ·witnessᵇ (Shamir's trick).
+// One of a and b is negative: ErrNotInvertible is returned if the corresponding value
+// is not invertible.
+func UpdateWitnessOnDelete[E any](group Group[E], witness E, elem, deleted []byte, after E) (E, error) {
+	x, y := elementPrime(elem), elementPrime(deleted)
+	if x.Cmp(y) == 0 {
+		return witness, ErrSameElement
+	}
+	a, b := new(big.Int), new(big.Int)
+	new(big.Int).GCD(a, b, x, y)
+	wa, err := expSigned(group, after, a)
+	if err != nil {
+		return witness, err
+	}
+	wb, err := expSigned(group, witness, b)
+	if err != nil {
+		return witness, err
+	}
+	return group.Mul(wa, wb), nil
+}
+
+// expSigned returns aᵉ, inverting a if e is negative.
+func expSigned[E any](group Group[E], a E, e *big.Int) (E, error) {
+	if e.Sign() >= 0 {
+		return group.Exp(a, e), nil
+	}
+	inv, err := group.Inverse(a)
+	if err != nil {
+		return a, err
+	}
+	return group.Exp(inv, new(big.Int).Neg(e)), nil
+}
+
+// elementPrime returns the prime representing elem.
+func elementPrime(elem []byte) *big.Int {
+	return HashToPrime(domainElement, elem)
+}
+
+func elementPrimes(elems [][]byte) []*big.Int {
+	res := make([]*big.Int, len(elems))
+	for i := range elems {
+		res[i] = elementPrime(elems[i])
+	}
+	return res
+}
+
+// product returns ∏ᵢ xᵢ, computed as a product tree.
+func product(x []*big.Int) *big.Int {
+	switch len(x) {
+	case 0:
+		return big.NewInt(1)
+	case 1:
+		return new(big.Int).Set(x[0])
+	}
+	return new(big.Int).Mul(product(x[:len(x)/2]), product(x[len(x)/2:]))
+}
+
+// rootFactor returns the g^∏_{j≠i} xⱼ, in O(n log n) exponentiations.
+func rootFactor[E any](group Group[E], g E, x []*big.Int) []E {
+	if len(x) == 1 {
+		return []E{g}
+	}
+	left, right := x[:len(x)/2], x[len(x)/2:]
+	res := rootFactor(group, group.Exp(g, product(right)), left)
+	return append(res, rootFactor(group, group.Exp(g, product(left)), right)...)
+}
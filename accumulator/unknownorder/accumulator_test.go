@@ -0,0 +1,167 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unknownorder
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func testGroup(t testing.TB) *RSAGroup {
+	group, err := GenerateRSAGroup(nil, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return group
+}
+
+func testElements(n int) [][]byte {
+	res := make([][]byte, n)
+	for i := range res {
+		res[i] = []byte(fmt.Sprintf("element %d", i))
+	}
+	return res
+}
+
+func TestAccumulator(t *testing.T) {
+	group := testGroup(t)
+	acc := New[*big.Int](group)
+	elems := testElements(6)
+
+	// add in two batches
+	before := acc.Value()
+	witnesses, proof, err := acc.Add(elems[:4]...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyAdd[*big.Int](group, before, acc.Value(), elems[:4], proof) {
+		t.Fatal("invalid add proof")
+	}
+	if VerifyAdd[*big.Int](group, before, acc.Value(), elems[:3], proof) {
+		t.Fatal("add proof verified for the wrong elements")
+	}
+
+	before = acc.Value()
+	newWitnesses, proof, err := acc.Add(elems[4:]...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyAdd[*big.Int](group, before, acc.Value(), elems[4:], proof) {
+		t.Fatal("invalid add proof")
+	}
+	for i := range witnesses {
+		witnesses[i] = UpdateWitnessOnAdd[*big.Int](group, witnesses[i], elems[4:]...)
+	}
+	witnesses = append(witnesses, newWitnesses...)
+	for i := range elems {
+		if !VerifyMembership[*big.Int](group, acc.Value(), elems[i], witnesses[i]) {
+			t.Fatalf("invalid witness for element %d", i)
+		}
+	}
+	if VerifyMembership[*big.Int](group, acc.Value(), []byte("not a member"), witnesses[0]) {
+		t.Fatal("non member verified")
+	}
+
+	// delete an element, and update the other witnesses
+	if err := acc.Delete(elems[2], witnesses[0]); err != ErrNotMember {
+		t.Fatal("expected ErrNotMember")
+	}
+	if err := acc.Delete(elems[2], witnesses[2]); err != nil {
+		t.Fatal(err)
+	}
+	if VerifyMembership[*big.Int](group, acc.Value(), elems[2], witnesses[2]) {
+		t.Fatal("deleted element still verified")
+	}
+	for i := range elems {
+		if i == 2 {
+			if _, err := UpdateWitnessOnDelete[*big.Int](group, witnesses[i], elems[i], elems[2], acc.Value()); err != ErrSameElement {
+				t.Fatal("expected ErrSameElement")
+			}
+			continue
+		}
+		w, err := UpdateWitnessOnDelete[*big.Int](group, witnesses[i], elems[i], elems[2], acc.Value())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !VerifyMembership[*big.Int](group, acc.Value(), elems[i], w) {
+			t.Fatalf("invalid updated witness for element %d", i)
+		}
+	}
+}
+
+func TestRSAGroupNotInvertible(t *testing.T) {
+	group := testGroup(t)
+	n := group.Modulus()
+	if group.IsValid(n) || group.IsValid(big.NewInt(0)) || !group.IsValid(group.Generator()) {
+		t.Fatal("IsValid does not check the range")
+	}
+
+	// N is not invertible: updating a witness with it must fail, not panic
+	if _, err := group.Inverse(n); err != ErrNotInvertible {
+		t.Fatal("expected ErrNotInvertible")
+	}
+	elems := testElements(2)
+	if _, err := UpdateWitnessOnDelete[*big.Int](group, n, elems[0], elems[1], n); err != ErrNotInvertible {
+		t.Fatal("expected ErrNotInvertible")
+	}
+}
+
+func TestPoKE(t *testing.T) {
+	group := testGroup(t)
+	u := group.Exp(group.Generator(), big.NewInt(12345))
+	x, _ := new(big.Int).SetString("123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789", 16)
+	w := group.Exp(u, x)
+
+	proof := ProvePoKE[*big.Int](group, u, x, w)
+	if !VerifyPoKE[*big.Int](group, u, w, proof) {
+		t.Fatal("invalid PoKE")
+	}
+	if VerifyPoKE[*big.Int](group, u, group.Mul(w, u), proof) {
+		t.Fatal("PoKE verified for the wrong statement")
+	}
+	proof.R = new(big.Int).Add(proof.R, big.NewInt(1))
+	if VerifyPoKE[*big.Int](group, u, w, proof) {
+		t.Fatal("tampered PoKE verified")
+	}
+
+	poe := ProvePoE[*big.Int](group, u, x, w)
+	if !VerifyPoE[*big.Int](group, u, x, w, poe) {
+		t.Fatal("invalid PoE")
+	}
+	if VerifyPoE[*big.Int](group, u, new(big.Int).Add(x, big.NewInt(1)), w, poe) {
+		t.Fatal("PoE verified for the wrong exponent")
+	}
+}
+
+func TestHashToPrime(t *testing.T) {
+	p := HashToPrime("test", []byte("a"), []byte("b"))
+	if p.BitLen() != PrimeBits || !p.ProbablyPrime(20) {
+		t.Fatal("HashToPrime did not return a prime of PrimeBits bits")
+	}
+	if HashToPrime("test", []byte("ab")).Cmp(p) == 0 {
+		t.Fatal("HashToPrime does not separate its inputs")
+	}
+}
+
+func BenchmarkAccumulatorAdd(b *testing.B) {
+	group := testGroup(b)
+	acc := New[*big.Int](group)
+	elems := testElements(16)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		acc.Add(elems...)
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unknownorder
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+)
+
+var ErrInvalidDiscriminant = errors.New("the discriminant must be negative and congruent to 1 mod 8")
+
+// Form is a binary quadratic form ax² + bxy + cy².
+type Form struct {
+	A, B, C *big.Int
+}
+
+// ClassGroup is the class group of an imaginary quadratic order of discriminant Δ < 0,
+// whose elements are the reduced forms of discriminant Δ = b² - 4ac. Its order is
+// unknown for a large enough Δ, and contrary to an RSA group it needs no trusted setup:
+// Δ can be derived from a public seed.
+type ClassGroup struct {
+	d *big.Int // Δ
+	g Form
+}
+
+// NewClassGroup returns the class group of discriminant -p, where p is the first prime
+// of nbBits bits, congruent to 7 mod 8, derived from seed.
+func NewClassGroup(seed []byte, nbBits int) (*ClassGroup, error) {
+	p := new(big.Int)
+	buf := make([]byte, (nbBits+7)/8)
+	block := make([]byte, 0, len(seed)+16)
+	for i := uint64(0); ; i++ {
+		for j := uint64(0); j*sha256.Size < uint64(len(buf)); j++ {
+			block = append(block[:0], seed...)
+			block = binary.BigEndian.AppendUint64(block, i)
+			block = binary.BigEndian.AppendUint64(block, j)
+			h := sha256.Sum256(block)
+			copy(buf[j*sha256.Size:], h[:])
+		}
+		p.SetBytes(buf)
+		p.Rsh(p, uint(len(buf)*8-nbBits))
+		p.SetBit(p, nbBits-1, 1)
+		p.Or(p, big.NewInt(7))
+		if p.ProbablyPrime(20) {
+			return NewClassGroupFromDiscriminant(p.Neg(p))
+		}
+	}
+}
+
+// NewClassGroupFromDiscriminant returns the class group of discriminant d. d must be
+// negative and congruent to 1 mod 8, and -d should be a prime for the order of the
+// group to be hard to compute.
+func NewClassGroupFromDiscriminant(d *big.Int) (*ClassGroup, error) {
+	if d.Sign() >= 0 || new(big.Int).And(d, big.NewInt(7)).Int64() != 1 {
+		return nil, ErrInvalidDiscriminant
+	}
+	res := &ClassGroup{d: new(big.Int).Set(d)}
+
+	// the generator is the form (2, 1, (1-Δ)/8)
+	c := new(big.Int).Sub(big.NewInt(1), d)
+	res.g = res.reduce(Form{A: big.NewInt(2), B: big.NewInt(1), C: c.Rsh(c, 3)})
+	return res, nil
+}
+
+// Discriminant returns Δ.
+func (g *ClassGroup) Discriminant() *big.Int {
+	return new(big.Int).Set(g.d)
+}
+
+// NewForm returns the reduced form of discriminant Δ with a and b, or false if there is
+// none.
+func (g *ClassGroup) NewForm(a, b *big.Int) (Form, bool) {
+	if a.Sign() <= 0 {
+		return Form{}, false
+	}
+	// c = (b²-Δ)/4a
+	c := new(big.Int).Mul(b, b)
+	c.Sub(c, g.d)
+	a4 := new(big.Int).Lsh(a, 2)
+	c, m := c.DivMod(c, a4, new(big.Int))
+	if m.Sign() != 0 {
+		return Form{}, false
+	}
+	return g.reduce(Form{A: new(big.Int).Set(a), B: new(big.Int).Set(b), C: c}), true
+}
+
+// IsValid returns true if f is a positive definite form of discriminant Δ.
+func (g *ClassGroup) IsValid(f Form) bool {
+	if f.A == nil || f.B == nil || f.C == nil || f.A.Sign() <= 0 {
+		return false
+	}
+	d := new(big.Int).Mul(f.A, f.C)
+	d.Lsh(d, 2)
+	d.Sub(new(big.Int).Mul(f.B, f.B), d)
+	return d.Cmp(g.d) == 0
+}
+
+// HashToForm maps data to a form: with ℓ the first prime given by HashToPrime such that
+// Δ is a square mod ℓ, it is the reduced form (ℓ, b, c) with b an odd square root of Δ
+// mod ℓ.
+func (g *ClassGroup) HashToForm(domain string, data ...[]byte) Form {
+	b := new(big.Int)
+	for i := uint64(0); ; i++ {
+		l := HashToPrime(domain, append(data, binary.BigEndian.AppendUint64(nil, i))...)
+		if big.Jacobi(g.d, l) != 1 {
+			continue
+		}
+		b.ModSqrt(new(big.Int).Mod(g.d, l), l)
+		if b.Bit(0) == 0 {
+			b.Sub(l, b)
+		}
+		// b is odd and Δ ≡ 1 mod 8, so that 4ℓ divides b²-Δ
+		if f, ok := g.NewForm(l, b); ok {
+			return f
+		}
+	}
+}
+
+// reduce returns the reduced form equivalent to f, such that |b| ≤ a ≤ c, with b ≥ 0 if
+// |b| = a or a = c (Cohen, Algorithm 5.4.2). f is modified.
+func (g *ClassGroup) reduce(f Form) Form {
+	q, r, a2 := new(big.Int), new(big.Int), new(big.Int)
+	for {
+		// normalize: -a < b ≤ a
+		if f.B.CmpAbs(f.A) > 0 || f.B.Cmp(new(big.Int).Neg(f.A)) == 0 {
+			a2.Lsh(f.A, 1)
+			q.DivMod(f.B, a2, r) // b = 2aq + r, 0 ≤ r < 2a
+			if r.Cmp(f.A) > 0 {
+				r.Sub(r, a2)
+				q.Add(q, big.NewInt(1))
+			}
+			// c = c - q(b+r)/2
+			t := new(big.Int).Add(f.B, r)
+			t.Mul(t, q).Rsh(t, 1)
+			f.C.Sub(f.C, t)
+			f.B.Set(r)
+		}
+		if f.A.Cmp(f.C) > 0 {
+			f.A, f.C = f.C, f.A
+			f.B.Neg(f.B)
+			continue
+		}
+		if f.A.Cmp(f.C) == 0 && f.B.Sign() < 0 {
+			f.B.Neg(f.B)
+		}
+		return f
+	}
+}
+
+// Generator implements Group.
+func (g *ClassGroup) Generator() Form {
+	return g.g.clone()
+}
+
+// Identity implements Group.
+func (g *ClassGroup) Identity() Form {
+	c := new(big.Int).Sub(big.NewInt(1), g.d)
+	return Form{A: big.NewInt(1), B: big.NewInt(1), C: c.Rsh(c, 2)}
+}
+
+// Mul implements Group. The forms are composed with Cohen, Algorithm 5.4.7.
+func (g *ClassGroup) Mul(f1, f2 Form) Form {
+	if f1.A.Cmp(f2.A) > 0 {
+		f1, f2 = f2, f1
+	}
+	// s = (b₁+b₂)/2, n = b₂-s
+	s := new(big.Int).Add(f1.B, f2.B)
+	s.Rsh(s, 1)
+	n := new(big.Int).Sub(f2.B, s)
+
+	// u·a₂ + v·a₁ = d = gcd(a₂, a₁)
+	y1, d := new(big.Int), new(big.Int)
+	if new(big.Int).Mod(f2.A, f1.A).Sign() == 0 {
+		d.Set(f1.A)
+	} else {
+		d.GCD(y1, nil, f2.A, f1.A)
+	}
+
+	// x₂·s + y₂·d = d₁ = gcd(s, d)
+	x2, y2, d1 := new(big.Int), big.NewInt(-1), new(big.Int)
+	if new(big.Int).Mod(s, d).Sign() == 0 {
+		d1.Set(d)
+	} else {
+		d1.GCD(x2, y2, s, d)
+		y2.Neg(y2)
+	}
+
+	// v₁ = a₁/d₁, v₂ = a₂/d₁, r = y₁y₂n - x₂c₂ mod v₁
+	v1 := new(big.Int).Quo(f1.A, d1)
+	v2 := new(big.Int).Quo(f2.A, d1)
+	r := new(big.Int).Mul(y1, y2)
+	r.Mul(r, n)
+	r.Sub(r, new(big.Int).Mul(x2, f2.C))
+	r.Mod(r, v1)
+
+	// a₃ = v₁v₂, b₃ = b₂ + 2v₂r, c₃ = (b₃²-Δ)/4a₃
+	a3 := new(big.Int).Mul(v1, v2)
+	b3 := new(big.Int).Mul(v2, r)
+	b3.Lsh(b3, 1).Add(b3, f2.B)
+	c3 := new(big.Int).Mul(b3, b3)
+	c3.Sub(c3, g.d)
+	c3.Quo(c3, new(big.Int).Lsh(a3, 2))
+
+	return g.reduce(Form{A: a3, B: b3, C: c3})
+}
+
+// Inverse implements Group. Forms are always invertible.
+func (g *ClassGroup) Inverse(f Form) (Form, error) {
+	return g.inverse(f), nil
+}
+
+func (g *ClassGroup) inverse(f Form) Form {
+	res := f.clone()
+	res.B.Neg(res.B)
+	return g.reduce(res)
+}
+
+// Exp implements Group. As forms are always invertible, e can also be negative.
+func (g *ClassGroup) Exp(f Form, e *big.Int) Form {
+	if e.Sign() < 0 {
+		f = g.inverse(f)
+		e = new(big.Int).Neg(e)
+	}
+	res := g.Identity()
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		res = g.Mul(res, res)
+		if e.Bit(i) == 1 {
+			res = g.Mul(res, f)
+		}
+	}
+	return res
+}
+
+// Equal implements Group.
+func (g *ClassGroup) Equal(f1, f2 Form) bool {
+	f1, f2 = g.reduce(f1.clone()), g.reduce(f2.clone())
+	return f1.A.Cmp(f2.A) == 0 && f1.B.Cmp(f2.B) == 0
+}
+
+// Marshal implements Group. A reduced form is encoded as a ‖ sign(b) ‖ |b|, where a and
+// |b| ≤ a ≤ √(|Δ|/3) are big-endian and of the same size.
+func (g *ClassGroup) Marshal(f Form) []byte {
+	f = g.reduce(f.clone())
+	size := (g.d.BitLen()/2 + 8) / 8
+	res := make([]byte, 2*size+1)
+	f.A.FillBytes(res[:size])
+	if f.B.Sign() < 0 {
+		res[size] = 1
+	}
+	new(big.Int).Abs(f.B).FillBytes(res[size+1:])
+	return res
+}
+
+func (f Form) clone() Form {
+	return Form{A: new(big.Int).Set(f.A), B: new(big.Int).Set(f.B), C: new(big.Int).Set(f.C)}
+}
@@ -0,0 +1,118 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unknownorder
+
+import (
+	"math/big"
+	"testing"
+)
+
+func testClassGroup(t testing.TB) *ClassGroup {
+	group, err := NewClassGroup([]byte("test"), 256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return group
+}
+
+func TestClassGroup(t *testing.T) {
+	group := testClassGroup(t)
+	d := group.Discriminant()
+	if d.Sign() >= 0 || new(big.Int).Mod(d, big.NewInt(8)).Int64() != 1 || !new(big.Int).Neg(d).ProbablyPrime(20) {
+		t.Fatal("invalid discriminant")
+	}
+
+	isReduced := func(f Form) bool {
+		disc := new(big.Int).Mul(f.B, f.B)
+		disc.Sub(disc, new(big.Int).Lsh(new(big.Int).Mul(f.A, f.C), 2))
+		return disc.Cmp(d) == 0 && f.B.CmpAbs(f.A) <= 0 && f.A.Cmp(f.C) <= 0 &&
+			!(f.B.Sign() < 0 && (f.B.CmpAbs(f.A) == 0 || f.A.Cmp(f.C) == 0))
+	}
+
+	g := group.Generator()
+	x := group.HashToForm("test", []byte("x"))
+	y := group.HashToForm("test", []byte("y"))
+	one := group.Identity()
+	for _, f := range []Form{g, x, y, one, group.Mul(x, y), group.inverse(x), group.Exp(g, big.NewInt(1000))} {
+		if !isReduced(f) {
+			t.Fatalf("form (%s, %s, %s) is not reduced", f.A, f.B, f.C)
+		}
+	}
+
+	if !group.Equal(group.Mul(x, one), x) || !group.Equal(group.Mul(one, x), x) {
+		t.Fatal("identity is not neutral")
+	}
+	xInv, err := group.Inverse(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !group.Equal(group.Mul(x, xInv), one) {
+		t.Fatal("x·x⁻¹ ≠ 1")
+	}
+	if !group.Equal(group.Mul(x, y), group.Mul(y, x)) {
+		t.Fatal("composition is not commutative")
+	}
+	if !group.Equal(group.Mul(group.Mul(x, y), g), group.Mul(x, group.Mul(y, g))) {
+		t.Fatal("composition is not associative")
+	}
+	if !group.Equal(group.Mul(x, x), group.Exp(x, big.NewInt(2))) {
+		t.Fatal("x·x ≠ x²")
+	}
+	a, b := big.NewInt(123456789), big.NewInt(-987654321)
+	if !group.Equal(group.Exp(group.Exp(x, a), b), group.Exp(x, new(big.Int).Mul(a, b))) {
+		t.Fatal("(xᵃ)ᵇ ≠ xᵃᵇ")
+	}
+	if !group.Equal(group.Mul(group.Exp(x, a), group.Exp(x, b)), group.Exp(x, new(big.Int).Add(a, b))) {
+		t.Fatal("xᵃxᵇ ≠ xᵃ⁺ᵇ")
+	}
+	if group.Equal(x, y) || group.Equal(g, one) {
+		t.Fatal("distinct forms are equal")
+	}
+	if !group.IsValid(x) || group.IsValid(Form{A: x.A, B: new(big.Int).Add(x.B, big.NewInt(2)), C: x.C}) {
+		t.Fatal("IsValid does not check the discriminant")
+	}
+	if len(group.Marshal(x)) != len(group.Marshal(one)) {
+		t.Fatal("encodings should have the same size")
+	}
+}
+
+func TestAccumulatorClassGroup(t *testing.T) {
+	group := testClassGroup(t)
+	acc := New[Form](group)
+	elems := testElements(4)
+	before := acc.Value()
+	witnesses, proof, err := acc.Add(elems...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyAdd[Form](group, before, acc.Value(), elems, proof) {
+		t.Fatal("invalid add proof")
+	}
+	for i := range elems {
+		if !VerifyMembership[Form](group, acc.Value(), elems[i], witnesses[i]) {
+			t.Fatalf("invalid witness for element %d", i)
+		}
+	}
+	if err := acc.Delete(elems[1], witnesses[1]); err != nil {
+		t.Fatal(err)
+	}
+	w, err := UpdateWitnessOnDelete[Form](group, witnesses[0], elems[0], elems[1], acc.Value())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyMembership[Form](group, acc.Value(), elems[0], w) {
+		t.Fatal("invalid updated witness")
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package unknownorder implements a cryptographic accumulator over a group of unknown
+// order (an RSA group or a class group), following Boneh, Bünz and Fisch, "Batching
+// Techniques for Accumulators with Applications to IOPs and Stateless Blockchains"
+// (https://eprint.iacr.org/2018/1188).
+//
+// Elements are mapped to primes with HashToPrime, and the accumulator of a set S is
+// g^∏ₓ₌ₛ H(x). Contrary to the Merkle accumulator, its value and its witnesses have a
+// constant size, and witnesses can be updated without access to the set. Exponentiations
+// are proven non-interactively, with a PoE or a PoKE.
+package unknownorder
+
+import (
+	"errors"
+	"math/big"
+)
+
+var ErrNotInvertible = errors.New("the element is not invertible")
+
+// Group is a group of unknown order, whose elements are of type E.
+type Group[E any] interface {
+	// Generator returns the base of the accumulator.
+	Generator() E
+
+	// Identity returns the neutral element.
+	Identity() E
+
+	// Mul returns a·b.
+	Mul(a, b E) E
+
+	// Inverse returns a⁻¹, or ErrNotInvertible if a is not invertible.
+	Inverse(a E) (E, error)
+
+	// Exp returns aᵉ. e must be non-negative: negative powers are computed with
+	// Inverse.
+	Exp(a E, e *big.Int) E
+
+	// Equal returns true if a and b are equal.
+	Equal(a, b E) bool
+
+	// Marshal returns the canonical encoding of a, used for Fiat-Shamir.
+	Marshal(a E) []byte
+}
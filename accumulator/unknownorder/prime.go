@@ -0,0 +1,62 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unknownorder
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+)
+
+// PrimeBits is the size of the primes returned by HashToPrime.
+const PrimeBits = 256
+
+// HashToPrime maps data to a prime of PrimeBits bits: with
+// d = SHA256(domain ‖ len(data₀) ‖ data₀ ‖ …), it returns the first prime among the
+// SHA256(d ‖ i), i = 0, 1, …, with the top bit set.
+func HashToPrime(domain string, data ...[]byte) *big.Int {
+	h := sha256.New()
+	h.Write([]byte(domain))
+	for _, d := range data {
+		h.Write(binary.BigEndian.AppendUint64(nil, uint64(len(d))))
+		h.Write(d)
+	}
+	seed := h.Sum(nil)
+
+	buf := make([]byte, len(seed)+8)
+	copy(buf, seed)
+	res := new(big.Int)
+	for i := uint64(0); ; i++ {
+		binary.BigEndian.PutUint64(buf[len(seed):], i)
+		candidate := sha256.Sum256(buf)
+		res.SetBytes(candidate[:])
+		res.SetBit(res, PrimeBits-1, 1)
+		if res.ProbablyPrime(20) {
+			return res
+		}
+	}
+}
+
+// hashToInt maps data to an integer of nbBits bits, nbBits ≤ 256.
+func hashToInt(nbBits int, domain string, data ...[]byte) *big.Int {
+	h := sha256.New()
+	h.Write([]byte(domain))
+	for _, d := range data {
+		h.Write(binary.BigEndian.AppendUint64(nil, uint64(len(d))))
+		h.Write(d)
+	}
+	res := new(big.Int).SetBytes(h.Sum(nil))
+	return res.Rsh(res, uint(256-nbBits))
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unknownorder
+
+import (
+	"math/big"
+)
+
+const (
+	domainPoE       = "unknownorder/poe"
+	domainPoKE      = "unknownorder/poke"
+	domainPoKEAlpha = "unknownorder/poke/alpha"
+)
+
+// PoE is a non-interactive proof of exponentiation: a proof that w = uˣ for public u, x
+// and w, whose verification costs two exponentiations by numbers of PrimeBits bits
+// instead of an exponentiation by x (Wesolowski).
+type PoE[E any] struct {
+	Q E
+}
+
+// ProvePoE returns a proof that w = uˣ. x must be non-negative.
+func ProvePoE[E any](group Group[E], u E, x *big.Int, w E) PoE[E] {
+	l := poeChallenge(group, u, x, w)
+	q := new(big.Int).Div(x, l)
+	return PoE[E]{Q: group.Exp(u, q)}
+}
+
+// VerifyPoE returns true if proof proves that w = uˣ.
+func VerifyPoE[E any](group Group[E], u E, x *big.Int, w E, proof PoE[E]) bool {
+	if x.Sign() < 0 {
+		return false
+	}
+	l := poeChallenge(group, u, x, w)
+	r := new(big.Int).Mod(x, l)
+
+	// Qˡuʳ = w
+	return group.Equal(group.Mul(group.Exp(proof.Q, l), group.Exp(u, r)), w)
+}
+
+func poeChallenge[E any](group Group[E], u E, x *big.Int, w E) *big.Int {
+	return HashToPrime(domainPoE, group.Marshal(u), x.Bytes(), group.Marshal(w))
+}
+
+// PoKE is a non-interactive proof of knowledge of exponent: a proof that the prover
+// knows an x such that w = uˣ, of constant size whatever the size of x (PoKE2 in
+// Boneh-Bünz-Fisch).
+type PoKE[E any] struct {
+	Z E        // gˣ
+	Q E        // (u·gᵅ)^⌊x/ℓ⌋
+	R *big.Int // x mod ℓ
+}
+
+// ProvePoKE returns a proof of knowledge of x such that w = uˣ.
+func ProvePoKE[E any](group Group[E], u E, x *big.Int, w E) PoKE[E] {
+	z := group.Exp(group.Generator(), x)
+	l, alpha := pokeChallenges(group, u, w, z)
+	q, r := new(big.Int).DivMod(x, l, new(big.Int))
+	base := group.Mul(u, group.Exp(group.Generator(), alpha))
+	return PoKE[E]{Z: z, Q: group.Exp(base, q), R: r}
+}
+
+// VerifyPoKE returns true if proof proves the knowledge of an x such that w = uˣ.
+func VerifyPoKE[E any](group Group[E], u, w E, proof PoKE[E]) bool {
+	l, alpha := pokeChallenges(group, u, w, proof.Z)
+	if proof.R == nil || proof.R.Sign() < 0 || proof.R.Cmp(l) >= 0 {
+		return false
+	}
+
+	// Qˡ(u·gᵅ)ʳ = w·zᵅ
+	base := group.Mul(u, group.Exp(group.Generator(), alpha))
+	left := group.Mul(group.Exp(proof.Q, l), group.Exp(base, proof.R))
+	right := group.Mul(w, group.Exp(proof.Z, alpha))
+	return group.Equal(left, right)
+}
+
+// pokeChallenges returns the prime ℓ and the 128 bits integer α of PoKE2.
+func pokeChallenges[E any](group Group[E], u, w, z E) (l, alpha *big.Int) {
+	bu, bw, bz := group.Marshal(u), group.Marshal(w), group.Marshal(z)
+	l = HashToPrime(domainPoKE, bu, bw, bz)
+	alpha = hashToInt(128, domainPoKEAlpha, bu, bw, bz, l.Bytes())
+	return
+}
@@ -0,0 +1,137 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unknownorder
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+)
+
+var ErrInvalidModulus = errors.New("the RSA modulus must be odd and at least 1024 bits")
+
+// RSAGroup is the quotient group QR⁺_N = ℤ*_N/{±1} for an RSA modulus N, whose order is
+// unknown as long as the factorization of N is. Elements are represented by the
+// integer in [1, N/2] of their class. The low order element -1 is thus identified with
+// 1, as required by the adaptive root assumption.
+type RSAGroup struct {
+	n, halfN *big.Int
+	g        *big.Int
+}
+
+// NewRSAGroup returns the RSA group of modulus n, with generator 3. The factorization of n
+// must be unknown to all parties, e.g. the RSA-2048 challenge number or the output of a
+// multi-party computation.
+func NewRSAGroup(n *big.Int) (*RSAGroup, error) {
+	if n.Bit(0) != 1 || n.BitLen() < 1024 {
+		return nil, ErrInvalidModulus
+	}
+	return &RSAGroup{
+		n:     new(big.Int).Set(n),
+		halfN: new(big.Int).Rsh(n, 1),
+		g:     big.NewInt(3),
+	}, nil
+}
+
+// GenerateRSAGroup returns the RSA group of a random modulus of nbBits bits, whose
+// factors are immediately discarded. The party running it is trusted to forget them:
+// it is meant for tests.
+func GenerateRSAGroup(r io.Reader, nbBits int) (*RSAGroup, error) {
+	if r == nil {
+		r = rand.Reader
+	}
+	for {
+		p, err := rand.Prime(r, nbBits/2)
+		if err != nil {
+			return nil, err
+		}
+		q, err := rand.Prime(r, nbBits-nbBits/2)
+		if err != nil {
+			return nil, err
+		}
+		n := new(big.Int).Mul(p, q)
+		if p.Cmp(q) != 0 && n.BitLen() == nbBits {
+			return NewRSAGroup(n)
+		}
+	}
+}
+
+// Modulus returns the RSA modulus N.
+func (g *RSAGroup) Modulus() *big.Int {
+	return new(big.Int).Set(g.n)
+}
+
+// reduce sets a to the representative of its class in [0, N/2], and returns it.
+func (g *RSAGroup) reduce(a *big.Int) *big.Int {
+	a.Mod(a, g.n)
+	if a.Cmp(g.halfN) > 0 {
+		a.Sub(g.n, a)
+	}
+	return a
+}
+
+// Generator implements Group.
+func (g *RSAGroup) Generator() *big.Int {
+	return new(big.Int).Set(g.g)
+}
+
+// Identity implements Group.
+func (g *RSAGroup) Identity() *big.Int {
+	return big.NewInt(1)
+}
+
+// Mul implements Group.
+func (g *RSAGroup) Mul(a, b *big.Int) *big.Int {
+	return g.reduce(new(big.Int).Mul(a, b))
+}
+
+// IsValid returns true if a is in [1, N) and coprime with N, that is if it represents
+// an element of the group. Elements received from other parties should be checked.
+func (g *RSAGroup) IsValid(a *big.Int) bool {
+	if a == nil || a.Sign() <= 0 || a.Cmp(g.n) >= 0 {
+		return false
+	}
+	return new(big.Int).GCD(nil, nil, a, g.n).Cmp(big.NewInt(1)) == 0
+}
+
+// Inverse implements Group. a is not invertible if it shares a factor with N, which
+// would then be factored.
+func (g *RSAGroup) Inverse(a *big.Int) (*big.Int, error) {
+	res := new(big.Int).ModInverse(a, g.n)
+	if res == nil {
+		return nil, ErrNotInvertible
+	}
+	return g.reduce(res), nil
+}
+
+// Exp implements Group.
+func (g *RSAGroup) Exp(a, e *big.Int) *big.Int {
+	if e.Sign() < 0 {
+		panic("unknownorder: negative exponent")
+	}
+	return g.reduce(new(big.Int).Exp(a, e, g.n))
+}
+
+// Equal implements Group.
+func (g *RSAGroup) Equal(a, b *big.Int) bool {
+	return g.reduce(new(big.Int).Set(a)).Cmp(g.reduce(new(big.Int).Set(b))) == 0
+}
+
+// Marshal implements Group. The encoding is big-endian, of the size of N.
+func (g *RSAGroup) Marshal(a *big.Int) []byte {
+	res := make([]byte, (g.n.BitLen()+7)/8)
+	return g.reduce(new(big.Int).Set(a)).FillBytes(res)
+}
@@ -0,0 +1,192 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime/debug"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/field/generator"
+	field "github.com/consensys/gnark-crypto/field/generator/config"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+	"github.com/consensys/gnark-crypto/internal/generator/hash_to_field"
+	"github.com/consensys/gnark-crypto/internal/generator/polynomial"
+)
+
+const (
+	copyrightHolder = "Consensys Software Inc."
+	copyrightYear   = 2020
+	modulePath      = "github.com/consensys/gnark-crypto"
+	goVersion       = "1.22"
+)
+
+// generate writes the module described by spec in outputDir.
+func generate(spec *Spec, outputDir string) error {
+	outputDir, err := filepath.Abs(outputDir)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return err
+	}
+	if err := writeGoMod(spec.Module, outputDir); err != nil {
+		return err
+	}
+
+	// the package templates are read from the disk, relative to the working directory,
+	// as when running internal/generator
+	tmplDir, err := os.MkdirTemp("", "gnark-crypto-gen")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmplDir)
+	if err := extractTemplates(tmplDir); err != nil {
+		return err
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if err := os.Chdir(tmplDir); err != nil {
+		return err
+	}
+	defer func() { _ = os.Chdir(wd) }()
+
+	bgen := bavard.NewBatchGenerator(copyrightHolder, copyrightYear, "consensys/gnark-crypto")
+	for _, f := range spec.Fields {
+		dir := filepath.Join(outputDir, f.Package)
+		F, err := field.NewFieldConfig(f.Package, f.Element, f.Modulus, f.AddChain)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.Package, err)
+		}
+		var opts []generator.Option
+		if f.Canonical {
+			opts = append(opts, generator.WithCanonical())
+		}
+		if err := generator.GenerateFF(F, dir, opts...); err != nil {
+			return fmt.Errorf("%s: %w", f.Package, err)
+		}
+
+		dep := config.FieldDependency{
+			FieldPackagePath: path.Join(spec.Module, f.Package),
+			FieldPackageName: f.Package,
+			ElementType:      f.Package + "." + f.Element,
+		}
+		for _, p := range f.Packages {
+			switch p {
+			case "polynomial":
+				err = polynomial.Generate(dep, filepath.Join(dir, "polynomial"), false, false, bgen)
+			case "hash_to_field":
+				err = hash_to_field.Generate(dep, filepath.Join(dir, "hash_to_field"), bgen)
+			}
+			if err != nil {
+				return fmt.Errorf("%s/%s: %w", f.Package, p, err)
+			}
+		}
+	}
+
+	return formatDir(outputDir)
+}
+
+// formatDir runs gofmt on the Go files of dir and its sub-directories.
+func formatDir(dir string) error {
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(p) != ".go" {
+			return err
+		}
+		src, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		res, err := format.Source(src)
+		if err != nil {
+			return fmt.Errorf("%s: %w", p, err)
+		}
+		return os.WriteFile(p, res, 0o644)
+	})
+}
+
+// extractTemplates writes the embedded package templates in dir, with the layout of
+// internal/generator.
+func extractTemplates(dir string) error {
+	for name, templates := range map[string]fs.FS{
+		"polynomial":    polynomial.Templates,
+		"hash_to_field": hash_to_field.Templates,
+	} {
+		err := fs.WalkDir(templates, ".", func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			dst := filepath.Join(dir, name, filepath.FromSlash(p))
+			if d.IsDir() {
+				return os.MkdirAll(dst, 0o755)
+			}
+			data, err := fs.ReadFile(templates, p)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(dst, data, 0o644)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeGoMod writes a go.mod file for module in dir, unless there is one already.
+// It requires the version of gnark-crypto this binary was built from, when known.
+func writeGoMod(module, dir string) error {
+	p := filepath.Join(dir, "go.mod")
+	if _, err := os.Stat(p); err == nil {
+		return nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	content := fmt.Sprintf("module %s\n\ngo %s\n", module, goVersion)
+	if v := gnarkCryptoVersion(); v != "" {
+		content += fmt.Sprintf("\nrequire %s %s\n", modulePath, v)
+	}
+	return os.WriteFile(p, []byte(content), 0o644)
+}
+
+// gnarkCryptoVersion returns the version of gnark-crypto this binary was built from, or
+// "" for a development build.
+func gnarkCryptoVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	v := info.Main.Version
+	if info.Main.Path != modulePath {
+		v = ""
+		for _, d := range info.Deps {
+			if d.Path == modulePath {
+				v = d.Version
+			}
+		}
+	}
+	if v == "(devel)" {
+		return ""
+	}
+	return v
+}
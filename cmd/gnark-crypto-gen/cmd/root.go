@@ -0,0 +1,61 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cmd is the CLI interface for gnark-crypto-gen
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "gnark-crypto-gen spec.json",
+	Short: "gnark-crypto-gen generates field arithmetic and field packages for custom moduli",
+	Long: `gnark-crypto-gen reads a JSON spec (see cmd.Spec) and generates, in the output directory,
+a Go module with one package per field, and the requested packages on top of them.
+If the output directory has no go.mod, one is created; run go mod tidy afterwards.`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         cmdGenerate,
+	SilenceUsage: true,
+}
+
+// flags
+var fOutputDir string
+
+func init() {
+	rootCmd.Flags().StringVarP(&fOutputDir, "output", "o", ".", "destination directory of the generated module")
+}
+
+// Execute adds all child commands to the root command and sets flags appropriately.
+// This is called by main.main(). It only needs to happen once to the rootCmd.
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func cmdGenerate(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	spec, err := parseSpec(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", args[0], err)
+	}
+	return generate(spec, fOutputDir)
+}
@@ -0,0 +1,125 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/token"
+)
+
+// Spec describes the module to generate.
+type Spec struct {
+	// Module is the module path of the generated module, e.g. "example.com/mycurve".
+	Module string `json:"module"`
+
+	// Fields are the prime fields to generate, each in its own package of the module.
+	Fields []FieldSpec `json:"fields"`
+}
+
+// FieldSpec describes a prime field and the packages to generate on top of it.
+type FieldSpec struct {
+	// Package is the name of the package, and of its directory in the module.
+	Package string `json:"package"`
+
+	// Element is the name of the field element type. Default is "Element".
+	Element string `json:"element,omitempty"`
+
+	// Modulus is the field modulus, in base 10 or in base 16 with a 0x prefix.
+	Modulus string `json:"modulus"`
+
+	// AddChain uses addition chains for the fixed exponentiations (square roots,
+	// Legendre symbol). It is faster at runtime but slow to generate for large moduli.
+	AddChain bool `json:"addChain,omitempty"`
+
+	// Canonical also generates the non-Montgomery Canonical<Element> type.
+	Canonical bool `json:"canonical,omitempty"`
+
+	// Packages are the sub-packages to generate on the field: "polynomial" and
+	// "hash_to_field".
+	Packages []string `json:"packages,omitempty"`
+}
+
+// fieldPackages are the packages which can be generated on top of any field.
+var fieldPackages = map[string]bool{
+	"polynomial":    true,
+	"hash_to_field": true,
+}
+
+// curvePackages are packages of gnark-crypto which are only generated for its built-in
+// curves, as their templates import the curve packages.
+var curvePackages = map[string]bool{
+	"fft": true,
+	"fri": true,
+	"kzg": true,
+}
+
+// parseSpec parses and validates a JSON spec.
+func parseSpec(data []byte) (*Spec, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var spec Spec
+	if err := dec.Decode(&spec); err != nil {
+		return nil, err
+	}
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// validate checks the spec and sets the default values.
+func (s *Spec) validate() error {
+	if s.Module == "" {
+		return fmt.Errorf("%w: module", errMissingField)
+	}
+	if len(s.Fields) == 0 {
+		return fmt.Errorf("%w: fields", errMissingField)
+	}
+	seen := make(map[string]bool)
+	for i := range s.Fields {
+		f := &s.Fields[i]
+		if f.Package == "" {
+			return fmt.Errorf("%w: fields[%d].package", errMissingField, i)
+		}
+		if !token.IsIdentifier(f.Package) {
+			return fmt.Errorf("%w: %q", errInvalidPackage, f.Package)
+		}
+		if seen[f.Package] {
+			return fmt.Errorf("%w: %q", errDuplicatePackage, f.Package)
+		}
+		seen[f.Package] = true
+		if f.Modulus == "" {
+			return fmt.Errorf("%w: fields[%d].modulus", errMissingField, i)
+		}
+		if f.Element == "" {
+			f.Element = "Element"
+		}
+		if !token.IsIdentifier(f.Element) || !token.IsExported(f.Element) {
+			return fmt.Errorf("fields[%d]: element name %q is not an exported identifier", i, f.Element)
+		}
+		for _, p := range f.Packages {
+			switch {
+			case fieldPackages[p]:
+			case curvePackages[p]:
+				return fmt.Errorf("%w: %q is only generated for the curves of gnark-crypto", errUnsupportedPackage, p)
+			default:
+				return fmt.Errorf("%w: %q", errUnknownPackage, p)
+			}
+		}
+	}
+	return nil
+}
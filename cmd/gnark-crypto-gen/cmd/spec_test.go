@@ -0,0 +1,88 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSpec(t *testing.T) {
+	assert := require.New(t)
+
+	spec, err := parseSpec([]byte(`{"module": "example.com/m", "fields": [{"package": "fr", "modulus": "0xffffffff00000001"}]}`))
+	assert.NoError(err)
+	assert.Equal("Element", spec.Fields[0].Element, "default element name")
+
+	for _, tc := range []struct {
+		spec string
+		err  error
+	}{
+		{`{"fields": [{"package": "fr", "modulus": "7"}]}`, errMissingField},
+		{`{"module": "example.com/m"}`, errMissingField},
+		{`{"module": "example.com/m", "fields": [{"package": "fr"}]}`, errMissingField},
+		{`{"module": "example.com/m", "fields": [{"package": "my-field", "modulus": "7"}]}`, errInvalidPackage},
+		{`{"module": "example.com/m", "fields": [{"package": "fr", "modulus": "7"}, {"package": "fr", "modulus": "11"}]}`, errDuplicatePackage},
+		{`{"module": "example.com/m", "fields": [{"package": "fr", "modulus": "7", "packages": ["kzg"]}]}`, errUnsupportedPackage},
+		{`{"module": "example.com/m", "fields": [{"package": "fr", "modulus": "7", "packages": ["poseidon"]}]}`, errUnknownPackage},
+	} {
+		_, err := parseSpec([]byte(tc.spec))
+		assert.ErrorIs(err, tc.err, tc.spec)
+	}
+
+	_, err = parseSpec([]byte(`{"module": "example.com/m", "curve": "bn254"}`))
+	assert.Error(err, "unknown fields must be rejected")
+}
+
+func TestGenerate(t *testing.T) {
+	assert := require.New(t)
+
+	spec, err := parseSpec([]byte(`{
+		"module": "example.com/m",
+		"fields": [{"package": "fr", "modulus": "0xffffffff00000001", "canonical": true, "packages": ["polynomial", "hash_to_field"]}]
+	}`))
+	assert.NoError(err)
+
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	assert.NoError(err)
+	assert.NoError(generate(spec, dir))
+
+	after, err := os.Getwd()
+	assert.NoError(err)
+	assert.Equal(wd, after, "working directory must be restored")
+
+	for _, f := range []string{
+		"go.mod",
+		"fr/element.go",
+		"fr/element_canonical.go",
+		"fr/polynomial/polynomial.go",
+		"fr/hash_to_field/hash_to_field.go",
+	} {
+		assert.FileExists(filepath.Join(dir, f))
+	}
+
+	// the generated packages must at least parse
+	fset := token.NewFileSet()
+	for _, p := range []string{"fr", "fr/polynomial", "fr/hash_to_field"} {
+		_, err := parser.ParseDir(fset, filepath.Join(dir, p), nil, parser.SkipObjectResolution)
+		assert.NoError(err, p)
+	}
+}
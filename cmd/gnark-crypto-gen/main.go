@@ -0,0 +1,39 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gnark-crypto-gen generates a standalone Go module with field arithmetic for custom
+// moduli, and the packages of gnark-crypto that only depend on a field, from a JSON spec.
+//
+// Example usage:
+//
+//	gnark-crypto-gen -o ./mycurve spec.json
+//
+// with spec.json such as
+//
+//	{
+//		"module": "example.com/mycurve",
+//		"fields": [
+//			{"package": "fp", "modulus": "0x1a0111ea3…"},
+//			{"package": "fr", "modulus": "5243587517512619047944774050818596583769055250052763782260365869993858118657", "packages": ["polynomial", "hash_to_field"]}
+//		]
+//	}
+//
+// See cmd.Spec for the supported fields.
+package main
+
+import "github.com/consensys/gnark-crypto/cmd/gnark-crypto-gen/cmd"
+
+func main() {
+	cmd.Execute()
+}
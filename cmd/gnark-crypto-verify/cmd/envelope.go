@@ -0,0 +1,151 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"os"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"golang.org/x/crypto/sha3"
+)
+
+// Envelope is a proof or a signature to verify, with its public parameters.
+// Binary fields are hex encoded, with an optional 0x prefix.
+//
+// Supported types are:
+//   - "kzg": opening of Commitment at Point, Proof being a kzg.OpeningProof;
+//   - "kzg-batch": opening of Commitments at Point, Proof being a kzg.BatchOpeningProof
+//     folded with the Fiat-Shamir hash function Hash;
+//   - "ecdsa": Signature of Message by PublicKey, the message being hashed with Hash;
+//   - "bls": BLS signature on bls12-381 with public keys in G1 (proof of possession scheme),
+//     PublicKey and Signature being compressed points;
+//   - "fri": Proof being a fri.ProofOfProximity for polynomials of Size coefficients, with the
+//     fri instance built from Rho, NbRounds, MerkleCapHeight, InstanceID and WideChallenges
+//     (zero values select the defaults) and the hash function Hash.
+//
+// Binary kzg and fri objects are encoded as by their WriteTo methods, points as by their Bytes
+// methods, and field elements in big endian.
+type Envelope struct {
+	Type string `json:"type"`
+
+	// Curve is the name of an ecc.ID, such as bn254 or bls12-381
+	Curve string `json:"curve"`
+
+	// VerifyingKey is a kzg.VerifyingKey, VerifyingKeyFile a path to a binary one
+	VerifyingKey     string `json:"verifyingKey,omitempty"`
+	VerifyingKeyFile string `json:"verifyingKeyFile,omitempty"`
+
+	Commitment  string   `json:"commitment,omitempty"`
+	Commitments []string `json:"commitments,omitempty"`
+	Point       string   `json:"point,omitempty"`
+	Proof       string   `json:"proof,omitempty"`
+
+	PublicKey string `json:"publicKey,omitempty"`
+	Message   string `json:"message,omitempty"`
+	Signature string `json:"signature,omitempty"`
+
+	// Hash is one of sha256 (default), sha512, keccak256, or none if the message is pre-hashed (ecdsa only)
+	Hash string `json:"hash,omitempty"`
+
+	// DST is the domain separation tag of the bls hash to curve
+	DST string `json:"dst,omitempty"`
+
+	// Size is the number of coefficients of the polynomial, the other fields are the
+	// options of the fri instance
+	Size            uint64 `json:"size,omitempty"`
+	Rho             uint64 `json:"rho,omitempty"`
+	NbRounds        int    `json:"nbRounds,omitempty"`
+	MerkleCapHeight int    `json:"merkleCapHeight,omitempty"`
+	InstanceID      string `json:"instanceID,omitempty"`
+	WideChallenges  bool   `json:"wideChallenges,omitempty"`
+}
+
+// Result is the outcome of the verification of an envelope.
+type Result struct {
+	Index int    `json:"index"`
+	Type  string `json:"type"`
+	Curve string `json:"curve"`
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+}
+
+// Verify returns nil if the envelope is valid, or the reason why it isn't.
+func Verify(e *Envelope) error {
+	switch e.Type {
+	case "kzg":
+		return verifyKZG(e, false)
+	case "kzg-batch":
+		return verifyKZG(e, true)
+	case "ecdsa":
+		return verifyECDSA(e)
+	case "bls":
+		return verifyBLS(e)
+	case "fri":
+		return verifyFRI(e)
+	case "shplonk":
+		return fmt.Errorf("%w: %s proofs have no serialized format", errUnsupported, e.Type)
+	default:
+		return fmt.Errorf("%w: %q", errUnknownType, e.Type)
+	}
+}
+
+func (e *Envelope) curveID() (ecc.ID, error) {
+	if e.Curve == "" {
+		return ecc.UNKNOWN, fmt.Errorf("%w: curve", errMissingField)
+	}
+	// accept both bls12-381 and bls12_381
+	return ecc.IDFromString(strings.ReplaceAll(e.Curve, "-", "_"))
+}
+
+func (e *Envelope) verifyingKey() ([]byte, error) {
+	if e.VerifyingKeyFile != "" {
+		return os.ReadFile(e.VerifyingKeyFile)
+	}
+	return decodeHex("verifyingKey", e.VerifyingKey)
+}
+
+// hashFunction returns the hash function named by e.Hash. It returns nil for "none".
+func (e *Envelope) hashFunction() (hash.Hash, error) {
+	switch strings.ToLower(e.Hash) {
+	case "", "sha256":
+		return sha256.New(), nil
+	case "sha512":
+		return sha512.New(), nil
+	case "keccak256":
+		return sha3.NewLegacyKeccak256(), nil
+	case "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownHash, e.Hash)
+	}
+}
+
+// decodeHex decodes the hex encoded field name.
+func decodeHex(name, s string) ([]byte, error) {
+	if s == "" {
+		return nil, fmt.Errorf("%w: %s", errMissingField, name)
+	}
+	res, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return res, nil
+}
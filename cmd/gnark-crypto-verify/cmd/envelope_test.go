@@ -0,0 +1,245 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	fri_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/fri"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	"github.com/consensys/gnark-crypto/signature/ecdsa"
+	"github.com/stretchr/testify/require"
+)
+
+func toHex(v interface{}) string {
+	switch t := v.(type) {
+	case []byte:
+		return "0x" + hex.EncodeToString(t)
+	case io.WriterTo:
+		var buf bytes.Buffer
+		if _, err := t.WriteTo(&buf); err != nil {
+			panic(err)
+		}
+		return hex.EncodeToString(buf.Bytes())
+	}
+	panic("unsupported type")
+}
+
+func kzgEnvelopes(t *testing.T) (Envelope, Envelope) {
+	srs, err := kzg_bn254.NewSRS(16, big.NewInt(42))
+	require.NoError(t, err)
+
+	polys := make([][]fr.Element, 2)
+	digests := make([]kzg_bn254.Digest, len(polys))
+	commitments := make([]string, len(polys))
+	for i := range polys {
+		polys[i] = make([]fr.Element, 10)
+		for j := range polys[i] {
+			polys[i][j].SetRandom()
+		}
+		digests[i], err = kzg_bn254.Commit(polys[i], srs.Pk)
+		require.NoError(t, err)
+		commitments[i] = toHex(digests[i].Marshal())
+	}
+	var point fr.Element
+	point.SetRandom()
+	bPoint := point.Bytes()
+
+	proof, err := kzg_bn254.Open(polys[0], point, srs.Pk)
+	require.NoError(t, err)
+	batchProof, err := kzg_bn254.BatchOpenSinglePoint(polys, digests, point, sha256.New(), srs.Pk)
+	require.NoError(t, err)
+
+	single := Envelope{
+		Type:         "kzg",
+		Curve:        "bn254",
+		VerifyingKey: toHex(&srs.Vk),
+		Commitment:   commitments[0],
+		Point:        toHex(bPoint[:]),
+		Proof:        toHex(&proof),
+	}
+	batch := Envelope{
+		Type:         "kzg-batch",
+		Curve:        "bn254",
+		VerifyingKey: toHex(&srs.Vk),
+		Commitments:  commitments,
+		Point:        toHex(bPoint[:]),
+		Proof:        toHex(&batchProof),
+	}
+	return single, batch
+}
+
+func ecdsaEnvelope(t *testing.T) Envelope {
+	signer, err := ecdsa.New(ecc.SECP256K1, rand.Reader)
+	require.NoError(t, err)
+	msg := []byte("gnark-crypto-verify")
+	sig, err := signer.Sign(msg, sha256.New())
+	require.NoError(t, err)
+	return Envelope{
+		Type:      "ecdsa",
+		Curve:     "secp256k1",
+		PublicKey: toHex(signer.Public().Bytes()),
+		Message:   toHex(msg),
+		Signature: toHex(sig),
+	}
+}
+
+func blsEnvelope(t *testing.T) Envelope {
+	var sk fr.Element // any scalar is fine here
+	sk.SetRandom()
+	var bSk big.Int
+	sk.BigInt(&bSk)
+
+	msg := []byte("gnark-crypto-verify")
+	h, err := bls12381.HashToG2(msg, []byte(blsDST))
+	require.NoError(t, err)
+	var pk bls12381.G1Affine
+	var sig bls12381.G2Affine
+	_, _, g1, _ := bls12381.Generators()
+	pk.ScalarMultiplication(&g1, &bSk)
+	sig.ScalarMultiplication(&h, &bSk)
+
+	bPk, bSig := pk.Bytes(), sig.Bytes()
+	return Envelope{
+		Type:      "bls",
+		Curve:     "bls12-381",
+		PublicKey: toHex(bPk[:]),
+		Message:   toHex(msg),
+		Signature: toHex(bSig[:]),
+	}
+}
+
+func friEnvelope(t *testing.T) Envelope {
+	const size = 64
+	instanceID := []byte("gnark-crypto-verify")
+	iopp, err := fri_bn254.RADIX_2_FRI.NewWithOptions(size, sha256.New(), fri_bn254.WithNbRounds(2), fri_bn254.WithInstanceID(instanceID))
+	require.NoError(t, err)
+	p := make([]fr.Element, size)
+	for i := range p {
+		p[i].SetRandom()
+	}
+	proof, err := iopp.BuildProofOfProximity(p)
+	require.NoError(t, err)
+	return Envelope{
+		Type:       "fri",
+		Curve:      "bn254",
+		Size:       size,
+		NbRounds:   2,
+		InstanceID: toHex(instanceID),
+		Proof:      toHex(&proof),
+	}
+}
+
+func TestVerify(t *testing.T) {
+	assert := require.New(t)
+
+	single, batch := kzgEnvelopes(t)
+	assert.NoError(Verify(&single))
+	assert.NoError(Verify(&batch))
+
+	e := ecdsaEnvelope(t)
+	assert.NoError(Verify(&e))
+
+	b := blsEnvelope(t)
+	assert.NoError(Verify(&b))
+
+	f := friEnvelope(t)
+	assert.NoError(Verify(&f))
+
+	// wrong point
+	wrong := single
+	wrong.Point = "0x01"
+	assert.Error(Verify(&wrong))
+
+	// wrong hash function
+	wrong = batch
+	wrong.Hash = "sha512"
+	assert.Error(Verify(&wrong))
+
+	// wrong message
+	wrong = e
+	wrong.Message = "0x00"
+	assert.ErrorIs(Verify(&wrong), errInvalidSig)
+	wrong = b
+	wrong.Message = "0x00"
+	assert.ErrorIs(Verify(&wrong), errInvalidSig)
+
+	// wrong fri instance
+	wrong = f
+	wrong.InstanceID = "0x00"
+	assert.Error(Verify(&wrong))
+	wrong = f
+	wrong.NbRounds = 1
+	assert.Error(Verify(&wrong))
+
+	// infinity public key
+	wrong = b
+	var inf bls12381.G1Affine
+	bInf := inf.Bytes()
+	wrong.PublicKey = toHex(bInf[:])
+	assert.ErrorIs(Verify(&wrong), errInfinityPubKey)
+
+	// missing field
+	wrong = e
+	wrong.Signature = ""
+	assert.ErrorIs(Verify(&wrong), errMissingField)
+
+	assert.ErrorIs(Verify(&Envelope{Type: "shplonk", Curve: "bn254"}), errUnsupported)
+	assert.ErrorIs(Verify(&Envelope{Type: "fri", Curve: "bn254"}), errMissingField)
+	assert.ErrorIs(Verify(&Envelope{Type: "groth16", Curve: "bn254"}), errUnknownType)
+	assert.ErrorIs(Verify(&Envelope{Type: "bls", Curve: "bn254"}), errUnsupported)
+	assert.ErrorIs(Verify(&Envelope{Type: "ecdsa", Curve: "secp256k1", Hash: "md5", PublicKey: "00", Message: "00", Signature: "00"}), errUnknownHash)
+}
+
+func TestRun(t *testing.T) {
+	assert := require.New(t)
+
+	single, batch := kzgEnvelopes(t)
+	wrong := ecdsaEnvelope(t)
+	wrong.Message = "0x00"
+	data, err := json.Marshal([]Envelope{single, batch, ecdsaEnvelope(t), blsEnvelope(t), friEnvelope(t)})
+	assert.NoError(err)
+
+	var out bytes.Buffer
+	allValid, err := run(nil, bytes.NewReader(data), &out)
+	assert.NoError(err)
+	assert.True(allValid)
+	assert.Equal(5, strings.Count(out.String(), "\n"))
+
+	data, err = json.Marshal(wrong)
+	assert.NoError(err)
+	out.Reset()
+	allValid, err = run(nil, bytes.NewReader(data), &out)
+	assert.NoError(err)
+	assert.False(allValid)
+
+	var res Result
+	assert.NoError(json.Unmarshal(out.Bytes(), &res))
+	assert.Equal(Result{Index: 0, Type: "ecdsa", Curve: "secp256k1", Valid: false, Error: errInvalidSig.Error()}, res)
+
+	_, err = run(nil, strings.NewReader("{"), &out)
+	assert.Error(err)
+}
@@ -0,0 +1,26 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import "errors"
+
+var (
+	errUnknownType    = errors.New("unknown envelope type")
+	errUnsupported    = errors.New("unsupported envelope type")
+	errMissingField   = errors.New("missing field")
+	errUnknownHash    = errors.New("unknown hash function")
+	errInvalidSig     = errors.New("invalid signature")
+	errInfinityPubKey = errors.New("public key is the point at infinity")
+)
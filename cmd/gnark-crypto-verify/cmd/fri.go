@@ -0,0 +1,146 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	fri_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fri"
+	fri_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fri"
+	fri_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr/fri"
+	fri_bls24317 "github.com/consensys/gnark-crypto/ecc/bls24-317/fr/fri"
+	fri_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/fri"
+	fri_bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633/fr/fri"
+	fri_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/fri"
+)
+
+// friInput holds the decoded fields of a fri envelope
+type friInput struct {
+	size           uint64
+	proof          []byte
+	hf             hash.Hash
+	rho            uint64
+	nbRounds       int
+	capHeight      int
+	instanceID     []byte
+	wideChallenges bool
+}
+
+func verifyFRI(e *Envelope) error {
+	curve, err := e.curveID()
+	if err != nil {
+		return err
+	}
+	if e.Size == 0 {
+		return fmt.Errorf("%w: size", errMissingField)
+	}
+	in := friInput{
+		size:           e.Size,
+		rho:            e.Rho,
+		nbRounds:       e.NbRounds,
+		capHeight:      e.MerkleCapHeight,
+		wideChallenges: e.WideChallenges,
+	}
+	if in.proof, err = decodeHex("proof", e.Proof); err != nil {
+		return err
+	}
+	if in.hf, err = e.hashFunction(); err != nil {
+		return err
+	}
+	if in.hf == nil {
+		return fmt.Errorf("%w: fri needs a hash function", errUnknownHash)
+	}
+	if e.InstanceID != "" {
+		if in.instanceID, err = decodeHex("instanceID", e.InstanceID); err != nil {
+			return err
+		}
+	}
+
+	switch curve {
+	case ecc.BN254:
+		return verifyFRIWith(&in, fri_bn254.RADIX_2_FRI.NewWithOptions,
+			fri_bn254.WithRho, fri_bn254.WithNbRounds, fri_bn254.WithMerkleCapHeight, fri_bn254.WithInstanceID, fri_bn254.WithWideChallenges)
+	case ecc.BLS12_377:
+		return verifyFRIWith(&in, fri_bls12377.RADIX_2_FRI.NewWithOptions,
+			fri_bls12377.WithRho, fri_bls12377.WithNbRounds, fri_bls12377.WithMerkleCapHeight, fri_bls12377.WithInstanceID, fri_bls12377.WithWideChallenges)
+	case ecc.BLS12_381:
+		return verifyFRIWith(&in, fri_bls12381.RADIX_2_FRI.NewWithOptions,
+			fri_bls12381.WithRho, fri_bls12381.WithNbRounds, fri_bls12381.WithMerkleCapHeight, fri_bls12381.WithInstanceID, fri_bls12381.WithWideChallenges)
+	case ecc.BLS24_315:
+		return verifyFRIWith(&in, fri_bls24315.RADIX_2_FRI.NewWithOptions,
+			fri_bls24315.WithRho, fri_bls24315.WithNbRounds, fri_bls24315.WithMerkleCapHeight, fri_bls24315.WithInstanceID, fri_bls24315.WithWideChallenges)
+	case ecc.BLS24_317:
+		return verifyFRIWith(&in, fri_bls24317.RADIX_2_FRI.NewWithOptions,
+			fri_bls24317.WithRho, fri_bls24317.WithNbRounds, fri_bls24317.WithMerkleCapHeight, fri_bls24317.WithInstanceID, fri_bls24317.WithWideChallenges)
+	case ecc.BW6_633:
+		return verifyFRIWith(&in, fri_bw6633.RADIX_2_FRI.NewWithOptions,
+			fri_bw6633.WithRho, fri_bw6633.WithNbRounds, fri_bw6633.WithMerkleCapHeight, fri_bw6633.WithInstanceID, fri_bw6633.WithWideChallenges)
+	case ecc.BW6_761:
+		return verifyFRIWith(&in, fri_bw6761.RADIX_2_FRI.NewWithOptions,
+			fri_bw6761.WithRho, fri_bw6761.WithNbRounds, fri_bw6761.WithMerkleCapHeight, fri_bw6761.WithInstanceID, fri_bw6761.WithWideChallenges)
+	default:
+		return fmt.Errorf("%w: fri on %s", errUnsupported, curve)
+	}
+}
+
+// verifyFRIWith builds the fri instance described by the envelope with the curve specific
+// constructors, and verifies the decoded proof of proximity with it.
+func verifyFRIWith[O, P any,
+	I interface {
+		VerifyProofOfProximity(P) error
+	},
+	PP interface {
+		*P
+		io.ReaderFrom
+	},
+](
+	in *friInput,
+	newIOPP func(uint64, hash.Hash, ...O) (I, error),
+	withRho func(uint64) O,
+	withNbRounds func(int) O,
+	withMerkleCapHeight func(int) O,
+	withInstanceID func([]byte) O,
+	withWideChallenges func() O,
+) error {
+	var opts []O
+	if in.rho != 0 {
+		opts = append(opts, withRho(in.rho))
+	}
+	if in.nbRounds != 0 {
+		opts = append(opts, withNbRounds(in.nbRounds))
+	}
+	if in.capHeight != 0 {
+		opts = append(opts, withMerkleCapHeight(in.capHeight))
+	}
+	if in.instanceID != nil {
+		opts = append(opts, withInstanceID(in.instanceID))
+	}
+	if in.wideChallenges {
+		opts = append(opts, withWideChallenges())
+	}
+	iopp, err := newIOPP(in.size, in.hf, opts...)
+	if err != nil {
+		return err
+	}
+
+	var proof P
+	if err := readFull(PP(&proof), in.proof); err != nil {
+		return fmt.Errorf("proof: %w", err)
+	}
+	return iopp.VerifyProofOfProximity(proof)
+}
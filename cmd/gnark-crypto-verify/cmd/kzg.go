@@ -0,0 +1,174 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	kzg_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/kzg"
+	kzg_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+	kzg_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/kzg"
+	kzg_bls24317 "github.com/consensys/gnark-crypto/ecc/bls24-317/kzg"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	kzg_bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633/kzg"
+	kzg_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/kzg"
+)
+
+// kzgInput holds the decoded fields of a kzg envelope
+type kzgInput struct {
+	vk          []byte
+	commitments [][]byte
+	point       []byte
+	proof       []byte
+	batch       bool
+	hf          hash.Hash
+}
+
+func verifyKZG(e *Envelope, batch bool) error {
+	curve, err := e.curveID()
+	if err != nil {
+		return err
+	}
+	in := kzgInput{batch: batch}
+	if in.vk, err = e.verifyingKey(); err != nil {
+		return err
+	}
+	if in.point, err = decodeHex("point", e.Point); err != nil {
+		return err
+	}
+	if in.proof, err = decodeHex("proof", e.Proof); err != nil {
+		return err
+	}
+	if batch {
+		if len(e.Commitments) == 0 {
+			return fmt.Errorf("%w: commitments", errMissingField)
+		}
+		in.commitments = make([][]byte, len(e.Commitments))
+		for i := range e.Commitments {
+			if in.commitments[i], err = decodeHex("commitments", e.Commitments[i]); err != nil {
+				return err
+			}
+		}
+		if in.hf, err = e.hashFunction(); err != nil {
+			return err
+		}
+		if in.hf == nil {
+			return fmt.Errorf("%w: kzg-batch needs a hash function", errUnknownHash)
+		}
+	} else {
+		c, err := decodeHex("commitment", e.Commitment)
+		if err != nil {
+			return err
+		}
+		in.commitments = [][]byte{c}
+	}
+
+	switch curve {
+	case ecc.BN254:
+		return verifyKZGWith(&in, kzg_bn254.Verify, kzg_bn254.BatchVerifySinglePoint)
+	case ecc.BLS12_377:
+		return verifyKZGWith(&in, kzg_bls12377.Verify, kzg_bls12377.BatchVerifySinglePoint)
+	case ecc.BLS12_381:
+		return verifyKZGWith(&in, kzg_bls12381.Verify, kzg_bls12381.BatchVerifySinglePoint)
+	case ecc.BLS24_315:
+		return verifyKZGWith(&in, kzg_bls24315.Verify, kzg_bls24315.BatchVerifySinglePoint)
+	case ecc.BLS24_317:
+		return verifyKZGWith(&in, kzg_bls24317.Verify, kzg_bls24317.BatchVerifySinglePoint)
+	case ecc.BW6_633:
+		return verifyKZGWith(&in, kzg_bw6633.Verify, kzg_bw6633.BatchVerifySinglePoint)
+	case ecc.BW6_761:
+		return verifyKZGWith(&in, kzg_bw6761.Verify, kzg_bw6761.BatchVerifySinglePoint)
+	default:
+		return fmt.Errorf("%w: kzg on %s", errUnsupported, curve)
+	}
+}
+
+// verifyKZGWith decodes the kzg input with the types of the curve specific verification
+// functions, and calls the one corresponding to in.batch.
+func verifyKZGWith[VK, D, F, P, BP any,
+	PVK interface {
+		*VK
+		io.ReaderFrom
+	},
+	PD interface {
+		*D
+		SetBytes([]byte) (int, error)
+	},
+	PF interface {
+		*F
+		SetBytesCanonical([]byte) error
+	},
+	PP interface {
+		*P
+		io.ReaderFrom
+	},
+	PBP interface {
+		*BP
+		io.ReaderFrom
+	},
+](
+	in *kzgInput,
+	verify func(*D, *P, F, VK) error,
+	batchVerify func([]D, *BP, F, hash.Hash, VK, ...[]byte) error,
+) error {
+	var vk VK
+	if err := readFull(PVK(&vk), in.vk); err != nil {
+		return fmt.Errorf("verifyingKey: %w", err)
+	}
+	var point F
+	if err := PF(&point).SetBytesCanonical(in.point); err != nil {
+		return fmt.Errorf("point: %w", err)
+	}
+	digests := make([]D, len(in.commitments))
+	for i := range digests {
+		n, err := PD(&digests[i]).SetBytes(in.commitments[i])
+		if err == nil && n != len(in.commitments[i]) {
+			err = errors.New("trailing bytes")
+		}
+		if err != nil {
+			return fmt.Errorf("commitment: %w", err)
+		}
+	}
+
+	if in.batch {
+		var proof BP
+		if err := readFull(PBP(&proof), in.proof); err != nil {
+			return fmt.Errorf("proof: %w", err)
+		}
+		return batchVerify(digests, &proof, point, in.hf, vk)
+	}
+	var proof P
+	if err := readFull(PP(&proof), in.proof); err != nil {
+		return fmt.Errorf("proof: %w", err)
+	}
+	return verify(&digests[0], &proof, point, vk)
+}
+
+// readFull reads v from data, and checks all of data was consumed.
+func readFull(v io.ReaderFrom, data []byte) error {
+	n, err := v.ReadFrom(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if n != int64(len(data)) {
+		return errors.New("trailing bytes")
+	}
+	return nil
+}
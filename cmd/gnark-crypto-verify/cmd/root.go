@@ -0,0 +1,111 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cmd is the CLI interface for gnark-crypto-verify
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "gnark-crypto-verify [envelope files]",
+	Short: "gnark-crypto-verify verifies the proofs and signatures in JSON envelopes",
+	Long: `gnark-crypto-verify reads JSON envelopes (a single object or an array) from the given
+files, or from the standard input, verifies them and prints one JSON result per envelope.
+The exit code is 1 if some envelope doesn't verify.`,
+	RunE:         cmdVerify,
+	SilenceUsage: true,
+}
+
+// Execute adds all child commands to the root command and sets flags appropriately.
+// This is called by main.main(). It only needs to happen once to the rootCmd.
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(2)
+	}
+}
+
+func cmdVerify(cmd *cobra.Command, args []string) error {
+	allValid, err := run(args, cmd.InOrStdin(), cmd.OutOrStdout())
+	if err != nil {
+		return err
+	}
+	if !allValid {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// run verifies the envelopes in the files (or in stdin if there is none), writes the results
+// in out, and returns true if all of them are valid.
+func run(files []string, stdin io.Reader, out io.Writer) (bool, error) {
+	var envelopes []Envelope
+	if len(files) == 0 {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return false, err
+		}
+		if envelopes, err = parseEnvelopes(data); err != nil {
+			return false, fmt.Errorf("stdin: %w", err)
+		}
+	}
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return false, err
+		}
+		e, err := parseEnvelopes(data)
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", f, err)
+		}
+		envelopes = append(envelopes, e...)
+	}
+
+	allValid := true
+	enc := json.NewEncoder(out)
+	for i := range envelopes {
+		res := Result{Index: i, Type: envelopes[i].Type, Curve: envelopes[i].Curve, Valid: true}
+		if err := Verify(&envelopes[i]); err != nil {
+			res.Valid = false
+			res.Error = err.Error()
+			allValid = false
+		}
+		if err := enc.Encode(&res); err != nil {
+			return false, err
+		}
+	}
+	return allValid, nil
+}
+
+// parseEnvelopes parses a JSON envelope or an array of envelopes.
+func parseEnvelopes(data []byte) ([]Envelope, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var res []Envelope
+		err := json.Unmarshal(data, &res)
+		return res, err
+	}
+	var res Envelope
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	return []Envelope{res}, nil
+}
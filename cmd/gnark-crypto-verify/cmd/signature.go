@@ -0,0 +1,119 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/signature/ecdsa"
+)
+
+// blsDST is the default domain separation tag of the bls signatures, as in the Ethereum consensus specs.
+const blsDST = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+
+func verifyECDSA(e *Envelope) error {
+	curve, err := e.curveID()
+	if err != nil {
+		return err
+	}
+	bPublicKey, err := decodeHex("publicKey", e.PublicKey)
+	if err != nil {
+		return err
+	}
+	msg, err := decodeHex("message", e.Message)
+	if err != nil {
+		return err
+	}
+	sig, err := decodeHex("signature", e.Signature)
+	if err != nil {
+		return err
+	}
+	hf, err := e.hashFunction()
+	if err != nil {
+		return err
+	}
+
+	publicKey, err := ecdsa.NewPublicKey(curve)
+	if err != nil {
+		return fmt.Errorf("%w: ecdsa on %s", errUnsupported, curve)
+	}
+	if _, err = publicKey.SetBytes(bPublicKey); err != nil {
+		return fmt.Errorf("publicKey: %w", err)
+	}
+	ok, err := publicKey.Verify(sig, msg, hf)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errInvalidSig
+	}
+	return nil
+}
+
+func verifyBLS(e *Envelope) error {
+	curve, err := e.curveID()
+	if err != nil {
+		return err
+	}
+	if curve != ecc.BLS12_381 {
+		return fmt.Errorf("%w: bls on %s", errUnsupported, curve)
+	}
+	bPublicKey, err := decodeHex("publicKey", e.PublicKey)
+	if err != nil {
+		return err
+	}
+	msg, err := decodeHex("message", e.Message)
+	if err != nil {
+		return err
+	}
+	bSig, err := decodeHex("signature", e.Signature)
+	if err != nil {
+		return err
+	}
+	dst := e.DST
+	if dst == "" {
+		dst = blsDST
+	}
+
+	var publicKey bls12381.G1Affine
+	if err = publicKey.SetBytesStrict(bPublicKey); err != nil {
+		return fmt.Errorf("publicKey: %w", err)
+	}
+	if publicKey.IsInfinity() {
+		return errInfinityPubKey
+	}
+	var sig bls12381.G2Affine
+	if err = sig.SetBytesStrict(bSig); err != nil {
+		return fmt.Errorf("signature: %w", err)
+	}
+	h, err := bls12381.HashToG2(msg, []byte(dst))
+	if err != nil {
+		return err
+	}
+
+	// e(-g₁, σ)⋅e(pk, H(m)) == 1
+	_, _, g1, _ := bls12381.Generators()
+	g1.Neg(&g1)
+	ok, err := bls12381.PairingCheck([]bls12381.G1Affine{g1, publicKey}, []bls12381.G2Affine{sig, h})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errInvalidSig
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gnark-crypto-verify verifies proofs and signatures described in JSON envelopes,
+// and prints the results as JSON, one object per line.
+//
+// Example usage:
+//
+//	gnark-crypto-verify envelopes.json
+//	cat envelope.json | gnark-crypto-verify
+//
+// An envelope is a JSON object (or an array of objects) such as
+//
+//	{"type": "ecdsa", "curve": "secp256k1", "publicKey": "0x…", "message": "0x…", "signature": "0x…"}
+//
+// See cmd.Envelope for the supported types and fields.
+package main
+
+import "github.com/consensys/gnark-crypto/cmd/gnark-crypto-verify/cmd"
+
+func main() {
+	cmd.Execute()
+}
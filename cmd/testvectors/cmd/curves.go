@@ -0,0 +1,378 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	fp_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	fr_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	fri_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fri"
+	mimc_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr/mimc"
+	kzg_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/kzg"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	fp_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	fr_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	fri_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fri"
+	mimc_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/mimc"
+	kzg_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+	bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315"
+	fp_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fp"
+	fr_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	fri_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr/fri"
+	mimc_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr/mimc"
+	kzg_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/kzg"
+	bls24317 "github.com/consensys/gnark-crypto/ecc/bls24-317"
+	fp_bls24317 "github.com/consensys/gnark-crypto/ecc/bls24-317/fp"
+	fr_bls24317 "github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	fri_bls24317 "github.com/consensys/gnark-crypto/ecc/bls24-317/fr/fri"
+	mimc_bls24317 "github.com/consensys/gnark-crypto/ecc/bls24-317/fr/mimc"
+	kzg_bls24317 "github.com/consensys/gnark-crypto/ecc/bls24-317/kzg"
+	bn254 "github.com/consensys/gnark-crypto/ecc/bn254"
+	fp_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	fr_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	fri_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/fri"
+	mimc_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633"
+	fp_bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633/fp"
+	fr_bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633/fr"
+	fri_bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633/fr/fri"
+	mimc_bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633/fr/mimc"
+	kzg_bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633/kzg"
+	bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761"
+	fp_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fp"
+	fr_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
+	fri_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/fri"
+	mimc_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/mimc"
+	kzg_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/kzg"
+)
+
+// supportedCurves are the curves with vectors, in the order of the output.
+var supportedCurves = []ecc.ID{ecc.BN254, ecc.BLS12_377, ecc.BLS12_381, ecc.BLS24_315, ecc.BLS24_317, ecc.BW6_633, ecc.BW6_761}
+
+// curveImpl computes the vectors of a curve with its packages.
+type curveImpl struct {
+	fp, fr   func(s sampler, count int) FieldVectors
+	hashToG1 func(dst string) ([]HashToCurveVector, error)
+	hashToG2 func(dst string) ([]HashToCurveVector, error)
+	mimc     func(s sampler) ([]MiMCVector, error)
+	kzg      func(s sampler, tau *big.Int) (KZGVector, error)
+	fri      func(s sampler) (FRIVector, error)
+}
+
+// curveVectors sets v to the vectors of the curve id derived from s.
+func curveVectors(v *CurveVectors, id ecc.ID, s sampler, count int) error {
+	impl, err := curveImplementation(id)
+	if err != nil {
+		return err
+	}
+	v.Curve = id.String()
+	v.Fp = impl.fp(s, count)
+	v.Fr = impl.fr(s, count)
+
+	dst := "GNARK-CRYPTO-TESTVECTORS-V01-with-" + id.String()
+	if v.HashToG1, err = impl.hashToG1(dst + "-G1"); err != nil {
+		return fmt.Errorf("hash to G1: %w", err)
+	}
+	if v.HashToG2, err = impl.hashToG2(dst + "-G2"); err != nil {
+		return fmt.Errorf("hash to G2: %w", err)
+	}
+	if v.MiMC, err = impl.mimc(s); err != nil {
+		return fmt.Errorf("mimc: %w", err)
+	}
+
+	// the secret of the SRS is public in test vectors
+	tau := new(big.Int).SetBytes(s.bytes("kzg/tau", 0, 32))
+	tau.Mod(tau, id.ScalarField())
+	if v.KZG, err = impl.kzg(s, tau); err != nil {
+		return fmt.Errorf("kzg: %w", err)
+	}
+	if v.FRI, err = impl.fri(s); err != nil {
+		return fmt.Errorf("fri: %w", err)
+	}
+	return nil
+}
+
+func curveImplementation(id ecc.ID) (*curveImpl, error) {
+	switch id {
+	case ecc.BN254:
+		return &curveImpl{
+			fp: func(s sampler, count int) FieldVectors {
+				return fieldVectors[fp_bn254.Element](s, "fp", fp_bn254.Modulus(), count)
+			},
+			fr: func(s sampler, count int) FieldVectors {
+				return fieldVectors[fr_bn254.Element](s, "fr", fr_bn254.Modulus(), count)
+			},
+			hashToG1: func(dst string) ([]HashToCurveVector, error) {
+				return hashToCurveVectors(dst, bn254.HashToG1)
+			},
+			hashToG2: func(dst string) ([]HashToCurveVector, error) {
+				return hashToCurveVectors(dst, bn254.HashToG2)
+			},
+			mimc: func(s sampler) ([]MiMCVector, error) {
+				return mimcVectors[fr_bn254.Element](s, func() hash.Hash { return mimc_bn254.NewMiMC() })
+			},
+			kzg: func(s sampler, tau *big.Int) (KZGVector, error) {
+				srs, err := kzg_bn254.NewSRS(kzgSRSSize, tau)
+				if err != nil {
+					return KZGVector{}, err
+				}
+				return kzgVectorWith[fr_bn254.Element](s, tau, &srs.Vk,
+					func(p []fr_bn254.Element) (kzg_bn254.Digest, error) { return kzg_bn254.Commit(p, srs.Pk) },
+					func(p []fr_bn254.Element, z fr_bn254.Element) (kzg_bn254.OpeningProof, error) {
+						return kzg_bn254.Open(p, z, srs.Pk)
+					},
+					func(d *kzg_bn254.Digest, proof *kzg_bn254.OpeningProof, z fr_bn254.Element) error {
+						return kzg_bn254.Verify(d, proof, z, srs.Vk)
+					},
+				)
+			},
+			fri: func(s sampler) (FRIVector, error) {
+				iop := fri_bn254.RADIX_2_FRI.New(friSize, sha256.New())
+				return friVectorWith[fr_bn254.Element](s, iop, fri_bn254.GetRho(), func(p fri_bn254.ProofOfProximity) []fri_bn254.Round { return p.Rounds })
+			},
+		}, nil
+	case ecc.BLS12_377:
+		return &curveImpl{
+			fp: func(s sampler, count int) FieldVectors {
+				return fieldVectors[fp_bls12377.Element](s, "fp", fp_bls12377.Modulus(), count)
+			},
+			fr: func(s sampler, count int) FieldVectors {
+				return fieldVectors[fr_bls12377.Element](s, "fr", fr_bls12377.Modulus(), count)
+			},
+			hashToG1: func(dst string) ([]HashToCurveVector, error) {
+				return hashToCurveVectors(dst, bls12377.HashToG1)
+			},
+			hashToG2: func(dst string) ([]HashToCurveVector, error) {
+				return hashToCurveVectors(dst, bls12377.HashToG2)
+			},
+			mimc: func(s sampler) ([]MiMCVector, error) {
+				return mimcVectors[fr_bls12377.Element](s, func() hash.Hash { return mimc_bls12377.NewMiMC() })
+			},
+			kzg: func(s sampler, tau *big.Int) (KZGVector, error) {
+				srs, err := kzg_bls12377.NewSRS(kzgSRSSize, tau)
+				if err != nil {
+					return KZGVector{}, err
+				}
+				return kzgVectorWith[fr_bls12377.Element](s, tau, &srs.Vk,
+					func(p []fr_bls12377.Element) (kzg_bls12377.Digest, error) { return kzg_bls12377.Commit(p, srs.Pk) },
+					func(p []fr_bls12377.Element, z fr_bls12377.Element) (kzg_bls12377.OpeningProof, error) {
+						return kzg_bls12377.Open(p, z, srs.Pk)
+					},
+					func(d *kzg_bls12377.Digest, proof *kzg_bls12377.OpeningProof, z fr_bls12377.Element) error {
+						return kzg_bls12377.Verify(d, proof, z, srs.Vk)
+					},
+				)
+			},
+			fri: func(s sampler) (FRIVector, error) {
+				iop := fri_bls12377.RADIX_2_FRI.New(friSize, sha256.New())
+				return friVectorWith[fr_bls12377.Element](s, iop, fri_bls12377.GetRho(), func(p fri_bls12377.ProofOfProximity) []fri_bls12377.Round { return p.Rounds })
+			},
+		}, nil
+	case ecc.BLS12_381:
+		return &curveImpl{
+			fp: func(s sampler, count int) FieldVectors {
+				return fieldVectors[fp_bls12381.Element](s, "fp", fp_bls12381.Modulus(), count)
+			},
+			fr: func(s sampler, count int) FieldVectors {
+				return fieldVectors[fr_bls12381.Element](s, "fr", fr_bls12381.Modulus(), count)
+			},
+			hashToG1: func(dst string) ([]HashToCurveVector, error) {
+				return hashToCurveVectors(dst, bls12381.HashToG1)
+			},
+			hashToG2: func(dst string) ([]HashToCurveVector, error) {
+				return hashToCurveVectors(dst, bls12381.HashToG2)
+			},
+			mimc: func(s sampler) ([]MiMCVector, error) {
+				return mimcVectors[fr_bls12381.Element](s, func() hash.Hash { return mimc_bls12381.NewMiMC() })
+			},
+			kzg: func(s sampler, tau *big.Int) (KZGVector, error) {
+				srs, err := kzg_bls12381.NewSRS(kzgSRSSize, tau)
+				if err != nil {
+					return KZGVector{}, err
+				}
+				return kzgVectorWith[fr_bls12381.Element](s, tau, &srs.Vk,
+					func(p []fr_bls12381.Element) (kzg_bls12381.Digest, error) { return kzg_bls12381.Commit(p, srs.Pk) },
+					func(p []fr_bls12381.Element, z fr_bls12381.Element) (kzg_bls12381.OpeningProof, error) {
+						return kzg_bls12381.Open(p, z, srs.Pk)
+					},
+					func(d *kzg_bls12381.Digest, proof *kzg_bls12381.OpeningProof, z fr_bls12381.Element) error {
+						return kzg_bls12381.Verify(d, proof, z, srs.Vk)
+					},
+				)
+			},
+			fri: func(s sampler) (FRIVector, error) {
+				iop := fri_bls12381.RADIX_2_FRI.New(friSize, sha256.New())
+				return friVectorWith[fr_bls12381.Element](s, iop, fri_bls12381.GetRho(), func(p fri_bls12381.ProofOfProximity) []fri_bls12381.Round { return p.Rounds })
+			},
+		}, nil
+	case ecc.BLS24_315:
+		return &curveImpl{
+			fp: func(s sampler, count int) FieldVectors {
+				return fieldVectors[fp_bls24315.Element](s, "fp", fp_bls24315.Modulus(), count)
+			},
+			fr: func(s sampler, count int) FieldVectors {
+				return fieldVectors[fr_bls24315.Element](s, "fr", fr_bls24315.Modulus(), count)
+			},
+			hashToG1: func(dst string) ([]HashToCurveVector, error) {
+				return hashToCurveVectors(dst, bls24315.HashToG1)
+			},
+			hashToG2: func(dst string) ([]HashToCurveVector, error) {
+				return hashToCurveVectors(dst, bls24315.HashToG2)
+			},
+			mimc: func(s sampler) ([]MiMCVector, error) {
+				return mimcVectors[fr_bls24315.Element](s, func() hash.Hash { return mimc_bls24315.NewMiMC() })
+			},
+			kzg: func(s sampler, tau *big.Int) (KZGVector, error) {
+				srs, err := kzg_bls24315.NewSRS(kzgSRSSize, tau)
+				if err != nil {
+					return KZGVector{}, err
+				}
+				return kzgVectorWith[fr_bls24315.Element](s, tau, &srs.Vk,
+					func(p []fr_bls24315.Element) (kzg_bls24315.Digest, error) { return kzg_bls24315.Commit(p, srs.Pk) },
+					func(p []fr_bls24315.Element, z fr_bls24315.Element) (kzg_bls24315.OpeningProof, error) {
+						return kzg_bls24315.Open(p, z, srs.Pk)
+					},
+					func(d *kzg_bls24315.Digest, proof *kzg_bls24315.OpeningProof, z fr_bls24315.Element) error {
+						return kzg_bls24315.Verify(d, proof, z, srs.Vk)
+					},
+				)
+			},
+			fri: func(s sampler) (FRIVector, error) {
+				iop := fri_bls24315.RADIX_2_FRI.New(friSize, sha256.New())
+				return friVectorWith[fr_bls24315.Element](s, iop, fri_bls24315.GetRho(), func(p fri_bls24315.ProofOfProximity) []fri_bls24315.Round { return p.Rounds })
+			},
+		}, nil
+	case ecc.BLS24_317:
+		return &curveImpl{
+			fp: func(s sampler, count int) FieldVectors {
+				return fieldVectors[fp_bls24317.Element](s, "fp", fp_bls24317.Modulus(), count)
+			},
+			fr: func(s sampler, count int) FieldVectors {
+				return fieldVectors[fr_bls24317.Element](s, "fr", fr_bls24317.Modulus(), count)
+			},
+			hashToG1: func(dst string) ([]HashToCurveVector, error) {
+				return hashToCurveVectors(dst, bls24317.HashToG1)
+			},
+			hashToG2: func(dst string) ([]HashToCurveVector, error) {
+				return hashToCurveVectors(dst, bls24317.HashToG2)
+			},
+			mimc: func(s sampler) ([]MiMCVector, error) {
+				return mimcVectors[fr_bls24317.Element](s, func() hash.Hash { return mimc_bls24317.NewMiMC() })
+			},
+			kzg: func(s sampler, tau *big.Int) (KZGVector, error) {
+				srs, err := kzg_bls24317.NewSRS(kzgSRSSize, tau)
+				if err != nil {
+					return KZGVector{}, err
+				}
+				return kzgVectorWith[fr_bls24317.Element](s, tau, &srs.Vk,
+					func(p []fr_bls24317.Element) (kzg_bls24317.Digest, error) { return kzg_bls24317.Commit(p, srs.Pk) },
+					func(p []fr_bls24317.Element, z fr_bls24317.Element) (kzg_bls24317.OpeningProof, error) {
+						return kzg_bls24317.Open(p, z, srs.Pk)
+					},
+					func(d *kzg_bls24317.Digest, proof *kzg_bls24317.OpeningProof, z fr_bls24317.Element) error {
+						return kzg_bls24317.Verify(d, proof, z, srs.Vk)
+					},
+				)
+			},
+			fri: func(s sampler) (FRIVector, error) {
+				iop := fri_bls24317.RADIX_2_FRI.New(friSize, sha256.New())
+				return friVectorWith[fr_bls24317.Element](s, iop, fri_bls24317.GetRho(), func(p fri_bls24317.ProofOfProximity) []fri_bls24317.Round { return p.Rounds })
+			},
+		}, nil
+	case ecc.BW6_633:
+		return &curveImpl{
+			fp: func(s sampler, count int) FieldVectors {
+				return fieldVectors[fp_bw6633.Element](s, "fp", fp_bw6633.Modulus(), count)
+			},
+			fr: func(s sampler, count int) FieldVectors {
+				return fieldVectors[fr_bw6633.Element](s, "fr", fr_bw6633.Modulus(), count)
+			},
+			hashToG1: func(dst string) ([]HashToCurveVector, error) {
+				return hashToCurveVectors(dst, bw6633.HashToG1)
+			},
+			hashToG2: func(dst string) ([]HashToCurveVector, error) {
+				return hashToCurveVectors(dst, bw6633.HashToG2)
+			},
+			mimc: func(s sampler) ([]MiMCVector, error) {
+				return mimcVectors[fr_bw6633.Element](s, func() hash.Hash { return mimc_bw6633.NewMiMC() })
+			},
+			kzg: func(s sampler, tau *big.Int) (KZGVector, error) {
+				srs, err := kzg_bw6633.NewSRS(kzgSRSSize, tau)
+				if err != nil {
+					return KZGVector{}, err
+				}
+				return kzgVectorWith[fr_bw6633.Element](s, tau, &srs.Vk,
+					func(p []fr_bw6633.Element) (kzg_bw6633.Digest, error) { return kzg_bw6633.Commit(p, srs.Pk) },
+					func(p []fr_bw6633.Element, z fr_bw6633.Element) (kzg_bw6633.OpeningProof, error) {
+						return kzg_bw6633.Open(p, z, srs.Pk)
+					},
+					func(d *kzg_bw6633.Digest, proof *kzg_bw6633.OpeningProof, z fr_bw6633.Element) error {
+						return kzg_bw6633.Verify(d, proof, z, srs.Vk)
+					},
+				)
+			},
+			fri: func(s sampler) (FRIVector, error) {
+				iop := fri_bw6633.RADIX_2_FRI.New(friSize, sha256.New())
+				return friVectorWith[fr_bw6633.Element](s, iop, fri_bw6633.GetRho(), func(p fri_bw6633.ProofOfProximity) []fri_bw6633.Round { return p.Rounds })
+			},
+		}, nil
+	case ecc.BW6_761:
+		return &curveImpl{
+			fp: func(s sampler, count int) FieldVectors {
+				return fieldVectors[fp_bw6761.Element](s, "fp", fp_bw6761.Modulus(), count)
+			},
+			fr: func(s sampler, count int) FieldVectors {
+				return fieldVectors[fr_bw6761.Element](s, "fr", fr_bw6761.Modulus(), count)
+			},
+			hashToG1: func(dst string) ([]HashToCurveVector, error) {
+				return hashToCurveVectors(dst, bw6761.HashToG1)
+			},
+			hashToG2: func(dst string) ([]HashToCurveVector, error) {
+				return hashToCurveVectors(dst, bw6761.HashToG2)
+			},
+			mimc: func(s sampler) ([]MiMCVector, error) {
+				return mimcVectors[fr_bw6761.Element](s, func() hash.Hash { return mimc_bw6761.NewMiMC() })
+			},
+			kzg: func(s sampler, tau *big.Int) (KZGVector, error) {
+				srs, err := kzg_bw6761.NewSRS(kzgSRSSize, tau)
+				if err != nil {
+					return KZGVector{}, err
+				}
+				return kzgVectorWith[fr_bw6761.Element](s, tau, &srs.Vk,
+					func(p []fr_bw6761.Element) (kzg_bw6761.Digest, error) { return kzg_bw6761.Commit(p, srs.Pk) },
+					func(p []fr_bw6761.Element, z fr_bw6761.Element) (kzg_bw6761.OpeningProof, error) {
+						return kzg_bw6761.Open(p, z, srs.Pk)
+					},
+					func(d *kzg_bw6761.Digest, proof *kzg_bw6761.OpeningProof, z fr_bw6761.Element) error {
+						return kzg_bw6761.Verify(d, proof, z, srs.Vk)
+					},
+				)
+			},
+			fri: func(s sampler) (FRIVector, error) {
+				iop := fri_bw6761.RADIX_2_FRI.New(friSize, sha256.New())
+				return friVectorWith[fr_bw6761.Element](s, iop, fri_bw6761.GetRho(), func(p fri_bw6761.ProofOfProximity) []fri_bw6761.Round { return p.Rounds })
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("no vectors for curve %s", id)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cmd is the CLI interface for testvectors
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/spf13/cobra"
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "testvectors",
+	Short: "testvectors emits JSON test vectors for the primitives of gnark-crypto",
+	Long: `testvectors derives, from a seed, the inputs of field operations, hash to curve, MiMC,
+kzg openings and fri proofs on each curve, and writes them with the outputs computed by
+gnark-crypto as JSON (see cmd.Vectors). The same seed always gives the same vectors.`,
+	Args:         cobra.NoArgs,
+	RunE:         cmdGenerate,
+	SilenceUsage: true,
+}
+
+// flags
+var (
+	fSeed   string
+	fCurves []string
+	fCount  int
+	fOutput string
+)
+
+func init() {
+	rootCmd.Flags().StringVar(&fSeed, "seed", "gnark-crypto test vectors", "seed of the inputs")
+	rootCmd.Flags().StringSliceVar(&fCurves, "curves", nil, "curves to generate vectors for (default all)")
+	rootCmd.Flags().IntVar(&fCount, "count", 4, "number of random inputs per field operation")
+	rootCmd.Flags().StringVarP(&fOutput, "output", "o", "", "output file (default stdout)")
+}
+
+// Execute adds all child commands to the root command and sets flags appropriately.
+// This is called by main.main(). It only needs to happen once to the rootCmd.
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func cmdGenerate(cmd *cobra.Command, args []string) error {
+	curves, err := parseCurves(fCurves)
+	if err != nil {
+		return err
+	}
+	if fCount < 0 {
+		return fmt.Errorf("count must be non-negative, got %d", fCount)
+	}
+	out := cmd.OutOrStdout()
+	if fOutput != "" {
+		f, err := os.Create(fOutput)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	return run(fSeed, curves, fCount, out)
+}
+
+// run generates the vectors for the curves and writes them to out.
+func run(seed string, curves []ecc.ID, count int, out io.Writer) error {
+	v, err := Generate(seed, curves, count)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// parseCurves returns the curves named in names, or all the supported curves if
+// names is empty.
+func parseCurves(names []string) ([]ecc.ID, error) {
+	if len(names) == 0 {
+		return supportedCurves, nil
+	}
+	res := make([]ecc.ID, len(names))
+	for i, name := range names {
+		// accept both bls12-381 and bls12_381
+		id, err := ecc.IDFromString(strings.ReplaceAll(strings.TrimSpace(name), "-", "_"))
+		if err != nil {
+			return nil, err
+		}
+		res[i] = id
+	}
+	return res, nil
+}
@@ -0,0 +1,430 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+// Version of the format of the vectors. It changes whenever the vectors derived from
+// a given seed change.
+const Version = 1
+
+// Vectors are the test vectors of all the curves.
+//
+// All binary values are hex encoded with a 0x prefix: field elements in big endian on
+// the size of the modulus, points compressed as by their Bytes methods, and kzg objects
+// as by their WriteTo methods.
+type Vectors struct {
+	Version int            `json:"version"`
+	Seed    string         `json:"seed"`
+	Curves  []CurveVectors `json:"curves"`
+}
+
+// CurveVectors are the test vectors of a curve.
+type CurveVectors struct {
+	Curve string `json:"curve"`
+
+	Fp FieldVectors `json:"fp"`
+	Fr FieldVectors `json:"fr"`
+
+	HashToG1 []HashToCurveVector `json:"hashToG1"`
+	HashToG2 []HashToCurveVector `json:"hashToG2"`
+
+	MiMC []MiMCVector `json:"mimc"`
+
+	KZG KZGVector `json:"kzg"`
+	FRI FRIVector `json:"fri"`
+}
+
+// FieldVectors are the test vectors of the operations of a prime field.
+type FieldVectors struct {
+	Modulus string `json:"modulus"`
+	// Vectors are the results of Op (add, sub, mul, square, neg or inverse, the
+	// inverse of 0 being 0) on Inputs.
+	Vectors []FieldVector `json:"vectors"`
+}
+
+type FieldVector struct {
+	Op     string   `json:"op"`
+	Inputs []string `json:"inputs"`
+	Output string   `json:"output"`
+}
+
+// HashToCurveVector is the hash of Msg to the curve with the domain separation tag DST,
+// as specified by RFC 9380 with the suite of the curve (see HashToG1 and HashToG2).
+// Msg and DST are given as strings.
+type HashToCurveVector struct {
+	DST    string `json:"dst"`
+	Msg    string `json:"msg"`
+	Output string `json:"output"`
+}
+
+// MiMCVector is the MiMC hash, with the default parameters of the scalar field, of the
+// concatenation of the canonical encodings of Inputs.
+type MiMCVector struct {
+	Inputs []string `json:"inputs"`
+	Output string   `json:"output"`
+}
+
+// KZGVector is an opening of Polynomial (coefficients in increasing degree order) at
+// Point with the SRS of size SRSSize for the secret Tau. The ClaimedValue is included
+// in Proof.
+type KZGVector struct {
+	Tau          string   `json:"tau"`
+	SRSSize      int      `json:"srsSize"`
+	VerifyingKey string   `json:"verifyingKey"`
+	Polynomial   []string `json:"polynomial"`
+	Commitment   string   `json:"commitment"`
+	Point        string   `json:"point"`
+	ClaimedValue string   `json:"claimedValue"`
+	Proof        string   `json:"proof"`
+}
+
+// FRIVector is a radix 2 FRI proof of proximity of Polynomial, with the default
+// blow-up factor and sha256 for the Merkle trees and Fiat-Shamir.
+type FRIVector struct {
+	Size       int        `json:"size"`
+	Rho        int        `json:"rho"`
+	Polynomial []string   `json:"polynomial"`
+	Rounds     []FRIRound `json:"rounds"`
+}
+
+// FRIRound is a round of a FRI proof, with the challenges and queries positions (in
+// sorted order) derived by the verifier, see fri.Iopp.DeriveQueries.
+type FRIRound struct {
+	Interactions [][2]FRIMerkleProof `json:"interactions"`
+	Evaluation   string              `json:"evaluation"`
+	Challenges   []string            `json:"challenges"`
+	Queries      []int               `json:"queries"`
+}
+
+type FRIMerkleProof struct {
+	MerkleRoot string   `json:"merkleRoot"`
+	ProofSet   []string `json:"proofSet"`
+}
+
+// sizes of the kzg and fri vectors
+const (
+	kzgSRSSize        = 16
+	kzgPolynomialSize = 10
+	friSize           = 16
+)
+
+// hashToCurveMessages are the messages of the test vectors of RFC 9380.
+var hashToCurveMessages = []string{
+	"",
+	"abc",
+	"abcdef0123456789",
+	"q128_" + string(bytes.Repeat([]byte{'q'}, 128)),
+	"a512_" + string(bytes.Repeat([]byte{'a'}, 512)),
+}
+
+// Generate returns the vectors of the curves derived from seed, with count random
+// inputs per field operation.
+func Generate(seed string, curves []ecc.ID, count int) (*Vectors, error) {
+	res := &Vectors{Version: Version, Seed: seed, Curves: make([]CurveVectors, len(curves))}
+	for i, id := range curves {
+		if err := curveVectors(&res.Curves[i], id, sampler{seed: seed + "/" + id.String()}, count); err != nil {
+			return nil, fmt.Errorf("%s: %w", id, err)
+		}
+	}
+	return res, nil
+}
+
+// sampler derives pseudo-random values from a seed and a label.
+type sampler struct {
+	seed string
+}
+
+// bytes returns n bytes derived from the seed, the label and the index i, by
+// expanding sha512(len(seed) || seed || len(label) || label || i || counter).
+func (s sampler) bytes(label string, i, n int) []byte {
+	h := sha512.New()
+	res := make([]byte, 0, n+h.Size())
+	for counter := uint32(0); len(res) < n; counter++ {
+		h.Reset()
+		writeString(h, s.seed)
+		writeString(h, label)
+		var buf [8]byte
+		binary.BigEndian.PutUint32(buf[:4], uint32(i))
+		binary.BigEndian.PutUint32(buf[4:], counter)
+		h.Write(buf[:])
+		res = h.Sum(res)
+	}
+	return res[:n]
+}
+
+func writeString(h hash.Hash, s string) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(len(s)))
+	h.Write(buf[:])
+	h.Write([]byte(s))
+}
+
+// element is a pointer to a field element, such as *fr.Element.
+type element[F any] interface {
+	*F
+	SetBytes([]byte) *F
+	SetOne() *F
+	Marshal() []byte
+	Add(*F, *F) *F
+	Sub(*F, *F) *F
+	Mul(*F, *F) *F
+	Square(*F) *F
+	Neg(*F) *F
+	Inverse(*F) *F
+}
+
+// elements returns n field elements derived from the label, reduced from 16 more bytes
+// than the size of the modulus so that they are statistically uniform.
+func elements[F any, PF element[F]](s sampler, label string, n int) []F {
+	res := make([]F, n)
+	if n == 0 {
+		return res
+	}
+	size := len(PF(&res[0]).Marshal()) + 16
+	for i := range res {
+		PF(&res[i]).SetBytes(s.bytes(label, i, size))
+	}
+	return res
+}
+
+func toHex(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+func elementHex[F any, PF element[F]](x F) string {
+	return toHex(PF(&x).Marshal())
+}
+
+func elementsHex[F any, PF element[F]](x []F) []string {
+	res := make([]string, len(x))
+	for i := range x {
+		res[i] = elementHex[F, PF](x[i])
+	}
+	return res
+}
+
+// fieldVectors returns the vectors of the field operations on 0, 1, -1, then on count
+// pairs of random elements.
+func fieldVectors[F any, PF element[F]](s sampler, label string, modulus *big.Int, count int) FieldVectors {
+	var zero, one, minusOne F
+	PF(&one).SetOne()
+	PF(&minusOne).Neg(&one)
+	inputs := append([]F{zero, one, minusOne}, elements[F, PF](s, label, 2*count)...)
+
+	res := FieldVectors{Modulus: toHex(modulus.Bytes())}
+	unary := func(op string, x F, f func(z, x *F) *F) {
+		var z F
+		f(&z, &x)
+		res.Vectors = append(res.Vectors, FieldVector{Op: op, Inputs: []string{elementHex[F, PF](x)}, Output: elementHex[F, PF](z)})
+	}
+	binary := func(op string, x, y F, f func(z, x, y *F) *F) {
+		var z F
+		f(&z, &x, &y)
+		res.Vectors = append(res.Vectors, FieldVector{Op: op, Inputs: []string{elementHex[F, PF](x), elementHex[F, PF](y)}, Output: elementHex[F, PF](z)})
+	}
+
+	for i := 0; i+1 < len(inputs); i += 2 {
+		x, y := inputs[i], inputs[i+1]
+		binary("add", x, y, func(z, x, y *F) *F { return PF(z).Add(x, y) })
+		binary("sub", x, y, func(z, x, y *F) *F { return PF(z).Sub(x, y) })
+		binary("mul", x, y, func(z, x, y *F) *F { return PF(z).Mul(x, y) })
+	}
+	for i := range inputs {
+		unary("square", inputs[i], func(z, x *F) *F { return PF(z).Square(x) })
+		unary("neg", inputs[i], func(z, x *F) *F { return PF(z).Neg(x) })
+		unary("inverse", inputs[i], func(z, x *F) *F { return PF(z).Inverse(x) })
+	}
+	return res
+}
+
+// hashToCurveVectors returns the hashes of the RFC 9380 messages with hashTo.
+func hashToCurveVectors[P any, PP interface {
+	*P
+	Marshal() []byte
+}](dst string, hashTo func(msg, dst []byte) (P, error)) ([]HashToCurveVector, error) {
+	res := make([]HashToCurveVector, len(hashToCurveMessages))
+	for i, msg := range hashToCurveMessages {
+		p, err := hashTo([]byte(msg), []byte(dst))
+		if err != nil {
+			return nil, err
+		}
+		res[i] = HashToCurveVector{DST: dst, Msg: msg, Output: toHex(PP(&p).Marshal())}
+	}
+	return res, nil
+}
+
+// mimcVectors returns the hashes of 0 to 3 random field elements.
+func mimcVectors[F any, PF element[F]](s sampler, newHash func() hash.Hash) ([]MiMCVector, error) {
+	var res []MiMCVector
+	for n := 0; n < 4; n++ {
+		inputs := elements[F, PF](s, fmt.Sprintf("mimc/%d", n), n)
+		h := newHash()
+		for i := range inputs {
+			if _, err := h.Write(PF(&inputs[i]).Marshal()); err != nil {
+				return nil, err
+			}
+		}
+		res = append(res, MiMCVector{Inputs: elementsHex[F, PF](inputs), Output: toHex(h.Sum(nil))})
+	}
+	return res, nil
+}
+
+// kzgVectorWith opens a random polynomial at a random point with the curve specific
+// functions, bound to an SRS of size kzgSRSSize for the secret tau.
+func kzgVectorWith[F any, PF element[F], D any, PD interface {
+	*D
+	Marshal() []byte
+}, P any, PP interface {
+	*P
+	io.WriterTo
+}](
+	s sampler,
+	tau *big.Int,
+	vk io.WriterTo,
+	commit func([]F) (D, error),
+	open func([]F, F) (P, error),
+	verify func(*D, *P, F) error,
+) (KZGVector, error) {
+	p := elements[F, PF](s, "kzg/polynomial", kzgPolynomialSize)
+	point := elements[F, PF](s, "kzg/point", 1)[0]
+
+	digest, err := commit(p)
+	if err != nil {
+		return KZGVector{}, err
+	}
+	proof, err := open(p, point)
+	if err != nil {
+		return KZGVector{}, err
+	}
+	if err := verify(&digest, &proof, point); err != nil {
+		return KZGVector{}, err
+	}
+
+	// Horner evaluation of the claimed value
+	var claimed F
+	for i := len(p) - 1; i >= 0; i-- {
+		PF(&claimed).Mul(&claimed, &point)
+		PF(&claimed).Add(&claimed, &p[i])
+	}
+
+	var bVk, bProof bytes.Buffer
+	if _, err := vk.WriteTo(&bVk); err != nil {
+		return KZGVector{}, err
+	}
+	if _, err := PP(&proof).WriteTo(&bProof); err != nil {
+		return KZGVector{}, err
+	}
+	return KZGVector{
+		Tau:          toHex(tau.Bytes()),
+		SRSSize:      kzgSRSSize,
+		VerifyingKey: toHex(bVk.Bytes()),
+		Polynomial:   elementsHex[F, PF](p),
+		Commitment:   toHex(PD(&digest).Marshal()),
+		Point:        elementHex[F, PF](point),
+		ClaimedValue: elementHex[F, PF](claimed),
+		Proof:        toHex(bProof.Bytes()),
+	}, nil
+}
+
+// friIopp is the part of fri.Iopp used for the vectors.
+type friIopp[F, P, R any] interface {
+	BuildProofOfProximity(p []F) (P, error)
+	VerifyProofOfProximity(proof P) error
+	DeriveQueries(round int, proof R) ([]F, []int, error)
+}
+
+// friVectorWith builds and verifies a proof of proximity of a random polynomial with
+// iop, rounds returning the rounds of a proof.
+func friVectorWith[F any, PF element[F], P, R any](s sampler, iop friIopp[F, P, R], rho int, rounds func(P) []R) (FRIVector, error) {
+	p := elements[F, PF](s, "fri/polynomial", friSize)
+	proof, err := iop.BuildProofOfProximity(p)
+	if err != nil {
+		return FRIVector{}, err
+	}
+	if err := iop.VerifyProofOfProximity(proof); err != nil {
+		return FRIVector{}, err
+	}
+
+	res := FRIVector{Size: friSize, Rho: rho, Polynomial: elementsHex[F, PF](p)}
+	for r, round := range rounds(proof) {
+		v, err := friRound[F, PF](&round)
+		if err != nil {
+			return FRIVector{}, err
+		}
+		xi, si, err := iop.DeriveQueries(r, round)
+		if err != nil {
+			return FRIVector{}, err
+		}
+		v.Challenges = elementsHex[F, PF](xi)
+		v.Queries = si
+		res.Rounds = append(res.Rounds, v)
+	}
+	return res, nil
+}
+
+// friRound converts a fri.Round. The rounds of all the curves have the same fields but
+// different types, so the conversion goes through their JSON encoding, in which byte
+// slices are base64 encoded and field elements are decimal numbers.
+func friRound[F any, PF element[F], R any](round *R) (FRIRound, error) {
+	data, err := json.Marshal(round)
+	if err != nil {
+		return FRIRound{}, err
+	}
+	var decoded struct {
+		Interactions [][2]struct {
+			MerkleRoot []byte
+			ProofSet   [][]byte
+		}
+		Evaluation json.Number
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&decoded); err != nil {
+		return FRIRound{}, err
+	}
+
+	var res FRIRound
+	res.Interactions = make([][2]FRIMerkleProof, len(decoded.Interactions))
+	for i, interaction := range decoded.Interactions {
+		for j := range interaction {
+			res.Interactions[i][j].MerkleRoot = toHex(interaction[j].MerkleRoot)
+			res.Interactions[i][j].ProofSet = make([]string, len(interaction[j].ProofSet))
+			for k, node := range interaction[j].ProofSet {
+				res.Interactions[i][j].ProofSet[k] = toHex(node)
+			}
+		}
+	}
+	evaluation, ok := new(big.Int).SetString(decoded.Evaluation.String(), 10)
+	if !ok {
+		return FRIRound{}, fmt.Errorf("invalid evaluation %q", decoded.Evaluation)
+	}
+	var e F
+	PF(&e).SetBytes(evaluation.Bytes())
+	res.Evaluation = elementHex[F, PF](e)
+	return res, nil
+}
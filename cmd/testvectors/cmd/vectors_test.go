@@ -0,0 +1,145 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	"github.com/stretchr/testify/require"
+)
+
+func fromHex(t *testing.T, s string) []byte {
+	require.True(t, strings.HasPrefix(s, "0x"), s)
+	res, err := hex.DecodeString(s[2:])
+	require.NoError(t, err)
+	return res
+}
+
+func TestDeterministic(t *testing.T) {
+	var a, b, c bytes.Buffer
+	curves := []ecc.ID{ecc.BN254, ecc.BLS12_381}
+	require.NoError(t, run("seed", curves, 2, &a))
+	require.NoError(t, run("seed", curves, 2, &b))
+	require.NoError(t, run("other seed", curves, 2, &c))
+	require.Equal(t, a.String(), b.String(), "same seed, different vectors")
+	require.NotEqual(t, a.String(), c.String(), "different seeds, same vectors")
+}
+
+func TestParseCurves(t *testing.T) {
+	curves, err := parseCurves(nil)
+	require.NoError(t, err)
+	require.Equal(t, supportedCurves, curves)
+
+	curves, err = parseCurves([]string{"bls12-381", "bn254"})
+	require.NoError(t, err)
+	require.Equal(t, []ecc.ID{ecc.BLS12_381, ecc.BN254}, curves)
+
+	_, err = parseCurves([]string{"curve25519"})
+	require.Error(t, err)
+
+	_, err = Generate("seed", []ecc.ID{ecc.SECP256K1}, 1)
+	require.Error(t, err)
+}
+
+func TestAllCurves(t *testing.T) {
+	v, err := Generate("seed", supportedCurves, 1)
+	require.NoError(t, err)
+	require.Len(t, v.Curves, len(supportedCurves))
+	for i, c := range v.Curves {
+		require.Equal(t, supportedCurves[i].String(), c.Curve)
+		require.NotEmpty(t, c.Fp.Vectors)
+		require.NotEmpty(t, c.Fr.Vectors)
+		require.Len(t, c.HashToG1, len(hashToCurveMessages))
+		require.Len(t, c.HashToG2, len(hashToCurveMessages))
+		require.NotEmpty(t, c.MiMC)
+		require.NotEmpty(t, c.KZG.Proof)
+		require.NotEmpty(t, c.FRI.Rounds)
+	}
+}
+
+// TestVectorsBN254 checks the vectors of bn254 against independent computations.
+func TestVectorsBN254(t *testing.T) {
+	v, err := Generate("seed", []ecc.ID{ecc.BN254}, 3)
+	require.NoError(t, err)
+	c := v.Curves[0]
+
+	// field operations, with math/big
+	q := new(big.Int).SetBytes(fromHex(t, c.Fp.Modulus))
+	require.Equal(t, ecc.BN254.BaseField(), q)
+	for _, vector := range c.Fp.Vectors {
+		x := new(big.Int).SetBytes(fromHex(t, vector.Inputs[0]))
+		var expected big.Int
+		switch vector.Op {
+		case "add":
+			expected.Add(x, new(big.Int).SetBytes(fromHex(t, vector.Inputs[1])))
+		case "sub":
+			expected.Sub(x, new(big.Int).SetBytes(fromHex(t, vector.Inputs[1])))
+		case "mul":
+			expected.Mul(x, new(big.Int).SetBytes(fromHex(t, vector.Inputs[1])))
+		case "square":
+			expected.Mul(x, x)
+		case "neg":
+			expected.Neg(x)
+		case "inverse":
+			if x.Sign() != 0 {
+				expected.ModInverse(x, q)
+			}
+		default:
+			t.Fatalf("unknown operation %s", vector.Op)
+		}
+		expected.Mod(&expected, q)
+		require.Equal(t, expected.String(), new(big.Int).SetBytes(fromHex(t, vector.Output)).String(), "%s %v", vector.Op, vector.Inputs)
+	}
+
+	// hash to curve
+	for _, vector := range c.HashToG1 {
+		p, err := bn254.HashToG1([]byte(vector.Msg), []byte(vector.DST))
+		require.NoError(t, err)
+		require.Equal(t, vector.Output, toHex(p.Marshal()))
+	}
+
+	// mimc
+	for _, vector := range c.MiMC {
+		h := mimc.NewMiMC()
+		for _, in := range vector.Inputs {
+			h.Write(fromHex(t, in))
+		}
+		require.Equal(t, vector.Output, toHex(h.Sum(nil)))
+	}
+
+	// kzg, decoding the binary objects
+	var vk kzg_bn254.VerifyingKey
+	_, err = vk.ReadFrom(bytes.NewReader(fromHex(t, c.KZG.VerifyingKey)))
+	require.NoError(t, err)
+	var proof kzg_bn254.OpeningProof
+	_, err = proof.ReadFrom(bytes.NewReader(fromHex(t, c.KZG.Proof)))
+	require.NoError(t, err)
+	var commitment kzg_bn254.Digest
+	_, err = commitment.SetBytes(fromHex(t, c.KZG.Commitment))
+	require.NoError(t, err)
+	var point fr.Element
+	require.NoError(t, point.SetBytesCanonical(fromHex(t, c.KZG.Point)))
+	require.Equal(t, c.KZG.ClaimedValue, toHex(proof.ClaimedValue.Marshal()))
+	require.NoError(t, kzg_bn254.Verify(&commitment, &proof, point, vk))
+}
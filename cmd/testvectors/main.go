@@ -0,0 +1,31 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// testvectors emits deterministic JSON test vectors (field operations, hash to curve,
+// MiMC, kzg openings and fri proofs) so that implementations in other languages can
+// cross-test against gnark-crypto.
+//
+// Example usage:
+//
+//	testvectors -o vectors.json
+//	testvectors --curves bn254,bls12-381 --seed "my seed"
+//
+// The same seed always gives the same vectors. See cmd.Vectors for the format.
+package main
+
+import "github.com/consensys/gnark-crypto/cmd/testvectors/cmd"
+
+func main() {
+	cmd.Execute()
+}
@@ -0,0 +1,116 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package elgamal
+
+import (
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+)
+
+const (
+	sizeG1 = bls12377.SizeOfG1AffineCompressed
+
+	// SizePublicKey is the size of the binary representation of a public key
+	SizePublicKey = sizeG1
+
+	// SizeCiphertext is the size of the binary representation of a ciphertext
+	SizeCiphertext = 2 * sizeG1
+
+	// SizeEncryptionProof is the size of the binary representation of an encryption proof
+	SizeEncryptionProof = 3*sizeG1 + 3*sizeFr
+)
+
+// Bytes returns the binary representation of the public key, the encoding of the point A.
+func (pk *PublicKey) Bytes() []byte {
+	b := pk.A.Bytes()
+	return b[:]
+}
+
+// SetBytes sets pk from its binary representation in buf, and returns the number of
+// bytes read. It checks that the point is in G1.
+func (pk *PublicKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < SizePublicKey {
+		return 0, io.ErrShortBuffer
+	}
+	if _, err := pk.A.SetBytes(buf[:SizePublicKey]); err != nil {
+		return 0, err
+	}
+	return SizePublicKey, nil
+}
+
+// Bytes returns the binary representation of the ciphertext, the encodings of C₁ and C₂.
+func (ct *Ciphertext) Bytes() []byte {
+	res := make([]byte, 0, SizeCiphertext)
+	b := ct.C1.Bytes()
+	res = append(res, b[:]...)
+	b = ct.C2.Bytes()
+	return append(res, b[:]...)
+}
+
+// SetBytes sets ct from its binary representation in buf, and returns the number of
+// bytes read. It checks that the points are in G1.
+func (ct *Ciphertext) SetBytes(buf []byte) (int, error) {
+	if len(buf) < SizeCiphertext {
+		return 0, io.ErrShortBuffer
+	}
+	if _, err := ct.C1.SetBytes(buf[:sizeG1]); err != nil {
+		return 0, err
+	}
+	if _, err := ct.C2.SetBytes(buf[sizeG1:SizeCiphertext]); err != nil {
+		return 0, err
+	}
+	return SizeCiphertext, nil
+}
+
+// Bytes returns the binary representation of the proof, the encodings of T₁, T₂, T₃,
+// zₘ, zᵣ and zᵨ.
+func (proof *EncryptionProof) Bytes() []byte {
+	res := make([]byte, 0, SizeEncryptionProof)
+	for _, p := range []*bls12377.G1Affine{&proof.T1, &proof.T2, &proof.T3} {
+		b := p.Bytes()
+		res = append(res, b[:]...)
+	}
+	for _, z := range []*fr.Element{&proof.Zm, &proof.Zr, &proof.Zrho} {
+		b := z.Bytes()
+		res = append(res, b[:]...)
+	}
+	return res
+}
+
+// SetBytes sets proof from its binary representation in buf, and returns the number of
+// bytes read. It checks that the points are in G1 and the scalars canonical.
+func (proof *EncryptionProof) SetBytes(buf []byte) (int, error) {
+	if len(buf) < SizeEncryptionProof {
+		return 0, io.ErrShortBuffer
+	}
+	offset := 0
+	for _, p := range []*bls12377.G1Affine{&proof.T1, &proof.T2, &proof.T3} {
+		if _, err := p.SetBytes(buf[offset : offset+sizeG1]); err != nil {
+			return 0, err
+		}
+		offset += sizeG1
+	}
+	for _, z := range []*fr.Element{&proof.Zm, &proof.Zr, &proof.Zrho} {
+		if err := z.SetBytesCanonical(buf[offset : offset+sizeFr]); err != nil {
+			return 0, err
+		}
+		offset += sizeFr
+	}
+	return SizeEncryptionProof, nil
+}
@@ -22,6 +22,8 @@ package fp
 import "golang.org/x/sys/cpu"
 
 var (
-	supportAdx = cpu.X86.HasADX && cpu.X86.HasBMI2
-	_          = supportAdx
+	supportAdx    = cpu.X86.HasADX && cpu.X86.HasBMI2
+	_             = supportAdx
+	supportAvx512 = supportAdx && cpu.X86.HasAVX512F
+	_             = supportAvx512
 )
@@ -19,7 +19,14 @@
 
 package fp
 
-import "math/bits"
+import (
+	"math/bits"
+	"runtime"
+)
+
+// use32BitLimbs is set on the platforms where bits.Mul64 is emulated: 32-bit architectures,
+// and wasm which has no 64×64→128 multiplication. Mul and Square then run on 32-bit limbs.
+const use32BitLimbs = bits.UintSize == 32 || runtime.GOARCH == "wasm"
 
 // MulBy3 x *= 3 (mod q)
 func MulBy3(x *Element) {
@@ -35,15 +42,9 @@ func MulBy5(x *Element) {
 
 // MulBy13 x *= 13 (mod q)
 func MulBy13(x *Element) {
-	var y = Element{
-		1176283927673829444,
-		14130787773971430395,
-		11354866436980285261,
-		15740727779991009548,
-		14951814113394531041,
-		33013799364667434,
-	}
-	x.Mul(x, &y)
+	// 13 = ((2+1)⋅2⋅2)+1: 5 additions are cheaper than a multiplication on 5 words or more
+	_x := *x
+	x.Double(x).Add(x, &_x).Double(x).Double(x).Add(x, &_x)
 }
 
 // Butterfly sets
@@ -66,6 +67,10 @@ func reduce(z *Element) {
 //
 // x and y must be less than q
 func (z *Element) Mul(x, y *Element) *Element {
+	if use32BitLimbs {
+		mul32(z, x, y)
+		return z
+	}
 
 	// Implements CIOS multiplication -- section 2.3.2 of Tolga Acar's thesis
 	// https://www.microsoft.com/en-us/research/wp-content/uploads/1998/06/97Acar.pdf
@@ -429,6 +434,10 @@ func (z *Element) Mul(x, y *Element) *Element {
 // x must be less than q
 func (z *Element) Square(x *Element) *Element {
 	// see Mul for algorithm documentation
+	if use32BitLimbs {
+		mul32(z, x, x)
+		return z
+	}
 
 	var t0, t1, t2, t3, t4, t5 uint64
 	var u0, u1, u2, u3, u4, u5 uint64
@@ -0,0 +1,112 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+
+	"github.com/stretchr/testify/require"
+)
+
+// cpuBackend delegates to the CPU implementation and counts the calls.
+type cpuBackend struct {
+	calls   int
+	minSize uint64
+}
+
+func (b *cpuBackend) FFT(domain *Domain, a []fr.Element, decimation Decimation, coset bool) bool {
+	if uint64(len(a)) < b.minSize {
+		return false
+	}
+	b.calls++
+	domain.FFT(a, decimation, cosetOptions(coset)...)
+	return true
+}
+
+func (b *cpuBackend) FFTInverse(domain *Domain, a []fr.Element, decimation Decimation, coset bool) bool {
+	if uint64(len(a)) < b.minSize {
+		return false
+	}
+	b.calls++
+	domain.FFTInverse(a, decimation, cosetOptions(coset)...)
+	return true
+}
+
+func cosetOptions(coset bool) []Option {
+	opts := []Option{WithoutBackend()}
+	if coset {
+		opts = append(opts, OnCoset())
+	}
+	return opts
+}
+
+// brokenBackend forgets the coset shift.
+type brokenBackend struct{}
+
+func (brokenBackend) FFT(domain *Domain, a []fr.Element, decimation Decimation, coset bool) bool {
+	domain.FFT(a, decimation, WithoutBackend())
+	return true
+}
+
+func (brokenBackend) FFTInverse(domain *Domain, a []fr.Element, decimation Decimation, coset bool) bool {
+	domain.FFTInverse(a, decimation, WithoutBackend())
+	return true
+}
+
+func TestCheckBackend(t *testing.T) {
+	assert := require.New(t)
+
+	assert.NoError(CheckBackend(&cpuBackend{}, 1, 2, 16, 256))
+	assert.NoError(CheckBackend(&cpuBackend{minSize: 64}, 16, 256))
+	assert.Error(CheckBackend(brokenBackend{}, 16))
+}
+
+func TestRegisterBackend(t *testing.T) {
+	assert := require.New(t)
+
+	b := &cpuBackend{minSize: 16}
+	RegisterBackend(b)
+	defer RegisterBackend(nil)
+
+	domain := NewDomain(32)
+	a := make([]fr.Element, 32)
+	for i := range a {
+		a[i].SetRandom()
+	}
+	expected := make([]fr.Element, len(a))
+	copy(expected, a)
+
+	domain.FFT(a, DIF)
+	domain.FFTInverse(a, DIT)
+	assert.Equal(2, b.calls)
+	assert.Equal(expected, a)
+
+	// declined inputs fall back to the CPU implementation
+	small := NewDomain(8)
+	c := make([]fr.Element, 8)
+	small.FFT(c, DIF)
+	assert.Equal(2, b.calls)
+
+	domain.FFT(a, DIF, WithoutBackend())
+	assert.Equal(2, b.calls)
+
+	RegisterBackend(nil)
+	domain.FFT(a, DIF)
+	assert.Equal(2, b.calls)
+}
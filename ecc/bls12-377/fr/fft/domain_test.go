@@ -20,6 +20,8 @@ import (
 	"bytes"
 	"reflect"
 	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 )
 
 func TestDomainSerialization(t *testing.T) {
@@ -45,3 +47,33 @@ func TestDomainSerialization(t *testing.T) {
 		t.Fatal("Domain.SetBytes(Bytes()) failed")
 	}
 }
+func TestDomainFingerprint(t *testing.T) {
+
+	domain := NewDomain(1<<6, WithoutPrecompute())
+	other := NewDomain(1 << 6)
+	if domain.Fingerprint() != other.Fingerprint() {
+		t.Fatal("fingerprint depends on precomputation")
+	}
+
+	var buf bytes.Buffer
+	if _, err := domain.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var reconstructed Domain
+	if _, err := reconstructed.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if domain.Fingerprint() != reconstructed.Fingerprint() {
+		t.Fatal("fingerprint changed after serialization")
+	}
+
+	if domain.Fingerprint() == NewDomain(1<<7).Fingerprint() {
+		t.Fatal("domains of different sizes have the same fingerprint")
+	}
+	var shift fr.Element
+	shift.Square(&domain.FrMultiplicativeGen)
+	shifted := NewDomain(1<<6, WithShift(shift))
+	if domain.Fingerprint() == shifted.Fingerprint() {
+		t.Fatal("domains with different shifts have the same fingerprint")
+	}
+}
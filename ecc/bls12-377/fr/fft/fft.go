@@ -43,6 +43,10 @@ func (domain *Domain) FFT(a []fr.Element, decimation Decimation, opts ...Option)
 
 	opt := fftOptions(opts...)
 
+	if b := registeredBackend(opt); b != nil && b.FFT(domain, a, decimation, opt.coset) {
+		return
+	}
+
 	// find the stage where we should stop spawning go routines in our recursive calls
 	// (ie when we have as many go routines running as we have available CPUs)
 	maxSplits := bits.TrailingZeros64(ecc.NextPowerOfTwo(uint64(opt.nbTasks)))
@@ -57,7 +61,7 @@ func (domain *Domain) FFT(a []fr.Element, decimation Decimation, opts ...Option)
 			cosetTable := domain.cosetTable
 			if !domain.withPrecompute {
 				// we need to build the full table or do a bit reverse dance.
-				cosetTable = make([]fr.Element, len(a))
+				cosetTable = opt.arena.Alloc(len(a))
 				BuildExpTable(domain.FrMultiplicativeGen, cosetTable)
 			}
 			parallel.Execute(len(a), func(start, end int) {
@@ -90,6 +94,11 @@ func (domain *Domain) FFT(a []fr.Element, decimation Decimation, opts ...Option)
 		}
 	}
 
+	if domain.sixStep != nil {
+		domain.sixStep.fft(a, decimation, false, opt)
+		return
+	}
+
 	twiddles := domain.twiddles
 	twiddlesStartStage := 0
 	if !domain.withPrecompute {
@@ -99,7 +108,7 @@ func (domain *Domain) FFT(a []fr.Element, decimation Decimation, opts ...Option)
 			twiddles = make([][]fr.Element, nbStages-twiddlesStartStage)
 			w := domain.Generator
 			w.Exp(w, big.NewInt(int64(1<<twiddlesStartStage)))
-			buildTwiddles(twiddles, w, uint64(nbStages-twiddlesStartStage))
+			buildTwiddles(twiddles, w, uint64(nbStages-twiddlesStartStage), opt.arena)
 		} // else, we don't need twiddles
 	}
 
@@ -121,33 +130,41 @@ func (domain *Domain) FFT(a []fr.Element, decimation Decimation, opts ...Option)
 func (domain *Domain) FFTInverse(a []fr.Element, decimation Decimation, opts ...Option) {
 	opt := fftOptions(opts...)
 
-	// find the stage where we should stop spawning go routines in our recursive calls
-	// (ie when we have as many go routines running as we have available CPUs)
-	maxSplits := bits.TrailingZeros64(ecc.NextPowerOfTwo(uint64(opt.nbTasks)))
-	if opt.nbTasks == 1 {
-		maxSplits = -1
+	if b := registeredBackend(opt); b != nil && b.FFTInverse(domain, a, decimation, opt.coset) {
+		return
 	}
 
-	twiddlesInv := domain.twiddlesInv
-	twiddlesStartStage := 0
-	if !domain.withPrecompute {
-		twiddlesStartStage = 3
-		nbStages := int(bits.TrailingZeros64(domain.Cardinality))
-		if nbStages-twiddlesStartStage > 0 {
-			twiddlesInv = make([][]fr.Element, nbStages-twiddlesStartStage)
-			w := domain.GeneratorInv
-			w.Exp(w, big.NewInt(int64(1<<twiddlesStartStage)))
-			buildTwiddles(twiddlesInv, w, uint64(nbStages-twiddlesStartStage))
-		} // else, we don't need twiddles
-	}
+	if domain.sixStep != nil {
+		domain.sixStep.fft(a, decimation, true, opt)
+	} else {
+		// find the stage where we should stop spawning go routines in our recursive calls
+		// (ie when we have as many go routines running as we have available CPUs)
+		maxSplits := bits.TrailingZeros64(ecc.NextPowerOfTwo(uint64(opt.nbTasks)))
+		if opt.nbTasks == 1 {
+			maxSplits = -1
+		}
 
-	switch decimation {
-	case DIF:
-		difFFT(a, domain.GeneratorInv, twiddlesInv, twiddlesStartStage, 0, maxSplits, nil, opt.nbTasks)
-	case DIT:
-		ditFFT(a, domain.GeneratorInv, twiddlesInv, twiddlesStartStage, 0, maxSplits, nil, opt.nbTasks)
-	default:
-		panic("not implemented")
+		twiddlesInv := domain.twiddlesInv
+		twiddlesStartStage := 0
+		if !domain.withPrecompute {
+			twiddlesStartStage = 3
+			nbStages := int(bits.TrailingZeros64(domain.Cardinality))
+			if nbStages-twiddlesStartStage > 0 {
+				twiddlesInv = make([][]fr.Element, nbStages-twiddlesStartStage)
+				w := domain.GeneratorInv
+				w.Exp(w, big.NewInt(int64(1<<twiddlesStartStage)))
+				buildTwiddles(twiddlesInv, w, uint64(nbStages-twiddlesStartStage), opt.arena)
+			} // else, we don't need twiddles
+		}
+
+		switch decimation {
+		case DIF:
+			difFFT(a, domain.GeneratorInv, twiddlesInv, twiddlesStartStage, 0, maxSplits, nil, opt.nbTasks)
+		case DIT:
+			ditFFT(a, domain.GeneratorInv, twiddlesInv, twiddlesStartStage, 0, maxSplits, nil, opt.nbTasks)
+		default:
+			panic("not implemented")
+		}
 	}
 
 	// scale by CardinalityInv
@@ -187,7 +204,7 @@ func (domain *Domain) FFTInverse(a []fr.Element, decimation Decimation, opts ...
 	cosetTableInv := domain.cosetTableInv
 	if !domain.withPrecompute {
 		// we need to build the full table or do a bit reverse dance.
-		cosetTableInv = make([]fr.Element, len(a))
+		cosetTableInv = opt.arena.Alloc(len(a))
 		BuildExpTable(domain.FrMultiplicativeGenInv, cosetTableInv)
 	}
 	parallel.Execute(len(a), func(start, end int) {
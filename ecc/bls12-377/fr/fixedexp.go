@@ -0,0 +1,135 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fr
+
+import (
+	"math/big"
+)
+
+// FixedExp computes xᵉ for a fixed public exponent e, by sliding window
+// exponentiation: the windows of e are computed once in [NewFixedExp]. It is the
+// runtime counterpart of the addition chains generated for the square root and
+// inversion exponents, and is useful for protocol constants known only at runtime.
+//
+// A FixedExp is immutable and safe for concurrent use.
+type FixedExp struct {
+	negative  bool
+	zero      bool
+	tableSize int // number of odd powers x, x³, … to precompute
+	steps     []fixedExpStep
+}
+
+// fixedExpStep squares the accumulator nbSquares times then multiplies it by
+// the odd power x^(2⋅index+1), if index >= 0.
+type fixedExpStep struct {
+	nbSquares int
+	index     int
+}
+
+// NewFixedExp returns an exponentiator by e. e may be negative, in which case Exp
+// computes (x⁻¹)⁻ᵉ.
+//
+// The windows are computed at each call; callers exponentiating by the same e
+// repeatedly should keep the returned FixedExp.
+func NewFixedExp(e *big.Int) *FixedExp {
+	f := &FixedExp{negative: e.Sign() == -1}
+	if e.Sign() == 0 {
+		f.zero = true
+		return f
+	}
+
+	// xᵉ = x^(((e-1) mod (q-1)) + 1) for all x, including 0
+	var k, qMinusOne big.Int
+	qMinusOne.Sub(&_modulus, big.NewInt(1))
+	k.Abs(e).Sub(&k, big.NewInt(1)).Mod(&k, &qMinusOne).Add(&k, big.NewInt(1))
+
+	// choose the window minimizing the number of multiplications
+	nbBits := k.BitLen()
+	window, best := 1, nbBits
+	for w := 2; w <= 8; w++ {
+		if cost := (1 << (w - 1)) + nbBits/(w+1); cost < best {
+			window, best = w, cost
+		}
+	}
+
+	nbSquares := 0
+	for i := nbBits - 1; i >= 0; {
+		if k.Bit(i) == 0 {
+			nbSquares++
+			i--
+			continue
+		}
+		// longest window [j, i] ending with a 1
+		j := i - window + 1
+		if j < 0 {
+			j = 0
+		}
+		for k.Bit(j) == 0 {
+			j++
+		}
+		value := 0
+		for l := i; l >= j; l-- {
+			value = value<<1 | int(k.Bit(l))
+		}
+		nbSquares += i - j + 1
+		f.steps = append(f.steps, fixedExpStep{nbSquares: nbSquares, index: value >> 1})
+		if value>>1 >= f.tableSize {
+			f.tableSize = value>>1 + 1
+		}
+		nbSquares = 0
+		i = j - 1
+	}
+	if nbSquares != 0 {
+		f.steps = append(f.steps, fixedExpStep{nbSquares: nbSquares, index: -1})
+	}
+
+	return f
+}
+
+// Exp sets z = xᵉ and returns z.
+func (f *FixedExp) Exp(z *Element, x Element) *Element {
+	if f.zero {
+		return z.SetOne()
+	}
+	if f.negative {
+		x.Inverse(&x)
+	}
+
+	// table[i] = x^(2i+1)
+	table := make([]Element, f.tableSize)
+	table[0] = x
+	if f.tableSize > 1 {
+		var x2 Element
+		x2.Square(&x)
+		for i := 1; i < len(table); i++ {
+			table[i].Mul(&table[i-1], &x2)
+		}
+	}
+
+	// the first step starts from 1, no need to square
+	res := table[f.steps[0].index]
+	for _, s := range f.steps[1:] {
+		for i := 0; i < s.nbSquares; i++ {
+			res.Square(&res)
+		}
+		if s.index >= 0 {
+			res.Mul(&res, &table[s.index])
+		}
+	}
+
+	return z.Set(&res)
+}
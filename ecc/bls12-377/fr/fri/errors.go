@@ -0,0 +1,104 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fri
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// VerificationError is returned when the verification of an opening or of a proof of
+// proximity fails. It wraps one of the sentinel errors of the package (ErrMerklePath,
+// ErrProximityTestFolding, …), so errors.Is keeps working, and records where the
+// verification failed.
+type VerificationError struct {
+	// Err is the sentinel error describing the failure.
+	Err error
+
+	// Round is the index of the round of the proof of proximity, -1 if not applicable.
+	Round int
+
+	// Step is the index of the folding step in the round, -1 if not applicable.
+	Step int
+
+	// Query is the queried position, in sorted form, -1 if not applicable.
+	Query int
+
+	// Expected and Actual are the mismatching values, if any. They are empty if the
+	// IOPP was built with WithRedactedErrors.
+	Expected, Actual string
+}
+
+func (e *VerificationError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(e.Err.Error())
+	var ctx []string
+	if e.Round >= 0 {
+		ctx = append(ctx, fmt.Sprintf("round %d", e.Round))
+	}
+	if e.Step >= 0 {
+		ctx = append(ctx, fmt.Sprintf("step %d", e.Step))
+	}
+	if e.Query >= 0 {
+		ctx = append(ctx, fmt.Sprintf("query %d", e.Query))
+	}
+	if len(ctx) > 0 {
+		sb.WriteString(" (")
+		sb.WriteString(strings.Join(ctx, ", "))
+		sb.WriteString(")")
+	}
+	if e.Expected != "" || e.Actual != "" {
+		fmt.Fprintf(&sb, ": expected %s, got %s", e.Expected, e.Actual)
+	}
+	return sb.String()
+}
+
+func (e *VerificationError) Unwrap() error {
+	return e.Err
+}
+
+// verificationError returns a VerificationError wrapping err. expected and actual
+// are fmt.Stringer, []byte (hex encoded) or int, and are dropped if errors are redacted.
+func (s radixTwoFri) verificationError(err error, round, step, query int, expected, actual any) error {
+	res := &VerificationError{
+		Err:   err,
+		Round: round,
+		Step:  step,
+		Query: query,
+	}
+	if !s.redactErrors && (expected != nil || actual != nil) {
+		res.Expected = formatValue(expected)
+		res.Actual = formatValue(actual)
+	}
+	return res
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return "0x" + hex.EncodeToString(v)
+	case Digest:
+		return "0x" + hex.EncodeToString(v)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fri
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+)
+
+// maxEncodedLength bounds the lengths read by ProofOfProximity.ReadFrom, so that a
+// malformed input doesn't trigger huge allocations.
+const maxEncodedLength = 1 << 16
+
+var errEncodedLength = errors.New("fri: encoded length out of range")
+
+// WriteTo writes the binary encoding of the proof of proximity to w. Lengths are
+// encoded on 4 bytes and the number of leaves of the Merkle trees on 8 bytes, in big
+// endian, the evaluations as by fr.Element.Bytes.
+func (proof *ProofOfProximity) WriteTo(w io.Writer) (int64, error) {
+	enc := encoder{w: w}
+	enc.bytes(proof.ID)
+	enc.length(len(proof.Rounds))
+	for i := range proof.Rounds {
+		enc.length(len(proof.Rounds[i].Interactions))
+		for j := range proof.Rounds[i].Interactions {
+			for c := 0; c < 2; c++ {
+				mp := &proof.Rounds[i].Interactions[j][c]
+				enc.bytes(mp.MerkleRoot)
+				enc.uint64(mp.numLeaves)
+				enc.length(len(mp.ProofSet))
+				for k := range mp.ProofSet {
+					enc.bytes(mp.ProofSet[k])
+				}
+			}
+		}
+		b := proof.Rounds[i].Evaluation.Bytes()
+		enc.write(b[:])
+	}
+	return enc.n, enc.err
+}
+
+// ReadFrom decodes a proof of proximity written by WriteTo from r. The evaluations must
+// be canonical. The proof is only checked by IOPP.VerifyProofOfProximity.
+func (proof *ProofOfProximity) ReadFrom(r io.Reader) (int64, error) {
+	dec := decoder{r: r}
+	proof.ID = dec.bytes()
+
+	// the slices grow with the data actually read, a forged length doesn't allocate
+	proof.Rounds = nil
+	nbRounds := dec.length()
+	for i := 0; i < nbRounds && dec.err == nil; i++ {
+		var round Round
+		nbInteractions := dec.length()
+		for j := 0; j < nbInteractions && dec.err == nil; j++ {
+			var interaction [2]MerkleProof
+			for c := 0; c < 2; c++ {
+				mp := &interaction[c]
+				mp.MerkleRoot = dec.bytes()
+				mp.numLeaves = dec.uint64()
+				nbNodes := dec.length()
+				for k := 0; k < nbNodes && dec.err == nil; k++ {
+					mp.ProofSet = append(mp.ProofSet, dec.bytes())
+				}
+			}
+			round.Interactions = append(round.Interactions, interaction)
+		}
+		var b [fr.Bytes]byte
+		dec.read(b[:])
+		if dec.err == nil {
+			dec.err = round.Evaluation.SetBytesCanonical(b[:])
+		}
+		proof.Rounds = append(proof.Rounds, round)
+	}
+	return dec.n, dec.err
+}
+
+// encoder writes to w until the first error.
+type encoder struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (enc *encoder) write(b []byte) {
+	if enc.err != nil {
+		return
+	}
+	var n int
+	n, enc.err = enc.w.Write(b)
+	enc.n += int64(n)
+}
+
+func (enc *encoder) length(l int) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], uint32(l))
+	enc.write(b[:])
+}
+
+func (enc *encoder) uint64(v uint64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	enc.write(b[:])
+}
+
+func (enc *encoder) bytes(b []byte) {
+	enc.length(len(b))
+	enc.write(b)
+}
+
+// decoder reads from r until the first error. After an error, it returns zero values.
+type decoder struct {
+	r   io.Reader
+	n   int64
+	err error
+}
+
+func (dec *decoder) read(b []byte) {
+	if dec.err != nil {
+		return
+	}
+	var n int
+	n, dec.err = io.ReadFull(dec.r, b)
+	dec.n += int64(n)
+}
+
+func (dec *decoder) length() int {
+	var b [4]byte
+	dec.read(b[:])
+	if dec.err != nil {
+		return 0
+	}
+	l := binary.BigEndian.Uint32(b[:])
+	if l > maxEncodedLength {
+		dec.err = errEncodedLength
+		return 0
+	}
+	return int(l)
+}
+
+func (dec *decoder) uint64() uint64 {
+	var b [8]byte
+	dec.read(b[:])
+	return binary.BigEndian.Uint64(b[:])
+}
+
+func (dec *decoder) bytes() []byte {
+	l := dec.length()
+	if dec.err != nil || l == 0 {
+		return nil
+	}
+	b := make([]byte, l)
+	dec.read(b)
+	return b
+}
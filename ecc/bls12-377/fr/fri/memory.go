@@ -0,0 +1,53 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fri
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
+)
+
+// ProverMemory holds the scratch memory of the prover (evaluations on the domain,
+// folded polynomials). Passing the same ProverMemory to successive calls of
+// BuildProofOfProximityWithMemory, and resetting it between them, reuses the
+// buffers of the previous proofs instead of allocating new ones.
+//
+// A ProverMemory is not safe for concurrent use.
+type ProverMemory struct {
+	arena *fft.Arena
+}
+
+// NewProverMemory returns an empty ProverMemory. It grows to the size needed by
+// the first proof.
+func NewProverMemory() *ProverMemory {
+	return &ProverMemory{arena: fft.NewArena(0)}
+}
+
+// Arena returns the arena the memory is allocated from. It can be used by the
+// caller for its own buffers (e.g. in fft.WithArena), which then share the
+// lifetime of the prover buffers.
+func (m *ProverMemory) Arena() *fft.Arena {
+	if m == nil {
+		return nil
+	}
+	return m.arena
+}
+
+// Reset makes the memory available for the next proof. The proofs built with m
+// stay valid, as they don't reference it.
+func (m *ProverMemory) Reset() {
+	m.Arena().Reset()
+}
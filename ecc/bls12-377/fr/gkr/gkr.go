@@ -424,6 +424,7 @@ type settings struct {
 	sorted           []*Wire
 	transcript       *fiatshamir.Transcript
 	transcriptPrefix string
+	wideChallenges   bool
 	nbVars           int
 	workers          *utils.WorkerPool
 }
@@ -499,6 +500,7 @@ func setup(c Circuit, assignment WireAssignment, transcriptSettings fiatshamir.S
 	} else {
 		o.transcript, o.transcriptPrefix = transcriptSettings.Transcript, transcriptSettings.Prefix
 	}
+	o.wideChallenges = transcriptSettings.WideChallenges || fiatshamir.WideChallenges()
 
 	return o, err
 }
@@ -573,10 +575,17 @@ func getFirstChallengeNames(logNbInstances int, prefix string) []string {
 	return res
 }
 
-func getChallenges(transcript *fiatshamir.Transcript, names []string) ([]fr.Element, error) {
+func getChallenges(transcript *fiatshamir.Transcript, names []string, wide bool) ([]fr.Element, error) {
 	res := make([]fr.Element, len(names))
 	for i, name := range names {
-		if bytes, err := transcript.ComputeChallenge(name); err == nil {
+		var bytes []byte
+		var err error
+		if wide {
+			bytes, err = transcript.ComputeChallengeWide(name, 2*fr.Bytes)
+		} else {
+			bytes, err = transcript.ComputeChallenge(name)
+		}
+		if err == nil {
 			res[i].SetBytes(bytes)
 		} else {
 			return nil, err
@@ -585,6 +594,13 @@ func getChallenges(transcript *fiatshamir.Transcript, names []string) ([]fr.Elem
 	return res, nil
 }
 
+// sumcheckSettings returns the transcript settings of the sumcheck proof of a wire.
+func (o *settings) sumcheckSettings(prefix string, baseChallenge [][]byte) fiatshamir.Settings {
+	res := fiatshamir.WithTranscript(o.transcript, prefix, baseChallenge...)
+	res.WideChallenges = o.wideChallenges
+	return res
+}
+
 // Prove consistency of the claimed assignment
 func Prove(c Circuit, assignment WireAssignment, transcriptSettings fiatshamir.Settings, options ...Option) (Proof, error) {
 	o, err := setup(c, assignment, transcriptSettings, options...)
@@ -598,7 +614,7 @@ func Prove(c Circuit, assignment WireAssignment, transcriptSettings fiatshamir.S
 	proof := make(Proof, len(c))
 	// firstChallenge called rho in the paper
 	var firstChallenge []fr.Element
-	firstChallenge, err = getChallenges(o.transcript, getFirstChallengeNames(o.nbVars, o.transcriptPrefix))
+	firstChallenge, err = getChallenges(o.transcript, getFirstChallengeNames(o.nbVars, o.transcriptPrefix), o.wideChallenges)
 	if err != nil {
 		return nil, err
 	}
@@ -621,7 +637,7 @@ func Prove(c Circuit, assignment WireAssignment, transcriptSettings fiatshamir.S
 			}
 		} else {
 			if proof[i], err = sumcheck.Prove(
-				claim, fiatshamir.WithTranscript(o.transcript, wirePrefix+strconv.Itoa(i)+".", baseChallenge...),
+				claim, o.sumcheckSettings(wirePrefix+strconv.Itoa(i)+".", baseChallenge),
 			); err != nil {
 				return proof, err
 			}
@@ -652,7 +668,7 @@ func Verify(c Circuit, assignment WireAssignment, proof Proof, transcriptSetting
 	claims := newClaimsManager(c, assignment, o)
 
 	var firstChallenge []fr.Element
-	firstChallenge, err = getChallenges(o.transcript, getFirstChallengeNames(o.nbVars, o.transcriptPrefix))
+	firstChallenge, err = getChallenges(o.transcript, getFirstChallengeNames(o.nbVars, o.transcriptPrefix), o.wideChallenges)
 	if err != nil {
 		return err
 	}
@@ -683,7 +699,7 @@ func Verify(c Circuit, assignment WireAssignment, proof Proof, transcriptSetting
 				}
 			}
 		} else if err = sumcheck.Verify(
-			claim, proof[i], fiatshamir.WithTranscript(o.transcript, wirePrefix+strconv.Itoa(i)+".", baseChallenge...),
+			claim, proof[i], o.sumcheckSettings(wirePrefix+strconv.Itoa(i)+".", baseChallenge),
 		); err == nil {
 			baseChallenge = make([][]byte, len(finalEvalProof))
 			for j := range finalEvalProof {
@@ -855,8 +871,8 @@ func frToBigInts(dst []*big.Int, src []fr.Element) {
 	}
 }
 
-// Gates defined by name
-var Gates = map[string]Gate{
+// gates defined by name, accessed through GetGate and RegisterGate
+var gates = map[string]Gate{
 	"identity": IdentityGate{},
 	"add":      AddGate{},
 	"sub":      SubGate{},
@@ -864,6 +880,105 @@ var Gates = map[string]Gate{
 	"mul":      MulGate(2),
 }
 
+var gatesLock sync.Mutex
+
+// GateFunction is the evaluation function of a custom gate. It must be a polynomial in its inputs.
+type GateFunction func(...fr.Element) fr.Element
+
+// customGate is a gate defined at runtime through RegisterGate
+type customGate struct {
+	evaluate GateFunction
+	nbIn     int
+	degree   int
+}
+
+func (g *customGate) Evaluate(x ...fr.Element) fr.Element {
+	if len(x) != g.nbIn {
+		panic("wrong input count")
+	}
+	return g.evaluate(x...)
+}
+
+func (g *customGate) Degree() int {
+	return g.degree
+}
+
+// RegisterGate creates a gate from its evaluation function and registers it under the given name,
+// so that it can be used in circuits like the predefined ones.
+// f must be a polynomial of total degree exactly degree in its nbIn inputs; this is checked
+// by interpolating f along a random line. The sumcheck protocol is run over fr,
+// so no other evaluation function is needed.
+func RegisterGate(name string, f GateFunction, nbIn int, degree int) (Gate, error) {
+	if nbIn < 1 {
+		return nil, fmt.Errorf("gate \"%s\": must have at least one input", name)
+	}
+	if degree < 1 {
+		return nil, fmt.Errorf("gate \"%s\": degree must be positive", name)
+	}
+	if found, err := findGateDegree(f, nbIn, degree); err != nil {
+		return nil, fmt.Errorf("gate \"%s\": %w", name, err)
+	} else if found != degree {
+		return nil, fmt.Errorf("gate \"%s\": claimed degree %d, found %d", name, degree, found)
+	}
+
+	gatesLock.Lock()
+	defer gatesLock.Unlock()
+	if _, ok := gates[name]; ok {
+		return nil, fmt.Errorf("gate \"%s\" already exists", name)
+	}
+	g := &customGate{evaluate: f, nbIn: nbIn, degree: degree}
+	gates[name] = g
+	return g, nil
+}
+
+// GetGate returns the gate registered under the given name, or nil if there is none
+func GetGate(name string) Gate {
+	gatesLock.Lock()
+	defer gatesLock.Unlock()
+	return gates[name]
+}
+
+// findGateDegree returns the degree of f restricted to a random line, which is its total degree
+// with high probability. It errors if f appears to have degree larger than maxDegree.
+func findGateDegree(f GateFunction, nbIn, maxDegree int) (int, error) {
+	// x(t) = a + t b
+	a := make([]fr.Element, nbIn)
+	b := make([]fr.Element, nbIn)
+	for i := range a {
+		if _, err := a[i].SetRandom(); err != nil {
+			return -1, err
+		}
+		if _, err := b[i].SetRandom(); err != nil {
+			return -1, err
+		}
+	}
+
+	// evaluate f(x(t)) at t = 0, ..., maxDegree+1, so that a polynomial of degree
+	// larger than maxDegree is detected
+	values := make([]fr.Element, maxDegree+2)
+	x := make([]fr.Element, nbIn)
+	var t, one fr.Element
+	one.SetOne()
+	for i := range values {
+		for j := range x {
+			x[j].Mul(&b[j], &t).Add(&x[j], &a[j])
+		}
+		values[i] = f(x...)
+		t.Add(&t, &one)
+	}
+
+	p := polynomial.InterpolateOnRange(values)
+	for d := len(p) - 1; d >= 0; d-- {
+		if !p[d].IsZero() {
+			if d > maxDegree {
+				return d, fmt.Errorf("degree exceeds %d", maxDegree)
+			}
+			return d, nil
+		}
+	}
+	return 0, nil
+}
+
 type IdentityGate struct{}
 type AddGate struct{}
 type MulGate int
@@ -0,0 +1,183 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package grandproduct
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+	"github.com/consensys/gnark-crypto/utils/concurrency"
+)
+
+var (
+	ErrSizeMismatch     = errors.New("numerator and denominator should have the same size")
+	ErrZeroDenominator  = errors.New("denominator has a zero entry")
+	ErrTooManyBlindings = errors.New("number of blinding rows should be smaller than the size of the accumulator")
+)
+
+// Option allows to customize the computation of the accumulator.
+type Option func(*config)
+
+type config struct {
+	nbBlindingRows int
+	nbTasks        int
+}
+
+// WithBlindingRows sets the last nbRows entries of the accumulator to random
+// values. The accumulation then stops nbRows entries earlier, that is the
+// last non-random entry of Z is the full product of the ratios of the
+// non-blinded rows.
+func WithBlindingRows(nbRows int) Option {
+	return func(c *config) {
+		c.nbBlindingRows = nbRows
+	}
+}
+
+// WithNbTasks sets the maximum number of go routines used. Default is concurrency.MaxTasks(), see ecc.SetNbTasks.
+func WithNbTasks(nbTasks int) Option {
+	if nbTasks < 1 {
+		nbTasks = 1
+	} else if nbTasks > 512 {
+		nbTasks = 512
+	}
+	return func(c *config) {
+		c.nbTasks = nbTasks
+	}
+}
+
+// Build returns the grand product accumulator Z of size n = len(numerator):
+//
+//	Z[0] = 1, Z[i] = ∏_{j<i} numerator[j]/denominator[j]
+//
+// The last ratio is not used in Z, it only appears in the wrap-around
+// relation Z[0] = Z[n-1]⋅numerator[n-1]/denominator[n-1] which holds when the
+// grand products of numerator and denominator are equal.
+//
+// With [WithBlindingRows] set to k, only the ratios of the first n-k-1 rows are
+// accumulated and Z[n-k:] is random.
+func Build(numerator, denominator []fr.Element, opts ...Option) ([]fr.Element, error) {
+	cfg := config{nbTasks: concurrency.MaxTasks()}
+	for _, o := range opts {
+		o(&cfg)
+	}
+	n := len(numerator)
+	if len(denominator) != n {
+		return nil, ErrSizeMismatch
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	if cfg.nbBlindingRows < 0 || cfg.nbBlindingRows >= n {
+		return nil, ErrTooManyBlindings
+	}
+
+	// nbRatios ratios are accumulated, Z[nbRatios+1:] are blinding rows
+	nbRatios := n - 1 - cfg.nbBlindingRows
+	z := make([]fr.Element, n)
+	z[0].SetOne()
+	if nbRatios > 0 {
+		ratios := z[1 : nbRatios+1]
+		copy(ratios, denominator[:nbRatios])
+		for i := range ratios {
+			if ratios[i].IsZero() {
+				return nil, ErrZeroDenominator
+			}
+		}
+		batchInvert(ratios, cfg.nbTasks)
+		parallel.Execute(nbRatios, func(start, end int) {
+			for i := start; i < end; i++ {
+				ratios[i].Mul(&ratios[i], &numerator[i])
+			}
+		}, cfg.nbTasks)
+		PrefixProduct(ratios, cfg.nbTasks)
+	}
+
+	for i := nbRatios + 1; i < n; i++ {
+		if _, err := z[i].SetRandom(); err != nil {
+			return nil, err
+		}
+	}
+
+	return z, nil
+}
+
+// PrefixProduct replaces v[i] with v[0]⋅v[1]⋯v[i], using up to nbTasks go
+// routines.
+func PrefixProduct(v []fr.Element, nbTasks int) {
+	chunks := chunkBounds(len(v), nbTasks)
+	nbChunks := len(chunks) - 1
+	if nbChunks <= 1 {
+		for i := 1; i < len(v); i++ {
+			v[i].Mul(&v[i], &v[i-1])
+		}
+		return
+	}
+
+	// local prefix products in each chunk
+	parallel.Execute(nbChunks, func(start, end int) {
+		for c := start; c < end; c++ {
+			for i := chunks[c] + 1; i < chunks[c+1]; i++ {
+				v[i].Mul(&v[i], &v[i-1])
+			}
+		}
+	}, nbTasks)
+
+	// offsets[c] is the product of all the chunks before c
+	offsets := make([]fr.Element, nbChunks)
+	offsets[0].SetOne()
+	for c := 1; c < nbChunks; c++ {
+		offsets[c].Mul(&offsets[c-1], &v[chunks[c]-1])
+	}
+
+	parallel.Execute(nbChunks-1, func(start, end int) {
+		for c := start + 1; c < end+1; c++ {
+			for i := chunks[c]; i < chunks[c+1]; i++ {
+				v[i].Mul(&v[i], &offsets[c])
+			}
+		}
+	}, nbTasks)
+}
+
+// batchInvert inverts the non zero entries of v in place, splitting the work
+// in chunks each costing one field inversion.
+func batchInvert(v []fr.Element, nbTasks int) {
+	// rough ratio inverse to mul; see if it makes sense to parallelize the batch inverse.
+	const ratioInvMul = 1000 / 17
+	if ratio := len(v) / ratioInvMul; ratio < nbTasks {
+		nbTasks = ratio
+	}
+	parallel.Execute(len(v), func(start, end int) {
+		copy(v[start:end], fr.BatchInvert(v[start:end]))
+	}, nbTasks)
+}
+
+// chunkBounds splits [0, n) in at most nbChunks contiguous chunks and returns
+// their bounds.
+func chunkBounds(n, nbChunks int) []int {
+	if nbChunks > n {
+		nbChunks = n
+	}
+	if nbChunks < 1 {
+		nbChunks = 1
+	}
+	bounds := make([]int, nbChunks+1)
+	for c := 0; c <= nbChunks; c++ {
+		bounds[c] = c * n / nbChunks
+	}
+	return bounds
+}
@@ -0,0 +1,128 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package grandproduct
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func randomVector(size int) []fr.Element {
+	v := make([]fr.Element, size)
+	for i := range v {
+		v[i].SetRandom()
+	}
+	return v
+}
+
+func TestPrefixProduct(t *testing.T) {
+	assert := require.New(t)
+
+	for _, size := range []int{0, 1, 2, 7, 64, 1000} {
+		v := randomVector(size)
+		expected := make([]fr.Element, size)
+		copy(expected, v)
+		for i := 1; i < size; i++ {
+			expected[i].Mul(&expected[i], &expected[i-1])
+		}
+		for _, nbTasks := range []int{1, 3, 16} {
+			got := make([]fr.Element, size)
+			copy(got, v)
+			PrefixProduct(got, nbTasks)
+			assert.Equal(expected, got, "size=%d nbTasks=%d", size, nbTasks)
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	assert := require.New(t)
+
+	const n = 257
+	num := randomVector(n)
+	den := randomVector(n)
+
+	for _, nbTasks := range []int{1, 4} {
+		z, err := Build(num, den, WithNbTasks(nbTasks))
+		assert.NoError(err)
+		assert.Len(z, n)
+		assert.True(z[0].IsOne())
+		var lhs, rhs fr.Element
+		for i := 0; i < n-1; i++ {
+			lhs.Mul(&z[i+1], &den[i])
+			rhs.Mul(&z[i], &num[i])
+			assert.True(lhs.Equal(&rhs), "row %d", i)
+		}
+	}
+
+	// a permuted denominator closes the accumulator
+	copy(den, num)
+	den[0], den[n-1] = den[n-1], den[0]
+	den[3], den[17] = den[17], den[3]
+	z, err := Build(num, den)
+	assert.NoError(err)
+	var wrap fr.Element
+	wrap.Div(&num[n-1], &den[n-1]).Mul(&wrap, &z[n-1])
+	assert.True(wrap.IsOne())
+}
+
+func TestBuildBlinding(t *testing.T) {
+	assert := require.New(t)
+
+	const n, nbBlindings = 64, 3
+	num := randomVector(n)
+	den := randomVector(n)
+
+	z, err := Build(num, den, WithBlindingRows(nbBlindings))
+	assert.NoError(err)
+	reference, err := Build(num, den)
+	assert.NoError(err)
+	assert.Equal(reference[:n-nbBlindings], z[:n-nbBlindings])
+	assert.NotEqual(reference[n-nbBlindings:], z[n-nbBlindings:])
+
+	_, err = Build(num, den, WithBlindingRows(n))
+	assert.ErrorIs(err, ErrTooManyBlindings)
+}
+
+func TestBuildErrors(t *testing.T) {
+	assert := require.New(t)
+
+	_, err := Build(randomVector(4), randomVector(5))
+	assert.ErrorIs(err, ErrSizeMismatch)
+
+	den := randomVector(4)
+	den[1].SetZero()
+	_, err = Build(randomVector(4), den)
+	assert.ErrorIs(err, ErrZeroDenominator)
+
+	// the last denominator is not used in Z
+	den = randomVector(4)
+	den[3].SetZero()
+	_, err = Build(randomVector(4), den)
+	assert.NoError(err)
+}
+
+func BenchmarkBuild(b *testing.B) {
+	const n = 1 << 20
+	num := randomVector(n)
+	den := randomVector(n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Build(num, den)
+	}
+}
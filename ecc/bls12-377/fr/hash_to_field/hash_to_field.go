@@ -21,19 +21,50 @@ import (
 	"hash"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	fieldhash "github.com/consensys/gnark-crypto/field/hash"
 )
 
+// Hash hashes msg to count field elements, as hash_to_field in RFC 9380
+// section 5.2, with 128 bits of security. expand derives the pseudo-random
+// bytes (e.g. [fieldhash.ExpanderXofSHAKE128]) and dst is the domain separation tag.
+//
+// With [fieldhash.ExpanderXmdSHA256], it is equivalent to [fr.Hash].
+func Hash(expand fieldhash.Expander, msg, dst []byte, count int) ([]fr.Element, error) {
+	// L = ceil((ceil(log2(p)) + k) / 8), where k is the security parameter = 128
+	const L = 16 + ((fr.Bits + 7) / 8)
+
+	pseudoRandomBytes, err := expand(msg, dst, count*L)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]fr.Element, count)
+	for i := range res {
+		// SetBytes reduces the L bytes modulo the field order, without big.Int
+		res[i].SetBytes(pseudoRandomBytes[i*L : (i+1)*L])
+	}
+	return res, nil
+}
+
 type wrappedHashToField struct {
 	domain []byte
 	toHash []byte
+	expand fieldhash.Expander
 }
 
 // New returns a new hasher instance which uses [fr.Hash] to hash all the
 // written bytes to a field element, returning the byte representation of the
 // field element. The domain separator is passed as-is to hashing method.
 func New(domainSeparator []byte) hash.Hash {
+	return NewWithExpander(domainSeparator, fieldhash.ExpanderXmdSHA256)
+}
+
+// NewWithExpander is like New, but uses [Hash] with the given expander,
+// e.g. [fieldhash.ExpanderXofSHAKE128].
+func NewWithExpander(domainSeparator []byte, expand fieldhash.Expander) hash.Hash {
 	return &wrappedHashToField{
 		domain: append([]byte{}, domainSeparator...), // copy in case the argument is modified
+		expand: expand,
 	}
 }
 
@@ -43,7 +74,7 @@ func (w *wrappedHashToField) Write(p []byte) (n int, err error) {
 }
 
 func (w *wrappedHashToField) Sum(b []byte) []byte {
-	res, err := fr.Hash(w.toHash, w.domain, 1)
+	res, err := Hash(w.expand, w.toHash, w.domain, 1)
 	if err != nil {
 		// we want to follow the interface, cannot return error and have to panic
 		// but by default the method shouldn't return an error internally
@@ -21,10 +21,10 @@ import (
 	"io"
 	"math/big"
 	"math/bits"
-	"runtime"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
+	"github.com/consensys/gnark-crypto/utils/concurrency"
 )
 
 // Basis indicates the basis in which a polynomial is represented.
@@ -304,7 +304,7 @@ func (p *Polynomial) ToLagrange(d *fft.Domain, nbTasks ...int) *Polynomial {
 	id := p.Form
 	p.grow(int(d.Cardinality))
 
-	n := runtime.NumCPU()
+	n := concurrency.MaxTasks()
 	if len(nbTasks) > 0 {
 		n = nbTasks[0]
 	}
@@ -338,7 +338,7 @@ func (p *Polynomial) ToLagrange(d *fft.Domain, nbTasks ...int) *Polynomial {
 func (p *Polynomial) ToCanonical(d *fft.Domain, nbTasks ...int) *Polynomial {
 	id := p.Form
 	p.grow(int(d.Cardinality))
-	n := runtime.NumCPU()
+	n := concurrency.MaxTasks()
 	if len(nbTasks) > 0 {
 		n = nbTasks[0]
 	}
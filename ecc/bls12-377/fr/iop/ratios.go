@@ -20,13 +20,13 @@ import (
 	"errors"
 	"math/big"
 	"math/bits"
-	"runtime"
 	"sync"
 
 	"github.com/consensys/gnark-crypto/internal/parallel"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
+	"github.com/consensys/gnark-crypto/utils/concurrency"
 )
 
 // errors related to the computation of the quotient and the ratios.
@@ -233,7 +233,7 @@ func BuildRatioCopyConstraint(
 
 	// rough ratio inverse to mul; see if it makes sense to parallelize the batch inverse.
 	const ratioInvMul = 1000 / 17
-	nbTasks := runtime.NumCPU()
+	nbTasks := concurrency.MaxTasks()
 	if ratio := n / ratioInvMul; ratio < nbTasks {
 		nbTasks = ratio
 	}
@@ -361,7 +361,7 @@ func getSupportIdentityPermutation(nbCopies int, domain *fft.Domain) []fr.Elemen
 				for j := start; j < end; j++ {
 					res[i*sizePoly+j].Mul(&res[j], &coset)
 				}
-			}, (runtime.NumCPU()/(nbCopies-1))+1)
+			}, (concurrency.MaxTasks()/(nbCopies-1))+1)
 			wg.Done()
 		}()
 	}
@@ -0,0 +1,80 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package mimc
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+)
+
+// FieldHasher is the MiMC hash function operating directly on field elements.
+// It absorbs and squeezes fr.Element, without the bytes round trips (Marshal,
+// Write, Sum, SetBytes) of the hash.Hash returned by NewMiMC, and its state is a
+// single field element whatever the length of the input.
+//
+// Absorbing x₁, …, xₙ then squeezing gives the same element as writing the
+// big-endian encodings of x₁, …, xₙ to NewMiMC and reading the sum.
+type FieldHasher struct {
+	h      fr.Element
+	params *Params
+}
+
+// NewFieldHasher returns a FieldHasher. Only the WithParams option is relevant.
+func NewFieldHasher(opts ...Option) *FieldHasher {
+	cfg := mimcOptions(opts...)
+	params := cfg.params
+	if params == nil {
+		params = defaultParams()
+	}
+	return &FieldHasher{params: params}
+}
+
+// Reset sets the hasher back to its initial state.
+func (f *FieldHasher) Reset() {
+	f.h.SetZero()
+}
+
+// Absorb updates the state with the elements of x, in order.
+func (f *FieldHasher) Absorb(x ...fr.Element) {
+	for i := range x {
+		// Miyaguchi-Preneel: h ← E_h(x) + h + x
+		r := f.params.Encrypt(x[i], f.h)
+		f.h.Add(&r, &f.h).Add(&f.h, &x[i])
+	}
+}
+
+// Squeeze returns the digest of the elements absorbed so far. The state is then
+// updated as if a zero was absorbed, so that successive calls return a stream of
+// independent-looking elements; absorbing after squeezing is allowed.
+func (f *FieldHasher) Squeeze() fr.Element {
+	res := f.h
+	f.Absorb(fr.Element{})
+	return res
+}
+
+// State returns the digest of the elements absorbed so far, without updating the
+// state.
+func (f *FieldHasher) State() fr.Element {
+	return f.h
+}
+
+// SumElements returns the MiMC digest of x, with the default parameters.
+func SumElements(x ...fr.Element) fr.Element {
+	var f FieldHasher
+	f.params = defaultParams()
+	f.Absorb(x...)
+	return f.h
+}
@@ -0,0 +1,226 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package mle
+
+import (
+	"errors"
+	"math/bits"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+	"github.com/consensys/gnark-crypto/utils/concurrency"
+)
+
+var (
+	ErrNotPowerOfTwo = errors.New("the size of the table should be a power of two")
+	ErrTooManyVars   = errors.New("too many variables for the size of the table")
+)
+
+// minChunkSize is the number of entries under which a table is processed by a single go routine
+const minChunkSize = 1 << 10
+
+// Option allows to customize the computations.
+type Option func(*config)
+
+type config struct {
+	nbTasks int
+}
+
+// WithNbTasks sets the maximum number of go routines used. Default is concurrency.MaxTasks(), see ecc.SetNbTasks.
+func WithNbTasks(nbTasks int) Option {
+	if nbTasks < 1 {
+		nbTasks = 1
+	} else if nbTasks > 512 {
+		nbTasks = 512
+	}
+	return func(c *config) {
+		c.nbTasks = nbTasks
+	}
+}
+
+func options(opts []Option) config {
+	cfg := config{nbTasks: concurrency.MaxTasks()}
+	for _, o := range opts {
+		o(&cfg)
+	}
+	return cfg
+}
+
+// execute runs work on [0, n), in parallel if n is large enough
+func (cfg *config) execute(n int, work func(start, end int)) {
+	if n < minChunkSize || cfg.nbTasks == 1 {
+		work(0, n)
+		return
+	}
+	parallel.Execute(n, work, min(cfg.nbTasks, n/minChunkSize))
+}
+
+// NumVars returns the number of variables of the multilinear polynomial given by table.
+func NumVars(table []fr.Element) (int, error) {
+	if len(table) == 0 || len(table)&(len(table)-1) != 0 {
+		return 0, ErrNotPowerOfTwo
+	}
+	return bits.TrailingZeros(uint(len(table))), nil
+}
+
+// FixFirstVariable partially evaluates the multilinear polynomial given by table at X₁ = r:
+//
+//	f(r, b₂, …, bₙ) = f(0, b₂, …, bₙ) + r⋅(f(1, b₂, …, bₙ) - f(0, b₂, …, bₙ))
+//
+// The computation is done in place, and the result is the first half of table.
+func FixFirstVariable(table []fr.Element, r fr.Element, opts ...Option) []fr.Element {
+	cfg := options(opts)
+	mid := len(table) / 2
+	bottom, top := table[:mid], table[mid:]
+	cfg.execute(mid, func(start, end int) {
+		var t fr.Element
+		for i := start; i < end; i++ {
+			t.Sub(&top[i], &bottom[i]).Mul(&t, &r)
+			bottom[i].Add(&bottom[i], &t)
+		}
+	})
+	return bottom
+}
+
+// FixLastVariable partially evaluates the multilinear polynomial given by table at Xₙ = r.
+// The computation is done in place, and the result is the first half of table.
+func FixLastVariable(table []fr.Element, r fr.Element, opts ...Option) []fr.Element {
+	cfg := options(opts)
+	mid := len(table) / 2
+	// entry i is computed from entries 2i and 2i+1, which may belong to another
+	// chunk: the result is written to a separate buffer
+	res := make([]fr.Element, mid)
+	cfg.execute(mid, func(start, end int) {
+		var t fr.Element
+		for i := start; i < end; i++ {
+			t.Sub(&table[2*i+1], &table[2*i]).Mul(&t, &r)
+			res[i].Add(&table[2*i], &t)
+		}
+	})
+	copy(table, res)
+	return table[:mid]
+}
+
+// FixVariables partially evaluates the multilinear polynomial given by table at
+// X₁ = r₁, …, Xₖ = rₖ where k = len(r), and returns the table of the resulting
+// polynomial in Xₖ₊₁, …, Xₙ. table is not modified.
+func FixVariables(table []fr.Element, r []fr.Element, opts ...Option) ([]fr.Element, error) {
+	n, err := NumVars(table)
+	if err != nil {
+		return nil, err
+	}
+	if len(r) > n {
+		return nil, ErrTooManyVars
+	}
+	if len(r) == 0 {
+		res := make([]fr.Element, len(table))
+		copy(res, table)
+		return res, nil
+	}
+
+	// fold the first variable out of place to avoid copying the whole table
+	cfg := options(opts)
+	mid := len(table) / 2
+	res := make([]fr.Element, mid)
+	cfg.execute(mid, func(start, end int) {
+		var t fr.Element
+		for i := start; i < end; i++ {
+			t.Sub(&table[i+mid], &table[i]).Mul(&t, &r[0])
+			res[i].Add(&table[i], &t)
+		}
+	})
+	for i := 1; i < len(r); i++ {
+		res = FixFirstVariable(res, r[i], opts...)
+	}
+	return res, nil
+}
+
+// Evaluate returns the value of the multilinear polynomial given by table at point.
+// table is not modified.
+func Evaluate(table []fr.Element, point []fr.Element, opts ...Option) (fr.Element, error) {
+	n, err := NumVars(table)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	if len(point) != n {
+		return fr.Element{}, errors.New("the number of coordinates should match the number of variables")
+	}
+	res, err := FixVariables(table, point, opts...)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	return res[0], nil
+}
+
+// EqTable returns the table of the polynomial Eq(q, ·) on the hypercube, that is
+//
+//	table[b] = ∏ᵢ (qᵢ bᵢ + (1 - qᵢ)(1 - bᵢ))
+//
+// so that for any multilinear f, f(q) = ∑_b table[b] f(b).
+func EqTable(q []fr.Element, opts ...Option) []fr.Element {
+	cfg := options(opts)
+	n := len(q)
+	table := make([]fr.Element, 1<<n)
+	table[0].SetOne()
+
+	// after step i, table[j << (n-i)] = Eq(q₁, …, qᵢ, b₁, …, bᵢ) where j = (b₁ … bᵢ)₂
+	for i := range q {
+		shift := n - 1 - i
+		cfg.execute(1<<i, func(start, end int) {
+			for j := start; j < end; j++ {
+				j0 := j << (shift + 1) // bᵢ₊₁ = 0
+				j1 := j0 + 1<<shift    // bᵢ₊₁ = 1
+				table[j1].Mul(&q[i], &table[j0])
+				table[j0].Sub(&table[j0], &table[j1])
+			}
+		})
+	}
+	return table
+}
+
+// EvalEq returns Eq(q, h) = ∏ᵢ (qᵢ hᵢ + (1 - qᵢ)(1 - hᵢ)).
+func EvalEq(q, h []fr.Element) fr.Element {
+	var res, t, one fr.Element
+	one.SetOne()
+	res.SetOne()
+	for i := range q {
+		// 1 - qᵢ - hᵢ + 2 qᵢ hᵢ
+		t.Mul(&q[i], &h[i]).Double(&t).Add(&t, &one).Sub(&t, &q[i]).Sub(&t, &h[i])
+		res.Mul(&res, &t)
+	}
+	return res
+}
+
+// InnerProduct returns ∑ᵢ a[i]⋅b[i]; with b = EqTable(q) this is the evaluation of
+// the multilinear polynomial given by a at q.
+func InnerProduct(a, b []fr.Element, opts ...Option) fr.Element {
+	cfg := options(opts)
+	var lock sync.Mutex
+	var res fr.Element
+	cfg.execute(min(len(a), len(b)), func(start, end int) {
+		var sum, t fr.Element
+		for i := start; i < end; i++ {
+			t.Mul(&a[i], &b[i])
+			sum.Add(&sum, &t)
+		}
+		lock.Lock()
+		res.Add(&res, &sum)
+		lock.Unlock()
+	})
+	return res
+}
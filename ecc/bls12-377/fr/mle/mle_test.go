@@ -0,0 +1,111 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package mle
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/polynomial"
+	"github.com/stretchr/testify/require"
+)
+
+func randomTable(size int) []fr.Element {
+	res := make([]fr.Element, size)
+	for i := range res {
+		res[i].SetRandom()
+	}
+	return res
+}
+
+func TestEvaluate(t *testing.T) {
+	// large enough to be processed in parallel
+	const nbVars = 12
+	table := randomTable(1 << nbVars)
+	point := randomTable(nbVars)
+
+	expected := polynomial.MultiLin(table).Evaluate(point, nil)
+	for _, opts := range [][]Option{nil, {WithNbTasks(1)}} {
+		res, err := Evaluate(table, point, opts...)
+		require.NoError(t, err)
+		require.True(t, res.Equal(&expected))
+	}
+
+	// through the eq table
+	res := InnerProduct(table, EqTable(point))
+	require.True(t, res.Equal(&expected))
+
+	_, err := Evaluate(table, point[1:])
+	require.Error(t, err)
+	_, err = Evaluate(table[1:], point)
+	require.ErrorIs(t, err, ErrNotPowerOfTwo)
+}
+
+func TestFixVariables(t *testing.T) {
+	const nbVars = 11
+	table := randomTable(1 << nbVars)
+	point := randomTable(nbVars)
+	expected, err := Evaluate(table, point)
+	require.NoError(t, err)
+
+	// fix the first variables, then the last ones
+	partial, err := FixVariables(table, point[:4])
+	require.NoError(t, err)
+	require.Equal(t, 1<<(nbVars-4), len(partial))
+	for i := nbVars - 1; i >= 4; i-- {
+		partial = FixLastVariable(partial, point[i])
+	}
+	require.Equal(t, 1, len(partial))
+	require.True(t, partial[0].Equal(&expected))
+
+	clone := make([]fr.Element, len(table))
+	copy(clone, table)
+	folded := FixFirstVariable(clone, point[0])
+	expectedFolded, err := FixVariables(table, point[:1])
+	require.NoError(t, err)
+	require.Equal(t, expectedFolded, folded)
+
+	_, err = FixVariables(table, randomTable(nbVars+1))
+	require.ErrorIs(t, err, ErrTooManyVars)
+}
+
+func TestEqTable(t *testing.T) {
+	const nbVars = 11
+	q := randomTable(nbVars)
+	table := EqTable(q)
+
+	expected := make(polynomial.MultiLin, 1<<nbVars)
+	expected[0].SetOne()
+	expected.Eq(q)
+	require.Equal(t, []fr.Element(expected), table)
+
+	h := randomTable(nbVars)
+	eq := EvalEq(q, h)
+	expectedEq := polynomial.EvalEq(q, h)
+	require.True(t, eq.Equal(&expectedEq))
+	res, err := Evaluate(table, h)
+	require.NoError(t, err)
+	require.True(t, res.Equal(&expectedEq))
+}
+
+func BenchmarkEqTable(b *testing.B) {
+	q := randomTable(20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		EqTable(q)
+	}
+}
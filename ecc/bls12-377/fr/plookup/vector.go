@@ -25,6 +25,7 @@ import (
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/grandproduct"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/kzg"
 	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
 )
@@ -60,49 +61,43 @@ type ProofLookupVector struct {
 // * lt is the lookup table
 // * lh1, lh2 is lf sorted by lt split in 2 overlapping slices
 // * beta, gamma are challenges (Schwartz-zippel: they are the random evaluations point)
-func evaluateAccumulationPolynomial(lf, lt, lh1, lh2 []fr.Element, beta, gamma fr.Element) []fr.Element {
-
-	z := make([]fr.Element, len(lt))
+func evaluateAccumulationPolynomial(lf, lt, lh1, lh2 []fr.Element, beta, gamma fr.Element) ([]fr.Element, error) {
 
 	n := len(lt)
-	d := make([]fr.Element, n-1)
-	var u, c fr.Element
+	num := make([]fr.Element, n)
+	den := make([]fr.Element, n)
+	var u, c, e fr.Element
 	c.SetOne().
 		Add(&c, &beta).
 		Mul(&c, &gamma)
+	e.SetOne().Add(&e, &beta)
 	for i := 0; i < n-1; i++ {
 
-		d[i].Mul(&beta, &lh1[i+1]).
-			Add(&d[i], &lh1[i]).
-			Add(&d[i], &c)
+		den[i].Mul(&beta, &lh1[i+1]).
+			Add(&den[i], &lh1[i]).
+			Add(&den[i], &c)
 
 		u.Mul(&beta, &lh2[i+1]).
 			Add(&u, &lh2[i]).
 			Add(&u, &c)
 
-		d[i].Mul(&d[i], &u)
-	}
-	d = fr.BatchInvert(d)
-
-	z[0].SetOne()
-	var a, b, e fr.Element
-	e.SetOne().Add(&e, &beta)
-	for i := 0; i < n-1; i++ {
-
-		a.Add(&gamma, &lf[i])
+		den[i].Mul(&den[i], &u)
 
-		b.Mul(&beta, &lt[i+1]).
-			Add(&b, &lt[i]).
-			Add(&b, &c)
+		num[i].Add(&gamma, &lf[i])
 
-		a.Mul(&a, &b).
-			Mul(&a, &e)
+		u.Mul(&beta, &lt[i+1]).
+			Add(&u, &lt[i]).
+			Add(&u, &c)
 
-		z[i+1].Mul(&z[i], &a).
-			Mul(&z[i+1], &d[i])
+		num[i].Mul(&num[i], &u).
+			Mul(&num[i], &e)
 	}
 
-	return z
+	// the last ratio is not part of Z
+	num[n-1].SetOne()
+	den[n-1].SetOne()
+
+	return grandproduct.Build(num, den)
 }
 
 // evaluateNumBitReversed computes the evaluation (shifted, bit reversed) of h where
@@ -451,7 +446,10 @@ func ProveLookupVector(pk kzg.ProvingKey, f, t fr.Vector) (ProofLookupVector, er
 	}
 
 	// Compute to Z
-	lz := evaluateAccumulationPolynomial(lf, lt, lh1, lh2, beta, gamma)
+	lz, err := evaluateAccumulationPolynomial(lf, lt, lh1, lh2, beta, gamma)
+	if err != nil {
+		return proof, err
+	}
 	cz := make([]fr.Element, len(lz))
 	copy(cz, lz)
 	domainSmall.FFTInverse(cz, fft.DIF)
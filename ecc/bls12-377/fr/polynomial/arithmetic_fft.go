@@ -0,0 +1,98 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
+)
+
+var ErrDomainTooSmall = errors.New("polynomial does not fit in the domain")
+
+// mulFFTThreshold is the output size from which Mul switches from Karatsuba's
+// algorithm to FFT-based multiplication.
+const mulFFTThreshold = 64
+
+// domains caches the FFT domains used for multiplication, indexed by cardinality.
+var domains sync.Map
+
+func getDomain(n uint64) *fft.Domain {
+	if d, ok := domains.Load(n); ok {
+		return d.(*fft.Domain)
+	}
+	d, _ := domains.LoadOrStore(n, fft.NewDomain(n))
+	return d.(*fft.Domain)
+}
+
+// Mul sets p to p1 * p2 and returns p. Large products are computed with FFTs.
+// The result has len(p1) + len(p2) - 1 coefficients.
+func (p *Polynomial) Mul(p1, p2 Polynomial) *Polynomial {
+	if len(p1) == 0 || len(p2) == 0 {
+		*p = Polynomial{}
+		return p
+	}
+	n := len(p1) + len(p2) - 1
+	if n < mulFFTThreshold {
+		*p = mulKaratsuba(p1, p2)
+		return p
+	}
+
+	size := ecc.NextPowerOfTwo(uint64(n))
+	domain := getDomain(size)
+	a := make([]fr.Element, size)
+	b := make([]fr.Element, size)
+	copy(a, p1)
+	copy(b, p2)
+	domain.FFT(a, fft.DIF)
+	domain.FFT(b, fft.DIF)
+	for i := range a {
+		a[i].Mul(&a[i], &b[i])
+	}
+	domain.FFTInverse(a, fft.DIT)
+	*p = a[:n]
+	return p
+}
+
+// EvalOnCoset returns the evaluations of p on the coset g⋅<ω> of the domain, where g is
+// domain.FrMultiplicativeGen, in natural order: the i-th entry is p(g⋅ωⁱ).
+func (p Polynomial) EvalOnCoset(domain *fft.Domain) ([]fr.Element, error) {
+	if uint64(len(p)) > domain.Cardinality {
+		return nil, ErrDomainTooSmall
+	}
+	res := make([]fr.Element, domain.Cardinality)
+	copy(res, p)
+	domain.FFT(res, fft.DIF, fft.OnCoset())
+	fft.BitReverse(res)
+	return res, nil
+}
+
+// InterpolateOnCoset is the inverse of EvalOnCoset: it returns the coefficients of the
+// polynomial of degree < domain.Cardinality taking the given values on the coset g⋅<ω>.
+func InterpolateOnCoset(domain *fft.Domain, evaluations []fr.Element) (Polynomial, error) {
+	if uint64(len(evaluations)) != domain.Cardinality {
+		return nil, ErrLengthMismatch
+	}
+	res := make(Polynomial, len(evaluations))
+	copy(res, evaluations)
+	domain.FFTInverse(res, fft.DIF, fft.OnCoset())
+	fft.BitReverse(res)
+	return res, nil
+}
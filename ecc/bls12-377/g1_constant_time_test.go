@@ -0,0 +1,172 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12377
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestG1AffineScalarMultiplicationConstantTime(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[BLS12-377] ScalarMultiplicationConstantTime should output the same result as ScalarMultiplication", prop.ForAll(
+		func(s, u fr.Element) bool {
+			var sInt, uInt big.Int
+			s.BigInt(&sInt)
+			u.BigInt(&uInt)
+
+			var a, expected, res G1Affine
+			a.ScalarMultiplication(&g1GenAff, &uInt)
+			expected.ScalarMultiplication(&a, &sInt)
+			res.ScalarMultiplicationConstantTime(&a, &s)
+
+			var expectedBase, resBase G1Affine
+			expectedBase.ScalarMultiplicationBase(&sInt)
+			resBase.ScalarMultiplicationBaseConstantTime(&s)
+
+			return res.Equal(&expected) && resBase.Equal(&expectedBase)
+		},
+		GenFr(),
+		GenFr(),
+	))
+
+	properties.Property("[BLS12-377] ScalarMultiplicationConstantTime edge cases", prop.ForAll(
+		func(s fr.Element) bool {
+			var zero, one, minusOne fr.Element
+			one.SetOne()
+			minusOne.Neg(&one)
+
+			var inf, res G1Affine
+			ok := true
+
+			// [s]∞ = ∞
+			res.ScalarMultiplicationConstantTime(&inf, &s)
+			ok = ok && res.IsInfinity()
+
+			// [0]g = ∞
+			res.ScalarMultiplicationBaseConstantTime(&zero)
+			ok = ok && res.IsInfinity()
+
+			// [1]g = g
+			res.ScalarMultiplicationBaseConstantTime(&one)
+			ok = ok && res.Equal(&g1GenAff)
+
+			// [-1]g = -g
+			var neg G1Affine
+			neg.Neg(&g1GenAff)
+			res.ScalarMultiplicationBaseConstantTime(&minusOne)
+			ok = ok && res.Equal(&neg)
+
+			return ok
+		},
+		GenFr(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestCTField(t *testing.T) {
+	t.Parallel()
+
+	var zero, one, minusOne, half, qMinusOne fp.Element
+	one.SetOne()
+	minusOne.Neg(&one)
+	half.SetUint64(2)
+	half.Inverse(&half)
+	// the largest representation, q-1 as raw limbs
+	copy(qMinusOne[:], ctFp.q)
+	qMinusOne[0]--
+	values := []fp.Element{zero, one, minusOne, half, qMinusOne, {1}}
+	for i := 0; i < 16; i++ {
+		var x fp.Element
+		x.SetRandom()
+		values = append(values, x)
+	}
+
+	for i := range values {
+		for j := range values {
+			x, y := &values[i], &values[j]
+			var expected, res fp.Element
+			expected.Add(x, y)
+			ctAdd(&res, x, y)
+			if res != expected {
+				t.Fatalf("ctAdd(%s, %s)", x, y)
+			}
+			expected.Sub(x, y)
+			ctSub(&res, x, y)
+			if res != expected {
+				t.Fatalf("ctSub(%s, %s)", x, y)
+			}
+			expected.Mul(x, y)
+			ctMul(&res, x, y)
+			if res != expected {
+				t.Fatalf("ctMul(%s, %s)", x, y)
+			}
+			// in place
+			res = *x
+			ctMul(&res, &res, &res)
+			expected.Square(x)
+			if res != expected {
+				t.Fatalf("ctMul(%s, %s) in place", x, x)
+			}
+		}
+	}
+
+	// scalars out of the Montgomery form
+	var s, k, rawOne fr.Element
+	rawOne[0] = 1
+	for i := 0; i < 16; i++ {
+		s.SetRandom()
+		ctFr.mul(k[:], s[:], rawOne[:])
+		if expected := s.Bits(); [fr.Limbs]uint64(k) != expected {
+			t.Fatalf("ctFr.mul(%s, 1)", s.String())
+		}
+	}
+}
+
+func BenchmarkG1AffineScalarMultiplicationConstantTime(b *testing.B) {
+	var s fr.Element
+	s.SetRandom()
+	var sInt big.Int
+	s.BigInt(&sInt)
+
+	var p G1Affine
+	b.Run("variable-time", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p.ScalarMultiplication(&g1GenAff, &sInt)
+		}
+	})
+	b.Run("constant-time", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p.ScalarMultiplicationConstantTime(&g1GenAff, &s)
+		}
+	})
+}
@@ -0,0 +1,179 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12377
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+)
+
+// G1ScalarMulTable holds precomputed multiples of a G1 point, for repeated
+// scalar multiplications of the same point with the GLV method and width-w
+// NAF recoding of the two half-size scalars.
+//
+// It is meant for verifiers that multiply the same commitment by many
+// different challenges. A table is safe for concurrent use.
+type G1ScalarMulTable struct {
+	windowBits int
+	// points[i] = [2i+1]base and phiPoints[i] = ϕ(points[i]),
+	// for 0 ⩽ i < 2^(windowBits-2)
+	points, phiPoints []G1Affine
+}
+
+// NewScalarMulTable precomputes the 2^(windowBits-2) odd multiples of p (and
+// their images by the GLV endomorphism) used by G1ScalarMulTable.Mul.
+// windowBits must be in [2, 16]; larger windows trade memory and precomputation
+// for fewer additions per multiplication.
+func (p *G1Affine) NewScalarMulTable(windowBits int) (*G1ScalarMulTable, error) {
+	if windowBits < 2 || windowBits > 16 {
+		return nil, errors.New("windowBits must be in [2, 16]")
+	}
+	t := &G1ScalarMulTable{windowBits: windowBits}
+
+	n := 1 << (windowBits - 2)
+	multiples := make([]G1Jac, n)
+	multiples[0].FromAffine(p)
+	var double G1Jac
+	double.Double(&multiples[0])
+	for i := 1; i < n; i++ {
+		multiples[i].Set(&multiples[i-1]).AddAssign(&double)
+	}
+	t.points = BatchJacobianToAffineG1(multiples)
+
+	t.phiPoints = make([]G1Affine, n)
+	for i := range t.points {
+		t.phiPoints[i].X.Mul(&t.points[i].X, &thirdRootOneG1)
+		t.phiPoints[i].Y = t.points[i].Y
+	}
+
+	return t, nil
+}
+
+// WindowBits returns the width of the NAF used by t.
+func (t *G1ScalarMulTable) WindowBits() int {
+	return t.windowBits
+}
+
+// MulJac returns [s]base in Jacobian coordinates, where base is the point t
+// was built from. s is reduced modulo r.
+func (t *G1ScalarMulTable) MulJac(s *big.Int) G1Jac {
+	var res G1Jac
+	res.Set(&g1Infinity)
+
+	// split the scalar s = k₁ + λ⋅k₂ with k₁, k₂ about half the size of r
+	var e big.Int
+	e.Mod(s, fr.Modulus())
+	k := ecc.SplitScalar(&e, &glvBasis)
+	neg1, neg2 := k[0].Sign() == -1, k[1].Sign() == -1
+	k[0].Abs(&k[0])
+	k[1].Abs(&k[1])
+	digits1 := wnaf(&k[0], t.windowBits)
+	digits2 := wnaf(&k[1], t.windowBits)
+
+	n := len(digits1)
+	if len(digits2) > n {
+		n = len(digits2)
+	}
+	var tmp G1Affine
+	add := func(table []G1Affine, d int, neg bool) {
+		if d == 0 {
+			return
+		}
+		if d < 0 {
+			d, neg = -d, !neg
+		}
+		if neg {
+			tmp.Neg(&table[d>>1])
+			res.AddMixed(&tmp)
+		} else {
+			res.AddMixed(&table[d>>1])
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		res.DoubleAssign()
+		if i < len(digits1) {
+			add(t.points, digits1[i], neg1)
+		}
+		if i < len(digits2) {
+			add(t.phiPoints, digits2[i], neg2)
+		}
+	}
+	return res
+}
+
+// Mul returns [s]base, where base is the point t was built from.
+// s is reduced modulo r.
+func (t *G1ScalarMulTable) Mul(s *big.Int) G1Affine {
+	res := t.MulJac(s)
+	var p G1Affine
+	p.FromJacobian(&res)
+	return p
+}
+
+// wnaf returns the width-w NAF of the non-negative integer s, least
+// significant digit first. The non-zero digits are odd and in
+// (-2^(w-1), 2^(w-1)).
+func wnaf(s *big.Int, w int) []int {
+	// s fits in fr.Limbs+1 words, the extra word absorbs the carries
+	var k [fr.Limbs + 1]uint64
+	for i, word := range s.Bits() {
+		k[i] = uint64(word)
+	}
+	isZero := func() bool {
+		for _, word := range k {
+			if word != 0 {
+				return false
+			}
+		}
+		return true
+	}
+
+	digits := make([]int, 0, s.BitLen()+1)
+	mod := uint64(1) << w
+	for !isZero() {
+		d := 0
+		if k[0]&1 == 1 {
+			u := k[0] & (mod - 1)
+			if u >= mod>>1 {
+				// d = u - 2^w < 0: k = k - d = k + (2^w - u)
+				d = int(u) - int(mod)
+				carry := mod - u
+				for i := range k {
+					k[i] += carry
+					if k[i] >= carry {
+						break
+					}
+					carry = 1
+				}
+			} else {
+				// d = u > 0: k = k - u, which only clears low bits of k[0]
+				d = int(u)
+				k[0] -= u
+			}
+		}
+		digits = append(digits, d)
+		// k >>= 1
+		for i := 0; i < len(k)-1; i++ {
+			k[i] = k[i]>>1 | k[i+1]<<63
+		}
+		k[len(k)-1] >>= 1
+	}
+	return digits
+}
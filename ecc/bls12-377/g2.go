@@ -18,12 +18,16 @@ package bls12377
 
 import (
 	"crypto/rand"
+	"encoding/binary"
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/internal/fptower"
 	"github.com/consensys/gnark-crypto/internal/parallel"
 	"math/big"
-	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/consensys/gnark-crypto/utils/concurrency"
 )
 
 // G2Affine is a point in affine coordinates (x,y)
@@ -1001,6 +1005,83 @@ func (p *g2Proj) FromAffine(a *G2Affine) *g2Proj {
 	return p
 }
 
+// BatchJacobianToAffineG2 converts points in Jacobian coordinates to Affine coordinates
+// performing a single field inversion using the Montgomery batch inversion trick.
+func BatchJacobianToAffineG2(points []G2Jac) []G2Affine {
+	return BatchJacobianToAffineG2Into(nil, points)
+}
+
+// BatchJacobianToAffineG2Into is like BatchJacobianToAffineG2, but writes the result in dst,
+// which is reallocated only if its capacity is smaller than len(points). It returns dst[:len(points)].
+//
+// dst must not overlap with points.
+func BatchJacobianToAffineG2Into(dst []G2Affine, points []G2Jac) []G2Affine {
+	if cap(dst) < len(points) {
+		dst = make([]G2Affine, len(points))
+	}
+	result := dst[:len(points)]
+	var accumulator fptower.E2
+	accumulator.SetOne()
+
+	// batch invert all points[].Z coordinates with Montgomery batch inversion trick
+	// (stores points[].Z^-1 in result[i].X to avoid allocating a slice of fr.Elements)
+	for i := 0; i < len(points); i++ {
+		if points[i].Z.IsZero() {
+			continue
+		}
+		result[i].X = accumulator
+		accumulator.Mul(&accumulator, &points[i].Z)
+	}
+
+	var accInverse fptower.E2
+	accInverse.Inverse(&accumulator)
+
+	for i := len(points) - 1; i >= 0; i-- {
+		if points[i].Z.IsZero() {
+			continue
+		}
+		result[i].X.Mul(&result[i].X, &accInverse)
+		accInverse.Mul(&accInverse, &points[i].Z)
+	}
+
+	// batch convert to affine.
+	parallel.Execute(len(points), func(start, end int) {
+		for i := start; i < end; i++ {
+			if points[i].Z.IsZero() {
+				// (X=0, Y=0) is infinity point in affine
+				result[i].setInfinity()
+				continue
+			}
+			var a, b fptower.E2
+			a = result[i].X
+			b.Square(&a)
+			result[i].X.Mul(&points[i].X, &b)
+			result[i].Y.Mul(&points[i].Y, &b).
+				Mul(&result[i].Y, &a)
+		}
+	})
+
+	return result
+}
+
+// batchJacobianToAffineG2Pool holds the buffers used by BatchJacobianToAffineG2Pooled.
+var batchJacobianToAffineG2Pool = sync.Pool{
+	New: func() any {
+		return new([]G2Affine)
+	},
+}
+
+// BatchJacobianToAffineG2Pooled is like BatchJacobianToAffineG2, but the result is
+// backed by a buffer taken from a pool. The caller must call release once it
+// no longer uses the result.
+func BatchJacobianToAffineG2Pooled(points []G2Jac) (result []G2Affine, release func()) {
+	buf := batchJacobianToAffineG2Pool.Get().(*[]G2Affine)
+	*buf = BatchJacobianToAffineG2Into(*buf, points)
+	return *buf, func() {
+		batchJacobianToAffineG2Pool.Put(buf)
+	}
+}
+
 // BatchScalarMultiplicationG2 multiplies the same base by all scalars
 // and return resulting points in affine coordinates
 // uses a simple windowed-NAF-like multiplication algorithm.
@@ -1042,7 +1123,7 @@ func BatchScalarMultiplicationG2(base *G2Affine, scalars []fr.Element) []G2Affin
 	toReturn := make([]G2Affine, len(scalars))
 
 	// partition the scalars into digits
-	digits, _ := partitionScalars(scalars, c, runtime.NumCPU())
+	digits, _ := partitionScalars(scalars, c, concurrency.MaxTasks())
 
 	// for each digit, take value in the base table, double it c time, voilà.
 	parallel.Execute(len(scalars), func(start, end int) {
@@ -1148,3 +1229,61 @@ func randomFrSizedBytes() ([]byte, error) {
 	_, err := rand.Read(res)
 	return res, err
 }
+
+// BatchIsInSubGroupG2 returns true if all the points are on the curve and
+// in the r-torsion subgroup, false otherwise.
+//
+// The points are checked to be on the curve one by one. Instead of running a subgroup
+// check per point, it then checks that batchSubGroupCheckRounds sums of random subsets
+// of the points are in the subgroup. If a point is not in the subgroup, each sum is
+// outside the subgroup with probability at least 1/2, so an invalid batch is accepted
+// with probability at most 2⁻⁶⁴.
+//
+// Small batches, for which this is not worth it, are checked point by point.
+func BatchIsInSubGroupG2(points []G2Affine) bool {
+	var invalid atomic.Bool
+	if len(points) <= 2*batchSubGroupCheckRounds {
+		parallel.Execute(len(points), func(start, end int) {
+			for i := start; i < end && !invalid.Load(); i++ {
+				if !points[i].IsInSubGroup() {
+					invalid.Store(true)
+				}
+			}
+		})
+		return !invalid.Load()
+	}
+
+	// bit j of masks[i] tells if the point i is in the j-th subset
+	randomness := make([]byte, 8*len(points))
+	if _, err := rand.Read(randomness); err != nil {
+		panic(err)
+	}
+	masks := make([]uint64, len(points))
+	parallel.Execute(len(points), func(start, end int) {
+		for i := start; i < end && !invalid.Load(); i++ {
+			if !points[i].IsOnCurve() {
+				invalid.Store(true)
+			}
+			masks[i] = binary.LittleEndian.Uint64(randomness[8*i:])
+		}
+	})
+	if invalid.Load() {
+		return false
+	}
+
+	parallel.Execute(batchSubGroupCheckRounds, func(start, end int) {
+		var sum G2Jac
+		for j := start; j < end && !invalid.Load(); j++ {
+			sum.Set(&g2Infinity)
+			for i := range points {
+				if masks[i]>>j&1 == 1 {
+					sum.AddMixed(&points[i])
+				}
+			}
+			if !sum.IsInSubGroup() {
+				invalid.Store(true)
+			}
+		}
+	})
+	return !invalid.Load()
+}
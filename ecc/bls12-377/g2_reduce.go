@@ -0,0 +1,121 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12377
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// SortG2Affine sorts points in canonical order, that is by increasing uncompressed
+// encoding (see RawBytes). The order doesn't depend on the input order, so it can be used
+// to agree on a list of public keys or commitments.
+func SortG2Affine(points []G2Affine) {
+	keys := g2SortKeys(points)
+	sort.Sort(&g2Sorter{points: points, keys: keys})
+}
+
+// DeduplicateG2Affine returns the distinct points of the input, in canonical order
+// (see SortG2Affine). The input is not modified.
+func DeduplicateG2Affine(points []G2Affine) []G2Affine {
+	res := make([]G2Affine, len(points))
+	copy(res, points)
+	keys := g2SortKeys(res)
+	sort.Sort(&g2Sorter{points: res, keys: keys})
+
+	n := 0
+	for i := range res {
+		if i > 0 && keys[i] == keys[i-1] {
+			continue
+		}
+		res[n] = res[i]
+		n++
+	}
+	return res[:n]
+}
+
+// ReduceG2AffineMSM reduces the multi-scalar multiplication instance ∑ sᵢ⋅Pᵢ: the
+// returned points are distinct and in canonical order (see SortG2Affine), the scalars
+// of duplicate points are summed, and the terms with a zero scalar or a point at infinity
+// are removed. The reduced instance has the same result, and can be passed to MultiExp.
+// The inputs are not modified.
+func ReduceG2AffineMSM(points []G2Affine, scalars []fr.Element) ([]G2Affine, []fr.Element, error) {
+	if len(points) != len(scalars) {
+		return nil, nil, errors.New("len(points) != len(scalars)")
+	}
+	resPoints := make([]G2Affine, len(points))
+	resScalars := make([]fr.Element, len(scalars))
+	copy(resPoints, points)
+	copy(resScalars, scalars)
+	keys := g2SortKeys(resPoints)
+	sort.Sort(&g2Sorter{points: resPoints, keys: keys, scalars: resScalars})
+
+	n := 0
+	for i := 0; i < len(resPoints); {
+		// sum the scalars of the run of equal points starting at i
+		s := resScalars[i]
+		j := i + 1
+		for ; j < len(resPoints) && keys[j] == keys[i]; j++ {
+			s.Add(&s, &resScalars[j])
+		}
+		if !s.IsZero() && !resPoints[i].IsInfinity() {
+			resPoints[n] = resPoints[i]
+			resScalars[n] = s
+			n++
+		}
+		i = j
+	}
+	return resPoints[:n], resScalars[:n], nil
+}
+
+// g2SortKeys returns the uncompressed encodings of the points, computed in parallel
+func g2SortKeys(points []G2Affine) [][SizeOfG2AffineUncompressed]byte {
+	keys := make([][SizeOfG2AffineUncompressed]byte, len(points))
+	parallel.Execute(len(points), func(start, end int) {
+		for i := start; i < end; i++ {
+			keys[i] = points[i].RawBytes()
+		}
+	})
+	return keys
+}
+
+// g2Sorter sorts points (and optionally scalars) by key
+type g2Sorter struct {
+	points  []G2Affine
+	keys    [][SizeOfG2AffineUncompressed]byte
+	scalars []fr.Element
+}
+
+func (s *g2Sorter) Len() int {
+	return len(s.points)
+}
+
+func (s *g2Sorter) Less(i, j int) bool {
+	return bytes.Compare(s.keys[i][:], s.keys[j][:]) < 0
+}
+
+func (s *g2Sorter) Swap(i, j int) {
+	s.points[i], s.points[j] = s.points[j], s.points[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	if s.scalars != nil {
+		s.scalars[i], s.scalars[j] = s.scalars[j], s.scalars[i]
+	}
+}
@@ -236,6 +236,20 @@ func TestG2AffineConversions(t *testing.T) {
 		GenE2(),
 	))
 
+	properties.Property("[BLS12-377] BatchJacobianToAffineG2 and FromJacobian should output the same result", prop.ForAll(
+		func(a, b fptower.E2) bool {
+			p1 := fuzzG2Jac(&g2Gen, a)
+			p2 := fuzzG2Jac(&g2Gen, b)
+			var op1, op2 G2Affine
+			op1.FromJacobian(&p1)
+			op2.FromJacobian(&p2)
+			baseTableAff := BatchJacobianToAffineG2([]G2Jac{p1, p2})
+			return op1.Equal(&baseTableAff[0]) && op2.Equal(&baseTableAff[1])
+		},
+		GenE2(),
+		GenE2(),
+	))
+
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
@@ -538,6 +552,59 @@ func TestG2AffineCofactorCleaning(t *testing.T) {
 
 }
 
+func TestBatchJacobianToAffineG2Into(t *testing.T) {
+	t.Parallel()
+
+	const nbPoints = 16
+	points := make([]G2Jac, nbPoints)
+	expected := make([]G2Affine, nbPoints)
+	for i := range points {
+		var s fr.Element
+		s.SetRandom()
+		points[i].ScalarMultiplication(&g2Gen, s.BigInt(new(big.Int)))
+	}
+	points[3].Set(&g2Infinity)
+	for i := range points {
+		expected[i].FromJacobian(&points[i])
+	}
+
+	check := func(name string, got, want []G2Affine) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("%s: expected %d points, got %d", name, len(want), len(got))
+		}
+		for i := range want {
+			if !got[i].Equal(&want[i]) {
+				t.Fatalf("%s: point %d mismatch", name, i)
+			}
+		}
+	}
+
+	// dst too small: reallocated
+	dst := make([]G2Affine, 0, 2)
+	dst = BatchJacobianToAffineG2Into(dst, points)
+	check("reallocated", dst, expected)
+
+	// dst reused, with stale content
+	ptr := &dst[0]
+	points[0], points[3] = points[3], points[0]
+	expected[0], expected[3] = expected[3], expected[0]
+	dst = BatchJacobianToAffineG2Into(dst, points)
+	check("reused", dst, expected)
+	if ptr != &dst[0] {
+		t.Fatal("dst should be reused")
+	}
+
+	// shorter input
+	dst = BatchJacobianToAffineG2Into(dst, points[:4])
+	check("shorter", dst, expected[:4])
+
+	// pooled
+	res, release := BatchJacobianToAffineG2Pooled(points)
+	check("pooled", res, expected)
+	release()
+}
+
 func TestG2AffineBatchScalarMultiplication(t *testing.T) {
 
 	parameters := gopter.DefaultTestParameters()
@@ -588,6 +655,87 @@ func TestG2AffineBatchScalarMultiplication(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestG2AffineBatchIsInSubGroup(t *testing.T) {
+	t.Parallel()
+
+	// small batches are checked point by point, larger ones with random subset sums
+	for _, n := range []int{10, 300} {
+		jac := make([]G2Jac, n)
+		jac[0].Set(&g2Gen)
+		for i := 1; i < n; i++ {
+			jac[i].Set(&jac[i-1]).AddAssign(&g2Gen)
+		}
+		points := make([]G2Affine, n)
+		for i := range points {
+			points[i].FromJacobian(&jac[i])
+		}
+		points[n/2].X.SetZero()
+		points[n/2].Y.SetZero()
+		if !BatchIsInSubGroupG2(points) {
+			t.Fatalf("n=%d: valid points rejected", n)
+		}
+
+		// a point off the curve
+		invalid := make([]G2Affine, n)
+		copy(invalid, points)
+		invalid[n/4].Y.Double(&invalid[n/4].Y)
+		if BatchIsInSubGroupG2(invalid) {
+			t.Fatalf("n=%d: point off the curve accepted", n)
+		}
+
+		// a point on the curve, outside the subgroup
+		copy(invalid, points)
+		invalid[n/3] = randomNotInSubGroupG2Affine()
+		if BatchIsInSubGroupG2(invalid) {
+			t.Fatalf("n=%d: point outside the subgroup accepted", n)
+		}
+	}
+}
+
+// randomNotInSubGroupG2Affine returns a random point on the curve, outside the r-torsion
+func randomNotInSubGroupG2Affine() G2Affine {
+	var p G2Affine
+	var ySquared fptower.E2
+	for {
+		p.X.SetRandom()
+		ySquared.Square(&p.X).Mul(&ySquared, &p.X)
+		ySquared.Add(&ySquared, &bTwistCurveCoeff)
+		if ySquared.Legendre() != 1 {
+			continue
+		}
+		p.Y.Sqrt(&ySquared)
+		if !p.IsInSubGroup() {
+			return p
+		}
+	}
+}
+
+func BenchmarkG2AffineBatchIsInSubGroup(b *testing.B) {
+	const n = 1 << 10
+	jac := make([]G2Jac, n)
+	jac[0].Set(&g2Gen)
+	for i := 1; i < n; i++ {
+		jac[i].Set(&jac[i-1]).AddAssign(&g2Gen)
+	}
+	points := make([]G2Affine, n)
+	for i := range points {
+		points[i].FromJacobian(&jac[i])
+	}
+
+	b.Run("batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			BatchIsInSubGroupG2(points)
+		}
+	})
+	b.Run("individual", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range points {
+				points[j].IsInSubGroup()
+			}
+		}
+	})
+}
+
 // ------------------------------------------------------------
 // benches
 
@@ -673,6 +821,36 @@ func BenchmarkBatchAddG2Affine(b *testing.B) {
 	}
 }
 
+func BenchmarkBatchJacobianToAffineG2(b *testing.B) {
+	const nbPoints = 1 << 10
+	points := make([]G2Jac, nbPoints)
+	points[0].Set(&g2Gen)
+	for i := 1; i < nbPoints; i++ {
+		points[i].Set(&points[i-1]).AddAssign(&g2Gen)
+	}
+
+	b.Run("alloc", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = BatchJacobianToAffineG2(points)
+		}
+	})
+	b.Run("into", func(b *testing.B) {
+		b.ReportAllocs()
+		var dst []G2Affine
+		for i := 0; i < b.N; i++ {
+			dst = BatchJacobianToAffineG2Into(dst, points)
+		}
+	})
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, release := BatchJacobianToAffineG2Pooled(points)
+			release()
+		}
+	})
+}
+
 func BenchmarkG2AffineBatchScalarMultiplication(b *testing.B) {
 	// ensure every words of the scalars are filled
 	var mixer fr.Element
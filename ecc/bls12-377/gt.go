@@ -0,0 +1,58 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12377
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/internal/fptower"
+)
+
+// SizeOfGTCompressed represents the size in bytes that a torus-compressed GT element needs in binary form
+const SizeOfGTCompressed = fptower.SizeOfGTCompressed
+
+// BatchInvertGT returns the inverses of the elements of a, using a single field inversion.
+// Zero elements are left unchanged.
+func BatchInvertGT(a []GT) []GT {
+	return fptower.BatchInvertE12(a)
+}
+
+// BatchCompressGT returns the concatenated torus-compressed encodings of a,
+// using a single field inversion. Each encoding is SizeOfGTCompressed bytes,
+// half the size of GT.Bytes().
+//
+// See GT.BytesCompressed.
+func BatchCompressGT(a []GT) ([]byte, error) {
+	return fptower.BatchBytesCompressed(a)
+}
+
+// BatchDecompressGT decodes the concatenated torus-compressed GT elements in buf,
+// using a single field inversion. It returns an error if any of the elements is
+// not in GT.
+//
+// See GT.SetBytesCompressed.
+func BatchDecompressGT(buf []byte) ([]GT, error) {
+	return fptower.BatchSetBytesCompressed(buf)
+}
+
+// GTFixedBaseTable holds precomputed powers of a fixed GT element,
+// for use with GT.ExpFixedBase.
+type GTFixedBaseTable = fptower.FixedBaseTable
+
+// NewGTFixedBaseTable precomputes the powers of base needed by GT.ExpFixedBase.
+// base must be in GT.
+func NewGTFixedBaseTable(base *GT) *GTFixedBaseTable {
+	return fptower.NewFixedBaseTable(base)
+}
@@ -17,6 +17,7 @@
 package kzg
 
 import (
+	"crypto/sha256"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377"
 	"io"
 
@@ -52,6 +53,25 @@ func (vk *VerifyingKey) WriteTo(w io.Writer) (int64, error) {
 	return vk.writeTo(w)
 }
 
+// Fingerprint returns a constant-size digest identifying the verifying key, suitable as a cache key
+// for verifier objects or to detect parameter drift between provers and verifiers.
+//
+// It is computed over the compressed encodings of G₁, G₂ and [α]G₂ only (the precomputed lines
+// are derived from them), so it is stable across versions of the serialization format.
+func (vk *VerifyingKey) Fingerprint() [sha256.Size]byte {
+	h := sha256.New()
+	h.Write([]byte("gnark-crypto/bls12-377/kzg/vk/v1"))
+	g1 := vk.G1.Bytes()
+	h.Write(g1[:])
+	for i := range vk.G2 {
+		g2 := vk.G2[i].Bytes()
+		h.Write(g2[:])
+	}
+	var res [sha256.Size]byte
+	h.Sum(res[:0])
+	return res
+}
+
 func (vk *VerifyingKey) writeTo(w io.Writer, options ...func(*bls12377.Encoder)) (int64, error) {
 	// encode the VerifyingKey
 	enc := bls12377.NewEncoder(w, options...)
@@ -255,6 +275,82 @@ func (proof *OpeningProof) ReadFrom(r io.Reader) (int64, error) {
 	return dec.BytesRead(), nil
 }
 
+// WriteTo writes binary encoding of the entire SRSG2
+func (srs *SRSG2) WriteTo(w io.Writer) (int64, error) {
+	enc := bls12377.NewEncoder(w)
+
+	toEncode := []interface{}{
+		srs.Pk.G2,
+		&srs.Vk.G1[0],
+		&srs.Vk.G1[1],
+		&srs.Vk.G2,
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes SRSG2 data from reader.
+func (srs *SRSG2) ReadFrom(r io.Reader) (int64, error) {
+	dec := bls12377.NewDecoder(r)
+
+	toDecode := []interface{}{
+		&srs.Pk.G2,
+		&srs.Vk.G1[0],
+		&srs.Vk.G1[1],
+		&srs.Vk.G2,
+	}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+
+	return dec.BytesRead(), nil
+}
+
+// WriteTo writes binary encoding of a OpeningProofG2
+func (proof *OpeningProofG2) WriteTo(w io.Writer) (int64, error) {
+	enc := bls12377.NewEncoder(w)
+
+	toEncode := []interface{}{
+		&proof.H,
+		&proof.ClaimedValue,
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes OpeningProofG2 data from reader.
+func (proof *OpeningProofG2) ReadFrom(r io.Reader) (int64, error) {
+	dec := bls12377.NewDecoder(r)
+
+	toDecode := []interface{}{
+		&proof.H,
+		&proof.ClaimedValue,
+	}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+
+	return dec.BytesRead(), nil
+}
+
 // WriteTo writes binary encoding of a BatchOpeningProof
 func (proof *BatchOpeningProof) WriteTo(w io.Writer) (int64, error) {
 	enc := bls12377.NewEncoder(w)
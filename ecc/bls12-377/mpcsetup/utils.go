@@ -0,0 +1,124 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package mpcsetup
+
+import (
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// powers returns [1, a, a², …, aⁿ⁻¹]
+func powers(a *fr.Element, n int) []fr.Element {
+	res := make([]fr.Element, n)
+	res[0].SetOne()
+	for i := 1; i < n; i++ {
+		res[i].Mul(&res[i-1], a)
+	}
+	return res
+}
+
+// scaleG1InPlace sets A[i] to [a[i]]A[i]
+func scaleG1InPlace(A []bls12377.G1Affine, a []fr.Element) {
+	parallel.Execute(len(A), func(start, end int) {
+		var b big.Int
+		for i := start; i < end; i++ {
+			a[i].BigInt(&b)
+			A[i].ScalarMultiplication(&A[i], &b)
+		}
+	})
+}
+
+// scaleG2InPlace sets A[i] to [a[i]]A[i]
+func scaleG2InPlace(A []bls12377.G2Affine, a []fr.Element) {
+	parallel.Execute(len(A), func(start, end int) {
+		var b big.Int
+		for i := start; i < end; i++ {
+			a[i].BigInt(&b)
+			A[i].ScalarMultiplication(&A[i], &b)
+		}
+	})
+}
+
+// randomElements returns n non zero elements drawn from rand
+func randomElements(rand io.Reader, n int) ([]fr.Element, error) {
+	res := make([]fr.Element, n)
+	buf := make([]byte, fr.Bytes+8) // the bias of the reduction is negligible
+	var b big.Int
+	for i := range res {
+		for res[i].IsZero() {
+			if _, err := io.ReadFull(rand, buf); err != nil {
+				return nil, err
+			}
+			res[i].SetBigInt(b.SetBytes(buf))
+		}
+	}
+	return res, nil
+}
+
+// sameRatio returns true if b₁/a₁ = b₂/a₂, that is e(a₁, b₂) = e(b₁, a₂)
+func sameRatio(a1, b1 *bls12377.G1Affine, a2, b2 *bls12377.G2Affine) bool {
+	var na1 bls12377.G1Affine
+	na1.Neg(a1)
+	ok, err := bls12377.PairingCheck([]bls12377.G1Affine{na1, *b1}, []bls12377.G2Affine{*b2, *a2})
+	return err == nil && ok
+}
+
+// linearCombinationG1 returns ∑ rᵢAᵢ and ∑ rᵢAᵢ₊₁ for random rᵢ, i < len(A)-1. If A
+// are consecutive powers, the second is the first one times their ratio.
+func linearCombinationG1(A []bls12377.G1Affine) (l1, l2 bls12377.G1Affine, err error) {
+	r, err := linearCombinationCoefficients(len(A) - 1)
+	if err != nil {
+		return
+	}
+	config := ecc.MultiExpConfig{}
+	if _, err = l1.MultiExp(A[:len(A)-1], r, config); err != nil {
+		return
+	}
+	_, err = l2.MultiExp(A[1:], r, config)
+	return
+}
+
+// linearCombinationG2 returns ∑ rᵢAᵢ and ∑ rᵢAᵢ₊₁ for random rᵢ, i < len(A)-1. If A
+// are consecutive powers, the second is the first one times their ratio.
+func linearCombinationG2(A []bls12377.G2Affine) (l1, l2 bls12377.G2Affine, err error) {
+	r, err := linearCombinationCoefficients(len(A) - 1)
+	if err != nil {
+		return
+	}
+	config := ecc.MultiExpConfig{}
+	if _, err = l1.MultiExp(A[:len(A)-1], r, config); err != nil {
+		return
+	}
+	_, err = l2.MultiExp(A[1:], r, config)
+	return
+}
+
+// linearCombinationCoefficients returns n random elements
+func linearCombinationCoefficients(n int) ([]fr.Element, error) {
+	r := make([]fr.Element, n)
+	for i := range r {
+		if _, err := r[i].SetRandom(); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
@@ -21,8 +21,10 @@ import (
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/gnark-crypto/internal/parallel"
+	"github.com/consensys/gnark-crypto/utils/concurrency"
 	"math"
 	"runtime"
+	"time"
 )
 
 // MultiExp implements section 4 of https://eprint.iacr.org/2012/549.pdf
@@ -74,25 +76,41 @@ func (p *G1Jac) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.Mul
 		return nil, errors.New("len(points) != len(scalars)")
 	}
 
-	// if nbTasks is not set, use all available CPUs
+	// if nbTasks is not set, use all available CPUs, unless a limit was set with ecc.SetNbTasks
 	if config.NbTasks <= 0 {
-		config.NbTasks = runtime.NumCPU() * 2
+		if concurrency.IsLimited() {
+			config.NbTasks = concurrency.MaxTasks()
+		} else {
+			config.NbTasks = runtime.NumCPU() * 2
+		}
 	} else if config.NbTasks > 1024 {
 		return nil, errors.New("invalid config: config.NbTasks > 1024")
 	}
+	if config.C != 0 && !isImplementedCG1(config.C) {
+		return nil, errors.New("invalid config: config.C is not an implemented window size")
+	}
+	if config.BatchAffine > ecc.BatchAffineOff {
+		return nil, errors.New("invalid config: unknown config.BatchAffine mode")
+	}
 
 	// here, we compute the best C for nbPoints
 	// we split recursively until nbChunks(c) >= nbTasks,
 	bestC := func(nbPoints int) uint64 {
-		// implemented msmC methods (the c we use must be in this slice)
-		implementedCs := []uint64{4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+		// window size set by the caller
+		if config.C != 0 {
+			return config.C
+		}
+		// window size measured on this machine, see TuneMultiExp
+		if c, ok := ecc.MSMWindowSize(ecc.BLS12_377, "G1", nbPoints); ok && isImplementedCG1(c) {
+			return c
+		}
 		var C uint64
 		// approximate cost (in group operations)
 		// cost = bits/c * (nbPoints + 2^{c})
 		// this needs to be verified empirically.
 		// for example, on a MBP 2016, for G2 MultiExp > 8M points, hand picking c gives better results
 		min := math.MaxFloat64
-		for _, c := range implementedCs {
+		for _, c := range implementedCsG1 {
 			cc := (fr.Bits + 1) * (nbPoints + (1 << c))
 			cost := float64(cc) / float64(c)
 			if cost < min {
@@ -156,6 +174,65 @@ func (p *G1Jac) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.Mul
 	return p, nil
 }
 
+// implemented msmC methods (the c we use must be in this slice)
+var implementedCsG1 = []uint64{4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+
+func isImplementedCG1(c uint64) bool {
+	for _, ic := range implementedCsG1 {
+		if ic == c {
+			return true
+		}
+	}
+	return false
+}
+
+// calibrateMultiExpG1 returns the window sizes of the fastest MultiExp in G1
+// of msmCalibrationMinPoints, 2*msmCalibrationMinPoints, … up to the next power of two
+// of maxNbPoints points.
+func calibrateMultiExpG1(maxNbPoints int) []ecc.MSMWindow {
+	if maxNbPoints < msmCalibrationMinPoints {
+		maxNbPoints = msmCalibrationMinPoints
+	}
+	maxNbPoints = int(ecc.NextPowerOfTwo(uint64(maxNbPoints)))
+	var config ecc.MultiExpConfig
+	if concurrency.IsLimited() {
+		config.NbTasks = concurrency.MaxTasks()
+	} else {
+		config.NbTasks = runtime.NumCPU() * 2
+	}
+
+	scalars := make([]fr.Element, maxNbPoints)
+	for i := range scalars {
+		scalars[i].SetRandom()
+	}
+	points := BatchScalarMultiplicationG1(&g1GenAff, scalars)
+	for i := range scalars {
+		scalars[i].SetRandom()
+	}
+
+	var res []ecc.MSMWindow
+	for n := msmCalibrationMinPoints; n <= maxNbPoints; n *= 2 {
+		best := ecc.MSMWindow{NbPoints: n}
+		var bestTime time.Duration
+		for _, c := range implementedCsG1 {
+			// past 2n buckets, the bucket reduction dominates
+			if best.C != 0 && 1<<c > 2*n {
+				break
+			}
+			for i := 0; i < msmCalibrationRuns; i++ {
+				var p G1Jac
+				start := time.Now()
+				_innerMsmG1(&p, c, points[:n], scalars[:n], config)
+				if t := time.Since(start); best.C == 0 || t < bestTime {
+					best.C, bestTime = c, t
+				}
+			}
+		}
+		res = append(res, best)
+	}
+	return res
+}
+
 func _innerMsmG1(p *G1Jac, c uint64, points []G1Affine, scalars []fr.Element, config ecc.MultiExpConfig) *G1Jac {
 	// partition the scalars
 	digits, chunkStats := partitionScalars(scalars, c, config.NbTasks)
@@ -189,9 +266,9 @@ func _innerMsmG1(p *G1Jac, c uint64, points []G1Affine, scalars []fr.Element, co
 	// the last chunk may be processed with a different method than the rest, as it could be smaller.
 	n := len(points)
 	for j := int(nbChunks - 1); j >= 0; j-- {
-		processChunk := getChunkProcessorG1(c, chunkStats[j])
+		processChunk := getChunkProcessorG1(c, chunkStats[j], config.BatchAffine)
 		if j == int(nbChunks-1) {
-			processChunk = getChunkProcessorG1(lastC(c), chunkStats[j])
+			processChunk = getChunkProcessorG1(lastC(c), chunkStats[j], config.BatchAffine)
 		}
 		if chunkStats[j].weight >= 115 {
 			// we split this in more go routines since this chunk has more work to do than the others.
@@ -219,9 +296,9 @@ func _innerMsmG1(p *G1Jac, c uint64, points []G1Affine, scalars []fr.Element, co
 	return msmReduceChunkG1Affine(p, int(c), chChunks[:])
 }
 
-// getChunkProcessorG1 decides, depending on c window size and statistics for the chunk
-// to return the best algorithm to process the chunk.
-func getChunkProcessorG1(c uint64, stat chunkStat) func(chunkID uint64, chRes chan<- g1JacExtended, c uint64, points []G1Affine, digits []uint16, sem chan struct{}) {
+// getChunkProcessorG1 decides, depending on c window size, statistics for the chunk
+// and the batch affine mode of the config, to return the best algorithm to process the chunk.
+func getChunkProcessorG1(c uint64, stat chunkStat, mode ecc.BatchAffineMode) func(chunkID uint64, chRes chan<- g1JacExtended, c uint64, points []G1Affine, digits []uint16, sem chan struct{}) {
 	switch c {
 
 	case 2:
@@ -242,7 +319,7 @@ func getChunkProcessorG1(c uint64, stat chunkStat) func(chunkID uint64, chRes ch
 		const batchSize = 80
 		// here we could check some chunk statistic (deviation, ...) to determine if calling
 		// the batch affine version is worth it.
-		if stat.nbBucketFilled < batchSize {
+		if mode == ecc.BatchAffineOff || (mode == ecc.BatchAffineAuto && stat.nbBucketFilled < batchSize) {
 			// clear indicator that batch affine method is not appropriate here.
 			return processChunkG1Jacobian[bucketg1JacExtendedC10]
 		}
@@ -251,7 +328,7 @@ func getChunkProcessorG1(c uint64, stat chunkStat) func(chunkID uint64, chRes ch
 		const batchSize = 150
 		// here we could check some chunk statistic (deviation, ...) to determine if calling
 		// the batch affine version is worth it.
-		if stat.nbBucketFilled < batchSize {
+		if mode == ecc.BatchAffineOff || (mode == ecc.BatchAffineAuto && stat.nbBucketFilled < batchSize) {
 			// clear indicator that batch affine method is not appropriate here.
 			return processChunkG1Jacobian[bucketg1JacExtendedC11]
 		}
@@ -260,7 +337,7 @@ func getChunkProcessorG1(c uint64, stat chunkStat) func(chunkID uint64, chRes ch
 		const batchSize = 200
 		// here we could check some chunk statistic (deviation, ...) to determine if calling
 		// the batch affine version is worth it.
-		if stat.nbBucketFilled < batchSize {
+		if mode == ecc.BatchAffineOff || (mode == ecc.BatchAffineAuto && stat.nbBucketFilled < batchSize) {
 			// clear indicator that batch affine method is not appropriate here.
 			return processChunkG1Jacobian[bucketg1JacExtendedC12]
 		}
@@ -269,7 +346,7 @@ func getChunkProcessorG1(c uint64, stat chunkStat) func(chunkID uint64, chRes ch
 		const batchSize = 350
 		// here we could check some chunk statistic (deviation, ...) to determine if calling
 		// the batch affine version is worth it.
-		if stat.nbBucketFilled < batchSize {
+		if mode == ecc.BatchAffineOff || (mode == ecc.BatchAffineAuto && stat.nbBucketFilled < batchSize) {
 			// clear indicator that batch affine method is not appropriate here.
 			return processChunkG1Jacobian[bucketg1JacExtendedC13]
 		}
@@ -278,7 +355,7 @@ func getChunkProcessorG1(c uint64, stat chunkStat) func(chunkID uint64, chRes ch
 		const batchSize = 400
 		// here we could check some chunk statistic (deviation, ...) to determine if calling
 		// the batch affine version is worth it.
-		if stat.nbBucketFilled < batchSize {
+		if mode == ecc.BatchAffineOff || (mode == ecc.BatchAffineAuto && stat.nbBucketFilled < batchSize) {
 			// clear indicator that batch affine method is not appropriate here.
 			return processChunkG1Jacobian[bucketg1JacExtendedC14]
 		}
@@ -287,7 +364,7 @@ func getChunkProcessorG1(c uint64, stat chunkStat) func(chunkID uint64, chRes ch
 		const batchSize = 500
 		// here we could check some chunk statistic (deviation, ...) to determine if calling
 		// the batch affine version is worth it.
-		if stat.nbBucketFilled < batchSize {
+		if mode == ecc.BatchAffineOff || (mode == ecc.BatchAffineAuto && stat.nbBucketFilled < batchSize) {
 			// clear indicator that batch affine method is not appropriate here.
 			return processChunkG1Jacobian[bucketg1JacExtendedC15]
 		}
@@ -296,7 +373,7 @@ func getChunkProcessorG1(c uint64, stat chunkStat) func(chunkID uint64, chRes ch
 		const batchSize = 640
 		// here we could check some chunk statistic (deviation, ...) to determine if calling
 		// the batch affine version is worth it.
-		if stat.nbBucketFilled < batchSize {
+		if mode == ecc.BatchAffineOff || (mode == ecc.BatchAffineAuto && stat.nbBucketFilled < batchSize) {
 			// clear indicator that batch affine method is not appropriate here.
 			return processChunkG1Jacobian[bucketg1JacExtendedC16]
 		}
@@ -397,25 +474,41 @@ func (p *G2Jac) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.Mul
 		return nil, errors.New("len(points) != len(scalars)")
 	}
 
-	// if nbTasks is not set, use all available CPUs
+	// if nbTasks is not set, use all available CPUs, unless a limit was set with ecc.SetNbTasks
 	if config.NbTasks <= 0 {
-		config.NbTasks = runtime.NumCPU() * 2
+		if concurrency.IsLimited() {
+			config.NbTasks = concurrency.MaxTasks()
+		} else {
+			config.NbTasks = runtime.NumCPU() * 2
+		}
 	} else if config.NbTasks > 1024 {
 		return nil, errors.New("invalid config: config.NbTasks > 1024")
 	}
+	if config.C != 0 && !isImplementedCG2(config.C) {
+		return nil, errors.New("invalid config: config.C is not an implemented window size")
+	}
+	if config.BatchAffine > ecc.BatchAffineOff {
+		return nil, errors.New("invalid config: unknown config.BatchAffine mode")
+	}
 
 	// here, we compute the best C for nbPoints
 	// we split recursively until nbChunks(c) >= nbTasks,
 	bestC := func(nbPoints int) uint64 {
-		// implemented msmC methods (the c we use must be in this slice)
-		implementedCs := []uint64{4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+		// window size set by the caller
+		if config.C != 0 {
+			return config.C
+		}
+		// window size measured on this machine, see TuneMultiExp
+		if c, ok := ecc.MSMWindowSize(ecc.BLS12_377, "G2", nbPoints); ok && isImplementedCG2(c) {
+			return c
+		}
 		var C uint64
 		// approximate cost (in group operations)
 		// cost = bits/c * (nbPoints + 2^{c})
 		// this needs to be verified empirically.
 		// for example, on a MBP 2016, for G2 MultiExp > 8M points, hand picking c gives better results
 		min := math.MaxFloat64
-		for _, c := range implementedCs {
+		for _, c := range implementedCsG2 {
 			cc := (fr.Bits + 1) * (nbPoints + (1 << c))
 			cost := float64(cc) / float64(c)
 			if cost < min {
@@ -479,6 +572,65 @@ func (p *G2Jac) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.Mul
 	return p, nil
 }
 
+// implemented msmC methods (the c we use must be in this slice)
+var implementedCsG2 = []uint64{4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+
+func isImplementedCG2(c uint64) bool {
+	for _, ic := range implementedCsG2 {
+		if ic == c {
+			return true
+		}
+	}
+	return false
+}
+
+// calibrateMultiExpG2 returns the window sizes of the fastest MultiExp in G2
+// of msmCalibrationMinPoints, 2*msmCalibrationMinPoints, … up to the next power of two
+// of maxNbPoints points.
+func calibrateMultiExpG2(maxNbPoints int) []ecc.MSMWindow {
+	if maxNbPoints < msmCalibrationMinPoints {
+		maxNbPoints = msmCalibrationMinPoints
+	}
+	maxNbPoints = int(ecc.NextPowerOfTwo(uint64(maxNbPoints)))
+	var config ecc.MultiExpConfig
+	if concurrency.IsLimited() {
+		config.NbTasks = concurrency.MaxTasks()
+	} else {
+		config.NbTasks = runtime.NumCPU() * 2
+	}
+
+	scalars := make([]fr.Element, maxNbPoints)
+	for i := range scalars {
+		scalars[i].SetRandom()
+	}
+	points := BatchScalarMultiplicationG2(&g2GenAff, scalars)
+	for i := range scalars {
+		scalars[i].SetRandom()
+	}
+
+	var res []ecc.MSMWindow
+	for n := msmCalibrationMinPoints; n <= maxNbPoints; n *= 2 {
+		best := ecc.MSMWindow{NbPoints: n}
+		var bestTime time.Duration
+		for _, c := range implementedCsG2 {
+			// past 2n buckets, the bucket reduction dominates
+			if best.C != 0 && 1<<c > 2*n {
+				break
+			}
+			for i := 0; i < msmCalibrationRuns; i++ {
+				var p G2Jac
+				start := time.Now()
+				_innerMsmG2(&p, c, points[:n], scalars[:n], config)
+				if t := time.Since(start); best.C == 0 || t < bestTime {
+					best.C, bestTime = c, t
+				}
+			}
+		}
+		res = append(res, best)
+	}
+	return res
+}
+
 func _innerMsmG2(p *G2Jac, c uint64, points []G2Affine, scalars []fr.Element, config ecc.MultiExpConfig) *G2Jac {
 	// partition the scalars
 	digits, chunkStats := partitionScalars(scalars, c, config.NbTasks)
@@ -512,9 +664,9 @@ func _innerMsmG2(p *G2Jac, c uint64, points []G2Affine, scalars []fr.Element, co
 	// the last chunk may be processed with a different method than the rest, as it could be smaller.
 	n := len(points)
 	for j := int(nbChunks - 1); j >= 0; j-- {
-		processChunk := getChunkProcessorG2(c, chunkStats[j])
+		processChunk := getChunkProcessorG2(c, chunkStats[j], config.BatchAffine)
 		if j == int(nbChunks-1) {
-			processChunk = getChunkProcessorG2(lastC(c), chunkStats[j])
+			processChunk = getChunkProcessorG2(lastC(c), chunkStats[j], config.BatchAffine)
 		}
 		if chunkStats[j].weight >= 115 {
 			// we split this in more go routines since this chunk has more work to do than the others.
@@ -542,9 +694,9 @@ func _innerMsmG2(p *G2Jac, c uint64, points []G2Affine, scalars []fr.Element, co
 	return msmReduceChunkG2Affine(p, int(c), chChunks[:])
 }
 
-// getChunkProcessorG2 decides, depending on c window size and statistics for the chunk
-// to return the best algorithm to process the chunk.
-func getChunkProcessorG2(c uint64, stat chunkStat) func(chunkID uint64, chRes chan<- g2JacExtended, c uint64, points []G2Affine, digits []uint16, sem chan struct{}) {
+// getChunkProcessorG2 decides, depending on c window size, statistics for the chunk
+// and the batch affine mode of the config, to return the best algorithm to process the chunk.
+func getChunkProcessorG2(c uint64, stat chunkStat, mode ecc.BatchAffineMode) func(chunkID uint64, chRes chan<- g2JacExtended, c uint64, points []G2Affine, digits []uint16, sem chan struct{}) {
 	switch c {
 
 	case 2:
@@ -565,7 +717,7 @@ func getChunkProcessorG2(c uint64, stat chunkStat) func(chunkID uint64, chRes ch
 		const batchSize = 80
 		// here we could check some chunk statistic (deviation, ...) to determine if calling
 		// the batch affine version is worth it.
-		if stat.nbBucketFilled < batchSize {
+		if mode == ecc.BatchAffineOff || (mode == ecc.BatchAffineAuto && stat.nbBucketFilled < batchSize) {
 			// clear indicator that batch affine method is not appropriate here.
 			return processChunkG2Jacobian[bucketg2JacExtendedC10]
 		}
@@ -574,7 +726,7 @@ func getChunkProcessorG2(c uint64, stat chunkStat) func(chunkID uint64, chRes ch
 		const batchSize = 150
 		// here we could check some chunk statistic (deviation, ...) to determine if calling
 		// the batch affine version is worth it.
-		if stat.nbBucketFilled < batchSize {
+		if mode == ecc.BatchAffineOff || (mode == ecc.BatchAffineAuto && stat.nbBucketFilled < batchSize) {
 			// clear indicator that batch affine method is not appropriate here.
 			return processChunkG2Jacobian[bucketg2JacExtendedC11]
 		}
@@ -583,7 +735,7 @@ func getChunkProcessorG2(c uint64, stat chunkStat) func(chunkID uint64, chRes ch
 		const batchSize = 200
 		// here we could check some chunk statistic (deviation, ...) to determine if calling
 		// the batch affine version is worth it.
-		if stat.nbBucketFilled < batchSize {
+		if mode == ecc.BatchAffineOff || (mode == ecc.BatchAffineAuto && stat.nbBucketFilled < batchSize) {
 			// clear indicator that batch affine method is not appropriate here.
 			return processChunkG2Jacobian[bucketg2JacExtendedC12]
 		}
@@ -592,7 +744,7 @@ func getChunkProcessorG2(c uint64, stat chunkStat) func(chunkID uint64, chRes ch
 		const batchSize = 350
 		// here we could check some chunk statistic (deviation, ...) to determine if calling
 		// the batch affine version is worth it.
-		if stat.nbBucketFilled < batchSize {
+		if mode == ecc.BatchAffineOff || (mode == ecc.BatchAffineAuto && stat.nbBucketFilled < batchSize) {
 			// clear indicator that batch affine method is not appropriate here.
 			return processChunkG2Jacobian[bucketg2JacExtendedC13]
 		}
@@ -601,7 +753,7 @@ func getChunkProcessorG2(c uint64, stat chunkStat) func(chunkID uint64, chRes ch
 		const batchSize = 400
 		// here we could check some chunk statistic (deviation, ...) to determine if calling
 		// the batch affine version is worth it.
-		if stat.nbBucketFilled < batchSize {
+		if mode == ecc.BatchAffineOff || (mode == ecc.BatchAffineAuto && stat.nbBucketFilled < batchSize) {
 			// clear indicator that batch affine method is not appropriate here.
 			return processChunkG2Jacobian[bucketg2JacExtendedC14]
 		}
@@ -610,7 +762,7 @@ func getChunkProcessorG2(c uint64, stat chunkStat) func(chunkID uint64, chRes ch
 		const batchSize = 500
 		// here we could check some chunk statistic (deviation, ...) to determine if calling
 		// the batch affine version is worth it.
-		if stat.nbBucketFilled < batchSize {
+		if mode == ecc.BatchAffineOff || (mode == ecc.BatchAffineAuto && stat.nbBucketFilled < batchSize) {
 			// clear indicator that batch affine method is not appropriate here.
 			return processChunkG2Jacobian[bucketg2JacExtendedC15]
 		}
@@ -619,7 +771,7 @@ func getChunkProcessorG2(c uint64, stat chunkStat) func(chunkID uint64, chRes ch
 		const batchSize = 640
 		// here we could check some chunk statistic (deviation, ...) to determine if calling
 		// the batch affine version is worth it.
-		if stat.nbBucketFilled < batchSize {
+		if mode == ecc.BatchAffineOff || (mode == ecc.BatchAffineAuto && stat.nbBucketFilled < batchSize) {
 			// clear indicator that batch affine method is not appropriate here.
 			return processChunkG2Jacobian[bucketg2JacExtendedC16]
 		}
@@ -671,6 +823,40 @@ func (p *G2Jac) Fold(points []G2Affine, combinationCoeff fr.Element, config ecc.
 	return p.MultiExp(points, scalars, config)
 }
 
+// CalibrateMultiExp measures on this machine the fastest window sizes of the MultiExp
+// of up to maxNbPoints points, and sets them in tuning.
+func CalibrateMultiExp(tuning *ecc.MSMTuning, maxNbPoints int) {
+	tuning.Set(ecc.BLS12_377, "G1", calibrateMultiExpG1(maxNbPoints))
+	tuning.Set(ecc.BLS12_377, "G2", calibrateMultiExpG2(maxNbPoints))
+}
+
+// TuneMultiExp makes the MultiExp of up to maxNbPoints points use the window sizes
+// measured on this machine. They are read from the cache of the machine (see
+// ecc.LoadMSMTuning), or calibrated and saved in it the first time, which takes a few
+// seconds to a few minutes depending on maxNbPoints.
+func TuneMultiExp(maxNbPoints int) error {
+	tuning, err := ecc.LoadMSMTuning()
+	if err != nil {
+		// no tuning yet, or a stale one
+		tuning = ecc.NewMSMTuning()
+	}
+	if !tuning.Has(ecc.BLS12_377, "G1", maxNbPoints) || !tuning.Has(ecc.BLS12_377, "G2", maxNbPoints) {
+		CalibrateMultiExp(tuning, maxNbPoints)
+		if err := tuning.Save(); err != nil {
+			return err
+		}
+	}
+	ecc.SetMSMTuning(tuning)
+	return nil
+}
+
+// msmCalibration parameters: the smallest calibrated size, and the number of runs
+// of each measure, the fastest being kept.
+const (
+	msmCalibrationMinPoints = 1 << 8
+	msmCalibrationRuns      = 3
+)
+
 // selector stores the index, mask and shifts needed to select bits from a scalar
 // it is used during the multiExp algorithm or the batch scalar multiplication
 type selector struct {
@@ -715,8 +901,8 @@ type chunkStat struct {
 // (computing -G is cheap, and this saves us half of the buckets in the MultiExp or BatchScalarMultiplication)
 func partitionScalars(scalars []fr.Element, c uint64, nbTasks int) ([]uint16, []chunkStat) {
 	// no benefit here to have more tasks than CPUs
-	if nbTasks > runtime.NumCPU() {
-		nbTasks = runtime.NumCPU()
+	if nbTasks > concurrency.MaxTasks() {
+		nbTasks = concurrency.MaxTasks()
 	}
 
 	// number of c-bit radixes in a scalar
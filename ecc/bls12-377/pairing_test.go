@@ -163,6 +163,48 @@ func TestPairing(t *testing.T) {
 		genR2,
 	))
 
+	properties.Property("[BLS12-377] PairMSM should output the same result as Pair on the scaled points", prop.ForAll(
+		func(a, b fr.Element) bool {
+
+			var bg2 G2Affine
+			var bbigint big.Int
+			b.BigInt(&bbigint)
+			bg2.ScalarMultiplication(&g2GenAff, &bbigint)
+
+			var c fr.Element
+			c.Square(&a)
+			var ag1 G1Affine
+			var abigint, cbigint big.Int
+			a.BigInt(&abigint)
+			c.BigInt(&cbigint)
+			ag1.ScalarMultiplication(&g1GenAff, &abigint)
+
+			// two pairs share g2GenAff, one pair uses bg2
+			P := []G1Affine{g1GenAff, ag1, ag1}
+			Q := []G2Affine{g2GenAff, bg2, g2GenAff}
+			scalars := []fr.Element{a, b, c}
+
+			var scaled [3]G1Affine
+			scaled[0].ScalarMultiplication(&P[0], &abigint)
+			scaled[1].ScalarMultiplication(&P[1], &bbigint)
+			scaled[2].ScalarMultiplication(&P[2], &cbigint)
+
+			res1, _ := Pair(scaled[:], Q)
+			res2, _ := PairMSM(P, Q, scalars)
+
+			// e([a]g1, g2) ⋅ e([-a]g1, g2) = 1
+			var aNeg fr.Element
+			aNeg.Neg(&a)
+			ok, _ := PairingCheckMSM([]G1Affine{g1GenAff, g1GenAff}, []G2Affine{g2GenAff, g2GenAff}, []fr.Element{a, aNeg})
+
+			_, err := PairMSM(P, Q, scalars[:2])
+
+			return res1.Equal(&res2) && ok && err != nil
+		},
+		genR1,
+		genR2,
+	))
+
 	properties.Property("[BLS12-377] Pair should output the same result with MillerLoop or MillerLoopFixedQ", prop.ForAll(
 		func(a, b fr.Element) bool {
 
@@ -320,7 +320,7 @@ func TestOps(t *testing.T) {
 		func(s1 big.Int) bool {
 
 			var p1, p2, zero PointAffine
-			zero.setInfinity()
+			zero.SetInfinity()
 
 			p1.Add(&zero, &zero)
 			p2.Double(&zero)
@@ -337,7 +337,7 @@ func TestOps(t *testing.T) {
 
 			var p1, p2, zero PointAffine
 			p1.ScalarMultiplication(&params.Base, &s1)
-			zero.setInfinity()
+			zero.SetInfinity()
 
 			p2.Add(&p1, &zero)
 
@@ -446,7 +446,7 @@ func TestOps(t *testing.T) {
 		func(s1 big.Int) bool {
 
 			var p1, p2, zero PointProj
-			zero.setInfinity()
+			zero.SetInfinity()
 
 			p1.Add(&zero, &zero)
 			p2.Double(&zero)
@@ -464,7 +464,7 @@ func TestOps(t *testing.T) {
 			var baseProj, p1, p2, zero PointProj
 			baseProj.FromAffine(&params.Base)
 			p1.ScalarMultiplication(&baseProj, &s1)
-			zero.setInfinity()
+			zero.SetInfinity()
 
 			p2.Add(&p1, &zero)
 
@@ -531,7 +531,7 @@ func TestOps(t *testing.T) {
 		func(s1 big.Int) bool {
 
 			var p1, zero PointExtended
-			zero.setInfinity()
+			zero.SetInfinity()
 
 			p1.Add(&zero, &zero)
 
@@ -548,7 +548,7 @@ func TestOps(t *testing.T) {
 			var baseExtended, p1, p2, zero PointExtended
 			baseExtended.FromAffine(&params.Base)
 			p1.ScalarMultiplication(&baseExtended, &s1)
-			zero.setInfinity()
+			zero.SetInfinity()
 
 			p2.Add(&p1, &zero)
 
@@ -642,13 +642,104 @@ func TestOps(t *testing.T) {
 			pAffine.ScalarMultiplication(&params.Base, &s)
 
 			p.MixedAdd(&pExtended, &pAffine)
-			p2.MixedDouble(&pExtended)
+			p2.Double(&pExtended)
 
 			return p.Equal(&p2)
 		},
 		genS1,
 	))
 
+	properties.Property("(mixed affine+extended) 0+P=P and P+0=P", prop.ForAll(
+		func(s big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var zero, pExtended, p1, p2 PointExtended
+			var pAffine, zeroAffine PointAffine
+			zero.SetInfinity()
+			zeroAffine.SetInfinity()
+			pAffine.ScalarMultiplication(&params.Base, &s)
+			pExtended.FromAffine(&pAffine)
+
+			p1.MixedAdd(&zero, &pAffine)
+			p2.MixedAdd(&pExtended, &zeroAffine)
+
+			return p1.IsOnCurve() && p1.Equal(&pExtended) && p2.Equal(&pExtended)
+		},
+		genS1,
+	))
+
+	properties.Property("(mixed affine+extended) MixedAdd should match Add", prop.ForAll(
+		func(s1, s2 big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2, q, r PointExtended
+			var p2Affine PointAffine
+			p1.FromAffine(&params.Base)
+			p1.ScalarMultiplication(&p1, &s1)
+			p2Affine.ScalarMultiplication(&params.Base, &s2)
+			p2.FromAffine(&p2Affine)
+
+			q.Add(&p1, &p2)
+			r.MixedAdd(&p1, &p2Affine)
+
+			return r.IsOnCurve() && q.Equal(&r)
+		},
+		genS1,
+		genS2,
+	))
+
+	// conversions
+	properties.Property("(conversions) extended <-> projective should preserve the point", prop.ForAll(
+		func(s big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var pProj, qProj PointProj
+			var pExt PointExtended
+			pProj.FromAffine(&params.Base)
+			pProj.ScalarMultiplication(&pProj, &s)
+
+			pExt.FromProj(&pProj)
+			qProj.FromExtended(&pExt)
+
+			return pExt.IsOnCurve() && qProj.Equal(&pProj)
+		},
+		genS1,
+	))
+
+	properties.Property("(conversions) batch conversions to affine should match the single ones", prop.ForAll(
+		func(s big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			const n = 5
+			pointsProj := make([]PointProj, n)
+			pointsExt := make([]PointExtended, n)
+			pointsProj[0].FromAffine(&params.Base)
+			pointsProj[0].ScalarMultiplication(&pointsProj[0], &s)
+			for i := 1; i < n; i++ {
+				pointsProj[i].Double(&pointsProj[i-1])
+			}
+			for i := range pointsProj {
+				pointsExt[i].FromProj(&pointsProj[i])
+			}
+
+			fromProj := BatchFromProj(pointsProj)
+			fromExt := BatchFromExtended(pointsExt)
+			for i := range pointsProj {
+				var expected PointAffine
+				expected.FromProj(&pointsProj[i])
+				if !fromProj[i].Equal(&expected) || !fromExt[i].Equal(&expected) {
+					return false
+				}
+			}
+			return true
+		},
+		genS1,
+	))
+
 	// mixed affine+projective
 	properties.Property("(mixed affine+proj) P+(-P)=O", prop.ForAll(
 		func(s big.Int) bool {
@@ -729,6 +820,58 @@ func TestMarshal(t *testing.T) {
 	}
 }
 
+func TestBatchDecompress(t *testing.T) {
+	t.Parallel()
+	initOnce.Do(initCurveParams)
+
+	var point PointAffine
+	point.Set(&curveParams.Base)
+	points := make([]PointAffine, 20)
+	var buf []byte
+	for i := range points {
+		points[i].Set(&point)
+		if i%2 == 1 {
+			points[i].Neg(&points[i])
+		}
+		buf = append(buf, points[i].Marshal()...)
+		point.Add(&point, &curveParams.Base)
+	}
+
+	decoded, err := BatchDecompress(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range points {
+		if !decoded[i].Equal(&points[i]) {
+			t.Fatal("error BatchDecompress(marshal(points))")
+		}
+	}
+
+	if _, err := BatchDecompress(buf[1:]); err == nil {
+		t.Fatal("expected an error on a truncated buffer")
+	}
+
+	// find an y-coordinate which is not on the curve
+	var invalid PointAffine
+	var one, num, den fr.Element
+	one.SetOne()
+	for i := uint64(2); ; i++ {
+		invalid.Y.SetUint64(i)
+		num.Square(&invalid.Y)
+		den.Mul(&num, &curveParams.D)
+		num.Sub(&one, &num)
+		den.Sub(&curveParams.A, &den)
+		if num.Div(&num, &den).Legendre() == -1 {
+			break
+		}
+	}
+	invalidBytes := invalid.Bytes()
+	buf = append(buf, invalidBytes[:]...)
+	if _, err := BatchDecompress(buf); err == nil {
+		t.Fatal("expected an error on a point not on the curve")
+	}
+}
+
 // GenBigInt generates a big.Int
 // TODO @thomas we use fr size as max bound here
 func GenBigInt() gopter.Gen {
@@ -873,7 +1016,7 @@ func BenchmarkMixedAdd(b *testing.B) {
 
 	b.Run("Projective", func(b *testing.B) {
 		var accum PointProj
-		accum.setInfinity()
+		accum.SetInfinity()
 
 		b.ResetTimer()
 		for i := 0; i < b.N; i++ {
@@ -882,7 +1025,7 @@ func BenchmarkMixedAdd(b *testing.B) {
 	})
 	b.Run("Extended", func(b *testing.B) {
 		var accum PointExtended
-		accum.setInfinity()
+		accum.SetInfinity()
 
 		b.ResetTimer()
 		for i := 0; i < b.N; i++ {
@@ -900,7 +1043,7 @@ func BenchmarkAdd(b *testing.B) {
 		var point PointAffine
 		point.ScalarMultiplication(&params.Base, &s)
 		var accum PointAffine
-		accum.setInfinity()
+		accum.SetInfinity()
 
 		b.ResetTimer()
 		for i := 0; i < b.N; i++ {
@@ -912,7 +1055,7 @@ func BenchmarkAdd(b *testing.B) {
 		pointAff.ScalarMultiplication(&params.Base, &s)
 		var accum, point PointProj
 		point.FromAffine(&pointAff)
-		accum.setInfinity()
+		accum.SetInfinity()
 
 		b.ResetTimer()
 		for i := 0; i < b.N; i++ {
@@ -924,7 +1067,7 @@ func BenchmarkAdd(b *testing.B) {
 		pointAff.ScalarMultiplication(&params.Base, &s)
 		var accum, point PointExtended
 		point.FromAffine(&pointAff)
-		accum.setInfinity()
+		accum.SetInfinity()
 
 		b.ResetTimer()
 		for i := 0; i < b.N; i++ {
@@ -0,0 +1,168 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package eddsa
+
+import (
+	"crypto/rand"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/twistededwards"
+)
+
+var errBatchSize = errors.New("inconsistent batch sizes")
+
+// batchScalarBits is the bit size of the random coefficients of the linear
+// combination checked by BatchVerify.
+const batchScalarBits = 128
+
+// BatchDecompressPublicKeys decodes the concatenated public keys in buf,
+// recovering all the points with a single field inversion.
+//
+// See PublicKey.SetBytes and twistededwards.BatchDecompress.
+func BatchDecompressPublicKeys(buf []byte) ([]PublicKey, error) {
+	if len(buf)%sizePublicKey != 0 {
+		return nil, errWrongSize
+	}
+	points, err := twistededwards.BatchDecompress(buf)
+	if err != nil {
+		return nil, errNotOnCurve
+	}
+	pubs := make([]PublicKey, len(points))
+	for i := range points {
+		pubs[i].A = points[i]
+	}
+	return pubs, nil
+}
+
+// BatchVerify verifies the signatures sigBins[i] of messages[i] under pubs[i].
+// It returns true only if all the signatures are valid.
+//
+// The signatures are deserialized with a single field inversion and checked
+// together with a random linear combination of the verification equations
+//
+//	[cofactor]([∑ᵢ zᵢ·Sᵢ]B - ∑ᵢ [zᵢ]Rᵢ - ∑ᵢ [zᵢ·H(Rᵢ,Aᵢ,Mᵢ)]Aᵢ) = 0
+//
+// where the zᵢ are random 128-bit scalars, so that a batch containing an
+// invalid signature passes with probability at most 2⁻¹²⁸. A failing batch
+// doesn't tell which signature is invalid; Verify should then be used on each
+// of them.
+func BatchVerify(pubs []PublicKey, sigBins, messages [][]byte, hFunc hash.Hash) (bool, error) {
+	return BatchVerifyWithRandomness(pubs, sigBins, messages, hFunc, rand.Reader)
+}
+
+// BatchVerifyWithRandomness is BatchVerify, drawing the coefficients of the
+// linear combination from r.
+func BatchVerifyWithRandomness(pubs []PublicKey, sigBins, messages [][]byte, hFunc hash.Hash, r io.Reader) (bool, error) {
+
+	// hFunc cannot be nil.
+	// We need a hash function for the Fiat-Shamir.
+	if hFunc == nil {
+		return false, errHashNeeded
+	}
+	if len(pubs) != len(sigBins) || len(pubs) != len(messages) {
+		return false, errBatchSize
+	}
+	if len(pubs) == 0 {
+		return true, nil
+	}
+
+	curveParams := twistededwards.GetEdwardsCurve()
+
+	for i := range pubs {
+		if !pubs[i].A.IsOnCurve() {
+			return false, errNotOnCurve
+		}
+	}
+
+	// Deserialize the signatures
+	sigs, err := batchSetBytesSignatures(sigBins)
+	if err != nil {
+		return false, err
+	}
+
+	var inf twistededwards.PointAffine
+	inf.Y.SetOne()
+	var acc, tmp twistededwards.PointExtended
+	acc.FromAffine(&inf)
+
+	var z, s, sumS, hram big.Int
+	var buf [batchScalarBits / 8]byte
+	for i := range sigs {
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return false, err
+		}
+		z.SetBytes(buf[:])
+
+		// sumS += zᵢ·Sᵢ
+		s.SetBytes(sigs[i].S[:])
+		s.Mul(&s, &z)
+		sumS.Add(&sumS, &s)
+
+		// acc += [zᵢ]Rᵢ + [zᵢ·H(Rᵢ,Aᵢ,Mᵢ)]Aᵢ
+		hram, err = computeHRAM(hFunc, &sigs[i].R, &pubs[i].A, messages[i])
+		if err != nil {
+			return false, err
+		}
+		hram.Mul(&hram, &z).Mod(&hram, &curveParams.Order)
+		tmp.FromAffine(&sigs[i].R)
+		tmp.ScalarMultiplication(&tmp, &z)
+		acc.Add(&acc, &tmp)
+		tmp.FromAffine(&pubs[i].A)
+		tmp.ScalarMultiplication(&tmp, &hram)
+		acc.Add(&acc, &tmp)
+	}
+
+	// acc -= [∑ᵢ zᵢ·Sᵢ]B
+	sumS.Mod(&sumS, &curveParams.Order)
+	tmp.FromAffine(&curveParams.Base)
+	tmp.ScalarMultiplication(&tmp, &sumS)
+	tmp.Neg(&tmp)
+	acc.Add(&acc, &tmp)
+
+	// the cofactor clears the small order components of the points
+	var bCofactor big.Int
+	curveParams.Cofactor.BigInt(&bCofactor)
+	acc.ScalarMultiplication(&acc, &bCofactor)
+
+	return acc.IsZero(), nil
+}
+
+// batchSetBytesSignatures deserializes the signatures in sigBins, with the same
+// checks as Signature.SetBytes, recovering all the R points with a single field
+// inversion.
+func batchSetBytesSignatures(sigBins [][]byte) ([]Signature, error) {
+	rs := make([]byte, 0, len(sigBins)*sizeFr)
+	for _, sigBin := range sigBins {
+		if err := checkSignatureBytes(sigBin); err != nil {
+			return nil, err
+		}
+		rs = append(rs, sigBin[:sizeFr]...)
+	}
+	points, err := twistededwards.BatchDecompress(rs)
+	if err != nil {
+		return nil, errNotOnCurve
+	}
+	sigs := make([]Signature, len(sigBins))
+	for i := range sigs {
+		sigs[i].R = points[i]
+		copy(sigs[i].S[:], sigBins[i][sizeFr:2*sizeFr])
+	}
+	return sigs, nil
+}
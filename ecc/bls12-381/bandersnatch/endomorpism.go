@@ -39,7 +39,7 @@ func (p *PointProj) scalarMulGLV(p1 *PointProj, scalar *big.Int) *PointProj {
 	var res PointProj
 	var k1, k2 fr.Element
 
-	res.setInfinity()
+	res.SetInfinity()
 
 	// table[b3b2b1b0-1] = b3b2*phi(p1) + b1b0*p1
 	table[0].Set(p1)
@@ -136,7 +136,7 @@ func (p *PointExtended) scalarMulGLV(p1 *PointExtended, scalar *big.Int) *PointE
 	var res PointExtended
 	var k1, k2 fr.Element
 
-	res.setInfinity()
+	res.SetInfinity()
 
 	// table[b3b2b1b0-1] = b3b2*phi(p1) + b1b0*p1
 	table[0].Set(p1)
@@ -0,0 +1,216 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bandersnatch
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+const (
+	fixedBaseWindow = 8
+	// the GLV decomposition yields scalars of at most len(r)/2+1 bits,
+	// we leave some room for the signed recoding carry.
+	fixedBaseScalarBits = 136
+	nbFixedBaseWindows  = fixedBaseScalarBits / fixedBaseWindow
+)
+
+// FixedBaseTable holds precomputed multiples of a fixed point and of its
+// image by the GLV endomorphism, for use with ScalarMultiplicationFixedBase
+// and MultiScalarMulFixedBase.
+//
+// A table takes 2·nbFixedBaseWindows·2^(fixedBaseWindow-1) affine points (~280KB).
+type FixedBaseTable struct {
+	base PointAffine
+	// table[0][i][j] = (j+1)·2^(fixedBaseWindow·i)·base
+	// table[1][i][j] = ϕ(table[0][i][j])
+	table [2][nbFixedBaseWindows][1 << (fixedBaseWindow - 1)]PointAffine
+}
+
+// NewFixedBaseTable precomputes the multiples of base needed by
+// ScalarMultiplicationFixedBase and MultiScalarMulFixedBase.
+// base must be in the prime order subgroup.
+func NewFixedBaseTable(base *PointAffine) *FixedBaseTable {
+	initOnce.Do(initCurveParams)
+
+	t := new(FixedBaseTable)
+	t.base.Set(base)
+
+	const nbEntries = nbFixedBaseWindows << (fixedBaseWindow - 1)
+	points := make([]PointExtended, 2*nbEntries)
+	var b PointExtended
+	b.FromAffine(base)
+	for i := 0; i < nbFixedBaseWindows; i++ {
+		row := points[i<<(fixedBaseWindow-1) : (i+1)<<(fixedBaseWindow-1)]
+		row[0].Set(&b)
+		for j := 1; j < len(row); j++ {
+			row[j].Add(&row[j-1], &b)
+		}
+		for j := 0; j < fixedBaseWindow; j++ {
+			b.Double(&b)
+		}
+	}
+	for k := 0; k < nbEntries; k++ {
+		points[nbEntries+k].phi(&points[k])
+	}
+
+	// normalize all the points with a single inversion
+	zs := make([]fr.Element, len(points))
+	for k := range points {
+		zs[k].Set(&points[k].Z)
+	}
+	zs = fr.BatchInvert(zs)
+	for k := range points {
+		e := &t.table[k/nbEntries][(k%nbEntries)>>(fixedBaseWindow-1)][k&(1<<(fixedBaseWindow-1)-1)]
+		e.X.Mul(&points[k].X, &zs[k])
+		e.Y.Mul(&points[k].Y, &zs[k])
+	}
+
+	return t
+}
+
+// NewFixedBaseTables precomputes in parallel the tables of each of the bases.
+func NewFixedBaseTables(bases []PointAffine) []*FixedBaseTable {
+	tables := make([]*FixedBaseTable, len(bases))
+	parallel.Execute(len(bases), func(start, end int) {
+		for i := start; i < end; i++ {
+			tables[i] = NewFixedBaseTable(&bases[i])
+		}
+	})
+	return tables
+}
+
+// Base returns the point t was built from.
+func (t *FixedBaseTable) Base() PointAffine {
+	return t.base
+}
+
+// ScalarMultiplicationFixedBase sets p = [scalar]base and returns it, where base is
+// the point t was built from. It performs no doubling.
+func (p *PointExtended) ScalarMultiplicationFixedBase(t *FixedBaseTable, scalar *big.Int) *PointExtended {
+	var res PointExtended
+	res.SetInfinity()
+	res.addFixedBase(t, scalar)
+	return p.Set(&res)
+}
+
+// ScalarMultiplicationFixedBase sets p = [scalar]base and returns it, where base is
+// the point t was built from. It performs no doubling.
+func (p *PointAffine) ScalarMultiplicationFixedBase(t *FixedBaseTable, scalar *big.Int) *PointAffine {
+	var res PointExtended
+	res.ScalarMultiplicationFixedBase(t, scalar)
+	return p.FromExtended(&res)
+}
+
+// MultiScalarMulFixedBase sets p = ∑ᵢ [scalarsᵢ]baseᵢ and returns it, where baseᵢ
+// is the point tablesᵢ was built from.
+//
+// Since all the multiples are precomputed, the cost is about
+// 2·len(scalars)·nbFixedBaseWindows mixed additions and no doubling.
+func (p *PointExtended) MultiScalarMulFixedBase(tables []*FixedBaseTable, scalars []big.Int) (*PointExtended, error) {
+	if len(tables) != len(scalars) {
+		return nil, errors.New("len(tables) != len(scalars)")
+	}
+
+	var res PointExtended
+	var lock sync.Mutex
+	res.SetInfinity()
+	parallel.Execute(len(scalars), func(start, end int) {
+		var acc PointExtended
+		acc.SetInfinity()
+		for i := start; i < end; i++ {
+			acc.addFixedBase(tables[i], &scalars[i])
+		}
+		lock.Lock()
+		res.Add(&res, &acc)
+		lock.Unlock()
+	})
+
+	p.Set(&res)
+	return p, nil
+}
+
+// MultiScalarMulFixedBase sets p = ∑ᵢ [scalarsᵢ]baseᵢ and returns it, where baseᵢ
+// is the point tablesᵢ was built from.
+func (p *PointAffine) MultiScalarMulFixedBase(tables []*FixedBaseTable, scalars []big.Int) (*PointAffine, error) {
+	var res PointExtended
+	if _, err := res.MultiScalarMulFixedBase(tables, scalars); err != nil {
+		return nil, err
+	}
+	return p.FromExtended(&res), nil
+}
+
+// addFixedBase sets p = p + [scalar]base using the GLV decomposition
+// scalar = k₁ + λ·k₂ and signed fixed windows over the precomputed tables.
+func (p *PointExtended) addFixedBase(t *FixedBaseTable, scalar *big.Int) *PointExtended {
+	initOnce.Do(initCurveParams)
+
+	var s big.Int
+	s.Mod(scalar, &curveParams.Order)
+	k := ecc.SplitScalar(&s, &curveParams.glvBasis)
+
+	for c := 0; c < 2; c++ {
+		neg := k[c].Sign() == -1
+		if neg {
+			k[c].Neg(&k[c])
+		}
+		if k[c].BitLen() > fixedBaseScalarBits-1 {
+			// unreachable given the bounds on the lattice basis
+			var q PointExtended
+			q.FromAffine(&t.base)
+			if c == 1 {
+				q.phi(&q)
+			}
+			if neg {
+				q.Neg(&q)
+			}
+			q.ScalarMultiplication(&q, &k[c])
+			p.Add(p, &q)
+			continue
+		}
+
+		const mask = 1<<fixedBaseWindow - 1
+		var tmp PointAffine
+		carry := 0
+		for i := 0; i < nbFixedBaseWindows; i++ {
+			digit := carry
+			for j := 0; j < fixedBaseWindow; j++ {
+				digit += int(k[c].Bit(i*fixedBaseWindow+j)) << j
+			}
+			// recode the digit in [-2^(w-1), 2^(w-1)]
+			carry = 0
+			if digit > mask>>1+1 {
+				digit -= mask + 1
+				carry = 1
+			}
+			if neg {
+				digit = -digit
+			}
+			if digit > 0 {
+				p.MixedAdd(p, &t.table[c][i][digit-1])
+			} else if digit < 0 {
+				tmp.Neg(&t.table[c][i][-digit-1])
+				p.MixedAdd(p, &tmp)
+			}
+		}
+	}
+
+	return p
+}
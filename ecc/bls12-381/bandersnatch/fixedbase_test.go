@@ -0,0 +1,116 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bandersnatch
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestFixedBase(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	params := GetEdwardsCurve()
+	var base2 PointAffine
+	base2.ScalarMultiplication(&params.Base, big.NewInt(7))
+	tables := NewFixedBaseTables([]PointAffine{params.Base, base2})
+
+	properties.Property("ScalarMultiplicationFixedBase should output the same result as ScalarMultiplication", prop.ForAll(
+		func(s big.Int) bool {
+			var expected, res PointAffine
+			expected.ScalarMultiplication(&params.Base, &s)
+			res.ScalarMultiplicationFixedBase(tables[0], &s)
+
+			// negative scalars and scalars larger than the order
+			var n big.Int
+			n.Neg(&s)
+			var expectedNeg, resNeg PointAffine
+			expectedNeg.Neg(&expected)
+			resNeg.ScalarMultiplicationFixedBase(tables[0], &n)
+
+			var l PointAffine
+			n.Add(&s, &params.Order)
+			l.ScalarMultiplicationFixedBase(tables[0], &n)
+
+			return res.Equal(&expected) && resNeg.Equal(&expectedNeg) && l.Equal(&expected)
+		},
+		GenBigInt(),
+	))
+
+	properties.Property("MultiScalarMulFixedBase should output the sum of the scalar multiplications", prop.ForAll(
+		func(s1, s2 big.Int) bool {
+			var p1, p2, expected, res PointAffine
+			p1.ScalarMultiplication(&params.Base, &s1)
+			p2.ScalarMultiplication(&base2, &s2)
+			expected.Add(&p1, &p2)
+
+			if _, err := res.MultiScalarMulFixedBase(tables, []big.Int{s1, s2}); err != nil {
+				return false
+			}
+			return res.Equal(&expected)
+		},
+		GenBigInt(),
+		GenBigInt(),
+	))
+
+	properties.Property("ScalarMultiplicationFixedBase by 0 should output the point at infinity", prop.ForAll(
+		func() bool {
+			var res, inf PointAffine
+			inf.SetInfinity()
+			res.ScalarMultiplicationFixedBase(tables[1], big.NewInt(0))
+			_, err := res.MultiScalarMulFixedBase(tables, []big.Int{{}})
+			b2 := tables[1].Base()
+			return res.Equal(&inf) && err != nil && b2.Equal(&base2)
+		},
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func BenchmarkFixedBase(b *testing.B) {
+	params := GetEdwardsCurve()
+	var s big.Int
+	s.SetString("52435875175126190479447705081859658376581184513", 10)
+
+	b.Run("table", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NewFixedBaseTable(&params.Base)
+		}
+	})
+
+	table := NewFixedBaseTable(&params.Base)
+	var p PointAffine
+	b.Run("scalar multiplication", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p.ScalarMultiplication(&params.Base, &s)
+		}
+	})
+	b.Run("scalar multiplication fixed base", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p.ScalarMultiplicationFixedBase(table, &s)
+		}
+	})
+}
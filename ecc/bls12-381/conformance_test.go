@@ -0,0 +1,231 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bls12381
+
+// Differential conformance tests against the vectors published for blst / zkcrypto
+// interoperability (github.com/ethereum/bls12-381-tests layout):
+//
+//	deserialization_G1/*.yaml  input: {pubkey: <hex>},                                output: true | null
+//	deserialization_G2/*.yaml  input: {signature: <hex>},                             output: true | null
+//	hash_to_G2/*.yaml          input: {msg: <string>},                                output: {x: <hex>,<hex>, y: <hex>,<hex>}
+//	verify/*.yaml              input: {pubkey: <hex>, message: <hex>, signature: <hex>}, output: true | false
+//
+// The vectors are read from testing/bls by default, or from the directory set in
+// BLS12381_CONFORMANCE_VECTORS. testing/bls holds the deserialization vectors and the
+// hash_to_G2 vectors of RFC 9380 (appendix J.10.1), such that the tests run with the
+// other unit tests; the verify vectors (and the complete suites) are read from
+// BLS12381_CONFORMANCE_VECTORS, missing categories are skipped. The pairing is exercised
+// through signature verification.
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// domain separation tag of the hash_to_G2 vectors (RFC 9380, appendix J.10.1)
+	conformanceHashToG2DST = "QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_"
+
+	// domain separation tag of the proof of possession BLS signature scheme, min public key size
+	conformanceSignatureDST = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+)
+
+func conformanceVectors(t *testing.T, category string) []string {
+	dir := os.Getenv("BLS12381_CONFORMANCE_VECTORS")
+	if dir == "" {
+		dir = testDir
+	}
+	tests, err := filepath.Glob(filepath.Join(dir, category, "*.yaml"))
+	require.NoError(t, err)
+	if len(tests) == 0 {
+		t.Skipf("no %s vectors in %s", category, dir)
+	}
+	return tests
+}
+
+func decodeConformanceVector(t *testing.T, path string, v interface{}) {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, yaml.NewDecoder(f).Decode(v))
+}
+
+func decodeHex(s string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
+}
+
+func TestConformanceDeserializationG1(t *testing.T) {
+	type Test struct {
+		Input struct {
+			PubKey string `yaml:"pubkey"`
+		}
+		Output *bool `yaml:"output"`
+	}
+	for _, path := range conformanceVectors(t, "deserialization_G1") {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			var test Test
+			decodeConformanceVector(t, path, &test)
+			valid := test.Output != nil && *test.Output
+
+			buf, err := decodeHex(test.Input.PubKey)
+			if err == nil {
+				var p G1Affine
+				err = p.SetBytesStrict(buf)
+				if err == nil {
+					// the encoding is canonical
+					if len(buf) == SizeOfG1AffineCompressed {
+						b := p.Bytes()
+						require.Equal(t, buf, b[:])
+					} else {
+						b := p.RawBytes()
+						require.Equal(t, buf, b[:])
+					}
+				}
+			}
+			require.Equal(t, valid, err == nil, "err: %v", err)
+		})
+	}
+}
+
+func TestConformanceDeserializationG2(t *testing.T) {
+	type Test struct {
+		Input struct {
+			Signature string `yaml:"signature"`
+		}
+		Output *bool `yaml:"output"`
+	}
+	for _, path := range conformanceVectors(t, "deserialization_G2") {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			var test Test
+			decodeConformanceVector(t, path, &test)
+			valid := test.Output != nil && *test.Output
+
+			buf, err := decodeHex(test.Input.Signature)
+			if err == nil {
+				var p G2Affine
+				err = p.SetBytesStrict(buf)
+				if err == nil {
+					if len(buf) == SizeOfG2AffineCompressed {
+						b := p.Bytes()
+						require.Equal(t, buf, b[:])
+					} else {
+						b := p.RawBytes()
+						require.Equal(t, buf, b[:])
+					}
+				}
+			}
+			require.Equal(t, valid, err == nil, "err: %v", err)
+		})
+	}
+}
+
+func TestConformanceHashToG2(t *testing.T) {
+	type Test struct {
+		Input struct {
+			Msg string `yaml:"msg"`
+		}
+		Output struct {
+			X string `yaml:"x"`
+			Y string `yaml:"y"`
+		}
+	}
+	parseE2 := func(s string) (x0, x1 fp.Element) {
+		parts := strings.Split(s, ",")
+		require.Len(t, parts, 2)
+		b0, err := decodeHex(parts[0])
+		require.NoError(t, err)
+		b1, err := decodeHex(parts[1])
+		require.NoError(t, err)
+		require.NoError(t, x0.SetBytesCanonical(b0))
+		require.NoError(t, x1.SetBytesCanonical(b1))
+		return
+	}
+	for _, path := range conformanceVectors(t, "hash_to_G2") {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			var test Test
+			decodeConformanceVector(t, path, &test)
+
+			var expected G2Affine
+			expected.X.A0, expected.X.A1 = parseE2(test.Output.X)
+			expected.Y.A0, expected.Y.A1 = parseE2(test.Output.Y)
+
+			p, err := HashToG2([]byte(test.Input.Msg), []byte(conformanceHashToG2DST))
+			require.NoError(t, err)
+			require.True(t, p.Equal(&expected))
+		})
+	}
+}
+
+func TestConformanceVerify(t *testing.T) {
+	type Test struct {
+		Input struct {
+			PubKey    string `yaml:"pubkey"`
+			Message   string `yaml:"message"`
+			Signature string `yaml:"signature"`
+		}
+		Output bool `yaml:"output"`
+	}
+	for _, path := range conformanceVectors(t, "verify") {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			var test Test
+			decodeConformanceVector(t, path, &test)
+			require.Equal(t, test.Output, conformanceVerify(test.Input.PubKey, test.Input.Message, test.Input.Signature))
+		})
+	}
+}
+
+// conformanceVerify verifies a BLS signature (minimal public key size, proof of possession
+// scheme) as in the Ethereum consensus specs: the public key must be a valid, non infinity,
+// point of G1, and the signature a valid point of G2.
+func conformanceVerify(pubKey, message, signature string) bool {
+	bPubKey, err := decodeHex(pubKey)
+	if err != nil {
+		return false
+	}
+	msg, err := decodeHex(message)
+	if err != nil {
+		return false
+	}
+	bSignature, err := decodeHex(signature)
+	if err != nil {
+		return false
+	}
+
+	var pk G1Affine
+	if pk.SetPublicKeyBytes(bPubKey) != nil {
+		return false
+	}
+	var sig G2Affine
+	if sig.SetBytesStrict(bSignature) != nil {
+		return false
+	}
+	h, err := HashToG2(msg, []byte(conformanceSignatureDST))
+	if err != nil {
+		return false
+	}
+
+	// e(-g₁, σ)⋅e(pk, H(m)) == 1
+	_, _, g1, _ := Generators()
+	g1.Neg(&g1)
+	ok, err := PairingCheck([]G1Affine{g1, pk}, []G2Affine{sig, h})
+	return err == nil && ok
+}
@@ -0,0 +1,266 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package das provides helpers for data availability sampling on bls12-381.
+//
+// A k×k matrix of field elements is extended to a 2k×2k matrix with a 2D Reed-Solomon
+// code of rate 1/2: each row, then each column, is systematically encoded, so that the
+// original cells are at the even positions and any row or column of the extended matrix
+// is a codeword. Each row and each column is committed with KZG, as the polynomial of
+// degree less than k it evaluates on the 2k-th roots of unity.
+//
+// A sample is a cell of the extended matrix with KZG opening proofs against its row
+// and column commitments. Verifiers also check that the row (resp. column) commitments
+// are themselves a Reed-Solomon codeword in G₁, which binds them to a single 2D
+// extension.
+package das
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft/rs"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+)
+
+var (
+	ErrInvalidSize        = errors.New("das: the matrix must be k×k, k a power of two")
+	ErrSampleOutOfRange   = errors.New("das: the sample is out of the extended matrix")
+	ErrSampleValue        = errors.New("das: the opening proofs don't open the sample value")
+	ErrInvalidCommitments = errors.New("das: the commitments are not a Reed-Solomon codeword")
+)
+
+// Prover extends matrices of size k×k and commits to them.
+type Prover struct {
+	k    int
+	code *rs.Code
+	pk   kzg.ProvingKey
+}
+
+// Verifier verifies the samples of matrices of size k×k.
+type Verifier struct {
+	k    int
+	code *rs.Code
+	vk   kzg.VerifyingKey
+}
+
+// ExtendedMatrix is the 2D extension of a k×k matrix, with its commitments.
+type ExtendedMatrix struct {
+	// Cells 2k×2k extended matrix, Cells[2i][2j] is the original cell (i, j)
+	Cells [][]fr.Element
+
+	// RowCommitments and ColumnCommitments KZG commitments of the 2k rows and columns
+	RowCommitments    []kzg.Digest
+	ColumnCommitments []kzg.Digest
+
+	rows, columns [][]fr.Element // polynomials of the rows and columns, degree < k
+	prover        *Prover
+}
+
+// Sample is a cell of the extended matrix, with its opening proofs.
+type Sample struct {
+	Row, Column int
+	Value       fr.Element
+
+	// RowProof opening of the row polynomial at the point of the column, and
+	// ColumnProof opening of the column polynomial at the point of the row
+	RowProof, ColumnProof kzg.OpeningProof
+}
+
+// NewProver returns a Prover for k×k matrices. pk must hold at least k points.
+func NewProver(k int, pk kzg.ProvingKey) (*Prover, error) {
+	code, err := newCode(k)
+	if err != nil {
+		return nil, err
+	}
+	return &Prover{k: k, code: code, pk: pk}, nil
+}
+
+// NewVerifier returns a Verifier for k×k matrices.
+func NewVerifier(k int, vk kzg.VerifyingKey) (*Verifier, error) {
+	code, err := newCode(k)
+	if err != nil {
+		return nil, err
+	}
+	return &Verifier{k: k, code: code, vk: vk}, nil
+}
+
+func newCode(k int) (*rs.Code, error) {
+	if k < 1 || uint64(k) != ecc.NextPowerOfTwo(uint64(k)) {
+		return nil, fmt.Errorf("%w, got %d", ErrInvalidSize, k)
+	}
+	return rs.NewCode(uint64(k), 2)
+}
+
+// Extend returns the 2D extension of data, a k×k matrix, and commits to its rows and
+// columns.
+func (p *Prover) Extend(data [][]fr.Element) (*ExtendedMatrix, error) {
+	if len(data) != p.k {
+		return nil, fmt.Errorf("%w: %d rows", ErrInvalidSize, len(data))
+	}
+	n := 2 * p.k
+	m := &ExtendedMatrix{
+		Cells:             make([][]fr.Element, n),
+		RowCommitments:    make([]kzg.Digest, n),
+		ColumnCommitments: make([]kzg.Digest, n),
+		rows:              make([][]fr.Element, n),
+		columns:           make([][]fr.Element, n),
+		prover:            p,
+	}
+
+	// extend the original rows, at the even positions
+	for i := range data {
+		if len(data[i]) != p.k {
+			return nil, fmt.Errorf("%w: row %d has %d cells", ErrInvalidSize, i, len(data[i]))
+		}
+		var err error
+		if m.Cells[2*i], err = p.code.EncodeSystematic(data[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	// extend the columns
+	column := make([]fr.Element, p.k)
+	for i := 1; i < n; i += 2 {
+		m.Cells[i] = make([]fr.Element, n)
+	}
+	for j := 0; j < n; j++ {
+		for i := range column {
+			column[i] = m.Cells[2*i][j]
+		}
+		extended, err := p.code.EncodeSystematic(column)
+		if err != nil {
+			return nil, err
+		}
+		for i := 1; i < n; i += 2 {
+			m.Cells[i][j] = extended[i]
+		}
+	}
+
+	// interpolate and commit
+	column = make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		for j := range column {
+			column[j] = m.Cells[j][i]
+		}
+		var err error
+		if m.rows[i], err = p.code.Decode(m.Cells[i]); err != nil {
+			return nil, err
+		}
+		if m.columns[i], err = p.code.Decode(column); err != nil {
+			return nil, err
+		}
+		if m.RowCommitments[i], err = kzg.Commit(m.rows[i], p.pk); err != nil {
+			return nil, err
+		}
+		if m.ColumnCommitments[i], err = kzg.Commit(m.columns[i], p.pk); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// Sample returns the cell (row, column) of the extended matrix with its opening proofs.
+func (m *ExtendedMatrix) Sample(row, column int) (Sample, error) {
+	n := len(m.Cells)
+	if row < 0 || row >= n || column < 0 || column >= n {
+		return Sample{}, ErrSampleOutOfRange
+	}
+	code := m.prover.code
+	s := Sample{Row: row, Column: column, Value: m.Cells[row][column]}
+	var err error
+	if s.RowProof, err = kzg.Open(m.rows[row], point(code, column), m.prover.pk); err != nil {
+		return Sample{}, err
+	}
+	if s.ColumnProof, err = kzg.Open(m.columns[column], point(code, row), m.prover.pk); err != nil {
+		return Sample{}, err
+	}
+	return s, nil
+}
+
+// VerifySamples verifies the samples against the row and column commitments, which
+// must have been checked with VerifyCommitments.
+func (v *Verifier) VerifySamples(rowCommitments, columnCommitments []kzg.Digest, samples ...Sample) error {
+	n := 2 * v.k
+	if len(rowCommitments) != n || len(columnCommitments) != n {
+		return fmt.Errorf("%w: %d row and %d column commitments", ErrInvalidSize, len(rowCommitments), len(columnCommitments))
+	}
+	digests := make([]kzg.Digest, 0, 2*len(samples))
+	proofs := make([]kzg.OpeningProof, 0, 2*len(samples))
+	points := make([]fr.Element, 0, 2*len(samples))
+	for _, s := range samples {
+		if s.Row < 0 || s.Row >= n || s.Column < 0 || s.Column >= n {
+			return ErrSampleOutOfRange
+		}
+		if !s.RowProof.ClaimedValue.Equal(&s.Value) || !s.ColumnProof.ClaimedValue.Equal(&s.Value) {
+			return ErrSampleValue
+		}
+		digests = append(digests, rowCommitments[s.Row], columnCommitments[s.Column])
+		proofs = append(proofs, s.RowProof, s.ColumnProof)
+		points = append(points, point(v.code, s.Column), point(v.code, s.Row))
+	}
+	if len(samples) == 0 {
+		return nil
+	}
+	return kzg.BatchVerifyMultiPoints(digests, proofs, points, v.vk)
+}
+
+// VerifyCommitments checks that the 2k commitments, to the rows or to the columns of an
+// extended matrix, are the evaluations on the 2k-th roots of unity of a polynomial of
+// degree less than k with coefficients in G₁, as are the commitments of a 2D extension.
+//
+// For a codeword c of the Reed-Solomon code of dimension k and length n = 2k,
+// ∑ᵢ ωⁱʲ·cᵢ = 0 for 1 ≤ j ≤ n-k. The commitments are checked against a random linear
+// combination of these equations.
+func (v *Verifier) VerifyCommitments(commitments []kzg.Digest) error {
+	n := 2 * v.k
+	if len(commitments) != n {
+		return fmt.Errorf("%w: %d commitments", ErrInvalidSize, len(commitments))
+	}
+
+	// weights wᵢ = ∑ⱼ γʲ·ωⁱʲ, the evaluations of ∑ⱼ γʲXʲ at ωⁱ
+	var gamma fr.Element
+	if _, err := gamma.SetRandom(); err != nil {
+		return err
+	}
+	weights := make([]fr.Element, n)
+	weights[1] = gamma
+	for j := 2; j <= n-v.k; j++ {
+		weights[j].Mul(&weights[j-1], &gamma)
+	}
+	v.code.Domain().FFT(weights, fft.DIF)
+	fft.BitReverse(weights)
+
+	var res bls12381.G1Affine
+	if _, err := res.MultiExp(commitments, weights, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+	if !res.IsInfinity() {
+		return ErrInvalidCommitments
+	}
+	return nil
+}
+
+// point returns ωⁱ, the point of the i-th row or column.
+func point(code *rs.Code, i int) fr.Element {
+	var res fr.Element
+	res.Exp(code.Domain().Generator, big.NewInt(int64(i)))
+	return res
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package das
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft/rs"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAS(t *testing.T) {
+	assert := require.New(t)
+	const k = 8
+
+	srs, err := kzg.NewSRS(k, big.NewInt(42))
+	assert.NoError(err)
+	prover, err := NewProver(k, srs.Pk)
+	assert.NoError(err)
+	verifier, err := NewVerifier(k, srs.Vk)
+	assert.NoError(err)
+
+	data := make([][]fr.Element, k)
+	for i := range data {
+		data[i] = make([]fr.Element, k)
+		for j := range data[i] {
+			data[i][j].SetRandom()
+		}
+	}
+	m, err := prover.Extend(data)
+	assert.NoError(err)
+
+	// the original cells are at the even positions, and the rows and columns are codewords
+	code, err := rs.NewCode(k, 2)
+	assert.NoError(err)
+	column := make([]fr.Element, 2*k)
+	for i := 0; i < 2*k; i++ {
+		if i%2 == 0 {
+			for j := 0; j < k; j++ {
+				assert.True(data[i/2][j].Equal(&m.Cells[i][2*j]))
+			}
+		}
+		for j := range column {
+			column[j] = m.Cells[j][i]
+		}
+		assert.NoError(code.Check(m.Cells[i]))
+		assert.NoError(code.Check(column))
+	}
+
+	// commitments
+	assert.NoError(verifier.VerifyCommitments(m.RowCommitments))
+	assert.NoError(verifier.VerifyCommitments(m.ColumnCommitments))
+	tampered := append([]kzg.Digest{}, m.RowCommitments...)
+	tampered[0], tampered[1] = tampered[1], tampered[0]
+	assert.True(errors.Is(verifier.VerifyCommitments(tampered), ErrInvalidCommitments))
+
+	// samples
+	var samples []Sample
+	for _, c := range [][2]int{{0, 0}, {1, 2}, {2*k - 1, 3}, {5, 2*k - 1}} {
+		s, err := m.Sample(c[0], c[1])
+		assert.NoError(err)
+		assert.NoError(verifier.VerifySamples(m.RowCommitments, m.ColumnCommitments, s))
+		samples = append(samples, s)
+	}
+	assert.NoError(verifier.VerifySamples(m.RowCommitments, m.ColumnCommitments, samples...))
+
+	s := samples[1]
+	s.Value.SetOne()
+	assert.True(errors.Is(verifier.VerifySamples(m.RowCommitments, m.ColumnCommitments, s), ErrSampleValue))
+	s = samples[1]
+	s.Row++
+	assert.Error(verifier.VerifySamples(m.RowCommitments, m.ColumnCommitments, s), "a sample is bound to its position")
+	_, err = m.Sample(2*k, 0)
+	assert.True(errors.Is(err, ErrSampleOutOfRange))
+
+	_, err = NewProver(6, srs.Pk)
+	assert.True(errors.Is(err, ErrInvalidSize))
+	_, err = prover.Extend(data[1:])
+	assert.True(errors.Is(err, ErrInvalidSize))
+}
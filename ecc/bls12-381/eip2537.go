@@ -0,0 +1,175 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bls12381
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/internal/fptower"
+)
+
+// Encodings used by the EIP-2537 precompiles (https://eips.ethereum.org/EIPS/eip-2537).
+//
+// A base field element is encoded on 64 bytes, big endian, with the 16 top
+// bytes set to zero. A G1 point is encoded as x ‖ y and a G2 point as
+// x.A0 ‖ x.A1 ‖ y.A0 ‖ y.A1. The point at infinity is encoded with all bytes
+// set to zero. Pairing check inputs are the concatenation of (G1, G2) pairs.
+const (
+	SizeOfFpEIP2537          = 64
+	SizeOfG1AffineEIP2537    = 2 * SizeOfFpEIP2537
+	SizeOfG2AffineEIP2537    = 4 * SizeOfFpEIP2537
+	SizeOfPairingPairEIP2537 = SizeOfG1AffineEIP2537 + SizeOfG2AffineEIP2537
+	fpPaddingEIP2537         = SizeOfFpEIP2537 - fp.Bytes
+)
+
+var (
+	ErrInvalidEIP2537Padding = errors.New("eip-2537: non zero padding")
+	ErrInvalidEIP2537Size    = errors.New("eip-2537: invalid input size")
+)
+
+// MarshalEIP2537 returns the EIP-2537 encoding of p.
+func (p *G1Affine) MarshalEIP2537() (res [SizeOfG1AffineEIP2537]byte) {
+	if p.IsInfinity() {
+		return
+	}
+	putFpEIP2537(res[:SizeOfFpEIP2537], &p.X)
+	putFpEIP2537(res[SizeOfFpEIP2537:], &p.Y)
+	return
+}
+
+// UnmarshalEIP2537 sets p from its EIP-2537 encoding. It returns an error if
+// the padding is not zero, if a coordinate is not canonical, or if the point is
+// not on the curve or not in the prime order subgroup.
+func (p *G1Affine) UnmarshalEIP2537(buf []byte) error {
+	if len(buf) != SizeOfG1AffineEIP2537 {
+		return ErrInvalidEIP2537Size
+	}
+	if err := setFpEIP2537(&p.X, buf[:SizeOfFpEIP2537]); err != nil {
+		return err
+	}
+	if err := setFpEIP2537(&p.Y, buf[SizeOfFpEIP2537:]); err != nil {
+		return err
+	}
+	if p.IsInfinity() {
+		return nil
+	}
+	if !p.IsOnCurve() {
+		return errors.New("eip-2537: point not on curve")
+	}
+	if !p.IsInSubGroup() {
+		return errors.New("eip-2537: point not in subgroup")
+	}
+	return nil
+}
+
+// MarshalEIP2537 returns the EIP-2537 encoding of p.
+func (p *G2Affine) MarshalEIP2537() (res [SizeOfG2AffineEIP2537]byte) {
+	if p.IsInfinity() {
+		return
+	}
+	putE2EIP2537(res[:2*SizeOfFpEIP2537], &p.X)
+	putE2EIP2537(res[2*SizeOfFpEIP2537:], &p.Y)
+	return
+}
+
+// UnmarshalEIP2537 sets p from its EIP-2537 encoding. It returns an error if
+// the padding is not zero, if a coordinate is not canonical, or if the point is
+// not on the curve or not in the prime order subgroup.
+func (p *G2Affine) UnmarshalEIP2537(buf []byte) error {
+	if len(buf) != SizeOfG2AffineEIP2537 {
+		return ErrInvalidEIP2537Size
+	}
+	if err := setE2EIP2537(&p.X, buf[:2*SizeOfFpEIP2537]); err != nil {
+		return err
+	}
+	if err := setE2EIP2537(&p.Y, buf[2*SizeOfFpEIP2537:]); err != nil {
+		return err
+	}
+	if p.IsInfinity() {
+		return nil
+	}
+	if !p.IsOnCurve() {
+		return errors.New("eip-2537: point not on curve")
+	}
+	if !p.IsInSubGroup() {
+		return errors.New("eip-2537: point not in subgroup")
+	}
+	return nil
+}
+
+// PairingCheckInputEIP2537 returns the input of the EIP-2537 pairing check
+// precompile for ∏ᵢ e(Pᵢ, Qᵢ) == 1.
+func PairingCheckInputEIP2537(P []G1Affine, Q []G2Affine) ([]byte, error) {
+	if len(P) == 0 || len(P) != len(Q) {
+		return nil, errors.New("invalid inputs sizes")
+	}
+	res := make([]byte, 0, len(P)*SizeOfPairingPairEIP2537)
+	for i := range P {
+		p := P[i].MarshalEIP2537()
+		q := Q[i].MarshalEIP2537()
+		res = append(res, p[:]...)
+		res = append(res, q[:]...)
+	}
+	return res, nil
+}
+
+// DecodePairingCheckInputEIP2537 parses the input of the EIP-2537 pairing
+// check precompile. The result can be fed to [PairingCheck].
+func DecodePairingCheckInputEIP2537(buf []byte) ([]G1Affine, []G2Affine, error) {
+	if len(buf) == 0 || len(buf)%SizeOfPairingPairEIP2537 != 0 {
+		return nil, nil, ErrInvalidEIP2537Size
+	}
+	n := len(buf) / SizeOfPairingPairEIP2537
+	P := make([]G1Affine, n)
+	Q := make([]G2Affine, n)
+	for i := 0; i < n; i++ {
+		pair := buf[i*SizeOfPairingPairEIP2537 : (i+1)*SizeOfPairingPairEIP2537]
+		if err := P[i].UnmarshalEIP2537(pair[:SizeOfG1AffineEIP2537]); err != nil {
+			return nil, nil, err
+		}
+		if err := Q[i].UnmarshalEIP2537(pair[SizeOfG1AffineEIP2537:]); err != nil {
+			return nil, nil, err
+		}
+	}
+	return P, Q, nil
+}
+
+func putFpEIP2537(buf []byte, x *fp.Element) {
+	fp.BigEndian.PutElement((*[fp.Bytes]byte)(buf[fpPaddingEIP2537:]), *x)
+}
+
+func setFpEIP2537(x *fp.Element, buf []byte) error {
+	for i := 0; i < fpPaddingEIP2537; i++ {
+		if buf[i] != 0 {
+			return ErrInvalidEIP2537Padding
+		}
+	}
+	var err error
+	*x, err = fp.BigEndian.Element((*[fp.Bytes]byte)(buf[fpPaddingEIP2537:]))
+	return err
+}
+
+func putE2EIP2537(buf []byte, x *fptower.E2) {
+	putFpEIP2537(buf[:SizeOfFpEIP2537], &x.A0)
+	putFpEIP2537(buf[SizeOfFpEIP2537:], &x.A1)
+}
+
+func setE2EIP2537(x *fptower.E2, buf []byte) error {
+	if err := setFpEIP2537(&x.A0, buf[:SizeOfFpEIP2537]); err != nil {
+		return err
+	}
+	return setFpEIP2537(&x.A1, buf[SizeOfFpEIP2537:])
+}
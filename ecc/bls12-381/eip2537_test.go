@@ -0,0 +1,86 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bls12381
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEIP2537RoundTrip(t *testing.T) {
+	assert := require.New(t)
+
+	var s big.Int
+	s.SetUint64(0xdeadbeef)
+	var p G1Affine
+	var q G2Affine
+	p.ScalarMultiplication(&g1GenAff, &s)
+	q.ScalarMultiplication(&g2GenAff, &s)
+
+	pb := p.MarshalEIP2537()
+	qb := q.MarshalEIP2537()
+	for i := 0; i < fpPaddingEIP2537; i++ {
+		assert.Zero(pb[i])
+		assert.Zero(qb[SizeOfFpEIP2537+i])
+	}
+
+	var p2 G1Affine
+	var q2 G2Affine
+	assert.NoError(p2.UnmarshalEIP2537(pb[:]))
+	assert.NoError(q2.UnmarshalEIP2537(qb[:]))
+	assert.True(p.Equal(&p2))
+	assert.True(q.Equal(&q2))
+
+	// point at infinity is all zeros
+	var inf G1Affine
+	infb := inf.MarshalEIP2537()
+	assert.Equal([SizeOfG1AffineEIP2537]byte{}, infb)
+	assert.NoError(p2.UnmarshalEIP2537(infb[:]))
+	assert.True(p2.IsInfinity())
+
+	// non zero padding is rejected
+	pb[0] = 1
+	assert.ErrorIs(p2.UnmarshalEIP2537(pb[:]), ErrInvalidEIP2537Padding)
+
+	// points off the curve are rejected
+	pb = p.MarshalEIP2537()
+	pb[SizeOfG1AffineEIP2537-1] ^= 1
+	assert.Error(p2.UnmarshalEIP2537(pb[:]))
+
+	// wrong sizes are rejected
+	assert.ErrorIs(q2.UnmarshalEIP2537(qb[:10]), ErrInvalidEIP2537Size)
+}
+
+func TestPairingCheckInputEIP2537(t *testing.T) {
+	assert := require.New(t)
+
+	// e(P, Q).e(-P, Q) == 1
+	var negP G1Affine
+	negP.Neg(&g1GenAff)
+	input, err := PairingCheckInputEIP2537([]G1Affine{g1GenAff, negP}, []G2Affine{g2GenAff, g2GenAff})
+	assert.NoError(err)
+	assert.Len(input, 2*SizeOfPairingPairEIP2537)
+
+	P, Q, err := DecodePairingCheckInputEIP2537(input)
+	assert.NoError(err)
+	ok, err := PairingCheck(P, Q)
+	assert.NoError(err)
+	assert.True(ok)
+
+	_, _, err = DecodePairingCheckInputEIP2537(input[1:])
+	assert.ErrorIs(err, ErrInvalidEIP2537Size)
+}
@@ -0,0 +1,40 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package elgamal provides ElGamal encryption on the G1 group of the bls12-381 curve.
+//
+// A message is a point M of G1, encrypted under the public key A = sk⋅G as
+//
+//	(C₁, C₂) = (r⋅G, M + r⋅A)
+//
+// for a random r, and decrypted as M = C₂ - sk⋅C₁. Ciphertexts are additively homomorphic
+// and can be re-randomized.
+//
+// The exponential (or lifted) variant encrypts a small integer m as the point m⋅G: the
+// sum of two ciphertexts is then an encryption of the sum of the integers, as needed
+// for e.g. private voting. Decryption recovers m⋅G, and then m by solving a discrete
+// logarithm with a precomputed baby-step giant-step table (DLogTable), which is only
+// feasible for small m.
+//
+// EncryptVerifiable encrypts a field element m in this way, with a proof that m is the
+// value of a commitment m⋅B₀ + ρ⋅B₁, a Pedersen commitment or a KZG commitment to the
+// polynomial m + ρX for instance: a sealed bid can be checked against the commitment
+// of the bidder without being revealed.
+//
+// Documentation:
+// - Wikipedia: https://en.wikipedia.org/wiki/ElGamal_encryption
+// - Cramer, Gennaro, Schoenmakers, "A secure and optimally efficient multi-authority election scheme", Eurocrypt 1997
+package elgamal
@@ -0,0 +1,171 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package elgamal
+
+import (
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+var (
+	ErrInvalidCiphertext = errors.New("invalid ciphertext: points must be in G1")
+	ErrMessageTooLarge   = errors.New("the decrypted message is larger than the bound of the discrete logarithm table")
+)
+
+const sizeFr = fr.Bytes
+
+var (
+	order = fr.Modulus()
+	one   = new(big.Int).SetInt64(1)
+)
+
+// PublicKey represents an ElGamal public key A = sk⋅G
+type PublicKey struct {
+	A bls12381.G1Affine
+}
+
+// PrivateKey represents an ElGamal private key
+type PrivateKey struct {
+	PublicKey PublicKey
+	scalar    [sizeFr]byte // secret scalar, in big Endian
+}
+
+// Ciphertext represents an ElGamal ciphertext (C₁, C₂) = (r⋅G, M + r⋅A)
+type Ciphertext struct {
+	C1, C2 bls12381.G1Affine
+}
+
+// randomScalar returns a random scalar in [1, order-1].
+func randomScalar(rand io.Reader) (k *big.Int, err error) {
+	b := make([]byte, fr.Bits/8+8)
+	_, err = io.ReadFull(rand, b)
+	if err != nil {
+		return
+	}
+
+	k = new(big.Int).SetBytes(b)
+	n := new(big.Int).Sub(order, one)
+	k.Mod(k, n)
+	k.Add(k, one)
+	return
+}
+
+// GenerateKey generates a public and private key pair.
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	k, err := randomScalar(rand)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey := new(PrivateKey)
+	k.FillBytes(privateKey.scalar[:sizeFr])
+	privateKey.PublicKey.A.ScalarMultiplicationBase(k)
+	return privateKey, nil
+}
+
+// Public returns the public key associated to the private key.
+func (privKey *PrivateKey) Public() *PublicKey {
+	var pub PublicKey
+	pub.A.Set(&privKey.PublicKey.A)
+	return &pub
+}
+
+// Encrypt encrypts the point m, which must be in G1, with randomness from rand.
+func (pub *PublicKey) Encrypt(m *bls12381.G1Affine, rand io.Reader) (Ciphertext, error) {
+	var ct Ciphertext
+	r, err := randomScalar(rand)
+	if err != nil {
+		return ct, err
+	}
+	ct.C1.ScalarMultiplicationBase(r)
+	ct.C2.ScalarMultiplication(&pub.A, r)
+	ct.C2.Add(&ct.C2, m)
+	return ct, nil
+}
+
+// EncryptExp encrypts the integer m as the point m⋅G (exponential ElGamal), with
+// randomness from rand. The ciphertext is decrypted with DecryptExp.
+func (pub *PublicKey) EncryptExp(m uint64, rand io.Reader) (Ciphertext, error) {
+	var M bls12381.G1Affine
+	M.ScalarMultiplicationBase(new(big.Int).SetUint64(m))
+	return pub.Encrypt(&M, rand)
+}
+
+// Rerandomize returns a fresh encryption of the plaintext of ct: (C₁ + r⋅G, C₂ + r⋅A).
+func (pub *PublicKey) Rerandomize(ct *Ciphertext, rand io.Reader) (Ciphertext, error) {
+	var zero bls12381.G1Affine
+	res, err := pub.Encrypt(&zero, rand)
+	if err != nil {
+		return res, err
+	}
+	return *res.Add(&res, ct), nil
+}
+
+// Decrypt returns the point M = C₂ - sk⋅C₁ encrypted in ct.
+func (privKey *PrivateKey) Decrypt(ct *Ciphertext) (bls12381.G1Affine, error) {
+	var M bls12381.G1Affine
+	if !ct.C1.IsInSubGroup() || !ct.C2.IsInSubGroup() {
+		return M, ErrInvalidCiphertext
+	}
+	M.ScalarMultiplication(&ct.C1, new(big.Int).SetBytes(privKey.scalar[:sizeFr]))
+	M.Sub(&ct.C2, &M)
+	return M, nil
+}
+
+// DecryptExp returns the integer m encrypted in ct with EncryptExp, or with the
+// homomorphic operations on such ciphertexts. m must be smaller than the bound of table,
+// else ErrMessageTooLarge is returned.
+func (privKey *PrivateKey) DecryptExp(ct *Ciphertext, table *DLogTable) (uint64, error) {
+	M, err := privKey.Decrypt(ct)
+	if err != nil {
+		return 0, err
+	}
+	return table.DLog(&M)
+}
+
+// Add sets ct to a + b, an encryption of the sum of the plaintexts of a and b, and
+// returns ct.
+func (ct *Ciphertext) Add(a, b *Ciphertext) *Ciphertext {
+	ct.C1.Add(&a.C1, &b.C1)
+	ct.C2.Add(&a.C2, &b.C2)
+	return ct
+}
+
+// Sub sets ct to a - b, an encryption of the difference of the plaintexts of a and b,
+// and returns ct.
+func (ct *Ciphertext) Sub(a, b *Ciphertext) *Ciphertext {
+	ct.C1.Sub(&a.C1, &b.C1)
+	ct.C2.Sub(&a.C2, &b.C2)
+	return ct
+}
+
+// ScalarMultiplication sets ct to k⋅a, an encryption of k times the plaintext of a,
+// and returns ct.
+func (ct *Ciphertext) ScalarMultiplication(a *Ciphertext, k *big.Int) *Ciphertext {
+	ct.C1.ScalarMultiplication(&a.C1, k)
+	ct.C2.ScalarMultiplication(&a.C2, k)
+	return ct
+}
+
+// Equal returns true if ct and a are the same ciphertext.
+func (ct *Ciphertext) Equal(a *Ciphertext) bool {
+	return ct.C1.Equal(&a.C1) && ct.C2.Equal(&a.C2)
+}
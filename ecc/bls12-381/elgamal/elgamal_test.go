@@ -0,0 +1,163 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package elgamal
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestElGamal(t *testing.T) {
+
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = 10
+	}
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[BLS12-381] decryption of an encryption is the message", prop.ForAll(
+		func(m uint64) bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			var M bls12381.G1Affine
+			M.ScalarMultiplicationBase(new(big.Int).SetUint64(m))
+
+			ct, err := privKey.PublicKey.Encrypt(&M, rand.Reader)
+			if err != nil {
+				return false
+			}
+			dec, err := privKey.Decrypt(&ct)
+			return err == nil && dec.Equal(&M)
+		},
+		gen.UInt64(),
+	))
+
+	properties.Property("[BLS12-381] ciphertexts are additively homomorphic and can be re-randomized", prop.ForAll(
+		func(a, b uint64) bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			pub := privKey.Public()
+			ca, _ := pub.EncryptExp(a, rand.Reader)
+			cb, _ := pub.EncryptExp(b, rand.Reader)
+
+			var sum Ciphertext
+			sum.Add(&ca, &cb)
+			rerandomized, _ := pub.Rerandomize(&sum, rand.Reader)
+			if rerandomized.Equal(&sum) {
+				return false
+			}
+
+			var expected, dec bls12381.G1Affine
+			expected.ScalarMultiplicationBase(new(big.Int).SetUint64(a + b))
+			dec, err := privKey.Decrypt(&rerandomized)
+			return err == nil && dec.Equal(&expected)
+		},
+		gen.UInt64Range(0, 1<<32),
+		gen.UInt64Range(0, 1<<32),
+	))
+
+	properties.Property("[BLS12-381] ciphertext marshalling round trips", prop.ForAll(
+		func(m uint64) bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			ct, _ := privKey.PublicKey.EncryptExp(m, rand.Reader)
+			var res Ciphertext
+			n, err := res.SetBytes(ct.Bytes())
+			if err != nil || n != SizeCiphertext || !res.Equal(&ct) {
+				return false
+			}
+			var pub PublicKey
+			n, err = pub.SetBytes(privKey.PublicKey.Bytes())
+			return err == nil && n == SizePublicKey && pub.A.Equal(&privKey.PublicKey.A)
+		},
+		gen.UInt64(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestElGamalExp(t *testing.T) {
+	t.Parallel()
+
+	const bound = 1000
+	table := NewDLogTable(bound)
+	privKey, _ := GenerateKey(rand.Reader)
+	pub := privKey.Public()
+
+	// tally of votes
+	votes := []uint64{1, 0, 1, 1, 0, 1, 0, 0, 1, 1}
+	var tally Ciphertext
+	for i, v := range votes {
+		ct, err := pub.EncryptExp(v, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			tally = ct
+		} else {
+			tally.Add(&tally, &ct)
+		}
+	}
+	m, err := privKey.DecryptExp(&tally, table)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m != 6 {
+		t.Fatalf("expected 6, got %d", m)
+	}
+
+	for _, m := range []uint64{0, 1, 31, 32, 33, bound - 1} {
+		ct, _ := pub.EncryptExp(m, rand.Reader)
+		dec, err := privKey.DecryptExp(&ct, table)
+		if err != nil || dec != m {
+			t.Fatalf("DecryptExp(EncryptExp(%d)) = %d, %v", m, dec, err)
+		}
+	}
+
+	var scaled Ciphertext
+	ct, _ := pub.EncryptExp(7, rand.Reader)
+	scaled.ScalarMultiplication(&ct, big.NewInt(5))
+	if dec, err := privKey.DecryptExp(&scaled, table); err != nil || dec != 35 {
+		t.Fatalf("DecryptExp(5⋅EncryptExp(7)) = %d, %v", dec, err)
+	}
+	var diff Ciphertext
+	diff.Sub(&scaled, &ct)
+	if dec, err := privKey.DecryptExp(&diff, table); err != nil || dec != 28 {
+		t.Fatalf("DecryptExp(35 - 7) = %d, %v", dec, err)
+	}
+
+	for _, m := range []uint64{bound, bound + 1, 1 << 40} {
+		ct, _ := pub.EncryptExp(m, rand.Reader)
+		if _, err := privKey.DecryptExp(&ct, table); err != ErrMessageTooLarge {
+			t.Fatalf("expected ErrMessageTooLarge for %d", m)
+		}
+	}
+}
+
+func BenchmarkDecryptExp(b *testing.B) {
+	table := NewDLogTable(1 << 24)
+	privKey, _ := GenerateKey(rand.Reader)
+	ct, _ := privKey.PublicKey.EncryptExp(1<<24-1, rand.Reader)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		privKey.DecryptExp(&ct, table)
+	}
+}
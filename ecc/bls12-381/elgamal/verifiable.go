@@ -0,0 +1,186 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package elgamal
+
+import (
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+var (
+	ErrInvalidCommitmentBasis = errors.New("the commitment basis must have two points")
+	ErrInvalidEncryptionProof = errors.New("invalid proof of encryption")
+)
+
+// EncryptionProof is a non-interactive sigma proof that a ciphertext (C₁, C₂) =
+// (r⋅G, m⋅G + r⋅A) encrypts the value m of a commitment C = m⋅B₀ + ρ⋅B₁, with the
+// Fiat-Shamir challenge c.
+type EncryptionProof struct {
+	T1 bls12381.G1Affine // kᵣ⋅G
+	T2 bls12381.G1Affine // kₘ⋅G + kᵣ⋅A
+	T3 bls12381.G1Affine // kₘ⋅B₀ + kᵨ⋅B₁
+
+	Zm, Zr, Zrho fr.Element // kₘ + c⋅m, kᵣ + c⋅r, kᵨ + c⋅ρ
+}
+
+// EncryptVerifiable encrypts the field element m as m⋅G (exponential ElGamal) with
+// randomness from rand, and proves that the plaintext is the value committed to in
+// C = m⋅B₀ + ρ⋅B₁, basis being (B₀, B₁) and blinding ρ. The basis of a Pedersen
+// commitment to (m, ρ), or the first two points of a KZG SRS (a commitment to the
+// polynomial m + ρX) give such commitments.
+//
+// The challenge is derived with hf from the public key, the basis, the commitment, the
+// ciphertext, the prover's first message and the optional dataTranscript. The plaintext
+// is recovered with DecryptExp, m must then be small (a bid for instance).
+func (pub *PublicKey) EncryptVerifiable(m, blinding *fr.Element, basis []bls12381.G1Affine, hf hash.Hash, rand io.Reader, dataTranscript ...[]byte) (Ciphertext, EncryptionProof, error) {
+	var ct Ciphertext
+	var proof EncryptionProof
+	if len(basis) != 2 {
+		return ct, proof, ErrInvalidCommitmentBasis
+	}
+
+	r, err := randomScalar(rand)
+	if err != nil {
+		return ct, proof, err
+	}
+	var bm big.Int
+	m.BigInt(&bm)
+	var M bls12381.G1Affine
+	M.ScalarMultiplicationBase(&bm)
+	ct.C1.ScalarMultiplicationBase(r)
+	ct.C2.ScalarMultiplication(&pub.A, r)
+	ct.C2.Add(&ct.C2, &M)
+	commitment := commit(basis, m, blinding)
+
+	// first message
+	var k [3]*big.Int // kₘ, kᵣ, kᵨ
+	for i := range k {
+		if k[i], err = randomScalar(rand); err != nil {
+			return ct, proof, err
+		}
+	}
+	var tmp bls12381.G1Affine
+	proof.T1.ScalarMultiplicationBase(k[1])
+	proof.T2.ScalarMultiplicationBase(k[0])
+	tmp.ScalarMultiplication(&pub.A, k[1])
+	proof.T2.Add(&proof.T2, &tmp)
+	proof.T3.ScalarMultiplication(&basis[0], k[0])
+	tmp.ScalarMultiplication(&basis[1], k[2])
+	proof.T3.Add(&proof.T3, &tmp)
+
+	c, err := deriveEncryptionChallenge(pub, basis, &commitment, &ct, &proof, hf, dataTranscript...)
+	if err != nil {
+		return ct, proof, err
+	}
+
+	var x fr.Element
+	proof.Zm.SetBigInt(k[0])
+	proof.Zm.Add(&proof.Zm, x.Mul(&c, m))
+	proof.Zr.SetBigInt(k[1])
+	x.SetBigInt(r)
+	proof.Zr.Add(&proof.Zr, x.Mul(&x, &c))
+	proof.Zrho.SetBigInt(k[2])
+	proof.Zrho.Add(&proof.Zrho, x.Mul(&c, blinding))
+	return ct, proof, nil
+}
+
+// VerifyEncryption checks that ct encrypts the value committed to in commitment, over
+// basis. hf and dataTranscript must be the ones used by the prover.
+func (pub *PublicKey) VerifyEncryption(ct *Ciphertext, commitment *bls12381.G1Affine, basis []bls12381.G1Affine, proof *EncryptionProof, hf hash.Hash, dataTranscript ...[]byte) error {
+	if len(basis) != 2 {
+		return ErrInvalidCommitmentBasis
+	}
+	for _, p := range []*bls12381.G1Affine{&ct.C1, &ct.C2, commitment, &proof.T1, &proof.T2, &proof.T3} {
+		if !p.IsInSubGroup() {
+			return ErrInvalidEncryptionProof
+		}
+	}
+
+	c, err := deriveEncryptionChallenge(pub, basis, commitment, ct, proof, hf, dataTranscript...)
+	if err != nil {
+		return err
+	}
+	var bc, bZm, bZr, bZrho big.Int
+	c.BigInt(&bc)
+	proof.Zm.BigInt(&bZm)
+	proof.Zr.BigInt(&bZr)
+	proof.Zrho.BigInt(&bZrho)
+
+	// zᵣ⋅G == T₁ + c⋅C₁
+	var lhs, rhs, tmp bls12381.G1Affine
+	lhs.ScalarMultiplicationBase(&bZr)
+	rhs.ScalarMultiplication(&ct.C1, &bc)
+	rhs.Add(&rhs, &proof.T1)
+	if !lhs.Equal(&rhs) {
+		return ErrInvalidEncryptionProof
+	}
+
+	// zₘ⋅G + zᵣ⋅A == T₂ + c⋅C₂
+	lhs.ScalarMultiplicationBase(&bZm)
+	tmp.ScalarMultiplication(&pub.A, &bZr)
+	lhs.Add(&lhs, &tmp)
+	rhs.ScalarMultiplication(&ct.C2, &bc)
+	rhs.Add(&rhs, &proof.T2)
+	if !lhs.Equal(&rhs) {
+		return ErrInvalidEncryptionProof
+	}
+
+	// zₘ⋅B₀ + zᵨ⋅B₁ == T₃ + c⋅C
+	lhs.ScalarMultiplication(&basis[0], &bZm)
+	tmp.ScalarMultiplication(&basis[1], &bZrho)
+	lhs.Add(&lhs, &tmp)
+	rhs.ScalarMultiplication(commitment, &bc)
+	rhs.Add(&rhs, &proof.T3)
+	if !lhs.Equal(&rhs) {
+		return ErrInvalidEncryptionProof
+	}
+	return nil
+}
+
+// commit returns m⋅B₀ + ρ⋅B₁
+func commit(basis []bls12381.G1Affine, m, blinding *fr.Element) bls12381.G1Affine {
+	var bm, bBlinding big.Int
+	m.BigInt(&bm)
+	blinding.BigInt(&bBlinding)
+	var res, tmp bls12381.G1Affine
+	res.ScalarMultiplication(&basis[0], &bm)
+	tmp.ScalarMultiplication(&basis[1], &bBlinding)
+	return *res.Add(&res, &tmp)
+}
+
+func deriveEncryptionChallenge(pub *PublicKey, basis []bls12381.G1Affine, commitment *bls12381.G1Affine, ct *Ciphertext, proof *EncryptionProof, hf hash.Hash, dataTranscript ...[]byte) (fr.Element, error) {
+	fs := fiatshamir.NewTranscript(hf, "c")
+	toBind := []*bls12381.G1Affine{&pub.A, &basis[0], &basis[1], commitment, &ct.C1, &ct.C2, &proof.T1, &proof.T2, &proof.T3}
+	for _, p := range toBind {
+		b := p.Bytes()
+		if err := fs.Bind("c", b[:]); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	for i := range dataTranscript {
+		if err := fs.Bind("c", dataTranscript[i]); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	return fiatshamir.ComputeChallengeFr[fr.Element](fs, "c")
+}
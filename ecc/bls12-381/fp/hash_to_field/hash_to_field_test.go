@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	fieldhash "github.com/consensys/gnark-crypto/field/hash"
 )
 
 func TestHashInterface(t *testing.T) {
@@ -39,3 +40,59 @@ func TestHashInterface(t *testing.T) {
 		t.Error("not equal")
 	}
 }
+
+func TestHashExpanders(t *testing.T) {
+	msg := []byte("test")
+	sep := []byte("separator")
+	const count = 5
+
+	// xmd with SHA-256 should match fp.Hash
+	expected, err := fp.Hash(msg, sep, count)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := Hash(fieldhash.ExpanderXmdSHA256, msg, sep, count)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range expected {
+		if !expected[i].Equal(&res[i]) {
+			t.Fatal("xmd: not equal to fp.Hash")
+		}
+	}
+
+	// xof
+	for _, expand := range []fieldhash.Expander{fieldhash.ExpanderXofSHAKE128, fieldhash.ExpanderXofSHAKE256} {
+		res, err := Hash(expand, msg, sep, count)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res) != count {
+			t.Fatal("wrong number of elements")
+		}
+		// the expanded length is part of the input, so the first element depends on count
+		single, err := Hash(expand, msg, sep, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if single[0].Equal(&res[0]) {
+			t.Fatal("the expanded length should be bound to the output")
+		}
+		other, err := Hash(expand, msg, []byte("other separator"), count)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if other[0].Equal(&res[0]) {
+			t.Fatal("different domains should give different elements")
+		}
+
+		htfFn := NewWithExpander(sep, expand)
+		htfFn.Write(msg)
+		bts := htfFn.Sum(nil)
+		var res2 fp.Element
+		res2.SetBytes(bts[:fp.Bytes])
+		if !single[0].Equal(&res2) {
+			t.Error("hasher: not equal")
+		}
+	}
+}
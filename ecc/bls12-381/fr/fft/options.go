@@ -17,9 +17,9 @@
 package fft
 
 import (
-	"runtime"
-
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+
+	"github.com/consensys/gnark-crypto/utils/concurrency"
 )
 
 // Option defines option for altering the behavior of FFT methods.
@@ -28,8 +28,10 @@ import (
 type Option func(fftConfig) fftConfig
 
 type fftConfig struct {
-	coset   bool
-	nbTasks int
+	coset     bool
+	nbTasks   int
+	noBackend bool
+	arena     *Arena
 }
 
 // OnCoset if provided, FFT(a) returns the evaluation of a on a coset.
@@ -53,12 +55,30 @@ func WithNbTasks(nbTasks int) Option {
 	}
 }
 
+// WithArena allocates the temporary buffers of the FFT (needed when the domain
+// was built WithoutPrecompute, and by the six-step FFT of large domains) from arena.
+func WithArena(arena *Arena) Option {
+	return func(opt fftConfig) fftConfig {
+		opt.arena = arena
+		return opt
+	}
+}
+
+// WithoutBackend forces the FFT to run on the CPU, even if a [Backend] is
+// registered.
+func WithoutBackend() Option {
+	return func(opt fftConfig) fftConfig {
+		opt.noBackend = true
+		return opt
+	}
+}
+
 // default options
 func fftOptions(opts ...Option) fftConfig {
 	// apply options
 	opt := fftConfig{
 		coset:   false,
-		nbTasks: runtime.NumCPU(),
+		nbTasks: concurrency.MaxTasks(),
 	}
 	for _, option := range opts {
 		opt = option(opt)
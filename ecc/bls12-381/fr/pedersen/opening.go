@@ -0,0 +1,173 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package pedersen
+
+import (
+	"crypto/rand"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+// OpeningProof is a non-interactive Schnorr-style proof of knowledge of an
+// opening of a commitment C = ∑ vᵢBᵢ over a proving key's basis. Unlike
+// [ProvingKey.ProveKnowledge], it does not rely on a trusted setup nor on
+// pairings, at the cost of a proof linear in the number of values.
+type OpeningProof struct {
+	A curve.G1Affine // A = ∑ rᵢBᵢ for random rᵢ
+	Z []fr.Element   // zᵢ = rᵢ + c⋅vᵢ where c is the Fiat-Shamir challenge
+}
+
+// ProveOpening computes a proof of knowledge of values such that commitment
+// is a commitment to values over the proving key's basis. The challenge is
+// derived with hf from the basis, the commitment, the prover's first message
+// and the optional dataTranscript.
+func (pk *ProvingKey) ProveOpening(values []fr.Element, commitment curve.G1Affine, hf hash.Hash, dataTranscript ...[]byte) (OpeningProof, error) {
+	return pk.ProveOpeningWithRandomness(rand.Reader, values, commitment, hf, dataTranscript...)
+}
+
+// ProveOpeningWithRandomness is like ProveOpening, but draws the blinding
+// factors rᵢ from rnd. With a seeded reader the proof is reproducible; rnd
+// must be unpredictable for the proof to be zero-knowledge.
+func (pk *ProvingKey) ProveOpeningWithRandomness(rnd io.Reader, values []fr.Element, commitment curve.G1Affine, hf hash.Hash, dataTranscript ...[]byte) (OpeningProof, error) {
+	var proof OpeningProof
+	if len(values) != len(pk.Basis) {
+		return proof, errors.New("must have as many values as basis elements")
+	}
+
+	r := make([]fr.Element, len(values))
+	for i := range r {
+		s, err := randomScalar(rnd)
+		if err != nil {
+			return proof, err
+		}
+		r[i].SetBigInt(s)
+	}
+	if _, err := proof.A.MultiExp(pk.Basis, r, ecc.MultiExpConfig{NbTasks: 1}); err != nil {
+		return proof, err
+	}
+
+	c, err := deriveOpeningChallenge(pk.Basis, commitment, proof.A, hf, dataTranscript...)
+	if err != nil {
+		return proof, err
+	}
+
+	proof.Z = make([]fr.Element, len(values))
+	for i := range proof.Z {
+		proof.Z[i].Mul(&values[i], &c).Add(&proof.Z[i], &r[i])
+	}
+	return proof, nil
+}
+
+// VerifyOpening checks a proof of knowledge of an opening of commitment over
+// the proving key's basis. The proving key is public, hf and dataTranscript
+// must be the ones used by the prover.
+func (pk *ProvingKey) VerifyOpening(commitment curve.G1Affine, proof OpeningProof, hf hash.Hash, dataTranscript ...[]byte) error {
+	if len(proof.Z) != len(pk.Basis) {
+		return errors.New("proof length mismatch")
+	}
+	if !commitment.IsInSubGroup() || !proof.A.IsInSubGroup() {
+		return errors.New("subgroup check failed")
+	}
+
+	c, err := deriveOpeningChallenge(pk.Basis, commitment, proof.A, hf, dataTranscript...)
+	if err != nil {
+		return err
+	}
+
+	// ∑ zᵢBᵢ == A + [c]C
+	var lhs curve.G1Affine
+	if _, err = lhs.MultiExp(pk.Basis, proof.Z, ecc.MultiExpConfig{NbTasks: 1}); err != nil {
+		return err
+	}
+	var rhs curve.G1Jac
+	var cBigInt big.Int
+	c.BigInt(&cBigInt)
+	rhs.FromAffine(&commitment)
+	rhs.ScalarMultiplication(&rhs, &cBigInt)
+	rhs.AddMixed(&proof.A)
+
+	var lhsJac curve.G1Jac
+	lhsJac.FromAffine(&lhs)
+	if !lhsJac.Equal(&rhs) {
+		return errors.New("proof rejected")
+	}
+	return nil
+}
+
+// CombineCommitments returns ∑ coeffs[i]⋅commitments[i]. By homomorphism, it
+// is a commitment to [CombineValues](values, coeffs) when commitments[i] is a
+// commitment to values[i] over the same basis.
+func CombineCommitments(commitments []curve.G1Affine, coeffs []fr.Element) (curve.G1Affine, error) {
+	var res curve.G1Affine
+	if len(commitments) != len(coeffs) {
+		return res, errors.New("must have as many coefficients as commitments")
+	}
+	_, err := res.MultiExp(commitments, coeffs, ecc.MultiExpConfig{NbTasks: 1})
+	return res, err
+}
+
+// CombineValues returns the vector ∑ coeffs[i]⋅values[i]. All value vectors
+// must have the same length.
+func CombineValues(values [][]fr.Element, coeffs []fr.Element) ([]fr.Element, error) {
+	if len(values) != len(coeffs) {
+		return nil, errors.New("must have as many coefficients as value vectors")
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	res := make([]fr.Element, len(values[0]))
+	var tmp fr.Element
+	for i := range values {
+		if len(values[i]) != len(res) {
+			return nil, errors.New("value vectors length mismatch")
+		}
+		for j := range res {
+			tmp.Mul(&values[i][j], &coeffs[i])
+			res[j].Add(&res[j], &tmp)
+		}
+	}
+	return res, nil
+}
+
+func deriveOpeningChallenge(basis []curve.G1Affine, commitment, a curve.G1Affine, hf hash.Hash, dataTranscript ...[]byte) (fr.Element, error) {
+	fs := fiatshamir.NewTranscript(hf, "c")
+	for i := range basis {
+		if err := fs.Bind("c", basis[i].Marshal()); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	if err := fs.Bind("c", commitment.Marshal()); err != nil {
+		return fr.Element{}, err
+	}
+	if err := fs.Bind("c", a.Marshal()); err != nil {
+		return fr.Element{}, err
+	}
+	for i := range dataTranscript {
+		if err := fs.Bind("c", dataTranscript[i]); err != nil {
+			return fr.Element{}, err
+		}
+	}
+
+	return fiatshamir.DeriveChallengeFr[fr.Element](fs, "c")
+}
@@ -0,0 +1,129 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package permutation
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+)
+
+func TestNewCopyConstraints(t *testing.T) {
+
+	// {(0,0), (1,3), (2,1)} and {(0,2), (0,5)} are equal, through redundant pairs
+	c, err := NewCopyConstraints(3, 8, [][2]Cell{
+		{{2, 1}, {1, 3}},
+		{{0, 0}, {2, 1}},
+		{{1, 3}, {0, 0}},
+		{{0, 5}, {0, 2}},
+	})
+	require.NoError(t, err)
+
+	idx := func(column, row int) int { return column*8 + row }
+	next := func(column, row int) int { return c.sigma[idx(column, row)] }
+	assert.Equal(t, idx(1, 3), next(0, 0))
+	assert.Equal(t, idx(2, 1), next(1, 3))
+	assert.Equal(t, idx(0, 0), next(2, 1))
+	assert.Equal(t, idx(0, 5), next(0, 2))
+	assert.Equal(t, idx(0, 2), next(0, 5))
+	assert.Equal(t, idx(1, 1), next(1, 1))
+
+	_, err = NewCopyConstraints(3, 8, [][2]Cell{{{3, 0}, {0, 0}}})
+	assert.ErrorIs(t, err, ErrInvalidCell)
+	_, err = NewCopyConstraints(3, 6, nil)
+	assert.ErrorIs(t, err, ErrCopyConstraintsSize)
+}
+
+func TestCopyConstraintsProof(t *testing.T) {
+
+	kzgSrs, err := kzg.NewSRS(64, big.NewInt(13))
+	require.NoError(t, err)
+
+	const nbColumns, size = 3, 8
+	columns := make([]fr.Vector, nbColumns)
+	for j := range columns {
+		columns[j] = make(fr.Vector, size)
+		for i := range columns[j] {
+			columns[j][i].SetRandom()
+		}
+	}
+
+	// a wire goes through (0,1), (1,4), (2,7), another through (0,6), (1,0)
+	columns[1][4].Set(&columns[0][1])
+	columns[2][7].Set(&columns[0][1])
+	columns[1][0].Set(&columns[0][6])
+	c, err := NewCopyConstraints(nbColumns, size, [][2]Cell{
+		{{0, 1}, {1, 4}},
+		{{2, 7}, {1, 4}},
+		{{1, 0}, {0, 6}},
+	})
+	require.NoError(t, err)
+	assert.True(t, c.IsSatisfied(columns))
+
+	sigma, err := c.Commit(kzgSrs.Pk)
+	require.NoError(t, err)
+
+	// correct proof
+	proof, err := ProveCopyConstraints(kzgSrs.Pk, c, columns)
+	require.NoError(t, err)
+	require.NoError(t, VerifyCopyConstraints(kzgSrs.Vk, sigma, proof))
+
+	// proof against other copy constraints
+	other, err := NewCopyConstraints(nbColumns, size, [][2]Cell{{{0, 1}, {1, 4}}})
+	require.NoError(t, err)
+	otherSigma, err := other.Commit(kzgSrs.Pk)
+	require.NoError(t, err)
+	assert.Error(t, VerifyCopyConstraints(kzgSrs.Vk, otherSigma, proof))
+
+	// tampered proof
+	proof.batchedProof.ClaimedValues[0].SetRandom()
+	assert.Error(t, VerifyCopyConstraints(kzgSrs.Vk, sigma, proof))
+
+	// unsatisfied trace
+	columns[2][7].SetRandom()
+	assert.False(t, c.IsSatisfied(columns))
+	_, err = ProveCopyConstraints(kzgSrs.Pk, c, columns)
+	assert.ErrorIs(t, err, ErrCopyConstraintsNotSatisfied)
+}
+
+func BenchmarkCopyConstraintsProver(b *testing.B) {
+
+	const nbColumns, size = 3, 1 << 12
+	kzgSrs, _ := kzg.NewSRS(nbColumns*size, big.NewInt(13))
+	columns := make([]fr.Vector, nbColumns)
+	copies := make([][2]Cell, 0, size)
+	for j := range columns {
+		columns[j] = make(fr.Vector, size)
+		for i := range columns[j] {
+			columns[j][i].SetUint64(uint64(i))
+		}
+	}
+	for i := 0; i < size; i++ {
+		copies = append(copies, [2]Cell{{0, i}, {(i % 2) + 1, i}})
+	}
+	c, _ := NewCopyConstraints(nbColumns, size, copies)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ProveCopyConstraints(kzgSrs.Pk, c, columns)
+	}
+}
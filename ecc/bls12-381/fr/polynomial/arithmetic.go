@@ -0,0 +1,613 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+var (
+	ErrDivisionByZero = errors.New("division by the zero polynomial")
+	ErrDuplicateNodes = errors.New("interpolation nodes must be distinct")
+	ErrLengthMismatch = errors.New("nodes and values must have the same length")
+)
+
+// karatsubaThreshold is the size of the smallest operand from which products are computed
+// with Karatsuba's algorithm rather than the schoolbook one.
+const karatsubaThreshold = 32
+
+// toom3Threshold is the size of the smallest operand from which products are computed
+// with the Toom-3 algorithm rather than Karatsuba's.
+const toom3Threshold = 128
+
+// newtonDivisionThreshold is the size of the quotient and of the divisor from which
+// DivRem computes the quotient from the inverse power series of the divisor, obtained by
+// Newton iteration, rather than by long division.
+const newtonDivisionThreshold = 64
+
+// normalize removes the leading zero coefficients of p, keeping at least one coefficient
+// so that the zero polynomial is {0}.
+func normalize(p Polynomial) Polynomial {
+	i := len(p) - 1
+	for i > 0 && p[i].IsZero() {
+		i--
+	}
+	if i < 0 {
+		return Polynomial{fr.Element{}}
+	}
+	return p[:i+1]
+}
+
+// isZero returns true if all the coefficients of p are zero
+func isZero(p Polynomial) bool {
+	for i := range p {
+		if !p[i].IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// sub returns a - b, normalized; a and b may have different lengths
+func sub(a, b Polynomial) Polynomial {
+	res := make(Polynomial, max(len(a), len(b)))
+	copy(res, a)
+	for i := range b {
+		res[i].Sub(&res[i], &b[i])
+	}
+	return normalize(res)
+}
+
+// DivRem computes the Euclidean division of a by b: a = q * b + r with deg(r) < deg(b).
+// Leading zero coefficients are ignored; q and r are returned without them.
+//
+// Large divisions take O(M(n)) operations, where M(n) is the cost of Mul on polynomials
+// of degree n, small ones are done by long division.
+func DivRem(a, b Polynomial) (q, r Polynomial, err error) {
+	b = normalize(b)
+	if isZero(b) {
+		return nil, nil, ErrDivisionByZero
+	}
+	r = normalize(a.Clone())
+	if len(r) < len(b) {
+		return Polynomial{fr.Element{}}, r, nil
+	}
+	if k := len(r) - len(b) + 1; k >= newtonDivisionThreshold && len(b) >= newtonDivisionThreshold {
+		q, r = divRemNewton(r, b, invSeries(reverse(b), k))
+		return q, r, nil
+	}
+
+	var lcInv, c, tmp fr.Element
+	lcInv.Inverse(&b[len(b)-1])
+	q = make(Polynomial, len(r)-len(b)+1)
+	for i := len(q) - 1; i >= 0; i-- {
+		// eliminate the coefficient of degree i + deg(b)
+		c.Mul(&r[i+len(b)-1], &lcInv)
+		q[i] = c
+		for j := range b {
+			tmp.Mul(&c, &b[j])
+			r[i+j].Sub(&r[i+j], &tmp)
+		}
+	}
+	return q, normalize(r[:len(b)-1]), nil
+}
+
+// divRemNewton computes the Euclidean division of a by b, of lengths len(a) ≥ len(b) and
+// without leading zeroes, given bRevInv = rev(b)⁻¹ mod Xᵏ for some k ≥ len(a) - len(b) + 1,
+// where rev(b) = X^deg(b) b(1/X).
+//
+// rev(a) = rev(q) rev(b) + X^(deg(a)-deg(b)+1) rev(r), so that rev(q) = rev(a) rev(b)⁻¹
+// mod X^(deg(a)-deg(b)+1).
+func divRemNewton(a, b, bRevInv Polynomial) (q, r Polynomial) {
+	k := len(a) - len(b) + 1
+	var qRev Polynomial
+	qRev.Mul(reverse(a)[:k], bRevInv[:k])
+	q = make(Polynomial, k)
+	for i := range q {
+		if k-1-i < len(qRev) {
+			q[i] = qRev[k-1-i]
+		}
+	}
+
+	var qb Polynomial
+	qb.Mul(q, b)
+	r = make(Polynomial, len(b)-1)
+	for i := range r {
+		r[i].Sub(&a[i], &qb[i])
+	}
+	return q, normalize(r)
+}
+
+// reverse returns the coefficients of p in reverse order
+func reverse(p Polynomial) Polynomial {
+	res := make(Polynomial, len(p))
+	for i := range p {
+		res[len(p)-1-i] = p[i]
+	}
+	return res
+}
+
+// invSeries returns f⁻¹ mod Xⁿ, for f(0) ≠ 0, by Newton iteration: g ← g (2 - f g) doubles
+// the number of correct coefficients of g, so that the cost is O(M(n)).
+func invSeries(f Polynomial, n int) Polynomial {
+	var one fr.Element
+	one.SetOne()
+	g := make(Polynomial, 1, n)
+	g[0].Inverse(&f[0])
+	for k := 1; k < n; {
+		k = min(2*k, n)
+
+		// e = f g - 1 mod Xᵏ, whose coefficients below the previous precision are zero
+		var e Polynomial
+		e.Mul(f[:min(len(f), k)], g)
+		e = append(e, make(Polynomial, max(0, k-len(e)))...)[:k]
+		e[0].Sub(&e[0], &one)
+
+		// g ← g - g e mod Xᵏ
+		var ge Polynomial
+		ge.Mul(g, e)
+		g = append(g, make(Polynomial, k-len(g))...)
+		for i := range g {
+			g[i].Sub(&g[i], &ge[i])
+		}
+	}
+	return g
+}
+
+// XGCD computes the monic greatest common divisor g of a and b, along with u and v such
+// that u * a + v * b = g. If both a and b are zero, g = 0, u = 0 and v = 0.
+func XGCD(a, b Polynomial) (g, u, v Polynomial) {
+	var one fr.Element
+	one.SetOne()
+	zero := Polynomial{fr.Element{}}
+
+	// invariants: r0 = u0 * a + v0 * b and r1 = u1 * a + v1 * b
+	r0, r1 := normalize(a.Clone()), normalize(b.Clone())
+	u0, u1 := Polynomial{one}, zero.Clone()
+	v0, v1 := zero.Clone(), Polynomial{one}
+
+	var tmp Polynomial
+	for !isZero(r1) {
+		q, r, _ := DivRem(r0, r1)
+		r0, r1 = r1, r
+		tmp.Mul(q, u1)
+		u0, u1 = u1, sub(u0, tmp)
+		tmp.Mul(q, v1)
+		v0, v1 = v1, sub(v0, tmp)
+	}
+
+	if isZero(r0) {
+		return zero.Clone(), zero.Clone(), zero.Clone()
+	}
+
+	// make g monic
+	var lcInv fr.Element
+	lcInv.Inverse(&r0[len(r0)-1])
+	r0.ScaleInPlace(&lcInv)
+	u0.ScaleInPlace(&lcInv)
+	v0.ScaleInPlace(&lcInv)
+	return r0, u0, v0
+}
+
+// Vanishing returns the monic polynomial ∏ᵢ (X - xᵢ), computed with a product tree.
+func Vanishing(points []fr.Element) Polynomial {
+	if len(points) == 0 {
+		var one fr.Element
+		one.SetOne()
+		return Polynomial{one}
+	}
+	if len(points) == 1 {
+		var one fr.Element
+		one.SetOne()
+		res := Polynomial{fr.Element{}, one}
+		res[0].Neg(&points[0])
+		return res
+	}
+	m := len(points) / 2
+	var res Polynomial
+	res.Mul(Vanishing(points[:m]), Vanishing(points[m:]))
+	return res
+}
+
+// BarycentricWeights returns the weights wᵢ = 1 / ∏_{j≠i} (xᵢ - xⱼ) used by the barycentric
+// Lagrange formulas on the given nodes.
+func BarycentricWeights(nodes []fr.Element) ([]fr.Element, error) {
+	weights := make([]fr.Element, len(nodes))
+	var tmp fr.Element
+	for i := range nodes {
+		weights[i].SetOne()
+		for j := range nodes {
+			if i == j {
+				continue
+			}
+			tmp.Sub(&nodes[i], &nodes[j])
+			weights[i].Mul(&weights[i], &tmp)
+		}
+	}
+	for i := range weights {
+		if weights[i].IsZero() {
+			return nil, ErrDuplicateNodes
+		}
+	}
+	return fr.BatchInvert(weights), nil
+}
+
+// EvalBarycentric evaluates at x the polynomial of degree < len(nodes) taking the given
+// values on the nodes, without computing its coefficients. weights must be the output of
+// BarycentricWeights(nodes).
+func EvalBarycentric(nodes, weights, values []fr.Element, x *fr.Element) (fr.Element, error) {
+	if len(nodes) != len(values) || len(nodes) != len(weights) {
+		return fr.Element{}, ErrLengthMismatch
+	}
+
+	// second form: p(x) = (∑ᵢ wᵢ yᵢ / (x - xᵢ)) / (∑ᵢ wᵢ / (x - xᵢ))
+	diffs := make([]fr.Element, len(nodes))
+	for i := range nodes {
+		diffs[i].Sub(x, &nodes[i])
+		if diffs[i].IsZero() {
+			return values[i], nil
+		}
+	}
+	diffs = fr.BatchInvert(diffs)
+
+	var num, den, tmp fr.Element
+	for i := range diffs {
+		tmp.Mul(&weights[i], &diffs[i])
+		den.Add(&den, &tmp)
+		tmp.Mul(&tmp, &values[i])
+		num.Add(&num, &tmp)
+	}
+	den.Inverse(&den)
+	num.Mul(&num, &den)
+	return num, nil
+}
+
+// Interpolate returns the coefficients of the unique polynomial of degree < len(nodes)
+// taking the given values on the nodes.
+func Interpolate(nodes, values []fr.Element) (Polynomial, error) {
+	if len(nodes) != len(values) {
+		return nil, ErrLengthMismatch
+	}
+	if len(nodes) == 0 {
+		return Polynomial{fr.Element{}}, nil
+	}
+	weights, err := BarycentricWeights(nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	// p = ∑ᵢ yᵢ wᵢ Z(X) / (X - xᵢ) where Z = ∏ᵢ (X - xᵢ)
+	z := Vanishing(nodes)
+	res := make(Polynomial, len(nodes))
+	quotient := make(Polynomial, len(nodes))
+	var c fr.Element
+	for i := range nodes {
+		// synthetic division of Z by (X - xᵢ)
+		quotient[len(nodes)-1] = z[len(nodes)]
+		for j := len(nodes) - 2; j >= 0; j-- {
+			quotient[j].Mul(&quotient[j+1], &nodes[i]).Add(&quotient[j], &z[j+1])
+		}
+		c.Mul(&values[i], &weights[i])
+		for j := range quotient {
+			var tmp fr.Element
+			tmp.Mul(&quotient[j], &c)
+			res[j].Add(&res[j], &tmp)
+		}
+	}
+	return res, nil
+}
+
+// mulSchoolbook returns a * b, computed with the quadratic algorithm
+func mulSchoolbook(a, b Polynomial) Polynomial {
+	res := make(Polynomial, len(a)+len(b)-1)
+	var tmp fr.Element
+	for i := range a {
+		for j := range b {
+			tmp.Mul(&a[i], &b[j])
+			res[i+j].Add(&res[i+j], &tmp)
+		}
+	}
+	return res
+}
+
+// mulKaratsuba returns a * b, computed with Karatsuba's algorithm, or Toom-3 from
+// toom3Threshold. Unbalanced operands are handled by cutting the longest one in blocks.
+func mulKaratsuba(a, b Polynomial) Polynomial {
+	if len(a) == 0 || len(b) == 0 {
+		return Polynomial{}
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	if len(a) < karatsubaThreshold {
+		return mulSchoolbook(a, b)
+	}
+
+	res := make(Polynomial, len(a)+len(b)-1)
+	if 2*len(a) <= len(b) {
+		for start := 0; start < len(b); start += len(a) {
+			end := min(start+len(a), len(b))
+			block := mulKaratsuba(a, b[start:end])
+			for i := range block {
+				res[start+i].Add(&res[start+i], &block[i])
+			}
+		}
+		return res
+	}
+	if len(a) >= toom3Threshold {
+		return mulToom3(a, b)
+	}
+
+	// a = a₀ + Xᵐ a₁, b = b₀ + Xᵐ b₁
+	// a b = a₀b₀ + Xᵐ ((a₀+a₁)(b₀+b₁) - a₀b₀ - a₁b₁) + X²ᵐ a₁b₁
+	m := len(b) / 2
+	a0, a1 := a[:m], a[m:]
+	b0, b1 := b[:m], b[m:]
+	z0 := mulKaratsuba(a0, b0)
+	z2 := mulKaratsuba(a1, b1)
+	z1 := mulKaratsuba(addUnreduced(a0, a1), addUnreduced(b0, b1))
+	for i := range z0 {
+		z1[i].Sub(&z1[i], &z0[i])
+		res[i].Add(&res[i], &z0[i])
+	}
+	for i := range z2 {
+		z1[i].Sub(&z1[i], &z2[i])
+		res[i+2*m].Add(&res[i+2*m], &z2[i])
+	}
+	for i := range z1 {
+		res[i+m].Add(&res[i+m], &z1[i])
+	}
+	return res
+}
+
+// toom3Inv2 and toom3Inv3 are 1/2 and 1/3, used by the Toom-3 interpolation
+var toom3Inv2, toom3Inv3 = func() (fr.Element, fr.Element) {
+	var inv2, inv3 fr.Element
+	inv2.SetUint64(2)
+	inv2.Inverse(&inv2)
+	inv3.SetUint64(3)
+	inv3.Inverse(&inv3)
+	return inv2, inv3
+}()
+
+// mulToom3 returns a * b, computed with the Toom-3 algorithm, for len(b)/2 < len(a) ≤ len(b).
+//
+// a = a₀ + Xᵐ a₁ + X²ᵐ a₂ and b likewise are seen as polynomials in Y = Xᵐ, whose
+// product c = c₀ + Y c₁ + ... + Y⁴ c₄ is interpolated from its values at 0, 1, -1, -2 and
+// ∞, using 5 products of size m ≈ len(b)/3 instead of the 9 of the schoolbook algorithm.
+func mulToom3(a, b Polynomial) Polynomial {
+	m := (len(b) + 2) / 3
+
+	// values of a and b at 0, 1, -1, -2, ∞
+	evaluate := func(p Polynomial) [5]Polynomial {
+		var parts [3]Polynomial
+		for i := range parts {
+			parts[i] = p[min(i*m, len(p)):min((i+1)*m, len(p))]
+		}
+		var v [5]Polynomial
+		v[0], v[4] = parts[0], parts[2]
+		for i := 1; i < 4; i++ {
+			v[i] = make(Polynomial, m)
+		}
+		var t fr.Element
+		for j := 0; j < m; j++ {
+			var p0, p1, p2 fr.Element
+			if j < len(parts[0]) {
+				p0 = parts[0][j]
+			}
+			if j < len(parts[1]) {
+				p1 = parts[1][j]
+			}
+			if j < len(parts[2]) {
+				p2 = parts[2][j]
+			}
+			t.Add(&p0, &p2)
+			v[1][j].Add(&t, &p1)                  // p₀ + p₁ + p₂
+			v[2][j].Sub(&t, &p1)                  // p₀ - p₁ + p₂
+			t.Double(&p2).Sub(&t, &p1)            // 2p₂ - p₁
+			v[3][j].Double(&t).Add(&v[3][j], &p0) // p₀ - 2p₁ + 4p₂
+		}
+		return v
+	}
+	va, vb := evaluate(a), evaluate(b)
+
+	// values of c, zero padded to the same length
+	var r [5]Polynomial
+	for i := range r {
+		r[i] = mulKaratsuba(va[i], vb[i])
+		r[i] = append(r[i], make(Polynomial, max(0, 2*m-1-len(r[i])))...)
+	}
+	r0, r1, rm1, rm2, rInf := r[0], r[1], r[2], r[3], r[4]
+
+	// interpolation (Bodrato's sequence)
+	c1 := make(Polynomial, 2*m-1)
+	c2 := make(Polynomial, 2*m-1)
+	c3 := make(Polynomial, 2*m-1)
+	var t fr.Element
+	for j := range c1 {
+		c3[j].Sub(&rm2[j], &r1[j]).Mul(&c3[j], &toom3Inv3) // (r(-2) - r(1)) / 3
+		c1[j].Sub(&r1[j], &rm1[j]).Mul(&c1[j], &toom3Inv2) // (r(1) - r(-1)) / 2
+		c2[j].Sub(&rm1[j], &r0[j])                         // r(-1) - r(0)
+		c3[j].Sub(&c2[j], &c3[j]).Mul(&c3[j], &toom3Inv2)
+		t.Double(&rInf[j])
+		c3[j].Add(&c3[j], &t)
+		c2[j].Add(&c2[j], &c1[j]).Sub(&c2[j], &rInf[j])
+		c1[j].Sub(&c1[j], &c3[j])
+	}
+
+	// c = c₀ + Y c₁ + Y² c₂ + Y³ c₃ + Y⁴ c₄; the coefficients of degree ≥ len(res) are zero
+	res := make(Polynomial, len(a)+len(b)-1)
+	for i, c := range []Polynomial{r0, c1, c2, c3, rInf} {
+		for j := range c {
+			if k := i*m + j; k < len(res) {
+				res[k].Add(&res[k], &c[j])
+			}
+		}
+	}
+	return res
+}
+
+// addUnreduced returns a + b without removing leading zeroes
+func addUnreduced(a, b Polynomial) Polynomial {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	res := make(Polynomial, len(a))
+	copy(res, a)
+	for i := range b {
+		res[i].Add(&res[i], &b[i])
+	}
+	return res
+}
+
+// derivative returns the formal derivative of p
+func derivative(p Polynomial) Polynomial {
+	if len(p) <= 1 {
+		return Polynomial{fr.Element{}}
+	}
+	res := make(Polynomial, len(p)-1)
+	var k fr.Element
+	for i := range res {
+		k.SetUint64(uint64(i + 1))
+		res[i].Mul(&p[i+1], &k)
+	}
+	return res
+}
+
+// subproductTreeLeafSize is the number of points under which subproduct tree nodes
+// evaluate polynomials directly
+const subproductTreeLeafSize = 8
+
+// SubproductTree is the binary tree of the products ∏ (X - xᵢ) over halves of a set of points.
+// It allows evaluating a polynomial on all the n points, and interpolating from evaluations on
+// them, using polynomial multiplications and divisions only; this does not require the points
+// to form a multiplicative subgroup. Both take O(M(n) log n) operations, where M(n) is the cost
+// of Mul on polynomials of degree n: quasi-linear where Mul uses FFTs, O(n^1.47 log n) where it
+// falls back to Toom-3.
+type SubproductTree struct {
+	points      []fr.Element
+	poly        Polynomial // ∏ (X - xᵢ) over points
+	left, right *SubproductTree
+
+	// rev(poly)⁻¹ mod X^len(poly), computed on first use, for the remainders by poly
+	revInv     Polynomial
+	revInvOnce sync.Once
+}
+
+// NewSubproductTree builds the subproduct tree of the given points. The points slice
+// is retained and must not be modified afterwards.
+func NewSubproductTree(points []fr.Element) *SubproductTree {
+	t := &SubproductTree{points: points}
+	if len(points) <= 1 {
+		t.poly = Vanishing(points)
+		return t
+	}
+	m := len(points) / 2
+	t.left = NewSubproductTree(points[:m])
+	t.right = NewSubproductTree(points[m:])
+	t.poly.Mul(t.left.poly, t.right.poly)
+	return t
+}
+
+// Vanishing returns ∏ᵢ (X - xᵢ) over all the points of the tree
+func (t *SubproductTree) Vanishing() Polynomial {
+	return t.poly
+}
+
+// Points returns the points of the tree
+func (t *SubproductTree) Points() []fr.Element {
+	return t.points
+}
+
+// MultiEval returns the evaluations of p at all the points of the tree, in order.
+func (t *SubproductTree) MultiEval(p Polynomial) []fr.Element {
+	res := make([]fr.Element, 0, len(t.points))
+	return t.multiEval(normalize(p), res)
+}
+
+func (t *SubproductTree) multiEval(p Polynomial, res []fr.Element) []fr.Element {
+	if len(p) > len(t.poly)-1 {
+		p = t.rem(p)
+	}
+	if len(t.points) <= subproductTreeLeafSize {
+		for i := range t.points {
+			res = append(res, p.Eval(&t.points[i]))
+		}
+		return res
+	}
+	res = t.left.multiEval(p, res)
+	return t.right.multiEval(p, res)
+}
+
+// rem returns p mod poly, for a normalized p with len(p) ≥ len(poly). The remainders of the
+// recursion need a quotient of length at most len(poly), which the cached inverse series
+// covers; other ones go through DivRem.
+func (t *SubproductTree) rem(p Polynomial) Polynomial {
+	k := len(p) - len(t.poly) + 1
+	if k < newtonDivisionThreshold || len(t.poly) < newtonDivisionThreshold || k > len(t.poly) {
+		// the divisor is monic and non-zero
+		_, r, _ := DivRem(p, t.poly)
+		return r
+	}
+	t.revInvOnce.Do(func() {
+		t.revInv = invSeries(reverse(t.poly), len(t.poly))
+	})
+	_, r := divRemNewton(p, t.poly, t.revInv)
+	return r
+}
+
+// Interpolate returns the coefficients of the unique polynomial of degree < len(points)
+// taking the given values on the points of the tree.
+func (t *SubproductTree) Interpolate(values []fr.Element) (Polynomial, error) {
+	if len(values) != len(t.points) {
+		return nil, ErrLengthMismatch
+	}
+	if len(values) == 0 {
+		return Polynomial{fr.Element{}}, nil
+	}
+
+	// Lagrange: p = ∑ᵢ yᵢ / Z'(xᵢ) ⋅ Z / (X - xᵢ)
+	weights := t.MultiEval(derivative(t.poly))
+	for i := range weights {
+		if weights[i].IsZero() {
+			return nil, ErrDuplicateNodes
+		}
+	}
+	weights = fr.BatchInvert(weights)
+	for i := range weights {
+		weights[i].Mul(&weights[i], &values[i])
+	}
+	return t.linearCombination(weights), nil
+}
+
+// linearCombination returns ∑ᵢ cᵢ ⋅ Z / (X - xᵢ) where Z is the polynomial of the node
+func (t *SubproductTree) linearCombination(c []fr.Element) Polynomial {
+	if t.left == nil {
+		if len(c) == 0 {
+			return Polynomial{fr.Element{}}
+		}
+		return Polynomial{c[0]}
+	}
+	m := len(t.left.points)
+	var l, r Polynomial
+	l.Mul(t.left.linearCombination(c[:m]), t.right.poly)
+	r.Mul(t.right.linearCombination(c[m:]), t.left.poly)
+	return *l.Add(l, r)
+}
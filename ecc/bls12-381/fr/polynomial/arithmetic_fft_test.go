@@ -0,0 +1,48 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvalOnCoset(t *testing.T) {
+	domain := fft.NewDomain(32)
+	p := randomPolynomial(20)
+	evaluations, err := p.EvalOnCoset(domain)
+	require.NoError(t, err)
+
+	var x fr.Element
+	x.Set(&domain.FrMultiplicativeGen)
+	for i := range evaluations {
+		px := p.Eval(&x)
+		assert.True(t, px.Equal(&evaluations[i]))
+		x.Mul(&x, &domain.Generator)
+	}
+
+	interpolated, err := InterpolateOnCoset(domain, evaluations)
+	require.NoError(t, err)
+	assert.True(t, isZero(sub(p, interpolated)))
+
+	_, err = randomPolynomial(33).EvalOnCoset(domain)
+	assert.ErrorIs(t, err, ErrDomainTooSmall)
+}
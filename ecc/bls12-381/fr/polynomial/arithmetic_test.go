@@ -0,0 +1,255 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func randomPolynomial(size int) Polynomial {
+	p := make(Polynomial, size)
+	for i := range p {
+		p[i].SetRandom()
+	}
+	return p
+}
+
+func TestMul(t *testing.T) {
+	for _, sizes := range [][2]int{{1, 1}, {3, 5}, {40, 50}, {100, 37}} {
+		a, b := randomPolynomial(sizes[0]), randomPolynomial(sizes[1])
+		var c Polynomial
+		c.Mul(a, b)
+		assert.Equal(t, len(a)+len(b)-1, len(c))
+
+		var x fr.Element
+		x.SetRandom()
+		ax, bx, cx := a.Eval(&x), b.Eval(&x), c.Eval(&x)
+		ax.Mul(&ax, &bx)
+		assert.True(t, ax.Equal(&cx), "sizes %v", sizes)
+	}
+}
+
+func TestDivRem(t *testing.T) {
+	a, b := randomPolynomial(100), randomPolynomial(30)
+	q, r, err := DivRem(a, b)
+	require.NoError(t, err)
+	assert.Equal(t, 71, len(q))
+	assert.LessOrEqual(t, len(r), 29)
+
+	// a = q b + r
+	var qb Polynomial
+	qb.Mul(q, b)
+	assert.True(t, isZero(sub(sub(a, qb), r)))
+
+	// exact division
+	q, r, err = DivRem(qb, q)
+	require.NoError(t, err)
+	assert.True(t, isZero(r))
+	assert.True(t, isZero(sub(q, b)))
+
+	_, _, err = DivRem(a, make(Polynomial, 3))
+	assert.ErrorIs(t, err, ErrDivisionByZero)
+}
+
+func TestXGCD(t *testing.T) {
+	common, a, b := randomPolynomial(5), randomPolynomial(20), randomPolynomial(13)
+	var ca, cb Polynomial
+	ca.Mul(common, a)
+	cb.Mul(common, b)
+
+	g, u, v := XGCD(ca, cb)
+
+	// g is monic and divides both
+	assert.True(t, g[len(g)-1].IsOne())
+	_, r, err := DivRem(ca, g)
+	require.NoError(t, err)
+	assert.True(t, isZero(r))
+	_, r, err = DivRem(cb, g)
+	require.NoError(t, err)
+	assert.True(t, isZero(r))
+
+	// random a and b are coprime with overwhelming probability
+	assert.Equal(t, len(common), len(g))
+
+	// u a + v b = g
+	var ua, vb Polynomial
+	ua.Mul(u, ca)
+	vb.Mul(v, cb)
+	ua.Add(ua, vb)
+	assert.True(t, isZero(sub(ua, g)))
+}
+
+func TestInterpolate(t *testing.T) {
+	const n = 17
+	p := randomPolynomial(n)
+	nodes := make([]fr.Element, n)
+	values := make([]fr.Element, n)
+	for i := range nodes {
+		nodes[i].SetRandom()
+		values[i] = p.Eval(&nodes[i])
+	}
+
+	interpolated, err := Interpolate(nodes, values)
+	require.NoError(t, err)
+	assert.True(t, isZero(sub(p, interpolated)))
+
+	weights, err := BarycentricWeights(nodes)
+	require.NoError(t, err)
+	var x fr.Element
+	x.SetRandom()
+	px := p.Eval(&x)
+	y, err := EvalBarycentric(nodes, weights, values, &x)
+	require.NoError(t, err)
+	assert.True(t, y.Equal(&px))
+	y, err = EvalBarycentric(nodes, weights, values, &nodes[3])
+	require.NoError(t, err)
+	assert.True(t, y.Equal(&values[3]))
+
+	z := Vanishing(nodes)
+	for i := range nodes {
+		zi := z.Eval(&nodes[i])
+		assert.True(t, zi.IsZero())
+	}
+
+	nodes[5] = nodes[2]
+	_, err = Interpolate(nodes, values)
+	assert.ErrorIs(t, err, ErrDuplicateNodes)
+}
+
+func TestKaratsuba(t *testing.T) {
+	for _, sizes := range [][2]int{{32, 32}, {33, 64}, {50, 77}, {40, 200}, {128, 127}} {
+		a, b := randomPolynomial(sizes[0]), randomPolynomial(sizes[1])
+		expected := mulSchoolbook(a, b)
+		assert.Equal(t, expected, mulKaratsuba(a, b), "sizes %v", sizes)
+		assert.Equal(t, expected, mulKaratsuba(b, a), "sizes %v", sizes)
+	}
+}
+
+func TestToom3(t *testing.T) {
+	for _, sizes := range [][2]int{{128, 128}, {129, 200}, {150, 299}, {200, 201}, {300, 301}} {
+		a, b := randomPolynomial(sizes[0]), randomPolynomial(sizes[1])
+		expected := mulSchoolbook(a, b)
+		assert.Equal(t, expected, mulToom3(a, b), "sizes %v", sizes)
+		assert.Equal(t, expected, mulKaratsuba(b, a), "sizes %v", sizes)
+	}
+}
+
+func TestDivRemNewton(t *testing.T) {
+	// large enough for the quotient to be computed by Newton iteration
+	a, b := randomPolynomial(400), randomPolynomial(150)
+	q, r, err := DivRem(a, b)
+	require.NoError(t, err)
+	assert.Equal(t, 251, len(q))
+	assert.LessOrEqual(t, len(r), 149)
+
+	// same result as the long division
+	r = a.Clone()
+	var lcInv, c, tmp fr.Element
+	lcInv.Inverse(&b[len(b)-1])
+	expectedQ := make(Polynomial, len(q))
+	for i := len(q) - 1; i >= 0; i-- {
+		c.Mul(&r[i+len(b)-1], &lcInv)
+		expectedQ[i] = c
+		for j := range b {
+			tmp.Mul(&c, &b[j])
+			r[i+j].Sub(&r[i+j], &tmp)
+		}
+	}
+	assert.Equal(t, expectedQ, q)
+
+	f := randomPolynomial(100)
+	g := invSeries(f, 100)
+	var fg Polynomial
+	fg.Mul(f, g)
+	assert.True(t, fg[0].IsOne())
+	assert.True(t, isZero(fg[1:100]))
+}
+
+func TestSubproductTree(t *testing.T) {
+	const n = 45
+	points := make([]fr.Element, n)
+	for i := range points {
+		points[i].SetRandom()
+	}
+	tree := NewSubproductTree(points)
+	assert.Equal(t, Vanishing(points), tree.Vanishing())
+
+	p := randomPolynomial(2 * n)
+	values := tree.MultiEval(p)
+	require.Equal(t, n, len(values))
+	for i := range points {
+		expected := p.Eval(&points[i])
+		assert.True(t, expected.Equal(&values[i]))
+	}
+
+	p = p[:n]
+	values = tree.MultiEval(p)
+	interpolated, err := tree.Interpolate(values)
+	require.NoError(t, err)
+	assert.True(t, isZero(sub(p, interpolated)))
+
+	points[3] = points[30]
+	_, err = NewSubproductTree(points).Interpolate(values)
+	assert.ErrorIs(t, err, ErrDuplicateNodes)
+}
+
+func TestSubproductTreeLarge(t *testing.T) {
+	// large enough for the remainders to be computed by Newton iteration
+	const n = 300
+	points := make([]fr.Element, n)
+	for i := range points {
+		points[i].SetRandom()
+	}
+	tree := NewSubproductTree(points)
+
+	p := randomPolynomial(n)
+	values := tree.MultiEval(p)
+	for i := range points {
+		expected := p.Eval(&points[i])
+		assert.True(t, expected.Equal(&values[i]))
+	}
+	interpolated, err := tree.Interpolate(values)
+	require.NoError(t, err)
+	assert.True(t, isZero(sub(p, interpolated)))
+}
+
+func BenchmarkSubproductTree(b *testing.B) {
+	const n = 1 << 10
+	points := make([]fr.Element, n)
+	for i := range points {
+		points[i].SetRandom()
+	}
+	tree := NewSubproductTree(points)
+	p := randomPolynomial(n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.MultiEval(p)
+	}
+}
+
+func BenchmarkMul(b *testing.B) {
+	p1, p2 := randomPolynomial(1<<12), randomPolynomial(1<<12)
+	var res Polynomial
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		res.Mul(p1, p2)
+	}
+}
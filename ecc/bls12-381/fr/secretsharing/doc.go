@@ -0,0 +1,33 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package secretsharing implements Shamir secret sharing over fr and its
+// verifiable variant using Pedersen commitments.
+//
+// A secret s is shared among n parties by sampling a random polynomial f of
+// degree t-1 with f(0) = s and giving the share f(i) to party i. Any t shares
+// allow to recover s by Lagrange interpolation at zero, while t-1 shares leak
+// nothing about s.
+//
+// In the verifiable variant (Pedersen VSS) the dealer additionally samples a
+// blinding polynomial g and publishes commitments Cⱼ = [aⱼ]G + [bⱼ]H to the
+// coefficients of f and g. Each party can then check its share against the
+// commitments without learning anything about the secret. The points G and H
+// must be independent, i.e. nobody knows the discrete logarithm of H in base G.
+//
+// See https://www.cs.cornell.edu/courses/cs754/2001fa/129.PDF (Shamir) and
+// https://link.springer.com/chapter/10.1007/3-540-46766-1_9 (Pedersen).
+package secretsharing
@@ -0,0 +1,108 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package secretsharing
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitRecombine(t *testing.T) {
+	assert := require.New(t)
+
+	var secret fr.Element
+	_, err := secret.SetRandom()
+	assert.NoError(err)
+
+	const threshold, nbShares = 3, 5
+	shares, err := Split(secret, threshold, nbShares)
+	assert.NoError(err)
+	assert.Len(shares, nbShares)
+
+	// any subset of threshold shares recovers the secret
+	for _, subset := range [][]int{{0, 1, 2}, {1, 3, 4}, {4, 2, 0}, {0, 1, 2, 3, 4}} {
+		selected := make([]Share, len(subset))
+		for i, j := range subset {
+			selected[i] = shares[j]
+		}
+		recovered, err := Recombine(selected)
+		assert.NoError(err)
+		assert.True(recovered.Equal(&secret), "subset %v", subset)
+	}
+
+	// threshold-1 shares do not
+	recovered, err := Recombine(shares[:threshold-1])
+	assert.NoError(err)
+	assert.False(recovered.Equal(&secret))
+}
+
+func TestSplitErrors(t *testing.T) {
+	assert := require.New(t)
+	var secret fr.Element
+
+	_, err := Split(secret, 0, 3)
+	assert.ErrorIs(err, ErrInvalidThreshold)
+	_, err = Split(secret, 4, 3)
+	assert.ErrorIs(err, ErrInvalidThreshold)
+
+	shares, err := Split(secret, 2, 3)
+	assert.NoError(err)
+	_, err = Recombine([]Share{shares[0], shares[0]})
+	assert.ErrorIs(err, ErrDuplicateIndex)
+	_, err = Recombine(nil)
+	assert.ErrorIs(err, ErrNotEnoughShares)
+}
+
+func TestVerifiableSecretSharing(t *testing.T) {
+	assert := require.New(t)
+
+	params, err := NewParams([]byte("test"))
+	assert.NoError(err)
+
+	var secret fr.Element
+	_, err = secret.SetRandom()
+	assert.NoError(err)
+
+	const threshold, nbShares = 3, 4
+	shares, commitments, err := SplitVerifiable(params, secret, threshold, nbShares)
+	assert.NoError(err)
+	assert.Len(commitments, threshold)
+
+	for i := range shares {
+		assert.NoError(VerifyShare(params, shares[i], commitments))
+	}
+
+	plain := make([]Share, threshold)
+	for i := range plain {
+		plain[i] = shares[i+1].Share
+	}
+	recovered, err := Recombine(plain)
+	assert.NoError(err)
+	assert.True(recovered.Equal(&secret))
+
+	// tampered shares are rejected
+	var one fr.Element
+	one.SetOne()
+	bad := shares[0]
+	bad.Value.Add(&bad.Value, &one)
+	assert.ErrorIs(VerifyShare(params, bad, commitments), ErrInvalidShare)
+	bad = shares[0]
+	bad.Blinding.Add(&bad.Blinding, &one)
+	assert.ErrorIs(VerifyShare(params, bad, commitments), ErrInvalidShare)
+}
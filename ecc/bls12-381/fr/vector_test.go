@@ -19,6 +19,7 @@ package fr
 import (
 	"bytes"
 	"github.com/stretchr/testify/require"
+	"io"
 	"reflect"
 	"sort"
 	"testing"
@@ -80,6 +81,150 @@ func TestVectorEmptyRoundTrip(t *testing.T) {
 	assert.True(reflect.DeepEqual(v3, v2))
 }
 
+func TestVectorCompressedRoundTrip(t *testing.T) {
+	assert := require.New(t)
+
+	const n = 2500 // not a multiple of the block size
+	random := make(Vector, n)
+	boolean := make(Vector, n)
+	smallValues := make(Vector, n)
+	counter := make(Vector, n)
+	decreasing := make(Vector, n)
+	mixed := make(Vector, n)
+	for i := 0; i < n; i++ {
+		random[i].SetRandom()
+		boolean[i].SetUint64(uint64(i % 3 % 2))
+		smallValues[i].SetUint64(uint64(i*7919) % 1000)
+		counter[i].SetUint64(1<<40).Add(&counter[i], new(Element).SetUint64(uint64(i)))
+		decreasing[i].SetInt64(-int64(i))
+		if i < n/8 {
+			mixed[i].SetRandom()
+		} else {
+			mixed[i].SetUint64(uint64(i & 1))
+		}
+	}
+
+	for _, tc := range []struct {
+		name       string
+		v          Vector
+		compresses bool
+	}{
+		{"empty", make(Vector, 0), false},
+		{"random", random, false},
+		{"boolean", boolean, true},
+		{"small", smallValues, true},
+		{"counter", counter, true},
+		{"decreasing", decreasing, true},
+		{"mixed", mixed, true},
+	} {
+		var buf bytes.Buffer
+		written, err := tc.v.WriteCompressedTo(&buf)
+		assert.NoError(err, tc.name)
+		assert.Equal(int64(buf.Len()), written, tc.name)
+		if tc.compresses {
+			assert.Less(buf.Len(), len(tc.v)*Bytes/2, tc.name)
+		}
+
+		// the stream can be followed by other data
+		buf.WriteString("trailing")
+
+		var decoded Vector
+		read, err := decoded.ReadCompressedFrom(&buf)
+		assert.NoError(err, tc.name)
+		assert.Equal(written, read, tc.name)
+		assert.True(reflect.DeepEqual(tc.v, decoded), tc.name)
+		assert.Equal("trailing", buf.String(), tc.name)
+	}
+}
+
+func TestVectorEncoderStreaming(t *testing.T) {
+	assert := require.New(t)
+
+	// successive calls to Encode are concatenated, and delta encoding spans over them
+	var buf bytes.Buffer
+	enc := NewVectorEncoder(&buf)
+	var expected Vector
+	for i := 0; i < 10; i++ {
+		v := make(Vector, 100+i*200)
+		for j := range v {
+			v[j].SetUint64(uint64(len(expected) + j))
+		}
+		assert.NoError(enc.Encode(v))
+		expected = append(expected, v...)
+	}
+	assert.NoError(enc.Close())
+	assert.Equal(int64(buf.Len()), enc.BytesWritten())
+
+	dec := NewVectorDecoder(&buf)
+	var decoded Vector
+	for {
+		block, err := dec.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(err)
+		assert.LessOrEqual(len(block), 1024)
+		decoded = append(decoded, block...)
+	}
+	assert.True(reflect.DeepEqual(expected, decoded))
+	assert.Equal(enc.BytesWritten(), dec.BytesRead())
+	_, err := dec.Next()
+	assert.Equal(io.EOF, err)
+}
+
+func TestVectorCompressedInvalid(t *testing.T) {
+	assert := require.New(t)
+
+	v := make(Vector, 10)
+	for i := range v {
+		v[i].SetUint64(uint64(i))
+	}
+	var buf bytes.Buffer
+	_, err := v.WriteCompressedTo(&buf)
+	assert.NoError(err)
+	valid := buf.Bytes()
+
+	// truncated streams
+	for i := 0; i < len(valid); i++ {
+		var decoded Vector
+		_, err := decoded.ReadCompressedFrom(bytes.NewReader(valid[:i]))
+		assert.Error(err, "truncated at %d", i)
+	}
+
+	// invalid headers
+	for _, b := range [][]byte{
+		{0xff, 0xff, 0x01},      // too many elements
+		{1, 3, 0},               // unknown encoding
+		{1, encodingRaw, 1},     // raw with a bit width
+		{1, encodingPacked, 65}, // bit width too large
+	} {
+		var decoded Vector
+		_, err := decoded.ReadCompressedFrom(bytes.NewReader(b))
+		assert.Error(err)
+	}
+}
+
+func BenchmarkVectorCompressed(b *testing.B) {
+	const n = 1 << 16
+	v := make(Vector, n)
+	for i := range v {
+		v[i].SetUint64(uint64(i & 1))
+	}
+	var buf bytes.Buffer
+	b.Run("write", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			buf.Reset()
+			_, _ = v.WriteCompressedTo(&buf)
+		}
+	})
+	b.Run("read", func(b *testing.B) {
+		var decoded Vector
+		for i := 0; i < b.N; i++ {
+			_, _ = decoded.ReadCompressedFrom(bytes.NewReader(buf.Bytes()))
+		}
+	})
+}
+
 func (vector *Vector) unmarshalBinaryAsync(data []byte) error {
 	r := bytes.NewReader(data)
 	_, err, chErr := vector.AsyncReadFrom(r)
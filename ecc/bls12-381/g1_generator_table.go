@@ -0,0 +1,131 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12381
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+const (
+	// generatorTableWindow is the width of the signed digits used with the
+	// precomputed generator tables.
+	generatorTableWindow = 6
+	// generatorTableHalf is the number of multiples per window, digits are in
+	// [-generatorTableHalf, generatorTableHalf].
+	generatorTableHalf = 1 << (generatorTableWindow - 1)
+	// generatorTableNbWindows is enough windows for fr.Bits bits plus the last carry.
+	generatorTableNbWindows = (fr.Bits + generatorTableWindow) / generatorTableWindow
+	// generatorTableSize is the number of points of a generator table.
+	generatorTableSize = generatorTableNbWindows * generatorTableHalf
+)
+
+var errInvalidGeneratorTable = errors.New("invalid generator table")
+
+// generatorTableDigits returns the signed base 2^generatorTableWindow digits of s mod r,
+// least significant first.
+func generatorTableDigits(s *big.Int) (digits [generatorTableNbWindows]int) {
+	var e fr.Element
+	k := e.SetBigInt(s).Bits()
+
+	const mask = 1<<generatorTableWindow - 1
+	carry := 0
+	for i := range digits {
+		bit := i * generatorTableWindow
+		w, shift := bit/64, uint(bit%64)
+		var v uint64
+		if w < fr.Limbs {
+			v = k[w] >> shift
+			if shift > 64-generatorTableWindow && w+1 < fr.Limbs {
+				v |= k[w+1] << (64 - shift)
+			}
+		}
+		d := int(v&mask) + carry
+		carry = 0
+		if d > generatorTableHalf {
+			d -= 1 << generatorTableWindow
+			carry = 1
+		}
+		digits[i] = d
+	}
+	return
+}
+
+// g1GeneratorTable holds [j⋅2^(generatorTableWindow⋅i)]g for 1 ⩽ j ⩽ generatorTableHalf
+// at index i⋅generatorTableHalf + j - 1, where g is the G1 generator.
+//
+// It is nil unless the package is built with the generatortables build tag, in which case
+// it is decoded at init from a binary embedded in the package.
+var g1GeneratorTable []G1Affine
+
+// mulGeneratorTable sets p to [s]g with the precomputed g1GeneratorTable, using one mixed
+// addition per window and no doubling.
+func (p *G1Jac) mulGeneratorTable(s *big.Int) *G1Jac {
+	digits := generatorTableDigits(s)
+
+	var res G1Jac
+	res.Set(&g1Infinity)
+	var neg G1Affine
+	for i, d := range digits {
+		t := g1GeneratorTable[i*generatorTableHalf : (i+1)*generatorTableHalf]
+		if d > 0 {
+			res.AddMixed(&t[d-1])
+		} else if d < 0 {
+			neg.Neg(&t[-d-1])
+			res.AddMixed(&neg)
+		}
+	}
+	p.Set(&res)
+	return p
+}
+
+// computeG1GeneratorTable computes the content of g1GeneratorTable.
+func computeG1GeneratorTable() []G1Affine {
+	table := make([]G1Affine, generatorTableSize)
+	var base, acc G1Jac
+	base.Set(&g1Gen)
+	for i := 0; i < generatorTableNbWindows; i++ {
+		acc.Set(&base)
+		for j := 0; j < generatorTableHalf; j++ {
+			if j > 0 {
+				acc.AddAssign(&base)
+			}
+			table[i*generatorTableHalf+j].FromJacobian(&acc)
+		}
+		// acc = [generatorTableHalf]base
+		base.Double(&acc)
+	}
+	return table
+}
+
+// decodeG1GeneratorTable decodes a generator table from the
+// concatenation of its points in uncompressed form. The points are not checked to be
+// in the subgroup.
+func decodeG1GeneratorTable(data []byte) ([]G1Affine, error) {
+	if len(data) != generatorTableSize*SizeOfG1AffineUncompressed {
+		return nil, errInvalidGeneratorTable
+	}
+	table := make([]G1Affine, generatorTableSize)
+	for i := range table {
+		if _, err := table[i].setBytes(data[i*SizeOfG1AffineUncompressed:], false); err != nil {
+			return nil, err
+		}
+	}
+	return table, nil
+}
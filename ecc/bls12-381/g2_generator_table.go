@@ -0,0 +1,84 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12381
+
+import (
+	"math/big"
+)
+
+// g2GeneratorTable holds [j⋅2^(generatorTableWindow⋅i)]g for 1 ⩽ j ⩽ generatorTableHalf
+// at index i⋅generatorTableHalf + j - 1, where g is the G2 generator.
+//
+// It is nil unless the package is built with the generatortables build tag, in which case
+// it is decoded at init from a binary embedded in the package.
+var g2GeneratorTable []G2Affine
+
+// mulGeneratorTable sets p to [s]g with the precomputed g2GeneratorTable, using one mixed
+// addition per window and no doubling.
+func (p *G2Jac) mulGeneratorTable(s *big.Int) *G2Jac {
+	digits := generatorTableDigits(s)
+
+	var res G2Jac
+	res.Set(&g2Infinity)
+	var neg G2Affine
+	for i, d := range digits {
+		t := g2GeneratorTable[i*generatorTableHalf : (i+1)*generatorTableHalf]
+		if d > 0 {
+			res.AddMixed(&t[d-1])
+		} else if d < 0 {
+			neg.Neg(&t[-d-1])
+			res.AddMixed(&neg)
+		}
+	}
+	p.Set(&res)
+	return p
+}
+
+// computeG2GeneratorTable computes the content of g2GeneratorTable.
+func computeG2GeneratorTable() []G2Affine {
+	table := make([]G2Affine, generatorTableSize)
+	var base, acc G2Jac
+	base.Set(&g2Gen)
+	for i := 0; i < generatorTableNbWindows; i++ {
+		acc.Set(&base)
+		for j := 0; j < generatorTableHalf; j++ {
+			if j > 0 {
+				acc.AddAssign(&base)
+			}
+			table[i*generatorTableHalf+j].FromJacobian(&acc)
+		}
+		// acc = [generatorTableHalf]base
+		base.Double(&acc)
+	}
+	return table
+}
+
+// decodeG2GeneratorTable decodes a generator table from the
+// concatenation of its points in uncompressed form. The points are not checked to be
+// in the subgroup.
+func decodeG2GeneratorTable(data []byte) ([]G2Affine, error) {
+	if len(data) != generatorTableSize*SizeOfG2AffineUncompressed {
+		return nil, errInvalidGeneratorTable
+	}
+	table := make([]G2Affine, generatorTableSize)
+	for i := range table {
+		if _, err := table[i].setBytes(data[i*SizeOfG2AffineUncompressed:], false); err != nil {
+			return nil, err
+		}
+	}
+	return table, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12381
+
+import (
+	"bytes"
+	"math/big"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestReduceG2AffineMSM(t *testing.T) {
+	t.Parallel()
+	const nbDistinct = 10
+	distinct := make([]G2Affine, nbDistinct)
+	for i := range distinct {
+		distinct[i].ScalarMultiplication(&g2GenAff, big.NewInt(int64(i+1)))
+	}
+
+	// 5 copies of each point, a point at infinity, and opposite scalars on distinct[0]
+	var points []G2Affine
+	var scalars []fr.Element
+	for k := 0; k < 5; k++ {
+		for i := range distinct {
+			var s fr.Element
+			s.SetRandom()
+			points = append(points, distinct[i])
+			scalars = append(scalars, s)
+		}
+	}
+	var s, minusS fr.Element
+	s.SetRandom()
+	minusS.Neg(&s)
+	points = append(points, G2Affine{}, distinct[0], distinct[0])
+	scalars = append(scalars, s, s, minusS)
+	for i := range points {
+		if i%nbDistinct == 0 && i < 5*nbDistinct {
+			// distinct[0] cancels out
+			scalars[i].SetZero()
+		}
+	}
+	rand.Shuffle(len(points), func(i, j int) { //#nosec G404 weak rng is fine here
+		points[i], points[j] = points[j], points[i]
+		scalars[i], scalars[j] = scalars[j], scalars[i]
+	})
+
+	reducedPoints, reducedScalars, err := ReduceG2AffineMSM(points, scalars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reducedPoints) != nbDistinct-1 || len(reducedScalars) != nbDistinct-1 {
+		t.Fatalf("expected %d terms, got %d", nbDistinct-1, len(reducedPoints))
+	}
+	for i := 1; i < len(reducedPoints); i++ {
+		a, b := reducedPoints[i-1].RawBytes(), reducedPoints[i].RawBytes()
+		if bytes.Compare(a[:], b[:]) >= 0 {
+			t.Fatal("points are not in canonical order")
+		}
+	}
+
+	var expected, actual G2Jac
+	if _, err = expected.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = actual.MultiExp(reducedPoints, reducedScalars, ecc.MultiExpConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if !expected.Equal(&actual) {
+		t.Fatal("reduced MSM doesn't match")
+	}
+
+	if _, _, err = ReduceG2AffineMSM(points, scalars[1:]); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestDeduplicateG2Affine(t *testing.T) {
+	t.Parallel()
+	points := make([]G2Affine, 20)
+	for i := range points {
+		points[i].ScalarMultiplication(&g2GenAff, big.NewInt(int64(i%7)))
+	}
+	distinct := DeduplicateG2Affine(points)
+	if len(distinct) != 7 {
+		t.Fatalf("expected 7 distinct points, got %d", len(distinct))
+	}
+
+	// canonical order doesn't depend on the input order
+	sorted := make([]G2Affine, 7)
+	copy(sorted, points[7:14])
+	rand.Shuffle(len(sorted), func(i, j int) { sorted[i], sorted[j] = sorted[j], sorted[i] }) //#nosec G404 weak rng is fine here
+	SortG2Affine(sorted)
+	for i := range sorted {
+		if !sorted[i].Equal(&distinct[i]) {
+			t.Fatal("sorting is not canonical")
+		}
+	}
+}
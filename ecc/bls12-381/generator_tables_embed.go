@@ -0,0 +1,41 @@
+//go:build generatortables
+// +build generatortables
+
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12381
+
+import _ "embed"
+
+// The generator tables are embedded in the binary only with the generatortables
+// build tag, as they weigh a few hundred kilobytes.
+var (
+	//go:embed g1_generator_table.bin
+	g1GeneratorTableData []byte
+	//go:embed g2_generator_table.bin
+	g2GeneratorTableData []byte
+)
+
+func init() {
+	var err error
+	if g1GeneratorTable, err = decodeG1GeneratorTable(g1GeneratorTableData); err != nil {
+		panic(err)
+	}
+	if g2GeneratorTable, err = decodeG2GeneratorTable(g2GeneratorTableData); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kzg
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// EncodeOpeningEIP2537 returns the calldata of an opening proof for a verifier
+// relying on the EIP-2537 precompiles:
+//
+//	commitment (128 bytes) ‖ H (128 bytes) ‖ point (32 bytes) ‖ claimed value (32 bytes)
+//
+// Points use the EIP-2537 encoding, field elements are big endian.
+func EncodeOpeningEIP2537(commitment *Digest, proof *OpeningProof, point fr.Element) []byte {
+	res := make([]byte, 0, 2*bls12381.SizeOfG1AffineEIP2537+2*fr.Bytes)
+	c := commitment.MarshalEIP2537()
+	h := proof.H.MarshalEIP2537()
+	a := point.Bytes()
+	v := proof.ClaimedValue.Bytes()
+	res = append(res, c[:]...)
+	res = append(res, h[:]...)
+	res = append(res, a[:]...)
+	res = append(res, v[:]...)
+	return res
+}
+
+// PairingCheckInputEIP2537 returns the input of the EIP-2537 pairing check
+// precompile corresponding to [Verify]:
+//
+//	e([f(a) - a*H(α) - f(α)]G₁, G₂).e([H(α)]G₁, [α]G₂) == 1
+//
+// The precompile returns 1 if and only if Verify accepts the proof.
+func PairingCheckInputEIP2537(commitment *Digest, proof *OpeningProof, point fr.Element, vk VerifyingKey) ([]byte, error) {
+
+	// [f(a) - a*H(α) - f(α)]G₁, as in Verify
+	var totalG1 bls12381.G1Jac
+	var pointNeg fr.Element
+	var cmInt, pointInt big.Int
+	proof.ClaimedValue.BigInt(&cmInt)
+	pointNeg.Neg(&point).BigInt(&pointInt)
+	totalG1.JointScalarMultiplication(&vk.G1, &proof.H, &cmInt, &pointInt)
+
+	var commitmentJac bls12381.G1Jac
+	commitmentJac.FromAffine(commitment)
+	totalG1.SubAssign(&commitmentJac)
+
+	var totalG1Aff bls12381.G1Affine
+	totalG1Aff.FromJacobian(&totalG1)
+
+	return bls12381.PairingCheckInputEIP2537(
+		[]bls12381.G1Affine{totalG1Aff, proof.H},
+		vk.G2[:],
+	)
+}
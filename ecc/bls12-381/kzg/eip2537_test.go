@@ -0,0 +1,60 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kzg
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPairingCheckInputEIP2537(t *testing.T) {
+	assert := require.New(t)
+
+	f := randomPolynomial(60)
+	digest, err := Commit(f, testSrs.Pk)
+	assert.NoError(err)
+
+	var point fr.Element
+	point.SetString("4321")
+	proof, err := Open(f, point, testSrs.Pk)
+	assert.NoError(err)
+	assert.NoError(Verify(&digest, &proof, point, testSrs.Vk))
+
+	calldata := EncodeOpeningEIP2537(&digest, &proof, point)
+	assert.Len(calldata, 2*bls12381.SizeOfG1AffineEIP2537+2*fr.Bytes)
+
+	// the precompile input, decoded and checked with the Go pairing, accepts the proof
+	input, err := PairingCheckInputEIP2537(&digest, &proof, point, testSrs.Vk)
+	assert.NoError(err)
+	assert.Len(input, 2*bls12381.SizeOfPairingPairEIP2537)
+	P, Q, err := bls12381.DecodePairingCheckInputEIP2537(input)
+	assert.NoError(err)
+	ok, err := bls12381.PairingCheck(P, Q)
+	assert.NoError(err)
+	assert.True(ok)
+
+	// and rejects a wrong claimed value
+	proof.ClaimedValue.Double(&proof.ClaimedValue)
+	input, err = PairingCheckInputEIP2537(&digest, &proof, point, testSrs.Vk)
+	assert.NoError(err)
+	P, Q, err = bls12381.DecodePairingCheckInputEIP2537(input)
+	assert.NoError(err)
+	ok, err = bls12381.PairingCheck(P, Q)
+	assert.NoError(err)
+	assert.False(ok)
+}
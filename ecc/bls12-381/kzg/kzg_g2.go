@@ -0,0 +1,175 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// DigestG2 commitment of a polynomial in G₂.
+type DigestG2 = bls12381.G2Affine
+
+// ProvingKeyG2 used to create or open commitments in G₂
+type ProvingKeyG2 struct {
+	G2 []bls12381.G2Affine // [G₂ [α]G₂ , [α²]G₂, ... ]
+}
+
+// VerifyingKeyG2 used to verify opening proofs of commitments in G₂
+type VerifyingKeyG2 struct {
+	G1 [2]bls12381.G1Affine // [G₁, [α]G₁ ]
+	G2 bls12381.G2Affine
+}
+
+// SRSG2 comprises the ProvingKeyG2 and the VerifyingKeyG2 of commitments in G₂.
+//
+// When it is derived from the same α as an SRS, commitments to the same polynomial
+// in G₁ and G₂ satisfy e([f(α)]G₁, G₂) == e(G₁, [f(α)]G₂), which allows to check
+// relations between the two.
+type SRSG2 struct {
+	Pk ProvingKeyG2
+	Vk VerifyingKeyG2
+}
+
+// OpeningProofG2 KZG proof for opening a commitment in G₂ at a single point.
+//
+// implements io.ReaderFrom and io.WriterTo
+type OpeningProofG2 struct {
+	// H quotient polynomial (f - f(z))/(x-z)
+	H bls12381.G2Affine
+
+	// ClaimedValue purported value
+	ClaimedValue fr.Element
+}
+
+// NewSRSG2 returns a new SRSG2 using alpha as randomness source
+//
+// In production, a SRS generated through MPC should be used.
+func NewSRSG2(size uint64, bAlpha *big.Int) (*SRSG2, error) {
+
+	if size < 2 {
+		return nil, ErrMinSRSSize
+	}
+	var srs SRSG2
+	srs.Pk.G2 = make([]bls12381.G2Affine, size)
+
+	var alpha fr.Element
+	alpha.SetBigInt(bAlpha)
+
+	_, _, gen1Aff, gen2Aff := bls12381.Generators()
+
+	srs.Pk.G2[0] = gen2Aff
+	srs.Vk.G2 = gen2Aff
+	srs.Vk.G1[0] = gen1Aff
+	srs.Vk.G1[1].ScalarMultiplication(&gen1Aff, bAlpha)
+
+	alphas := make([]fr.Element, size-1)
+	alphas[0] = alpha
+	for i := 1; i < len(alphas); i++ {
+		alphas[i].Mul(&alphas[i-1], &alpha)
+	}
+	g2s := bls12381.BatchScalarMultiplicationG2(&gen2Aff, alphas)
+	copy(srs.Pk.G2[1:], g2s)
+
+	return &srs, nil
+}
+
+// CommitG2 commits to a polynomial in G₂ using a multi exponentiation with the SRS.
+// It is assumed that the polynomial is in canonical form, in Montgomery form.
+func CommitG2(p []fr.Element, pk ProvingKeyG2, nbTasks ...int) (DigestG2, error) {
+
+	if len(p) == 0 || len(p) > len(pk.G2) {
+		return DigestG2{}, ErrInvalidPolynomialSize
+	}
+
+	var res bls12381.G2Affine
+
+	config := ecc.MultiExpConfig{}
+	if len(nbTasks) > 0 {
+		config.NbTasks = nbTasks[0]
+	}
+	if _, err := res.MultiExp(pk.G2[:len(p)], p, config); err != nil {
+		return DigestG2{}, err
+	}
+
+	return res, nil
+}
+
+// OpenG2 computes an opening proof in G₂ of polynomial p at given point.
+func OpenG2(p []fr.Element, point fr.Element, pk ProvingKeyG2) (OpeningProofG2, error) {
+	if len(p) == 0 || len(p) > len(pk.G2) {
+		return OpeningProofG2{}, ErrInvalidPolynomialSize
+	}
+
+	// build the proof
+	res := OpeningProofG2{
+		ClaimedValue: eval(p, point),
+	}
+
+	// compute H
+	// h reuses memory from _p
+	_p := make([]fr.Element, len(p))
+	copy(_p, p)
+	h := dividePolyByXminusA(_p, res.ClaimedValue, point)
+
+	// commit to H
+	hCommit, err := CommitG2(h, pk)
+	if err != nil {
+		return OpeningProofG2{}, err
+	}
+	res.H.Set(&hCommit)
+
+	return res, nil
+}
+
+// VerifyG2 verifies a KZG opening proof in G₂ at a single point
+func VerifyG2(commitment *DigestG2, proof *OpeningProofG2, point fr.Element, vk VerifyingKeyG2) error {
+
+	// [f(a)]G₂ + [-a]([H(α)]G₂) = [f(a) - a*H(α)]G₂
+	var totalG2, tmp bls12381.G2Jac
+	var pointNeg fr.Element
+	var cmInt, pointInt big.Int
+	proof.ClaimedValue.BigInt(&cmInt)
+	pointNeg.Neg(&point).BigInt(&pointInt)
+	totalG2.ScalarMultiplicationBase(&cmInt)
+	tmp.FromAffine(&proof.H)
+	tmp.ScalarMultiplication(&tmp, &pointInt)
+	totalG2.AddAssign(&tmp)
+
+	// [f(a) - a*H(α)]G₂ + [-f(α)]G₂  = [f(a) - f(α) - a*H(α)]G₂
+	tmp.FromAffine(commitment)
+	totalG2.SubAssign(&tmp)
+
+	// e(G₁, [f(a)-f(α)-aH(α)]G₂).e([α]G₁, [H(α)]G₂) == 1
+	var totalG2Aff bls12381.G2Affine
+	totalG2Aff.FromJacobian(&totalG2)
+	check, err := bls12381.PairingCheck(
+		vk.G1[:],
+		[]bls12381.G2Affine{totalG2Aff, proof.H},
+	)
+
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
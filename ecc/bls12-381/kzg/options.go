@@ -0,0 +1,126 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+// ErrInvalidConfig is returned (wrapped) when the options given to one of the
+// *WithOptions functions are inconsistent.
+var ErrInvalidConfig = errors.New("invalid kzg configuration")
+
+// maxNbTasks is the largest number of tasks accepted by the multi-exponentiation.
+const maxNbTasks = 1024
+
+type config struct {
+	hf             hash.Hash
+	nbTasks        int
+	instanceID     []byte
+	dataTranscript [][]byte
+}
+
+// Option configures a commitment, an opening or a verification.
+type Option func(*config)
+
+// WithHash sets the hash function used for Fiat-Shamir. It is required by the batch
+// openings and verifications.
+func WithHash(hf hash.Hash) Option {
+	return func(c *config) {
+		c.hf = hf
+	}
+}
+
+// WithNbTasks sets the number of tasks of the multi-exponentiations. Default (0) is
+// twice the number of CPUs.
+func WithNbTasks(nbTasks int) Option {
+	return func(c *config) {
+		c.nbTasks = nbTasks
+	}
+}
+
+// WithInstanceID binds the Fiat-Shamir challenges to instanceID, as passing
+// fiatshamir.InstanceHash(hf, instanceID) as hash function does.
+func WithInstanceID(instanceID []byte) Option {
+	return func(c *config) {
+		c.instanceID = append([]byte{}, instanceID...)
+	}
+}
+
+// WithDataTranscript appends extra data to the transcript used to derive the
+// folding challenge.
+func WithDataTranscript(data ...[]byte) Option {
+	return func(c *config) {
+		c.dataTranscript = append(c.dataTranscript, data...)
+	}
+}
+
+// newConfig applies opts and validates the result. If needHash is set, a hash
+// function must have been provided.
+func newConfig(needHash bool, opts ...Option) (config, error) {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.nbTasks < 0 || cfg.nbTasks > maxNbTasks {
+		return config{}, fmt.Errorf("%w: nbTasks must be in [0, %d], got %d", ErrInvalidConfig, maxNbTasks, cfg.nbTasks)
+	}
+	if needHash && cfg.hf == nil {
+		return config{}, fmt.Errorf("%w: a hash function is needed for Fiat-Shamir, use WithHash", ErrInvalidConfig)
+	}
+	if cfg.instanceID != nil {
+		if cfg.hf == nil {
+			return config{}, fmt.Errorf("%w: WithInstanceID needs a hash function, use WithHash", ErrInvalidConfig)
+		}
+		cfg.hf = fiatshamir.InstanceHash(cfg.hf, cfg.instanceID)
+	}
+	return cfg, nil
+}
+
+// CommitWithOptions is like Commit, configured by opts.
+func CommitWithOptions(p []fr.Element, pk ProvingKey, opts ...Option) (Digest, error) {
+	cfg, err := newConfig(false, opts...)
+	if err != nil {
+		return Digest{}, err
+	}
+	return Commit(p, pk, cfg.nbTasks)
+}
+
+// BatchOpenSinglePointWithOptions is like BatchOpenSinglePoint, configured by opts.
+// WithHash is required.
+func BatchOpenSinglePointWithOptions(polynomials [][]fr.Element, digests []Digest, point fr.Element, pk ProvingKey, opts ...Option) (BatchOpeningProof, error) {
+	cfg, err := newConfig(true, opts...)
+	if err != nil {
+		return BatchOpeningProof{}, err
+	}
+	return batchOpenSinglePoint(polynomials, digests, point, cfg.hf, pk, cfg.nbTasks, cfg.dataTranscript...)
+}
+
+// BatchVerifySinglePointWithOptions is like BatchVerifySinglePoint, configured by opts.
+// WithHash is required, and the options must match the ones of the prover.
+func BatchVerifySinglePointWithOptions(digests []Digest, batchOpeningProof *BatchOpeningProof, point fr.Element, vk VerifyingKey, opts ...Option) error {
+	cfg, err := newConfig(true, opts...)
+	if err != nil {
+		return err
+	}
+	return BatchVerifySinglePoint(digests, batchOpeningProof, point, cfg.hf, vk, cfg.dataTranscript...)
+}
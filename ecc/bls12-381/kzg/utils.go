@@ -20,12 +20,12 @@ import (
 	"fmt"
 	"math/big"
 	"math/bits"
-	"runtime"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"github.com/consensys/gnark-crypto/internal/parallel"
+	"github.com/consensys/gnark-crypto/utils/concurrency"
 )
 
 // ToLagrangeG1 in place transform of coeffs canonical form into Lagrange form.
@@ -39,7 +39,7 @@ func ToLagrangeG1(coeffs []curve.G1Affine) ([]curve.G1Affine, error) {
 	}
 	size := len(coeffs)
 
-	numCPU := uint64(runtime.NumCPU())
+	numCPU := uint64(concurrency.MaxTasks())
 	maxSplits := bits.TrailingZeros64(ecc.NextPowerOfTwo(numCPU)) << 1
 
 	twiddlesInv, err := computeTwiddlesInv(size)
@@ -144,7 +144,7 @@ func difFFTG1(a []curve.G1Jac, twiddles []*big.Int, stage, maxSplits int, chDone
 	const butterflyThreshold = 8
 	if m >= butterflyThreshold {
 		// 1 << stage == estimated used CPUs
-		numCPU := runtime.NumCPU() / (1 << (stage))
+		numCPU := concurrency.MaxTasks() / (1 << (stage))
 		parallel.Execute(m, func(start, end int) {
 			if start == 0 {
 				start = 1
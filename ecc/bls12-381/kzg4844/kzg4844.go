@@ -0,0 +1,380 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kzg4844 implements the KZG polynomial commitment scheme used by
+// EIP-4844 (https://eips.ethereum.org/EIPS/eip-4844) blob transactions, following
+// the Deneb polynomial commitments specification of the Ethereum consensus layer.
+//
+// Blobs are 4096 field elements of bls12-381 encoded on 32 bytes, big endian,
+// interpreted as the evaluations of a polynomial on the 4096-th roots of unity in
+// bit reversed order. Commitments and proofs are compressed bls12-381 G₁ points on
+// 48 bytes.
+//
+// A Context is built from the output of the KZG ceremony, see LoadTrustedSetup.
+package kzg4844
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+)
+
+const (
+	// ScalarsPerBlob number of field elements in a blob
+	ScalarsPerBlob = 4096
+
+	// SizeOfScalar size of an encoded field element
+	SizeOfScalar = fr.Bytes
+
+	// SizeOfBlob size of an encoded blob
+	SizeOfBlob = ScalarsPerBlob * SizeOfScalar
+
+	// SizeOfCommitment size of an encoded commitment or proof
+	SizeOfCommitment = bls12381.SizeOfG1AffineCompressed
+
+	// VersionedHashVersionKZG version byte of the versioned hash of a commitment
+	VersionedHashVersionKZG = 0x01
+
+	// primitiveRootOfUnity generates the multiplicative group of fr
+	primitiveRootOfUnity = 7
+
+	// fiatShamirDomain domain separator of the blob challenge
+	fiatShamirDomain = "FSBLOBVERIFY_V1_"
+)
+
+var (
+	ErrInvalidScalar       = errors.New("kzg4844: non canonical field element")
+	ErrInvalidPoint        = errors.New("kzg4844: invalid G1 point encoding")
+	ErrInvalidTrustedSetup = errors.New("kzg4844: invalid trusted setup")
+	ErrVerifyProof         = errors.New("kzg4844: can't verify opening proof")
+)
+
+// Blob 4096 field elements, big endian
+type Blob [SizeOfBlob]byte
+
+// Scalar field element, big endian
+type Scalar [SizeOfScalar]byte
+
+// Commitment compressed G₁ point committing to a blob
+type Commitment [SizeOfCommitment]byte
+
+// Proof compressed G₁ point, KZG opening proof
+type Proof [SizeOfCommitment]byte
+
+// VersionedHash hash of a commitment, as it appears in blob transactions
+type VersionedHash [sha256.Size]byte
+
+// VersionedHash returns the versioned hash of the commitment, that is
+// VersionedHashVersionKZG ‖ sha256(c)[1:].
+func (c *Commitment) VersionedHash() VersionedHash {
+	h := VersionedHash(sha256.Sum256(c[:]))
+	h[0] = VersionedHashVersionKZG
+	return h
+}
+
+// Context holds the trusted setup and the evaluation domain.
+type Context struct {
+	// g1Lagrange [Lᵢ(τ)]G₁ in bit reversed order
+	g1Lagrange []bls12381.G1Affine
+
+	// g1, g2 generators and g2Tau [τ]G₂
+	g1        bls12381.G1Affine
+	g2, g2Tau bls12381.G2Affine
+
+	// roots 4096-th roots of unity in bit reversed order
+	roots []fr.Element
+}
+
+// NewContext returns a Context from the Lagrange form of the G₁ part of the setup,
+// in natural order, and the first two powers of τ in G₂, [G₂, [τ]G₂].
+func NewContext(g1Lagrange []bls12381.G1Affine, g2Monomial []bls12381.G2Affine) (*Context, error) {
+	if len(g1Lagrange) != ScalarsPerBlob || len(g2Monomial) < 2 {
+		return nil, ErrInvalidTrustedSetup
+	}
+	_, _, g1, g2 := bls12381.Generators()
+	if !g2Monomial[0].Equal(&g2) {
+		return nil, ErrInvalidTrustedSetup
+	}
+
+	ctx := &Context{
+		g1Lagrange: make([]bls12381.G1Affine, ScalarsPerBlob),
+		g1:         g1,
+		g2:         g2,
+		g2Tau:      g2Monomial[1],
+		roots:      make([]fr.Element, ScalarsPerBlob),
+	}
+	copy(ctx.g1Lagrange, g1Lagrange)
+	bitReverse(ctx.g1Lagrange)
+
+	// ω = 7^((r-1)/4096)
+	var exp big.Int
+	exp.Sub(fr.Modulus(), big.NewInt(1))
+	exp.Div(&exp, big.NewInt(ScalarsPerBlob))
+	var omega fr.Element
+	omega.SetUint64(primitiveRootOfUnity)
+	omega.Exp(omega, &exp)
+
+	ctx.roots[0].SetOne()
+	for i := 1; i < ScalarsPerBlob; i++ {
+		ctx.roots[i].Mul(&ctx.roots[i-1], &omega)
+	}
+	fft.BitReverse(ctx.roots)
+
+	return ctx, nil
+}
+
+// BlobToCommitment returns the commitment to the polynomial encoded by blob.
+func (ctx *Context) BlobToCommitment(blob *Blob) (Commitment, error) {
+	poly, err := blobToPolynomial(blob)
+	if err != nil {
+		return Commitment{}, err
+	}
+	c, err := ctx.lincomb(poly)
+	if err != nil {
+		return Commitment{}, err
+	}
+	return c.Bytes(), nil
+}
+
+// ComputeKZGProof returns a proof of the evaluation of the polynomial encoded
+// by blob at z, and the evaluation y.
+func (ctx *Context) ComputeKZGProof(blob *Blob, z Scalar) (Proof, Scalar, error) {
+	poly, err := blobToPolynomial(blob)
+	if err != nil {
+		return Proof{}, Scalar{}, err
+	}
+	var zz fr.Element
+	if err := zz.SetBytesCanonical(z[:]); err != nil {
+		return Proof{}, Scalar{}, ErrInvalidScalar
+	}
+	proof, y, err := ctx.computeProof(poly, zz)
+	if err != nil {
+		return Proof{}, Scalar{}, err
+	}
+	return proof, y.Bytes(), nil
+}
+
+// ComputeBlobKZGProof returns the proof of the evaluation of the polynomial encoded
+// by blob at the Fiat-Shamir challenge derived from the blob and its commitment.
+func (ctx *Context) ComputeBlobKZGProof(blob *Blob, commitment Commitment) (Proof, error) {
+	poly, err := blobToPolynomial(blob)
+	if err != nil {
+		return Proof{}, err
+	}
+	if _, err := decodePoint(commitment); err != nil {
+		return Proof{}, err
+	}
+	z := computeChallenge(blob, &commitment)
+	proof, _, err := ctx.computeProof(poly, z)
+	return proof, err
+}
+
+// VerifyKZGProof verifies that the polynomial committed to by commitment
+// evaluates to y at z.
+func (ctx *Context) VerifyKZGProof(commitment Commitment, z, y Scalar, proof Proof) error {
+	var zz, yy fr.Element
+	if err := zz.SetBytesCanonical(z[:]); err != nil {
+		return ErrInvalidScalar
+	}
+	if err := yy.SetBytesCanonical(y[:]); err != nil {
+		return ErrInvalidScalar
+	}
+	return ctx.verifyProof(commitment, zz, yy, proof)
+}
+
+// VerifyBlobKZGProof verifies that commitment is a commitment to blob, using a
+// proof computed by ComputeBlobKZGProof.
+func (ctx *Context) VerifyBlobKZGProof(blob *Blob, commitment Commitment, proof Proof) error {
+	poly, err := blobToPolynomial(blob)
+	if err != nil {
+		return err
+	}
+	z := computeChallenge(blob, &commitment)
+	y := ctx.evaluate(poly, z)
+	return ctx.verifyProof(commitment, z, y, proof)
+}
+
+// computeProof returns the proof of the evaluation of poly at z, and the evaluation.
+func (ctx *Context) computeProof(poly []fr.Element, z fr.Element) (Proof, fr.Element, error) {
+	y := ctx.evaluate(poly, z)
+
+	// qᵢ = (pᵢ - y) / (ωᵢ - z)
+	denominators := make([]fr.Element, ScalarsPerBlob)
+	inDomain := -1
+	for i := range ctx.roots {
+		denominators[i].Sub(&ctx.roots[i], &z)
+		if denominators[i].IsZero() {
+			inDomain = i
+		}
+	}
+	denominators = fr.BatchInvert(denominators)
+
+	quotient := make([]fr.Element, ScalarsPerBlob)
+	for i := range quotient {
+		quotient[i].Sub(&poly[i], &y).Mul(&quotient[i], &denominators[i])
+	}
+
+	// if z = ωₘ, qₘ = ∑_{i≠m} (pᵢ - y)ωᵢ / (z(z - ωᵢ)) = -1/z ∑_{i≠m} qᵢωᵢ
+	if inDomain != -1 {
+		var acc, t fr.Element
+		for i := range quotient {
+			if i == inDomain {
+				continue
+			}
+			t.Mul(&quotient[i], &ctx.roots[i])
+			acc.Add(&acc, &t)
+		}
+		t.Inverse(&z)
+		quotient[inDomain].Mul(&acc, &t).Neg(&quotient[inDomain])
+	}
+
+	h, err := ctx.lincomb(quotient)
+	if err != nil {
+		return Proof{}, fr.Element{}, err
+	}
+	return Proof(h.Bytes()), y, nil
+}
+
+// verifyProof checks e(C - [y]G₁, -G₂)⋅e(π, [τ - z]G₂) == 1
+func (ctx *Context) verifyProof(commitment Commitment, z, y fr.Element, proof Proof) error {
+	c, err := decodePoint(commitment)
+	if err != nil {
+		return err
+	}
+	pi, err := decodePoint(Commitment(proof))
+	if err != nil {
+		return err
+	}
+
+	var bi big.Int
+	var pMinusY, yG1 bls12381.G1Jac
+	pMinusY.FromAffine(&c)
+	yG1.ScalarMultiplicationBase(y.BigInt(&bi))
+	pMinusY.SubAssign(&yG1)
+
+	var xMinusZ, zG2 bls12381.G2Jac
+	xMinusZ.FromAffine(&ctx.g2Tau)
+	zG2.ScalarMultiplicationBase(z.BigInt(&bi))
+	xMinusZ.SubAssign(&zG2)
+
+	var pMinusYAff bls12381.G1Affine
+	var xMinusZAff, g2Neg bls12381.G2Affine
+	pMinusYAff.FromJacobian(&pMinusY)
+	xMinusZAff.FromJacobian(&xMinusZ)
+	g2Neg.Neg(&ctx.g2)
+
+	check, err := bls12381.PairingCheck(
+		[]bls12381.G1Affine{pMinusYAff, pi},
+		[]bls12381.G2Affine{g2Neg, xMinusZAff},
+	)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyProof
+	}
+	return nil
+}
+
+// evaluate returns the evaluation at z of the polynomial given by its
+// evaluations on the roots of unity, in bit reversed order
+//
+//	p(z) = (zⁿ - 1)/n ∑ pᵢωᵢ/(z - ωᵢ)
+func (ctx *Context) evaluate(poly []fr.Element, z fr.Element) fr.Element {
+	denominators := make([]fr.Element, ScalarsPerBlob)
+	for i := range ctx.roots {
+		if ctx.roots[i].Equal(&z) {
+			return poly[i]
+		}
+		denominators[i].Sub(&z, &ctx.roots[i])
+	}
+	denominators = fr.BatchInvert(denominators)
+
+	var res, t fr.Element
+	for i := range poly {
+		t.Mul(&poly[i], &ctx.roots[i]).Mul(&t, &denominators[i])
+		res.Add(&res, &t)
+	}
+
+	var one, n fr.Element
+	one.SetOne()
+	t.Exp(z, big.NewInt(ScalarsPerBlob)).Sub(&t, &one)
+	n.SetUint64(ScalarsPerBlob).Inverse(&n)
+	res.Mul(&res, &t).Mul(&res, &n)
+	return res
+}
+
+// lincomb returns ∑ pᵢ[Lᵢ(τ)]G₁
+func (ctx *Context) lincomb(poly []fr.Element) (bls12381.G1Affine, error) {
+	var res bls12381.G1Affine
+	_, err := res.MultiExp(ctx.g1Lagrange, poly, ecc.MultiExpConfig{})
+	return res, err
+}
+
+// computeChallenge derives the evaluation point of a blob proof, as
+// sha256(domain ‖ n ‖ blob ‖ commitment) mod r
+func computeChallenge(blob *Blob, commitment *Commitment) fr.Element {
+	var degree [16]byte
+	binary.BigEndian.PutUint64(degree[8:], ScalarsPerBlob)
+
+	h := sha256.New()
+	h.Write([]byte(fiatShamirDomain))
+	h.Write(degree[:])
+	h.Write(blob[:])
+	h.Write(commitment[:])
+
+	var z fr.Element
+	z.SetBytes(h.Sum(nil))
+	return z
+}
+
+// blobToPolynomial decodes the field elements of a blob
+func blobToPolynomial(blob *Blob) ([]fr.Element, error) {
+	poly := make([]fr.Element, ScalarsPerBlob)
+	for i := range poly {
+		if err := poly[i].SetBytesCanonical(blob[i*SizeOfScalar : (i+1)*SizeOfScalar]); err != nil {
+			return nil, ErrInvalidScalar
+		}
+	}
+	return poly, nil
+}
+
+// decodePoint decodes a compressed G₁ point, checking it is in the prime order
+// subgroup. The point at infinity is allowed.
+func decodePoint(buf Commitment) (bls12381.G1Affine, error) {
+	var p bls12381.G1Affine
+	if err := p.SetBytesStrict(buf[:]); err != nil {
+		return p, ErrInvalidPoint
+	}
+	return p, nil
+}
+
+// bitReverse permutes v in bit reversed order
+func bitReverse(v []bls12381.G1Affine) {
+	n := uint64(len(v))
+	nn := uint64(64 - bits.TrailingZeros64(n))
+	for i := uint64(0); i < n; i++ {
+		j := bits.Reverse64(i) >> nn
+		if i < j {
+			v[i], v[j] = v[j], v[i]
+		}
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kzg4844
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"sync"
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testTau       = big.NewInt(42)
+	testSetupOnce sync.Once
+	testSetupJSON []byte
+	testCtx       *Context
+)
+
+// testSetup returns a trusted setup in the consensus specs JSON format, generated
+// from a known τ, and the corresponding Context.
+func testSetup() ([]byte, *Context) {
+	testSetupOnce.Do(func() {
+		var tau, one, n, tauN, omega fr.Element
+		tau.SetBigInt(testTau)
+		one.SetOne()
+		n.SetUint64(ScalarsPerBlob)
+		tauN.Exp(tau, big.NewInt(ScalarsPerBlob)).Sub(&tauN, &one)
+
+		var exp big.Int
+		exp.Sub(fr.Modulus(), big.NewInt(1)).Div(&exp, big.NewInt(ScalarsPerBlob))
+		omega.SetUint64(primitiveRootOfUnity)
+		omega.Exp(omega, &exp)
+
+		// Lᵢ(τ) = ωⁱ(τⁿ - 1) / (n(τ - ωⁱ)), in natural order
+		lagrange := make([]fr.Element, ScalarsPerBlob)
+		denominators := make([]fr.Element, ScalarsPerBlob)
+		var w fr.Element
+		w.SetOne()
+		for i := range lagrange {
+			lagrange[i].Mul(&w, &tauN)
+			denominators[i].Sub(&tau, &w).Mul(&denominators[i], &n)
+			w.Mul(&w, &omega)
+		}
+		denominators = fr.BatchInvert(denominators)
+		for i := range lagrange {
+			lagrange[i].Mul(&lagrange[i], &denominators[i])
+		}
+
+		_, _, g1, g2 := bls12381.Generators()
+		g1Lagrange := bls12381.BatchScalarMultiplicationG1(&g1, lagrange)
+		var g2Tau bls12381.G2Affine
+		g2Tau.ScalarMultiplication(&g2, testTau)
+
+		ts := trustedSetupJSON{
+			G1Lagrange: make([]string, ScalarsPerBlob),
+			G2Monomial: make([]string, 2),
+		}
+		for i := range g1Lagrange {
+			b := g1Lagrange[i].Bytes()
+			ts.G1Lagrange[i] = "0x" + hex.EncodeToString(b[:])
+		}
+		for i, p := range []bls12381.G2Affine{g2, g2Tau} {
+			b := p.Bytes()
+			ts.G2Monomial[i] = "0x" + hex.EncodeToString(b[:])
+		}
+
+		var err error
+		if testSetupJSON, err = json.Marshal(ts); err != nil {
+			panic(err)
+		}
+		if testCtx, err = LoadTrustedSetup(bytes.NewReader(testSetupJSON)); err != nil {
+			panic(err)
+		}
+	})
+	return testSetupJSON, testCtx
+}
+
+func randomBlob() *Blob {
+	var blob Blob
+	var e fr.Element
+	for i := 0; i < ScalarsPerBlob; i++ {
+		e.SetRandom()
+		b := e.Bytes()
+		copy(blob[i*SizeOfScalar:], b[:])
+	}
+	return &blob
+}
+
+func TestLoadTrustedSetup(t *testing.T) {
+	assert := require.New(t)
+	setup, ctx := testSetup()
+
+	// roots of unity, in bit reversed order
+	var minusOne fr.Element
+	minusOne.SetOne().Neg(&minusOne)
+	assert.True(ctx.roots[0].IsOne())
+	assert.True(ctx.roots[1].Equal(&minusOne))
+
+	// older key names
+	var ts trustedSetupJSON
+	assert.NoError(json.Unmarshal(setup, &ts))
+	old, err := json.Marshal(map[string][]string{
+		"setup_G1_lagrange": ts.G1Lagrange,
+		"setup_G2":          ts.G2Monomial,
+	})
+	assert.NoError(err)
+	ctxOld, err := LoadTrustedSetup(bytes.NewReader(old))
+	assert.NoError(err)
+	assert.Equal(ctx.g1Lagrange, ctxOld.g1Lagrange)
+	assert.True(ctx.g2Tau.Equal(&ctxOld.g2Tau))
+
+	// invalid setups
+	short, err := json.Marshal(trustedSetupJSON{G1Lagrange: ts.G1Lagrange[1:], G2Monomial: ts.G2Monomial})
+	assert.NoError(err)
+	_, err = LoadTrustedSetup(bytes.NewReader(short))
+	assert.ErrorIs(err, ErrInvalidTrustedSetup)
+
+	ts.G1Lagrange[7] = "0x" + hex.EncodeToString(make([]byte, SizeOfCommitment))
+	invalid, err := json.Marshal(ts)
+	assert.NoError(err)
+	_, err = LoadTrustedSetup(bytes.NewReader(invalid))
+	assert.ErrorIs(err, ErrInvalidTrustedSetup)
+}
+
+func TestBlobToCommitment(t *testing.T) {
+	assert := require.New(t)
+	_, ctx := testSetup()
+
+	// the commitment to the zero blob is the point at infinity
+	var zero Blob
+	c, err := ctx.BlobToCommitment(&zero)
+	assert.NoError(err)
+	assert.Equal(byte(0xc0), c[0])
+	assert.Equal(make([]byte, SizeOfCommitment-1), c[1:])
+
+	// the commitment is [p(τ)]G₁
+	blob := randomBlob()
+	c, err = ctx.BlobToCommitment(blob)
+	assert.NoError(err)
+	poly, err := blobToPolynomial(blob)
+	assert.NoError(err)
+	var tau fr.Element
+	tau.SetBigInt(testTau)
+	pTau := ctx.evaluate(poly, tau)
+	var expected bls12381.G1Affine
+	expected.ScalarMultiplication(&ctx.g1, pTau.BigInt(new(big.Int)))
+	assert.Equal(Commitment(expected.Bytes()), c)
+
+	// non canonical field element
+	copy(blob[SizeOfScalar:], fr.Modulus().Bytes())
+	_, err = ctx.BlobToCommitment(blob)
+	assert.ErrorIs(err, ErrInvalidScalar)
+}
+
+func TestComputeKZGProof(t *testing.T) {
+	assert := require.New(t)
+	_, ctx := testSetup()
+
+	blob := randomBlob()
+	c, err := ctx.BlobToCommitment(blob)
+	assert.NoError(err)
+	poly, err := blobToPolynomial(blob)
+	assert.NoError(err)
+
+	var outside fr.Element
+	outside.SetRandom()
+	for _, z := range []fr.Element{outside, ctx.roots[0], ctx.roots[5]} {
+		proof, y, err := ctx.ComputeKZGProof(blob, z.Bytes())
+		assert.NoError(err)
+		assert.NoError(ctx.VerifyKZGProof(c, z.Bytes(), y, proof))
+
+		// the evaluation on the domain is the blob element
+		for i := range ctx.roots {
+			if ctx.roots[i].Equal(&z) {
+				assert.Equal(Scalar(poly[i].Bytes()), y)
+			}
+		}
+
+		// wrong evaluation
+		var wrong fr.Element
+		assert.NoError(wrong.SetBytesCanonical(y[:]))
+		wrong.Double(&wrong)
+		assert.ErrorIs(ctx.VerifyKZGProof(c, z.Bytes(), wrong.Bytes(), proof), ErrVerifyProof)
+	}
+}
+
+func TestVerifyBlobKZGProof(t *testing.T) {
+	assert := require.New(t)
+	_, ctx := testSetup()
+
+	blob := randomBlob()
+	c, err := ctx.BlobToCommitment(blob)
+	assert.NoError(err)
+	proof, err := ctx.ComputeBlobKZGProof(blob, c)
+	assert.NoError(err)
+	assert.NoError(ctx.VerifyBlobKZGProof(blob, c, proof))
+
+	// other blob
+	other := randomBlob()
+	assert.ErrorIs(ctx.VerifyBlobKZGProof(other, c, proof), ErrVerifyProof)
+
+	// other commitment
+	cOther, err := ctx.BlobToCommitment(other)
+	assert.NoError(err)
+	assert.ErrorIs(ctx.VerifyBlobKZGProof(blob, cOther, proof), ErrVerifyProof)
+
+	// invalid encodings
+	var invalid Commitment
+	_, err = ctx.ComputeBlobKZGProof(blob, invalid)
+	assert.ErrorIs(err, ErrInvalidPoint)
+	assert.ErrorIs(ctx.VerifyBlobKZGProof(blob, c, Proof(invalid)), ErrInvalidPoint)
+}
+
+func TestVersionedHash(t *testing.T) {
+	var c Commitment
+	c[0] = 0xc0
+	h := c.VersionedHash()
+	expected := sha256.Sum256(c[:])
+	require.Equal(t, byte(VersionedHashVersionKZG), h[0])
+	require.Equal(t, expected[1:], h[1:])
+}
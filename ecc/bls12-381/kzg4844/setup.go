@@ -0,0 +1,96 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kzg4844
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strings"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// trustedSetupJSON layout of the trusted setup files of the consensus specs
+// (trusted_setup_4096.json). Older releases of the file used the setup_G1,
+// setup_G1_lagrange and setup_G2 keys.
+type trustedSetupJSON struct {
+	G1Lagrange      []string `json:"g1_lagrange"`
+	G2Monomial      []string `json:"g2_monomial"`
+	SetupG1Lagrange []string `json:"setup_G1_lagrange"`
+	SetupG2         []string `json:"setup_G2"`
+}
+
+// LoadTrustedSetup reads a trusted setup in the JSON format of the consensus
+// specs, with hex encoded compressed points, and returns the corresponding Context.
+//
+// All points are checked to be in the prime order subgroups.
+func LoadTrustedSetup(r io.Reader) (*Context, error) {
+	var ts trustedSetupJSON
+	if err := json.NewDecoder(r).Decode(&ts); err != nil {
+		return nil, err
+	}
+	g1, g2 := ts.G1Lagrange, ts.G2Monomial
+	if len(g1) == 0 {
+		g1 = ts.SetupG1Lagrange
+	}
+	if len(g2) == 0 {
+		g2 = ts.SetupG2
+	}
+	if len(g1) != ScalarsPerBlob || len(g2) < 2 {
+		return nil, ErrInvalidTrustedSetup
+	}
+
+	g1Lagrange := make([]bls12381.G1Affine, len(g1))
+	g2Monomial := make([]bls12381.G2Affine, len(g2))
+
+	chErr := make(chan error, 1)
+	parallel.Execute(len(g1), func(start, end int) {
+		for i := start; i < end; i++ {
+			if err := decodeHexPoint(&g1Lagrange[i], g1[i]); err != nil {
+				select {
+				case chErr <- err:
+				default:
+				}
+				return
+			}
+		}
+	})
+	for i := range g2 {
+		if err := decodeHexPoint(&g2Monomial[i], g2[i]); err != nil {
+			return nil, err
+		}
+	}
+	select {
+	case err := <-chErr:
+		return nil, err
+	default:
+	}
+
+	return NewContext(g1Lagrange, g2Monomial)
+}
+
+// decodeHexPoint sets p from its hex encoded compressed form
+func decodeHexPoint(p interface{ SetBytesStrict([]byte) error }, s string) error {
+	buf, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return ErrInvalidTrustedSetup
+	}
+	if err := p.SetBytesStrict(buf); err != nil {
+		return ErrInvalidTrustedSetup
+	}
+	return nil
+}
@@ -0,0 +1,260 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kzg4844
+
+// Tests against the KZG vectors of the consensus specs (ethereum/consensus-spec-tests,
+// tests/general/deneb/kzg), with the official trusted setup:
+//
+//	trusted_setup_4096.json
+//	blob_to_kzg_commitment/kzg-mainnet/*/data.yaml  input: {blob},                        output: commitment | null
+//	compute_kzg_proof/kzg-mainnet/*/data.yaml       input: {blob, z},                     output: [proof, y] | null
+//	verify_kzg_proof/kzg-mainnet/*/data.yaml        input: {commitment, z, y, proof},     output: true | false | null
+//	verify_blob_kzg_proof/kzg-mainnet/*/data.yaml   input: {blob, commitment, proof},     output: true | false | null
+//
+// The files are read from testdata by default, or from the directory set in
+// KZG4844_SPEC_TESTS; the tests are skipped when they are missing. A null output
+// means the inputs are invalid, false that they are valid but the proof doesn't
+// verify.
+
+import (
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	specCtxOnce sync.Once
+	specCtx     *Context
+	specCtxErr  error
+)
+
+func specTestsDir() string {
+	if dir := os.Getenv("KZG4844_SPEC_TESTS"); dir != "" {
+		return dir
+	}
+	return "testdata"
+}
+
+// specContext returns the Context of the official trusted setup.
+func specContext(t *testing.T) *Context {
+	path := filepath.Join(specTestsDir(), "trusted_setup_4096.json")
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		t.Skipf("no trusted setup in %s", path)
+	}
+	require.NoError(t, err)
+	defer f.Close()
+
+	specCtxOnce.Do(func() {
+		specCtx, specCtxErr = LoadTrustedSetup(f)
+	})
+	require.NoError(t, specCtxErr)
+	return specCtx
+}
+
+// specVectors returns the paths of the vectors of the given handler, and the Context
+// to run them with.
+func specVectors(t *testing.T, handler string) (*Context, []string) {
+	dir := specTestsDir()
+	tests, err := filepath.Glob(filepath.Join(dir, handler, "*", "*", "data.yaml"))
+	require.NoError(t, err)
+	if len(tests) == 0 {
+		t.Skipf("no %s vectors in %s", handler, dir)
+	}
+	return specContext(t), tests
+}
+
+func decodeSpecVector(t *testing.T, path string, v interface{}) {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, yaml.NewDecoder(f).Decode(v))
+}
+
+// decodeSpecHex decodes s into dst, which must have exactly the size of the
+// decoded value.
+func decodeSpecHex(dst []byte, s string) error {
+	buf, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return err
+	}
+	if len(buf) != len(dst) {
+		return errors.New("invalid length")
+	}
+	copy(dst, buf)
+	return nil
+}
+
+func TestSpecBlobToKZGCommitment(t *testing.T) {
+	type Test struct {
+		Input struct {
+			Blob string `yaml:"blob"`
+		}
+		Output *string `yaml:"output"`
+	}
+	ctx, tests := specVectors(t, "blob_to_kzg_commitment")
+	for _, path := range tests {
+		t.Run(filepath.Base(filepath.Dir(path)), func(t *testing.T) {
+			var test Test
+			decodeSpecVector(t, path, &test)
+
+			var blob Blob
+			var commitment Commitment
+			err := decodeSpecHex(blob[:], test.Input.Blob)
+			if err == nil {
+				commitment, err = ctx.BlobToCommitment(&blob)
+			}
+			if test.Output == nil {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			var expected Commitment
+			require.NoError(t, decodeSpecHex(expected[:], *test.Output))
+			require.Equal(t, expected, commitment)
+		})
+	}
+}
+
+func TestSpecComputeKZGProof(t *testing.T) {
+	type Test struct {
+		Input struct {
+			Blob string `yaml:"blob"`
+			Z    string `yaml:"z"`
+		}
+		Output *[]string `yaml:"output"`
+	}
+	ctx, tests := specVectors(t, "compute_kzg_proof")
+	for _, path := range tests {
+		t.Run(filepath.Base(filepath.Dir(path)), func(t *testing.T) {
+			var test Test
+			decodeSpecVector(t, path, &test)
+
+			var blob Blob
+			var z, y Scalar
+			var proof Proof
+			err := decodeSpecHex(blob[:], test.Input.Blob)
+			if err == nil {
+				err = decodeSpecHex(z[:], test.Input.Z)
+			}
+			if err == nil {
+				proof, y, err = ctx.ComputeKZGProof(&blob, z)
+			}
+			if test.Output == nil {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, *test.Output, 2)
+			var expectedProof Proof
+			var expectedY Scalar
+			require.NoError(t, decodeSpecHex(expectedProof[:], (*test.Output)[0]))
+			require.NoError(t, decodeSpecHex(expectedY[:], (*test.Output)[1]))
+			require.Equal(t, expectedProof, proof)
+			require.Equal(t, expectedY, y)
+		})
+	}
+}
+
+// requireSpecVerification checks the result of a verification against the expected
+// output: nil for invalid inputs, or whether the proof verifies.
+func requireSpecVerification(t *testing.T, output *bool, err error) {
+	switch {
+	case output == nil:
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrVerifyProof, "the inputs should be rejected")
+	case *output:
+		require.NoError(t, err)
+	default:
+		require.ErrorIs(t, err, ErrVerifyProof)
+	}
+}
+
+func TestSpecVerifyKZGProof(t *testing.T) {
+	type Test struct {
+		Input struct {
+			Commitment string `yaml:"commitment"`
+			Z          string `yaml:"z"`
+			Y          string `yaml:"y"`
+			Proof      string `yaml:"proof"`
+		}
+		Output *bool `yaml:"output"`
+	}
+	ctx, tests := specVectors(t, "verify_kzg_proof")
+	for _, path := range tests {
+		t.Run(filepath.Base(filepath.Dir(path)), func(t *testing.T) {
+			var test Test
+			decodeSpecVector(t, path, &test)
+
+			var commitment Commitment
+			var z, y Scalar
+			var proof Proof
+			err := decodeSpecHex(commitment[:], test.Input.Commitment)
+			if err == nil {
+				err = decodeSpecHex(z[:], test.Input.Z)
+			}
+			if err == nil {
+				err = decodeSpecHex(y[:], test.Input.Y)
+			}
+			if err == nil {
+				err = decodeSpecHex(proof[:], test.Input.Proof)
+			}
+			if err == nil {
+				err = ctx.VerifyKZGProof(commitment, z, y, proof)
+			}
+			requireSpecVerification(t, test.Output, err)
+		})
+	}
+}
+
+func TestSpecVerifyBlobKZGProof(t *testing.T) {
+	type Test struct {
+		Input struct {
+			Blob       string `yaml:"blob"`
+			Commitment string `yaml:"commitment"`
+			Proof      string `yaml:"proof"`
+		}
+		Output *bool `yaml:"output"`
+	}
+	ctx, tests := specVectors(t, "verify_blob_kzg_proof")
+	for _, path := range tests {
+		t.Run(filepath.Base(filepath.Dir(path)), func(t *testing.T) {
+			var test Test
+			decodeSpecVector(t, path, &test)
+
+			var blob Blob
+			var commitment Commitment
+			var proof Proof
+			err := decodeSpecHex(blob[:], test.Input.Blob)
+			if err == nil {
+				err = decodeSpecHex(commitment[:], test.Input.Commitment)
+			}
+			if err == nil {
+				err = decodeSpecHex(proof[:], test.Input.Proof)
+			}
+			if err == nil {
+				err = ctx.VerifyBlobKZGProof(&blob, commitment, proof)
+			}
+			requireSpecVerification(t, test.Output, err)
+		})
+	}
+}
@@ -0,0 +1,311 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12381
+
+import (
+	"errors"
+	"math/big"
+	"math/rand/v2"
+	"reflect"
+	"testing"
+)
+
+func TestBatchDecompressG1Affine(t *testing.T) {
+	t.Parallel()
+	const n = 20
+	points := make([]G1Affine, n)
+	buf := make([]byte, 0, n*SizeOfG1AffineCompressed)
+	for i := range points {
+		if i != 7 { // keep one point at infinity
+			points[i].ScalarMultiplication(&g1GenAff, new(big.Int).SetUint64(rand.Uint64())) //#nosec G404 weak rng is fine here
+		}
+		b := points[i].Bytes()
+		buf = append(buf, b[:]...)
+	}
+
+	decoded, err := BatchDecompressG1Affine(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, points) {
+		t.Fatal("decoded points don't match")
+	}
+
+	// corrupt a few points: invalid coordinate, uncompressed metadata, non-zero infinity
+	bad := []int{3, 7, 15}
+	for j := 0; j < SizeOfG1AffineCompressed; j++ {
+		buf[3*SizeOfG1AffineCompressed+j] = 0xff
+	}
+	buf[15*SizeOfG1AffineCompressed] &^= mMask
+	buf[8*SizeOfG1AffineCompressed-1] = 1
+
+	if _, err = BatchDecompressG1Affine(buf); err == nil {
+		t.Fatal("expected an error")
+	}
+	var bErr *BatchDecompressionError
+	if !errors.As(err, &bErr) {
+		t.Fatalf("unexpected error type %T", err)
+	}
+	if !reflect.DeepEqual(bErr.Indices, bad) || len(bErr.Errs) != len(bad) {
+		t.Fatalf("expected invalid indices %v, got %v", bad, bErr.Indices)
+	}
+
+	valid, skipped, err := BatchDecompressG1AffineSkipInvalid(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skipped == nil || !reflect.DeepEqual(skipped.Indices, bad) {
+		t.Fatal("wrong skipped indices")
+	}
+	expected := make([]G1Affine, 0, n-len(bad))
+	for i := range points {
+		if i != 3 && i != 7 && i != 15 {
+			expected = append(expected, points[i])
+		}
+	}
+	if !reflect.DeepEqual(valid, expected) {
+		t.Fatal("valid points don't match")
+	}
+
+	if _, err = BatchDecompressG1Affine(buf[1:]); err != ErrBatchBufferSize {
+		t.Fatal("expected ErrBatchBufferSize")
+	}
+
+	// batch subgroup check: same invalid indices
+	if _, err = BatchDecompressG1Affine(buf, WithBatchSubGroupCheck()); !errors.As(err, &bErr) || !reflect.DeepEqual(bErr.Indices, bad) {
+		t.Fatalf("expected invalid indices %v with batch subgroup check, got %v", bad, err)
+	}
+}
+
+func TestBatchDecodeG1Affine(t *testing.T) {
+	t.Parallel()
+	const n = 20
+	points := make([]G1Affine, n)
+	raw := make([]byte, 0, n*SizeOfG1AffineUncompressed)
+	compressed := make([]byte, 0, n*SizeOfG1AffineCompressed)
+	for i := range points {
+		if i != 5 { // keep one point at infinity
+			points[i].ScalarMultiplication(&g1GenAff, new(big.Int).SetUint64(rand.Uint64())) //#nosec G404 weak rng is fine here
+		}
+		b := points[i].RawBytes()
+		raw = append(raw, b[:]...)
+		bc := points[i].Bytes()
+		compressed = append(compressed, bc[:]...)
+	}
+
+	for _, buf := range [][]byte{raw, compressed} {
+		for _, opts := range [][]BatchDecompressOption{nil, {WithBatchSubGroupCheck()}} {
+			decoded, err := BatchDecodeG1Affine(buf, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(decoded, points) {
+				t.Fatal("decoded points don't match")
+			}
+		}
+	}
+
+	// a compressed point among uncompressed ones, and a point off the curve
+	bc := points[9].Bytes()
+	copy(raw[9*SizeOfG1AffineUncompressed:], bc[:])
+	raw[12*SizeOfG1AffineUncompressed-1] ^= 1
+	_, err := BatchDecodeG1Affine(raw)
+	var bErr *BatchDecompressionError
+	if !errors.As(err, &bErr) || !reflect.DeepEqual(bErr.Indices, []int{9, 11}) {
+		t.Fatalf("expected invalid indices [9 11], got %v", err)
+	}
+
+	if _, err = BatchDecodeG1Affine(raw[1:]); err != ErrBatchBufferSize {
+		t.Fatal("expected ErrBatchBufferSize")
+	}
+}
+
+func TestBatchDecompressG1AffineBatchSubGroupCheck(t *testing.T) {
+	t.Parallel()
+	const n = 300
+	points := make([]G1Affine, n)
+	buf := make([]byte, 0, n*SizeOfG1AffineCompressed)
+	for i := range points {
+		points[i].ScalarMultiplication(&g1GenAff, new(big.Int).SetUint64(rand.Uint64())) //#nosec G404 weak rng is fine here
+		b := points[i].Bytes()
+		buf = append(buf, b[:]...)
+	}
+
+	decoded, err := BatchDecompressG1Affine(buf, WithBatchSubGroupCheck())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, points) {
+		t.Fatal("decoded points don't match")
+	}
+
+	// points on the curve, outside the subgroup, are reported
+	bad := []int{12, 200}
+	for _, i := range bad {
+		p := randomNotInSubGroupG1Affine()
+		b := p.Bytes()
+		copy(buf[i*SizeOfG1AffineCompressed:], b[:])
+	}
+	_, err = BatchDecompressG1Affine(buf, WithBatchSubGroupCheck())
+	var bErr *BatchDecompressionError
+	if !errors.As(err, &bErr) || !reflect.DeepEqual(bErr.Indices, bad) {
+		t.Fatalf("expected invalid indices %v, got %v", bad, err)
+	}
+}
+
+func TestBatchDecompressG2Affine(t *testing.T) {
+	t.Parallel()
+	const n = 20
+	points := make([]G2Affine, n)
+	buf := make([]byte, 0, n*SizeOfG2AffineCompressed)
+	for i := range points {
+		if i != 7 { // keep one point at infinity
+			points[i].ScalarMultiplication(&g2GenAff, new(big.Int).SetUint64(rand.Uint64())) //#nosec G404 weak rng is fine here
+		}
+		b := points[i].Bytes()
+		buf = append(buf, b[:]...)
+	}
+
+	decoded, err := BatchDecompressG2Affine(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, points) {
+		t.Fatal("decoded points don't match")
+	}
+
+	// corrupt a few points: invalid coordinate, uncompressed metadata, non-zero infinity
+	bad := []int{3, 7, 15}
+	for j := 0; j < SizeOfG2AffineCompressed; j++ {
+		buf[3*SizeOfG2AffineCompressed+j] = 0xff
+	}
+	buf[15*SizeOfG2AffineCompressed] &^= mMask
+	buf[8*SizeOfG2AffineCompressed-1] = 1
+
+	if _, err = BatchDecompressG2Affine(buf); err == nil {
+		t.Fatal("expected an error")
+	}
+	var bErr *BatchDecompressionError
+	if !errors.As(err, &bErr) {
+		t.Fatalf("unexpected error type %T", err)
+	}
+	if !reflect.DeepEqual(bErr.Indices, bad) || len(bErr.Errs) != len(bad) {
+		t.Fatalf("expected invalid indices %v, got %v", bad, bErr.Indices)
+	}
+
+	valid, skipped, err := BatchDecompressG2AffineSkipInvalid(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skipped == nil || !reflect.DeepEqual(skipped.Indices, bad) {
+		t.Fatal("wrong skipped indices")
+	}
+	expected := make([]G2Affine, 0, n-len(bad))
+	for i := range points {
+		if i != 3 && i != 7 && i != 15 {
+			expected = append(expected, points[i])
+		}
+	}
+	if !reflect.DeepEqual(valid, expected) {
+		t.Fatal("valid points don't match")
+	}
+
+	if _, err = BatchDecompressG2Affine(buf[1:]); err != ErrBatchBufferSize {
+		t.Fatal("expected ErrBatchBufferSize")
+	}
+
+	// batch subgroup check: same invalid indices
+	if _, err = BatchDecompressG2Affine(buf, WithBatchSubGroupCheck()); !errors.As(err, &bErr) || !reflect.DeepEqual(bErr.Indices, bad) {
+		t.Fatalf("expected invalid indices %v with batch subgroup check, got %v", bad, err)
+	}
+}
+
+func TestBatchDecodeG2Affine(t *testing.T) {
+	t.Parallel()
+	const n = 20
+	points := make([]G2Affine, n)
+	raw := make([]byte, 0, n*SizeOfG2AffineUncompressed)
+	compressed := make([]byte, 0, n*SizeOfG2AffineCompressed)
+	for i := range points {
+		if i != 5 { // keep one point at infinity
+			points[i].ScalarMultiplication(&g2GenAff, new(big.Int).SetUint64(rand.Uint64())) //#nosec G404 weak rng is fine here
+		}
+		b := points[i].RawBytes()
+		raw = append(raw, b[:]...)
+		bc := points[i].Bytes()
+		compressed = append(compressed, bc[:]...)
+	}
+
+	for _, buf := range [][]byte{raw, compressed} {
+		for _, opts := range [][]BatchDecompressOption{nil, {WithBatchSubGroupCheck()}} {
+			decoded, err := BatchDecodeG2Affine(buf, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(decoded, points) {
+				t.Fatal("decoded points don't match")
+			}
+		}
+	}
+
+	// a compressed point among uncompressed ones, and a point off the curve
+	bc := points[9].Bytes()
+	copy(raw[9*SizeOfG2AffineUncompressed:], bc[:])
+	raw[12*SizeOfG2AffineUncompressed-1] ^= 1
+	_, err := BatchDecodeG2Affine(raw)
+	var bErr *BatchDecompressionError
+	if !errors.As(err, &bErr) || !reflect.DeepEqual(bErr.Indices, []int{9, 11}) {
+		t.Fatalf("expected invalid indices [9 11], got %v", err)
+	}
+
+	if _, err = BatchDecodeG2Affine(raw[1:]); err != ErrBatchBufferSize {
+		t.Fatal("expected ErrBatchBufferSize")
+	}
+}
+
+func TestBatchDecompressG2AffineBatchSubGroupCheck(t *testing.T) {
+	t.Parallel()
+	const n = 300
+	points := make([]G2Affine, n)
+	buf := make([]byte, 0, n*SizeOfG2AffineCompressed)
+	for i := range points {
+		points[i].ScalarMultiplication(&g2GenAff, new(big.Int).SetUint64(rand.Uint64())) //#nosec G404 weak rng is fine here
+		b := points[i].Bytes()
+		buf = append(buf, b[:]...)
+	}
+
+	decoded, err := BatchDecompressG2Affine(buf, WithBatchSubGroupCheck())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, points) {
+		t.Fatal("decoded points don't match")
+	}
+
+	// points on the curve, outside the subgroup, are reported
+	bad := []int{12, 200}
+	for _, i := range bad {
+		p := randomNotInSubGroupG2Affine()
+		b := p.Bytes()
+		copy(buf[i*SizeOfG2AffineCompressed:], b[:])
+	}
+	_, err = BatchDecompressG2Affine(buf, WithBatchSubGroupCheck())
+	var bErr *BatchDecompressionError
+	if !errors.As(err, &bErr) || !reflect.DeepEqual(bErr.Indices, bad) {
+		t.Fatalf("expected invalid indices %v, got %v", bad, err)
+	}
+}
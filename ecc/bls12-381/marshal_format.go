@@ -0,0 +1,123 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12381
+
+import (
+	"errors"
+)
+
+// PointFormat identifies a binary encoding of points, to interoperate with other libraries.
+//
+// In every format, a compressed encoding stores the X coordinate and an uncompressed
+// encoding stores X then Y, each on SizeOfG1AffineCompressed bytes for G1Affine
+// (SizeOfG2AffineCompressed bytes for G2Affine). Metadata is stored in the
+// unused most significant bits of the coordinates.
+type PointFormat uint8
+
+const (
+	// FormatGnark is the encoding of Bytes and RawBytes. Field elements are big endian and
+	// the extension field elements are written from the highest degree coefficient.
+	// The 3 most significant bits of the first byte are the ZCash flags:
+	//
+	//	bit 7 -> compressed
+	//	bit 6 -> point at infinity (the rest of the encoding is zero)
+	//	bit 5 -> compressed, and Y is lexicographically larger than -Y
+	FormatGnark PointFormat = iota
+
+	// FormatArkworks is the encoding of the arkworks (ark-serialize) libraries.
+	// On bls12-381, arkworks follows the ZCash encoding, so it is the same as FormatGnark.
+	FormatArkworks
+)
+
+// FormatZCash is the ZCash / IETF encoding, also used by blst. On bls12-381, it
+// is the same as FormatGnark.
+const FormatZCash = FormatGnark
+
+// ErrUnknownPointFormat is returned when encoding or decoding points with an unsupported PointFormat.
+var ErrUnknownPointFormat = errors.New("unknown point format")
+
+// MarshalFormat returns the encoding of p in the given format, compressed or not.
+func (p *G1Affine) MarshalFormat(format PointFormat, compressed bool) ([]byte, error) {
+	switch format {
+	case FormatGnark, FormatArkworks:
+		if compressed {
+			b := p.Bytes()
+			return b[:], nil
+		}
+		b := p.RawBytes()
+		return b[:], nil
+	default:
+		return nil, ErrUnknownPointFormat
+	}
+}
+
+// UnmarshalFormat sets p from its encoding in the given format. buf must be exactly
+// SizeOfG1AffineCompressed bytes for a compressed encoding, or SizeOfG1AffineUncompressed
+// bytes for an uncompressed one.
+//
+// It checks that the point is on the curve and in the correct subgroup.
+func (p *G1Affine) UnmarshalFormat(buf []byte, format PointFormat) error {
+	switch format {
+	case FormatGnark, FormatArkworks:
+		n, err := p.setBytes(buf, true)
+		if err != nil {
+			return err
+		}
+		if n != len(buf) {
+			return ErrInvalidEncoding
+		}
+		return nil
+	default:
+		return ErrUnknownPointFormat
+	}
+}
+
+// MarshalFormat returns the encoding of p in the given format, compressed or not.
+func (p *G2Affine) MarshalFormat(format PointFormat, compressed bool) ([]byte, error) {
+	switch format {
+	case FormatGnark, FormatArkworks:
+		if compressed {
+			b := p.Bytes()
+			return b[:], nil
+		}
+		b := p.RawBytes()
+		return b[:], nil
+	default:
+		return nil, ErrUnknownPointFormat
+	}
+}
+
+// UnmarshalFormat sets p from its encoding in the given format. buf must be exactly
+// SizeOfG2AffineCompressed bytes for a compressed encoding, or SizeOfG2AffineUncompressed
+// bytes for an uncompressed one.
+//
+// It checks that the point is on the curve and in the correct subgroup.
+func (p *G2Affine) UnmarshalFormat(buf []byte, format PointFormat) error {
+	switch format {
+	case FormatGnark, FormatArkworks:
+		n, err := p.setBytes(buf, true)
+		if err != nil {
+			return err
+		}
+		if n != len(buf) {
+			return ErrInvalidEncoding
+		}
+		return nil
+	default:
+		return ErrUnknownPointFormat
+	}
+}
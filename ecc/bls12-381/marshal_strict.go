@@ -0,0 +1,126 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bls12381
+
+import (
+	"errors"
+)
+
+var (
+	// ErrInvalidEncodingLength is returned by the strict decoding methods when the length of
+	// the buffer doesn't match the encoding announced by the flags in its most significant byte.
+	ErrInvalidEncodingLength = errors.New("invalid point encoding length")
+
+	// ErrPointNotOnCurve is returned by the strict decoding methods when the coordinates of an
+	// uncompressed encoding don't satisfy the curve equation.
+	ErrPointNotOnCurve = errors.New("invalid point: not on curve")
+
+	// ErrInfinityPublicKey is returned by SetPublicKeyBytes for the point at infinity.
+	ErrInfinityPublicKey = errors.New("invalid public key: point at infinity")
+)
+
+// SetBytesStrict sets p from buf, following the zkcrypto / blst encoding rules exactly.
+// It differs from SetBytes in that:
+//
+//   - buf must be exactly SizeOfG1AffineCompressed bytes for a compressed encoding, or
+//     SizeOfG1AffineUncompressed bytes for an uncompressed one;
+//   - the point at infinity must carry the infinity flag; an uncompressed encoding of
+//     the all-zero coordinates without it is rejected, as blst does (it's not on the curve);
+//   - the coordinates of an uncompressed encoding must satisfy the curve equation. The
+//     subgroup check alone doesn't guarantee it, its formulas don't depend on the curve
+//     coefficient b.
+//
+// As SetBytes, it checks that the point is in the prime order subgroup.
+func (p *G1Affine) SetBytesStrict(buf []byte) error {
+	if len(buf) == 0 {
+		return ErrInvalidEncodingLength
+	}
+	expected := SizeOfG1AffineUncompressed
+	if isCompressed(buf[0]) {
+		expected = SizeOfG1AffineCompressed
+	}
+	if len(buf) != expected {
+		return ErrInvalidEncodingLength
+	}
+	if buf[0]&mMask == mUncompressed && isZeroed(0, buf) {
+		return ErrInvalidInfinityEncoding
+	}
+	if _, err := p.setBytes(buf, false); err != nil {
+		return err
+	}
+	if !p.IsOnCurve() {
+		return ErrPointNotOnCurve
+	}
+	if !p.IsInSubGroup() {
+		return errors.New("invalid point: subgroup check failed")
+	}
+	return nil
+}
+
+// SetPublicKeyBytes sets p from the public key buf, as SetBytesStrict, and rejects the
+// point at infinity, as the KeyValidate procedure of the IETF BLS signature draft
+// (draft-irtf-cfrg-bls-signature-05, section 2.5) and blst do. It is the public key
+// encoding of the ciphersuites with public keys in G1.
+func (p *G1Affine) SetPublicKeyBytes(buf []byte) error {
+	if err := p.SetBytesStrict(buf); err != nil {
+		return err
+	}
+	if p.IsInfinity() {
+		return ErrInfinityPublicKey
+	}
+	return nil
+}
+
+// SetBytesStrict sets p from buf, following the zkcrypto / blst encoding rules exactly.
+// See G1Affine.SetBytesStrict.
+func (p *G2Affine) SetBytesStrict(buf []byte) error {
+	if len(buf) == 0 {
+		return ErrInvalidEncodingLength
+	}
+	expected := SizeOfG2AffineUncompressed
+	if isCompressed(buf[0]) {
+		expected = SizeOfG2AffineCompressed
+	}
+	if len(buf) != expected {
+		return ErrInvalidEncodingLength
+	}
+	if buf[0]&mMask == mUncompressed && isZeroed(0, buf) {
+		return ErrInvalidInfinityEncoding
+	}
+	if _, err := p.setBytes(buf, false); err != nil {
+		return err
+	}
+	if !p.IsOnCurve() {
+		return ErrPointNotOnCurve
+	}
+	if !p.IsInSubGroup() {
+		return errors.New("invalid point: subgroup check failed")
+	}
+	return nil
+}
+
+// SetPublicKeyBytes sets p from the public key buf, as SetBytesStrict, and rejects the
+// point at infinity, as the KeyValidate procedure of the IETF BLS signature draft
+// (draft-irtf-cfrg-bls-signature-05, section 2.5) and blst do. It is the public key
+// encoding of the ciphersuites with public keys in G2.
+func (p *G2Affine) SetPublicKeyBytes(buf []byte) error {
+	if err := p.SetBytesStrict(buf); err != nil {
+		return err
+	}
+	if p.IsInfinity() {
+		return ErrInfinityPublicKey
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bls12381
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetBytesStrict(t *testing.T) {
+	_, _, g1, g2 := Generators()
+
+	t.Run("G1", func(t *testing.T) {
+		var p G1Affine
+		compressed, uncompressed := g1.Bytes(), g1.RawBytes()
+		require.NoError(t, p.SetBytesStrict(compressed[:]))
+		require.True(t, p.Equal(&g1))
+		require.NoError(t, p.SetBytesStrict(uncompressed[:]))
+		require.True(t, p.Equal(&g1))
+
+		// the length must match the flags
+		require.ErrorIs(t, p.SetBytesStrict(uncompressed[:SizeOfG1AffineCompressed]), ErrInvalidEncodingLength)
+		require.ErrorIs(t, p.SetBytesStrict(append(compressed[:], 0)), ErrInvalidEncodingLength)
+		require.ErrorIs(t, p.SetBytesStrict(nil), ErrInvalidEncodingLength)
+
+		// infinity must be flagged
+		var zero [SizeOfG1AffineUncompressed]byte
+		_, err := p.SetBytes(zero[:])
+		require.NoError(t, err)
+		require.ErrorIs(t, p.SetBytesStrict(zero[:]), ErrInvalidInfinityEncoding)
+		var inf G1Affine
+		compressed, uncompressed = inf.Bytes(), inf.RawBytes()
+		require.NoError(t, p.SetBytesStrict(compressed[:]))
+		require.True(t, p.IsInfinity())
+		require.NoError(t, p.SetBytesStrict(uncompressed[:]))
+		require.True(t, p.IsInfinity())
+	})
+
+	t.Run("G2", func(t *testing.T) {
+		var p G2Affine
+		compressed, uncompressed := g2.Bytes(), g2.RawBytes()
+		require.NoError(t, p.SetBytesStrict(compressed[:]))
+		require.True(t, p.Equal(&g2))
+		require.NoError(t, p.SetBytesStrict(uncompressed[:]))
+		require.True(t, p.Equal(&g2))
+
+		require.ErrorIs(t, p.SetBytesStrict(uncompressed[:SizeOfG2AffineCompressed]), ErrInvalidEncodingLength)
+		require.ErrorIs(t, p.SetBytesStrict(append(compressed[:], 0)), ErrInvalidEncodingLength)
+
+		var zero [SizeOfG2AffineUncompressed]byte
+		_, err := p.SetBytes(zero[:])
+		require.NoError(t, err)
+		require.ErrorIs(t, p.SetBytesStrict(zero[:]), ErrInvalidInfinityEncoding)
+	})
+}
+
+func TestSetBytesStrictEdgeCases(t *testing.T) {
+	_, _, g1, _ := Generators()
+	var p G1Affine
+
+	// uncompressed coordinates off the curve
+	uncompressed := g1.RawBytes()
+	uncompressed[SizeOfG1AffineUncompressed-1] ^= 1
+	require.ErrorIs(t, p.SetBytesStrict(uncompressed[:]), ErrPointNotOnCurve)
+
+	// compressed infinity with the sign flag or a non zero x
+	var inf [SizeOfG1AffineCompressed]byte
+	inf[0] = mCompressedInfinity | mCompressedLargest
+	require.Error(t, p.SetBytesStrict(inf[:]))
+	inf[0] = mCompressedInfinity
+	inf[SizeOfG1AffineCompressed-1] = 1
+	require.ErrorIs(t, p.SetBytesStrict(inf[:]), ErrInvalidInfinityEncoding)
+
+	// compressed infinity without the compression flag, on the compressed length
+	inf[0], inf[SizeOfG1AffineCompressed-1] = mUncompressedInfinity, 0
+	require.ErrorIs(t, p.SetBytesStrict(inf[:]), ErrInvalidEncodingLength)
+
+	// x equal to the modulus
+	var x [SizeOfG1AffineCompressed]byte
+	fp.Modulus().FillBytes(x[:])
+	x[0] |= mCompressedSmallest
+	require.Error(t, p.SetBytesStrict(x[:]))
+}
+
+func TestSetPublicKeyBytes(t *testing.T) {
+	_, _, g1, g2 := Generators()
+
+	var pk1 G1Affine
+	b1 := g1.Bytes()
+	require.NoError(t, pk1.SetPublicKeyBytes(b1[:]))
+	require.True(t, pk1.Equal(&g1))
+	var inf1 G1Affine
+	b1 = inf1.Bytes()
+	require.ErrorIs(t, pk1.SetPublicKeyBytes(b1[:]), ErrInfinityPublicKey)
+
+	var pk2 G2Affine
+	b2 := g2.Bytes()
+	require.NoError(t, pk2.SetPublicKeyBytes(b2[:]))
+	require.True(t, pk2.Equal(&g2))
+	var inf2 G2Affine
+	b2 = inf2.Bytes()
+	require.ErrorIs(t, pk2.SetPublicKeyBytes(b2[:]), ErrInfinityPublicKey)
+}
+
+// FuzzSetBytesStrict checks that an accepted encoding is the canonical encoding of a point
+// of the subgroup, also accepted by SetBytes.
+func FuzzSetBytesStrict(f *testing.F) {
+	_, _, g1, g2 := Generators()
+	var inf1 G1Affine
+	var inf2 G2Affine
+	for _, b := range [][]byte{
+		g1.Marshal(), g2.Marshal(), inf1.Marshal(), inf2.Marshal(),
+	} {
+		f.Add(b)
+	}
+	b1, r1 := g1.Bytes(), g1.RawBytes()
+	b2, r2 := g2.Bytes(), g2.RawBytes()
+	f.Add(b1[:])
+	f.Add(r1[:])
+	f.Add(b2[:])
+	f.Add(r2[:])
+
+	f.Fuzz(func(t *testing.T, buf []byte) {
+		var p1 G1Affine
+		if err := p1.SetBytesStrict(buf); err == nil {
+			if !p1.IsInSubGroup() {
+				t.Fatal("accepted a G1 point outside of the subgroup")
+			}
+			var b []byte
+			if len(buf) == SizeOfG1AffineCompressed {
+				e := p1.Bytes()
+				b = e[:]
+			} else {
+				e := p1.RawBytes()
+				b = e[:]
+			}
+			if !bytes.Equal(b, buf) {
+				t.Fatal("accepted a non canonical G1 encoding")
+			}
+			var q G1Affine
+			if _, err := q.SetBytes(buf); err != nil || !q.Equal(&p1) {
+				t.Fatalf("SetBytes doesn't match SetBytesStrict: %v", err)
+			}
+		}
+
+		var p2 G2Affine
+		if err := p2.SetBytesStrict(buf); err == nil {
+			if !p2.IsInSubGroup() {
+				t.Fatal("accepted a G2 point outside of the subgroup")
+			}
+			var b []byte
+			if len(buf) == SizeOfG2AffineCompressed {
+				e := p2.Bytes()
+				b = e[:]
+			} else {
+				e := p2.RawBytes()
+				b = e[:]
+			}
+			if !bytes.Equal(b, buf) {
+				t.Fatal("accepted a non canonical G2 encoding")
+			}
+			var q G2Affine
+			if _, err := q.SetBytes(buf); err != nil || !q.Equal(&p2) {
+				t.Fatalf("SetBytes doesn't match SetBytesStrict: %v", err)
+			}
+		}
+	})
+}
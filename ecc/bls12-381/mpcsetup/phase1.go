@@ -0,0 +1,390 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package mpcsetup
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+)
+
+// domain separation tags of the proofs of knowledge of τ, α and β, and of the beacon
+const (
+	dstTau    = 1
+	dstAlpha  = 2
+	dstBeta   = 3
+	dstBeacon = "gnark-crypto/bls12-381/mpcsetup/beacon"
+)
+
+var (
+	ErrMinSize             = errors.New("mpcsetup: the number of powers must be at least 2")
+	ErrMalformedTranscript = errors.New("mpcsetup: malformed transcript")
+	ErrInvalidHash         = errors.New("mpcsetup: the hash doesn't match the contribution")
+	ErrProofOfKnowledge    = errors.New("mpcsetup: invalid proof of knowledge of the contribution")
+	ErrInconsistentUpdate  = errors.New("mpcsetup: the update doesn't match the proof of knowledge")
+	ErrInconsistentPowers  = errors.New("mpcsetup: inconsistent powers")
+	ErrInvalidSeal         = errors.New("mpcsetup: the contribution doesn't match the beacon")
+)
+
+// Phase1 is a powers of tau transcript, after its last contribution.
+//
+// implements io.ReaderFrom and io.WriterTo
+type Phase1 struct {
+	Parameters struct {
+		G1 struct {
+			Tau      []bls12381.G1Affine // [τ⁰]₁, [τ¹]₁, [τ²]₁, …, [τ²ⁿ⁻²]₁
+			AlphaTau []bls12381.G1Affine // [ατ⁰]₁, [ατ¹]₁, [ατ²]₁, …, [ατⁿ⁻¹]₁
+			BetaTau  []bls12381.G1Affine // [βτ⁰]₁, [βτ¹]₁, [βτ²]₁, …, [βτⁿ⁻¹]₁
+		}
+		G2 struct {
+			Tau  []bls12381.G2Affine // [τ⁰]₂, [τ¹]₂, [τ²]₂, …, [τⁿ⁻¹]₂
+			Beta bls12381.G2Affine   // [β]₂
+		}
+	}
+
+	// PublicKeys prove the knowledge of the last contribution to τ, α and β.
+	PublicKeys struct {
+		Tau, Alpha, Beta PublicKey
+	}
+
+	// Hash of the previous transcript and of this one. It is the challenge of the next
+	// contribution.
+	Hash []byte
+}
+
+// PublicKey proves the knowledge of a contribution x: it is ([s]₁, [sx]₁, [xr]₂) for a
+// random s, [r]₂ being hashed from [s]₁, [sx]₁ and the hash of the previous transcript.
+type PublicKey struct {
+	SG  bls12381.G1Affine
+	SXG bls12381.G1Affine
+	XR  bls12381.G2Affine
+}
+
+// NewPhase1 returns the initial transcript of a ceremony for n powers, with τ = α = β = 1.
+func NewPhase1(n int) (*Phase1, error) {
+	if n < 2 {
+		return nil, ErrMinSize
+	}
+	_, _, g1, g2 := bls12381.Generators()
+
+	p := new(Phase1)
+	p.Parameters.G1.Tau = make([]bls12381.G1Affine, 2*n-1)
+	p.Parameters.G1.AlphaTau = make([]bls12381.G1Affine, n)
+	p.Parameters.G1.BetaTau = make([]bls12381.G1Affine, n)
+	p.Parameters.G2.Tau = make([]bls12381.G2Affine, n)
+	for i := range p.Parameters.G1.Tau {
+		p.Parameters.G1.Tau[i] = g1
+	}
+	for i := 0; i < n; i++ {
+		p.Parameters.G1.AlphaTau[i] = g1
+		p.Parameters.G1.BetaTau[i] = g1
+		p.Parameters.G2.Tau[i] = g2
+	}
+	p.Parameters.G2.Beta = g2
+	p.Hash = p.hash(nil)
+	return p, nil
+}
+
+// Size returns the number n of powers of the transcript.
+func (p *Phase1) Size() int {
+	return len(p.Parameters.G2.Tau)
+}
+
+// Clone returns a deep copy of p.
+func (p *Phase1) Clone() *Phase1 {
+	res := new(Phase1)
+	res.Parameters.G1.Tau = append([]bls12381.G1Affine(nil), p.Parameters.G1.Tau...)
+	res.Parameters.G1.AlphaTau = append([]bls12381.G1Affine(nil), p.Parameters.G1.AlphaTau...)
+	res.Parameters.G1.BetaTau = append([]bls12381.G1Affine(nil), p.Parameters.G1.BetaTau...)
+	res.Parameters.G2.Tau = append([]bls12381.G2Affine(nil), p.Parameters.G2.Tau...)
+	res.Parameters.G2.Beta = p.Parameters.G2.Beta
+	res.PublicKeys = p.PublicKeys
+	res.Hash = append([]byte(nil), p.Hash...)
+	return res
+}
+
+// Contribute multiplies τ, α and β by secrets drawn from rand, and sets the proofs of
+// knowledge of these secrets and the hash of the new transcript. The secrets are not
+// kept, the security of the ceremony relies on it.
+func (p *Phase1) Contribute(rand io.Reader) error {
+	secrets, err := randomElements(rand, 6)
+	if err != nil {
+		return err
+	}
+	p.update(secrets)
+	return nil
+}
+
+// Seal applies the last contribution of the ceremony, with secrets derived from a public
+// random beacon, that no participant could predict (a future block hash for instance).
+// It makes the final transcript independent of the last participant. See VerifySeal.
+func (p *Phase1) Seal(beacon []byte) error {
+	secrets, err := beaconSecrets(p.Hash, beacon)
+	if err != nil {
+		return err
+	}
+	p.update(secrets)
+	return nil
+}
+
+// update multiplies τ, α and β by secrets[0:3], the proofs of knowledge being randomized
+// by secrets[3:6].
+func (p *Phase1) update(secrets []fr.Element) {
+	tau, alpha, beta := &secrets[0], &secrets[1], &secrets[2]
+	challenge := p.Hash
+	p.PublicKeys.Tau = newPublicKey(tau, &secrets[3], challenge, dstTau)
+	p.PublicKeys.Alpha = newPublicKey(alpha, &secrets[4], challenge, dstAlpha)
+	p.PublicKeys.Beta = newPublicKey(beta, &secrets[5], challenge, dstBeta)
+
+	n := p.Size()
+	taus := powers(tau, len(p.Parameters.G1.Tau))
+	alphaTaus := make([]fr.Element, n)
+	betaTaus := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		alphaTaus[i].Mul(&taus[i], alpha)
+		betaTaus[i].Mul(&taus[i], beta)
+	}
+
+	scaleG1InPlace(p.Parameters.G1.Tau[1:], taus[1:])
+	scaleG1InPlace(p.Parameters.G1.AlphaTau, alphaTaus)
+	scaleG1InPlace(p.Parameters.G1.BetaTau, betaTaus)
+	scaleG2InPlace(p.Parameters.G2.Tau[1:], taus[1:n])
+	var bBeta big.Int
+	beta.BigInt(&bBeta)
+	p.Parameters.G2.Beta.ScalarMultiplication(&p.Parameters.G2.Beta, &bBeta)
+
+	p.Hash = p.hash(challenge)
+}
+
+// Verify checks that next is a valid contribution on top of p: the proofs of knowledge
+// of its secrets, that the transcript was updated with these secrets, that it is made
+// of consistent powers, and its hash.
+func (p *Phase1) Verify(next *Phase1) error {
+	n := p.Size()
+	if !next.isWellSized(n) {
+		return ErrMalformedTranscript
+	}
+
+	// proofs of knowledge
+	rTau, err := next.PublicKeys.Tau.verify(p.Hash, dstTau)
+	if err != nil {
+		return err
+	}
+	rAlpha, err := next.PublicKeys.Alpha.verify(p.Hash, dstAlpha)
+	if err != nil {
+		return err
+	}
+	rBeta, err := next.PublicKeys.Beta.verify(p.Hash, dstBeta)
+	if err != nil {
+		return err
+	}
+
+	// the updates are the secrets of the proofs of knowledge
+	if !sameRatio(&p.Parameters.G1.Tau[1], &next.Parameters.G1.Tau[1], &rTau, &next.PublicKeys.Tau.XR) ||
+		!sameRatio(&p.Parameters.G1.AlphaTau[0], &next.Parameters.G1.AlphaTau[0], &rAlpha, &next.PublicKeys.Alpha.XR) ||
+		!sameRatio(&p.Parameters.G1.BetaTau[0], &next.Parameters.G1.BetaTau[0], &rBeta, &next.PublicKeys.Beta.XR) {
+		return ErrInconsistentUpdate
+	}
+
+	if err := next.verifyPowers(); err != nil {
+		return err
+	}
+
+	if !bytes.Equal(next.Hash, next.hash(p.Hash)) {
+		return ErrInvalidHash
+	}
+	return nil
+}
+
+// VerifySeal checks that sealed is the contribution on top of p from the beacon, see
+// Seal.
+func (p *Phase1) VerifySeal(sealed *Phase1, beacon []byte) error {
+	secrets, err := beaconSecrets(p.Hash, beacon)
+	if err != nil {
+		return err
+	}
+	// the proofs of knowledge are deterministic: Verify checks that the secrets are the
+	// ones of the beacon
+	if sealed.PublicKeys.Tau != newPublicKey(&secrets[0], &secrets[3], p.Hash, dstTau) ||
+		sealed.PublicKeys.Alpha != newPublicKey(&secrets[1], &secrets[4], p.Hash, dstAlpha) ||
+		sealed.PublicKeys.Beta != newPublicKey(&secrets[2], &secrets[5], p.Hash, dstBeta) {
+		return ErrInvalidSeal
+	}
+	return p.Verify(sealed)
+}
+
+// VerifyPhase1 checks the chain of contributions c0, c1, c…: c0 must be the initial
+// transcript returned by NewPhase1 and each next transcript a valid contribution on top
+// of the previous one.
+func VerifyPhase1(c0, c1 *Phase1, c ...*Phase1) error {
+	initial, err := NewPhase1(c0.Size())
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(c0.Hash, initial.Hash) || !bytes.Equal(c0.hash(nil), initial.Hash) {
+		return ErrMalformedTranscript
+	}
+
+	contribs := append([]*Phase1{c0, c1}, c...)
+	for i := 1; i < len(contribs); i++ {
+		if err := contribs[i-1].Verify(contribs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SRS returns the KZG SRS for polynomials of up to size coefficients, made of the
+// powers of τ of the transcript.
+func (p *Phase1) SRS(size uint64) (*kzg.SRS, error) {
+	if size < 2 {
+		return nil, kzg.ErrMinSRSSize
+	}
+	if size > uint64(len(p.Parameters.G1.Tau)) {
+		return nil, ErrMalformedTranscript
+	}
+	var srs kzg.SRS
+	srs.Pk.G1 = append([]bls12381.G1Affine(nil), p.Parameters.G1.Tau[:size]...)
+	srs.Vk.G1 = p.Parameters.G1.Tau[0]
+	srs.Vk.G2[0] = p.Parameters.G2.Tau[0]
+	srs.Vk.G2[1] = p.Parameters.G2.Tau[1]
+	srs.Vk.Lines[0] = bls12381.PrecomputeLines(srs.Vk.G2[0])
+	srs.Vk.Lines[1] = bls12381.PrecomputeLines(srs.Vk.G2[1])
+	return &srs, nil
+}
+
+// isWellSized returns true if p is a transcript of n powers.
+func (p *Phase1) isWellSized(n int) bool {
+	return n >= 2 &&
+		len(p.Parameters.G1.Tau) == 2*n-1 &&
+		len(p.Parameters.G1.AlphaTau) == n &&
+		len(p.Parameters.G1.BetaTau) == n &&
+		len(p.Parameters.G2.Tau) == n
+}
+
+// verifyPowers checks that the parameters of p are powers of the same τ, and that [β]₂
+// matches [β]₁, with random linear combinations.
+func (p *Phase1) verifyPowers() error {
+	_, _, g1, g2 := bls12381.Generators()
+	if !p.Parameters.G1.Tau[0].Equal(&g1) || !p.Parameters.G2.Tau[0].Equal(&g2) {
+		return ErrInconsistentPowers
+	}
+	if p.Parameters.G1.Tau[1].IsInfinity() || p.Parameters.G2.Tau[1].IsInfinity() ||
+		p.Parameters.G1.AlphaTau[0].IsInfinity() || p.Parameters.G1.BetaTau[0].IsInfinity() {
+		return ErrInconsistentPowers
+	}
+
+	tauG2 := &p.Parameters.G2.Tau[1]
+	for _, powers := range [][]bls12381.G1Affine{p.Parameters.G1.Tau, p.Parameters.G1.AlphaTau, p.Parameters.G1.BetaTau} {
+		l1, l2, err := linearCombinationG1(powers)
+		if err != nil {
+			return err
+		}
+		// l2 = τ⋅l1
+		if !sameRatio(&l1, &l2, &g2, tauG2) {
+			return ErrInconsistentPowers
+		}
+	}
+	l1, l2, err := linearCombinationG2(p.Parameters.G2.Tau)
+	if err != nil {
+		return err
+	}
+	if !sameRatio(&g1, &p.Parameters.G1.Tau[1], &l1, &l2) {
+		return ErrInconsistentPowers
+	}
+
+	if !sameRatio(&g1, &p.Parameters.G1.BetaTau[0], &g2, &p.Parameters.G2.Beta) {
+		return ErrInconsistentPowers
+	}
+	return nil
+}
+
+// hash returns the hash of prev and of the parameters and public keys of p.
+func (p *Phase1) hash(prev []byte) []byte {
+	h := sha256.New()
+	h.Write(prev)
+	if _, err := p.writeTo(h, bls12381.RawEncoding()); err != nil {
+		panic(err) // a hash doesn't return write errors
+	}
+	return h.Sum(nil)
+}
+
+func newPublicKey(x, s *fr.Element, challenge []byte, dst byte) PublicKey {
+	_, _, g1, _ := bls12381.Generators()
+	var pk PublicKey
+	var bx, bs big.Int
+	x.BigInt(&bx)
+	s.BigInt(&bs)
+	pk.SG.ScalarMultiplication(&g1, &bs)
+	pk.SXG.ScalarMultiplication(&pk.SG, &bx)
+	r := genR(&pk.SG, &pk.SXG, challenge, dst)
+	pk.XR.ScalarMultiplication(&r, &bx)
+	return pk
+}
+
+// verify checks the proof of knowledge of x, [sx]₁/[s]₁ = [xr]₂/[r]₂, and returns [r]₂.
+func (pk *PublicKey) verify(challenge []byte, dst byte) (bls12381.G2Affine, error) {
+	if pk.SG.IsInfinity() || pk.SXG.IsInfinity() || pk.XR.IsInfinity() {
+		return bls12381.G2Affine{}, ErrProofOfKnowledge
+	}
+	r := genR(&pk.SG, &pk.SXG, challenge, dst)
+	if !sameRatio(&pk.SG, &pk.SXG, &r, &pk.XR) {
+		return bls12381.G2Affine{}, ErrProofOfKnowledge
+	}
+	return r, nil
+}
+
+// genR hashes [s]₁, [sx]₁ and the challenge to [r]₂, whose discrete logarithm is
+// unknown.
+func genR(sG, sxG *bls12381.G1Affine, challenge []byte, dst byte) bls12381.G2Affine {
+	var buf bytes.Buffer
+	buf.Grow(2*bls12381.SizeOfG1AffineUncompressed + len(challenge))
+	b := sG.RawBytes()
+	buf.Write(b[:])
+	b = sxG.RawBytes()
+	buf.Write(b[:])
+	buf.Write(challenge)
+	r, err := bls12381.HashToG2(buf.Bytes(), []byte{dst})
+	if err != nil {
+		panic(err) // only fails on a too long dst
+	}
+	return r
+}
+
+// beaconSecrets derives the 6 secrets of the contribution Seal from the challenge and
+// the beacon.
+func beaconSecrets(challenge, beacon []byte) ([]fr.Element, error) {
+	msg := make([]byte, 0, len(challenge)+len(beacon))
+	msg = append(msg, challenge...)
+	msg = append(msg, beacon...)
+	secrets, err := fr.Hash(msg, []byte(dstBeacon), 6)
+	if err != nil {
+		return nil, err
+	}
+	for i := range secrets {
+		if secrets[i].IsZero() {
+			return nil, ErrInvalidSeal
+		}
+	}
+	return secrets, nil
+}
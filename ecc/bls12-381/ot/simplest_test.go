@@ -0,0 +1,127 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ot
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ot"
+)
+
+func randomChoices(t testing.TB, n int) []bool {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		t.Fatal(err)
+	}
+	res := make([]bool, n)
+	for i := range res {
+		res[i] = buf[i]&1 == 1
+	}
+	return res
+}
+
+func TestSimplestOT(t *testing.T) {
+	t.Parallel()
+
+	const n = 32
+	sender, err := NewSender(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	choices := randomChoices(t, n)
+	msg, keys, err := Receive(rand.Reader, sender.Setup(), choices)
+	if err != nil {
+		t.Fatal(err)
+	}
+	senderKeys, err := sender.Keys(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < n; i++ {
+		c := 0
+		if choices[i] {
+			c = 1
+		}
+		if keys[i] != senderKeys[i][c] || keys[i] == senderKeys[i][1-c] {
+			t.Fatalf("unexpected keys for OT %d", i)
+		}
+	}
+
+	// invalid messages are rejected
+	if _, err := sender.Keys(msg[1:]); err != ErrInvalidLength {
+		t.Fatal("expected ErrInvalidLength")
+	}
+	if _, _, err := Receive(rand.Reader, make([]byte, SizePoint), choices); err != ErrInvalidPoint {
+		t.Fatal("expected ErrInvalidPoint")
+	}
+}
+
+func TestSimplestOTExtension(t *testing.T) {
+	t.Parallel()
+
+	// the sender of the extension is the receiver of the base OTs
+	baseSender, err := NewSender(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	delta := randomChoices(t, ot.Kappa)
+	msg, received, err := Receive(rand.Reader, baseSender.Setup(), delta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sent, err := baseSender.Keys(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	extSender, err := ot.NewExtensionSender(delta, received)
+	if err != nil {
+		t.Fatal(err)
+	}
+	extReceiver, err := ot.NewExtensionReceiver(sent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const m = 500
+	choices := randomChoices(t, m)
+	u, keys := extReceiver.Extend(choices)
+	senderKeys, err := extSender.Extend(m, u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < m; i++ {
+		c := 0
+		if choices[i] {
+			c = 1
+		}
+		if keys[i] != senderKeys[i][c] {
+			t.Fatalf("unexpected keys for extended OT %d", i)
+		}
+	}
+}
+
+func BenchmarkSimplestOT(b *testing.B) {
+	choices := randomChoices(b, ot.Kappa)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sender, _ := NewSender(rand.Reader)
+		msg, _, _ := Receive(rand.Reader, sender.Setup(), choices)
+		sender.Keys(msg)
+	}
+}
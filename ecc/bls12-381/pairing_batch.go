@@ -0,0 +1,130 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bls12381
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+)
+
+// batchScalarBits is the bit size of the random scalars used to combine
+// pairing equations in a PairingBatch.
+const batchScalarBits = 128
+
+// PairingBatch verifies several independent pairing equations
+//
+//	∏ᵢ e(Pⱼᵢ, Qⱼᵢ) = 1, for j = 0..n-1
+//
+// at the cost of n Miller loops, n easy parts and a single hard part of the
+// final exponentiation. Each equation (but the first one) is raised to a
+// random 128-bit scalar so that a batch passes with probability at most 2⁻¹²⁸
+// if one of the equations doesn't hold.
+//
+// PairingBatch is not safe for concurrent use.
+type PairingBatch struct {
+	acc  GT
+	n    int
+	rand io.Reader
+}
+
+// NewPairingBatch returns an empty PairingBatch drawing its randomness from crypto/rand.
+func NewPairingBatch() *PairingBatch {
+	return NewPairingBatchWithRandomness(rand.Reader)
+}
+
+// NewPairingBatchWithRandomness returns an empty PairingBatch drawing its randomness from r.
+func NewPairingBatchWithRandomness(r io.Reader) *PairingBatch {
+	b := &PairingBatch{rand: r}
+	b.acc.SetOne()
+	return b
+}
+
+// Len returns the number of equations added to the batch.
+func (b *PairingBatch) Len() int {
+	return b.n
+}
+
+// Reset empties the batch.
+func (b *PairingBatch) Reset() {
+	b.acc.SetOne()
+	b.n = 0
+}
+
+// Add adds the equation ∏ᵢ e(Pᵢ, Qᵢ) = 1 to the batch.
+//
+// This function doesn't check that the inputs are in the correct subgroup. See IsInSubGroup.
+func (b *PairingBatch) Add(P []G1Affine, Q []G2Affine) error {
+	if len(P) == 0 || len(P) != len(Q) {
+		return errors.New("invalid inputs sizes")
+	}
+	if b.n > 0 {
+		// scaling the G1 inputs is cheaper than exponentiating in GT.
+		s, err := b.randomScalar()
+		if err != nil {
+			return err
+		}
+		p := make([]G1Affine, len(P))
+		for i := range P {
+			p[i].ScalarMultiplication(&P[i], s)
+		}
+		P = p
+	}
+	f, err := MillerLoop(P, Q)
+	if err != nil {
+		return err
+	}
+	f = FinalExponentiationEasy(&f)
+	b.acc.Mul(&b.acc, &f)
+	b.n++
+	return nil
+}
+
+// AddMillerLoop adds the equation FinalExponentiation(f) = 1 to the batch,
+// where f is a previously computed (e.g. cached) Miller loop result.
+func (b *PairingBatch) AddMillerLoop(f *GT) error {
+	e := FinalExponentiationEasy(f)
+	if b.n > 0 {
+		s, err := b.randomScalar()
+		if err != nil {
+			return err
+		}
+		e.CyclotomicExp(e, s)
+	}
+	b.acc.Mul(&b.acc, &e)
+	b.n++
+	return nil
+}
+
+// Check returns true if all the equations in the batch hold. An empty batch is valid.
+func (b *PairingBatch) Check() bool {
+	res := FinalExponentiationHard(&b.acc)
+	var one GT
+	one.SetOne()
+	return res.Equal(&one)
+}
+
+func (b *PairingBatch) randomScalar() (*big.Int, error) {
+	var buf [batchScalarBits / 8]byte
+	if _, err := io.ReadFull(b.rand, buf[:]); err != nil {
+		return nil, err
+	}
+	s := new(big.Int).SetBytes(buf[:])
+	if s.Sign() == 0 {
+		s.SetUint64(1)
+	}
+	return s, nil
+}
@@ -163,6 +163,48 @@ func TestPairing(t *testing.T) {
 		genR2,
 	))
 
+	properties.Property("[BLS12-381] PairMSM should output the same result as Pair on the scaled points", prop.ForAll(
+		func(a, b fr.Element) bool {
+
+			var bg2 G2Affine
+			var bbigint big.Int
+			b.BigInt(&bbigint)
+			bg2.ScalarMultiplication(&g2GenAff, &bbigint)
+
+			var c fr.Element
+			c.Square(&a)
+			var ag1 G1Affine
+			var abigint, cbigint big.Int
+			a.BigInt(&abigint)
+			c.BigInt(&cbigint)
+			ag1.ScalarMultiplication(&g1GenAff, &abigint)
+
+			// two pairs share g2GenAff, one pair uses bg2
+			P := []G1Affine{g1GenAff, ag1, ag1}
+			Q := []G2Affine{g2GenAff, bg2, g2GenAff}
+			scalars := []fr.Element{a, b, c}
+
+			var scaled [3]G1Affine
+			scaled[0].ScalarMultiplication(&P[0], &abigint)
+			scaled[1].ScalarMultiplication(&P[1], &bbigint)
+			scaled[2].ScalarMultiplication(&P[2], &cbigint)
+
+			res1, _ := Pair(scaled[:], Q)
+			res2, _ := PairMSM(P, Q, scalars)
+
+			// e([a]g1, g2) ⋅ e([-a]g1, g2) = 1
+			var aNeg fr.Element
+			aNeg.Neg(&a)
+			ok, _ := PairingCheckMSM([]G1Affine{g1GenAff, g1GenAff}, []G2Affine{g2GenAff, g2GenAff}, []fr.Element{a, aNeg})
+
+			_, err := PairMSM(P, Q, scalars[:2])
+
+			return res1.Equal(&res2) && ok && err != nil
+		},
+		genR1,
+		genR2,
+	))
+
 	properties.Property("[BLS12-381] Pair should output the same result with MillerLoop or MillerLoopFixedQ", prop.ForAll(
 		func(a, b fr.Element) bool {
 
@@ -197,6 +239,75 @@ func TestPairing(t *testing.T) {
 		genR2,
 	))
 
+	properties.Property("[BLS12-381] FinalExponentiationHard(FinalExponentiationEasy) should equal FinalExponentiation", prop.ForAll(
+		func(a, b GT) bool {
+			res := FinalExponentiation(&a, &b)
+			easy := FinalExponentiationEasy(&a, &b)
+			hard := FinalExponentiationHard(&easy)
+			return res.Equal(&hard)
+		},
+		genA,
+		genA,
+	))
+
+	properties.Property("[BLS12-381] PairingBatch should accept valid equations and reject an invalid one", prop.ForAll(
+		func(a, b fr.Element) bool {
+
+			var ag1, ag1Neg G1Affine
+			var bg2 G2Affine
+
+			var abigint, bbigint big.Int
+
+			a.BigInt(&abigint)
+			b.BigInt(&bbigint)
+
+			ag1.ScalarMultiplication(&g1GenAff, &abigint)
+			ag1Neg.Neg(&ag1)
+			bg2.ScalarMultiplication(&g2GenAff, &bbigint)
+
+			// e(a, b) ⋅ e(-a, b) = 1
+			P := []G1Affine{ag1, ag1Neg}
+			Q := []G2Affine{bg2, bg2}
+			// e(a, b) ⋅ e(a, b) ≠ 1
+			PBad := []G1Affine{ag1, ag1}
+
+			ml, _ := MillerLoop(P, Q)
+
+			batch := NewPairingBatch()
+			if !batch.Check() {
+				return false
+			}
+			if err := batch.Add(P, Q); err != nil {
+				return false
+			}
+			if err := batch.AddMillerLoop(&ml); err != nil {
+				return false
+			}
+			if err := batch.Add(P, Q); err != nil {
+				return false
+			}
+			ok := batch.Check() && batch.Len() == 3
+
+			if err := batch.Add(PBad, Q); err != nil {
+				return false
+			}
+			ko := batch.Check()
+
+			batch.Reset()
+			mlBad, _ := MillerLoop(PBad, Q)
+			if err := batch.AddMillerLoop(&mlBad); err != nil {
+				return false
+			}
+			if err := batch.Add(P, Q); err != nil {
+				return false
+			}
+
+			return ok && !ko && !batch.Check()
+		},
+		genR1,
+		genR2,
+	))
+
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
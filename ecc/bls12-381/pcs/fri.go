@@ -0,0 +1,110 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package pcs
+
+import (
+	"bytes"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fri"
+)
+
+var _ PolynomialCommitment[fri.Digest, fri.DeepProof, []fri.DeepProof] = (*FRI)(nil)
+
+// FRI is the polynomial commitment scheme built on DEEP-FRI: a digest is the Merkle
+// root of the evaluations of the polynomial, an opening proof is a DEEP proof of
+// proximity at the opening point, see fri.Committer.BuildDeepProofOfProximityAt.
+//
+// The commitment is not kept between Commit and Open: Open evaluates and hashes the
+// polynomial again. A batch opening is the list of the individual openings.
+type FRI struct {
+	iopp      fri.Iopp
+	committer *fri.Committer
+}
+
+// NewFRI returns the FRI scheme on iopp, as returned by fri.IOPP.New or
+// fri.IOPP.NewWithOptions.
+func NewFRI(iopp fri.Iopp) (*FRI, error) {
+	committer, err := fri.NewCommitter(iopp)
+	if err != nil {
+		return nil, err
+	}
+	return &FRI{iopp: iopp, committer: committer}, nil
+}
+
+// Commit commits to the polynomial p.
+func (s *FRI) Commit(p []fr.Element) (fri.Digest, error) {
+	return s.committer.Commit(p).Root(), nil
+}
+
+// Open proves the evaluation of p at point. point must not be in the evaluation domain.
+func (s *FRI) Open(p []fr.Element, point fr.Element) (fri.DeepProof, error) {
+	return s.committer.BuildDeepProofOfProximityAt(s.committer.Commit(p), point)
+}
+
+// BatchOpen proves the evaluations of the polynomials, committed in digests, at point.
+func (s *FRI) BatchOpen(polynomials [][]fr.Element, digests []fri.Digest, point fr.Element) ([]fri.DeepProof, error) {
+	if len(polynomials) != len(digests) {
+		return nil, ErrInvalidNbDigests
+	}
+	proofs := make([]fri.DeepProof, len(polynomials))
+	for i := range polynomials {
+		var err error
+		if proofs[i], err = s.Open(polynomials[i], point); err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(proofs[i].Root, digests[i]) {
+			return nil, ErrDigestMismatch
+		}
+	}
+	return proofs, nil
+}
+
+// Verify verifies the opening of the polynomial committed in digest at point.
+func (s *FRI) Verify(digest fri.Digest, proof fri.DeepProof, point fr.Element) error {
+	if !bytes.Equal(proof.Root, digest) {
+		return ErrDigestMismatch
+	}
+	return s.iopp.VerifyDeepProofOfProximityAt(proof, point)
+}
+
+// BatchVerify verifies the batch opening of the polynomials committed in digests at point.
+func (s *FRI) BatchVerify(digests []fri.Digest, proofs []fri.DeepProof, point fr.Element) error {
+	if len(digests) != len(proofs) {
+		return ErrInvalidNbDigests
+	}
+	for i := range proofs {
+		if err := s.Verify(digests[i], proofs[i], point); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClaimedValue returns the evaluation proven by proof.
+func (s *FRI) ClaimedValue(proof fri.DeepProof) fr.Element {
+	return proof.Evaluation
+}
+
+// BatchClaimedValues returns the evaluations proven by proofs.
+func (s *FRI) BatchClaimedValues(proofs []fri.DeepProof) []fr.Element {
+	res := make([]fr.Element, len(proofs))
+	for i := range proofs {
+		res[i] = proofs[i].Evaluation
+	}
+	return res
+}
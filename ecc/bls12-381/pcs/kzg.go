@@ -0,0 +1,74 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package pcs
+
+import (
+	"hash"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+)
+
+var _ PolynomialCommitment[kzg.Digest, kzg.OpeningProof, kzg.BatchOpeningProof] = (*KZG)(nil)
+
+// KZG is the KZG polynomial commitment scheme.
+type KZG struct {
+	pk kzg.ProvingKey
+	vk kzg.VerifyingKey
+	hf hash.Hash
+}
+
+// NewKZG returns the KZG scheme with the given keys. hf is used for the Fiat-Shamir
+// challenges of batch openings. A verifier can give an empty proving key.
+func NewKZG(pk kzg.ProvingKey, vk kzg.VerifyingKey, hf hash.Hash) *KZG {
+	return &KZG{pk: pk, vk: vk, hf: hf}
+}
+
+// Commit commits to the polynomial p.
+func (s *KZG) Commit(p []fr.Element) (kzg.Digest, error) {
+	return kzg.Commit(p, s.pk)
+}
+
+// Open proves the evaluation of p at point.
+func (s *KZG) Open(p []fr.Element, point fr.Element) (kzg.OpeningProof, error) {
+	return kzg.Open(p, point, s.pk)
+}
+
+// BatchOpen proves the evaluations of the polynomials, committed in digests, at point.
+func (s *KZG) BatchOpen(polynomials [][]fr.Element, digests []kzg.Digest, point fr.Element) (kzg.BatchOpeningProof, error) {
+	return kzg.BatchOpenSinglePoint(polynomials, digests, point, s.hf, s.pk)
+}
+
+// Verify verifies the opening of the polynomial committed in digest at point.
+func (s *KZG) Verify(digest kzg.Digest, proof kzg.OpeningProof, point fr.Element) error {
+	return kzg.Verify(&digest, &proof, point, s.vk)
+}
+
+// BatchVerify verifies the batch opening of the polynomials committed in digests at point.
+func (s *KZG) BatchVerify(digests []kzg.Digest, proof kzg.BatchOpeningProof, point fr.Element) error {
+	return kzg.BatchVerifySinglePoint(digests, &proof, point, s.hf, s.vk)
+}
+
+// ClaimedValue returns the evaluation proven by proof.
+func (s *KZG) ClaimedValue(proof kzg.OpeningProof) fr.Element {
+	return proof.ClaimedValue
+}
+
+// BatchClaimedValues returns the evaluations proven by proof.
+func (s *KZG) BatchClaimedValues(proof kzg.BatchOpeningProof) []fr.Element {
+	return proof.ClaimedValues
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package pcs
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fri"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+	"github.com/stretchr/testify/require"
+)
+
+const testSize = 64
+
+func TestKZG(t *testing.T) {
+	srs, err := kzg.NewSRS(testSize, big.NewInt(42))
+	require.NoError(t, err)
+	testScheme[kzg.Digest, kzg.OpeningProof, kzg.BatchOpeningProof](t, NewKZG(srs.Pk, srs.Vk, sha256.New()))
+}
+
+func TestFRI(t *testing.T) {
+	s, err := NewFRI(fri.RADIX_2_FRI.New(testSize, sha256.New()))
+	require.NoError(t, err)
+	testScheme[fri.Digest, fri.DeepProof, []fri.DeepProof](t, s)
+}
+
+// testScheme runs the same protocol with any scheme.
+func testScheme[D, P, B any](t *testing.T, s PolynomialCommitment[D, P, B]) {
+	assert := require.New(t)
+
+	polynomials := make([][]fr.Element, 3)
+	digests := make([]D, len(polynomials))
+	for i := range polynomials {
+		polynomials[i] = make([]fr.Element, testSize)
+		for j := range polynomials[i] {
+			polynomials[i][j].SetRandom()
+		}
+		var err error
+		digests[i], err = s.Commit(polynomials[i])
+		assert.NoError(err)
+	}
+
+	var point, other fr.Element
+	point.SetRandom()
+	other.SetRandom()
+
+	// single opening
+	proof, err := s.Open(polynomials[0], point)
+	assert.NoError(err)
+	assert.NoError(s.Verify(digests[0], proof, point))
+	claimed := s.ClaimedValue(proof)
+	expected := eval(polynomials[0], point)
+	assert.True(expected.Equal(&claimed), "wrong claimed value")
+	assert.Error(s.Verify(digests[1], proof, point), "verifying against another digest should fail")
+	assert.Error(s.Verify(digests[0], proof, other), "verifying at another point should fail")
+
+	// batch opening
+	batchProof, err := s.BatchOpen(polynomials, digests, point)
+	assert.NoError(err)
+	assert.NoError(s.BatchVerify(digests, batchProof, point))
+	values := s.BatchClaimedValues(batchProof)
+	assert.Equal(len(polynomials), len(values))
+	for i := range polynomials {
+		expected := eval(polynomials[i], point)
+		assert.True(expected.Equal(&values[i]), "wrong claimed value")
+	}
+	assert.Error(s.BatchVerify(digests, batchProof, other), "verifying at another point should fail")
+	digests[0], digests[1] = digests[1], digests[0]
+	assert.Error(s.BatchVerify(digests, batchProof, point), "verifying against other digests should fail")
+}
+
+func eval(p []fr.Element, point fr.Element) fr.Element {
+	var res fr.Element
+	for i := len(p) - 1; i >= 0; i-- {
+		res.Mul(&res, &point).Add(&res, &p[i])
+	}
+	return res
+}
@@ -0,0 +1,203 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bls12381
+
+import (
+	"crypto/rand"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+// ErrRerandomizationProof is returned when a rerandomization proof is invalid.
+var ErrRerandomizationProof = errors.New("invalid rerandomization proof")
+
+// A commitment C (Pedersen, KZG, …) is rerandomized into C' = C + [r]H, where H is
+// a blinding base whose discrete logarithm in the commitment bases is unknown, and
+// r a random blinding factor. C' hides which commitment it comes from; the
+// rerandomization proof convinces a verifier who knows C that C' is a
+// rerandomization of it, without revealing r.
+//
+// For a KZG commitment and H the first point [1]G₁ of the SRS, C' is the
+// commitment to p + r: an opening of p at any point is turned into an opening of
+// C' by adding r to the claimed value, the quotient is unchanged.
+
+// G1RerandomizationProof is a Schnorr proof of knowledge of r such that
+// C' - C = [r]H, in G1.
+type G1RerandomizationProof struct {
+	A G1Affine   // A = [k]H for a random k
+	Z fr.Element // z = k + c⋅r where c is the Fiat-Shamir challenge
+}
+
+// G2RerandomizationProof is a Schnorr proof of knowledge of r such that
+// C' - C = [r]H, in G2.
+type G2RerandomizationProof struct {
+	A G2Affine   // A = [k]H for a random k
+	Z fr.Element // z = k + c⋅r where c is the Fiat-Shamir challenge
+}
+
+// RandomBlindingFactor returns a uniformly random non-zero blinding factor read from rnd
+// (crypto/rand if nil).
+func RandomBlindingFactor(rnd io.Reader) (fr.Element, error) {
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+	var modMinusOne big.Int
+	modMinusOne.Sub(fr.Modulus(), big.NewInt(1))
+	s, err := rand.Int(rnd, &modMinusOne)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	s.Add(s, big.NewInt(1))
+	var res fr.Element
+	res.SetBigInt(s)
+	return res, nil
+}
+
+// Blind sets p to c + [r]h and returns it.
+func (p *G1Affine) Blind(c, h *G1Affine, r *fr.Element) *G1Affine {
+	var br big.Int
+	r.BigInt(&br)
+	var res G1Jac
+	res.FromAffine(h)
+	res.ScalarMultiplication(&res, &br)
+	res.AddMixed(c)
+	p.FromJacobian(&res)
+	return p
+}
+
+// Blind sets p to c + [r]h and returns it.
+func (p *G2Affine) Blind(c, h *G2Affine, r *fr.Element) *G2Affine {
+	var br big.Int
+	r.BigInt(&br)
+	var res G2Jac
+	res.FromAffine(h)
+	res.ScalarMultiplication(&res, &br)
+	res.AddMixed(c)
+	p.FromJacobian(&res)
+	return p
+}
+
+// ProveRerandomizationG1 proves that blinded = c + [r]h. The nonce of the proof is
+// read from rnd (crypto/rand if nil), the challenge is derived with hf from h, c,
+// blinded and the first message of the proof.
+func ProveRerandomizationG1(c, blinded, h *G1Affine, r *fr.Element, hf hash.Hash, rnd io.Reader) (G1RerandomizationProof, error) {
+	var proof G1RerandomizationProof
+	k, err := RandomBlindingFactor(rnd)
+	if err != nil {
+		return proof, err
+	}
+	var bk big.Int
+	k.BigInt(&bk)
+	proof.A.ScalarMultiplication(h, &bk)
+
+	challenge, err := deriveRerandomizationChallenge(hf, h.Marshal(), c.Marshal(), blinded.Marshal(), proof.A.Marshal())
+	if err != nil {
+		return proof, err
+	}
+	proof.Z.Mul(&challenge, r).Add(&proof.Z, &k)
+	return proof, nil
+}
+
+// VerifyRerandomizationG1 checks a proof that blinded = c + [r]h for an r known to
+// the prover. hf must be the hash function used by the prover.
+func VerifyRerandomizationG1(c, blinded, h *G1Affine, proof *G1RerandomizationProof, hf hash.Hash) error {
+	if !proof.A.IsInSubGroup() || !blinded.IsInSubGroup() {
+		return ErrRerandomizationProof
+	}
+	challenge, err := deriveRerandomizationChallenge(hf, h.Marshal(), c.Marshal(), blinded.Marshal(), proof.A.Marshal())
+	if err != nil {
+		return err
+	}
+
+	// [z]H == A + [c](C' - C)
+	var bz, bc big.Int
+	proof.Z.BigInt(&bz)
+	challenge.BigInt(&bc)
+	var lhs, rhs, diff G1Jac
+	lhs.FromAffine(h)
+	lhs.ScalarMultiplication(&lhs, &bz)
+	diff.FromAffine(c)
+	diff.Neg(&diff).AddMixed(blinded)
+	rhs.ScalarMultiplication(&diff, &bc).AddMixed(&proof.A)
+	if !lhs.Equal(&rhs) {
+		return ErrRerandomizationProof
+	}
+	return nil
+}
+
+// ProveRerandomizationG2 proves that blinded = c + [r]h. The nonce of the proof is
+// read from rnd (crypto/rand if nil), the challenge is derived with hf from h, c,
+// blinded and the first message of the proof.
+func ProveRerandomizationG2(c, blinded, h *G2Affine, r *fr.Element, hf hash.Hash, rnd io.Reader) (G2RerandomizationProof, error) {
+	var proof G2RerandomizationProof
+	k, err := RandomBlindingFactor(rnd)
+	if err != nil {
+		return proof, err
+	}
+	var bk big.Int
+	k.BigInt(&bk)
+	proof.A.ScalarMultiplication(h, &bk)
+
+	challenge, err := deriveRerandomizationChallenge(hf, h.Marshal(), c.Marshal(), blinded.Marshal(), proof.A.Marshal())
+	if err != nil {
+		return proof, err
+	}
+	proof.Z.Mul(&challenge, r).Add(&proof.Z, &k)
+	return proof, nil
+}
+
+// VerifyRerandomizationG2 checks a proof that blinded = c + [r]h for an r known to
+// the prover. hf must be the hash function used by the prover.
+func VerifyRerandomizationG2(c, blinded, h *G2Affine, proof *G2RerandomizationProof, hf hash.Hash) error {
+	if !proof.A.IsInSubGroup() || !blinded.IsInSubGroup() {
+		return ErrRerandomizationProof
+	}
+	challenge, err := deriveRerandomizationChallenge(hf, h.Marshal(), c.Marshal(), blinded.Marshal(), proof.A.Marshal())
+	if err != nil {
+		return err
+	}
+
+	// [z]H == A + [c](C' - C)
+	var bz, bc big.Int
+	proof.Z.BigInt(&bz)
+	challenge.BigInt(&bc)
+	var lhs, rhs, diff G2Jac
+	lhs.FromAffine(h)
+	lhs.ScalarMultiplication(&lhs, &bz)
+	diff.FromAffine(c)
+	diff.Neg(&diff).AddMixed(blinded)
+	rhs.ScalarMultiplication(&diff, &bc).AddMixed(&proof.A)
+	if !lhs.Equal(&rhs) {
+		return ErrRerandomizationProof
+	}
+	return nil
+}
+
+// deriveRerandomizationChallenge derives the challenge of a rerandomization proof
+// from the blinding base, the commitments and the first message.
+func deriveRerandomizationChallenge(hf hash.Hash, h, c, blinded, a []byte) (fr.Element, error) {
+	fs := fiatshamir.NewTranscript(hf, "c")
+	for _, b := range [][]byte{h, c, blinded, a} {
+		if err := fs.Bind("c", b); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	return fiatshamir.ComputeChallengeFr[fr.Element](fs, "c")
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bls12381
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestRerandomization(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[BLS12-381] G1 rerandomization proofs should verify, and only for the blinded commitment", prop.ForAll(
+		func(s, u fr.Element) bool {
+			var sInt, uInt big.Int
+			s.BigInt(&sInt)
+			u.BigInt(&uInt)
+			var c, h G1Affine
+			c.ScalarMultiplication(&g1GenAff, &sInt)
+			h.ScalarMultiplication(&g1GenAff, &uInt)
+
+			r, err := RandomBlindingFactor(nil)
+			if err != nil {
+				return false
+			}
+			var blinded G1Affine
+			blinded.Blind(&c, &h, &r)
+
+			proof, err := ProveRerandomizationG1(&c, &blinded, &h, &r, sha256.New(), nil)
+			if err != nil || VerifyRerandomizationG1(&c, &blinded, &h, &proof, sha256.New()) != nil {
+				return false
+			}
+
+			// another commitment, or a wrong blinding factor, are rejected
+			var other G1Affine
+			other.Double(&c)
+			if VerifyRerandomizationG1(&other, &blinded, &h, &proof, sha256.New()) == nil {
+				return false
+			}
+			var rr fr.Element
+			rr.SetOne().Add(&rr, &r)
+			proof, err = ProveRerandomizationG1(&c, &blinded, &h, &rr, sha256.New(), nil)
+			return err == nil && VerifyRerandomizationG1(&c, &blinded, &h, &proof, sha256.New()) != nil
+		},
+		GenFr(),
+		GenFr(),
+	))
+
+	properties.Property("[BLS12-381] G2 rerandomization proofs should verify, and only for the blinded commitment", prop.ForAll(
+		func(s, u fr.Element) bool {
+			var sInt, uInt big.Int
+			s.BigInt(&sInt)
+			u.BigInt(&uInt)
+			var c, h G2Affine
+			c.ScalarMultiplication(&g2GenAff, &sInt)
+			h.ScalarMultiplication(&g2GenAff, &uInt)
+
+			r, err := RandomBlindingFactor(nil)
+			if err != nil {
+				return false
+			}
+			var blinded G2Affine
+			blinded.Blind(&c, &h, &r)
+
+			proof, err := ProveRerandomizationG2(&c, &blinded, &h, &r, sha256.New(), nil)
+			if err != nil || VerifyRerandomizationG2(&c, &blinded, &h, &proof, sha256.New()) != nil {
+				return false
+			}
+
+			var other G2Affine
+			other.Double(&c)
+			return VerifyRerandomizationG2(&other, &blinded, &h, &proof, sha256.New()) != nil
+		},
+		GenFr(),
+		GenFr(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
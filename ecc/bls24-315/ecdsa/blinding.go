@@ -0,0 +1,116 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdsa
+
+import (
+	"crypto/rand"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fp"
+)
+
+// WithBlinding hardens the signing against side-channel attacks, for signers
+// running on hardware shared with potential attackers. The signatures are the
+// same as without blinding, at about twice the cost.
+//
+// The nonce k is randomly split in k₁ + k₂, and k ⋅ G is computed as
+// k₁ ⋅ G' + k₂ ⋅ G' where G' is the generator in Jacobian coordinates with a
+// random Z, instead of using the precomputed generator table. The inverse of k
+// and the product with the private key are blinded by a random factor β:
+// s = (β ⋅ k)⁻¹ ⋅ (β ⋅ m + r ⋅ (β ⋅ sk)).
+//
+// The blinding factors are drawn from crypto/rand, so that the nonce
+// derivation and the signatures are unchanged.
+func WithBlinding() SignOption {
+	return func(c *signConfig) {
+		c.blinding = true
+	}
+}
+
+// mulBase returns k ⋅ G.
+func (c *signConfig) mulBase(k *big.Int) (*bls24315.G1Affine, error) {
+	var res bls24315.G1Affine
+	if !c.blinding {
+		return res.ScalarMultiplicationBase(k), nil
+	}
+
+	k1, err := randFieldElement(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	k2 := new(big.Int).Sub(k, k1)
+	k2.Mod(k2, order)
+
+	g, err := randomizedGenerator()
+	if err != nil {
+		return nil, err
+	}
+	var p1, p2 bls24315.G1Jac
+	p1.ScalarMultiplication(&g, k1)
+	p2.ScalarMultiplication(&g, k2)
+	p1.AddAssign(&p2)
+	return res.FromJacobian(&p1), nil
+}
+
+// computeS sets s = k⁻¹ ⋅ (m + r ⋅ sk) mod order.
+func (c *signConfig) computeS(s, k, m, r, sk *big.Int) error {
+	if !c.blinding {
+		kInv := new(big.Int).ModInverse(k, order)
+		s.Mul(r, sk)
+		s.Add(m, s).
+			Mul(kInv, s).
+			Mod(s, order) // order != 0
+		return nil
+	}
+
+	beta, err := randFieldElement(rand.Reader)
+	if err != nil {
+		return err
+	}
+	// (β ⋅ k)⁻¹
+	kInv := new(big.Int).Mul(beta, k)
+	kInv.Mod(kInv, order).
+		ModInverse(kInv, order)
+	// β ⋅ m + r ⋅ (β ⋅ sk)
+	bm := new(big.Int).Mul(beta, m)
+	s.Mul(beta, sk).
+		Mod(s, order).
+		Mul(s, r).
+		Add(s, bm).
+		Mul(kInv, s).
+		Mod(s, order)
+	return nil
+}
+
+// randomizedGenerator returns the generator of G1 in Jacobian coordinates
+// (λ²x, λ³y, λ) for a random λ ≠ 0.
+func randomizedGenerator() (bls24315.G1Jac, error) {
+	g, _, _, _ := bls24315.Generators()
+	var lambda, l2, l3 fp.Element
+	for lambda.IsZero() {
+		if _, err := lambda.SetRandom(); err != nil {
+			return g, err
+		}
+	}
+	l2.Square(&lambda)
+	l3.Mul(&l2, &lambda)
+	g.X.Mul(&g.X, &l2)
+	g.Y.Mul(&g.Y, &l3)
+	g.Z.Mul(&g.Z, &lambda)
+	return g, nil
+}
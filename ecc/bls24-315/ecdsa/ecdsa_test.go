@@ -17,10 +17,12 @@
 package ecdsa
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/sha256"
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
 	"math/big"
+	mrand "math/rand"
 	"testing"
 
 	"github.com/leanovate/gopter"
@@ -65,6 +67,105 @@ func TestECDSA(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestDeterministicNonce(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey := privKey.PublicKey
+	msg := []byte("testing ECDSA")
+	hFunc := sha256.New()
+
+	// deterministic signatures are reproducible
+	sig1, err := privKey.SignWithOptions(msg, hFunc, WithNonceMode(NonceDeterministic))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig2, err := privKey.SignWithOptions(msg, hFunc, WithNonceMode(NonceDeterministic))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(sig1, sig2) {
+		t.Fatal("deterministic signatures of the same message should be equal")
+	}
+	if ok, err := publicKey.Verify(sig1, msg, hFunc); err != nil || !ok {
+		t.Fatal("deterministic signature should verify")
+	}
+
+	// hedged signatures depend on the entropy
+	sig3, err := privKey.SignWithOptions(msg, hFunc, WithNonceMode(NonceHedged))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(sig1, sig3) {
+		t.Fatal("hedged and deterministic signatures should differ")
+	}
+	if ok, err := publicKey.Verify(sig3, msg, hFunc); err != nil || !ok {
+		t.Fatal("hedged signature should verify")
+	}
+
+	if _, err := privKey.SignWithOptions(msg, hFunc, WithNonceMode(NonceHedged+1)); err == nil {
+		t.Fatal("unknown nonce mode should fail")
+	}
+	// with a seeded entropy source, random and hedged signatures are reproducible
+	for _, mode := range []NonceMode{NonceRandom, NonceHedged} {
+		sig4, err := privKey.SignWithOptions(msg, hFunc, WithNonceMode(mode), WithNonceEntropy(mrand.New(mrand.NewSource(42))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig5, err := privKey.SignWithOptions(msg, hFunc, WithNonceMode(mode), WithNonceEntropy(mrand.New(mrand.NewSource(42))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(sig4, sig5) {
+			t.Fatalf("mode %d: signatures with the same seed should be equal", mode)
+		}
+		if ok, err := publicKey.Verify(sig4, msg, hFunc); err != nil || !ok {
+			t.Fatalf("mode %d: seeded signature should verify", mode)
+		}
+	}
+}
+
+func TestBlinding(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey := privKey.PublicKey
+	hFunc := sha256.New()
+
+	for i := 0; i < 10; i++ {
+		msg := []byte{byte(i)}
+
+		// blinding doesn't change the signatures
+		for _, mode := range []NonceMode{NonceRandom, NonceDeterministic, NonceHedged} {
+			sig1, err := privKey.SignWithOptions(msg, hFunc, WithNonceMode(mode), WithNonceEntropy(mrand.New(mrand.NewSource(int64(i)))))
+			if err != nil {
+				t.Fatal(err)
+			}
+			sig2, err := privKey.SignWithOptions(msg, hFunc, WithNonceMode(mode), WithNonceEntropy(mrand.New(mrand.NewSource(int64(i)))), WithBlinding())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(sig1, sig2) {
+				t.Fatalf("mode %d: blinded and unblinded signatures should be equal", mode)
+			}
+		}
+
+		sig, err := privKey.SignWithOptions(msg, hFunc, WithBlinding())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok, err := publicKey.Verify(sig, msg, hFunc); err != nil || !ok {
+			t.Fatal("blinded signature should verify")
+		}
+	}
+}
+
 func TestNonMalleability(t *testing.T) {
 
 	// buffer too big
@@ -153,6 +254,17 @@ func BenchmarkSignECDSA(b *testing.B) {
 	}
 }
 
+func BenchmarkSignECDSABlinded(b *testing.B) {
+
+	privKey, _ := GenerateKey(rand.Reader)
+
+	msg := []byte("benchmarking ECDSA sign()")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		privKey.SignWithOptions(msg, nil, WithBlinding())
+	}
+}
+
 func BenchmarkVerifyECDSA(b *testing.B) {
 
 	privKey, _ := GenerateKey(rand.Reader)
@@ -19,13 +19,16 @@ package fp
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
-	"runtime"
+	"math/bits"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"unsafe"
+
+	"github.com/consensys/gnark-crypto/utils/concurrency"
 )
 
 // Vector represents a slice of Element.
@@ -170,6 +173,367 @@ func (vector *Vector) ReadFrom(r io.Reader) (int64, error) {
 	return n, nil
 }
 
+// The compressed encoding of vectors is a sequence of blocks of at most compressedBlockSize
+// elements, terminated by an empty block. Each block starts with its number of elements
+// (uvarint) and, if it is not empty, its encoding (1 byte) and a bit width k (1 byte):
+//   - encodingRaw: the elements, big endian encoded on Bytes bytes each (k = 0);
+//   - encodingPacked: the canonical values of the elements, on k bits each;
+//   - encodingDelta: the differences between consecutive elements, as zig-zag encoded signed
+//     integers on k bits each. The difference of the first element of a block is taken with
+//     the last element of the previous block, or 0 for the first block.
+//
+// Values on k bits are packed least significant bit first.
+const (
+	compressedBlockSize = 1024
+
+	encodingRaw    byte = 0
+	encodingPacked byte = 1
+	encodingDelta  byte = 2
+)
+
+// ErrInvalidCompressedVector is returned when decoding an invalid compressed vector.
+var ErrInvalidCompressedVector = errors.New("invalid compressed vector encoding")
+
+// VectorEncoder writes vectors of Element to a stream with a compressed encoding,
+// efficient for elements that are small (e.g. booleans) or close to their predecessor
+// (e.g. counters or sorted values). Blocks of the vectors that don't compress are written
+// with the big endian encoding of WriteTo, plus a few bytes of header.
+//
+// The vectors written by successive calls to Encode are concatenated; Close terminates the
+// stream, which is read back with a VectorDecoder.
+type VectorEncoder struct {
+	w      io.Writer
+	n      int64
+	prev   Element
+	buf    []byte
+	values []uint64
+	deltas []uint64
+}
+
+// NewVectorEncoder returns an encoder writing to w.
+func NewVectorEncoder(w io.Writer) *VectorEncoder {
+	return &VectorEncoder{
+		w:      w,
+		values: make([]uint64, compressedBlockSize),
+		deltas: make([]uint64, compressedBlockSize),
+	}
+}
+
+// BytesWritten returns the number of bytes written by the encoder.
+func (enc *VectorEncoder) BytesWritten() int64 {
+	return enc.n
+}
+
+// Encode writes the elements of v to the stream.
+func (enc *VectorEncoder) Encode(v Vector) error {
+	for len(v) > 0 {
+		m := min(len(v), compressedBlockSize)
+		if err := enc.encodeBlock(v[:m]); err != nil {
+			return err
+		}
+		v = v[m:]
+	}
+	return nil
+}
+
+// Close terminates the stream. It does not close the underlying writer.
+func (enc *VectorEncoder) Close() error {
+	enc.buf = binary.AppendUvarint(enc.buf[:0], 0)
+	return enc.write(enc.buf)
+}
+
+func (enc *VectorEncoder) write(b []byte) error {
+	m, err := enc.w.Write(b)
+	enc.n += int64(m)
+	return err
+}
+
+func (enc *VectorEncoder) encodeBlock(v Vector) error {
+	// bit widths of the values and of the differences, -1 if some don't fit on 64 bits
+	packedBits, deltaBits := 0, 0
+	values, deltas := enc.values[:len(v)], enc.deltas[:len(v)]
+	var d Element
+	for i := range v {
+		if packedBits >= 0 {
+			if x, ok := canonicalUint64(&v[i]); ok {
+				values[i] = x
+				packedBits = max(packedBits, bits.Len64(x))
+			} else {
+				packedBits = -1
+			}
+		}
+		if deltaBits >= 0 {
+			if i == 0 {
+				d.Sub(&v[0], &enc.prev)
+			} else {
+				d.Sub(&v[i], &v[i-1])
+			}
+			if x, ok := zigZag(&d); ok {
+				deltas[i] = x
+				deltaBits = max(deltaBits, bits.Len64(x))
+			} else {
+				deltaBits = -1
+			}
+		}
+	}
+	enc.prev = v[len(v)-1]
+
+	encoding, k, size := encodingRaw, 0, len(v)*Bytes
+	if packedBits >= 0 && packedSize(len(v), packedBits) < size {
+		encoding, k, size = encodingPacked, packedBits, packedSize(len(v), packedBits)
+	}
+	if deltaBits >= 0 && packedSize(len(v), deltaBits) < size {
+		encoding, k, values = encodingDelta, deltaBits, deltas
+	}
+
+	buf := binary.AppendUvarint(enc.buf[:0], uint64(len(v)))
+	buf = append(buf, encoding, byte(k))
+	if encoding == encodingRaw {
+		var b [Bytes]byte
+		for i := range v {
+			BigEndian.PutElement(&b, v[i])
+			buf = append(buf, b[:]...)
+		}
+	} else {
+		buf = appendPacked(buf, values, k)
+	}
+	enc.buf = buf
+	return enc.write(buf)
+}
+
+// VectorDecoder reads a stream written by a VectorEncoder.
+type VectorDecoder struct {
+	r      countingReader
+	prev   Element
+	done   bool
+	buf    []byte
+	values []uint64
+}
+
+// NewVectorDecoder returns a decoder reading from r. It doesn't read past the end of the
+// stream.
+func NewVectorDecoder(r io.Reader) *VectorDecoder {
+	return &VectorDecoder{r: countingReader{r: r}}
+}
+
+// BytesRead returns the number of bytes read by the decoder.
+func (dec *VectorDecoder) BytesRead() int64 {
+	return dec.r.n
+}
+
+// Next returns the next block of elements of the stream, of at most 1024 elements,
+// or io.EOF at the end of the stream.
+func (dec *VectorDecoder) Next() (Vector, error) {
+	if dec.done {
+		return nil, io.EOF
+	}
+	n, err := binary.ReadUvarint(&dec.r)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if n == 0 {
+		dec.done = true
+		return nil, io.EOF
+	}
+	if n > compressedBlockSize {
+		return nil, ErrInvalidCompressedVector
+	}
+	var header [2]byte
+	if _, err := io.ReadFull(&dec.r, header[:]); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	encoding, k := header[0], int(header[1])
+
+	res := make(Vector, n)
+	switch encoding {
+	case encodingRaw:
+		if k != 0 {
+			return nil, ErrInvalidCompressedVector
+		}
+		var b [Bytes]byte
+		for i := range res {
+			if _, err := io.ReadFull(&dec.r, b[:]); err != nil {
+				return nil, unexpectedEOF(err)
+			}
+			if res[i], err = BigEndian.Element(&b); err != nil {
+				return nil, err
+			}
+		}
+	case encodingPacked, encodingDelta:
+		if k > 64 {
+			return nil, ErrInvalidCompressedVector
+		}
+		dec.buf = append(dec.buf[:0], make([]byte, packedSize(len(res), k))...)
+		if _, err := io.ReadFull(&dec.r, dec.buf); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		if cap(dec.values) < len(res) {
+			dec.values = make([]uint64, compressedBlockSize)
+		}
+		values := dec.values[:len(res)]
+		unpack(values, dec.buf, k)
+		for i := range res {
+			if encoding == encodingPacked {
+				if !setCanonicalUint64(&res[i], values[i]) {
+					return nil, ErrInvalidCompressedVector
+				}
+				continue
+			}
+			var d Element
+			if !unZigZag(&d, values[i]) {
+				return nil, ErrInvalidCompressedVector
+			}
+			if i == 0 {
+				res[0].Add(&dec.prev, &d)
+			} else {
+				res[i].Add(&res[i-1], &d)
+			}
+		}
+	default:
+		return nil, ErrInvalidCompressedVector
+	}
+	dec.prev = res[len(res)-1]
+	return res, nil
+}
+
+// WriteCompressedTo writes the vector with the compressed encoding of VectorEncoder.
+func (vector *Vector) WriteCompressedTo(w io.Writer) (int64, error) {
+	enc := NewVectorEncoder(w)
+	if err := enc.Encode(*vector); err != nil {
+		return enc.BytesWritten(), err
+	}
+	err := enc.Close()
+	return enc.BytesWritten(), err
+}
+
+// ReadCompressedFrom reads a vector written by WriteCompressedTo, or all the vectors written
+// to a VectorEncoder.
+func (vector *Vector) ReadCompressedFrom(r io.Reader) (int64, error) {
+	dec := NewVectorDecoder(r)
+	res := make(Vector, 0)
+	for {
+		block, err := dec.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return dec.BytesRead(), err
+		}
+		res = append(res, block...)
+	}
+	*vector = res
+	return dec.BytesRead(), nil
+}
+
+// canonicalUint64 returns the canonical value of z, if it fits on 64 bits.
+func canonicalUint64(z *Element) (uint64, bool) {
+	zz := *z
+	zz.fromMont()
+	return zz[0], zz.FitsOnOneWord()
+}
+
+// setCanonicalUint64 sets z to x, and returns false if x is not smaller than the modulus.
+func setCanonicalUint64(z *Element, x uint64) bool {
+	z.SetUint64(x)
+	return true
+}
+
+// zigZag returns the zig-zag encoding of the signed integer of smallest absolute value
+// congruent to d, if it fits on 64 bits.
+func zigZag(d *Element) (uint64, bool) {
+	var negD Element
+	negD.Neg(d)
+	x, xOk := canonicalUint64(d)
+	y, yOk := canonicalUint64(&negD)
+	if xOk && x < 1<<63 && (!yOk || x <= y) {
+		return x << 1, true
+	}
+	if yOk && y <= 1<<63 {
+		// y != 0
+		return (y-1)<<1 | 1, true
+	}
+	return 0, false
+}
+
+// unZigZag sets z to the signed integer of zig-zag encoding x, and returns false if its absolute
+// value is not smaller than the modulus.
+func unZigZag(z *Element, x uint64) bool {
+	if x&1 == 0 {
+		return setCanonicalUint64(z, x>>1)
+	}
+	if !setCanonicalUint64(z, x>>1+1) {
+		return false
+	}
+	z.Neg(z)
+	return true
+}
+
+// packedSize returns the number of bytes of n values packed on k bits.
+func packedSize(n, k int) int {
+	return (n*k + 7) / 8
+}
+
+// appendPacked appends the values, on k bits each, least significant bit first.
+func appendPacked(buf []byte, values []uint64, k int) []byte {
+	start := len(buf)
+	buf = append(buf, make([]byte, packedSize(len(values), k))...)
+	out := buf[start:]
+	bitPos := 0
+	for _, x := range values {
+		for remaining := k; remaining > 0; {
+			i, offset := bitPos/8, bitPos%8
+			nb := min(8-offset, remaining)
+			out[i] |= byte(x&(1<<nb-1)) << offset
+			x >>= nb
+			remaining -= nb
+			bitPos += nb
+		}
+	}
+	return buf
+}
+
+// unpack reads len(values) values packed on k bits by appendPacked.
+func unpack(values []uint64, in []byte, k int) {
+	bitPos := 0
+	for j := range values {
+		var x uint64
+		for read := 0; read < k; {
+			i, offset := bitPos/8, bitPos%8
+			nb := min(8-offset, k-read)
+			x |= uint64((in[i]>>offset)&(1<<nb-1)) << read
+			read += nb
+			bitPos += nb
+		}
+		values[j] = x
+	}
+}
+
+// countingReader counts the bytes read from r, and reads bytes one by one for
+// binary.ReadUvarint, to not read past the end of the stream.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	m, err := cr.r.Read(p)
+	cr.n += int64(m)
+	return m, err
+}
+
+func (cr *countingReader) ReadByte() (byte, error) {
+	var b [1]byte
+	_, err := io.ReadFull(cr, b[:])
+	return b[0], err
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
 // String implements fmt.Stringer interface
 func (vector Vector) String() string {
 	var sbb strings.Builder
@@ -200,22 +564,198 @@ func (vector Vector) Swap(i, j int) {
 	vector[i], vector[j] = vector[j], vector[i]
 }
 
+// VectorOption defines option for altering the behavior of the vector operations.
+// See the descriptions of functions returning instances of this type for
+// particular options.
+type VectorOption func(vectorConfig) vectorConfig
+
+type vectorConfig struct {
+	nbTasks int
+}
+
+// WithNbTasks sets the max number of task (go routine) to spawn. Must be between 1 and 512.
+// By default, vector operations run on the calling go routine.
+func WithNbTasks(nbTasks int) VectorOption {
+	if nbTasks < 1 {
+		nbTasks = 1
+	} else if nbTasks > 512 {
+		nbTasks = 512
+	}
+	return func(opt vectorConfig) vectorConfig {
+		opt.nbTasks = nbTasks
+		return opt
+	}
+}
+
+// default options
+func vectorOptions(opts ...VectorOption) vectorConfig {
+	// apply options
+	opt := vectorConfig{
+		nbTasks: 1,
+	}
+	for _, option := range opts {
+		opt = option(opt)
+	}
+	return opt
+}
+
 // Add adds two vectors element-wise and stores the result in self.
 // It panics if the vectors don't have the same length.
-func (vector *Vector) Add(a, b Vector) {
-	addVecGeneric(*vector, a, b)
+func (vector *Vector) Add(a, b Vector, opts ...VectorOption) {
+	if len(a) != len(b) || len(a) != len(*vector) {
+		panic("vector.Add: vectors don't have the same length")
+	}
+	res := *vector
+	execute(len(a), func(start, end int) {
+		addVecChunk(res[start:end], a[start:end], b[start:end])
+	}, vectorOptions(opts...).nbTasks)
 }
 
 // Sub subtracts two vectors element-wise and stores the result in self.
 // It panics if the vectors don't have the same length.
-func (vector *Vector) Sub(a, b Vector) {
-	subVecGeneric(*vector, a, b)
+func (vector *Vector) Sub(a, b Vector, opts ...VectorOption) {
+	if len(a) != len(b) || len(a) != len(*vector) {
+		panic("vector.Sub: vectors don't have the same length")
+	}
+	res := *vector
+	execute(len(a), func(start, end int) {
+		subVecChunk(res[start:end], a[start:end], b[start:end])
+	}, vectorOptions(opts...).nbTasks)
 }
 
 // ScalarMul multiplies a vector by a scalar element-wise and stores the result in self.
 // It panics if the vectors don't have the same length.
-func (vector *Vector) ScalarMul(a Vector, b *Element) {
-	scalarMulVecGeneric(*vector, a, b)
+func (vector *Vector) ScalarMul(a Vector, b *Element, opts ...VectorOption) {
+	if len(a) != len(*vector) {
+		panic("vector.ScalarMul: vectors don't have the same length")
+	}
+	res := *vector
+	execute(len(a), func(start, end int) {
+		scalarMulVecChunk(res[start:end], a[start:end], b)
+	}, vectorOptions(opts...).nbTasks)
+}
+
+// MulAccumulate sets vector[i] = vector[i] + a[i] * b for all i, in a single pass over the vectors.
+// It panics if the vectors don't have the same length.
+func (vector *Vector) MulAccumulate(a Vector, b *Element, opts ...VectorOption) {
+	if len(a) != len(*vector) {
+		panic("vector.MulAccumulate: vectors don't have the same length")
+	}
+	res := *vector
+	execute(len(a), func(start, end int) {
+		mulAccVecChunk(res[start:end], a[start:end], b)
+	}, vectorOptions(opts...).nbTasks)
+}
+
+// Mul multiplies two vectors element-wise and stores the result in self.
+// It panics if the vectors don't have the same length.
+func (vector *Vector) Mul(a, b Vector, opts ...VectorOption) {
+	if len(a) != len(b) || len(a) != len(*vector) {
+		panic("vector.Mul: vectors don't have the same length")
+	}
+	res := *vector
+	execute(len(a), func(start, end int) {
+		mulVecChunk(res[start:end], a[start:end], b[start:end])
+	}, vectorOptions(opts...).nbTasks)
+}
+
+// Sum computes the sum of all elements in the vector.
+func (vector Vector) Sum(opts ...VectorOption) (res Element) {
+	partials := make([]Element, vectorOptions(opts...).nbTasks)
+	var chunk uint64
+	execute(len(vector), func(start, end int) {
+		i := atomic.AddUint64(&chunk, 1) - 1
+		sumVecChunk(&partials[i], vector[start:end])
+	}, len(partials))
+	for i := range partials {
+		res.Add(&res, &partials[i])
+	}
+	return
+}
+
+// InnerProduct computes the inner product of vector and other.
+// It panics if the vectors don't have the same length.
+func (vector Vector) InnerProduct(other Vector, opts ...VectorOption) (res Element) {
+	if len(vector) != len(other) {
+		panic("vector.InnerProduct: vectors don't have the same length")
+	}
+	partials := make([]Element, vectorOptions(opts...).nbTasks)
+	var chunk uint64
+	execute(len(vector), func(start, end int) {
+		i := atomic.AddUint64(&chunk, 1) - 1
+		innerProductVecChunk(&partials[i], vector[start:end], other[start:end])
+	}, len(partials))
+	for i := range partials {
+		res.Add(&res, &partials[i])
+	}
+	return
+}
+
+// ToMont converts, in place, each element of the vector from regular to Montgomery form.
+// It is a scalar multiplication by R², the Montgomery form of R.
+func (vector Vector) ToMont(opts ...VectorOption) {
+	execute(len(vector), func(start, end int) {
+		scalarMulVecChunk(vector[start:end], vector[start:end], &rSquare)
+	}, vectorOptions(opts...).nbTasks)
+}
+
+// FromMont converts, in place, each element of the vector from Montgomery to regular form.
+func (vector Vector) FromMont(opts ...VectorOption) {
+	execute(len(vector), func(start, end int) {
+		fromMontVecChunk(vector[start:end])
+	}, vectorOptions(opts...).nbTasks)
+}
+
+// BatchInvert sets vector[i] = a[i]⁻¹ for all i (0 if a[i] == 0), using the Montgomery batch inversion trick.
+// With WithNbTasks, a is split in chunks inverted in parallel, at the cost of one field inversion per chunk.
+// It panics if the vectors don't have the same length.
+func (vector *Vector) BatchInvert(a Vector, opts ...VectorOption) {
+	if len(a) != len(*vector) {
+		panic("vector.BatchInvert: vectors don't have the same length")
+	}
+	if len(a) == 0 {
+		return
+	}
+	res := *vector
+	if &res[0] == &a[0] {
+		// in place inversion
+		a = append(Vector(nil), a...)
+	}
+	execute(len(a), func(start, end int) {
+		batchInvert(res[start:end], a[start:end])
+	}, vectorOptions(opts...).nbTasks)
+}
+
+func addVecChunk(res, a, b Vector) {
+	addVecGeneric(res, a, b)
+}
+
+func subVecChunk(res, a, b Vector) {
+	subVecGeneric(res, a, b)
+}
+
+func scalarMulVecChunk(res, a Vector, b *Element) {
+	scalarMulVecGeneric(res, a, b)
+}
+
+func mulAccVecChunk(res, a Vector, b *Element) {
+	mulAccVecGeneric(res, a, b)
+}
+
+func mulVecChunk(res, a, b Vector) {
+	mulVecGeneric(res, a, b)
+}
+
+func sumVecChunk(res *Element, a Vector) {
+	sumVecGeneric(res, a)
+}
+
+func innerProductVecChunk(res *Element, a, b Vector) {
+	innerProductVecGeneric(res, a, b)
+}
+
+func fromMontVecChunk(a Vector) {
+	fromMontVecGeneric(a)
 }
 
 func addVecGeneric(res, a, b Vector) {
@@ -245,13 +785,55 @@ func scalarMulVecGeneric(res, a Vector, b *Element) {
 	}
 }
 
+func mulAccVecGeneric(res, a Vector, b *Element) {
+	if len(a) != len(res) {
+		panic("vector.MulAccumulate: vectors don't have the same length")
+	}
+	var tmp Element
+	for i := 0; i < len(a); i++ {
+		tmp.Mul(&a[i], b)
+		res[i].Add(&res[i], &tmp)
+	}
+}
+
+func mulVecGeneric(res, a, b Vector) {
+	if len(a) != len(b) || len(a) != len(res) {
+		panic("vector.Mul: vectors don't have the same length")
+	}
+	for i := 0; i < len(a); i++ {
+		res[i].Mul(&a[i], &b[i])
+	}
+}
+
+func sumVecGeneric(res *Element, a Vector) {
+	res.SetZero()
+	for i := 0; i < len(a); i++ {
+		res.Add(res, &a[i])
+	}
+}
+
+func innerProductVecGeneric(res *Element, a, b Vector) {
+	res.SetZero()
+	var tmp Element
+	for i := 0; i < len(a); i++ {
+		tmp.Mul(&a[i], &b[i])
+		res.Add(res, &tmp)
+	}
+}
+
+func fromMontVecGeneric(a Vector) {
+	for i := 0; i < len(a); i++ {
+		a[i].fromMont()
+	}
+}
+
 // TODO @gbotrel make a public package out of that.
 // execute executes the work function in parallel.
 // this is copy paste from internal/parallel/parallel.go
 // as we don't want to generate code importing internal/
 func execute(nbIterations int, work func(int, int), maxCpus ...int) {
 
-	nbTasks := runtime.NumCPU()
+	nbTasks := concurrency.MaxTasks()
 	if len(maxCpus) == 1 {
 		nbTasks = maxCpus[0]
 		if nbTasks < 1 {
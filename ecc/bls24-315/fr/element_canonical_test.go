@@ -0,0 +1,179 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fr
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	ggen "github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestCanonicalElement(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+	genB := gen()
+
+	properties.Property("Canonical: conversion round trip", prop.ForAll(
+		func(p testPairElement) bool {
+			a := p.element
+			var c CanonicalElement
+			c.SetElement(&a)
+			e := c.Element()
+			return e.Equal(&a) && c == CanonicalElement(a.Bits())
+		},
+		genA,
+	))
+
+	properties.Property("Canonical: arithmetic must match Element", prop.ForAll(
+		func(pa, pb testPairElement) bool {
+			a, b := pa.element, pb.element
+			var ca, cb, c CanonicalElement
+			ca.SetElement(&a)
+			cb.SetElement(&b)
+
+			var e Element
+			ok := true
+			check := func() {
+				r := c.Element()
+				ok = ok && r.Equal(&e)
+			}
+
+			c.Mul(&ca, &cb)
+			e.Mul(&a, &b)
+			check()
+			c.Square(&ca)
+			e.Square(&a)
+			check()
+			c.Add(&ca, &cb)
+			e.Add(&a, &b)
+			check()
+			c.Sub(&ca, &cb)
+			e.Sub(&a, &b)
+			check()
+			c.Double(&ca)
+			e.Double(&a)
+			check()
+			c.Neg(&ca)
+			e.Neg(&a)
+			check()
+			c.Inverse(&ca)
+			e.Inverse(&a)
+			check()
+			return ok
+		},
+		genA, genB,
+	))
+
+	properties.Property("Canonical: bytes must match Element", prop.ForAll(
+		func(p testPairElement) bool {
+			a := p.element
+			var c, d CanonicalElement
+			c.SetElement(&a)
+			if c.Bytes() != a.Bytes() {
+				return false
+			}
+			if err := d.SetBytesCanonical(c.Marshal()); err != nil || d != c {
+				return false
+			}
+			var b big.Int
+			return c.BigInt(&b).Cmp(a.BigInt(new(big.Int))) == 0 && c.String() == a.String()
+		},
+		genA,
+	))
+
+	properties.Property("Canonical: SetBytes must reduce like Element", prop.ForAll(
+		func(buf []byte) bool {
+			var c CanonicalElement
+			var e Element
+			c.SetBytes(buf)
+			e.SetBytes(buf)
+			r := c.Element()
+			return r.Equal(&e)
+		},
+		ggen.SliceOfN(2*Bytes, ggen.UInt8()),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestCanonicalElementEdgeCases(t *testing.T) {
+	var qMinusOne, c CanonicalElement
+	m := Modulus()
+	m.Sub(m, big.NewInt(1))
+	qMinusOne.SetBigInt(m)
+
+	// (q-1)² = 1, the largest product Barrett has to reduce
+	c.Mul(&qMinusOne, &qMinusOne)
+	var one CanonicalElement
+	one.SetOne()
+	if !c.Equal(&one) {
+		t.Fatal("(q-1)² != 1")
+	}
+
+	q := Modulus()
+	b := make([]byte, Bytes)
+	q.FillBytes(b)
+	if err := c.SetBytesCanonical(b); err == nil {
+		t.Fatal("SetBytesCanonical accepted q")
+	}
+	if c.SetBytes(b); !c.IsZero() {
+		t.Fatal("SetBytes(q) != 0")
+	}
+	if c.SetUint64(42); c.String() != "42" {
+		t.Fatal("SetUint64 failed")
+	}
+}
+
+func BenchmarkCanonicalElement(b *testing.B) {
+	var x Element
+	x.SetRandom()
+	var c CanonicalElement
+	c.SetElement(&x)
+	buf := x.Bytes()
+
+	b.Run("Mul", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			c.Mul(&c, &c)
+		}
+	})
+
+	b.Run("SetBytes+Bytes", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			c.SetBytes(buf[:])
+			buf = c.Bytes()
+		}
+	})
+
+	b.Run("Element/SetBytes+Bytes", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x.SetBytes(buf[:])
+			buf = x.Bytes()
+		}
+	})
+}
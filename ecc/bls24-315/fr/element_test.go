@@ -74,6 +74,18 @@ func BenchmarkElementSetBytes(b *testing.B) {
 
 }
 
+func BenchmarkElementSetBytesWide(b *testing.B) {
+	var bb [64]byte
+	if _, err := rand.Read(bb[:]); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		benchResElement.SetBytesWide(bb)
+	}
+}
+
 func BenchmarkElementMulByConstants(b *testing.B) {
 	b.Run("mulBy3", func(b *testing.B) {
 		benchResElement.SetRandom()
@@ -217,6 +229,19 @@ func BenchmarkElementMul(b *testing.B) {
 	}
 }
 
+func BenchmarkElementLincomb(b *testing.B) {
+	var a, c, x, y Element
+	a.SetRandom()
+	c.SetRandom()
+	x.SetRandom()
+	y.SetRandom()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchResElement.Lincomb(&a, &c, &x, &y)
+		x[0] ^= benchResElement[0] & 1
+	}
+}
+
 func BenchmarkElementCmp(b *testing.B) {
 	x := Element{
 		6242551132904523857,
@@ -467,6 +492,56 @@ func TestElementBytes(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestElementSetBytesWide(t *testing.T) {
+	t.Parallel()
+	check := func(e []byte) {
+		t.Helper()
+		expected := new(big.Int).SetBytes(e)
+		expected.Mod(expected, Modulus())
+
+		var z Element
+		z.SetBytes(e)
+		if z.BigInt(new(big.Int)).Cmp(expected) != 0 {
+			t.Fatalf("SetBytes(%x) = %s, expected %s", e, z.String(), expected.String())
+		}
+		if len(e) == 64 {
+			z.SetBytesWide([64]byte(e))
+			if z.BigInt(new(big.Int)).Cmp(expected) != 0 {
+				t.Fatalf("SetBytesWide(%x) = %s, expected %s", e, z.String(), expected.String())
+			}
+		}
+	}
+
+	// edge cases: 0, q-1, q, 2⁵¹²-1
+	check(nil)
+	check(make([]byte, 64))
+	var qMinusOne big.Int
+	qMinusOne.Sub(Modulus(), big.NewInt(1))
+	check(qMinusOne.Bytes())
+	check(Modulus().Bytes())
+	all := make([]byte, 64)
+	for i := range all {
+		all[i] = 0xff
+	}
+	check(all)
+
+	// random inputs of all lengths up to 3·Bytes
+	for l := 1; l <= 3*Bytes; l++ {
+		e := make([]byte, l)
+		if _, err := rand.Read(e); err != nil {
+			t.Fatal(err)
+		}
+		check(e)
+	}
+	for i := 0; i < 100; i++ {
+		var e [64]byte
+		if _, err := rand.Read(e[:]); err != nil {
+			t.Fatal(err)
+		}
+		check(e[:])
+	}
+}
+
 func TestElementInverseExp(t *testing.T) {
 	// inverse must be equal to exp^-2
 	exp := Modulus()
@@ -742,6 +817,102 @@ func TestElementVecOps(t *testing.T) {
 		expected.Mul(&a[i], &b[0])
 		assert.True(c[i].Equal(&expected), "Vector scaling failed")
 	}
+
+	// Vector multiply-accumulate
+	copy(c, b)
+	c.MulAccumulate(a, &b[0])
+	for i := 0; i < N; i++ {
+		var expected Element
+		expected.Mul(&a[i], &b[0]).Add(&expected, &b[i])
+		assert.True(c[i].Equal(&expected), "Vector multiply-accumulate failed")
+	}
+
+	// Vector multiplication
+	c.Mul(a, b)
+	for i := 0; i < N; i++ {
+		var expected Element
+		expected.Mul(&a[i], &b[i])
+		assert.True(c[i].Equal(&expected), "Vector multiplication failed")
+	}
+
+	// Vector reductions
+	var sum, innerProduct, tmp Element
+	for i := 0; i < N; i++ {
+		sum.Add(&sum, &a[i])
+		tmp.Mul(&a[i], &b[i])
+		innerProduct.Add(&innerProduct, &tmp)
+	}
+	computed := a.Sum()
+	assert.True(computed.Equal(&sum), "Vector sum failed")
+	computed = a.InnerProduct(b)
+	assert.True(computed.Equal(&innerProduct), "Vector inner product failed")
+	computed = Vector{}.Sum()
+	assert.True(computed.IsZero(), "Vector sum of empty vector failed")
+
+	// sums of large values, over odd and even lengths
+	var minusOne Element
+	minusOne.SetOne()
+	minusOne.Neg(&minusOne)
+	for _, n := range []int{1, 2, 3, 17} {
+		v := make(Vector, n)
+		var expected Element
+		for i := range v {
+			v[i] = minusOne
+			expected.Add(&expected, &minusOne)
+		}
+		computed = v.Sum()
+		assert.True(computed.Equal(&expected), "Vector sum of %d large values failed", n)
+	}
+
+	// Montgomery conversion
+	copy(c, a)
+	c.FromMont()
+	for i := 0; i < N; i++ {
+		expected := a[i].Bits()
+		assert.Equal(expected[:], c[i][:], "Vector FromMont failed")
+	}
+	c.ToMont()
+	for i := 0; i < N; i++ {
+		assert.True(c[i].Equal(&a[i]), "Vector ToMont failed")
+	}
+
+	// parallel operations
+	for _, nbTasks := range []int{2, 3, N + 1} {
+		d := make(Vector, N)
+		opt := WithNbTasks(nbTasks)
+
+		d.Add(a, b, opt)
+		c.Add(a, b)
+		assert.Equal(c, d, "Vector addition failed with %d tasks", nbTasks)
+
+		d.Sub(a, b, opt)
+		c.Sub(a, b)
+		assert.Equal(c, d, "Vector subtraction failed with %d tasks", nbTasks)
+
+		d.ScalarMul(a, &b[0], opt)
+		c.ScalarMul(a, &b[0])
+		assert.Equal(c, d, "Vector scaling failed with %d tasks", nbTasks)
+
+		copy(d, b)
+		copy(c, b)
+		d.MulAccumulate(a, &b[0], opt)
+		c.MulAccumulate(a, &b[0])
+		assert.Equal(c, d, "Vector multiply-accumulate failed with %d tasks", nbTasks)
+
+		d.Mul(a, b, opt)
+		c.Mul(a, b)
+		assert.Equal(c, d, "Vector multiplication failed with %d tasks", nbTasks)
+
+		computed = a.Sum(opt)
+		assert.True(computed.Equal(&sum), "Vector sum failed with %d tasks", nbTasks)
+		computed = a.InnerProduct(b, opt)
+		assert.True(computed.Equal(&innerProduct), "Vector inner product failed with %d tasks", nbTasks)
+
+		copy(d, a)
+		d.FromMont(opt)
+		d.ToMont(opt)
+		assert.Equal(a, d, "Vector Montgomery conversion failed with %d tasks", nbTasks)
+	}
 }
 
 func BenchmarkElementVecOps(b *testing.B) {
@@ -776,6 +947,48 @@ func BenchmarkElementVecOps(b *testing.B) {
 			c1.ScalarMul(a1, &b1[0])
 		}
 	})
+
+	b.Run("MulAccumulate", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			c1.MulAccumulate(a1, &b1[0])
+		}
+	})
+
+	b.Run("Mul", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			c1.Mul(a1, b1)
+		}
+	})
+
+	b.Run("Sum", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = a1.Sum()
+		}
+	})
+
+	b.Run("InnerProduct", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = a1.InnerProduct(b1)
+		}
+	})
+
+	b.Run("ToMont", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			c1.ToMont()
+		}
+	})
+
+	b.Run("FromMont", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			c1.FromMont()
+		}
+	})
 }
 
 func TestElementAdd(t *testing.T) {
@@ -1806,6 +2019,136 @@ func TestElementHalve(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestElementMul32(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+	genB := gen()
+
+	properties.Property("mul32: must match Mul", prop.ForAll(
+		func(a, b testPairElement) bool {
+			var c, d Element
+			mul32(&c, &a.element, &b.element)
+			d.Mul(&a.element, &b.element)
+			return c.Equal(&d)
+		},
+		genA, genB,
+	))
+
+	properties.Property("mul32: must reduce the largest operands", prop.ForAll(
+		func(a testPairElement) bool {
+			var qMinusOne, c, d Element
+			qMinusOne.SetOne().Neg(&qMinusOne)
+			mul32(&c, &qMinusOne, &qMinusOne)
+			d.Mul(&qMinusOne, &qMinusOne)
+			return c.Equal(&d) && c.smallerThanModulus()
+		},
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestElementFusedOps(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+	genB := gen()
+	genC := gen()
+	genD := gen()
+
+	properties.Property("MulAdd: must match Mul then Add", prop.ForAll(
+		func(a, b, c testPairElement) bool {
+			var z, expected Element
+			z.MulAdd(&a.element, &b.element, &c.element)
+			expected.Mul(&a.element, &b.element).Add(&expected, &c.element)
+			return z.Equal(&expected)
+		},
+		genA, genB, genC,
+	))
+
+	properties.Property("AddMul: must match Add then Mul", prop.ForAll(
+		func(a, b, c testPairElement) bool {
+			var z, expected Element
+			z.AddMul(&a.element, &b.element, &c.element)
+			expected.Add(&a.element, &b.element).Mul(&expected, &c.element)
+			return z.Equal(&expected)
+		},
+		genA, genB, genC,
+	))
+
+	properties.Property("Lincomb: must match a*x + b*y", prop.ForAll(
+		func(a, b, x, y testPairElement) bool {
+			var z, expected, t Element
+			z.Lincomb(&a.element, &b.element, &x.element, &y.element)
+			expected.Mul(&a.element, &x.element)
+			t.Mul(&b.element, &y.element)
+			expected.Add(&expected, &t)
+			return z.Equal(&expected)
+		},
+		genA, genB, genC, genD,
+	))
+
+	properties.Property("fused operations: must support aliasing with the result", prop.ForAll(
+		func(a, b, c testPairElement) bool {
+			var expected Element
+			expected.MulAdd(&a.element, &b.element, &c.element)
+			z := a.element
+			z.MulAdd(&z, &b.element, &c.element)
+			if !z.Equal(&expected) {
+				return false
+			}
+			expected.Lincomb(&a.element, &b.element, &c.element, &a.element)
+			z = c.element
+			z.Lincomb(&a.element, &b.element, &z, &a.element)
+			return z.Equal(&expected)
+		},
+		genA, genB, genC,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// the single reduction must handle the largest unreduced sums
+	for _, a := range staticTestValues {
+		for _, b := range staticTestValues {
+			var z, expected, t0 Element
+			z.Lincomb(&a, &b, &a, &b)
+			expected.Mul(&a, &a)
+			t0.Mul(&b, &b)
+			expected.Add(&expected, &t0)
+			if !z.Equal(&expected) {
+				t.Fatal("Lincomb failed special test values")
+			}
+			z.AddMul(&a, &b, &b)
+			expected.Add(&a, &b).Mul(&expected, &b)
+			if !z.Equal(&expected) {
+				t.Fatal("AddMul failed special test values")
+			}
+			z.MulAdd(&a, &a, &b)
+			expected.Mul(&a, &a).Add(&expected, &b)
+			if !z.Equal(&expected) {
+				t.Fatal("MulAdd failed special test values")
+			}
+		}
+	}
+}
+
 func combineSelectionArguments(c int64, z int8) int {
 	if z%3 == 0 {
 		return 0
@@ -2219,6 +2562,62 @@ func TestElementBatchInvert(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestElementBatchInvertParallel(t *testing.T) {
+	assert := require.New(t)
+
+	t.Parallel()
+
+	const N = 37
+	a := make(Vector, N)
+	for i := 0; i < N; i++ {
+		if i%5 != 0 {
+			a[i].SetRandom()
+		}
+	}
+	expected := BatchInvert(a)
+
+	for _, nbTasks := range []int{1, 2, 7, N, N + 1} {
+		aInv := BatchInvertParallel(a, nbTasks)
+		assert.Equal(Vector(expected), Vector(aInv), "BatchInvertParallel failed with %d tasks", nbTasks)
+
+		v := make(Vector, N)
+		v.BatchInvert(a, WithNbTasks(nbTasks))
+		assert.Equal(Vector(expected), v, "Vector.BatchInvert failed with %d tasks", nbTasks)
+
+		// in place
+		copy(v, a)
+		v.BatchInvert(v, WithNbTasks(nbTasks))
+		assert.Equal(Vector(expected), v, "in place Vector.BatchInvert failed with %d tasks", nbTasks)
+	}
+
+	assert.Equal(Vector(expected), Vector(BatchInvertParallel(a)))
+	assert.Len(BatchInvertParallel(nil), 0)
+	var empty Vector
+	empty.BatchInvert(nil)
+}
+
+func BenchmarkElementBatchInvert(b *testing.B) {
+	const N = 1 << 16
+	a := make([]Element, N)
+	for i := 0; i < N; i++ {
+		a[i].SetRandom()
+	}
+
+	b.Run("sequential", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = BatchInvert(a)
+		}
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = BatchInvertParallel(a)
+		}
+	})
+}
+
 func TestElementFromMont(t *testing.T) {
 
 	t.Parallel()
@@ -2958,3 +3357,54 @@ func approximateRef(x *Element) uint64 {
 	hi.Add(&hi, &lo)
 	return hi.Uint64()
 }
+
+func TestElementNewFixedExp(t *testing.T) {
+	assert := require.New(t)
+
+	qMinusOne := Modulus()
+	qMinusOne.Sub(qMinusOne, big.NewInt(1))
+	exponents := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(2),
+		big.NewInt(5),
+		big.NewInt(-7),
+		big.NewInt(65537),
+		new(big.Int).Lsh(big.NewInt(1), 200),
+		qMinusOne,
+		Modulus(),
+		new(big.Int).Mul(Modulus(), big.NewInt(3)),
+	}
+	for i := 0; i < 5; i++ {
+		e, err := rand.Int(rand.Reader, Modulus())
+		assert.NoError(err)
+		exponents = append(exponents, e, new(big.Int).Neg(e))
+	}
+
+	var x, zero, expected, got Element
+	for _, e := range exponents {
+		f := NewFixedExp(e)
+		for i := 0; i < 10; i++ {
+			x.SetRandom()
+			expected.Exp(x, e)
+			f.Exp(&got, x)
+			assert.True(expected.Equal(&got), "x^%s", e.String())
+		}
+		if e.Sign() > 0 {
+			f.Exp(&got, zero)
+			assert.True(got.IsZero(), "0^%s", e.String())
+		}
+	}
+}
+
+func BenchmarkElementNewFixedExp(b *testing.B) {
+	e := Modulus()
+	e.Sub(e, big.NewInt(2))
+	f := NewFixedExp(e)
+	var x Element
+	x.SetRandom()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.Exp(&benchResElement, x)
+	}
+}
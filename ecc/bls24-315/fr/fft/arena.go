@@ -0,0 +1,90 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+)
+
+// Arena is a bump allocator of field elements. The buffers it returns stay valid
+// until the next call to Reset, after which their memory is reused: a prover running
+// in a loop can allocate its scratch buffers from an Arena and Reset it between two
+// proofs, instead of leaving them to the garbage collector.
+//
+// An Arena is not safe for concurrent use. A nil *Arena is valid and allocates on
+// the heap.
+type Arena struct {
+	chunks [][]fr.Element // the last chunk is the one being filled
+	offset int            // number of used elements in the last chunk
+}
+
+// NewArena returns an Arena with an initial capacity of capacity field elements.
+func NewArena(capacity int) *Arena {
+	a := &Arena{}
+	if capacity > 0 {
+		a.chunks = [][]fr.Element{make([]fr.Element, capacity)}
+	}
+	return a
+}
+
+// Alloc returns a zeroed slice of n field elements.
+func (a *Arena) Alloc(n int) []fr.Element {
+	if a == nil {
+		return make([]fr.Element, n)
+	}
+	if len(a.chunks) == 0 || a.offset+n > len(a.chunks[len(a.chunks)-1]) {
+		// the new chunk is at least twice as large as the previous one, so that
+		// the number of chunks stays logarithmic in the total size.
+		size := n
+		if len(a.chunks) != 0 {
+			size = max(size, 2*len(a.chunks[len(a.chunks)-1]))
+		}
+		a.chunks = append(a.chunks, make([]fr.Element, size))
+		a.offset = 0
+	}
+	last := a.chunks[len(a.chunks)-1]
+	res := last[a.offset : a.offset+n : a.offset+n]
+	a.offset += n
+	clear(res)
+	return res
+}
+
+// Cap returns the number of field elements the Arena can hand out without allocating.
+func (a *Arena) Cap() int {
+	if a == nil {
+		return 0
+	}
+	res := 0
+	for _, c := range a.chunks {
+		res += len(c)
+	}
+	return res
+}
+
+// Reset makes all the memory of the Arena available again. The slices previously
+// returned by Alloc must not be used anymore.
+func (a *Arena) Reset() {
+	if a == nil {
+		return
+	}
+	if len(a.chunks) > 1 {
+		// merge the chunks, so that the next use with the same pattern fits in
+		// one chunk and doesn't allocate.
+		a.chunks = [][]fr.Element{make([]fr.Element, a.Cap())}
+	}
+	a.offset = 0
+}
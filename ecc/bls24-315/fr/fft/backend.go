@@ -0,0 +1,125 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+)
+
+// Backend is an alternative implementation of the transforms of a [Domain],
+// typically offloading them to a GPU. When a backend is registered with
+// [RegisterBackend], [Domain.FFT] and [Domain.FFTInverse] delegate to it
+// before falling back to the CPU implementation.
+//
+// The methods must have exactly the semantics of their [Domain] counterparts
+// (same decimation conventions, same coset shift domain.FrMultiplicativeGen,
+// inverse transform scaled by domain.CardinalityInv), operate in place on a
+// and return true. A backend may decline an input (e.g. too small to be worth
+// the transfer) by returning false without modifying a.
+//
+// [CheckBackend] can be used by integrators to test a backend against the CPU
+// implementation.
+type Backend interface {
+	FFT(domain *Domain, a []fr.Element, decimation Decimation, coset bool) bool
+	FFTInverse(domain *Domain, a []fr.Element, decimation Decimation, coset bool) bool
+}
+
+type backendHolder struct {
+	b Backend
+}
+
+var backend atomic.Pointer[backendHolder]
+
+// RegisterBackend sets the backend used by all domains. Passing nil restores
+// the CPU implementation.
+func RegisterBackend(b Backend) {
+	if b == nil {
+		backend.Store(nil)
+		return
+	}
+	backend.Store(&backendHolder{b: b})
+}
+
+func registeredBackend(opt fftConfig) Backend {
+	if opt.noBackend {
+		return nil
+	}
+	if h := backend.Load(); h != nil {
+		return h.b
+	}
+	return nil
+}
+
+// CheckBackend runs b on random inputs of the given sizes, for all decimations,
+// with and without coset, and compares the results with the CPU
+// implementation. Sizes must be powers of 2. Inputs declined by the backend are
+// skipped.
+func CheckBackend(b Backend, sizes ...uint64) error {
+	if len(sizes) == 0 {
+		return errors.New("no sizes to check")
+	}
+	for _, size := range sizes {
+		domain := NewDomain(size)
+		for _, decimation := range []Decimation{DIT, DIF} {
+			for _, coset := range []bool{false, true} {
+				a := make([]fr.Element, size)
+				for i := range a {
+					a[i].SetRandom()
+				}
+				opts := []Option{WithoutBackend()}
+				if coset {
+					opts = append(opts, OnCoset())
+				}
+
+				expected := make([]fr.Element, size)
+				copy(expected, a)
+				domain.FFT(expected, decimation, opts...)
+				got := make([]fr.Element, size)
+				copy(got, a)
+				if b.FFT(domain, got, decimation, coset) {
+					if err := compareVectors(expected, got); err != nil {
+						return fmt.Errorf("FFT size=%d decimation=%d coset=%t: %w", size, decimation, coset, err)
+					}
+				}
+
+				expected = make([]fr.Element, size)
+				copy(expected, a)
+				domain.FFTInverse(expected, decimation, opts...)
+				copy(got, a)
+				if b.FFTInverse(domain, got, decimation, coset) {
+					if err := compareVectors(expected, got); err != nil {
+						return fmt.Errorf("FFTInverse size=%d decimation=%d coset=%t: %w", size, decimation, coset, err)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func compareVectors(expected, got []fr.Element) error {
+	for i := range expected {
+		if !expected[i].Equal(&got[i]) {
+			return fmt.Errorf("mismatch at index %d", i)
+		}
+	}
+	return nil
+}
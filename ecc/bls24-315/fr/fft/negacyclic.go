@@ -0,0 +1,104 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+// ErrNegacyclicSize is returned by NewNegacyclicDomain when the size is not a power of two.
+var ErrNegacyclicSize = errors.New("the size of a negacyclic domain must be a power of two")
+
+// NegacyclicDomain is the number theoretic transform over fr[X]/(Xⁿ+1), n a power of two.
+//
+// The roots of Xⁿ+1 are ψ·ωⁱ, where ψ is a primitive 2n-th root of unity and ω = ψ² a
+// primitive n-th root of unity: the transform is the FFT on the coset ψ·<ω>. Products
+// of polynomials modulo Xⁿ+1 are computed pointwise in the evaluation domain, as in
+// ring-SIS and lattice-based schemes.
+type NegacyclicDomain struct {
+	Cardinality uint64
+
+	// Psi primitive 2n-th root of unity, Psi^Cardinality = -1
+	Psi fr.Element
+
+	// domain on the coset Psi·<Psi²>; its coset tables are the powers of Psi and Psi⁻¹
+	domain *Domain
+}
+
+// NewNegacyclicDomain returns the negacyclic domain of size n. It returns an error if n
+// is not a power of two or if fr has no root of unity of order 2n.
+func NewNegacyclicDomain(n uint64) (*NegacyclicDomain, error) {
+	if n == 0 || n != ecc.NextPowerOfTwo(n) {
+		return nil, fmt.Errorf("%w, got %d", ErrNegacyclicSize, n)
+	}
+	psi, err := Generator(2 * n)
+	if err != nil {
+		return nil, err
+	}
+	return &NegacyclicDomain{
+		Cardinality: n,
+		Psi:         psi,
+		domain:      NewDomain(n, WithShift(psi)),
+	}, nil
+}
+
+// Domain returns the cyclic domain on the coset Psi·<Psi²>: FFT on this coset is NTT.
+func (d *NegacyclicDomain) Domain() *Domain {
+	return d.domain
+}
+
+// NTT replaces the coefficients of p ∈ fr[X]/(Xⁿ+1) with its evaluations at the
+// roots of Xⁿ+1, in bit-reversed order: a[i] = p(Psi·Psi^{2·bitReverse(i)}).
+func (d *NegacyclicDomain) NTT(a []fr.Element, opts ...Option) {
+	d.checkSize(a)
+	d.domain.FFT(a, DIF, append(opts, OnCoset())...)
+}
+
+// InverseNTT is the inverse of NTT: a holds evaluations in bit-reversed order, and is
+// replaced with the coefficients of the polynomial of degree less than n.
+func (d *NegacyclicDomain) InverseNTT(a []fr.Element, opts ...Option) {
+	d.checkSize(a)
+	d.domain.FFTInverse(a, DIT, append(opts, OnCoset())...)
+}
+
+// Mul sets res to a·b mod Xⁿ+1, a and b given by their coefficients. res may alias
+// a or b.
+func (d *NegacyclicDomain) Mul(res, a, b []fr.Element, opts ...Option) {
+	d.checkSize(res)
+	d.checkSize(a)
+	d.checkSize(b)
+	_a := make(fr.Vector, d.Cardinality)
+	_b := make(fr.Vector, d.Cardinality)
+	copy(_a, a)
+	copy(_b, b)
+	d.NTT(_a, opts...)
+	d.NTT(_b, opts...)
+	_a.Mul(_a, _b)
+	d.InverseNTT(_a, opts...)
+	copy(res, _a)
+}
+
+func (d *NegacyclicDomain) checkSize(a []fr.Element) {
+	if uint64(len(a)) != d.Cardinality {
+		panic(fmt.Sprintf("fft: size %d doesn't match the negacyclic domain size %d", len(a), d.Cardinality))
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+)
+
+func TestNegacyclicDomain(t *testing.T) {
+	const n = 64
+
+	d, err := NewNegacyclicDomain(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ψⁿ = -1
+	var psiN, minusOne fr.Element
+	psiN.Exp(d.Psi, big.NewInt(n))
+	minusOne.SetOne().Neg(&minusOne)
+	if !psiN.Equal(&minusOne) {
+		t.Fatal("psi is not a 2n-th root of -1")
+	}
+
+	a := make([]fr.Element, n)
+	b := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		a[i].SetRandom()
+		b[i].SetRandom()
+	}
+
+	// NTT evaluates at the roots of Xⁿ+1, in bit-reversed order
+	evals := make([]fr.Element, n)
+	copy(evals, a)
+	d.NTT(evals)
+	BitReverse(evals)
+	var x fr.Element
+	x.Set(&d.Psi)
+	for i := 0; i < n; i++ {
+		var e fr.Element
+		for j := n - 1; j >= 0; j-- {
+			e.Mul(&e, &x).Add(&e, &a[j])
+		}
+		if !e.Equal(&evals[i]) {
+			t.Fatal("wrong evaluation")
+		}
+		x.Mul(&x, &d.Psi).Mul(&x, &d.Psi)
+	}
+
+	// InverseNTT(NTT(a)) = a
+	BitReverse(evals)
+	d.InverseNTT(evals)
+	for i := 0; i < n; i++ {
+		if !evals[i].Equal(&a[i]) {
+			t.Fatal("InverseNTT(NTT(a)) != a")
+		}
+	}
+
+	// schoolbook negacyclic product
+	expected := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			var t fr.Element
+			t.Mul(&a[i], &b[j])
+			if i+j < n {
+				expected[i+j].Add(&expected[i+j], &t)
+			} else {
+				expected[i+j-n].Sub(&expected[i+j-n], &t)
+			}
+		}
+	}
+	d.Mul(a, a, b)
+	for i := 0; i < n; i++ {
+		if !expected[i].Equal(&a[i]) {
+			t.Fatal("wrong negacyclic product")
+		}
+	}
+
+	if _, err := NewNegacyclicDomain(48); !errors.Is(err, ErrNegacyclicSize) {
+		t.Fatal("expected ErrNegacyclicSize")
+	}
+}
+
+func BenchmarkNegacyclicNTT(b *testing.B) {
+	const n = 1 << 10
+	d, err := NewNegacyclicDomain(n)
+	if err != nil {
+		b.Fatal(err)
+	}
+	a := make([]fr.Element, n)
+	for i := range a {
+		a[i].SetRandom()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.NTT(a)
+		d.InverseNTT(a)
+	}
+}
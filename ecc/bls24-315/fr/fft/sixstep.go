@@ -0,0 +1,174 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/internal/parallel"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+)
+
+// sixStepMinSize is the smallest cardinality for which FFT and FFTInverse use the
+// six-step algorithm. Below it, the recursive FFT works mostly within the caches and
+// is faster; above it, each of its stages streams the whole vector through memory.
+var sixStepMinSize uint64 = 1 << 22
+
+// transposeBlockSize is the side of the square tiles of the cache-blocked transpose.
+// A tile of source and destination rows fits in L1 for all the supported fields.
+const transposeBlockSize = 16
+
+// sixStepPlan holds the sub-domains of the six-step FFT of a domain of cardinality
+// n = n1·n2: the vector is seen as a n2×n1 row-major matrix, on which we run n1 FFTs
+// of size n2 and n2 FFTs of size n1, with three transposes so that each small FFT
+// runs on a contiguous row that fits in the caches.
+type sixStepPlan struct {
+	n1, n2  int
+	d1, d2  *Domain    // domains of cardinality n1 and n2, with precomputed twiddles
+	w, wInv fr.Element // generator of the domain of cardinality n and its inverse
+}
+
+// newSixStepPlan returns the six-step plan of a domain of cardinality n, or nil if n is
+// below sixStepMinSize.
+func newSixStepPlan(n uint64) *sixStepPlan {
+	if n < sixStepMinSize || n < 4 {
+		return nil
+	}
+	logN := bits.TrailingZeros64(n)
+	n1 := uint64(1) << (logN / 2)
+	n2 := n / n1
+
+	p := &sixStepPlan{
+		n1: int(n1),
+		n2: int(n2),
+		d1: NewDomain(n1),
+		d2: NewDomain(n2),
+	}
+	var err error
+	if p.w, err = Generator(n); err != nil {
+		panic(err)
+	}
+	p.wInv.Inverse(&p.w)
+	return p
+}
+
+// fft computes in place the (unscaled) discrete Fourier transform of a, with the
+// generator of the domain or its inverse. The input and output orders follow
+// decimation, as in Domain.FFT.
+//
+// With j = j1 + n1·j2 and k = k2 + n2·k1, ωʲᵏ = ω₂^(j2·k2)·ω^(j1·k2)·ω₁^(j1·k1) where
+// ω₁ = ω^n2 and ω₂ = ω^n1 are the generators of the sub-domains, so the transform is:
+//  1. a transpose, so that the j2 are contiguous,
+//  2. n1 FFTs of size n2, each followed by the multiplication by the twiddles ω^(j1·k2)
+//     while the row is still in the caches,
+//  3. a transpose, so that the j1 are contiguous,
+//  4. n2 FFTs of size n1,
+//  5. a transpose, so that the output is in natural order,
+//  6. the copy back into a, bit-reversing it for DIF.
+func (p *sixStepPlan) fft(a []fr.Element, decimation Decimation, inverse bool, opt fftConfig) {
+	if decimation != DIF && decimation != DIT {
+		panic("not implemented")
+	}
+	if decimation == DIT {
+		BitReverse(a)
+	}
+
+	w, w1, w2 := p.w, p.d1.Generator, p.d2.Generator
+	t1, t2 := p.d1.twiddles, p.d2.twiddles
+	if inverse {
+		w, w1, w2 = p.wInv, p.d1.GeneratorInv, p.d2.GeneratorInv
+		t1, t2 = p.d1.twiddlesInv, p.d2.twiddlesInv
+	}
+
+	// the scratch matrix is a single contiguous buffer, so that the transposes walk
+	// through large pages rather than scattered allocations.
+	buf := opt.arena.Alloc(len(a))
+
+	// 1. buf[j1][j2] = a[j2][j1]
+	transpose(buf, a, p.n2, p.n1, opt.nbTasks)
+
+	// 2. FFTs of size n2 on the rows of buf, fused with the twiddles ω^(j1·k2)
+	parallel.Execute(p.n1, func(start, end int) {
+		var wj1 fr.Element
+		wj1.Exp(w, big.NewInt(int64(start)))
+		for j1 := start; j1 < end; j1++ {
+			row := buf[j1*p.n2 : (j1+1)*p.n2]
+			difFFT(row, w2, t2, 0, 0, -1, nil, 1)
+			BitReverse(row)
+			var wk fr.Element
+			wk.SetOne()
+			for k2 := range row {
+				row[k2].Mul(&row[k2], &wk)
+				wk.Mul(&wk, &wj1)
+			}
+			wj1.Mul(&wj1, &w)
+		}
+	}, opt.nbTasks)
+
+	// 3. a[k2][j1] = buf[j1][k2]
+	transpose(a, buf, p.n1, p.n2, opt.nbTasks)
+
+	// 4. FFTs of size n1 on the rows of a
+	parallel.Execute(p.n2, func(start, end int) {
+		for k2 := start; k2 < end; k2++ {
+			row := a[k2*p.n1 : (k2+1)*p.n1]
+			difFFT(row, w1, t1, 0, 0, -1, nil, 1)
+			BitReverse(row)
+		}
+	}, opt.nbTasks)
+
+	// 5. buf[k1][k2] = a[k2][k1], the transform in natural order
+	transpose(buf, a, p.n2, p.n1, opt.nbTasks)
+
+	// 6. copy back, bit-reversing on the fly for DIF
+	if decimation == DIT {
+		parallel.Execute(len(a), func(start, end int) {
+			copy(a[start:end], buf[start:end])
+		}, opt.nbTasks)
+		return
+	}
+	nn := uint64(64 - bits.TrailingZeros64(uint64(len(a))))
+	parallel.Execute(len(a), func(start, end int) {
+		for i := start; i < end; i++ {
+			irev := bits.Reverse64(uint64(i)) >> nn
+			a[irev] = buf[i]
+		}
+	}, opt.nbTasks)
+}
+
+// transpose writes in dst the transpose of the rows×cols row-major matrix src.
+// It goes through square tiles of transposeBlockSize, so that both the source and the
+// destination rows of a tile stay in the caches.
+func transpose(dst, src []fr.Element, rows, cols, nbTasks int) {
+	nbBlocks := (rows + transposeBlockSize - 1) / transposeBlockSize
+	parallel.Execute(nbBlocks, func(start, end int) {
+		for b := start; b < end; b++ {
+			r0 := b * transposeBlockSize
+			r1 := min(r0+transposeBlockSize, rows)
+			for c0 := 0; c0 < cols; c0 += transposeBlockSize {
+				c1 := min(c0+transposeBlockSize, cols)
+				for r := r0; r < r1; r++ {
+					for c := c0; c < c1; c++ {
+						dst[c*rows+r] = src[r*cols+c]
+					}
+				}
+			}
+		}
+	}, nbTasks)
+}
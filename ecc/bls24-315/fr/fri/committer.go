@@ -0,0 +1,178 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fri
+
+import (
+	"bytes"
+	"errors"
+	"hash"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var ErrUnsupportedIopp = errors.New("the IOPP doesn't support commitments")
+
+// Committer commits to polynomials and keeps their evaluations on the evaluation domain
+// along with their Merkle trees, so that the proof of proximity and the openings of a
+// committed polynomial reuse them instead of evaluating the polynomial and hashing the
+// evaluations again.
+//
+// Proofs built by a Committer are verified with the Iopp it was created from.
+type Committer struct {
+	s radixTwoFri
+}
+
+// Commitment is a handle on a polynomial committed with a Committer.
+type Commitment struct {
+
+	// evaluations of the polynomial on the evaluation domain, sorted
+	// such that contiguous entries are in the same fiber.
+	sorted []fr.Element
+
+	// Merkle tree of the sorted evaluations
+	tree merkleTree
+}
+
+// NewCommitter returns a Committer for iopp, as returned by IOPP.New or IOPP.NewWithInstance.
+func NewCommitter(iopp Iopp) (*Committer, error) {
+	s, ok := iopp.(radixTwoFri)
+	if !ok {
+		return nil, ErrUnsupportedIopp
+	}
+	return &Committer{s: s}, nil
+}
+
+// Commit commits to the polynomial p, given by its coefficients.
+func (c *Committer) Commit(p []fr.Element) *Commitment {
+	return c.s.commit(c.s.evaluate(p, nil), nil)
+}
+
+// CommitEvaluations commits to the polynomial given by its evaluations on the evaluation
+// domain (of size ρ*size), in natural order: evals[i] = p(gⁱ).
+func (c *Committer) CommitEvaluations(evals []fr.Element) (*Commitment, error) {
+	if uint64(len(evals)) != c.s.domain.Cardinality {
+		return nil, ErrEvaluationsSize
+	}
+	return c.s.commit(evals, nil), nil
+}
+
+// BuildProofOfProximity creates a proof of proximity for the committed polynomial.
+func (c *Committer) BuildProofOfProximity(cm *Commitment) (ProofOfProximity, error) {
+	return c.s.buildProofOfProximity(cm, nil)
+}
+
+// Open opens the committed polynomial at gⁱ where i = position.
+func (c *Committer) Open(cm *Commitment, position uint64) (OpeningProof, error) {
+	return c.s.open(cm, position)
+}
+
+// Root returns the Merkle root of the evaluations of the committed polynomial, or the
+// concatenation of the nodes of their Merkle cap with WithMerkleCapHeight. It is the
+// Merkle root of the first interaction of its proofs of proximity.
+func (cm *Commitment) Root() Digest {
+	return cm.tree.root()
+}
+
+// merkleTree is a Merkle tree on a power of two number of leaves, hashed as in the
+// accumulator/merkletree package. It keeps all its nodes, so that proofs for several
+// leaves are generated without hashing the leaves again.
+type merkleTree struct {
+
+	// leaves data of the leaves
+	leaves [][]byte
+
+	// nodes[0] are the hashes of the leaves, nodes[len(nodes)-1][0] is the root
+	nodes [][][]byte
+
+	// capHeight height of the Merkle cap below the root, see WithMerkleCapHeight
+	capHeight int
+}
+
+// minParallelHashes is the size from which the levels of a merkleTree are hashed in
+// parallel, below it the goroutines cost more than they save.
+const minParallelHashes = 64
+
+// newMerkleTree returns the Merkle tree of evals, hashed with h, or in parallel with
+// instances of newHash if it is not nil.
+func newMerkleTree(h hash.Hash, newHash func() hash.Hash, evals []fr.Element, capHeight int) merkleTree {
+	t := merkleTree{capHeight: capHeight}
+	t.leaves = make([][]byte, len(evals))
+	level := make([][]byte, len(evals))
+	hashLevel(h, newHash, len(level), func(h hash.Hash, i int) {
+		t.leaves[i] = evals[i].Marshal()
+		level[i] = merkleSum(h, t.leaves[i])
+	})
+	t.nodes = append(t.nodes, level)
+	for len(level) > 1 {
+		children := level
+		level = make([][]byte, len(children)/2)
+		hashLevel(h, newHash, len(level), func(h hash.Hash, i int) {
+			level[i] = merkleSum(h, children[2*i], children[2*i+1])
+		})
+		t.nodes = append(t.nodes, level)
+	}
+	return t
+}
+
+// hashLevel calls hashNode on the n nodes of a level, with h, or in parallel with
+// instances of newHash if it is not nil and the level is large enough.
+func hashLevel(h hash.Hash, newHash func() hash.Hash, n int, hashNode func(h hash.Hash, i int)) {
+	if newHash == nil || n < minParallelHashes {
+		for i := 0; i < n; i++ {
+			hashNode(h, i)
+		}
+		return
+	}
+	parallel.Execute(n, func(start, end int) {
+		h := newHash()
+		for i := start; i < end; i++ {
+			hashNode(h, i)
+		}
+	})
+}
+
+// root returns the commitment to the leaves: the Merkle root, or the concatenation of
+// the nodes of the Merkle cap.
+func (t *merkleTree) root() []byte {
+	nodes := t.nodes[len(t.nodes)-1-t.capHeight]
+	if len(nodes) == 1 {
+		return nodes[0]
+	}
+	return bytes.Join(nodes, nil)
+}
+
+// prove returns the proof set of the leaf at index, as merkletree.Tree.Prove does:
+// [leaf ∥ sibling leaf hash ∥ .. ∥ child of the root], where the leaf is not hashed.
+// With a Merkle cap, it stops at the child of the node of the cap.
+func (t *merkleTree) prove(index uint64) [][]byte {
+	proofSet := make([][]byte, len(t.nodes)-t.capHeight)
+	proofSet[0] = t.leaves[index]
+	for i := 0; i < len(proofSet)-1; i++ {
+		proofSet[i+1] = t.nodes[i][index^1]
+		index >>= 1
+	}
+	return proofSet
+}
+
+func merkleSum(h hash.Hash, data ...[]byte) []byte {
+	h.Reset()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
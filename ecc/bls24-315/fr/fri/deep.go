@@ -0,0 +1,255 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fri
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+)
+
+var (
+	ErrDeepPoint      = errors.New("the out of domain point is in the evaluation domain")
+	ErrDeepProof      = errors.New("the DEEP proof is malformed")
+	ErrDeepQuotient   = errors.New("the quotient is inconsistent with the committed polynomial")
+	ErrDeepCommitment = errors.New("merkle roots of the openings and the commitment don't coincide")
+)
+
+// DeepProof is a DEEP-FRI proof of proximity. The prover commits to a polynomial p, the
+// verifier samples an out of domain point z, the prover sends p(z) and a proof of proximity
+// of the quotient (p-p(z))/(X-z). At each query of the first layer of the quotient, p is
+// opened to check that the quotient is consistent with p.
+//
+// Besides proving that p is close to a low degree polynomial, a valid proof proves that
+// Evaluation is the evaluation of this polynomial at Point.
+type DeepProof struct {
+
+	// Root Merkle root (or cap) of the evaluations of p, as returned by Commitment.Root
+	Root Digest
+
+	// Point out of domain point z, derived from Root with Fiat Shamir, or chosen by
+	// the caller of Committer.BuildDeepProofOfProximityAt
+	Point fr.Element
+
+	// Evaluation claimed value p(z)
+	Evaluation fr.Element
+
+	// Quotient proof of proximity of (p-p(z))/(X-z)
+	Quotient ProofOfProximity
+
+	// Openings of p at the fibers queried in the first layer of each round of Quotient
+	Openings [][2]MerkleProof
+}
+
+// BuildDeepProofOfProximity creates a DEEP-FRI proof of proximity for the committed polynomial.
+func (c *Committer) BuildDeepProofOfProximity(cm *Commitment) (DeepProof, error) {
+	z, err := c.s.deriveDeepPoint(cm.Root())
+	if err != nil {
+		return DeepProof{}, err
+	}
+	return c.s.buildDeepProofOfProximity(cm, z)
+}
+
+// BuildDeepProofOfProximityAt is like BuildDeepProofOfProximity, but the out of domain
+// point is chosen by the caller instead of being derived from the commitment. It is an
+// opening proof of the committed polynomial at point, verified with
+// Iopp.VerifyDeepProofOfProximityAt.
+func (c *Committer) BuildDeepProofOfProximityAt(cm *Commitment, point fr.Element) (DeepProof, error) {
+	if c.s.inDomain(point) {
+		return DeepProof{}, ErrDeepPoint
+	}
+	return c.s.buildDeepProofOfProximity(cm, point)
+}
+
+// deriveDeepPoint derives the out of domain point z from the commitment root.
+func (s radixTwoFri) deriveDeepPoint(root Digest) (fr.Element, error) {
+	fs := s.newTranscript("z")
+	if err := fs.Bind("z", root); err != nil {
+		return fr.Element{}, err
+	}
+	z, err := s.computeChallenge(fs, "z")
+	if err != nil {
+		return z, err
+	}
+
+	// z must not be in the evaluation domain
+	if s.inDomain(z) {
+		return z, ErrDeepPoint
+	}
+	return z, nil
+}
+
+// inDomain returns true if z is in the evaluation domain.
+func (s radixTwoFri) inDomain(z fr.Element) bool {
+	var zn fr.Element
+	zn.Exp(z, big.NewInt(int64(s.domain.Cardinality)))
+	return zn.IsOne()
+}
+
+// sortedDomain returns the points of the evaluation domain, sorted as the committed
+// evaluations: {g⁰, g^{n/2}, g¹, g^{1+n/2},...,g^{n/2-1}, gⁿ⁻¹}
+func (s radixTwoFri) sortedDomain() []fr.Element {
+	points := make([]fr.Element, s.domain.Cardinality)
+	points[0].SetOne()
+	for i := 1; i < len(points); i++ {
+		points[i].Mul(&points[i-1], &s.domain.Generator)
+	}
+	return sort(points, nil)
+}
+
+func (s radixTwoFri) buildDeepProofOfProximity(cm *Commitment, z fr.Element) (DeepProof, error) {
+
+	var proof DeepProof
+	var err error
+	proof.Root = cm.Root()
+	proof.Point = z
+
+	// inv[i] = 1/(xᵢ - z), the xᵢ being the points of the evaluation domain
+	points := s.sortedDomain()
+	for i := 0; i < len(points); i++ {
+		points[i].Sub(&points[i], &proof.Point)
+	}
+	inv := fr.BatchInvert(points)
+	for i := 0; i < len(points); i++ {
+		points[i].Add(&points[i], &proof.Point)
+	}
+
+	// barycentric evaluation of p at z, on the evaluation domain of size N:
+	// p(z) = (zᴺ-1)/N ∑ᵢ p(xᵢ)xᵢ/(z-xᵢ)
+	var acc, t fr.Element
+	for i := 0; i < len(points); i++ {
+		t.Mul(&cm.sorted[i], &points[i]).Mul(&t, &inv[i])
+		acc.Sub(&acc, &t)
+	}
+	var zn, one fr.Element
+	one.SetOne()
+	zn.Exp(proof.Point, big.NewInt(int64(s.domain.Cardinality))).
+		Sub(&zn, &one).
+		Mul(&zn, &s.domain.CardinalityInv)
+	proof.Evaluation.Mul(&acc, &zn)
+
+	// quotient (p-p(z))/(X-z), evaluated on the domain
+	quotient := make([]fr.Element, len(points))
+	for i := 0; i < len(points); i++ {
+		quotient[i].Sub(&cm.sorted[i], &proof.Evaluation).Mul(&quotient[i], &inv[i])
+	}
+	cmQuotient := &Commitment{sorted: quotient, tree: newMerkleTree(s.h, s.newHash, quotient, s.capHeight)}
+	proof.Quotient, err = s.buildProofOfProximity(cmQuotient, nil)
+	if err != nil {
+		return proof, err
+	}
+
+	// open p at the first queries of each round
+	proof.Openings = make([][2]MerkleProof, s.nbRounds)
+	for i := 0; i < s.nbRounds; i++ {
+		_, si, err := s.DeriveQueries(i, proof.Quotient.Rounds[i])
+		if err != nil {
+			return proof, err
+		}
+		pos := si[0]
+		c := pos % 2
+		numLeaves := uint64(len(cm.sorted))
+		proof.Openings[i][c] = MerkleProof{proof.Root, cm.tree.prove(uint64(pos)), numLeaves}
+		proof.Openings[i][1-c] = MerkleProof{
+			proof.Root,
+			[][]byte{cm.tree.leaves[pos+1-2*c], cm.tree.nodes[0][pos]},
+			numLeaves,
+		}
+	}
+
+	return proof, nil
+}
+
+// VerifyDeepProofOfProximity verifies a DEEP-FRI proof of proximity.
+func (s radixTwoFri) VerifyDeepProofOfProximity(proof DeepProof) error {
+	z, err := s.deriveDeepPoint(proof.Root)
+	if err != nil {
+		return err
+	}
+	return s.verifyDeepProofOfProximity(proof, z)
+}
+
+// VerifyDeepProofOfProximityAt verifies a DEEP-FRI proof of proximity built with
+// Committer.BuildDeepProofOfProximityAt at point.
+func (s radixTwoFri) VerifyDeepProofOfProximityAt(proof DeepProof, point fr.Element) error {
+	if s.inDomain(point) {
+		return ErrDeepPoint
+	}
+	return s.verifyDeepProofOfProximity(proof, point)
+}
+
+// verifyDeepProofOfProximity verifies a DEEP-FRI proof of proximity at the out of
+// domain point z.
+func (s radixTwoFri) verifyDeepProofOfProximity(proof DeepProof, z fr.Element) error {
+
+	if len(proof.Openings) != s.nbRounds {
+		return s.verificationError(ErrDeepProof, -1, -1, -1, s.nbRounds, len(proof.Openings))
+	}
+	if len(proof.Quotient.Rounds) != s.nbRounds {
+		return s.verificationError(ErrDeepProof, -1, -1, -1, s.nbRounds, len(proof.Quotient.Rounds))
+	}
+	for i := 0; i < s.nbRounds; i++ {
+		if len(proof.Quotient.Rounds[i].Interactions) != s.nbSteps {
+			return s.verificationError(ErrDeepProof, i, -1, -1, s.nbSteps, len(proof.Quotient.Rounds[i].Interactions))
+		}
+	}
+
+	if !z.Equal(&proof.Point) {
+		return s.verificationError(ErrDeepProof, -1, -1, -1, &z, &proof.Point)
+	}
+
+	if err := s.VerifyProofOfProximity(proof.Quotient); err != nil {
+		return err
+	}
+
+	// check that the quotient is consistent with p at the queried fibers:
+	// q(x)(x-z) = p(x)-p(z)
+	for i := 0; i < s.nbRounds; i++ {
+		_, si, err := s.DeriveQueries(i, proof.Quotient.Rounds[i])
+		if err != nil {
+			return err
+		}
+		pos := si[0]
+		for j := 0; j < 2; j++ {
+			if !bytes.Equal(proof.Openings[i][j].MerkleRoot, proof.Root) {
+				return s.verificationError(ErrDeepCommitment, i, 0, pos, proof.Root, proof.Openings[i][j].MerkleRoot)
+			}
+		}
+		if err := s.verifyFiberOpening(proof.Openings[i], pos, i, 0); err != nil {
+			return err
+		}
+
+		// the fiber is {g^{pos/2}, -g^{pos/2}}
+		var x fr.Element
+		x.Exp(s.domain.Generator, big.NewInt(int64(pos/2)))
+		for j := 0; j < 2; j++ {
+			var p, q, l, r fr.Element
+			p.SetBytes(proof.Openings[i][j].ProofSet[0])
+			q.SetBytes(proof.Quotient.Rounds[i].Interactions[0][j].ProofSet[0])
+			l.Sub(&x, &proof.Point).Mul(&l, &q)
+			r.Sub(&p, &proof.Evaluation)
+			if !l.Equal(&r) {
+				return s.verificationError(ErrDeepQuotient, i, 0, pos-pos%2+j, &r, &l)
+			}
+			x.Neg(&x)
+		}
+	}
+
+	return nil
+}
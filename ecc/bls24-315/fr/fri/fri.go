@@ -18,6 +18,8 @@ package fri
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"hash"
@@ -34,15 +36,19 @@ import (
 var (
 	ErrLowDegree            = errors.New("the fully folded polynomial in not of degree 1")
 	ErrProximityTestFolding = errors.New("one round of interaction failed")
+	ErrProofShape           = errors.New("the proof doesn't have the expected number of rounds or interactions")
 	ErrOddSize              = errors.New("the size should be even")
 	ErrMerkleRoot           = errors.New("merkle roots of the opening and the proof of proximity don't coincide")
 	ErrMerklePath           = errors.New("merkle path proof is wrong")
 	ErrRangePosition        = errors.New("the asked opening position is out of range")
+	ErrEvaluationsSize      = errors.New("the number of evaluations should be the size of the evaluation domain")
 )
 
-const rho = 8
-
-const nbRounds = 1
+// default blow-up factor and number of rounds, see WithRho and WithNbRounds
+const (
+	defaultRho      = 8
+	defaultNbRounds = 1
+)
 
 // 2^{-1}, used several times
 var twoInv fr.Element
@@ -57,11 +63,12 @@ type Digest []byte
 // be empty), since the Merkle path is the same as for the first value.
 type MerkleProof struct {
 
-	// Merkle root
+	// Merkle root, or concatenation of the nodes of the Merkle cap (see
+	// WithMerkleCapHeight)
 	MerkleRoot []byte
 
 	// ProofSet stores [leaf ∥ node_1 ∥ .. ∥ merkleRoot ], where the leaf is not
-	// hashed.
+	// hashed. With a Merkle cap, it stops below the node of the cap.
 	ProofSet [][]byte
 
 	// number of leaves of the tree.
@@ -140,33 +147,91 @@ type Iopp interface {
 	// of degree len(p). The proof is built non interactively using Fiat Shamir.
 	BuildProofOfProximity(p []fr.Element) (ProofOfProximity, error)
 
+	// BuildProofOfProximityFromEvaluations is like BuildProofOfProximity, but takes the
+	// evaluations of the polynomial on the evaluation domain (of size ρ*size), in natural
+	// order: evals[i] = p(gⁱ). This skips the FFT done by BuildProofOfProximity.
+	BuildProofOfProximityFromEvaluations(evals []fr.Element) (ProofOfProximity, error)
+
+	// BuildProofOfProximityWithMemory is like BuildProofOfProximity, but allocates its
+	// scratch buffers from mem, see ProverMemory.
+	BuildProofOfProximityWithMemory(p []fr.Element, mem *ProverMemory) (ProofOfProximity, error)
+
 	// VerifyProofOfProximity verifies the proof of proximity. It returns an error if the
 	// verification fails.
 	VerifyProofOfProximity(proof ProofOfProximity) error
 
+	// VerifyDeepProofOfProximity verifies a DEEP-FRI proof of proximity, built with
+	// Committer.BuildDeepProofOfProximity. It returns an error if the verification fails.
+	VerifyDeepProofOfProximity(proof DeepProof) error
+
+	// VerifyDeepProofOfProximityAt verifies a DEEP-FRI proof of proximity built with
+	// Committer.BuildDeepProofOfProximityAt at point. It returns an error if the
+	// verification fails.
+	VerifyDeepProofOfProximityAt(proof DeepProof, point fr.Element) error
+
 	// Opens a polynomial at gⁱ where i = position.
 	Open(p []fr.Element, position uint64) (OpeningProof, error)
 
 	// Verifies the opening of a polynomial at gⁱ where i = position.
 	VerifyOpening(position uint64, openingProof OpeningProof, pp ProofOfProximity) error
+
+	// DeriveQueries derives the folding challenges and the positions (in sorted form)
+	// of the queries of the given round of proof, as the verifier does. The semantics
+	// are stable, so that a recursive verifier can recreate them.
+	DeriveQueries(round int, proof Round) ([]fr.Element, []int, error)
+
+	// QueriesPositions derives the positions (in sorted form) of the queries of a
+	// round from its seed, the raw value of its last challenge s0.
+	QueriesPositions(seed []byte) []int
+
+	// Fingerprint returns a constant-size digest of the parameters of the IOPP (variant, rate,
+	// number of rounds and steps, evaluation domain). The hash function is not included.
+	Fingerprint() [sha256.Size]byte
 }
 
-// GetRho returns the factor ρ = size_code_word/size_polynomial
+// GetRho returns the default factor ρ = size_code_word/size_polynomial
 func GetRho() int {
-	return rho
+	return defaultRho
 }
 
 func init() {
 	twoInv.SetUint64(2).Inverse(&twoInv)
 }
 
-// New creates a new IOPP capable to handle degree(size) polynomials.
+// New creates a new IOPP capable to handle degree(size) polynomials, with the
+// default parameters. It panics if the parameters are invalid, see NewWithOptions.
 func (iopp IOPP) New(size uint64, h hash.Hash) Iopp {
+	res, err := iopp.NewWithOptions(size, h)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// NewWithInstance is like New, but binds the Fiat-Shamir challenges to instanceID, a unique
+// identifier of the proof (e.g. a proof ID, chain ID or block height), so that proofs for
+// different instances don't share challenges. The verifier must use the same instanceID.
+func (iopp IOPP) NewWithInstance(size uint64, h hash.Hash, instanceID []byte) Iopp {
+	res, err := iopp.NewWithOptions(size, h, WithInstanceID(instanceID))
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// NewWithOptions creates a new IOPP capable to handle degree(size) polynomials,
+// configured by opts. It returns an error wrapping ErrInvalidConfig if the
+// parameters are inconsistent. The verifier must use the same options as the prover.
+func (iopp IOPP) NewWithOptions(size uint64, h hash.Hash, opts ...Option) (Iopp, error) {
+	cfg, err := newConfig(size, h, opts...)
+	if err != nil {
+		return nil, err
+	}
 	switch iopp {
 	case RADIX_2_FRI:
-		return newRadixTwoFri(size, h)
+		return newRadixTwoFri(size, h, cfg), nil
 	default:
-		panic("iopp name is not recognized")
+		return nil, fmt.Errorf("%w: iopp name is not recognized", ErrInvalidConfig)
 	}
 }
 
@@ -178,15 +243,40 @@ type radixTwoFri struct {
 	// the oracles.
 	h hash.Hash
 
+	// newHash builds instances of h to hash the Merkle trees in parallel, see
+	// WithParallelHashing
+	newHash func() hash.Hash
+
 	// nbSteps number of Interactions between the prover and the verifier
 	nbSteps int
 
 	// domain used to build the Reed Solomon code from the given polynomial.
 	// The size of the domain is ρ*size_polynomial.
 	domain *fft.Domain
+
+	// instanceID, if not nil, is bound into the Fiat-Shamir transcript
+	instanceID []byte
+
+	// rho blow-up factor of the Reed Solomon code
+	rho uint64
+
+	// nbRounds number of rounds of the proof of proximity
+	nbRounds int
+
+	// recorder, if not nil, records the Fiat-Shamir transcripts
+	recorder *fiatshamir.Recorder
+
+	// redactErrors removes the values from the verification errors
+	redactErrors bool
+
+	// capHeight height of the Merkle caps of the oracles, see WithMerkleCapHeight
+	capHeight int
+
+	// wideChallenges derives the challenges with fiatshamir.ComputeChallengeFr
+	wideChallenges bool
 }
 
-func newRadixTwoFri(size uint64, h hash.Hash) radixTwoFri {
+func newRadixTwoFri(size uint64, h hash.Hash, cfg config) radixTwoFri {
 
 	var res radixTwoFri
 
@@ -196,21 +286,91 @@ func newRadixTwoFri(size uint64, h hash.Hash) radixTwoFri {
 	res.nbSteps = nbSteps
 
 	// extending the domain
-	n = n * rho
+	n = n * cfg.rho
 
 	// building the domains
 	res.domain = fft.NewDomain(n)
 
 	// hash function
 	res.h = h
+	res.newHash = cfg.newHash
+
+	res.rho = cfg.rho
+	res.nbRounds = cfg.nbRounds
+	res.instanceID = cfg.instanceID
+	res.recorder = cfg.recorder
+	res.redactErrors = cfg.redactErrors
+	res.capHeight = cfg.capHeight
+	res.wideChallenges = cfg.wideChallenges || fiatshamir.WideChallenges()
+
+	return res
+}
 
+func (s radixTwoFri) Fingerprint() [sha256.Size]byte {
+	h := sha256.New()
+	h.Write([]byte("gnark-crypto/bls24-315/fri/radix2/v1"))
+	var buf [8]byte
+	for _, v := range []uint64{uint64(RADIX_2_FRI), s.rho, uint64(s.nbRounds), uint64(s.nbSteps)} {
+		binary.BigEndian.PutUint64(buf[:], v)
+		h.Write(buf[:])
+	}
+	if s.capHeight != 0 {
+		// keep the fingerprints of the instances without cap unchanged
+		binary.BigEndian.PutUint64(buf[:], uint64(s.capHeight))
+		h.Write(buf[:])
+	}
+	if s.wideChallenges {
+		h.Write([]byte("wide challenges"))
+	}
+	d := s.domain.Fingerprint()
+	h.Write(d[:])
+	var res [sha256.Size]byte
+	h.Sum(res[:0])
 	return res
 }
 
-// convertCanonicalSorted convert the index i, an entry in a
-// sorted polynomial, to the corresponding entry in canonical
-// representation. n is the size of the polynomial.
-func convertCanonicalSorted(i, n int) int {
+// newTranscript returns a Fiat-Shamir transcript for the given challenges,
+// bound to the instance identifier if there is one.
+func (s radixTwoFri) newTranscript(challengesID ...string) *fiatshamir.Transcript {
+	if s.instanceID == nil {
+		return fiatshamir.NewTranscript(s.h, challengesID...).Record(s.recorder)
+	}
+	return fiatshamir.NewInstanceTranscript(s.h, s.instanceID, challengesID...).Record(s.recorder)
+}
+
+// computeChallenge returns the challenge challengeID of fs as a field element, see
+// WithWideChallenges.
+func (s radixTwoFri) computeChallenge(fs *fiatshamir.Transcript, challengeID string) (fr.Element, error) {
+	if s.wideChallenges {
+		return fiatshamir.ComputeChallengeFr[fr.Element](fs, challengeID)
+	}
+	var res fr.Element
+	b, err := fs.ComputeChallenge(challengeID)
+	if err != nil {
+		return res, err
+	}
+	res.SetBytes(b)
+	return res, nil
+}
+
+// roundTranscript returns the Fiat-Shamir transcript of the given round, and the
+// names of its challenges: the folding challenges xᵢ, then the seed s0 of the
+// queries positions. Each round uses an independent fork of the transcript.
+func (s radixTwoFri) roundTranscript(round int) (*fiatshamir.Transcript, []string, error) {
+	xis := make([]string, s.nbSteps+1)
+	for i := 0; i < s.nbSteps; i++ {
+		xis[i] = fmt.Sprintf("x%d", i)
+	}
+	xis[s.nbSteps] = "s0"
+	fs, err := s.newTranscript().Fork(fmt.Sprintf("round %d", round), nil, xis...)
+	return fs, xis, err
+}
+
+// CanonicalToSorted converts the index i of an evaluation on a domain of size n, in
+// natural order (the evaluation at gⁱ), to its index in sorted order, where the two
+// entries of a fiber {gⁱ, g^{i+n/2}} are contiguous (see the Merkle trees of the
+// oracles): i ↦ 2i if i < n/2, 2(i-n/2)+1 otherwise. n must be even.
+func CanonicalToSorted(i, n int) int {
 
 	if i < n/2 {
 		return 2 * i
@@ -222,6 +382,33 @@ func convertCanonicalSorted(i, n int) int {
 
 }
 
+// SortedToCanonical is the inverse of CanonicalToSorted: it converts the index i of an
+// evaluation on a domain of size n in sorted order to its index in natural order.
+func SortedToCanonical(i, n int) int {
+	if i%2 == 0 {
+		return i / 2
+	}
+	return i/2 + n/2
+}
+
+// QueriesPositions derives, from the seed s0 of a round (see DeriveQueries), the
+// positions in sorted order of the entries of the oracles that the verifier queries.
+// They are:
+//   - q₀ = seed mod |D|, where seed is read as a big-endian integer and D is the
+//     evaluation domain, of size ρ*size;
+//   - qₖ₊₁ = CanonicalToSorted(⌊qₖ/2⌋, |D|/2ᵏ⁺¹), for the following steps, since the
+//     fiber of qₖ folds to the point of index ⌊qₖ/2⌋ of the next domain.
+//
+// At step k, the verifier opens the entries qₖ and its neighbor qₖ xor 1, the other
+// element of its fiber.
+func (s radixTwoFri) QueriesPositions(seed []byte) []int {
+	var bPos, bCardinality big.Int
+	bPos.SetBytes(seed)
+	bCardinality.SetUint64(s.domain.Cardinality)
+	bPos.Mod(&bPos, &bCardinality)
+	return s.deriveQueriesPositions(int(bPos.Uint64()), int(s.domain.Cardinality))
+}
+
 // deriveQueriesPositions derives the indices of the oracle
 // function that the verifier has to pick, in sorted form.
 // * pos is the initial position, i.e. the logarithm of the first challenge
@@ -236,7 +423,7 @@ func (s radixTwoFri) deriveQueriesPositions(pos int, size int) []int {
 	res[0] = pos
 	for i := 1; i < s.nbSteps; i++ {
 		t := (res[i-1] - (res[i-1] % 2)) / 2
-		res[i] = convertCanonicalSorted(t, _s)
+		res[i] = CanonicalToSorted(t, _s)
 		_s = _s / 2
 	}
 
@@ -246,8 +433,9 @@ func (s radixTwoFri) deriveQueriesPositions(pos int, size int) []int {
 // sort orders the evaluation of a polynomial on a domain
 // such that contiguous entries are in the same fiber:
 // {q(g⁰), q(g^{n/2}), q(g¹), q(g^{1+n/2}),...,q(g^{n/2-1}), q(gⁿ⁻¹)}
-func sort(evaluations []fr.Element) []fr.Element {
-	q := make([]fr.Element, len(evaluations))
+// The result is allocated from arena.
+func sort(evaluations []fr.Element, arena *fft.Arena) []fr.Element {
+	q := arena.Alloc(len(evaluations))
 	n := len(evaluations) / 2
 	for i := 0; i < n; i++ {
 		q[2*i].Set(&evaluations[i])
@@ -264,29 +452,25 @@ func (s radixTwoFri) Open(p []fr.Element, position uint64) (OpeningProof, error)
 		return OpeningProof{}, ErrRangePosition
 	}
 
-	// put q in evaluation form
-	q := make([]fr.Element, s.domain.Cardinality)
-	copy(q, p)
-	s.domain.FFT(q, fft.DIF)
-	fft.BitReverse(q)
+	return s.open(s.commit(s.evaluate(p, nil), nil), position)
+}
 
-	// sort q to have fibers in contiguous entries. The goal is to have one
-	// Merkle path for both openings of entries which are in the same fiber.
-	q = sort(q)
+// open opens the committed polynomial at gⁱ where i = position.
+func (s radixTwoFri) open(cm *Commitment, position uint64) (OpeningProof, error) {
+
+	// check that position is in the correct range
+	if position >= s.domain.Cardinality {
+		return OpeningProof{}, ErrRangePosition
+	}
 
 	// build the Merkle proof, we the position is converted to fit the sorted polynomial
-	pos := convertCanonicalSorted(int(position), len(q))
+	pos := CanonicalToSorted(int(position), len(cm.sorted))
 
-	tree := merkletree.New(s.h)
-	err := tree.SetIndex(uint64(pos))
-	if err != nil {
-		return OpeningProof{}, err
-	}
-	for i := 0; i < len(q); i++ {
-		tree.Push(q[i].Marshal())
-	}
 	var res OpeningProof
-	res.merkleRoot, res.ProofSet, res.index, res.numLeaves = tree.Prove()
+	res.merkleRoot = cm.tree.root()
+	res.ProofSet = cm.tree.prove(uint64(pos))
+	res.index = uint64(pos)
+	res.numLeaves = uint64(len(cm.sorted))
 
 	// set the claimed value, which is the first entry of the Merkle proof
 	res.ClaimedValue.SetBytes(res.ProofSet[0])
@@ -294,6 +478,27 @@ func (s radixTwoFri) Open(p []fr.Element, position uint64) (OpeningProof, error)
 	return res, nil
 }
 
+// evaluate returns the evaluations of p, given by its coefficients, on the
+// evaluation domain, in natural order. The result is allocated from arena.
+func (s radixTwoFri) evaluate(p []fr.Element, arena *fft.Arena) []fr.Element {
+	q := arena.Alloc(int(s.domain.Cardinality))
+	copy(q, p)
+	s.domain.FFT(q, fft.DIF, fft.WithArena(arena))
+	fft.BitReverse(q)
+	return q
+}
+
+// commit sorts the evaluations to have fibers in contiguous entries, and builds
+// their Merkle tree. The goal of the sorting is to have one Merkle path for both
+// openings of entries which are in the same fiber. The sorted evaluations are
+// allocated from arena.
+func (s radixTwoFri) commit(evals []fr.Element, arena *fft.Arena) *Commitment {
+	var res Commitment
+	res.sorted = sort(evals, arena)
+	res.tree = newMerkleTree(s.h, s.newHash, res.sorted, s.capHeight)
+	return &res
+}
+
 // Verifies the opening of a polynomial.
 // * position the point at which the proof is opened (the point is gⁱ where i = position)
 // * openingProof Merkle path proof
@@ -303,6 +508,10 @@ func (s radixTwoFri) Open(p []fr.Element, position uint64) (OpeningProof, error)
 // those should be equal, if not an error is raised.
 func (s radixTwoFri) VerifyOpening(position uint64, openingProof OpeningProof, pp ProofOfProximity) error {
 
+	if len(pp.Rounds) == 0 || len(pp.Rounds[0].Interactions) == 0 {
+		return s.verificationError(ErrProofShape, 0, 0, -1, nil, nil)
+	}
+
 	// To query the Merkle path, we look at the first series of Interactions, and check whether it's the point
 	// at 'position' or its neighbor that contains the full Merkle path.
 	var fullMerkleProof int
@@ -314,17 +523,17 @@ func (s radixTwoFri) VerifyOpening(position uint64, openingProof OpeningProof, p
 
 	// check that the merkle roots coincide
 	if !bytes.Equal(openingProof.merkleRoot, pp.Rounds[0].Interactions[0][fullMerkleProof].MerkleRoot) {
-		return ErrMerkleRoot
+		return s.verificationError(ErrMerkleRoot, 0, 0, -1, pp.Rounds[0].Interactions[0][fullMerkleProof].MerkleRoot, openingProof.merkleRoot)
 	}
 
 	// convert position to the sorted version
 	sizePoly := s.domain.Cardinality
-	pos := convertCanonicalSorted(int(position), int(sizePoly))
+	pos := CanonicalToSorted(int(position), int(sizePoly))
 
 	// check the Merkle proof
-	res := merkletree.VerifyProof(s.h, openingProof.merkleRoot, openingProof.ProofSet, uint64(pos), openingProof.numLeaves)
+	res := s.verifyMerkleProof(openingProof.merkleRoot, openingProof.ProofSet, uint64(pos), openingProof.numLeaves)
 	if !res {
-		return ErrMerklePath
+		return s.verificationError(ErrMerklePath, -1, -1, pos, nil, nil)
 	}
 	return nil
 
@@ -340,14 +549,15 @@ func (s radixTwoFri) VerifyOpening(position uint64, openingProof OpeningProof, p
 // * p is the polynomial to fold, in Lagrange basis, sorted like this: p = [p(1),p(-1),p(g),p(-g),p(g²),p(-g²),...]
 // * g is a generator of the subgroup of Fᵣ^{*} of size len(p)
 // * x is the folding challenge x, used to return p₁+x*p₂
-func foldPolynomialLagrangeBasis(pSorted []fr.Element, gInv, x fr.Element) []fr.Element {
+// * arena is used to allocate the result
+func foldPolynomialLagrangeBasis(pSorted []fr.Element, gInv, x fr.Element, arena *fft.Arena) []fr.Element {
 
 	// we have the following system
 	// p₁(g²ⁱ)+gⁱp₂(g²ⁱ) = p(gⁱ)
 	// p₁(g²ⁱ)-gⁱp₂(g²ⁱ) = p(-gⁱ)
 	// we solve the system for p₁(g²ⁱ),p₂(g²ⁱ)
 	s := len(pSorted)
-	res := make([]fr.Element, s/2)
+	res := arena.Alloc(s / 2)
 
 	var p1, p2, acc fr.Element
 	acc.SetOne()
@@ -367,9 +577,10 @@ func foldPolynomialLagrangeBasis(pSorted []fr.Element, gInv, x fr.Element) []fr.
 
 // buildProofOfProximitySingleRound generates a proof that a function, given as an oracle from
 // the verifier point of view, is in fact δ-close to a polynomial.
-// * salt is a variable for multi rounds, it allows to generate different challenges using Fiat Shamir
-// * p is in evaluation form
-func (s radixTwoFri) buildProofOfProximitySingleRound(salt fr.Element, p []fr.Element) (Round, error) {
+// * round is the index of the round, each round derives independent challenges using Fiat Shamir
+// * cm is the commitment to the evaluations of p
+// * arena is used to allocate the folded polynomials
+func (s radixTwoFri) buildProofOfProximitySingleRound(round int, cm *Commitment, arena *fft.Arena) (Round, error) {
 
 	// the proof will contain nbSteps Interactions
 	var res Round
@@ -381,16 +592,7 @@ func (s radixTwoFri) buildProofOfProximitySingleRound(salt fr.Element, p []fr.El
 	// xᵢ∈ Fᵣ to the prover. The prover expresses F in Fᵣ[X,Y]/<Y-X²> as
 	// P₀(Y)+X P₁(Y) where P₀, P₁ are of degree n/2, and he then folds the polynomial
 	// by replacing x by xᵢ.
-	xis := make([]string, s.nbSteps+1)
-	for i := 0; i < s.nbSteps; i++ {
-		xis[i] = fmt.Sprintf("x%d", i)
-	}
-	xis[s.nbSteps] = "s0"
-	fs := fiatshamir.NewTranscript(s.h, xis...)
-
-	// the salt is binded to the first challenge, to ensure the challenges
-	// are different at each round.
-	err := fs.Bind(xis[0], salt.Marshal())
+	fs, xis, err := s.roundTranscript(round)
 	if err != nil {
 		return Round{}, err
 	}
@@ -398,43 +600,40 @@ func (s radixTwoFri) buildProofOfProximitySingleRound(salt fr.Element, p []fr.El
 	// step 1 : fold the polynomial using the xi
 
 	// evalsAtRound stores the list of the nbSteps polynomial evaluations, each evaluation
-	// corresponds to the evaluation o the folded polynomial at round i.
+	// corresponds to the evaluation o the folded polynomial at round i. trees stores
+	// their Merkle trees. Those of the first round are the committed ones.
 	evalsAtRound := make([][]fr.Element, s.nbSteps)
-
-	// evaluate p and sort the result
-	_p := make([]fr.Element, s.domain.Cardinality)
-	copy(_p, p)
+	trees := make([]merkleTree, s.nbSteps)
 
 	// gInv inverse of the generator of the cyclic group of size the size of the polynomial.
 	// The size of the cyclic group is ρ*s.domainSize, and not s.domainSize.
 	var gInv fr.Element
 	gInv.Set(&s.domain.GeneratorInv)
 
+	_p := cm.sorted
 	for i := 0; i < s.nbSteps; i++ {
 
-		evalsAtRound[i] = sort(_p)
-
-		// compute the root hash, needed to derive xi
-		t := merkletree.New(s.h)
-		for k := 0; k < len(_p); k++ {
-			t.Push(evalsAtRound[i][k].Marshal())
+		if i == 0 {
+			evalsAtRound[i], trees[i] = cm.sorted, cm.tree
+		} else {
+			evalsAtRound[i] = sort(_p, arena)
+			trees[i] = newMerkleTree(s.h, s.newHash, evalsAtRound[i], s.capHeight)
 		}
-		rh := t.Root()
-		err := fs.Bind(xis[i], rh)
+
+		// bind the root hash, needed to derive xi
+		err := fs.Bind(xis[i], trees[i].root())
 		if err != nil {
 			return res, err
 		}
 
 		// derive the challenge
-		bxi, err := fs.ComputeChallenge(xis[i])
+		xi, err := s.computeChallenge(fs, xis[i])
 		if err != nil {
 			return res, err
 		}
-		var xi fr.Element
-		xi.SetBytes(bxi)
 
-		// fold _p, reusing its memory
-		_p = foldPolynomialLagrangeBasis(evalsAtRound[i], gInv, xi)
+		// fold _p
+		_p = foldPolynomialLagrangeBasis(evalsAtRound[i], gInv, xi, arena)
 
 		// g <- g²
 		gInv.Square(&gInv)
@@ -457,24 +656,14 @@ func (s radixTwoFri) buildProofOfProximitySingleRound(salt fr.Element, p []fr.El
 	if err != nil {
 		return res, err
 	}
-	var bPos, bCardinality big.Int
-	bPos.SetBytes(binSeed)
-	bCardinality.SetUint64(s.domain.Cardinality)
-	bPos.Mod(&bPos, &bCardinality)
-	si := s.deriveQueriesPositions(int(bPos.Uint64()), int(s.domain.Cardinality))
+	si := s.QueriesPositions(binSeed)
 
 	for i := 0; i < s.nbSteps; i++ {
 
 		// build proofs of queries at s[i]
-		t := merkletree.New(s.h)
-		err := t.SetIndex(uint64(si[i]))
-		if err != nil {
-			return res, err
-		}
-		for k := 0; k < len(evalsAtRound[i]); k++ {
-			t.Push(evalsAtRound[i][k].Marshal())
-		}
-		mr, ProofSet, _, numLeaves := t.Prove()
+		mr := trees[i].root()
+		ProofSet := trees[i].prove(uint64(si[i]))
+		numLeaves := uint64(len(evalsAtRound[i]))
 
 		// c denotes the entry that contains the full Merkle proof. The entry 1-c will
 		// only contain 2 elements, which are the neighbor point, and the hash of the
@@ -488,12 +677,7 @@ func (s radixTwoFri) buildProofOfProximitySingleRound(salt fr.Element, p []fr.El
 			numLeaves,
 		}
 		res.Interactions[i][1-c].ProofSet[0] = evalsAtRound[i][si[i]+1-2*c].Marshal()
-		s.h.Reset()
-		_, err = s.h.Write(res.Interactions[i][c].ProofSet[0])
-		if err != nil {
-			return res, err
-		}
-		res.Interactions[i][1-c].ProofSet[1] = s.h.Sum(nil)
+		res.Interactions[i][1-c].ProofSet[1] = trees[i].nodes[0][si[i]]
 
 	}
 
@@ -504,85 +688,159 @@ func (s radixTwoFri) buildProofOfProximitySingleRound(salt fr.Element, p []fr.El
 // BuildProofOfProximity generates a proof that a function, given as an oracle from
 // the verifier point of view, is in fact δ-close to a polynomial.
 func (s radixTwoFri) BuildProofOfProximity(p []fr.Element) (ProofOfProximity, error) {
+	return s.buildProofOfProximity(s.commit(s.evaluate(p, nil), nil), nil)
+}
+
+// BuildProofOfProximityWithMemory is like BuildProofOfProximity, but allocates its
+// scratch buffers from mem. The proof doesn't reference mem.
+func (s radixTwoFri) BuildProofOfProximityWithMemory(p []fr.Element, mem *ProverMemory) (ProofOfProximity, error) {
+	arena := mem.Arena()
+	return s.buildProofOfProximity(s.commit(s.evaluate(p, arena), arena), arena)
+}
+
+// BuildProofOfProximityFromEvaluations generates a proof that a function, given by its
+// evaluations evals[i] = p(gⁱ) on the evaluation domain, is in fact δ-close to a polynomial.
+func (s radixTwoFri) BuildProofOfProximityFromEvaluations(evals []fr.Element) (ProofOfProximity, error) {
+	if uint64(len(evals)) != s.domain.Cardinality {
+		return ProofOfProximity{}, ErrEvaluationsSize
+	}
+	return s.buildProofOfProximity(s.commit(evals, nil), nil)
+}
+
+// buildProofOfProximity builds the nbRounds rounds of the proof of proximity of the
+// committed polynomial. The scratch buffers are allocated from arena.
+func (s radixTwoFri) buildProofOfProximity(cm *Commitment, arena *fft.Arena) (ProofOfProximity, error) {
 
 	// the proof will contain nbSteps Interactions
 	var proof ProofOfProximity
-	proof.Rounds = make([]Round, nbRounds)
-
-	// evaluate p
-	// evaluate p and sort the result
-	_p := make([]fr.Element, s.domain.Cardinality)
-	copy(_p, p)
-	s.domain.FFT(_p, fft.DIF)
-	fft.BitReverse(_p)
+	proof.Rounds = make([]Round, s.nbRounds)
 
 	var err error
-	var salt, one fr.Element
-	one.SetOne()
-	for i := 0; i < nbRounds; i++ {
-		proof.Rounds[i], err = s.buildProofOfProximitySingleRound(salt, _p)
+	for i := 0; i < s.nbRounds; i++ {
+		proof.Rounds[i], err = s.buildProofOfProximitySingleRound(i, cm, arena)
 		if err != nil {
 			return proof, err
 		}
-		salt.Add(&salt, &one)
 	}
 
 	return proof, nil
 }
 
-// verifyProofOfProximitySingleRound verifies the proof of proximity. It returns an error if the
-// verification fails.
-func (s radixTwoFri) verifyProofOfProximitySingleRound(salt fr.Element, proof Round) error {
+// DeriveQueries derives the folding challenges xᵢ and the positions of the queries
+// (in sorted form) of the given round of a proof, exactly as the verifier does.
+//
+// The challenges are drawn from a fork of the transcript labeled "round <round>",
+// whose challenges are x0, …, x{nbSteps-1}, then s0:
+//   - xᵢ is bound to the Merkle root (or cap) of the i-th folded oracle,
+//     proof.Interactions[i][0].MerkleRoot, and reduced modulo r (see WithWideChallenges);
+//   - s0 is bound to proof.Evaluation.Marshal(), and the positions are derived from
+//     its raw bytes with QueriesPositions.
+func (s radixTwoFri) DeriveQueries(round int, proof Round) ([]fr.Element, []int, error) {
 
 	// Fiat Shamir transcript to derive the challenges
-	xis := make([]string, s.nbSteps+1)
-	for i := 0; i < s.nbSteps; i++ {
-		xis[i] = fmt.Sprintf("x%d", i)
+	fs, xis, err := s.roundTranscript(round)
+	if err != nil {
+		return nil, nil, err
 	}
-	xis[s.nbSteps] = "s0"
-	fs := fiatshamir.NewTranscript(s.h, xis...)
 
 	xi := make([]fr.Element, s.nbSteps)
 
-	// the salt is binded to the first challenge, to ensure the challenges
-	// are different at each round.
-	err := fs.Bind(xis[0], salt.Marshal())
-	if err != nil {
-		return err
-	}
-
 	for i := 0; i < s.nbSteps; i++ {
 		err := fs.Bind(xis[i], proof.Interactions[i][0].MerkleRoot)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
-		bxi, err := fs.ComputeChallenge(xis[i])
+		xi[i], err = s.computeChallenge(fs, xis[i])
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
-		xi[i].SetBytes(bxi)
 	}
 
 	// derive the verifier queries
-	// for i := 0; i < len(proof.evaluation); i++ {
-	// 	err := fs.Bind(xis[s.nbSteps], proof.evaluation[i].Marshal())
-	// 	if err != nil {
-	// 		return err
-	// 	}
-	// }
 	err = fs.Bind(xis[s.nbSteps], proof.Evaluation.Marshal())
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 	binSeed, err := fs.ComputeChallenge(xis[s.nbSteps])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return xi, s.QueriesPositions(binSeed), nil
+}
+
+// verifyFiberOpening verifies the Merkle proofs of the two entries of a fiber, the one at
+// position pos (in sorted form) and its neighbor. round and step locate the fiber in
+// the proof, for the errors.
+func (s radixTwoFri) verifyFiberOpening(interaction [2]MerkleProof, pos, round, step int) error {
+
+	// c is the entry containing the full Merkle proof.
+	c := pos % 2
+	if len(interaction[c].ProofSet) < 2 || len(interaction[1-c].ProofSet) != 2 {
+		return s.verificationError(ErrMerklePath, round, step, pos, nil, nil)
+	}
+	res := s.verifyMerkleProof(
+		interaction[c].MerkleRoot,
+		interaction[c].ProofSet,
+		uint64(pos),
+		interaction[c].numLeaves,
+	)
+	if !res {
+		return s.verificationError(ErrMerklePath, round, step, pos, nil, nil)
+	}
+
+	// we verify the Merkle proof for the neighbor query, to do that we have
+	// to pick the full Merkle proof of the first entry, stripped off of the leaf and
+	// the first node. We replace the leaf and the first node by the leaf and the first
+	// node of the partial Merkle proof, since the leaf and the first node of both proofs
+	// are the only entries that differ.
+	ProofSet := make([][]byte, len(interaction[c].ProofSet))
+	copy(ProofSet[2:], interaction[c].ProofSet[2:])
+	ProofSet[0] = interaction[1-c].ProofSet[0]
+	ProofSet[1] = interaction[1-c].ProofSet[1]
+	res = s.verifyMerkleProof(
+		interaction[1-c].MerkleRoot,
+		ProofSet,
+		uint64(pos+1-2*c),
+		interaction[1-c].numLeaves,
+	)
+	if !res {
+		return s.verificationError(ErrMerklePath, round, step, pos+1-2*c, nil, nil)
+	}
+	return nil
+}
+
+// verifyMerkleProof verifies the proof set of the leaf at index in a tree of numLeaves
+// leaves, committed with root: its Merkle root, or the concatenation of the nodes of
+// its Merkle cap, in which case the proof set stops below the node of the cap.
+func (s radixTwoFri) verifyMerkleProof(root []byte, proofSet [][]byte, index, numLeaves uint64) bool {
+	nbNodes := uint64(1) << s.capHeight
+	hashSize := s.h.Size()
+	if len(root) != int(nbNodes)*hashSize || numLeaves%nbNodes != 0 || numLeaves < 2*nbNodes {
+		return false
+	}
+
+	// the node of the cap is the root of a subtree of numLeaves/2ʰ leaves
+	subtreeLeaves := numLeaves / nbNodes
+	node := index / subtreeLeaves
+	if node >= nbNodes {
+		return false
+	}
+	return merkletree.VerifyProof(s.h, root[int(node)*hashSize:int(node+1)*hashSize], proofSet, index%subtreeLeaves, subtreeLeaves)
+}
+
+// verifyProofOfProximitySingleRound verifies the proof of proximity. It returns an error if the
+// verification fails.
+func (s radixTwoFri) verifyProofOfProximitySingleRound(round int, proof Round) error {
+
+	if len(proof.Interactions) != s.nbSteps {
+		return s.verificationError(ErrProofShape, round, -1, -1, s.nbSteps, len(proof.Interactions))
+	}
+
+	xi, si, err := s.DeriveQueries(round, proof)
 	if err != nil {
 		return err
 	}
-	var bPos, bCardinality big.Int
-	bPos.SetBytes(binSeed)
-	bCardinality.SetUint64(s.domain.Cardinality)
-	bPos.Mod(&bPos, &bCardinality)
-	si := s.deriveQueriesPositions(int(bPos.Uint64()), int(s.domain.Cardinality))
 
 	// for each round check the Merkle proof and the correctness of the folding
 
@@ -591,38 +849,18 @@ func (s radixTwoFri) verifyProofOfProximitySingleRound(salt fr.Element, proof Ro
 	accGInv.Set(&s.domain.GeneratorInv)
 	for i := 0; i < s.nbSteps; i++ {
 
-		// correctness of Merkle proof
-		// c is the entry containing the full Merkle proof.
-		c := si[i] % 2
-		res := merkletree.VerifyProof(
-			s.h,
-			proof.Interactions[i][c].MerkleRoot,
-			proof.Interactions[i][c].ProofSet,
-			uint64(si[i]),
-			proof.Interactions[i][c].numLeaves,
-		)
-		if !res {
-			return ErrMerklePath
+		// the oracle of the i-th step has ρ⋅n/2ⁱ entries; the number of leaves is part
+		// of the serialized proof, so it is checked against the parameters
+		numLeaves := s.domain.Cardinality >> i
+		for c := 0; c < 2; c++ {
+			if proof.Interactions[i][c].numLeaves != numLeaves {
+				return s.verificationError(ErrProofShape, round, i, -1, numLeaves, proof.Interactions[i][c].numLeaves)
+			}
 		}
 
-		// we verify the Merkle proof for the neighbor query, to do that we have
-		// to pick the full Merkle proof of the first entry, stripped off of the leaf and
-		// the first node. We replace the leaf and the first node by the leaf and the first
-		// node of the partial Merkle proof, since the leaf and the first node of both proofs
-		// are the only entries that differ.
-		ProofSet := make([][]byte, len(proof.Interactions[i][c].ProofSet))
-		copy(ProofSet[2:], proof.Interactions[i][c].ProofSet[2:])
-		ProofSet[0] = proof.Interactions[i][1-c].ProofSet[0]
-		ProofSet[1] = proof.Interactions[i][1-c].ProofSet[1]
-		res = merkletree.VerifyProof(
-			s.h,
-			proof.Interactions[i][1-c].MerkleRoot,
-			ProofSet,
-			uint64(si[i]+1-2*c),
-			proof.Interactions[i][1-c].numLeaves,
-		)
-		if !res {
-			return ErrMerklePath
+		// correctness of Merkle proofs
+		if err := s.verifyFiberOpening(proof.Interactions[i], si[i], round, i); err != nil {
+			return err
 		}
 
 		// correctness of the folding
@@ -648,7 +886,7 @@ func (s radixTwoFri) verifyProofOfProximitySingleRound(salt fr.Element, proof Ro
 			fn.SetBytes(proof.Interactions[i+1][si[i+1]%2].ProofSet[0])
 
 			if !fo.Equal(&fn) {
-				return ErrProximityTestFolding
+				return s.verificationError(ErrProximityTestFolding, round, i, si[i+1], &fo, &fn)
 			}
 
 			// next inverse generator
@@ -674,7 +912,7 @@ func (s radixTwoFri) verifyProofOfProximitySingleRound(salt fr.Element, proof Ro
 	// Last step: the final evaluation should be the evaluation of a degree 0 polynomial,
 	// so it must be constant.
 	if !fo.Equal(&proof.Evaluation) {
-		return ErrProximityTestFolding
+		return s.verificationError(ErrProximityTestFolding, round, s.nbSteps-1, si[s.nbSteps-1], &fo, &proof.Evaluation)
 	}
 
 	return nil
@@ -684,14 +922,14 @@ func (s radixTwoFri) verifyProofOfProximitySingleRound(salt fr.Element, proof Ro
 // by one.
 func (s radixTwoFri) VerifyProofOfProximity(proof ProofOfProximity) error {
 
-	var salt, one fr.Element
-	one.SetOne()
-	for i := 0; i < nbRounds; i++ {
-		err := s.verifyProofOfProximitySingleRound(salt, proof.Rounds[i])
+	if len(proof.Rounds) != s.nbRounds {
+		return s.verificationError(ErrProofShape, -1, -1, -1, s.nbRounds, len(proof.Rounds))
+	}
+	for i := 0; i < s.nbRounds; i++ {
+		err := s.verifyProofOfProximitySingleRound(i, proof.Rounds[i])
 		if err != nil {
 			return err
 		}
-		salt.Add(&salt, &one)
 	}
 	return nil
 
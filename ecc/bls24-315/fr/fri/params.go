@@ -0,0 +1,145 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fri
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+)
+
+// ErrNoParameters is returned by ParametersForSecurity when no parameters reach the
+// requested security level within the proof size bound.
+var ErrNoParameters = errors.New("no fri parameters reach the security level within the proof size")
+
+// maxLogRho bounds the blow-up factors tried by ParametersForSecurity. The prover
+// cost is linear in ρ, larger factors are rarely worth it.
+const maxLogRho = 8
+
+// Parameters are the parameters of a FRI instance, as returned by ParametersForSecurity.
+type Parameters struct {
+	// Rho is the blow-up factor, see WithRho.
+	Rho uint64
+
+	// NbRounds is the number of rounds, see WithNbRounds.
+	NbRounds int
+
+	// ProofSize is the estimated size of a proof of proximity, see EstimateProofSize.
+	ProofSize uint64
+
+	// SecurityBits is the estimated soundness of the proof of proximity, in bits.
+	SecurityBits float64
+}
+
+// Options returns the options configuring a FRI instance with p.
+func (p Parameters) Options() []Option {
+	return []Option{WithRho(p.Rho), WithNbRounds(p.NbRounds)}
+}
+
+// EstimateProofSize returns the size in bytes of a proof of proximity for polynomials
+// of the given size, with blow-up factor rho, nbRounds rounds and a hash function
+// whose digests are hashSize bytes long.
+//
+// The size is the one of the field elements and digests carried by the proof, each
+// Merkle root counted once per step, without Merkle cap (see WithMerkleCapHeight). The
+// overhead of an encoding (lengths, number of leaves) is not included.
+func EstimateProofSize(size, rho uint64, nbRounds, hashSize int) (uint64, error) {
+	if hashSize <= 0 {
+		return 0, fmt.Errorf("%w: hashSize must be positive, got %d", ErrInvalidConfig, hashSize)
+	}
+	cfg := config{rho: rho, nbRounds: nbRounds}
+	if err := cfg.validate(size); err != nil {
+		return 0, err
+	}
+	return proofSize(size, rho, nbRounds, hashSize), nil
+}
+
+// ParametersForSecurity returns the parameters of a FRI instance for polynomials of the
+// given size, reaching securityBits bits of soundness with a proof of at most
+// maxProofSize bytes (0 means no bound), hashed with a hash function whose digests are
+// hashSize bytes long.
+//
+// Among the blow-up factors ρ = 2, 4, …, 2⁸ it picks the smallest one, that is the
+// cheapest prover, with which the proof fits in maxProofSize. It returns
+// ErrNoParameters if there is none.
+//
+// The soundness is estimated from the Johnson bound: each round, which queries one
+// position, contributes log₂(ρ)/2 bits. It is capped by the folding error, about
+// log₂(|fr|) - log₂(ρ·size·nbSteps) bits. Grinding is not taken into account.
+func ParametersForSecurity(size uint64, hashSize int, securityBits int, maxProofSize uint64) (Parameters, error) {
+	if securityBits <= 0 {
+		return Parameters{}, fmt.Errorf("%w: securityBits must be positive, got %d", ErrInvalidConfig, securityBits)
+	}
+	for logRho := 1; logRho <= maxLogRho; logRho++ {
+		rho := uint64(1) << logRho
+		nbRounds := (2*securityBits + logRho - 1) / logRho
+		proofSize, err := EstimateProofSize(size, rho, nbRounds, hashSize)
+		if err != nil {
+			if logRho == 1 {
+				return Parameters{}, err
+			}
+			// the domain is too large for the field
+			break
+		}
+		security := securityLevel(size, rho, nbRounds)
+		if security < float64(securityBits) {
+			// larger ρ only make the folding error worse
+			break
+		}
+		if maxProofSize == 0 || proofSize <= maxProofSize {
+			return Parameters{
+				Rho:          rho,
+				NbRounds:     nbRounds,
+				ProofSize:    proofSize,
+				SecurityBits: security,
+			}, nil
+		}
+	}
+	return Parameters{}, fmt.Errorf("%w: %d bits, %d bytes", ErrNoParameters, securityBits, maxProofSize)
+}
+
+// proofSize is EstimateProofSize for valid parameters.
+func proofSize(size, rho uint64, nbRounds, hashSize int) uint64 {
+	n := ecc.NextPowerOfTwo(size)
+	nbSteps := bits.TrailingZeros64(n)
+	depth := nbSteps + bits.TrailingZeros64(rho)
+
+	// at step i, the Merkle tree has 2^(depth-i) leaves: the proof contains its root,
+	// the two queried evaluations, and the path of one of them plus the hash of the other.
+	var round uint64
+	for i := 0; i < nbSteps; i++ {
+		round += 2*fr.Bytes + uint64(depth-i+2)*uint64(hashSize)
+	}
+	// evaluation of the fully folded polynomial
+	round += fr.Bytes
+
+	return uint64(nbRounds) * round
+}
+
+// securityLevel estimates the soundness of a proof of proximity in bits, see
+// ParametersForSecurity.
+func securityLevel(size, rho uint64, nbRounds int) float64 {
+	n := ecc.NextPowerOfTwo(size)
+	nbSteps := bits.TrailingZeros64(n)
+	query := float64(nbRounds) * float64(bits.TrailingZeros64(rho)) / 2
+	folding := float64(fr.Bits) - math.Log2(float64(n*rho)) - math.Log2(float64(max(nbSteps, 1)))
+	return math.Min(query, folding)
+}
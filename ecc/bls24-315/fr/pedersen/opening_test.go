@@ -0,0 +1,123 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package pedersen
+
+import (
+	"crypto/sha256"
+	mrand "math/rand"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls24-315"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpeningProof(t *testing.T) {
+	assert := require.New(t)
+
+	const nbElem = 5
+	basis := randomG1Slice(t, nbElem)
+	pk, _, err := Setup([][]curve.G1Affine{basis})
+	assert.NoError(err)
+
+	values := interfaceSliceToFrSlice(t, randomFrSlice(t, nbElem)...)
+	commitment, err := pk[0].Commit(values)
+	assert.NoError(err)
+
+	proof, err := pk[0].ProveOpening(values, commitment, sha256.New(), []byte("context"))
+	assert.NoError(err)
+	assert.NoError(pk[0].VerifyOpening(commitment, proof, sha256.New(), []byte("context")))
+
+	// the proof is bound to the transcript
+	assert.Error(pk[0].VerifyOpening(commitment, proof, sha256.New(), []byte("other context")))
+
+	// and to the commitment
+	var other curve.G1Affine
+	other.Double(&commitment)
+	assert.Error(pk[0].VerifyOpening(other, proof, sha256.New(), []byte("context")))
+
+	// wrong values are not accepted
+	values[0].SetOne()
+	proof, err = pk[0].ProveOpening(values, commitment, sha256.New())
+	assert.NoError(err)
+	assert.Error(pk[0].VerifyOpening(commitment, proof, sha256.New()))
+}
+
+func TestDeterministicProver(t *testing.T) {
+	assert := require.New(t)
+
+	const nbElem = 4
+	basis := randomG1Slice(t, nbElem)
+	values := interfaceSliceToFrSlice(t, randomFrSlice(t, nbElem)...)
+
+	// with the same seed, the setups and the proofs are identical
+	var pks [2][]ProvingKey
+	var vks [2]VerifyingKey
+	var proofs [2]OpeningProof
+	for i := range pks {
+		var err error
+		pks[i], vks[i], err = Setup([][]curve.G1Affine{basis}, WithRandomness(mrand.New(mrand.NewSource(1))))
+		assert.NoError(err)
+		commitment, err := pks[i][0].Commit(values)
+		assert.NoError(err)
+		proofs[i], err = pks[i][0].ProveOpeningWithRandomness(mrand.New(mrand.NewSource(2)), values, commitment, sha256.New())
+		assert.NoError(err)
+		assert.NoError(pks[i][0].VerifyOpening(commitment, proofs[i], sha256.New()))
+	}
+	assert.Equal(pks[0], pks[1])
+	assert.Equal(vks[0], vks[1])
+	assert.Equal(proofs[0], proofs[1])
+
+	// the seed matters
+	pk, _, err := Setup([][]curve.G1Affine{basis}, WithRandomness(mrand.New(mrand.NewSource(3))))
+	assert.NoError(err)
+	assert.NotEqual(pks[0], pk)
+}
+
+func TestCombineCommitments(t *testing.T) {
+	assert := require.New(t)
+
+	const nbElem, nbCommitments = 4, 3
+	basis := randomG1Slice(t, nbElem)
+	pk, _, err := Setup([][]curve.G1Affine{basis})
+	assert.NoError(err)
+
+	values := make([][]fr.Element, nbCommitments)
+	commitments := make([]curve.G1Affine, nbCommitments)
+	coeffs := interfaceSliceToFrSlice(t, randomFrSlice(t, nbCommitments)...)
+	for i := range values {
+		values[i] = interfaceSliceToFrSlice(t, randomFrSlice(t, nbElem)...)
+		commitments[i], err = pk[0].Commit(values[i])
+		assert.NoError(err)
+	}
+
+	combined, err := CombineCommitments(commitments, coeffs)
+	assert.NoError(err)
+	combinedValues, err := CombineValues(values, coeffs)
+	assert.NoError(err)
+	expected, err := pk[0].Commit(combinedValues)
+	assert.NoError(err)
+	assert.True(expected.Equal(&combined))
+
+	// the combined values open the combined commitment
+	proof, err := pk[0].ProveOpening(combinedValues, combined, sha256.New())
+	assert.NoError(err)
+	assert.NoError(pk[0].VerifyOpening(combined, proof, sha256.New()))
+
+	_, err = CombineValues(values, coeffs[1:])
+	assert.Error(err)
+}
@@ -0,0 +1,551 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package permutation
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/grandproduct"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/kzg"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+var (
+	ErrCopyConstraintsSize         = errors.New("the trace should have at least one column and a power of 2 number of rows")
+	ErrInvalidCell                 = errors.New("cell out of the bounds of the trace")
+	ErrTraceSize                   = errors.New("the columns of the trace should have the size of the copy constraints")
+	ErrCopyConstraintsNotSatisfied = errors.New("the trace does not satisfy the copy constraints")
+	ErrCopyConstraintsProof        = errors.New("copy constraints proof verification failed")
+)
+
+// Cell identifies an entry of a trace made of columns of the same size.
+type Cell struct {
+	Column, Row int
+}
+
+// CopyConstraints is a permutation σ of the cells of a trace, whose cycles are the sets
+// of cells that must hold the same value (Plonk copy constraints).
+type CopyConstraints struct {
+	nbColumns, size int
+
+	// sigma[c*size+r] is the index of σ(Cell{c, r})
+	sigma []int
+}
+
+// CopyConstraintsCommitment is the preprocessed data needed to verify copy constraints proofs:
+// the commitments to the polynomials Sσⱼ, where Sσⱼ(ωʳ) = kₖ⋅ωˢ if σ(Cell{j, r}) = Cell{k, s}.
+type CopyConstraintsCommitment struct {
+	size  int
+	sigma []kzg.Digest
+}
+
+// CopyConstraintsProof proof that a committed trace satisfies copy constraints.
+type CopyConstraintsProof struct {
+
+	// commitments to the columns of the trace
+	columns []kzg.Digest
+
+	// commitments to the accumulation polynomial and to the quotient
+	z, h kzg.Digest
+
+	// opening proofs of the columns, Sσ, z, h (in that order) at ζ
+	batchedProof kzg.BatchOpeningProof
+
+	// opening proof of z at ω⋅ζ
+	shiftedProof kzg.OpeningProof
+}
+
+// NewCopyConstraints returns the copy constraints on a trace of nbColumns columns of size rows,
+// size being a power of 2, such that the cells of each pair of copies hold the same value.
+//
+// The pairs are first merged in equivalence classes, each of which becomes a single cycle of σ,
+// so that the permutation has as few non trivial cycles as possible. Unconstrained cells
+// are fixed points of σ.
+func NewCopyConstraints(nbColumns, size int, copies [][2]Cell) (*CopyConstraints, error) {
+	if nbColumns < 1 || size < 1 || size&(size-1) != 0 {
+		return nil, ErrCopyConstraintsSize
+	}
+	nbCells := nbColumns * size
+	index := func(c Cell) (int, error) {
+		if c.Column < 0 || c.Column >= nbColumns || c.Row < 0 || c.Row >= size {
+			return 0, ErrInvalidCell
+		}
+		return c.Column*size + c.Row, nil
+	}
+
+	// union find on the cells
+	parent := make([]int, nbCells)
+	for i := range parent {
+		parent[i] = i
+	}
+	find := func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+	for _, pair := range copies {
+		a, err := index(pair[0])
+		if err != nil {
+			return nil, err
+		}
+		b, err := index(pair[1])
+		if err != nil {
+			return nil, err
+		}
+		ra, rb := find(a), find(b)
+		if ra < rb {
+			parent[rb] = ra
+		} else {
+			parent[ra] = rb
+		}
+	}
+
+	// each class is a cycle, going through its cells in increasing order
+	res := &CopyConstraints{nbColumns: nbColumns, size: size, sigma: make([]int, nbCells)}
+	last := make([]int, nbCells)
+	for i := range last {
+		last[i] = -1
+	}
+	for i := 0; i < nbCells; i++ {
+		r := find(i)
+		if last[r] == -1 {
+			res.sigma[i] = i
+		} else {
+			res.sigma[i] = res.sigma[last[r]]
+			res.sigma[last[r]] = i
+		}
+		last[r] = i
+	}
+
+	return res, nil
+}
+
+// NbColumns returns the number of columns of the trace.
+func (c *CopyConstraints) NbColumns() int {
+	return c.nbColumns
+}
+
+// Size returns the number of rows of the trace.
+func (c *CopyConstraints) Size() int {
+	return c.size
+}
+
+// IsSatisfied returns true if the cells of each cycle of σ hold the same value in columns.
+func (c *CopyConstraints) IsSatisfied(columns []fr.Vector) bool {
+	if c.checkTrace(columns) != nil {
+		return false
+	}
+	for i, j := range c.sigma {
+		if !columns[i/c.size][i%c.size].Equal(&columns[j/c.size][j%c.size]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *CopyConstraints) checkTrace(columns []fr.Vector) error {
+	if len(columns) != c.nbColumns {
+		return ErrTraceSize
+	}
+	for i := range columns {
+		if len(columns[i]) != c.size {
+			return ErrTraceSize
+		}
+	}
+	return nil
+}
+
+// sigmaCanonical returns the polynomials Sσⱼ in canonical basis.
+func (c *CopyConstraints) sigmaCanonical(domain *fft.Domain) [][]fr.Element {
+	shifts := cosetShifts(c.nbColumns)
+	powers := make([]fr.Element, c.size)
+	powers[0].SetOne()
+	for i := 1; i < c.size; i++ {
+		powers[i].Mul(&powers[i-1], &domain.Generator)
+	}
+
+	res := make([][]fr.Element, c.nbColumns)
+	for j := range res {
+		res[j] = make([]fr.Element, c.size)
+		for r := range res[j] {
+			s := c.sigma[j*c.size+r]
+			res[j][r].Mul(&shifts[s/c.size], &powers[s%c.size])
+		}
+		domain.FFTInverse(res[j], fft.DIF)
+		fft.BitReverse(res[j])
+	}
+	return res
+}
+
+// Commit returns the commitments to the polynomials Sσⱼ, used to verify proofs.
+func (c *CopyConstraints) Commit(pk kzg.ProvingKey) (CopyConstraintsCommitment, error) {
+	return c.commit(c.sigmaCanonical(fft.NewDomain(uint64(c.size))), pk)
+}
+
+func (c *CopyConstraints) commit(cSigma [][]fr.Element, pk kzg.ProvingKey) (CopyConstraintsCommitment, error) {
+	res := CopyConstraintsCommitment{size: c.size, sigma: make([]kzg.Digest, c.nbColumns)}
+	var err error
+	for j := range cSigma {
+		if res.sigma[j], err = kzg.Commit(cSigma[j], pk); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// cosetShifts returns k₀ = 1, k₁ = u, .., kₙ₋₁ = uⁿ⁻¹ where u generates fr*, so that the
+// cosets kⱼ⋅<ω> used to label the cells of each column are disjoint.
+func cosetShifts(n int) []fr.Element {
+	u := fft.GeneratorFullMultiplicativeGroup()
+	res := make([]fr.Element, n)
+	res[0].SetOne()
+	for i := 1; i < n; i++ {
+		res[i].Mul(&res[i-1], &u)
+	}
+	return res
+}
+
+// ProveCopyConstraints generates a proof that columns satisfies the copy constraints c,
+// that is ∏ⱼ,ᵣ (wⱼ(ωʳ) + β⋅kⱼωʳ + γ) = ∏ⱼ,ᵣ (wⱼ(ωʳ) + β⋅Sσⱼ(ωʳ) + γ) for random β, γ.
+func ProveCopyConstraints(pk kzg.ProvingKey, c *CopyConstraints, columns []fr.Vector) (CopyConstraintsProof, error) {
+
+	var proof CopyConstraintsProof
+	var err error
+
+	if err = c.checkTrace(columns); err != nil {
+		return proof, err
+	}
+	if !c.IsSatisfied(columns) {
+		return proof, ErrCopyConstraintsNotSatisfied
+	}
+
+	nbColumns := c.nbColumns
+	domain := fft.NewDomain(uint64(c.size))
+	n := int(domain.Cardinality)
+
+	// hash function for Fiat Shamir
+	hFunc := sha256.New()
+
+	// transcript to derive the challenges
+	fs := fiatshamir.NewTranscript(hFunc, "beta", "gamma", "alpha", "zeta")
+
+	// commit to Sσ and to the columns
+	cSigma := c.sigmaCanonical(domain)
+	sigma, err := c.commit(cSigma, pk)
+	if err != nil {
+		return proof, err
+	}
+	cw := make([][]fr.Element, nbColumns)
+	proof.columns = make([]kzg.Digest, nbColumns)
+	toBind := make([]*bls24315.G1Affine, 0, 2*nbColumns)
+	for j := range sigma.sigma {
+		toBind = append(toBind, &sigma.sigma[j])
+	}
+	for j := range cw {
+		cw[j] = make([]fr.Element, n)
+		copy(cw[j], columns[j])
+		domain.FFTInverse(cw[j], fft.DIF)
+		fft.BitReverse(cw[j])
+		if proof.columns[j], err = kzg.Commit(cw[j], pk); err != nil {
+			return proof, err
+		}
+		toBind = append(toBind, &proof.columns[j])
+	}
+
+	// derive β, γ
+	beta, err := deriveRandomness(fs, "beta", toBind...)
+	if err != nil {
+		return proof, err
+	}
+	gamma, err := deriveRandomness(fs, "gamma")
+	if err != nil {
+		return proof, err
+	}
+
+	// compute Z and commit it
+	shifts := cosetShifts(nbColumns)
+	powers := make([]fr.Element, n)
+	powers[0].SetOne()
+	for i := 1; i < n; i++ {
+		powers[i].Mul(&powers[i-1], &domain.Generator)
+	}
+	num := make([]fr.Element, n)
+	den := make([]fr.Element, n)
+	var u fr.Element
+	for r := 0; r < n; r++ {
+		num[r].SetOne()
+		den[r].SetOne()
+		for j := 0; j < nbColumns; j++ {
+			u.Mul(&shifts[j], &powers[r]).
+				Mul(&u, &beta).
+				Add(&u, &columns[j][r]).
+				Add(&u, &gamma)
+			num[r].Mul(&num[r], &u)
+
+			s := c.sigma[j*n+r]
+			u.Mul(&shifts[s/n], &powers[s%n]).
+				Mul(&u, &beta).
+				Add(&u, &columns[j][r]).
+				Add(&u, &gamma)
+			den[r].Mul(&den[r], &u)
+		}
+	}
+	lz, err := grandproduct.Build(num, den)
+	if err != nil {
+		return proof, err
+	}
+	cz := lz
+	domain.FFTInverse(cz, fft.DIF)
+	fft.BitReverse(cz)
+	if proof.z, err = kzg.Commit(cz, pk); err != nil {
+		return proof, err
+	}
+
+	// derive the challenge used for the folding
+	alpha, err := deriveRandomness(fs, "alpha", &proof.z)
+	if err != nil {
+		return proof, err
+	}
+
+	// compute the quotient and commit it
+	ch := computeCopyConstraintsQuotient(cw, cSigma, cz, shifts, beta, gamma, alpha, domain)
+	if proof.h, err = kzg.Commit(ch, pk); err != nil {
+		return proof, err
+	}
+
+	// derive the evaluation challenge
+	zeta, err := deriveRandomness(fs, "zeta", &proof.h)
+	if err != nil {
+		return proof, err
+	}
+
+	// compute the opening proofs
+	polynomials := make([][]fr.Element, 0, 2*nbColumns+2)
+	polynomials = append(polynomials, cw...)
+	polynomials = append(polynomials, cSigma...)
+	polynomials = append(polynomials, cz, ch)
+	proof.batchedProof, err = kzg.BatchOpenSinglePoint(polynomials, proof.digests(sigma), zeta, hFunc, pk)
+	if err != nil {
+		return proof, err
+	}
+
+	zeta.Mul(&zeta, &domain.Generator)
+	proof.shiftedProof, err = kzg.Open(cz, zeta, pk)
+	if err != nil {
+		return proof, err
+	}
+
+	return proof, nil
+}
+
+// computeCopyConstraintsQuotient returns h in canonical basis, where
+//
+//	h⋅(Xⁿ-1) = Z(ωX)⋅∏ⱼ(wⱼ + β⋅Sσⱼ + γ) - Z⋅∏ⱼ(wⱼ + β⋅kⱼX + γ) + α⋅n⋅L₀⋅(Z-1)
+//
+// cw, cSigma, cz are the columns, Sσ and Z in canonical basis.
+func computeCopyConstraintsQuotient(cw, cSigma [][]fr.Element, cz []fr.Element, shifts []fr.Element, beta, gamma, alpha fr.Element, domain *fft.Domain) []fr.Element {
+
+	nbColumns := len(cw)
+	n := int(domain.Cardinality)
+	domainBig := fft.NewDomain(uint64((nbColumns + 1) * n))
+	s := int(domainBig.Cardinality)
+	ratio := s / n
+
+	evaluateOnCoset := func(p []fr.Element) []fr.Element {
+		res := make([]fr.Element, s)
+		copy(res, p)
+		domainBig.FFT(res, fft.DIF, fft.OnCoset())
+		fft.BitReverse(res)
+		return res
+	}
+	ew := make([][]fr.Element, nbColumns)
+	eSigma := make([][]fr.Element, nbColumns)
+	for j := 0; j < nbColumns; j++ {
+		ew[j] = evaluateOnCoset(cw[j])
+		eSigma[j] = evaluateOnCoset(cSigma[j])
+	}
+	ez := evaluateOnCoset(cz)
+
+	// xⁿ-1 on the coset only takes ratio values
+	var one, acc, step fr.Element
+	one.SetOne()
+	bn := big.NewInt(int64(n))
+	xnMinusOne := make([]fr.Element, ratio)
+	acc.Exp(domainBig.FrMultiplicativeGen, bn)
+	step.Exp(domainBig.Generator, bn)
+	for i := 0; i < ratio; i++ {
+		xnMinusOne[i].Sub(&acc, &one)
+		acc.Mul(&acc, &step)
+	}
+	xnMinusOneInv := fr.BatchInvert(xnMinusOne)
+
+	// x and 1/(x-1) on the coset
+	x := make([]fr.Element, s)
+	xMinusOneInv := make([]fr.Element, s)
+	x[0].Set(&domainBig.FrMultiplicativeGen)
+	for i := 1; i < s; i++ {
+		x[i].Mul(&x[i-1], &domainBig.Generator)
+	}
+	for i := 0; i < s; i++ {
+		xMinusOneInv[i].Sub(&x[i], &one)
+	}
+	xMinusOneInv = fr.BatchInvert(xMinusOneInv)
+
+	res := make([]fr.Element, s)
+	var a, b, u fr.Element
+	for i := 0; i < s; i++ {
+		a.Set(&ez[(i+ratio)%s])
+		b.Set(&ez[i])
+		for j := 0; j < nbColumns; j++ {
+			u.Mul(&beta, &eSigma[j][i]).
+				Add(&u, &ew[j][i]).
+				Add(&u, &gamma)
+			a.Mul(&a, &u)
+			u.Mul(&shifts[j], &x[i]).
+				Mul(&u, &beta).
+				Add(&u, &ew[j][i]).
+				Add(&u, &gamma)
+			b.Mul(&b, &u)
+		}
+		res[i].Sub(&a, &b)
+
+		// α⋅(xⁿ-1)/(x-1)⋅(Z-1)
+		u.Sub(&ez[i], &one).
+			Mul(&u, &xnMinusOne[i%ratio]).
+			Mul(&u, &xMinusOneInv[i]).
+			Mul(&u, &alpha)
+		res[i].Add(&res[i], &u).
+			Mul(&res[i], &xnMinusOneInv[i%ratio])
+	}
+
+	domainBig.FFTInverse(res, fft.DIF, fft.OnCoset())
+	fft.BitReverse(res)
+
+	// h is of degree < nbColumns⋅n
+	return res[:nbColumns*n]
+}
+
+// VerifyCopyConstraints verifies a copy constraints proof against the commitment of the
+// copy constraints.
+func VerifyCopyConstraints(vk kzg.VerifyingKey, sigma CopyConstraintsCommitment, proof CopyConstraintsProof) error {
+
+	nbColumns := len(sigma.sigma)
+	if len(proof.columns) != nbColumns || len(proof.batchedProof.ClaimedValues) != 2*nbColumns+2 {
+		return ErrTraceSize
+	}
+	g, err := fft.Generator(uint64(sigma.size))
+	if err != nil {
+		return err
+	}
+
+	// hash function that is used for Fiat Shamir
+	hFunc := sha256.New()
+
+	// transcript to derive the challenges
+	fs := fiatshamir.NewTranscript(hFunc, "beta", "gamma", "alpha", "zeta")
+
+	// derive the challenges
+	toBind := make([]*bls24315.G1Affine, 0, 2*nbColumns)
+	for j := range sigma.sigma {
+		toBind = append(toBind, &sigma.sigma[j])
+	}
+	for j := range proof.columns {
+		toBind = append(toBind, &proof.columns[j])
+	}
+	beta, err := deriveRandomness(fs, "beta", toBind...)
+	if err != nil {
+		return err
+	}
+	gamma, err := deriveRandomness(fs, "gamma")
+	if err != nil {
+		return err
+	}
+	alpha, err := deriveRandomness(fs, "alpha", &proof.z)
+	if err != nil {
+		return err
+	}
+	zeta, err := deriveRandomness(fs, "zeta", &proof.h)
+	if err != nil {
+		return err
+	}
+
+	// check the opening proofs
+	err = kzg.BatchVerifySinglePoint(proof.digests(sigma), &proof.batchedProof, zeta, hFunc, vk)
+	if err != nil {
+		return err
+	}
+	var shiftedZeta fr.Element
+	shiftedZeta.Mul(&zeta, &g)
+	err = kzg.Verify(&proof.z, &proof.shiftedProof, shiftedZeta, vk)
+	if err != nil {
+		return err
+	}
+
+	// check the relation
+	claimedValues := proof.batchedProof.ClaimedValues
+	w, sigmaValues := claimedValues[:nbColumns], claimedValues[nbColumns:2*nbColumns]
+	z, h := claimedValues[2*nbColumns], claimedValues[2*nbColumns+1]
+	shifts := cosetShifts(nbColumns)
+
+	var a, b, u, one fr.Element
+	one.SetOne()
+	a.Set(&proof.shiftedProof.ClaimedValue)
+	b.Set(&z)
+	for j := 0; j < nbColumns; j++ {
+		u.Mul(&beta, &sigmaValues[j]).
+			Add(&u, &w[j]).
+			Add(&u, &gamma)
+		a.Mul(&a, &u)
+		u.Mul(&shifts[j], &zeta).
+			Mul(&u, &beta).
+			Add(&u, &w[j]).
+			Add(&u, &gamma)
+		b.Mul(&b, &u)
+	}
+
+	var lhs, rhs, l0 fr.Element
+	rhs.Exp(zeta, big.NewInt(int64(sigma.size))).
+		Sub(&rhs, &one)
+	u.Sub(&zeta, &one)
+	l0.Div(&rhs, &u)
+	rhs.Mul(&rhs, &h)
+	u.Sub(&z, &one).
+		Mul(&u, &l0).
+		Mul(&u, &alpha)
+	lhs.Sub(&a, &b).
+		Add(&lhs, &u)
+	if !lhs.Equal(&rhs) {
+		return ErrCopyConstraintsProof
+	}
+
+	return nil
+}
+
+// digests returns the commitments to the columns, Sσ, z, h, in that order
+func (proof *CopyConstraintsProof) digests(sigma CopyConstraintsCommitment) []kzg.Digest {
+	res := make([]kzg.Digest, 0, 2*len(proof.columns)+2)
+	res = append(res, proof.columns...)
+	res = append(res, sigma.sigma...)
+	return append(res, proof.z, proof.h)
+}
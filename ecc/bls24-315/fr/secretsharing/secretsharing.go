@@ -0,0 +1,142 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package secretsharing
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/polynomial"
+)
+
+var (
+	ErrInvalidThreshold = errors.New("threshold must be between 1 and the number of shares")
+	ErrNotEnoughShares  = errors.New("not enough shares to recombine the secret")
+	ErrDuplicateIndex   = errors.New("shares indices must be distinct")
+	ErrZeroIndex        = errors.New("share index must be non zero")
+)
+
+// Share is the evaluation of the sharing polynomial at a non zero index.
+type Share struct {
+	Index fr.Element
+	Value fr.Element
+}
+
+// Split shares secret among nbShares parties such that any threshold of them
+// can recombine it. The shares are evaluations of a random polynomial of degree
+// threshold-1 at 1, 2, …, nbShares.
+func Split(secret fr.Element, threshold, nbShares int) ([]Share, error) {
+	f, err := randomPolynomial(secret, threshold, nbShares)
+	if err != nil {
+		return nil, err
+	}
+	return evaluateShares(f, nbShares), nil
+}
+
+// Recombine returns the secret from the given shares, using Lagrange
+// interpolation at zero. It is the caller's responsibility to provide at least
+// threshold shares: with fewer shares the result is a random value.
+func Recombine(shares []Share) (fr.Element, error) {
+	var secret fr.Element
+	if len(shares) == 0 {
+		return secret, ErrNotEnoughShares
+	}
+	indices := make([]fr.Element, len(shares))
+	for i := range shares {
+		indices[i] = shares[i].Index
+	}
+	lagrange, err := LagrangeCoefficientsAtZero(indices)
+	if err != nil {
+		return secret, err
+	}
+	var tmp fr.Element
+	for i := range shares {
+		tmp.Mul(&shares[i].Value, &lagrange[i])
+		secret.Add(&secret, &tmp)
+	}
+	return secret, nil
+}
+
+// LagrangeCoefficientsAtZero returns λᵢ = ∏_{j≠i} xⱼ/(xⱼ-xᵢ), such that for any
+// polynomial f of degree < len(indices), f(0) = ∑ λᵢ f(xᵢ).
+//
+// The indices must be non zero and pairwise distinct.
+func LagrangeCoefficientsAtZero(indices []fr.Element) ([]fr.Element, error) {
+	n := len(indices)
+	for i := range indices {
+		if indices[i].IsZero() {
+			return nil, ErrZeroIndex
+		}
+	}
+
+	// numerators[i] = ∏_{j≠i} xⱼ, denominators[i] = ∏_{j≠i} (xⱼ-xᵢ)
+	numerators := make([]fr.Element, n)
+	denominators := make([]fr.Element, n)
+	var diff fr.Element
+	for i := 0; i < n; i++ {
+		numerators[i].SetOne()
+		denominators[i].SetOne()
+		for j := 0; j < n; j++ {
+			if j == i {
+				continue
+			}
+			diff.Sub(&indices[j], &indices[i])
+			if diff.IsZero() {
+				return nil, ErrDuplicateIndex
+			}
+			numerators[i].Mul(&numerators[i], &indices[j])
+			denominators[i].Mul(&denominators[i], &diff)
+		}
+	}
+	denominators = fr.BatchInvert(denominators)
+	for i := range numerators {
+		numerators[i].Mul(&numerators[i], &denominators[i])
+	}
+	return numerators, nil
+}
+
+// randomPolynomial returns a random polynomial of degree threshold-1 whose
+// constant coefficient is secret.
+func randomPolynomial(secret fr.Element, threshold, nbShares int) (polynomial.Polynomial, error) {
+	if threshold < 1 || threshold > nbShares {
+		return nil, ErrInvalidThreshold
+	}
+	f := make(polynomial.Polynomial, threshold)
+	f[0] = secret
+	for i := 1; i < threshold; i++ {
+		if _, err := f[i].SetRandom(); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// evaluateShares returns f(1), f(2), …, f(nbShares).
+func evaluateShares(f polynomial.Polynomial, nbShares int) []Share {
+	shares := make([]Share, nbShares)
+	var one fr.Element
+	one.SetOne()
+	for i := range shares {
+		if i == 0 {
+			shares[i].Index = one
+		} else {
+			shares[i].Index.Add(&shares[i-1].Index, &one)
+		}
+		shares[i].Value = f.Eval(&shares[i].Index)
+	}
+	return shares
+}
@@ -0,0 +1,120 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package secretsharing
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls24-315"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+)
+
+var ErrInvalidShare = errors.New("share does not match the commitments")
+
+// Params are the public parameters of the Pedersen verifiable secret sharing.
+// The discrete logarithm of H in base G must be unknown to the dealer.
+type Params struct {
+	G, H curve.G1Affine
+}
+
+// NewParams returns parameters where G is the canonical generator of G1 and H
+// is obtained by hashing seed to G1, so that nobody knows its discrete logarithm.
+func NewParams(seed []byte) (Params, error) {
+	var params Params
+	_, _, params.G, _ = curve.Generators()
+	var err error
+	params.H, err = curve.HashToG1(seed, []byte("SECRETSHARING_PEDERSEN_H"))
+	return params, err
+}
+
+// VerifiableShare is a Shamir share together with the evaluation of the
+// blinding polynomial at the same index.
+type VerifiableShare struct {
+	Share
+	Blinding fr.Element
+}
+
+// SplitVerifiable shares secret among nbShares parties like [Split], and
+// returns the Pedersen commitments to the coefficients of the sharing
+// polynomial. The commitments are public and let each party check its share
+// with [VerifyShare].
+func SplitVerifiable(params Params, secret fr.Element, threshold, nbShares int) ([]VerifiableShare, []curve.G1Affine, error) {
+	f, err := randomPolynomial(secret, threshold, nbShares)
+	if err != nil {
+		return nil, nil, err
+	}
+	var zero fr.Element
+	if _, err = zero.SetRandom(); err != nil {
+		return nil, nil, err
+	}
+	g, err := randomPolynomial(zero, threshold, nbShares)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	commitments := make([]curve.G1Affine, threshold)
+	bases := []curve.G1Affine{params.G, params.H}
+	for i := range commitments {
+		if _, err = commitments[i].MultiExp(bases, []fr.Element{f[i], g[i]}, ecc.MultiExpConfig{NbTasks: 1}); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	shares := evaluateShares(f, nbShares)
+	res := make([]VerifiableShare, nbShares)
+	for i := range res {
+		res[i].Share = shares[i]
+		res[i].Blinding = g.Eval(&shares[i].Index)
+	}
+	return res, commitments, nil
+}
+
+// VerifyShare checks that [s]G + [t]H = ∑ [iʲ]Cⱼ where s, t are the share
+// value and blinding and Cⱼ the commitments published by the dealer.
+func VerifyShare(params Params, share VerifiableShare, commitments []curve.G1Affine) error {
+	if len(commitments) == 0 {
+		return ErrInvalidThreshold
+	}
+	if share.Index.IsZero() {
+		return ErrZeroIndex
+	}
+	for i := range commitments {
+		if !commitments[i].IsInSubGroup() {
+			return errors.New("commitment subgroup check failed")
+		}
+	}
+
+	powers := make([]fr.Element, len(commitments))
+	powers[0].SetOne()
+	for i := 1; i < len(powers); i++ {
+		powers[i].Mul(&powers[i-1], &share.Index)
+	}
+	config := ecc.MultiExpConfig{NbTasks: 1}
+
+	var expected, got curve.G1Affine
+	if _, err := expected.MultiExp(commitments, powers, config); err != nil {
+		return err
+	}
+	if _, err := got.MultiExp([]curve.G1Affine{params.G, params.H}, []fr.Element{share.Value, share.Blinding}, config); err != nil {
+		return err
+	}
+	if !expected.Equal(&got) {
+		return ErrInvalidShare
+	}
+	return nil
+}
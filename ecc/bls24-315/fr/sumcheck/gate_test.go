@@ -0,0 +1,122 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package sumcheck
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/polynomial"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/stretchr/testify/require"
+)
+
+// mulAddGate computes x⋅y + z
+var mulAddGate = GateFunc{
+	F: func(x ...fr.Element) fr.Element {
+		var res fr.Element
+		res.Mul(&x[0], &x[1])
+		res.Add(&res, &x[2])
+		return res
+	},
+	D: 2,
+}
+
+func randomMultiLin(size int) polynomial.MultiLin {
+	res := make(polynomial.MultiLin, size)
+	for i := range res {
+		res[i].SetInt64(int64(i*i + 3*size - 7))
+	}
+	return res
+}
+
+func cloneAll(inputs []polynomial.MultiLin) []polynomial.MultiLin {
+	res := make([]polynomial.MultiLin, len(inputs))
+	for i := range inputs {
+		res[i] = inputs[i].Clone()
+	}
+	return res
+}
+
+func TestGateClaims(t *testing.T) {
+	for _, nbVars := range []int{1, 2, 5} {
+		assert := require.New(t)
+
+		inputs := []polynomial.MultiLin{
+			randomMultiLin(1 << nbVars),
+			randomMultiLin(1 << nbVars),
+			randomMultiLin(1 << nbVars),
+		}
+		inputs[1][0].SetInt64(42)
+
+		claims, err := NewGateClaims(mulAddGate, cloneAll(inputs)...)
+		assert.NoError(err)
+		sum := claims.Sum()
+
+		proof, err := Prove(claims, fiatshamir.WithHash(sha256.New()))
+		assert.NoError(err)
+
+		lazyClaims := NewGateLazyClaims(mulAddGate, nbVars, len(inputs), sum, EvaluateInputs(inputs...))
+		assert.NoError(Verify(lazyClaims, proof, fiatshamir.WithHash(sha256.New())))
+
+		// wrong sum
+		var one fr.Element
+		one.SetOne()
+		var wrongSum fr.Element
+		wrongSum.Add(&sum, &one)
+		lazyClaims = NewGateLazyClaims(mulAddGate, nbVars, len(inputs), wrongSum, EvaluateInputs(inputs...))
+		assert.Error(Verify(lazyClaims, proof, fiatshamir.WithHash(sha256.New())))
+
+		// wrong inputs
+		other := cloneAll(inputs)
+		other[2][len(other[2])-1].Add(&other[2][len(other[2])-1], &one)
+		lazyClaims = NewGateLazyClaims(mulAddGate, nbVars, len(inputs), sum, EvaluateInputs(other...))
+		assert.Error(Verify(lazyClaims, proof, fiatshamir.WithHash(sha256.New())))
+	}
+}
+
+func TestNewGateClaimsErrors(t *testing.T) {
+	assert := require.New(t)
+
+	_, err := NewGateClaims(mulAddGate)
+	assert.Error(err)
+	_, err = NewGateClaims(mulAddGate, randomMultiLin(3))
+	assert.Error(err)
+	_, err = NewGateClaims(mulAddGate, randomMultiLin(4), randomMultiLin(8))
+	assert.Error(err)
+}
+
+func TestWideChallenges(t *testing.T) {
+	poly := make(polynomial.MultiLin, 8)
+	for i := range poly {
+		poly[i].SetUint64(uint64(i + 1))
+	}
+	wide := func() fiatshamir.Settings {
+		settings := fiatshamir.WithHash(sha256.New())
+		settings.WideChallenges = true
+		return settings
+	}
+
+	claim := singleMultilinClaim{g: poly.Clone()}
+	proof, err := Prove(&claim, wide())
+	require.NoError(t, err)
+
+	lazyClaim := singleMultilinLazyClaim{g: poly, claimedSum: poly.Sum()}
+	require.NoError(t, Verify(lazyClaim, proof, wide()))
+	require.Error(t, Verify(lazyClaim, proof, fiatshamir.WithHash(sha256.New())), "the challenges should depend on the derivation")
+}
@@ -221,14 +221,15 @@ func TestG1AffineConversions(t *testing.T) {
 		GenFp(),
 		GenFp(),
 	))
+
 	properties.Property("[BLS24-315] BatchJacobianToAffineG1 and FromJacobian should output the same result", prop.ForAll(
 		func(a, b fp.Element) bool {
-			g1 := fuzzG1Jac(&g1Gen, a)
-			g2 := fuzzG1Jac(&g1Gen, b)
+			p1 := fuzzG1Jac(&g1Gen, a)
+			p2 := fuzzG1Jac(&g1Gen, b)
 			var op1, op2 G1Affine
-			op1.FromJacobian(&g1)
-			op2.FromJacobian(&g2)
-			baseTableAff := BatchJacobianToAffineG1([]G1Jac{g1, g2})
+			op1.FromJacobian(&p1)
+			op2.FromJacobian(&p2)
+			baseTableAff := BatchJacobianToAffineG1([]G1Jac{p1, p2})
 			return op1.Equal(&baseTableAff[0]) && op2.Equal(&baseTableAff[1])
 		},
 		GenFp(),
@@ -549,6 +550,59 @@ func TestG1AffineCofactorCleaning(t *testing.T) {
 
 }
 
+func TestBatchJacobianToAffineG1Into(t *testing.T) {
+	t.Parallel()
+
+	const nbPoints = 16
+	points := make([]G1Jac, nbPoints)
+	expected := make([]G1Affine, nbPoints)
+	for i := range points {
+		var s fr.Element
+		s.SetRandom()
+		points[i].ScalarMultiplication(&g1Gen, s.BigInt(new(big.Int)))
+	}
+	points[3].Set(&g1Infinity)
+	for i := range points {
+		expected[i].FromJacobian(&points[i])
+	}
+
+	check := func(name string, got, want []G1Affine) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("%s: expected %d points, got %d", name, len(want), len(got))
+		}
+		for i := range want {
+			if !got[i].Equal(&want[i]) {
+				t.Fatalf("%s: point %d mismatch", name, i)
+			}
+		}
+	}
+
+	// dst too small: reallocated
+	dst := make([]G1Affine, 0, 2)
+	dst = BatchJacobianToAffineG1Into(dst, points)
+	check("reallocated", dst, expected)
+
+	// dst reused, with stale content
+	ptr := &dst[0]
+	points[0], points[3] = points[3], points[0]
+	expected[0], expected[3] = expected[3], expected[0]
+	dst = BatchJacobianToAffineG1Into(dst, points)
+	check("reused", dst, expected)
+	if ptr != &dst[0] {
+		t.Fatal("dst should be reused")
+	}
+
+	// shorter input
+	dst = BatchJacobianToAffineG1Into(dst, points[:4])
+	check("shorter", dst, expected[:4])
+
+	// pooled
+	res, release := BatchJacobianToAffineG1Pooled(points)
+	check("pooled", res, expected)
+	release()
+}
+
 func TestG1AffineBatchScalarMultiplication(t *testing.T) {
 
 	parameters := gopter.DefaultTestParameters()
@@ -599,6 +653,87 @@ func TestG1AffineBatchScalarMultiplication(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestG1AffineBatchIsInSubGroup(t *testing.T) {
+	t.Parallel()
+
+	// small batches are checked point by point, larger ones with random subset sums
+	for _, n := range []int{10, 300} {
+		jac := make([]G1Jac, n)
+		jac[0].Set(&g1Gen)
+		for i := 1; i < n; i++ {
+			jac[i].Set(&jac[i-1]).AddAssign(&g1Gen)
+		}
+		points := make([]G1Affine, n)
+		for i := range points {
+			points[i].FromJacobian(&jac[i])
+		}
+		points[n/2].X.SetZero()
+		points[n/2].Y.SetZero()
+		if !BatchIsInSubGroupG1(points) {
+			t.Fatalf("n=%d: valid points rejected", n)
+		}
+
+		// a point off the curve
+		invalid := make([]G1Affine, n)
+		copy(invalid, points)
+		invalid[n/4].Y.Double(&invalid[n/4].Y)
+		if BatchIsInSubGroupG1(invalid) {
+			t.Fatalf("n=%d: point off the curve accepted", n)
+		}
+
+		// a point on the curve, outside the subgroup
+		copy(invalid, points)
+		invalid[n/3] = randomNotInSubGroupG1Affine()
+		if BatchIsInSubGroupG1(invalid) {
+			t.Fatalf("n=%d: point outside the subgroup accepted", n)
+		}
+	}
+}
+
+// randomNotInSubGroupG1Affine returns a random point on the curve, outside the r-torsion
+func randomNotInSubGroupG1Affine() G1Affine {
+	var p G1Affine
+	var ySquared fp.Element
+	for {
+		p.X.SetRandom()
+		ySquared.Square(&p.X).Mul(&ySquared, &p.X)
+		ySquared.Add(&ySquared, &bCurveCoeff)
+		if ySquared.Legendre() != 1 {
+			continue
+		}
+		p.Y.Sqrt(&ySquared)
+		if !p.IsInSubGroup() {
+			return p
+		}
+	}
+}
+
+func BenchmarkG1AffineBatchIsInSubGroup(b *testing.B) {
+	const n = 1 << 10
+	jac := make([]G1Jac, n)
+	jac[0].Set(&g1Gen)
+	for i := 1; i < n; i++ {
+		jac[i].Set(&jac[i-1]).AddAssign(&g1Gen)
+	}
+	points := make([]G1Affine, n)
+	for i := range points {
+		points[i].FromJacobian(&jac[i])
+	}
+
+	b.Run("batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			BatchIsInSubGroupG1(points)
+		}
+	})
+	b.Run("individual", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range points {
+				points[j].IsInSubGroup()
+			}
+		}
+	})
+}
+
 // ------------------------------------------------------------
 // benches
 
@@ -684,6 +819,36 @@ func BenchmarkBatchAddG1Affine(b *testing.B) {
 	}
 }
 
+func BenchmarkBatchJacobianToAffineG1(b *testing.B) {
+	const nbPoints = 1 << 10
+	points := make([]G1Jac, nbPoints)
+	points[0].Set(&g1Gen)
+	for i := 1; i < nbPoints; i++ {
+		points[i].Set(&points[i-1]).AddAssign(&g1Gen)
+	}
+
+	b.Run("alloc", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = BatchJacobianToAffineG1(points)
+		}
+	})
+	b.Run("into", func(b *testing.B) {
+		b.ReportAllocs()
+		var dst []G1Affine
+		for i := 0; i < b.N; i++ {
+			dst = BatchJacobianToAffineG1Into(dst, points)
+		}
+	})
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, release := BatchJacobianToAffineG1Pooled(points)
+			release()
+		}
+	})
+}
+
 func BenchmarkG1AffineBatchScalarMultiplication(b *testing.B) {
 	// ensure every words of the scalars are filled
 	var mixer fr.Element
@@ -0,0 +1,111 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/utils/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSerializationSRSG2(t *testing.T) {
+	srs, err := NewSRSG2(16, new(big.Int).SetInt64(42))
+	require.NoError(t, err)
+	t.Run("whole SRSG2 round-trip", testutils.SerializationRoundTrip(srs))
+
+	f := randomPolynomial(10)
+	proof, err := OpenG2(f, fr.NewElement(7), srs.Pk)
+	require.NoError(t, err)
+	t.Run("opening proof round-trip", testutils.SerializationRoundTrip(&proof))
+}
+
+func TestVerifySinglePointG2(t *testing.T) {
+	assert := require.New(t)
+
+	srs, err := NewSRSG2(32, bAlpha)
+	assert.NoError(err)
+
+	f := randomPolynomial(30)
+
+	digest, err := CommitG2(f, srs.Pk)
+	assert.NoError(err)
+
+	var point fr.Element
+	point.SetRandom()
+	proof, err := OpenG2(f, point, srs.Pk)
+	assert.NoError(err)
+
+	// verify the claimed value
+	expected := eval(f, point)
+	assert.True(proof.ClaimedValue.Equal(&expected), "inconsistent claimed value")
+
+	// verify correct proof
+	assert.NoError(VerifyG2(&digest, &proof, point, srs.Vk))
+
+	// wrong claimed value
+	tampered := proof
+	tampered.ClaimedValue.Double(&tampered.ClaimedValue)
+	assert.Error(VerifyG2(&digest, &tampered, point, srs.Vk))
+
+	// wrong point
+	var otherPoint fr.Element
+	otherPoint.Double(&point)
+	assert.Error(VerifyG2(&digest, &proof, otherPoint, srs.Vk))
+
+	// polynomial too large
+	_, err = CommitG2(randomPolynomial(33), srs.Pk)
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+}
+
+func TestCommitG1G2Consistency(t *testing.T) {
+	assert := require.New(t)
+
+	// same α as testSrs
+	srs, err := NewSRSG2(32, bAlpha)
+	assert.NoError(err)
+
+	f := randomPolynomial(20)
+	digestG1, err := Commit(f, testSrs.Pk)
+	assert.NoError(err)
+	digestG2, err := CommitG2(f, srs.Pk)
+	assert.NoError(err)
+
+	// e([f(α)]G₁, -G₂)⋅e(G₁, [f(α)]G₂) == 1
+	var g2Neg bls24315.G2Affine
+	g2Neg.Neg(&srs.Vk.G2)
+	ok, err := bls24315.PairingCheck(
+		[]bls24315.G1Affine{digestG1, srs.Vk.G1[0]},
+		[]bls24315.G2Affine{g2Neg, digestG2},
+	)
+	assert.NoError(err)
+	assert.True(ok, "commitments in G₁ and G₂ are inconsistent")
+
+	// a different polynomial does not match
+	f[0].Double(&f[0])
+	digestG2, err = CommitG2(f, srs.Pk)
+	assert.NoError(err)
+	ok, err = bls24315.PairingCheck(
+		[]bls24315.G1Affine{digestG1, srs.Vk.G1[0]},
+		[]bls24315.G2Affine{g2Neg, digestG2},
+	)
+	assert.NoError(err)
+	assert.False(ok)
+}
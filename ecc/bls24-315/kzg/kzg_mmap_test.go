@@ -0,0 +1,103 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvingKeyMMap(t *testing.T) {
+	assert := require.New(t)
+	dir := t.TempDir()
+
+	var buf bytes.Buffer
+	_, err := testSrs.Pk.WriteRawTo(&buf)
+	assert.NoError(err)
+	path := filepath.Join(dir, "pk")
+	assert.NoError(os.WriteFile(path, buf.Bytes(), 0600))
+
+	pk, err := NewProvingKeyMMap(path)
+	assert.NoError(err)
+	assert.Equal(len(testSrs.Pk.G1), pk.Len())
+
+	// partial key
+	small, err := pk.ProvingKey(10)
+	assert.NoError(err)
+	assert.Equal(testSrs.Pk.G1[:10], small.G1)
+
+	f := randomPolynomial(10)
+	expected, err := Commit(f, testSrs.Pk)
+	assert.NoError(err)
+	digest, err := Commit(f, small)
+	assert.NoError(err)
+	assert.True(expected.Equal(&digest))
+
+	// whole key
+	full, err := pk.ProvingKey(pk.Len())
+	assert.NoError(err)
+	assert.Equal(testSrs.Pk.G1, full.G1)
+
+	_, err = pk.ProvingKey(pk.Len() + 1)
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+
+	assert.NoError(pk.Close())
+
+	// decoded points outlive the mapping
+	full, err = pk.ProvingKey(pk.Len())
+	assert.NoError(err)
+	assert.Equal(testSrs.Pk.G1, full.G1)
+}
+
+func TestProvingKeyMMapInvalid(t *testing.T) {
+	assert := require.New(t)
+	dir := t.TempDir()
+
+	// compressed encoding is not supported
+	var buf bytes.Buffer
+	_, err := testSrs.Pk.WriteTo(&buf)
+	assert.NoError(err)
+	path := filepath.Join(dir, "compressed")
+	assert.NoError(os.WriteFile(path, buf.Bytes(), 0600))
+	_, err = NewProvingKeyMMap(path)
+	assert.ErrorIs(err, ErrInvalidProvingKeyFile)
+
+	// truncated file
+	buf.Reset()
+	_, err = testSrs.Pk.WriteRawTo(&buf)
+	assert.NoError(err)
+	path = filepath.Join(dir, "truncated")
+	assert.NoError(os.WriteFile(path, buf.Bytes()[:buf.Len()-1], 0600))
+	_, err = NewProvingKeyMMap(path)
+	assert.ErrorIs(err, ErrInvalidProvingKeyFile)
+
+	// point not on the curve
+	raw := bytes.Clone(buf.Bytes())
+	raw[4+bls24315.SizeOfG1AffineUncompressed-1] ^= 1
+	path = filepath.Join(dir, "invalid")
+	assert.NoError(os.WriteFile(path, raw, 0600))
+	pk, err := NewProvingKeyMMap(path)
+	assert.NoError(err)
+	defer pk.Close()
+	_, err = pk.ProvingKey(1)
+	assert.Error(err)
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/fft"
 
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
 	"github.com/consensys/gnark-crypto/utils/testutils"
 )
 
@@ -113,6 +114,117 @@ func TestCommitLagrange(t *testing.T) {
 	assert.True(digestCanonical.Equal(&digestLagrange), "error CommitLagrange")
 }
 
+func TestCommitSparse(t *testing.T) {
+
+	assert := require.New(t)
+
+	// a selector-like polynomial, with a repeated index
+	indices := []uint64{0, 3, 17, 42, 3, 63}
+	values := make([]fr.Element, len(indices))
+	dense := make([]fr.Element, 64)
+	for i := range values {
+		values[i].SetRandom()
+		dense[indices[i]].Add(&dense[indices[i]], &values[i])
+	}
+
+	expected, err := Commit(dense, testSrs.Pk)
+	assert.NoError(err)
+	digest, err := CommitSparse(indices, values, testSrs.Pk)
+	assert.NoError(err)
+	assert.True(expected.Equal(&digest))
+
+	// single term
+	monomial := make([]fr.Element, 18)
+	monomial[17].Set(&values[2])
+	expected, err = Commit(monomial, testSrs.Pk)
+	assert.NoError(err)
+	digest, err = CommitSparse([]uint64{17}, values[2:3], testSrs.Pk)
+	assert.NoError(err)
+	assert.True(expected.Equal(&digest))
+
+	// zero polynomial
+	digest, err = CommitSparse(nil, nil, testSrs.Pk)
+	assert.NoError(err)
+	assert.True(digest.IsInfinity())
+
+	// invalid inputs
+	_, err = CommitSparse([]uint64{1, 2}, values[:1], testSrs.Pk)
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+	_, err = CommitSparse([]uint64{uint64(len(testSrs.Pk.G1))}, values[:1], testSrs.Pk)
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+}
+
+func TestTruncate(t *testing.T) {
+
+	assert := require.New(t)
+
+	const size = 32
+	small, err := testSrs.Truncate(size)
+	assert.NoError(err)
+	assert.Len(small.Pk.G1, size)
+
+	// the truncated SRS is independent of the original one
+	small.Pk.G1[1].Set(&small.Pk.G1[0])
+	assert.False(testSrs.Pk.G1[1].Equal(&small.Pk.G1[1]))
+
+	// it round-trips on its own
+	small, err = testSrs.Truncate(size)
+	assert.NoError(err)
+	var buf bytes.Buffer
+	_, err = small.WriteTo(&buf)
+	assert.NoError(err)
+	var read SRS
+	_, err = read.ReadFrom(&buf)
+	assert.NoError(err)
+	assert.Len(read.Pk.G1, size)
+
+	// and commits and opens as the original one
+	pol := randomPolynomial(size)
+	digest, err := Commit(pol, testSrs.Pk)
+	assert.NoError(err)
+	smallDigest, err := Commit(pol, read.Pk)
+	assert.NoError(err)
+	assert.True(digest.Equal(&smallDigest))
+	var point fr.Element
+	point.SetRandom()
+	proof, err := Open(pol, point, read.Pk)
+	assert.NoError(err)
+	assert.NoError(Verify(&digest, &proof, point, read.Vk))
+
+	// invalid sizes
+	_, err = testSrs.Pk.Truncate(1)
+	assert.ErrorIs(err, ErrInvalidSRSSize)
+	_, err = testSrs.Pk.Truncate(len(testSrs.Pk.G1) + 1)
+	assert.ErrorIs(err, ErrInvalidSRSSize)
+}
+
+func TestExtractLagrange(t *testing.T) {
+
+	assert := require.New(t)
+
+	const size = 16
+	domain := fft.NewDomain(size)
+	pkLagrange, err := testSrs.Pk.ExtractLagrange(domain)
+	assert.NoError(err)
+	assert.Len(pkLagrange.G1, size)
+
+	// committing to the evaluations with the Lagrange key is committing to the
+	// coefficients with the canonical one
+	pol := randomPolynomial(size)
+	digest, err := Commit(pol, testSrs.Pk)
+	assert.NoError(err)
+	evals := make([]fr.Element, size)
+	copy(evals, pol)
+	domain.FFT(evals, fft.DIF)
+	fft.BitReverse(evals)
+	digestLagrange, err := Commit(evals, pkLagrange)
+	assert.NoError(err)
+	assert.True(digest.Equal(&digestLagrange))
+
+	_, err = testSrs.Pk.ExtractLagrange(fft.NewDomain(2 * uint64(len(testSrs.Pk.G1))))
+	assert.ErrorIs(err, ErrInvalidSRSSize)
+}
+
 func TestDividePolyByXminusA(t *testing.T) {
 
 	const pSize = 230
@@ -165,6 +277,21 @@ func TestSerializationSRS(t *testing.T) {
 	t.Run("unsafe whole SRS round-trip", testutils.UnsafeBinaryMarshalerRoundTrip(srs))
 }
 
+func TestVerifyingKeyFingerprint(t *testing.T) {
+	srs, err := NewSRS(64, new(big.Int).SetInt64(43))
+	assert.NoError(t, err)
+
+	// the fingerprint survives serialization, and depends on α
+	var buf bytes.Buffer
+	_, err = srs.Vk.WriteTo(&buf)
+	assert.NoError(t, err)
+	var vk VerifyingKey
+	_, err = vk.ReadFrom(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, srs.Vk.Fingerprint(), vk.Fingerprint())
+	assert.NotEqual(t, testSrs.Vk.Fingerprint(), vk.Fingerprint())
+}
+
 func TestCommit(t *testing.T) {
 
 	// create a polynomial
@@ -198,6 +325,28 @@ func TestCommit(t *testing.T) {
 
 }
 
+func TestCommitConstant(t *testing.T) {
+
+	var c fr.Element
+	c.SetRandom()
+
+	// the first point of the SRS is the generator, then any other point
+	for _, pk := range []ProvingKey{testSrs.Pk, {G1: testSrs.Pk.G1[1:]}} {
+		digest, err := Commit([]fr.Element{c}, pk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var expected bls24315.G1Affine
+		if _, err := expected.MultiExp(pk.G1[:1], []fr.Element{c}, ecc.MultiExpConfig{}); err != nil {
+			t.Fatal(err)
+		}
+		if !digest.Equal(&expected) {
+			t.Fatal("wrong commitment to a constant polynomial")
+		}
+	}
+
+}
+
 func TestVerifySinglePoint(t *testing.T) {
 
 	// create a polynomial
@@ -365,6 +514,97 @@ func TestBatchVerifySinglePoint(t *testing.T) {
 	}
 }
 
+func TestBatchVerifySinglePointInstance(t *testing.T) {
+
+	size := 20
+	f := make([][]fr.Element, 3)
+	digests := make([]Digest, len(f))
+	for i := range f {
+		f[i] = randomPolynomial(size)
+		digests[i], _ = Commit(f[i], testSrs.Pk)
+	}
+
+	var point fr.Element
+	point.SetRandom()
+	proof, err := BatchOpenSinglePoint(f, digests, point, fiatshamir.InstanceHash(sha256.New(), []byte("instance 1")), testSrs.Pk)
+	require.NoError(t, err)
+
+	err = BatchVerifySinglePoint(digests, &proof, point, fiatshamir.InstanceHash(sha256.New(), []byte("instance 1")), testSrs.Vk)
+	require.NoError(t, err, "verifying with the same instance should succeed")
+
+	err = BatchVerifySinglePoint(digests, &proof, point, fiatshamir.InstanceHash(sha256.New(), []byte("instance 2")), testSrs.Vk)
+	require.Error(t, err, "verifying with another instance should fail")
+
+	err = BatchVerifySinglePoint(digests, &proof, point, sha256.New(), testSrs.Vk)
+	require.Error(t, err, "verifying without instance should fail")
+}
+
+func TestBatchVerifySinglePointWideChallenges(t *testing.T) {
+	defer fiatshamir.SetWideChallenges(fiatshamir.WideChallenges())
+
+	size := 20
+	f := make([][]fr.Element, 3)
+	digests := make([]Digest, len(f))
+	for i := range f {
+		f[i] = randomPolynomial(size)
+		digests[i], _ = Commit(f[i], testSrs.Pk)
+	}
+
+	var point fr.Element
+	point.SetRandom()
+	fiatshamir.SetWideChallenges(true)
+	proof, err := BatchOpenSinglePoint(f, digests, point, sha256.New(), testSrs.Pk)
+	require.NoError(t, err)
+
+	err = BatchVerifySinglePoint(digests, &proof, point, sha256.New(), testSrs.Vk)
+	require.NoError(t, err, "verifying with wide challenges should succeed")
+
+	fiatshamir.SetWideChallenges(false)
+	err = BatchVerifySinglePoint(digests, &proof, point, sha256.New(), testSrs.Vk)
+	require.Error(t, err, "verifying with the default challenges should fail")
+}
+
+func TestBatchVerifySinglePointWithOptions(t *testing.T) {
+
+	size := 20
+	f := make([][]fr.Element, 3)
+	digests := make([]Digest, len(f))
+	for i := range f {
+		f[i] = randomPolynomial(size)
+		var err error
+		digests[i], err = CommitWithOptions(f[i], testSrs.Pk, WithNbTasks(2))
+		require.NoError(t, err)
+		expected, _ := Commit(f[i], testSrs.Pk)
+		require.True(t, expected.Equal(&digests[i]))
+	}
+
+	var point fr.Element
+	point.SetRandom()
+
+	opts := []Option{WithHash(sha256.New()), WithInstanceID([]byte("instance")), WithDataTranscript([]byte("data"))}
+	proof, err := BatchOpenSinglePointWithOptions(f, digests, point, testSrs.Pk, opts...)
+	require.NoError(t, err)
+
+	// the options are equivalent to the explicit arguments
+	expected, err := BatchOpenSinglePoint(f, digests, point, fiatshamir.InstanceHash(sha256.New(), []byte("instance")), testSrs.Pk, []byte("data"))
+	require.NoError(t, err)
+	require.True(t, expected.H.Equal(&proof.H))
+
+	err = BatchVerifySinglePointWithOptions(digests, &proof, point, testSrs.Vk, WithHash(sha256.New()), WithInstanceID([]byte("instance")), WithDataTranscript([]byte("data")))
+	require.NoError(t, err)
+
+	err = BatchVerifySinglePointWithOptions(digests, &proof, point, testSrs.Vk, WithHash(sha256.New()), WithDataTranscript([]byte("data")))
+	require.Error(t, err, "verifying without instance should fail")
+
+	// invalid configurations
+	_, err = CommitWithOptions(f[0], testSrs.Pk, WithNbTasks(-1))
+	require.ErrorIs(t, err, ErrInvalidConfig)
+	_, err = BatchOpenSinglePointWithOptions(f, digests, point, testSrs.Pk)
+	require.ErrorIs(t, err, ErrInvalidConfig)
+	err = BatchVerifySinglePointWithOptions(digests, &proof, point, testSrs.Vk, WithHash(sha256.New()), WithNbTasks(maxNbTasks+1))
+	require.ErrorIs(t, err, ErrInvalidConfig)
+}
+
 func TestBatchVerifyMultiPoints(t *testing.T) {
 
 	// create polynomials
@@ -435,6 +675,69 @@ func TestBatchVerifyMultiPoints(t *testing.T) {
 	}
 }
 
+func TestBatchVerifyMultiPointsMultiKeys(t *testing.T) {
+
+	// srs of different sizes from the same ceremony, and from another ceremony
+	smallSrs, err := NewSRS(16, bAlpha)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherSrs, err := NewSRS(32, new(big.Int).SetInt64(43))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pks := []ProvingKey{testSrs.Pk, smallSrs.Pk, otherSrs.Pk, testSrs.Pk, otherSrs.Pk}
+	vks := []VerifyingKey{testSrs.Vk, smallSrs.Vk, otherSrs.Vk, testSrs.Vk, otherSrs.Vk}
+
+	digests := make([]Digest, len(pks))
+	proofs := make([]OpeningProof, len(pks))
+	points := make([]fr.Element, len(pks))
+	for i := range pks {
+		f := randomPolynomial(10)
+		digests[i], err = Commit(f, pks[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		points[i].SetRandom()
+		proofs[i], err = Open(f, points[i], pks[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = BatchVerifyMultiPointsMultiKeys(digests, proofs, points, vks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// same ceremony only
+	err = BatchVerifyMultiPointsMultiKeys(digests[:2], proofs[:2], points[:2], vks[:2])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// wrong verifying key
+	wrongVks := make([]VerifyingKey, len(vks))
+	copy(wrongVks, vks)
+	wrongVks[2] = testSrs.Vk
+	err = BatchVerifyMultiPointsMultiKeys(digests, proofs, points, wrongVks)
+	if err == nil {
+		t.Fatal("verifying a proof against the wrong verifying key should fail")
+	}
+
+	// tampered proof
+	proofs[4].ClaimedValue.Double(&proofs[4].ClaimedValue)
+	err = BatchVerifyMultiPointsMultiKeys(digests, proofs, points, vks)
+	if err == nil {
+		t.Fatal("verifying a tampered proof should fail")
+	}
+
+	err = BatchVerifyMultiPointsMultiKeys(digests, proofs, points, vks[1:])
+	if err != ErrInvalidNbDigests {
+		t.Fatal("wrong number of verifying keys should be rejected")
+	}
+}
+
 func TestUnsafeToBytesTruncating(t *testing.T) {
 	assert := require.New(t)
 	srs, err := NewSRS(ecc.NextPowerOfTwo(1<<10), big.NewInt(-1))
@@ -489,6 +792,25 @@ func BenchmarkSRSGen(b *testing.B) {
 	})
 }
 
+func BenchmarkKZGCommitSparse(b *testing.B) {
+	srs, err := NewSRS(ecc.NextPowerOfTwo(benchSize), big.NewInt(-1))
+	assert.NoError(b, err)
+
+	// 1% of nonzero coefficients
+	nbTerms := benchSize / 100
+	indices := make([]uint64, nbTerms)
+	values := make([]fr.Element, nbTerms)
+	for i := range indices {
+		indices[i] = uint64(i * 100)
+		values[i].SetRandom()
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = CommitSparse(indices, values, srs.Pk)
+	}
+}
+
 func BenchmarkKZGCommit(b *testing.B) {
 
 	b.Run("real SRS", func(b *testing.B) {
@@ -0,0 +1,332 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls24315
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// ErrBatchBufferSize is returned by the batch decoding functions when the input
+// buffer is not a multiple of the point size.
+var ErrBatchBufferSize = errors.New("buffer size is not a multiple of the point size")
+
+// BatchDecompressionError is returned by the batch decompression functions when
+// some of the points could not be decoded. Indices holds the positions (in the input
+// buffer, in point units) of all the invalid points, in increasing order, and Errs
+// the corresponding decoding errors.
+type BatchDecompressionError struct {
+	Indices []int
+	Errs    []error
+}
+
+func (e *BatchDecompressionError) Error() string {
+	if len(e.Indices) == 1 {
+		return fmt.Sprintf("point decompression failed at index %d: %v", e.Indices[0], e.Errs[0])
+	}
+	return fmt.Sprintf("point decompression failed for %d points, first at index %d: %v", len(e.Indices), e.Indices[0], e.Errs[0])
+}
+
+// batchFailures collects decoding failures reported concurrently by batch decompression tasks.
+type batchFailures struct {
+	lock    sync.Mutex
+	indices []int
+	errs    []error
+}
+
+func (f *batchFailures) add(i int, err error) {
+	f.lock.Lock()
+	f.indices = append(f.indices, i)
+	f.errs = append(f.errs, err)
+	f.lock.Unlock()
+}
+
+// sorted returns the failures ordered by index, or nil if there are none.
+func (f *batchFailures) sorted() *BatchDecompressionError {
+	if len(f.indices) == 0 {
+		return nil
+	}
+	perm := make([]int, len(f.indices))
+	for i := range perm {
+		perm[i] = i
+	}
+	sort.Slice(perm, func(i, j int) bool { return f.indices[perm[i]] < f.indices[perm[j]] })
+	e := &BatchDecompressionError{Indices: make([]int, len(perm)), Errs: make([]error, len(perm))}
+	for i, j := range perm {
+		e.Indices[i], e.Errs[i] = f.indices[j], f.errs[j]
+	}
+	return e
+}
+
+// BatchDecompressOption configures the batch decompression functions.
+type BatchDecompressOption func(*batchDecompressConfig)
+
+type batchDecompressConfig struct {
+	batchSubGroupCheck bool
+}
+
+// WithBatchSubGroupCheck replaces the subgroup check of each point by a single batch
+// check of all the valid points (see BatchIsInSubGroupG1). It is much faster
+// on large inputs. If the batch check fails, the points are checked one by one to report
+// the invalid ones.
+func WithBatchSubGroupCheck() BatchDecompressOption {
+	return func(cfg *batchDecompressConfig) {
+		cfg.batchSubGroupCheck = true
+	}
+}
+
+// BatchDecompressG1Affine decodes buf, the concatenation of compressed G1Affine points
+// (as produced by Bytes), checking in parallel that each point is on the curve and in the subgroup.
+//
+// If some points are invalid, it returns a *BatchDecompressionError listing the exact indices
+// of all of them, not only the first one. The returned slice is nil in that case.
+func BatchDecompressG1Affine(buf []byte, opts ...BatchDecompressOption) ([]G1Affine, error) {
+	points, failures, err := batchDecodeG1Affine(buf, true, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if e := failures.sorted(); e != nil {
+		return nil, e
+	}
+	return points, nil
+}
+
+// BatchDecompressG1AffineSkipInvalid decodes buf like BatchDecompressG1Affine, but does
+// not fail on invalid points: they are dropped from the result and reported in skipped.
+// The valid points are returned in their original order; err is only set if buf
+// is malformed as a whole.
+func BatchDecompressG1AffineSkipInvalid(buf []byte, opts ...BatchDecompressOption) (points []G1Affine, skipped *BatchDecompressionError, err error) {
+	all, failures, err := batchDecodeG1Affine(buf, true, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	skipped = failures.sorted()
+	if skipped == nil {
+		return all, nil, nil
+	}
+	points = make([]G1Affine, 0, len(all)-len(skipped.Indices))
+	next := 0
+	for _, i := range skipped.Indices {
+		points = append(points, all[next:i]...)
+		next = i + 1
+	}
+	points = append(points, all[next:]...)
+	return points, skipped, nil
+}
+
+// BatchDecodeG1Affine decodes buf, the concatenation of G1Affine points all in the same
+// form: compressed (as produced by Bytes) or uncompressed (as produced by RawBytes), as
+// announced by the metadata of the first point. The points are decoded and checked to be
+// on the curve and in the subgroup in parallel.
+//
+// As BatchDecompressG1Affine, if some points are invalid, it returns a single
+// *BatchDecompressionError listing all of them, and a nil slice.
+func BatchDecodeG1Affine(buf []byte, opts ...BatchDecompressOption) ([]G1Affine, error) {
+	compressed := len(buf) == 0 || isCompressed(buf[0])
+	points, failures, err := batchDecodeG1Affine(buf, compressed, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if e := failures.sorted(); e != nil {
+		return nil, e
+	}
+	return points, nil
+}
+
+func batchDecodeG1Affine(buf []byte, compressed bool, opts ...BatchDecompressOption) ([]G1Affine, *batchFailures, error) {
+	pointSize := SizeOfG1AffineUncompressed
+	if compressed {
+		pointSize = SizeOfG1AffineCompressed
+	}
+	if len(buf)%pointSize != 0 {
+		return nil, nil, ErrBatchBufferSize
+	}
+	var cfg batchDecompressConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	n := len(buf) / pointSize
+	points := make([]G1Affine, n)
+	var failures batchFailures
+	parallel.Execute(n, func(start, end int) {
+		for i := start; i < end; i++ {
+			pBuf := buf[i*pointSize : (i+1)*pointSize]
+			if isCompressed(pBuf[0]) != compressed {
+				failures.add(i, ErrInvalidEncoding)
+				continue
+			}
+			if _, err := points[i].setBytes(pBuf, !cfg.batchSubGroupCheck); err != nil {
+				failures.add(i, err)
+			}
+		}
+	})
+	if cfg.batchSubGroupCheck {
+		batchSubGroupCheckG1Affine(points, &failures)
+	}
+	return points, &failures, nil
+}
+
+// batchSubGroupCheckG1Affine checks that the points which were decoded successfully are in
+// the subgroup, with a batch check first, and records the failures.
+func batchSubGroupCheckG1Affine(points []G1Affine, failures *batchFailures) {
+	failed := make(map[int]bool, len(failures.indices))
+	for _, i := range failures.indices {
+		failed[i] = true
+	}
+	valid := points
+	if len(failed) != 0 {
+		valid = make([]G1Affine, 0, len(points)-len(failed))
+		for i := range points {
+			if !failed[i] {
+				valid = append(valid, points[i])
+			}
+		}
+	}
+	if BatchIsInSubGroupG1(valid) {
+		return
+	}
+	parallel.Execute(len(points), func(start, end int) {
+		for i := start; i < end; i++ {
+			if !failed[i] && !points[i].IsInSubGroup() {
+				failures.add(i, errors.New("invalid point: subgroup check failed"))
+			}
+		}
+	})
+}
+
+// BatchDecompressG2Affine decodes buf, the concatenation of compressed G2Affine points
+// (as produced by Bytes), checking in parallel that each point is on the curve and in the subgroup.
+//
+// If some points are invalid, it returns a *BatchDecompressionError listing the exact indices
+// of all of them, not only the first one. The returned slice is nil in that case.
+func BatchDecompressG2Affine(buf []byte, opts ...BatchDecompressOption) ([]G2Affine, error) {
+	points, failures, err := batchDecodeG2Affine(buf, true, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if e := failures.sorted(); e != nil {
+		return nil, e
+	}
+	return points, nil
+}
+
+// BatchDecompressG2AffineSkipInvalid decodes buf like BatchDecompressG2Affine, but does
+// not fail on invalid points: they are dropped from the result and reported in skipped.
+// The valid points are returned in their original order; err is only set if buf
+// is malformed as a whole.
+func BatchDecompressG2AffineSkipInvalid(buf []byte, opts ...BatchDecompressOption) (points []G2Affine, skipped *BatchDecompressionError, err error) {
+	all, failures, err := batchDecodeG2Affine(buf, true, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	skipped = failures.sorted()
+	if skipped == nil {
+		return all, nil, nil
+	}
+	points = make([]G2Affine, 0, len(all)-len(skipped.Indices))
+	next := 0
+	for _, i := range skipped.Indices {
+		points = append(points, all[next:i]...)
+		next = i + 1
+	}
+	points = append(points, all[next:]...)
+	return points, skipped, nil
+}
+
+// BatchDecodeG2Affine decodes buf, the concatenation of G2Affine points all in the same
+// form: compressed (as produced by Bytes) or uncompressed (as produced by RawBytes), as
+// announced by the metadata of the first point. The points are decoded and checked to be
+// on the curve and in the subgroup in parallel.
+//
+// As BatchDecompressG2Affine, if some points are invalid, it returns a single
+// *BatchDecompressionError listing all of them, and a nil slice.
+func BatchDecodeG2Affine(buf []byte, opts ...BatchDecompressOption) ([]G2Affine, error) {
+	compressed := len(buf) == 0 || isCompressed(buf[0])
+	points, failures, err := batchDecodeG2Affine(buf, compressed, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if e := failures.sorted(); e != nil {
+		return nil, e
+	}
+	return points, nil
+}
+
+func batchDecodeG2Affine(buf []byte, compressed bool, opts ...BatchDecompressOption) ([]G2Affine, *batchFailures, error) {
+	pointSize := SizeOfG2AffineUncompressed
+	if compressed {
+		pointSize = SizeOfG2AffineCompressed
+	}
+	if len(buf)%pointSize != 0 {
+		return nil, nil, ErrBatchBufferSize
+	}
+	var cfg batchDecompressConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	n := len(buf) / pointSize
+	points := make([]G2Affine, n)
+	var failures batchFailures
+	parallel.Execute(n, func(start, end int) {
+		for i := start; i < end; i++ {
+			pBuf := buf[i*pointSize : (i+1)*pointSize]
+			if isCompressed(pBuf[0]) != compressed {
+				failures.add(i, ErrInvalidEncoding)
+				continue
+			}
+			if _, err := points[i].setBytes(pBuf, !cfg.batchSubGroupCheck); err != nil {
+				failures.add(i, err)
+			}
+		}
+	})
+	if cfg.batchSubGroupCheck {
+		batchSubGroupCheckG2Affine(points, &failures)
+	}
+	return points, &failures, nil
+}
+
+// batchSubGroupCheckG2Affine checks that the points which were decoded successfully are in
+// the subgroup, with a batch check first, and records the failures.
+func batchSubGroupCheckG2Affine(points []G2Affine, failures *batchFailures) {
+	failed := make(map[int]bool, len(failures.indices))
+	for _, i := range failures.indices {
+		failed[i] = true
+	}
+	valid := points
+	if len(failed) != 0 {
+		valid = make([]G2Affine, 0, len(points)-len(failed))
+		for i := range points {
+			if !failed[i] {
+				valid = append(valid, points[i])
+			}
+		}
+	}
+	if BatchIsInSubGroupG2(valid) {
+		return
+	}
+	parallel.Execute(len(points), func(start, end int) {
+		for i := start; i < end; i++ {
+			if !failed[i] && !points[i].IsInSubGroup() {
+				failures.add(i, errors.New("invalid point: subgroup check failed"))
+			}
+		}
+	})
+}
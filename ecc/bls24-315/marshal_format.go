@@ -0,0 +1,358 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls24315
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/internal/fptower"
+)
+
+// PointFormat identifies a binary encoding of points, to interoperate with other libraries.
+//
+// In every format, a compressed encoding stores the X coordinate and an uncompressed
+// encoding stores X then Y, each on SizeOfG1AffineCompressed bytes for G1Affine
+// (SizeOfG2AffineCompressed bytes for G2Affine). Metadata is stored in the
+// unused most significant bits of the coordinates.
+type PointFormat uint8
+
+const (
+	// FormatGnark is the encoding of Bytes and RawBytes. Field elements are big endian and
+	// the extension field elements are written from the highest degree coefficient.
+	// The 3 most significant bits of the first byte are the ZCash flags:
+	//
+	//	bit 7 -> compressed
+	//	bit 6 -> point at infinity (the rest of the encoding is zero)
+	//	bit 5 -> compressed, and Y is lexicographically larger than -Y
+	FormatGnark PointFormat = iota
+
+	// FormatArkworks is the encoding of the arkworks (ark-serialize) libraries.
+	// Field elements are little endian and the extension field elements are written from
+	// the lowest degree coefficient. The 2 most significant bits of the last byte are:
+	//
+	//	bit 7 -> compressed, and Y is lexicographically larger than -Y
+	//	bit 6 -> point at infinity (the rest of the encoding is zero)
+	//
+	// The compression is given by the size of the encoding.
+	FormatArkworks
+)
+
+// FormatZCash is the ZCash / IETF encoding, also used by blst. On bls24-315, it
+// is the same as FormatGnark.
+const FormatZCash = FormatGnark
+
+// ErrUnknownPointFormat is returned when encoding or decoding points with an unsupported PointFormat.
+var ErrUnknownPointFormat = errors.New("unknown point format")
+
+// arkworks flags, in the most significant bits of the last byte
+const (
+	mArkworksMask        byte = 0b11 << 6
+	mArkworksYIsNegative byte = 0b10 << 6
+	mArkworksInfinity    byte = 0b01 << 6
+)
+
+// MarshalFormat returns the encoding of p in the given format, compressed or not.
+func (p *G1Affine) MarshalFormat(format PointFormat, compressed bool) ([]byte, error) {
+	switch format {
+	case FormatGnark:
+		if compressed {
+			b := p.Bytes()
+			return b[:], nil
+		}
+		b := p.RawBytes()
+		return b[:], nil
+	case FormatArkworks:
+		return p.marshalArkworks(compressed), nil
+	default:
+		return nil, ErrUnknownPointFormat
+	}
+}
+
+// UnmarshalFormat sets p from its encoding in the given format. buf must be exactly
+// SizeOfG1AffineCompressed bytes for a compressed encoding, or SizeOfG1AffineUncompressed
+// bytes for an uncompressed one.
+//
+// It checks that the point is on the curve and in the correct subgroup.
+func (p *G1Affine) UnmarshalFormat(buf []byte, format PointFormat) error {
+	switch format {
+	case FormatGnark:
+		n, err := p.setBytes(buf, true)
+		if err != nil {
+			return err
+		}
+		if n != len(buf) {
+			return ErrInvalidEncoding
+		}
+		return nil
+	case FormatArkworks:
+		return p.unmarshalArkworks(buf)
+	default:
+		return ErrUnknownPointFormat
+	}
+}
+
+func (p *G1Affine) marshalArkworks(compressed bool) []byte {
+	size := SizeOfG1AffineUncompressed
+	if compressed {
+		size = SizeOfG1AffineCompressed
+	}
+	res := make([]byte, size)
+	if p.IsInfinity() {
+		res[size-1] = mArkworksInfinity
+		return res
+	}
+
+	putG1AffineCoordinateArkworks(res[:SizeOfG1AffineCompressed], &p.X)
+	if !compressed {
+		putG1AffineCoordinateArkworks(res[SizeOfG1AffineCompressed:], &p.Y)
+		return res
+	}
+	if p.Y.LexicographicallyLargest() {
+		res[size-1] |= mArkworksYIsNegative
+	}
+	return res
+}
+
+func (p *G1Affine) unmarshalArkworks(buf []byte) error {
+	var compressed bool
+	switch len(buf) {
+	case SizeOfG1AffineCompressed:
+		compressed = true
+	case SizeOfG1AffineUncompressed:
+	default:
+		return ErrInvalidEncoding
+	}
+
+	// copy the buffer without the flags
+	var b [SizeOfG1AffineUncompressed]byte
+	copy(b[:], buf)
+	flags := buf[len(buf)-1] & mArkworksMask
+	b[len(buf)-1] &^= mArkworksMask
+
+	switch flags {
+	case mArkworksInfinity:
+		if !isZeroed(0, b[:]) {
+			return ErrInvalidInfinityEncoding
+		}
+		p.X.SetZero()
+		p.Y.SetZero()
+		return nil
+	case mArkworksMask:
+		return ErrInvalidEncoding
+	case mArkworksYIsNegative:
+		if !compressed {
+			return ErrInvalidEncoding
+		}
+	}
+
+	if err := setG1AffineCoordinateArkworks(&p.X, b[:SizeOfG1AffineCompressed]); err != nil {
+		return err
+	}
+
+	if compressed {
+		var YSquared, Y fp.Element
+		YSquared.Square(&p.X).Mul(&YSquared, &p.X)
+		YSquared.Add(&YSquared, &bCurveCoeff)
+		if Y.Sqrt(&YSquared) == nil {
+			return errors.New("invalid compressed coordinate: square root doesn't exist")
+		}
+		if Y.LexicographicallyLargest() != (flags == mArkworksYIsNegative) {
+			Y.Neg(&Y)
+		}
+		p.Y.Set(&Y)
+	} else {
+		if err := setG1AffineCoordinateArkworks(&p.Y, b[SizeOfG1AffineCompressed:]); err != nil {
+			return err
+		}
+		// (0, 0) is not on the curve, the point at infinity must be flagged
+		if p.IsInfinity() {
+			return ErrInvalidInfinityEncoding
+		}
+		if !p.IsOnCurve() {
+			return errors.New("invalid point: not on the curve")
+		}
+	}
+
+	if !p.IsInSubGroup() {
+		return errors.New("invalid point: subgroup check failed")
+	}
+	return nil
+}
+
+// putG1AffineCoordinateArkworks writes x in little endian, lowest degree coefficient first
+func putG1AffineCoordinateArkworks(buf []byte, x *fp.Element) {
+	fp.LittleEndian.PutElement((*[fp.Bytes]byte)(buf[:fp.Bytes]), *x)
+}
+
+// setG1AffineCoordinateArkworks reads x in little endian, lowest degree coefficient first
+func setG1AffineCoordinateArkworks(x *fp.Element, buf []byte) (err error) {
+	*x, err = fp.LittleEndian.Element((*[fp.Bytes]byte)(buf[:fp.Bytes]))
+	return
+}
+
+// MarshalFormat returns the encoding of p in the given format, compressed or not.
+func (p *G2Affine) MarshalFormat(format PointFormat, compressed bool) ([]byte, error) {
+	switch format {
+	case FormatGnark:
+		if compressed {
+			b := p.Bytes()
+			return b[:], nil
+		}
+		b := p.RawBytes()
+		return b[:], nil
+	case FormatArkworks:
+		return p.marshalArkworks(compressed), nil
+	default:
+		return nil, ErrUnknownPointFormat
+	}
+}
+
+// UnmarshalFormat sets p from its encoding in the given format. buf must be exactly
+// SizeOfG2AffineCompressed bytes for a compressed encoding, or SizeOfG2AffineUncompressed
+// bytes for an uncompressed one.
+//
+// It checks that the point is on the curve and in the correct subgroup.
+func (p *G2Affine) UnmarshalFormat(buf []byte, format PointFormat) error {
+	switch format {
+	case FormatGnark:
+		n, err := p.setBytes(buf, true)
+		if err != nil {
+			return err
+		}
+		if n != len(buf) {
+			return ErrInvalidEncoding
+		}
+		return nil
+	case FormatArkworks:
+		return p.unmarshalArkworks(buf)
+	default:
+		return ErrUnknownPointFormat
+	}
+}
+
+func (p *G2Affine) marshalArkworks(compressed bool) []byte {
+	size := SizeOfG2AffineUncompressed
+	if compressed {
+		size = SizeOfG2AffineCompressed
+	}
+	res := make([]byte, size)
+	if p.IsInfinity() {
+		res[size-1] = mArkworksInfinity
+		return res
+	}
+
+	putG2AffineCoordinateArkworks(res[:SizeOfG2AffineCompressed], &p.X)
+	if !compressed {
+		putG2AffineCoordinateArkworks(res[SizeOfG2AffineCompressed:], &p.Y)
+		return res
+	}
+	if p.Y.LexicographicallyLargest() {
+		res[size-1] |= mArkworksYIsNegative
+	}
+	return res
+}
+
+func (p *G2Affine) unmarshalArkworks(buf []byte) error {
+	var compressed bool
+	switch len(buf) {
+	case SizeOfG2AffineCompressed:
+		compressed = true
+	case SizeOfG2AffineUncompressed:
+	default:
+		return ErrInvalidEncoding
+	}
+
+	// copy the buffer without the flags
+	var b [SizeOfG2AffineUncompressed]byte
+	copy(b[:], buf)
+	flags := buf[len(buf)-1] & mArkworksMask
+	b[len(buf)-1] &^= mArkworksMask
+
+	switch flags {
+	case mArkworksInfinity:
+		if !isZeroed(0, b[:]) {
+			return ErrInvalidInfinityEncoding
+		}
+		p.X.SetZero()
+		p.Y.SetZero()
+		return nil
+	case mArkworksMask:
+		return ErrInvalidEncoding
+	case mArkworksYIsNegative:
+		if !compressed {
+			return ErrInvalidEncoding
+		}
+	}
+
+	if err := setG2AffineCoordinateArkworks(&p.X, b[:SizeOfG2AffineCompressed]); err != nil {
+		return err
+	}
+
+	if compressed {
+		var YSquared, Y fptower.E4
+		YSquared.Square(&p.X).Mul(&YSquared, &p.X)
+		YSquared.Add(&YSquared, &bTwistCurveCoeff)
+		if YSquared.Legendre() == -1 {
+			return errors.New("invalid compressed coordinate: square root doesn't exist")
+		}
+		Y.Sqrt(&YSquared)
+		if Y.LexicographicallyLargest() != (flags == mArkworksYIsNegative) {
+			Y.Neg(&Y)
+		}
+		p.Y.Set(&Y)
+	} else {
+		if err := setG2AffineCoordinateArkworks(&p.Y, b[SizeOfG2AffineCompressed:]); err != nil {
+			return err
+		}
+		// (0, 0) is not on the curve, the point at infinity must be flagged
+		if p.IsInfinity() {
+			return ErrInvalidInfinityEncoding
+		}
+		if !p.IsOnCurve() {
+			return errors.New("invalid point: not on the curve")
+		}
+	}
+
+	if !p.IsInSubGroup() {
+		return errors.New("invalid point: subgroup check failed")
+	}
+	return nil
+}
+
+// putG2AffineCoordinateArkworks writes x in little endian, lowest degree coefficient first
+func putG2AffineCoordinateArkworks(buf []byte, x *fptower.E4) {
+	fp.LittleEndian.PutElement((*[fp.Bytes]byte)(buf[:fp.Bytes]), x.B0.A0)
+	fp.LittleEndian.PutElement((*[fp.Bytes]byte)(buf[fp.Bytes:fp.Bytes*2]), x.B0.A1)
+	fp.LittleEndian.PutElement((*[fp.Bytes]byte)(buf[fp.Bytes*2:fp.Bytes*3]), x.B1.A0)
+	fp.LittleEndian.PutElement((*[fp.Bytes]byte)(buf[fp.Bytes*3:fp.Bytes*4]), x.B1.A1)
+}
+
+// setG2AffineCoordinateArkworks reads x in little endian, lowest degree coefficient first
+func setG2AffineCoordinateArkworks(x *fptower.E4, buf []byte) (err error) {
+	if x.B0.A0, err = fp.LittleEndian.Element((*[fp.Bytes]byte)(buf[:fp.Bytes])); err != nil {
+		return
+	}
+	if x.B0.A1, err = fp.LittleEndian.Element((*[fp.Bytes]byte)(buf[fp.Bytes : fp.Bytes*2])); err != nil {
+		return
+	}
+	if x.B1.A0, err = fp.LittleEndian.Element((*[fp.Bytes]byte)(buf[fp.Bytes*2 : fp.Bytes*3])); err != nil {
+		return
+	}
+	x.B1.A1, err = fp.LittleEndian.Element((*[fp.Bytes]byte)(buf[fp.Bytes*3 : fp.Bytes*4]))
+	return
+}
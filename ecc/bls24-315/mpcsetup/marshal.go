@@ -0,0 +1,104 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package mpcsetup
+
+import (
+	"crypto/sha256"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315"
+)
+
+// WriteTo writes the binary encoding of the transcript to w, with compressed points.
+func (p *Phase1) WriteTo(w io.Writer) (int64, error) {
+	n, err := p.writeTo(w)
+	if err != nil {
+		return n, err
+	}
+	m, err := w.Write(p.Hash)
+	return n + int64(m), err
+}
+
+// WriteRawTo writes the binary encoding of the transcript to w, without point compression.
+func (p *Phase1) WriteRawTo(w io.Writer) (int64, error) {
+	n, err := p.writeTo(w, bls24315.RawEncoding())
+	if err != nil {
+		return n, err
+	}
+	m, err := w.Write(p.Hash)
+	return n + int64(m), err
+}
+
+// writeTo encodes the parameters and public keys of the transcript, not its hash.
+func (p *Phase1) writeTo(w io.Writer, options ...func(*bls24315.Encoder)) (int64, error) {
+	enc := bls24315.NewEncoder(w, options...)
+	toEncode := []interface{}{
+		p.Parameters.G1.Tau,
+		p.Parameters.G1.AlphaTau,
+		p.Parameters.G1.BetaTau,
+		p.Parameters.G2.Tau,
+		&p.Parameters.G2.Beta,
+		&p.PublicKeys.Tau.SG,
+		&p.PublicKeys.Tau.SXG,
+		&p.PublicKeys.Tau.XR,
+		&p.PublicKeys.Alpha.SG,
+		&p.PublicKeys.Alpha.SXG,
+		&p.PublicKeys.Alpha.XR,
+		&p.PublicKeys.Beta.SG,
+		&p.PublicKeys.Beta.SXG,
+		&p.PublicKeys.Beta.XR,
+	}
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes a transcript written by WriteTo or WriteRawTo from r. The points are
+// checked to be in their subgroup; Verify and VerifyPhase1 check the rest.
+func (p *Phase1) ReadFrom(r io.Reader) (int64, error) {
+	dec := bls24315.NewDecoder(r)
+	toDecode := []interface{}{
+		&p.Parameters.G1.Tau,
+		&p.Parameters.G1.AlphaTau,
+		&p.Parameters.G1.BetaTau,
+		&p.Parameters.G2.Tau,
+		&p.Parameters.G2.Beta,
+		&p.PublicKeys.Tau.SG,
+		&p.PublicKeys.Tau.SXG,
+		&p.PublicKeys.Tau.XR,
+		&p.PublicKeys.Alpha.SG,
+		&p.PublicKeys.Alpha.SXG,
+		&p.PublicKeys.Alpha.XR,
+		&p.PublicKeys.Beta.SG,
+		&p.PublicKeys.Beta.SXG,
+		&p.PublicKeys.Beta.XR,
+	}
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+	if !p.isWellSized(p.Size()) {
+		return dec.BytesRead(), ErrMalformedTranscript
+	}
+	p.Hash = make([]byte, sha256.Size)
+	n, err := io.ReadFull(r, p.Hash)
+	return dec.BytesRead() + int64(n), err
+}
@@ -0,0 +1,221 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package mpcsetup
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/kzg"
+)
+
+func TestPhase1(t *testing.T) {
+	const n = 4
+	c0, err := NewPhase1(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c1 := c0.Clone()
+	if err := c1.Contribute(rand.Reader); err != nil {
+		t.Fatal(err)
+	}
+	c2 := c1.Clone()
+	if err := c2.Contribute(rand.Reader); err != nil {
+		t.Fatal(err)
+	}
+	beacon := []byte("beacon")
+	c3 := c2.Clone()
+	if err := c3.Seal(beacon); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := VerifyPhase1(c0, c1, c2, c3); err != nil {
+		t.Fatal(err)
+	}
+	if err := c2.VerifySeal(c3, beacon); err != nil {
+		t.Fatal(err)
+	}
+	if err := c2.VerifySeal(c3, []byte("another beacon")); !errors.Is(err, ErrInvalidSeal) {
+		t.Fatalf("expected ErrInvalidSeal, got %v", err)
+	}
+	if err := c1.VerifySeal(c2, beacon); !errors.Is(err, ErrInvalidSeal) {
+		t.Fatalf("expected ErrInvalidSeal, got %v", err)
+	}
+
+	// the order of the contributions matters
+	if err := VerifyPhase1(c0, c2, c1); err == nil {
+		t.Fatal("verifying contributions out of order should fail")
+	}
+	if err := VerifyPhase1(c1, c2); !errors.Is(err, ErrMalformedTranscript) {
+		t.Fatalf("expected ErrMalformedTranscript, got %v", err)
+	}
+
+	// serialization
+	for _, raw := range []bool{false, true} {
+		var buf bytes.Buffer
+		if raw {
+			_, err = c3.WriteRawTo(&buf)
+		} else {
+			_, err = c3.WriteTo(&buf)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded Phase1
+		if _, err := decoded.ReadFrom(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if err := c2.Verify(&decoded); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestPhase1InvalidContribution(t *testing.T) {
+	const n = 4
+	c0, err := NewPhase1(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c1 := c0.Clone()
+	if err := c1.Contribute(rand.Reader); err != nil {
+		t.Fatal(err)
+	}
+
+	// a power of τ is tampered with
+	c := c1.Clone()
+	c.Parameters.G1.Tau[2] = c.Parameters.G1.Tau[3]
+	c.Hash = c.hash(c0.Hash)
+	if err := c0.Verify(c); !errors.Is(err, ErrInconsistentPowers) {
+		t.Fatalf("expected ErrInconsistentPowers, got %v", err)
+	}
+
+	// [β]₂ doesn't match [β]₁
+	c = c1.Clone()
+	c.Parameters.G2.Beta = c.Parameters.G2.Tau[1]
+	c.Hash = c.hash(c0.Hash)
+	if err := c0.Verify(c); !errors.Is(err, ErrInconsistentPowers) {
+		t.Fatalf("expected ErrInconsistentPowers, got %v", err)
+	}
+
+	// the proof of knowledge of another contribution
+	c = c1.Clone()
+	if err := c.Contribute(rand.Reader); err != nil {
+		t.Fatal(err)
+	}
+	c.PublicKeys = c1.PublicKeys
+	c.Hash = c.hash(c0.Hash)
+	if err := c0.Verify(c); !errors.Is(err, ErrInconsistentUpdate) {
+		t.Fatalf("expected ErrInconsistentUpdate, got %v", err)
+	}
+
+	// a proof of knowledge for another challenge
+	c = c1.Clone()
+	c.PublicKeys.Tau.XR = c.PublicKeys.Alpha.XR
+	c.Hash = c.hash(c0.Hash)
+	if err := c0.Verify(c); !errors.Is(err, ErrProofOfKnowledge) {
+		t.Fatalf("expected ErrProofOfKnowledge, got %v", err)
+	}
+
+	// the hash doesn't chain
+	c = c1.Clone()
+	c.Hash[0] ^= 1
+	if err := c0.Verify(c); !errors.Is(err, ErrInvalidHash) {
+		t.Fatalf("expected ErrInvalidHash, got %v", err)
+	}
+
+	// another size
+	c2, err := NewPhase1(n + 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c0.Verify(c2); !errors.Is(err, ErrMalformedTranscript) {
+		t.Fatalf("expected ErrMalformedTranscript, got %v", err)
+	}
+}
+
+func TestPhase1SRS(t *testing.T) {
+	const n = 4
+	c, err := NewPhase1(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Contribute(rand.Reader); err != nil {
+		t.Fatal(err)
+	}
+	srs, err := c.SRS(2*n - 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := make([]fr.Element, 2*n-1)
+	for i := range p {
+		p[i].SetRandom()
+	}
+	var point fr.Element
+	point.SetRandom()
+	digest, err := kzg.Commit(p, srs.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, err := kzg.Open(p, point, srs.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := kzg.Verify(&digest, &proof, point, srs.Vk); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.SRS(2 * n); !errors.Is(err, ErrMalformedTranscript) {
+		t.Fatalf("expected ErrMalformedTranscript, got %v", err)
+	}
+}
+
+func BenchmarkPhase1Contribute(b *testing.B) {
+	const n = 1 << 8
+	c, err := NewPhase1(n)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := c.Contribute(rand.Reader); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPhase1Verify(b *testing.B) {
+	const n = 1 << 8
+	c0, err := NewPhase1(n)
+	if err != nil {
+		b.Fatal(err)
+	}
+	c1 := c0.Clone()
+	if err := c1.Contribute(rand.Reader); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := c0.Verify(c1); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
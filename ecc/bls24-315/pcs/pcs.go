@@ -0,0 +1,60 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package pcs
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+)
+
+var (
+	ErrInvalidNbDigests = errors.New("number of digests is not the same as the number of polynomials or proofs")
+	ErrDigestMismatch   = errors.New("the opening proof is not for the digest")
+)
+
+// PolynomialCommitment is a polynomial commitment scheme, with digests of type D, opening
+// proofs of type P and proofs of type B for batch openings at a single point.
+//
+// Polynomials are given by their coefficients in canonical basis. The claimed values
+// are carried by the proofs and read with ClaimedValue and BatchClaimedValues.
+type PolynomialCommitment[D, P, B any] interface {
+
+	// Commit commits to the polynomial p.
+	Commit(p []fr.Element) (D, error)
+
+	// Open proves the evaluation of p at point.
+	Open(p []fr.Element, point fr.Element) (P, error)
+
+	// BatchOpen proves the evaluations of the polynomials, committed in digests, at point.
+	BatchOpen(polynomials [][]fr.Element, digests []D, point fr.Element) (B, error)
+
+	// Verify verifies that the polynomial committed in digest evaluates to
+	// ClaimedValue(proof) at point.
+	Verify(digest D, proof P, point fr.Element) error
+
+	// BatchVerify verifies that the polynomials committed in digests evaluate to
+	// BatchClaimedValues(proof) at point.
+	BatchVerify(digests []D, proof B, point fr.Element) error
+
+	// ClaimedValue returns the evaluation proven by proof.
+	ClaimedValue(proof P) fr.Element
+
+	// BatchClaimedValues returns the evaluations proven by proof, in the order of
+	// the digests.
+	BatchClaimedValues(proof B) []fr.Element
+}
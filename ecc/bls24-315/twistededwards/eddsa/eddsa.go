@@ -17,6 +17,7 @@
 package eddsa
 
 import (
+	"crypto/rand"
 	"crypto/subtle"
 	"errors"
 	"hash"
@@ -115,10 +116,37 @@ func (privKey *PrivateKey) Public() signature.PublicKey {
 	return &pub
 }
 
+// SignOption configures the signing of a message.
+type SignOption func(*signConfig)
+
+type signConfig struct {
+	blinding bool
+}
+
+// WithBlinding hardens the signing against side-channel attacks, for signers
+// running on hardware shared with potential attackers. The signatures are the
+// same as without blinding, at about twice the cost.
+//
+// The secret scalar r of R = r ⋅ Base is randomly split in r₁ + r₂, and R is
+// computed as r₁ ⋅ B' + r₂ ⋅ B' where B' is the base point in projective
+// coordinates with a random Z. The private scalar s is split in the same way in
+// the computation of r + H(R,A,M) ⋅ s. The blinding factors are drawn from
+// crypto/rand.
+func WithBlinding() SignOption {
+	return func(c *signConfig) {
+		c.blinding = true
+	}
+}
+
 // Sign sign a sequence of field elements
 // For arbitrary strings use fr.Hash first
 // Pure Eddsa version (see https://tools.ietf.org/html/rfc8032#page-8)
 func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error) {
+	return privKey.SignWithOptions(message, hFunc)
+}
+
+// SignWithOptions is Sign, with optional blinding (see WithBlinding).
+func (privKey *PrivateKey) SignWithOptions(message []byte, hFunc hash.Hash, opts ...SignOption) ([]byte, error) {
 
 	// hFunc cannot be nil.
 	// We need a hash function for the Fiat-Shamir.
@@ -126,6 +154,11 @@ func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error)
 		return nil, errHashNeeded
 	}
 
+	var cfg signConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	curveParams := twistededwards.GetEdwardsCurve()
 
 	var res Signature
@@ -145,7 +178,13 @@ func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error)
 	blindingFactorBigInt.SetBytes(blindingFactorBytes[:sizeFr])
 
 	// compute R = randScalar*Base
-	res.R.ScalarMultiplication(&curveParams.Base, &blindingFactorBigInt)
+	if cfg.blinding {
+		if err := blindedScalarMulBase(&res.R, &curveParams, &blindingFactorBigInt); err != nil {
+			return nil, err
+		}
+	} else {
+		res.R.ScalarMultiplication(&curveParams.Base, &blindingFactorBigInt)
+	}
 	if !res.R.IsOnCurve() {
 		return nil, errNotOnCurve
 	}
@@ -172,9 +211,24 @@ func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error)
 	// going with big int to do ops mod curve order
 	var bscalar, bs big.Int
 	bscalar.SetBytes(privKey.scalar[:])
-	bs.Mul(&hramInt, &bscalar).
-		Add(&bs, &blindingFactorBigInt).
-		Mod(&bs, &curveParams.Order)
+	if cfg.blinding {
+		// s = s₁ + s₂ for a random s₁
+		s1, err := rand.Int(rand.Reader, &curveParams.Order)
+		if err != nil {
+			return nil, err
+		}
+		bscalar.Sub(&bscalar, s1).
+			Mod(&bscalar, &curveParams.Order)
+		s1.Mul(s1, &hramInt)
+		bs.Mul(&hramInt, &bscalar).
+			Add(&bs, s1).
+			Add(&bs, &blindingFactorBigInt).
+			Mod(&bs, &curveParams.Order)
+	} else {
+		bs.Mul(&hramInt, &bscalar).
+			Add(&bs, &blindingFactorBigInt).
+			Mod(&bs, &curveParams.Order)
+	}
 	sb := bs.Bytes()
 	if len(sb) < sizeFr {
 		offset := make([]byte, sizeFr-len(sb))
@@ -185,6 +239,36 @@ func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error)
 	return res.Bytes(), nil
 }
 
+// blindedScalarMulBase sets res = k ⋅ Base, computed as k₁ ⋅ B' + k₂ ⋅ B' for a
+// random split k₁ + k₂ of k, where B' is the base point in projective coordinates
+// with a random Z.
+func blindedScalarMulBase(res *twistededwards.PointAffine, curveParams *twistededwards.CurveParams, k *big.Int) error {
+	k1, err := rand.Int(rand.Reader, &curveParams.Order)
+	if err != nil {
+		return err
+	}
+	k2 := new(big.Int).Sub(k, k1)
+	k2.Mod(k2, &curveParams.Order)
+
+	var lambda fr.Element
+	for lambda.IsZero() {
+		if _, err := lambda.SetRandom(); err != nil {
+			return err
+		}
+	}
+	var base, p1, p2 twistededwards.PointProj
+	base.FromAffine(&curveParams.Base)
+	base.X.Mul(&base.X, &lambda)
+	base.Y.Mul(&base.Y, &lambda)
+	base.Z.Mul(&base.Z, &lambda)
+
+	p1.ScalarMultiplication(&base, k1)
+	p2.ScalarMultiplication(&base, k2)
+	p1.Add(&p1, &p2)
+	res.FromProj(&p1)
+	return nil
+}
+
 // Verify verifies an eddsa signature
 func (pub *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, error) {
 
@@ -207,26 +291,12 @@ func (pub *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, err
 		return false, err
 	}
 
-	// compute H(R, A, M), all parameters in data are in Montgomery form
-
-	hFunc.Reset()
-
-	sigRX := sig.R.X.Bytes()
-	sigRY := sig.R.Y.Bytes()
-	sigAX := pub.A.X.Bytes()
-	sigAY := pub.A.Y.Bytes()
-
-	toWrite := [][]byte{sigRX[:], sigRY[:], sigAX[:], sigAY[:], message}
-	for _, bytes := range toWrite {
-		if _, err := hFunc.Write(bytes); err != nil {
-			return false, err
-		}
+	// compute H(R, A, M)
+	hramInt, err := computeHRAM(hFunc, &sig.R, &pub.A, message)
+	if err != nil {
+		return false, err
 	}
 
-	var hramInt big.Int
-	hramBin := hFunc.Sum(nil)
-	hramInt.SetBytes(hramBin)
-
 	// lhs = cofactor*S*Base
 	var lhs twistededwards.PointAffine
 	var bCofactor, bs big.Int
@@ -255,3 +325,26 @@ func (pub *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, err
 
 	return true, nil
 }
+
+// computeHRAM computes H(R, A, M), all parameters in data are in Montgomery form
+func computeHRAM(hFunc hash.Hash, R, A *twistededwards.PointAffine, message []byte) (big.Int, error) {
+	var hramInt big.Int
+
+	hFunc.Reset()
+
+	sigRX := R.X.Bytes()
+	sigRY := R.Y.Bytes()
+	sigAX := A.X.Bytes()
+	sigAY := A.Y.Bytes()
+
+	toWrite := [][]byte{sigRX[:], sigRY[:], sigAX[:], sigAY[:], message}
+	for _, bytes := range toWrite {
+		if _, err := hFunc.Write(bytes); err != nil {
+			return hramInt, err
+		}
+	}
+
+	hramBin := hFunc.Sum(nil)
+	hramInt.SetBytes(hramBin)
+	return hramInt, nil
+}
@@ -17,6 +17,7 @@
 package eddsa
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"math/big"
 	"math/rand"
@@ -264,6 +265,115 @@ func TestEddsaSHA256(t *testing.T) {
 
 }
 
+func TestBlinding(t *testing.T) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src) //#nosec G404 weak rng is fine here
+
+	hFunc := sha256.New()
+
+	privKey, err := GenerateKey(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey := privKey.PublicKey
+
+	for i := 0; i < 10; i++ {
+		msg := []byte{byte(i)}
+
+		// blinding doesn't change the signatures
+		signature, err := privKey.Sign(msg, hFunc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		blinded, err := privKey.SignWithOptions(msg, hFunc, WithBlinding())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(signature, blinded) {
+			t.Fatal("blinded and unblinded signatures should be equal")
+		}
+
+		res, err := pubKey.Verify(blinded, msg, hFunc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res {
+			t.Fatal("Verify blinded signature should return true")
+		}
+	}
+}
+
+func TestBatchVerify(t *testing.T) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src) //#nosec G404 weak rng is fine here
+
+	hFunc := sha256.New()
+
+	const n = 8
+	pubs := make([]PublicKey, n)
+	sigs := make([][]byte, n)
+	msgs := make([][]byte, n)
+	var pubsBin []byte
+	for i := 0; i < n; i++ {
+		privKey, err := GenerateKey(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubs[i] = privKey.PublicKey
+		pubsBin = append(pubsBin, pubs[i].Bytes()...)
+		msgs[i] = []byte(fmt.Sprintf("message %d", i))
+		sigs[i], err = privKey.Sign(msgs[i], hFunc)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// public keys batch deserialization
+	decoded, err := BatchDecompressPublicKeys(pubsBin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range pubs {
+		if !decoded[i].Equal(&pubs[i]) {
+			t.Fatal("BatchDecompressPublicKeys(Bytes) should be the identity")
+		}
+	}
+
+	// verifies correct msgs
+	res, err := BatchVerify(pubs, sigs, msgs, hFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("BatchVerify correct signatures should return true")
+	}
+
+	// verifies a wrong msg
+	msgs[n/2] = []byte("wrong_message")
+	res, err = BatchVerify(pubs, sigs, msgs, hFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res {
+		t.Fatal("BatchVerify with a wrong signature should be false")
+	}
+
+	// wrong sizes
+	if _, err = BatchVerify(pubs, sigs[1:], msgs, hFunc); err != errBatchSize {
+		t.Fatal("BatchVerify should raise inconsistent batch sizes error")
+	}
+
+	// S overflows r_mod
+	cp := twistededwards.GetEdwardsCurve()
+	sigs[0] = append([]byte{}, sigs[0]...)
+	cp.Order.FillBytes(sigs[0][sizeFr:])
+	if _, err = BatchVerify(pubs, sigs, msgs, hFunc); err != errSBiggerThanRMod {
+		t.Fatal("BatchVerify should raise s >= r_mod error")
+	}
+}
+
 // benchmarks
 
 func BenchmarkVerify(b *testing.B) {
@@ -289,3 +399,33 @@ func BenchmarkVerify(b *testing.B) {
 		pubKey.Verify(signature, msgBin[:], hFunc)
 	}
 }
+
+func BenchmarkBatchVerify(b *testing.B) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src) //#nosec G404 weak rng is fine here
+
+	hFunc := hash.MIMC_BLS24_315.New()
+
+	const n = 64
+	pubs := make([]PublicKey, n)
+	sigs := make([][]byte, n)
+	msgs := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		privKey, err := GenerateKey(r)
+		if err != nil {
+			b.Fatal(err)
+		}
+		pubs[i] = privKey.PublicKey
+		var frMsg fr.Element
+		frMsg.SetUint64(uint64(i))
+		msgBin := frMsg.Bytes()
+		msgs[i] = msgBin[:]
+		sigs[i], _ = privKey.Sign(msgs[i], hFunc)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BatchVerify(pubs, sigs, msgs, hFunc)
+	}
+}
@@ -18,6 +18,7 @@ package twistededwards
 
 import (
 	"crypto/subtle"
+	"errors"
 	"io"
 	"math/big"
 	"math/bits"
@@ -129,6 +130,59 @@ func (p *PointAffine) SetBytes(buf []byte) (int, error) {
 	return sizePointCompressed, nil
 }
 
+// BatchDecompress decodes the concatenated compressed points in buf, recovering
+// all the x-coordinates with a single field inversion.
+// It returns an error if len(buf) is not a multiple of the size of a compressed
+// point or if one of the encodings is not a point on the curve.
+//
+// See PointAffine.SetBytes.
+func BatchDecompress(buf []byte) ([]PointAffine, error) {
+	if len(buf)%sizePointCompressed != 0 {
+		return nil, errors.New("invalid buffer size")
+	}
+	initOnce.Do(initCurveParams)
+
+	n := len(buf) / sizePointCompressed
+	points := make([]PointAffine, n)
+	negative := make([]bool, n)
+	num := make([]fr.Element, n)
+	den := make([]fr.Element, n)
+
+	var one fr.Element
+	one.SetOne()
+	var bufCopy [sizePointCompressed]byte
+	for i := range points {
+		chunk := buf[i*sizePointCompressed : (i+1)*sizePointCompressed]
+		for j := range bufCopy {
+			bufCopy[j] = chunk[sizePointCompressed-1-j]
+		}
+		negative[i] = (mCompressedNegative&bufCopy[0])>>7 == 1
+		bufCopy[0] &= mUnmask
+		points[i].Y.SetBytes(bufCopy[:])
+
+		// x² = (1 - y²)/(a - d·y²)
+		num[i].Square(&points[i].Y)
+		den[i].Mul(&num[i], &curveParams.D)
+		num[i].Sub(&one, &num[i])
+		den[i].Sub(&curveParams.A, &den[i])
+	}
+
+	den = fr.BatchInvert(den)
+
+	for i := range points {
+		p := &points[i]
+		p.X.Mul(&num[i], &den[i])
+		if p.X.Sqrt(&p.X) == nil || !p.IsOnCurve() {
+			return nil, errors.New("point not on curve")
+		}
+		if negative[i] != p.X.LexicographicallyLargest() {
+			p.X.Neg(&p.X)
+		}
+	}
+
+	return points, nil
+}
+
 // Unmarshal alias to SetBytes()
 func (p *PointAffine) Unmarshal(b []byte) error {
 	_, err := p.SetBytes(b)
@@ -254,6 +308,38 @@ func (p *PointAffine) FromExtended(p1 *PointExtended) *PointAffine {
 	return p
 }
 
+// BatchFromProj converts points in projective coordinates to affine coordinates,
+// performing a single field inversion using the Montgomery batch inversion trick.
+func BatchFromProj(points []PointProj) []PointAffine {
+	zs := make([]fr.Element, len(points))
+	for i := range points {
+		zs[i] = points[i].Z
+	}
+	zs = fr.BatchInvert(zs)
+	result := make([]PointAffine, len(points))
+	for i := range points {
+		result[i].X.Mul(&points[i].X, &zs[i])
+		result[i].Y.Mul(&points[i].Y, &zs[i])
+	}
+	return result
+}
+
+// BatchFromExtended converts points in extended coordinates to affine coordinates,
+// performing a single field inversion using the Montgomery batch inversion trick.
+func BatchFromExtended(points []PointExtended) []PointAffine {
+	zs := make([]fr.Element, len(points))
+	for i := range points {
+		zs[i] = points[i].Z
+	}
+	zs = fr.BatchInvert(zs)
+	result := make([]PointAffine, len(points))
+	for i := range points {
+		result[i].X.Mul(&points[i].X, &zs[i])
+		result[i].Y.Mul(&points[i].Y, &zs[i])
+	}
+	return result
+}
+
 // ScalarMultiplication scalar multiplication of a point
 // p1 in affine coordinates with a scalar in big.Int
 func (p *PointAffine) ScalarMultiplication(p1 *PointAffine, scalar *big.Int) *PointAffine {
@@ -266,8 +352,8 @@ func (p *PointAffine) ScalarMultiplication(p1 *PointAffine, scalar *big.Int) *Po
 	return p
 }
 
-// setInfinity sets p to O (0:1)
-func (p *PointAffine) setInfinity() *PointAffine {
+// SetInfinity sets p to O (0:1)
+func (p *PointAffine) SetInfinity() *PointAffine {
 	p.X.SetZero()
 	p.Y.SetOne()
 	return p
@@ -283,8 +369,8 @@ func (p *PointProj) Set(p1 *PointProj) *PointProj {
 	return p
 }
 
-// setInfinity sets p to O (0:1:1)
-func (p *PointProj) setInfinity() *PointProj {
+// SetInfinity sets p to O (0:1:1)
+func (p *PointProj) SetInfinity() *PointProj {
 	p.X.SetZero()
 	p.Y.SetOne()
 	p.Z.SetOne()
@@ -338,6 +424,14 @@ func (p *PointProj) FromAffine(p1 *PointAffine) *PointProj {
 	return p
 }
 
+// FromExtended sets p in projective from p in extended coordinates
+func (p *PointProj) FromExtended(p1 *PointExtended) *PointProj {
+	p.X.Set(&p1.X)
+	p.Y.Set(&p1.Y)
+	p.Z.Set(&p1.Z)
+	return p
+}
+
 // MixedAdd adds a point in projective to a point in affine coordinates
 // cf https://hyperelliptic.org/EFD/g1p/auto-twisted-projective.html#addition-madd-2008-bbjlp
 func (p *PointProj) MixedAdd(p1 *PointProj, p2 *PointAffine) *PointProj {
@@ -431,7 +525,7 @@ func (p *PointProj) scalarMulWindowed(p1 *PointProj, scalar *big.Int) *PointProj
 		p.Neg(p)
 	}
 	var resProj PointProj
-	resProj.setInfinity()
+	resProj.SetInfinity()
 	const wordSize = bits.UintSize
 	sWords := _scalar.Bits()
 
@@ -503,9 +597,51 @@ func (p *PointExtended) FromAffine(p1 *PointAffine) *PointExtended {
 	return p
 }
 
+// FromProj sets p in extended from p in projective coordinates
+func (p *PointExtended) FromProj(p1 *PointProj) *PointExtended {
+	var X, Y fr.Element
+	X.Set(&p1.X)
+	Y.Set(&p1.Y)
+	p.T.Mul(&X, &Y)
+	p.X.Mul(&X, &p1.Z)
+	p.Y.Mul(&Y, &p1.Z)
+	p.Z.Square(&p1.Z)
+	return p
+}
+
+// IsOnCurve checks if a point in extended coordinates is on the curve,
+// that is a⋅X²+Y² = Z²+d⋅T² and X⋅Y = Z⋅T with Z ≠ 0.
+func (p *PointExtended) IsOnCurve() bool {
+	initOnce.Do(initCurveParams)
+
+	if p.Z.IsZero() {
+		return false
+	}
+	var lhs, rhs, tmp fr.Element
+	lhs.Mul(&p.X, &p.Y)
+	rhs.Mul(&p.Z, &p.T)
+	if !lhs.Equal(&rhs) {
+		return false
+	}
+
+	lhs.Square(&p.X)
+	mulByA(&lhs)
+	tmp.Square(&p.Y)
+	lhs.Add(&lhs, &tmp)
+
+	rhs.Square(&p.T).
+		Mul(&rhs, &curveParams.D)
+	tmp.Square(&p.Z)
+	rhs.Add(&rhs, &tmp)
+
+	return lhs.Equal(&rhs)
+}
+
 // Add adds points in extended coordinates
 // See https://hyperelliptic.org/EFD/g1p/auto-twisted-extended.html#addition-add-2008-hwcd
 func (p *PointExtended) Add(p1, p2 *PointExtended) *PointExtended {
+	initOnce.Do(initCurveParams)
+
 	var A, B, C, D, E, F, G, H, tmp fr.Element
 	A.Mul(&p1.X, &p2.X)
 	B.Mul(&p1.Y, &p2.Y)
@@ -530,34 +666,29 @@ func (p *PointExtended) Add(p1, p2 *PointExtended) *PointExtended {
 	return p
 }
 
-// MixedAdd adds a point in extended coordinates to a point in affine coordinates
-// See https://hyperelliptic.org/EFD/g1p/auto-twisted-extended.html#addition-madd-2008-hwcd-2
+// MixedAdd adds a point in extended coordinates to a point in affine coordinates.
+// The formulas are complete: they hold for any inputs, including p1 = ±p2 and the identity.
+// See https://hyperelliptic.org/EFD/g1p/auto-twisted-extended.html#addition-madd-2008-hwcd
 func (p *PointExtended) MixedAdd(p1 *PointExtended, p2 *PointAffine) *PointExtended {
-	var A, B, C, D, E, F, G, H, tmp fr.Element
-
-	A.Mul(&p2.X, &p1.Z)
-	B.Mul(&p2.Y, &p1.Z)
-
-	if p1.X.Equal(&A) && p1.Y.Equal(&B) {
-		p.MixedDouble(p1)
-		return p
-	}
+	initOnce.Do(initCurveParams)
 
+	var A, B, C, D, E, F, G, H, tmp fr.Element
 	A.Mul(&p1.X, &p2.X)
 	B.Mul(&p1.Y, &p2.Y)
-	C.Mul(&p1.Z, &p2.X).
-		Mul(&C, &p2.Y)
-	D.Set(&p1.T)
-	E.Add(&D, &C)
-	tmp.Sub(&p1.X, &p1.Y)
-	F.Add(&p2.X, &p2.Y).
-		Mul(&F, &tmp).
-		Add(&F, &B).
-		Sub(&F, &A)
-	G.Set(&A)
-	mulByA(&G)
-	G.Add(&G, &B)
-	H.Sub(&D, &C)
+	C.Mul(&p2.X, &p2.Y).
+		Mul(&C, &p1.T).
+		Mul(&C, &curveParams.D)
+	D.Set(&p1.Z)
+	tmp.Add(&p1.X, &p1.Y)
+	E.Add(&p2.X, &p2.Y).
+		Mul(&E, &tmp).
+		Sub(&E, &A).
+		Sub(&E, &B)
+	F.Sub(&D, &C)
+	G.Add(&D, &C)
+	H.Set(&A)
+	mulByA(&H)
+	H.Sub(&B, &H)
 
 	p.X.Mul(&E, &F)
 	p.Y.Mul(&G, &H)
@@ -596,7 +727,7 @@ func (p *PointExtended) Double(p1 *PointExtended) *PointExtended {
 	return p
 }
 
-// MixedDouble adds points in extended coordinates
+// MixedDouble doubles a point in extended coordinates with Z = 1
 // Dedicated mixed doubling
 // https://hyperelliptic.org/EFD/g1p/auto-twisted-extended-1.html#doubling-mdbl-2008-hwcd
 func (p *PointExtended) MixedDouble(p1 *PointExtended) *PointExtended {
@@ -626,8 +757,8 @@ func (p *PointExtended) MixedDouble(p1 *PointExtended) *PointExtended {
 	return p
 }
 
-// setInfinity sets p to O (0:1:1:0)
-func (p *PointExtended) setInfinity() *PointExtended {
+// SetInfinity sets p to O (0:1:1:0)
+func (p *PointExtended) SetInfinity() *PointExtended {
 	p.X.SetZero()
 	p.Y.SetOne()
 	p.Z.SetOne()
@@ -647,7 +778,7 @@ func (p *PointExtended) scalarMulWindowed(p1 *PointExtended, scalar *big.Int) *P
 		p.Neg(p)
 	}
 	var resExtended PointExtended
-	resExtended.setInfinity()
+	resExtended.SetInfinity()
 	const wordSize = bits.UintSize
 	sWords := _scalar.Bits()
 
@@ -17,11 +17,12 @@
 package fft
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
 	"errors"
 	"io"
 	"math/big"
 	"math/bits"
-	"runtime"
 	"sync"
 
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
@@ -29,6 +30,7 @@ import (
 	curve "github.com/consensys/gnark-crypto/ecc/bls24-317"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/utils/concurrency"
 )
 
 // Domain with a power of 2 cardinality
@@ -62,6 +64,9 @@ type Domain struct {
 
 	// cosetTable[i][j] = domain.Generator(i-th)SqrtInv ^ j
 	cosetTableInv []fr.Element
+
+	// sub-domains of the six-step FFT, nil for domains smaller than sixStepMinSize
+	sixStep *sixStepPlan
 }
 
 // GeneratorFullMultiplicativeGroup returns a generator of 𝔽ᵣˣ
@@ -101,6 +106,7 @@ func NewDomain(m uint64, opts ...DomainOption) *Domain {
 	if domain.withPrecompute {
 		domain.preComputeTwiddles()
 	}
+	domain.sixStep = newSixStepPlan(domain.Cardinality)
 
 	return domain
 }
@@ -166,11 +172,11 @@ func (d *Domain) preComputeTwiddles() {
 
 	wg.Add(4)
 	go func() {
-		buildTwiddles(d.twiddles, d.Generator, nbStages)
+		buildTwiddles(d.twiddles, d.Generator, nbStages, nil)
 		wg.Done()
 	}()
 	go func() {
-		buildTwiddles(d.twiddlesInv, d.GeneratorInv, nbStages)
+		buildTwiddles(d.twiddlesInv, d.GeneratorInv, nbStages, nil)
 		wg.Done()
 	}()
 	go expTable(d.FrMultiplicativeGen, d.cosetTable)
@@ -180,7 +186,8 @@ func (d *Domain) preComputeTwiddles() {
 
 }
 
-func buildTwiddles(t [][]fr.Element, omega fr.Element, nbStages uint64) {
+// buildTwiddles builds the twiddles of the nbStages stages, allocated from arena.
+func buildTwiddles(t [][]fr.Element, omega fr.Element, nbStages uint64, arena *Arena) {
 	if nbStages == 0 {
 		return
 	}
@@ -188,12 +195,12 @@ func buildTwiddles(t [][]fr.Element, omega fr.Element, nbStages uint64) {
 		panic("invalid twiddle table")
 	}
 	// we just compute the first stage
-	t[0] = make([]fr.Element, 1+(1<<(nbStages-1)))
+	t[0] = arena.Alloc(1 + (1 << (nbStages - 1)))
 	BuildExpTable(omega, t[0])
 
 	// for the next stages, we just iterate on the first stage with larger stride
 	for i := uint64(1); i < nbStages; i++ {
-		t[i] = make([]fr.Element, 1+(1<<(nbStages-i-1)))
+		t[i] = arena.Alloc(1 + (1 << (nbStages - i - 1)))
 		k := 0
 		for j := 0; j < len(t[i]); j++ {
 			t[i][j] = t[0][k]
@@ -213,8 +220,8 @@ func BuildExpTable(w fr.Element, table []fr.Element) {
 
 	// see if it makes sense to parallelize exp tables pre-computation
 	interval := 0
-	if runtime.NumCPU() >= 4 {
-		interval = (n - 1) / (runtime.NumCPU() / 4)
+	if concurrency.MaxTasks() >= 4 {
+		interval = (n - 1) / (concurrency.MaxTasks() / 4)
 	}
 
 	// this ratio roughly correspond to the number of multiplication one can do in place of a Exp operation
@@ -287,6 +294,28 @@ func (d *Domain) ReadFrom(r io.Reader) (int64, error) {
 	if d.withPrecompute {
 		d.preComputeTwiddles()
 	}
+	d.sixStep = newSixStepPlan(d.Cardinality)
 
 	return dec.BytesRead(), nil
 }
+
+// Fingerprint returns a constant-size digest identifying the domain, suitable as a cache key
+// or to check that two parties use the same domain.
+//
+// It only depends on the cardinality, the generator and the coset shift, with a fixed
+// encoding (big-endian cardinality followed by canonical field elements), so it is stable
+// across versions and does not depend on precomputation options.
+func (d *Domain) Fingerprint() [sha256.Size]byte {
+	h := sha256.New()
+	h.Write([]byte("gnark-crypto/bls24-317/fft/domain/v1"))
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], d.Cardinality)
+	h.Write(buf[:])
+	for _, e := range []*fr.Element{&d.Generator, &d.FrMultiplicativeGen} {
+		b := e.Bytes()
+		h.Write(b[:])
+	}
+	var res [sha256.Size]byte
+	h.Sum(res[:0])
+	return res
+}
@@ -0,0 +1,147 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package rs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr/fft"
+	"github.com/stretchr/testify/require"
+)
+
+func randomVector(n int) []fr.Element {
+	res := make([]fr.Element, n)
+	for i := range res {
+		res[i].SetRandom()
+	}
+	return res
+}
+
+func TestReedSolomon(t *testing.T) {
+	const k, rate = 16, 4
+	var shift fr.Element
+	shift.SetUint64(7)
+
+	for _, opts := range [][]Option{nil, {WithShift(shift)}} {
+		assert := require.New(t)
+		code, err := NewCode(k, rate, opts...)
+		assert.NoError(err)
+		assert.Equal(uint64(k*rate), code.Length())
+
+		// non systematic encoding
+		p := randomVector(k)
+		codeword, err := code.Encode(p)
+		assert.NoError(err)
+		x := code.Domain().FrMultiplicativeGen
+		if opts == nil {
+			x.SetOne()
+		}
+		for i := range codeword {
+			var e fr.Element
+			for j := len(p) - 1; j >= 0; j-- {
+				e.Mul(&e, &x).Add(&e, &p[j])
+			}
+			assert.True(e.Equal(&codeword[i]), "wrong evaluation")
+			x.Mul(&x, &code.Domain().Generator)
+		}
+		decoded, err := code.Decode(codeword)
+		assert.NoError(err)
+		assert.Equal(p, decoded)
+
+		// systematic encoding
+		msg := randomVector(k)
+		codeword, err = code.EncodeSystematic(msg)
+		assert.NoError(err)
+		assert.NoError(code.Check(codeword))
+		for i := range msg {
+			assert.True(msg[i].Equal(&codeword[i*rate]), "the encoding is not systematic")
+		}
+
+		// a random word is not a codeword
+		assert.True(errors.Is(code.Check(randomVector(k*rate)), ErrNotACodeword))
+
+		// erasure decoding, up to n-k erasures
+		for _, nbErasures := range []int{0, 1, k, k*rate - k} {
+			missing := make([]int, nbErasures)
+			received := make([]fr.Element, len(codeword))
+			copy(received, codeword)
+			for j := range missing {
+				missing[j] = (j*7 + 3) % (k * rate)
+				received[missing[j]].SetRandom()
+			}
+			recovered, err := code.ErasureDecode(received, missing)
+			assert.NoError(err)
+			assert.Equal(codeword, recovered)
+		}
+
+		missing := make([]int, k*rate-k+1)
+		for j := range missing {
+			missing[j] = j
+		}
+		_, err = code.ErasureDecode(codeword, missing)
+		assert.True(errors.Is(err, ErrTooManyErasures))
+		_, err = code.ErasureDecode(codeword, []int{1, 1})
+		assert.True(errors.Is(err, ErrInvalidErasures))
+
+		// inconsistent known values
+		received := make([]fr.Element, len(codeword))
+		copy(received, codeword)
+		received[5].SetRandom()
+		_, err = code.ErasureDecode(received, []int{0, 1})
+		assert.True(errors.Is(err, ErrNotACodeword))
+	}
+
+	_, err := NewCode(12, 4)
+	require.True(t, errors.Is(err, ErrInvalidParameters))
+	_, err = NewCode(16, 1)
+	require.True(t, errors.Is(err, ErrInvalidParameters))
+}
+
+func TestEncode(t *testing.T) {
+	p := randomVector(10)
+	codeword, err := Encode(p, 2)
+	require.NoError(t, err)
+	require.Len(t, codeword, 32)
+
+	// same as the FFT on the domain of size 32
+	expected := make([]fr.Element, 32)
+	copy(expected, p)
+	fft.NewDomain(32).FFT(expected, fft.DIF)
+	fft.BitReverse(expected)
+	require.Equal(t, expected, codeword)
+}
+
+func BenchmarkErasureDecode(b *testing.B) {
+	const k, rate = 1 << 10, 2
+	code, err := NewCode(k, rate)
+	require.NoError(b, err)
+	codeword, err := code.Encode(randomVector(k))
+	require.NoError(b, err)
+	missing := make([]int, k)
+	for i := range missing {
+		missing[i] = 2 * i
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := code.ErasureDecode(codeword, missing); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+)
+
+func TestSixStepFFT(t *testing.T) {
+	defer func(old uint64) { sixStepMinSize = old }(sixStepMinSize)
+
+	// odd and even log sizes, so that n1 = n2 and n1 < n2 are both covered
+	for _, logN := range []int{9, 10} {
+		n := uint64(1) << logN
+
+		sixStepMinSize = 1 << 62
+		ref := NewDomain(n)
+		refWithoutPrecompute := NewDomain(n, WithoutPrecompute())
+		sixStepMinSize = 1 << 8
+		domain := NewDomain(n)
+		if ref.sixStep != nil || domain.sixStep == nil {
+			t.Fatal("six-step FFT not selected by size")
+		}
+
+		for _, decimation := range []Decimation{DIF, DIT} {
+			for _, opts := range [][]Option{nil, {OnCoset()}, {WithNbTasks(1)}, {WithArena(NewArena(0))}} {
+				a := make([]fr.Element, n)
+				for i := range a {
+					a[i].SetRandom()
+				}
+				b := make([]fr.Element, n)
+				c := make([]fr.Element, n)
+
+				copy(b, a)
+				copy(c, a)
+				ref.FFT(b, decimation, opts...)
+				domain.FFT(c, decimation, opts...)
+				checkSameVectors(t, b, c)
+
+				copy(b, a)
+				copy(c, a)
+				refWithoutPrecompute.FFTInverse(b, decimation, opts...)
+				domain.FFTInverse(c, decimation, opts...)
+				checkSameVectors(t, b, c)
+			}
+		}
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	const rows, cols = 8, 64
+	src := make([]fr.Element, rows*cols)
+	for i := range src {
+		src[i].SetUint64(uint64(i))
+	}
+	dst := make([]fr.Element, rows*cols)
+	transpose(dst, src, rows, cols, 4)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			if !dst[c*rows+r].Equal(&src[r*cols+c]) {
+				t.Fatalf("transpose: mismatch at (%d, %d)", r, c)
+			}
+		}
+	}
+}
+
+func checkSameVectors(t *testing.T, expected, got []fr.Element) {
+	t.Helper()
+	for i := range expected {
+		if !expected[i].Equal(&got[i]) {
+			t.Fatalf("mismatch at index %d", i)
+		}
+	}
+}
+
+// BenchmarkSixStepFFT compares the recursive and six-step FFTs on domains around
+// sixStepMinSize.
+func BenchmarkSixStepFFT(b *testing.B) {
+	defer func(old uint64) { sixStepMinSize = old }(sixStepMinSize)
+
+	for _, logN := range []int{20, 22, 24} {
+		n := uint64(1) << logN
+		pol := make([]fr.Element, n)
+		for i := range pol {
+			pol[i].SetUint64(uint64(i))
+		}
+
+		for _, sixStep := range []bool{false, true} {
+			name := "recursive"
+			sixStepMinSize = 1 << 62
+			if sixStep {
+				name = "six-step"
+				sixStepMinSize = 4
+			}
+			domain := NewDomain(n, WithoutPrecompute())
+			arena := NewArena(int(n))
+			b.Run(name+" 2**"+strconv.Itoa(logN), func(b *testing.B) {
+				for j := 0; j < b.N; j++ {
+					arena.Reset()
+					domain.FFT(pol, DIF, WithArena(arena))
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fri
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr/fft"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+// ErrInvalidConfig is returned (wrapped) when the options given to IOPP.NewWithOptions
+// are inconsistent.
+var ErrInvalidConfig = errors.New("invalid fri configuration")
+
+type config struct {
+	rho        uint64
+	nbRounds   int
+	instanceID []byte
+	recorder   *fiatshamir.Recorder
+	capHeight  int
+	newHash    func() hash.Hash
+
+	redactErrors   bool
+	wideChallenges bool
+}
+
+// Option configures a FRI instance.
+type Option func(*config)
+
+// WithRho sets the blow-up factor ρ = size_code_word/size_polynomial. It must be a
+// power of two, at least 2. Default is 8.
+func WithRho(rho uint64) Option {
+	return func(c *config) {
+		c.rho = rho
+	}
+}
+
+// WithNbRounds sets the number of independent rounds of the proof of proximity. Each
+// round queries one position, so the soundness error decreases exponentially with it.
+// Default is 1.
+func WithNbRounds(nbRounds int) Option {
+	return func(c *config) {
+		c.nbRounds = nbRounds
+	}
+}
+
+// WithInstanceID binds the Fiat-Shamir challenges to instanceID, see IOPP.NewWithInstance.
+func WithInstanceID(instanceID []byte) Option {
+	return func(c *config) {
+		c.instanceID = append([]byte{}, instanceID...)
+	}
+}
+
+// WithTranscriptRecorder records the operations on the Fiat-Shamir transcripts of the
+// prover or the verifier in r. Recording both and comparing the traces with
+// fiatshamir.Diff locates the first diverging value when a valid proof is rejected.
+func WithTranscriptRecorder(r *fiatshamir.Recorder) Option {
+	return func(c *config) {
+		c.recorder = r
+	}
+}
+
+// WithMerkleCapHeight commits to the 2ʰ nodes at height h below the root of the Merkle
+// trees of the oracles, their Merkle cap, instead of their root, as Plonky2 does. The
+// Merkle paths of the proofs stop below the cap, h nodes shorter: a recursive verifier
+// hashes less, at the cost of 2ʰ digests per commitment. h must be at most log₂(ρ), so
+// that the cap is above the smallest tree. Default is 0, a single root.
+func WithMerkleCapHeight(h int) Option {
+	return func(c *config) {
+		c.capHeight = h
+	}
+}
+
+// WithWideChallenges derives the field challenges with fiatshamir.ComputeChallengeFr,
+// which expands them to twice the size of fr before reducing them, instead of
+// reducing the output of the hash: the challenges are then statistically uniform.
+// Proofs are not compatible with the default instances, the option is part of the
+// fingerprint. It is implied by fiatshamir.SetWideChallenges(true) when the instance
+// is created.
+func WithWideChallenges() Option {
+	return func(c *config) {
+		c.wideChallenges = true
+	}
+}
+
+// WithParallelHashing hashes the leaves and the nodes of the Merkle trees of the prover
+// in parallel, each goroutine with its own instance of newHash, which must build the
+// same hash function as the one the instance is created with. Proofs are unchanged.
+func WithParallelHashing(newHash func() hash.Hash) Option {
+	return func(c *config) {
+		c.newHash = newHash
+	}
+}
+
+// WithRedactedErrors removes the values (Merkle roots, evaluations) from the
+// VerificationError returned by the verifier, keeping only their location.
+func WithRedactedErrors() Option {
+	return func(c *config) {
+		c.redactErrors = true
+	}
+}
+
+func newConfig(size uint64, h hash.Hash, opts ...Option) (config, error) {
+	cfg := config{
+		rho:      defaultRho,
+		nbRounds: defaultNbRounds,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if h == nil {
+		return config{}, fmt.Errorf("%w: hash function is nil", ErrInvalidConfig)
+	}
+	if cfg.newHash != nil && cfg.newHash().Size() != h.Size() {
+		return config{}, fmt.Errorf("%w: the hash constructor doesn't match the hash function", ErrInvalidConfig)
+	}
+	if err := cfg.validate(size); err != nil {
+		return config{}, err
+	}
+	return cfg, nil
+}
+
+// validate returns a descriptive error if the configuration can't be used to
+// build a FRI instance for polynomials of the given size.
+func (cfg *config) validate(size uint64) error {
+	if size == 0 {
+		return fmt.Errorf("%w: size must be positive", ErrInvalidConfig)
+	}
+	if cfg.rho < 2 || cfg.rho&(cfg.rho-1) != 0 {
+		return fmt.Errorf("%w: rho must be a power of two greater than 1, got %d", ErrInvalidConfig, cfg.rho)
+	}
+	if cfg.nbRounds < 1 {
+		return fmt.Errorf("%w: nbRounds must be positive, got %d", ErrInvalidConfig, cfg.nbRounds)
+	}
+	if cfg.capHeight < 0 || cfg.capHeight > bits.TrailingZeros64(cfg.rho) {
+		return fmt.Errorf("%w: the Merkle cap height must be between 0 and log₂(rho) = %d, got %d", ErrInvalidConfig, bits.TrailingZeros64(cfg.rho), cfg.capHeight)
+	}
+	n := ecc.NextPowerOfTwo(size)
+	if n > (1<<63)/cfg.rho {
+		return fmt.Errorf("%w: size %d with rho %d overflows", ErrInvalidConfig, size, cfg.rho)
+	}
+	if _, err := fft.Generator(n * cfg.rho); err != nil {
+		return fmt.Errorf("%w: size %d with rho %d: %v", ErrInvalidConfig, size, cfg.rho, err)
+	}
+	return nil
+}
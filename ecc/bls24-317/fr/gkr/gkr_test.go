@@ -61,6 +61,65 @@ func TestSingleMulGate(t *testing.T) {
 	testManyInstances(t, 2, testSingleMulGate)
 }
 
+func TestRegisterGate(t *testing.T) {
+	// f(x, y, z) = x²y + z
+	f := func(x ...fr.Element) (res fr.Element) {
+		res.Square(&x[0]).Mul(&res, &x[1]).Add(&res, &x[2])
+		return
+	}
+
+	_, err := RegisterGate("x2y+z-wrong-degree", f, 3, 2)
+	assert.Error(t, err, "understated degree accepted")
+	_, err = RegisterGate("x2y+z-wrong-degree", f, 3, 4)
+	assert.Error(t, err, "overstated degree accepted")
+
+	g, err := registerTestGate(t, "x2y+z", f, 3, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, g, GetGate("x2y+z"))
+	assert.Nil(t, GetGate("x2y+z-wrong-degree"))
+	_, err = RegisterGate("x2y+z", f, 3, 3)
+	assert.Error(t, err, "duplicate name accepted")
+
+	testManyInstances(t, 3, func(t *testing.T, inputAssignments ...[]fr.Element) {
+		c := make(Circuit, 4)
+		c[3] = Wire{
+			Gate:   g,
+			Inputs: []*Wire{&c[0], &c[1], &c[2]},
+		}
+
+		assignment := WireAssignment{&c[0]: inputAssignments[0], &c[1]: inputAssignments[1], &c[2]: inputAssignments[2]}.Complete(c)
+
+		proof, err := Prove(c, assignment, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+		assert.NoError(t, err)
+
+		err = Verify(c, assignment, proof, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+		assert.NoError(t, err, "proof rejected")
+
+		err = Verify(c, assignment, proof, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(0, 1)))
+		assert.NotNil(t, err, "bad proof accepted")
+	})
+}
+
+// registerTestGate registers a gate for the duration of the test
+func registerTestGate(t *testing.T, name string, f GateFunction, nbIn int, degree int) (Gate, error) {
+	g, err := RegisterGate(name, f, nbIn, degree)
+	if err == nil {
+		t.Cleanup(func() {
+			gatesLock.Lock()
+			defer gatesLock.Unlock()
+			delete(gates, name)
+		})
+	}
+	return g, err
+}
+
+// testGates are the gates the test vectors refer to by name
+var testGates = map[string]Gate{
+	"identity": GetGate("identity"),
+	"add":      GetGate("add"),
+	"mul":      GetGate("mul"),
+}
+
 func TestSingleInputTwoIdentityGatesTwoInstances(t *testing.T) {
 
 	testSingleInputTwoIdentityGates(t, []fr.Element{two, three})
@@ -214,7 +273,7 @@ func testNoGate(t *testing.T, inputAssignments ...[]fr.Element) {
 func testSingleAddGate(t *testing.T, inputAssignments ...[]fr.Element) {
 	c := make(Circuit, 3)
 	c[2] = Wire{
-		Gate:   Gates["add"],
+		Gate:   GetGate("add"),
 		Inputs: []*Wire{&c[0], &c[1]},
 	}
 
@@ -234,7 +293,7 @@ func testSingleMulGate(t *testing.T, inputAssignments ...[]fr.Element) {
 
 	c := make(Circuit, 3)
 	c[2] = Wire{
-		Gate:   Gates["mul"],
+		Gate:   GetGate("mul"),
 		Inputs: []*Wire{&c[0], &c[1]},
 	}
 
@@ -250,6 +309,30 @@ func testSingleMulGate(t *testing.T, inputAssignments ...[]fr.Element) {
 	assert.NotNil(t, err, "bad proof accepted")
 }
 
+func TestWideChallenges(t *testing.T) {
+	c := make(Circuit, 3)
+	c[2] = Wire{
+		Gate:   GetGate("mul"),
+		Inputs: []*Wire{&c[0], &c[1]},
+	}
+
+	assignment := WireAssignment{&c[0]: []fr.Element{four, three}, &c[1]: []fr.Element{two, three}}.Complete(c)
+	wide := func() fiatshamir.Settings {
+		settings := fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1))
+		settings.WideChallenges = true
+		return settings
+	}
+
+	proof, err := Prove(c, assignment, wide())
+	assert.NoError(t, err)
+
+	err = Verify(c, assignment, proof, wide())
+	assert.NoError(t, err, "proof rejected")
+
+	err = Verify(c, assignment, proof, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+	assert.NotNil(t, err, "proof accepted with the default challenges")
+}
+
 func testSingleInputTwoIdentityGates(t *testing.T, inputAssignments ...[]fr.Element) {
 	c := make(Circuit, 3)
 
@@ -364,7 +447,7 @@ func testATimesBSquared(t *testing.T, numRounds int, inputAssignments ...[]fr.El
 
 	for i := 2; i < len(c); i++ {
 		c[i] = Wire{
-			Gate:   Gates["mul"],
+			Gate:   GetGate("mul"),
 			Inputs: []*Wire{&c[i-1], &c[0]},
 		}
 	}
@@ -556,7 +639,7 @@ func getCircuit(path string) (Circuit, error) {
 func (c CircuitInfo) toCircuit() (circuit Circuit) {
 	circuit = make(Circuit, len(c))
 	for i := range c {
-		circuit[i].Gate = Gates[c[i].Gate]
+		circuit[i].Gate = testGates[c[i].Gate]
 		circuit[i].Inputs = make([]*Wire, len(c[i].Inputs))
 		for k, inputCoord := range c[i].Inputs {
 			input := &circuit[inputCoord]
@@ -567,8 +650,8 @@ func (c CircuitInfo) toCircuit() (circuit Circuit) {
 }
 
 func init() {
-	Gates["mimc"] = mimcCipherGate{} //TODO: Add ark
-	Gates["select-input-3"] = _select(2)
+	testGates["mimc"] = mimcCipherGate{} //TODO: Add ark
+	testGates["select-input-3"] = _select(2)
 }
 
 type mimcCipherGate struct {
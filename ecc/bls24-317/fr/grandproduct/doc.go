@@ -0,0 +1,28 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package grandproduct computes grand product accumulators.
+//
+// Permutation and lookup arguments (Plonk copy constraints, plookup, …) prove
+// that ∏ᵢ numᵢ = ∏ᵢ denᵢ by committing to the accumulator Z defined by
+//
+//	Z(ω⁰) = 1, Z(ωⁱ⁺¹) = Z(ωⁱ)⋅numᵢ/denᵢ
+//
+// and checking the local relation between Z(X) and Z(ωX). This package builds
+// Z in Lagrange basis from the vectors num and den, using a single batch
+// inversion and a parallel prefix product, and optionally appends random
+// blinding rows.
+package grandproduct
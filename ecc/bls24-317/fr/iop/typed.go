@@ -0,0 +1,377 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package iop
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr/fft"
+)
+
+// indices of the representations of a typed polynomial
+const (
+	formCanonical = iota
+	formLagrange
+	formLagrangeCoset
+	nbTypedForms
+)
+
+// representations holds the forms of a polynomial that have been computed so far,
+// all in Regular layout. It is shared between the typed handles on the polynomial and
+// reference counted, so that conversions are computed at most once and memory is
+// released when the last handle is.
+type representations struct {
+	lock   sync.Mutex
+	domain *fft.Domain
+	forms  [nbTypedForms][]fr.Element
+	refs   int32
+}
+
+func newRepresentations(domain *fft.Domain, form int, values []fr.Element) (*representations, error) {
+	if uint64(len(values)) != domain.Cardinality {
+		return nil, ErrInconsistentSizeDomain
+	}
+	r := &representations{domain: domain, refs: 1}
+	r.forms[form] = values
+	return r, nil
+}
+
+// get returns the representation of the polynomial in the given form, computing it
+// on first access.
+func (r *representations) get(form int) []fr.Element {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.forms[form] == nil {
+		r.compute(form)
+	}
+	return r.forms[form]
+}
+
+// compute sets r.forms[form], from the canonical form if possible. r.lock must be held.
+func (r *representations) compute(form int) {
+	if r.forms[formCanonical] == nil && r.forms[formLagrange] == nil && r.forms[formLagrangeCoset] == nil {
+		panic("polynomial used after release")
+	}
+	if form != formCanonical && r.forms[formCanonical] == nil {
+		r.compute(formCanonical)
+	}
+
+	res := make([]fr.Element, r.domain.Cardinality)
+	switch form {
+	case formCanonical:
+		if r.forms[formLagrange] != nil {
+			copy(res, r.forms[formLagrange])
+			r.domain.FFTInverse(res, fft.DIF)
+		} else {
+			copy(res, r.forms[formLagrangeCoset])
+			r.domain.FFTInverse(res, fft.DIF, fft.OnCoset())
+		}
+	case formLagrange:
+		copy(res, r.forms[formCanonical])
+		r.domain.FFT(res, fft.DIF)
+	case formLagrangeCoset:
+		copy(res, r.forms[formCanonical])
+		r.domain.FFT(res, fft.DIF, fft.OnCoset())
+	}
+	fft.BitReverse(res)
+	r.forms[form] = res
+}
+
+func (r *representations) share() *representations {
+	atomic.AddInt32(&r.refs, 1)
+	return r
+}
+
+func (r *representations) release() {
+	if atomic.AddInt32(&r.refs, -1) == 0 {
+		r.lock.Lock()
+		r.forms = [nbTypedForms][]fr.Element{}
+		r.lock.Unlock()
+	}
+}
+
+// writable returns representations holding only the given form, owned exclusively
+// by the caller. The backing slice is copied if it is shared with other handles.
+func (r *representations) writable(form int) *representations {
+	values := r.get(form)
+	if atomic.LoadInt32(&r.refs) == 1 {
+		r.lock.Lock()
+		r.forms = [nbTypedForms][]fr.Element{}
+		r.forms[form] = values
+		r.lock.Unlock()
+		return r
+	}
+	res := &representations{domain: r.domain, refs: 1}
+	res.forms[form] = make([]fr.Element, len(values))
+	copy(res.forms[form], values)
+	r.release()
+	return res
+}
+
+// CanonicalPoly is a polynomial of degree < domain.Cardinality represented by
+// its coefficients in the canonical basis, in Regular layout.
+//
+// Conversions to the other representations are lazy: they are computed when their
+// values are first accessed, and cached for all the handles on the same polynomial.
+// Handles share their backing slices; Writable copies them when needed, and Release
+// frees them once no handle uses them anymore. A handle must not be used concurrently
+// with Writable or Release.
+type CanonicalPoly struct {
+	r *representations
+}
+
+// NewCanonicalPoly returns a CanonicalPoly from its coefficients in the canonical basis.
+// It takes ownership of values, which must be of length domain.Cardinality.
+func NewCanonicalPoly(domain *fft.Domain, values []fr.Element) (*CanonicalPoly, error) {
+	r, err := newRepresentations(domain, formCanonical, values)
+	if err != nil {
+		return nil, err
+	}
+	return &CanonicalPoly{r: r}, nil
+}
+
+// Values returns its coefficients in the canonical basis. The result must not be modified; see Writable.
+func (p *CanonicalPoly) Values() []fr.Element {
+	return p.r.get(formCanonical)
+}
+
+// Writable returns its coefficients in the canonical basis for modification. The values are copied
+// if they are shared with other handles, and the cached conversions are discarded.
+func (p *CanonicalPoly) Writable() []fr.Element {
+	p.r = p.r.writable(formCanonical)
+	return p.r.forms[formCanonical]
+}
+
+// Domain returns the domain of the polynomial
+func (p *CanonicalPoly) Domain() *fft.Domain {
+	return p.r.domain
+}
+
+// Share returns a new handle on the same polynomial, without copying it.
+func (p *CanonicalPoly) Share() *CanonicalPoly {
+	return &CanonicalPoly{r: p.r.share()}
+}
+
+// Release signals that p won't be used anymore. The backing slices are
+// freed when all the handles on the polynomial are released.
+func (p *CanonicalPoly) Release() {
+	p.r.release()
+	p.r = nil
+}
+
+// ToCanonical returns a handle on the canonical representation of p.
+func (p *CanonicalPoly) ToCanonical() *CanonicalPoly {
+	return &CanonicalPoly{r: p.r.share()}
+}
+
+// ToLagrange returns a handle on the Lagrange representation of p.
+func (p *CanonicalPoly) ToLagrange() *LagrangePoly {
+	return &LagrangePoly{r: p.r.share()}
+}
+
+// ToLagrangeCoset returns a handle on the Lagrange coset representation of p.
+func (p *CanonicalPoly) ToLagrangeCoset() *LagrangeCosetPoly {
+	return &LagrangeCosetPoly{r: p.r.share()}
+}
+
+// Evaluate evaluates p at x, using its canonical representation.
+func (p *CanonicalPoly) Evaluate(x fr.Element) fr.Element {
+	coeffs := p.r.get(formCanonical)
+	var res fr.Element
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		res.Mul(&res, &x).Add(&res, &coeffs[i])
+	}
+	return res
+}
+
+// ToPolynomial returns a copy of p as a *Polynomial in Canonical basis and Regular layout.
+func (p *CanonicalPoly) ToPolynomial() *Polynomial {
+	values := p.Values()
+	coeffs := make([]fr.Element, len(values))
+	copy(coeffs, values)
+	return NewPolynomial(&coeffs, Form{Basis: Canonical, Layout: Regular})
+}
+
+// LagrangePoly is a polynomial of degree < domain.Cardinality represented by
+// its evaluations on the domain <ω>, in Regular layout.
+//
+// Conversions to the other representations are lazy: they are computed when their
+// values are first accessed, and cached for all the handles on the same polynomial.
+// Handles share their backing slices; Writable copies them when needed, and Release
+// frees them once no handle uses them anymore. A handle must not be used concurrently
+// with Writable or Release.
+type LagrangePoly struct {
+	r *representations
+}
+
+// NewLagrangePoly returns a LagrangePoly from its evaluations on the domain <ω>.
+// It takes ownership of values, which must be of length domain.Cardinality.
+func NewLagrangePoly(domain *fft.Domain, values []fr.Element) (*LagrangePoly, error) {
+	r, err := newRepresentations(domain, formLagrange, values)
+	if err != nil {
+		return nil, err
+	}
+	return &LagrangePoly{r: r}, nil
+}
+
+// Values returns its evaluations on the domain <ω>. The result must not be modified; see Writable.
+func (p *LagrangePoly) Values() []fr.Element {
+	return p.r.get(formLagrange)
+}
+
+// Writable returns its evaluations on the domain <ω> for modification. The values are copied
+// if they are shared with other handles, and the cached conversions are discarded.
+func (p *LagrangePoly) Writable() []fr.Element {
+	p.r = p.r.writable(formLagrange)
+	return p.r.forms[formLagrange]
+}
+
+// Domain returns the domain of the polynomial
+func (p *LagrangePoly) Domain() *fft.Domain {
+	return p.r.domain
+}
+
+// Share returns a new handle on the same polynomial, without copying it.
+func (p *LagrangePoly) Share() *LagrangePoly {
+	return &LagrangePoly{r: p.r.share()}
+}
+
+// Release signals that p won't be used anymore. The backing slices are
+// freed when all the handles on the polynomial are released.
+func (p *LagrangePoly) Release() {
+	p.r.release()
+	p.r = nil
+}
+
+// ToCanonical returns a handle on the canonical representation of p.
+func (p *LagrangePoly) ToCanonical() *CanonicalPoly {
+	return &CanonicalPoly{r: p.r.share()}
+}
+
+// ToLagrange returns a handle on the Lagrange representation of p.
+func (p *LagrangePoly) ToLagrange() *LagrangePoly {
+	return &LagrangePoly{r: p.r.share()}
+}
+
+// ToLagrangeCoset returns a handle on the Lagrange coset representation of p.
+func (p *LagrangePoly) ToLagrangeCoset() *LagrangeCosetPoly {
+	return &LagrangeCosetPoly{r: p.r.share()}
+}
+
+// Evaluate evaluates p at x, using its canonical representation.
+func (p *LagrangePoly) Evaluate(x fr.Element) fr.Element {
+	coeffs := p.r.get(formCanonical)
+	var res fr.Element
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		res.Mul(&res, &x).Add(&res, &coeffs[i])
+	}
+	return res
+}
+
+// ToPolynomial returns a copy of p as a *Polynomial in Lagrange basis and Regular layout.
+func (p *LagrangePoly) ToPolynomial() *Polynomial {
+	values := p.Values()
+	coeffs := make([]fr.Element, len(values))
+	copy(coeffs, values)
+	return NewPolynomial(&coeffs, Form{Basis: Lagrange, Layout: Regular})
+}
+
+// LagrangeCosetPoly is a polynomial of degree < domain.Cardinality represented by
+// its evaluations on the coset g⋅<ω>, g being domain.FrMultiplicativeGen, in Regular layout.
+//
+// Conversions to the other representations are lazy: they are computed when their
+// values are first accessed, and cached for all the handles on the same polynomial.
+// Handles share their backing slices; Writable copies them when needed, and Release
+// frees them once no handle uses them anymore. A handle must not be used concurrently
+// with Writable or Release.
+type LagrangeCosetPoly struct {
+	r *representations
+}
+
+// NewLagrangeCosetPoly returns a LagrangeCosetPoly from its evaluations on the coset g⋅<ω>, g being domain.FrMultiplicativeGen.
+// It takes ownership of values, which must be of length domain.Cardinality.
+func NewLagrangeCosetPoly(domain *fft.Domain, values []fr.Element) (*LagrangeCosetPoly, error) {
+	r, err := newRepresentations(domain, formLagrangeCoset, values)
+	if err != nil {
+		return nil, err
+	}
+	return &LagrangeCosetPoly{r: r}, nil
+}
+
+// Values returns its evaluations on the coset g⋅<ω>, g being domain.FrMultiplicativeGen. The result must not be modified; see Writable.
+func (p *LagrangeCosetPoly) Values() []fr.Element {
+	return p.r.get(formLagrangeCoset)
+}
+
+// Writable returns its evaluations on the coset g⋅<ω>, g being domain.FrMultiplicativeGen for modification. The values are copied
+// if they are shared with other handles, and the cached conversions are discarded.
+func (p *LagrangeCosetPoly) Writable() []fr.Element {
+	p.r = p.r.writable(formLagrangeCoset)
+	return p.r.forms[formLagrangeCoset]
+}
+
+// Domain returns the domain of the polynomial
+func (p *LagrangeCosetPoly) Domain() *fft.Domain {
+	return p.r.domain
+}
+
+// Share returns a new handle on the same polynomial, without copying it.
+func (p *LagrangeCosetPoly) Share() *LagrangeCosetPoly {
+	return &LagrangeCosetPoly{r: p.r.share()}
+}
+
+// Release signals that p won't be used anymore. The backing slices are
+// freed when all the handles on the polynomial are released.
+func (p *LagrangeCosetPoly) Release() {
+	p.r.release()
+	p.r = nil
+}
+
+// ToCanonical returns a handle on the canonical representation of p.
+func (p *LagrangeCosetPoly) ToCanonical() *CanonicalPoly {
+	return &CanonicalPoly{r: p.r.share()}
+}
+
+// ToLagrange returns a handle on the Lagrange representation of p.
+func (p *LagrangeCosetPoly) ToLagrange() *LagrangePoly {
+	return &LagrangePoly{r: p.r.share()}
+}
+
+// ToLagrangeCoset returns a handle on the Lagrange coset representation of p.
+func (p *LagrangeCosetPoly) ToLagrangeCoset() *LagrangeCosetPoly {
+	return &LagrangeCosetPoly{r: p.r.share()}
+}
+
+// Evaluate evaluates p at x, using its canonical representation.
+func (p *LagrangeCosetPoly) Evaluate(x fr.Element) fr.Element {
+	coeffs := p.r.get(formCanonical)
+	var res fr.Element
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		res.Mul(&res, &x).Add(&res, &coeffs[i])
+	}
+	return res
+}
+
+// ToPolynomial returns a copy of p as a *Polynomial in LagrangeCoset basis and Regular layout.
+func (p *LagrangeCosetPoly) ToPolynomial() *Polynomial {
+	values := p.Values()
+	coeffs := make([]fr.Element, len(values))
+	copy(coeffs, values)
+	return NewPolynomial(&coeffs, Form{Basis: LagrangeCoset, Layout: Regular})
+}
@@ -0,0 +1,103 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package iop
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr/fft"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypedPolynomialConversions(t *testing.T) {
+	const size = 16
+	d := fft.NewDomain(size)
+	coeffs := *randomVector(size)
+	expected := make([]fr.Element, size)
+	copy(expected, coeffs)
+
+	p, err := NewCanonicalPoly(d, coeffs)
+	require.NoError(t, err)
+	lagrange := p.ToLagrange()
+	coset := lagrange.ToLagrangeCoset()
+
+	// check the evaluations against the canonical form
+	var x fr.Element
+	x.SetOne()
+	evals := lagrange.Values()
+	for i := range evals {
+		y := p.Evaluate(x)
+		require.True(t, y.Equal(&evals[i]))
+		x.Mul(&x, &d.Generator)
+	}
+	x.Set(&d.FrMultiplicativeGen)
+	evals = coset.Values()
+	for i := range evals {
+		y := p.Evaluate(x)
+		require.True(t, y.Equal(&evals[i]))
+		x.Mul(&x, &d.Generator)
+	}
+
+	// back to canonical, starting from each evaluation form only
+	fromLagrange, err := NewLagrangePoly(d, append([]fr.Element{}, lagrange.Values()...))
+	require.NoError(t, err)
+	require.Equal(t, expected, fromLagrange.ToCanonical().Values())
+	fromCoset, err := NewLagrangeCosetPoly(d, append([]fr.Element{}, coset.Values()...))
+	require.NoError(t, err)
+	require.Equal(t, expected, fromCoset.ToCanonical().Values())
+	require.Equal(t, lagrange.Values(), fromCoset.ToLagrange().Values())
+
+	// interoperability with Polynomial
+	wp := coset.ToPolynomial()
+	require.Equal(t, Form{Basis: LagrangeCoset, Layout: Regular}, wp.Form)
+	wp.ToCanonical(d).ToRegular()
+	require.Equal(t, expected, wp.Coefficients())
+
+	_, err = NewLagrangePoly(d, make([]fr.Element, size+1))
+	require.ErrorIs(t, err, ErrInconsistentSizeDomain)
+}
+
+func TestTypedPolynomialSharing(t *testing.T) {
+	const size = 8
+	d := fft.NewDomain(size)
+	p, err := NewCanonicalPoly(d, *randomVector(size))
+	require.NoError(t, err)
+	lagrange := p.ToLagrange()
+	before := append([]fr.Element{}, lagrange.Values()...)
+
+	// writing to a shared polynomial copies it
+	shared := p.Share()
+	coeffs := shared.Writable()
+	require.NotSame(t, &coeffs[0], &p.Values()[0])
+	coeffs[0].SetOne()
+	require.Equal(t, before, lagrange.Values())
+	sharedLagrange := shared.ToLagrange()
+	require.NotEqual(t, before, sharedLagrange.Values())
+	sharedLagrange.Release()
+
+	// writing to an exclusively owned polynomial doesn't
+	values := shared.Values()
+	require.Same(t, &values[0], &shared.Writable()[0])
+
+	// the polynomial remains usable until all handles are released
+	p.Release()
+	require.Equal(t, before, lagrange.Values())
+	lagrange.Release()
+	require.Panics(t, func() { lagrange.Values() })
+}
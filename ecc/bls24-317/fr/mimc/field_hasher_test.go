@@ -0,0 +1,101 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package mimc
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldHasher(t *testing.T) {
+	assert := require.New(t)
+
+	x := make([]fr.Element, 10)
+	for i := range x {
+		x[i].SetRandom()
+	}
+
+	// same digest as the hash.Hash
+	h := NewMiMC()
+	for i := range x {
+		b := x[i].Bytes()
+		_, err := h.Write(b[:])
+		assert.NoError(err)
+	}
+	var expected fr.Element
+	expected.SetBytes(h.Sum(nil))
+
+	f := NewFieldHasher()
+	f.Absorb(x[:3]...)
+	f.Absorb(x[3:]...)
+	assert.True(expected.Equal(ptr(f.State())))
+	assert.True(expected.Equal(ptr(SumElements(x...))))
+
+	// squeezing
+	first := f.Squeeze()
+	assert.True(expected.Equal(&first))
+	second := f.Squeeze()
+	assert.False(first.Equal(&second))
+
+	f.Reset()
+	assert.True(ptr(f.State()).IsZero())
+	f.Absorb(x...)
+	assert.True(expected.Equal(ptr(f.Squeeze())))
+
+	// custom parameters
+	params, err := NewParams("custom seed", MinNbRounds)
+	assert.NoError(err)
+	custom := NewFieldHasher(WithParams(params))
+	custom.Absorb(x...)
+	assert.False(expected.Equal(ptr(custom.State())))
+}
+
+func ptr(x fr.Element) *fr.Element {
+	return &x
+}
+
+func BenchmarkFieldHasher(b *testing.B) {
+	const n = 64
+	x := make([]fr.Element, n)
+	for i := range x {
+		x[i].SetRandom()
+	}
+
+	b.Run("hash.Hash", func(b *testing.B) {
+		h := NewMiMC()
+		for i := 0; i < b.N; i++ {
+			h.Reset()
+			for j := range x {
+				b := x[j].Bytes()
+				h.Write(b[:])
+			}
+			var res fr.Element
+			res.SetBytes(h.Sum(nil))
+		}
+	})
+
+	b.Run("FieldHasher", func(b *testing.B) {
+		f := NewFieldHasher()
+		for i := 0; i < b.N; i++ {
+			f.Reset()
+			f.Absorb(x...)
+			f.Squeeze()
+		}
+	})
+}
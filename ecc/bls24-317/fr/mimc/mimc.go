@@ -32,18 +32,61 @@ const (
 	BlockSize    = fr.Bytes // BlockSize size that mimc consumes
 )
 
+// MinNbRounds is the minimal number of rounds of a MiMC instance, ⌈log_e(r)⌉
+// where e is the exponent of the round function. It is the number of rounds
+// of the default instance.
+const MinNbRounds = mimcNbRounds
+
+// ErrTooFewRounds is returned when the number of rounds is below MinNbRounds.
+var ErrTooFewRounds = errors.New("mimc: number of rounds below the security floor")
+
 // Params constants for the mimc hash function
 var (
 	mimcConstants [mimcNbRounds]fr.Element
+	mimcParams    Params
 	once          sync.Once
 )
 
+// Params holds the round constants of a MiMC instance.
+type Params struct {
+	constants []fr.Element
+}
+
+// NewParams derives nbRounds round constants from seed, the same way the
+// constants of the default instance are derived from "seed".
+// It returns ErrTooFewRounds if nbRounds < MinNbRounds.
+func NewParams(seed string, nbRounds int) (*Params, error) {
+	if nbRounds < MinNbRounds {
+		return nil, ErrTooFewRounds
+	}
+	p := &Params{constants: make([]fr.Element, nbRounds)}
+	deriveConstants(seed, p.constants)
+	return p, nil
+}
+
+// NbRounds returns the number of rounds of the instance.
+func (p *Params) NbRounds() int {
+	return len(p.constants)
+}
+
+// Constants returns a copy of the round constants.
+func (p *Params) Constants() []fr.Element {
+	return append([]fr.Element(nil), p.constants...)
+}
+
+// defaultParams returns the parameters of the default instance.
+func defaultParams() *Params {
+	once.Do(initConstants) // init constants
+	return &mimcParams
+}
+
 // digest represents the partial evaluation of the checksum
 // along with the params of the mimc function
 type digest struct {
 	h         fr.Element
 	data      []fr.Element // data to hash
 	byteOrder fr.ByteOrder
+	params    *Params
 }
 
 // GetConstants exposed to be used in gnark
@@ -62,6 +105,7 @@ func NewMiMC(opts ...Option) hash.Hash {
 	d.Reset()
 	cfg := mimcOptions(opts...)
 	d.byteOrder = cfg.byteOrder
+	d.params = cfg.params
 	return d
 }
 
@@ -151,19 +195,32 @@ func (d *digest) checksum() fr.Element {
 // m: message
 // k: encryption key
 func (d *digest) encrypt(m fr.Element) fr.Element {
-	once.Do(initConstants) // init constants
+	p := d.params
+	if p == nil {
+		p = defaultParams()
+	}
+	return p.Encrypt(m, d.h)
+}
+
+// Encrypt returns the MiMC encryption of the message m with the key k, using
+// the default round constants. It is the permutation used by the hash function,
+// and can be used to build other constructions (sponge, Feistel network, …).
+func Encrypt(m, k fr.Element) fr.Element {
+	return defaultParams().Encrypt(m, k)
+}
 
+// Encrypt returns the MiMC encryption of the message m with the key k.
+func (p *Params) Encrypt(m, k fr.Element) fr.Element {
 	var tmp1, tmp2 fr.Element
-	for i := 0; i < mimcNbRounds; i++ {
+	for i := range p.constants {
 		// m = (m+k+c)^7
-		tmp1.Add(&m, &d.h).Add(&tmp1, &mimcConstants[i])
+		tmp1.Add(&m, &k).Add(&tmp1, &p.constants[i])
 		tmp2.Square(&tmp1)
 		m.Square(&tmp2).
 			Mul(&m, &tmp2).
 			Mul(&m, &tmp1)
 	}
-
-	m.Add(&m, &d.h)
+	m.Add(&m, &k)
 	return m
 }
 
@@ -179,6 +236,12 @@ func Sum(msg []byte) ([]byte, error) {
 }
 
 func initConstants() {
+	deriveConstants(seed, mimcConstants[:])
+	mimcParams.constants = mimcConstants[:]
+}
+
+// deriveConstants fills constants from successive keccak256 hashes of seed.
+func deriveConstants(seed string, constants []fr.Element) {
 	bseed := ([]byte)(seed)
 
 	hash := sha3.NewLegacyKeccak256()
@@ -187,9 +250,9 @@ func initConstants() {
 	hash.Reset()
 	_, _ = hash.Write(rnd)
 
-	for i := 0; i < mimcNbRounds; i++ {
+	for i := range constants {
 		rnd = hash.Sum(nil)
-		mimcConstants[i].SetBytes(rnd)
+		constants[i].SetBytes(rnd)
 		hash.Reset()
 		_, _ = hash.Write(rnd)
 	}
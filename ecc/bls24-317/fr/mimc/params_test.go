@@ -0,0 +1,86 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package mimc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParams(t *testing.T) {
+	assert := require.New(t)
+
+	_, err := NewParams(seed, MinNbRounds-1)
+	assert.ErrorIs(err, ErrTooFewRounds)
+
+	// default instance
+	params, err := NewParams(seed, MinNbRounds)
+	assert.NoError(err)
+	assert.Equal(MinNbRounds, params.NbRounds())
+	constants := GetConstants()
+	for i, c := range params.Constants() {
+		assert.Equal(0, c.BigInt(new(big.Int)).Cmp(&constants[i]))
+	}
+
+	var x fr.Element
+	x.SetRandom()
+	msg := x.Marshal()
+
+	sum := func(opts ...Option) []byte {
+		h := NewMiMC(opts...)
+		_, err := h.Write(msg)
+		assert.NoError(err)
+		return h.Sum(nil)
+	}
+	expected := sum()
+	assert.Equal(expected, sum(WithParams(params)))
+
+	// custom seed and number of rounds
+	custom, err := NewParams("custom seed", MinNbRounds)
+	assert.NoError(err)
+	assert.NotEqual(expected, sum(WithParams(custom)))
+
+	more, err := NewParams(seed, MinNbRounds+10)
+	assert.NoError(err)
+	assert.Equal(MinNbRounds+10, more.NbRounds())
+	assert.NotEqual(expected, sum(WithParams(more)))
+}
+
+func TestEncrypt(t *testing.T) {
+	assert := require.New(t)
+
+	// for a single block, the hash is Encrypt(m, 0) + m (Miyaguchi-Preneel with h₀ = 0)
+	var m, zero, expected fr.Element
+	m.SetRandom()
+	h := NewMiMC()
+	_, err := h.Write(m.Marshal())
+	assert.NoError(err)
+	expected = Encrypt(m, zero)
+	expected.Add(&expected, &m)
+	var actual fr.Element
+	actual.SetBytes(h.Sum(nil))
+	assert.True(expected.Equal(&actual))
+
+	// different keys give different permutations
+	var k fr.Element
+	k.SetRandom()
+	c0, c1 := Encrypt(m, zero), Encrypt(m, k)
+	assert.False(c0.Equal(&c1))
+}
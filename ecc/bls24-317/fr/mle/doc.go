@@ -0,0 +1,27 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package mle provides multi-core utilities for multilinear extensions.
+//
+// A multilinear polynomial f in the variables X₁, …, Xₙ is given by its
+// evaluations on the boolean hypercube {0,1}ⁿ, as a table of size 2ⁿ where
+//
+//	table[∑ᵢ 2ⁿ⁻ⁱ bᵢ] = f(b₁, …, bₙ)
+//
+// that is, X₁ corresponds to the most significant bit of the index. This is
+// the convention of polynomial.MultiLin, so tables can be shared with the
+// sumcheck and GKR packages. Large tables are processed in parallel chunks.
+package mle
@@ -14,5 +14,6 @@
 
 // Code generated by consensys/gnark-crypto DO NOT EDIT
 
-// Package permutation provides an API to build permutation proofs.
+// Package permutation provides an API to build permutation proofs, and copy
+// constraints proofs (Plonk permutation argument) on traces made of several columns.
 package permutation
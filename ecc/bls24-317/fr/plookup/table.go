@@ -243,10 +243,5 @@ func deriveRandomness(fs *fiatshamir.Transcript, challenge string, points ...*bl
 		}
 	}
 
-	b, err := fs.ComputeChallenge(challenge)
-	if err != nil {
-		return r, err
-	}
-	r.SetBytes(b)
-	return r, nil
+	return fiatshamir.DeriveChallengeFr[fr.Element](fs, challenge)
 }
@@ -78,7 +78,7 @@ func setupTranscript(claimsNum int, varsNum int, settings *fiatshamir.Settings)
 	return
 }
 
-func next(transcript *fiatshamir.Transcript, bindings []fr.Element, remainingChallengeNames *[]string) (fr.Element, error) {
+func next(transcript *fiatshamir.Transcript, wide bool, bindings []fr.Element, remainingChallengeNames *[]string) (fr.Element, error) {
 	challengeName := (*remainingChallengeNames)[0]
 	for i := range bindings {
 		bytes := bindings[i].Bytes()
@@ -87,7 +87,7 @@ func next(transcript *fiatshamir.Transcript, bindings []fr.Element, remainingCha
 		}
 	}
 	var res fr.Element
-	bytes, err := transcript.ComputeChallenge(challengeName)
+	bytes, err := computeChallenge(transcript, challengeName, wide)
 	res.SetBytes(bytes)
 
 	*remainingChallengeNames = (*remainingChallengeNames)[1:]
@@ -95,6 +95,15 @@ func next(transcript *fiatshamir.Transcript, bindings []fr.Element, remainingCha
 	return res, err
 }
 
+// computeChallenge returns the challenge of the given name, expanded to twice the size
+// of the field if wide is set, see fiatshamir.Settings and fiatshamir.SetWideChallenges.
+func computeChallenge(transcript *fiatshamir.Transcript, challengeName string, wide bool) ([]byte, error) {
+	if wide || fiatshamir.WideChallenges() {
+		return transcript.ComputeChallengeWide(challengeName, 2*fr.Bytes)
+	}
+	return transcript.ComputeChallenge(challengeName)
+}
+
 // Prove create a non-interactive sumcheck proof
 func Prove(claims Claims, transcriptSettings fiatshamir.Settings) (Proof, error) {
 
@@ -107,7 +116,7 @@ func Prove(claims Claims, transcriptSettings fiatshamir.Settings) (Proof, error)
 
 	var combinationCoeff fr.Element
 	if claims.ClaimsNum() >= 2 {
-		if combinationCoeff, err = next(transcript, []fr.Element{}, &remainingChallengeNames); err != nil {
+		if combinationCoeff, err = next(transcript, transcriptSettings.WideChallenges, []fr.Element{}, &remainingChallengeNames); err != nil {
 			return proof, err
 		}
 	}
@@ -118,13 +127,13 @@ func Prove(claims Claims, transcriptSettings fiatshamir.Settings) (Proof, error)
 	challenges := make([]fr.Element, varsNum)
 
 	for j := 0; j+1 < varsNum; j++ {
-		if challenges[j], err = next(transcript, proof.PartialSumPolys[j], &remainingChallengeNames); err != nil {
+		if challenges[j], err = next(transcript, transcriptSettings.WideChallenges, proof.PartialSumPolys[j], &remainingChallengeNames); err != nil {
 			return proof, err
 		}
 		proof.PartialSumPolys[j+1] = claims.Next(challenges[j])
 	}
 
-	if challenges[varsNum-1], err = next(transcript, proof.PartialSumPolys[varsNum-1], &remainingChallengeNames); err != nil {
+	if challenges[varsNum-1], err = next(transcript, transcriptSettings.WideChallenges, proof.PartialSumPolys[varsNum-1], &remainingChallengeNames); err != nil {
 		return proof, err
 	}
 
@@ -143,7 +152,7 @@ func Verify(claims LazyClaims, proof Proof, transcriptSettings fiatshamir.Settin
 	var combinationCoeff fr.Element
 
 	if claims.ClaimsNum() >= 2 {
-		if combinationCoeff, err = next(transcript, []fr.Element{}, &remainingChallengeNames); err != nil {
+		if combinationCoeff, err = next(transcript, transcriptSettings.WideChallenges, []fr.Element{}, &remainingChallengeNames); err != nil {
 			return err
 		}
 	}
@@ -169,7 +178,7 @@ func Verify(claims LazyClaims, proof Proof, transcriptSettings fiatshamir.Settin
 		// gJ is ready
 
 		//Prepare for the next iteration
-		if r[j], err = next(transcript, proof.PartialSumPolys[j], &remainingChallengeNames); err != nil {
+		if r[j], err = next(transcript, transcriptSettings.WideChallenges, proof.PartialSumPolys[j], &remainingChallengeNames); err != nil {
 			return err
 		}
 		// This is an extremely inefficient way of interpolating. TODO: Interpolate without symbolically computing a polynomial
@@ -17,12 +17,16 @@
 package bls24317
 
 import (
+	"crypto/rand"
+	"encoding/binary"
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/fp"
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
 	"github.com/consensys/gnark-crypto/internal/parallel"
+	"github.com/consensys/gnark-crypto/utils/concurrency"
 	"math/big"
-	"runtime"
+	"sync"
+	"sync/atomic"
 )
 
 // G1Affine is a point in affine coordinates (x,y)
@@ -1029,15 +1033,25 @@ func (p *g1JacExtended) doubleMixed(a *G1Affine) *g1JacExtended {
 // BatchJacobianToAffineG1 converts points in Jacobian coordinates to Affine coordinates
 // performing a single field inversion using the Montgomery batch inversion trick.
 func BatchJacobianToAffineG1(points []G1Jac) []G1Affine {
-	result := make([]G1Affine, len(points))
-	zeroes := make([]bool, len(points))
-	accumulator := fp.One()
+	return BatchJacobianToAffineG1Into(nil, points)
+}
+
+// BatchJacobianToAffineG1Into is like BatchJacobianToAffineG1, but writes the result in dst,
+// which is reallocated only if its capacity is smaller than len(points). It returns dst[:len(points)].
+//
+// dst must not overlap with points.
+func BatchJacobianToAffineG1Into(dst []G1Affine, points []G1Jac) []G1Affine {
+	if cap(dst) < len(points) {
+		dst = make([]G1Affine, len(points))
+	}
+	result := dst[:len(points)]
+	var accumulator fp.Element
+	accumulator.SetOne()
 
 	// batch invert all points[].Z coordinates with Montgomery batch inversion trick
 	// (stores points[].Z^-1 in result[i].X to avoid allocating a slice of fr.Elements)
 	for i := 0; i < len(points); i++ {
 		if points[i].Z.IsZero() {
-			zeroes[i] = true
 			continue
 		}
 		result[i].X = accumulator
@@ -1048,8 +1062,7 @@ func BatchJacobianToAffineG1(points []G1Jac) []G1Affine {
 	accInverse.Inverse(&accumulator)
 
 	for i := len(points) - 1; i >= 0; i-- {
-		if zeroes[i] {
-			// do nothing, (X=0, Y=0) is infinity point in affine
+		if points[i].Z.IsZero() {
 			continue
 		}
 		result[i].X.Mul(&result[i].X, &accInverse)
@@ -1059,8 +1072,9 @@ func BatchJacobianToAffineG1(points []G1Jac) []G1Affine {
 	// batch convert to affine.
 	parallel.Execute(len(points), func(start, end int) {
 		for i := start; i < end; i++ {
-			if zeroes[i] {
-				// do nothing, (X=0, Y=0) is infinity point in affine
+			if points[i].Z.IsZero() {
+				// (X=0, Y=0) is infinity point in affine
+				result[i].setInfinity()
 				continue
 			}
 			var a, b fp.Element
@@ -1075,6 +1089,24 @@ func BatchJacobianToAffineG1(points []G1Jac) []G1Affine {
 	return result
 }
 
+// batchJacobianToAffineG1Pool holds the buffers used by BatchJacobianToAffineG1Pooled.
+var batchJacobianToAffineG1Pool = sync.Pool{
+	New: func() any {
+		return new([]G1Affine)
+	},
+}
+
+// BatchJacobianToAffineG1Pooled is like BatchJacobianToAffineG1, but the result is
+// backed by a buffer taken from a pool. The caller must call release once it
+// no longer uses the result.
+func BatchJacobianToAffineG1Pooled(points []G1Jac) (result []G1Affine, release func()) {
+	buf := batchJacobianToAffineG1Pool.Get().(*[]G1Affine)
+	*buf = BatchJacobianToAffineG1Into(*buf, points)
+	return *buf, func() {
+		batchJacobianToAffineG1Pool.Put(buf)
+	}
+}
+
 // BatchScalarMultiplicationG1 multiplies the same base by all scalars
 // and return resulting points in affine coordinates
 // uses a simple windowed-NAF-like multiplication algorithm.
@@ -1114,11 +1146,12 @@ func BatchScalarMultiplicationG1(base *G1Affine, scalars []fr.Element) []G1Affin
 		baseTable[i].AddMixed(base)
 	}
 	// convert our base exp table into affine to use AddMixed
-	baseTableAff := BatchJacobianToAffineG1(baseTable)
+	baseTableAff, release := BatchJacobianToAffineG1Pooled(baseTable)
+	defer release()
 	toReturn := make([]G1Jac, len(scalars))
 
 	// partition the scalars into digits
-	digits, _ := partitionScalars(scalars, c, runtime.NumCPU())
+	digits, _ := partitionScalars(scalars, c, concurrency.MaxTasks())
 
 	// for each digit, take value in the base table, double it c time, voilà.
 	parallel.Execute(len(scalars), func(start, end int) {
@@ -1208,3 +1241,66 @@ func batchAddG1Affine[TP pG1Affine, TPP ppG1Affine, TC cG1Affine](R *TPP, P *TP,
 		(*R)[j].Set(&rr)
 	}
 }
+
+// batchSubGroupCheckRounds is the number of random subset sums checked by the batch
+// subgroup membership tests. A point outside the subgroup is detected by each of them
+// with probability at least 1/2.
+const batchSubGroupCheckRounds = 64
+
+// BatchIsInSubGroupG1 returns true if all the points are on the curve and
+// in the r-torsion subgroup, false otherwise.
+//
+// The points are checked to be on the curve one by one. Instead of running a subgroup
+// check per point, it then checks that batchSubGroupCheckRounds sums of random subsets
+// of the points are in the subgroup. If a point is not in the subgroup, each sum is
+// outside the subgroup with probability at least 1/2, so an invalid batch is accepted
+// with probability at most 2⁻⁶⁴.
+//
+// Small batches, for which this is not worth it, are checked point by point.
+func BatchIsInSubGroupG1(points []G1Affine) bool {
+	var invalid atomic.Bool
+	if len(points) <= 2*batchSubGroupCheckRounds {
+		parallel.Execute(len(points), func(start, end int) {
+			for i := start; i < end && !invalid.Load(); i++ {
+				if !points[i].IsInSubGroup() {
+					invalid.Store(true)
+				}
+			}
+		})
+		return !invalid.Load()
+	}
+
+	// bit j of masks[i] tells if the point i is in the j-th subset
+	randomness := make([]byte, 8*len(points))
+	if _, err := rand.Read(randomness); err != nil {
+		panic(err)
+	}
+	masks := make([]uint64, len(points))
+	parallel.Execute(len(points), func(start, end int) {
+		for i := start; i < end && !invalid.Load(); i++ {
+			if !points[i].IsOnCurve() {
+				invalid.Store(true)
+			}
+			masks[i] = binary.LittleEndian.Uint64(randomness[8*i:])
+		}
+	})
+	if invalid.Load() {
+		return false
+	}
+
+	parallel.Execute(batchSubGroupCheckRounds, func(start, end int) {
+		var sum G1Jac
+		for j := start; j < end && !invalid.Load(); j++ {
+			sum.Set(&g1Infinity)
+			for i := range points {
+				if masks[i]>>j&1 == 1 {
+					sum.AddMixed(&points[i])
+				}
+			}
+			if !sum.IsInSubGroup() {
+				invalid.Store(true)
+			}
+		}
+	})
+	return !invalid.Load()
+}
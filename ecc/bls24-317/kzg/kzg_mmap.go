@@ -0,0 +1,125 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"encoding/binary"
+	"errors"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317"
+	"github.com/consensys/gnark-crypto/utils/mmap"
+)
+
+// ErrInvalidProvingKeyFile is returned when a memory-mapped proving key file is malformed.
+var ErrInvalidProvingKeyFile = errors.New("invalid proving key file")
+
+// mmapChunkSize is the number of points decoded at once by ProvingKeyMMap.
+const mmapChunkSize = 1 << 16
+
+// ProvingKeyMMap is a ProvingKey backed by a memory-mapped file, as written by
+// ProvingKey.WriteRawTo.
+//
+// Points are decoded and checked by chunks, the first time they are needed.
+// It is safe for concurrent use.
+type ProvingKeyMMap struct {
+	file   *mmap.File
+	data   []byte // raw encoded points
+	g1     []bls24317.G1Affine
+	chunks []mmapChunk
+}
+
+type mmapChunk struct {
+	once sync.Once
+	err  error
+}
+
+// NewProvingKeyMMap memory-maps the uncompressed proving key file at path.
+// The caller must call Close once the key is no longer needed.
+func NewProvingKeyMMap(path string) (*ProvingKeyMMap, error) {
+	file, err := mmap.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	data := file.Bytes()
+	if len(data) < 4 {
+		file.Close()
+		return nil, ErrInvalidProvingKeyFile
+	}
+	n := uint64(binary.BigEndian.Uint32(data[:4]))
+	data = data[4:]
+	if uint64(len(data)) != n*bls24317.SizeOfG1AffineUncompressed {
+		file.Close()
+		return nil, ErrInvalidProvingKeyFile
+	}
+
+	return &ProvingKeyMMap{
+		file:   file,
+		data:   data,
+		g1:     make([]bls24317.G1Affine, n),
+		chunks: make([]mmapChunk, (n+mmapChunkSize-1)/mmapChunkSize),
+	}, nil
+}
+
+// Len returns the number of points in the proving key.
+func (pk *ProvingKeyMMap) Len() int {
+	return len(pk.g1)
+}
+
+// ProvingKey returns a ProvingKey made of the first n points, decoding the
+// ones that were not already.
+//
+// The returned ProvingKey shares its points with pk and stays valid after Close.
+func (pk *ProvingKeyMMap) ProvingKey(n int) (ProvingKey, error) {
+	if n < 0 || n > len(pk.g1) {
+		return ProvingKey{}, ErrInvalidPolynomialSize
+	}
+	for i := 0; i*mmapChunkSize < n; i++ {
+		if err := pk.decodeChunk(i); err != nil {
+			return ProvingKey{}, err
+		}
+	}
+	return ProvingKey{G1: pk.g1[:n:n]}, nil
+}
+
+// decodeChunk decodes the i-th chunk of points, once.
+func (pk *ProvingKeyMMap) decodeChunk(i int) error {
+	c := &pk.chunks[i]
+	c.once.Do(func() {
+		if pk.data == nil {
+			c.err = ErrInvalidProvingKeyFile
+			return
+		}
+		start := i * mmapChunkSize
+		end := min(start+mmapChunkSize, len(pk.g1))
+		buf := pk.data[start*bls24317.SizeOfG1AffineUncompressed : end*bls24317.SizeOfG1AffineUncompressed]
+		points, err := bls24317.BatchDecodeG1Affine(buf, bls24317.WithBatchSubGroupCheck())
+		if err != nil {
+			c.err = err
+			return
+		}
+		copy(pk.g1[start:end], points)
+	})
+	return c.err
+}
+
+// Close unmaps the underlying file. Chunks that were not decoded before can no
+// longer be accessed.
+func (pk *ProvingKeyMMap) Close() error {
+	pk.data = nil
+	return pk.file.Close()
+}
@@ -0,0 +1,177 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls24317
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fp"
+)
+
+// ctModulus and ctRSquare are q and R² mod q as raw limbs, used to decode the field
+// elements of an encoding in constant time.
+var (
+	ctModulus [fp.Limbs]uint64
+	ctRSquare fp.Element
+)
+
+func init() {
+	var buf [fp.Bytes]byte
+	q := fp.Modulus()
+	q.FillBytes(buf[:])
+	for i := range ctModulus {
+		ctModulus[i] = binary.BigEndian.Uint64(buf[fp.Bytes-8*(i+1):])
+	}
+	r2 := new(big.Int).Lsh(big.NewInt(1), 2*64*fp.Limbs)
+	r2.Mod(r2, q).FillBytes(buf[:])
+	for i := range ctRSquare {
+		ctRSquare[i] = binary.BigEndian.Uint64(buf[fp.Bytes-8*(i+1):])
+	}
+}
+
+// fpSetBytesConstantTime sets z from the fp.Bytes big-endian bytes in b. It returns 1 if
+// b is a canonical encoding (strictly smaller than q), 0 otherwise, in constant time.
+func fpSetBytesConstantTime(z *fp.Element, b []byte) int {
+	var x fp.Element
+	for i := range x {
+		x[i] = binary.BigEndian.Uint64(b[fp.Bytes-8*(i+1):])
+	}
+	// x < q if and only if x - q borrows
+	var borrow uint64
+	for i := range x {
+		_, borrow = bits.Sub64(x[i], ctModulus[i], borrow)
+	}
+	// to Montgomery form
+	z.Mul(&x, &ctRSquare)
+	return int(borrow)
+}
+
+// isZeroedConstantTime returns 1 if all the bytes of b are zero, 0 otherwise, in constant time.
+func isZeroedConstantTime(b []byte) int {
+	var acc byte
+	for _, v := range b {
+		acc |= v
+	}
+	return subtle.ConstantTimeByteEq(acc, 0)
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// SetBytesConstantTime sets p from its uncompressed encoding buf, as output by RawBytes,
+// and checks it strictly: the encoding must be exactly SizeOfG1AffineUncompressed bytes
+// with the uncompressed flags, the coordinates must be canonical (smaller than the modulus),
+// and the point must be on the curve and in the prime order subgroup.
+// The point at infinity must carry the infinity flag, with all the other bits set to zero.
+//
+// Unlike SetBytes, all the checks are performed regardless of the outcome of the previous
+// ones and any failure is reported with the same error, ErrInvalidEncoding, so that neither
+// the error nor the timing of the field operations reveal which check failed. This makes it
+// suitable to decode points from untrusted sources, e.g. to thwart invalid-curve attacks.
+//
+// Compressed encodings are not supported: recovering Y needs a square root, whose timing
+// depends on X. p is left unchanged on error.
+func (p *G1Affine) SetBytesConstantTime(buf []byte) error {
+	// the length is public
+	if len(buf) != SizeOfG1AffineUncompressed {
+		return ErrInvalidEncoding
+	}
+	var b [SizeOfG1AffineUncompressed]byte
+	copy(b[:], buf)
+	mData := b[0] & mMask
+	b[0] &^= mMask
+
+	var q G1Affine
+	coordinates := [...]*fp.Element{&q.X, &q.Y}
+	valid := 1
+	for i, c := range coordinates {
+		valid &= fpSetBytesConstantTime(c, b[i*fp.Bytes:(i+1)*fp.Bytes])
+	}
+
+	zero := isZeroedConstantTime(b[:])
+	isInfinity := subtle.ConstantTimeByteEq(mData, mUncompressedInfinity)
+	valid &= subtle.ConstantTimeByteEq(mData, mUncompressed) | isInfinity
+	// the infinity flag is set if and only if the coordinates are zero
+	valid &= 1 ^ (isInfinity ^ zero)
+
+	onCurve := boolToInt(q.IsOnCurve())
+	inSubGroup := boolToInt(q.IsInSubGroup())
+	valid &= isInfinity | (onCurve & inSubGroup)
+
+	if valid != 1 {
+		return ErrInvalidEncoding
+	}
+	p.Set(&q)
+	return nil
+}
+
+// SetBytesConstantTime sets p from its uncompressed encoding buf, as output by RawBytes,
+// and checks it strictly: the encoding must be exactly SizeOfG2AffineUncompressed bytes
+// with the uncompressed flags, the coordinates must be canonical (smaller than the modulus),
+// and the point must be on the curve and in the prime order subgroup.
+// The point at infinity must carry the infinity flag, with all the other bits set to zero.
+//
+// Unlike SetBytes, all the checks are performed regardless of the outcome of the previous
+// ones and any failure is reported with the same error, ErrInvalidEncoding, so that neither
+// the error nor the timing of the field operations reveal which check failed. This makes it
+// suitable to decode points from untrusted sources, e.g. to thwart invalid-curve attacks.
+//
+// Compressed encodings are not supported: recovering Y needs a square root, whose timing
+// depends on X. p is left unchanged on error.
+func (p *G2Affine) SetBytesConstantTime(buf []byte) error {
+	// the length is public
+	if len(buf) != SizeOfG2AffineUncompressed {
+		return ErrInvalidEncoding
+	}
+	var b [SizeOfG2AffineUncompressed]byte
+	copy(b[:], buf)
+	mData := b[0] & mMask
+	b[0] &^= mMask
+
+	var q G2Affine
+	coordinates := [...]*fp.Element{
+		&q.X.B1.A1, &q.X.B1.A0, &q.X.B0.A1, &q.X.B0.A0,
+		&q.Y.B1.A1, &q.Y.B1.A0, &q.Y.B0.A1, &q.Y.B0.A0,
+	}
+	valid := 1
+	for i, c := range coordinates {
+		valid &= fpSetBytesConstantTime(c, b[i*fp.Bytes:(i+1)*fp.Bytes])
+	}
+
+	zero := isZeroedConstantTime(b[:])
+	isInfinity := subtle.ConstantTimeByteEq(mData, mUncompressedInfinity)
+	valid &= subtle.ConstantTimeByteEq(mData, mUncompressed) | isInfinity
+	// the infinity flag is set if and only if the coordinates are zero
+	valid &= 1 ^ (isInfinity ^ zero)
+
+	onCurve := boolToInt(q.IsOnCurve())
+	inSubGroup := boolToInt(q.IsInSubGroup())
+	valid &= isInfinity | (onCurve & inSubGroup)
+
+	if valid != 1 {
+		return ErrInvalidEncoding
+	}
+	p.Set(&q)
+	return nil
+}
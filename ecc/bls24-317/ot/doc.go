@@ -0,0 +1,30 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package ot provides base oblivious transfers on the G1 group of the bls24-317
+// curve, with the "simplest OT" protocol of Chou and Orlandi.
+//
+// The sender draws a and sends A = a⋅G. For each OT, the receiver with choice bit c draws
+// b and sends B = b⋅G + c⋅A, and its key is H(A, B, b⋅A). The two keys of the sender are
+// H(A, B, a⋅B) and H(A, B, a⋅(B - A)): the receiver can compute only one of them, and B
+// does not reveal c.
+//
+// The keys are those of random OTs, to be extended with the IKNP extension of the
+// gnark-crypto/ot package, or used to transfer chosen messages with ot.Encrypt.
+//
+// Documentation:
+// - Chou, Orlandi, "The Simplest Protocol for Oblivious Transfer", https://eprint.iacr.org/2015/267
+package ot
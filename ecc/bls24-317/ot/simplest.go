@@ -0,0 +1,164 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ot
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/consensys/gnark-crypto/ot"
+)
+
+// SizePoint is the size of the encoding of A and of each B.
+const SizePoint = bls24317.SizeOfG1AffineCompressed
+
+// domain separates the hashes of the base OTs.
+const domain = "ot/simplest/bls24-317"
+
+var (
+	ErrInvalidPoint  = errors.New("invalid point: must be in G1 and not the infinity")
+	ErrInvalidLength = errors.New("invalid message length")
+)
+
+var (
+	order = fr.Modulus()
+	one   = new(big.Int).SetInt64(1)
+)
+
+// Sender is the sender of a batch of base OTs.
+type Sender struct {
+	a     *big.Int
+	A, aA bls24317.G1Affine
+	encA  []byte
+}
+
+// NewSender returns a sender, with randomness from rand. Setup must be sent to the
+// receiver.
+func NewSender(rand io.Reader) (*Sender, error) {
+	a, err := randomScalar(rand)
+	if err != nil {
+		return nil, err
+	}
+	s := &Sender{a: a}
+	s.A.ScalarMultiplicationBase(a)
+	s.aA.ScalarMultiplication(&s.A, a)
+	b := s.A.Bytes()
+	s.encA = b[:]
+	return s, nil
+}
+
+// Setup returns the message A = a⋅G of the sender.
+func (s *Sender) Setup() []byte {
+	return append([]byte{}, s.encA...)
+}
+
+// Keys returns the pairs of keys of the OTs, from the message of the receiver. The
+// receiver knows only one key of each pair.
+func (s *Sender) Keys(msg []byte) ([][2]ot.Key, error) {
+	if len(msg)%SizePoint != 0 {
+		return nil, ErrInvalidLength
+	}
+	keys := make([][2]ot.Key, len(msg)/SizePoint)
+	for i := range keys {
+		encB := msg[i*SizePoint : (i+1)*SizePoint]
+		var B, P bls24317.G1Affine
+		if err := setPoint(&B, encB); err != nil {
+			return nil, err
+		}
+		// k₀ = H(A, B, a⋅B), k₁ = H(A, B, a⋅B - a⋅A)
+		P.ScalarMultiplication(&B, s.a)
+		keys[i][0] = hashKey(uint64(i), s.encA, encB, &P)
+		P.Sub(&P, &s.aA)
+		keys[i][1] = hashKey(uint64(i), s.encA, encB, &P)
+	}
+	return keys, nil
+}
+
+// Receive runs the receiver of len(choices) base OTs, with randomness from rand, on the
+// setup message of the sender. It returns the message to send to the sender, and the
+// key of the sender chosen by each choice bit.
+func Receive(rand io.Reader, setup []byte, choices []bool) (msg []byte, keys []ot.Key, err error) {
+	if len(setup) != SizePoint {
+		return nil, nil, ErrInvalidLength
+	}
+	var A bls24317.G1Affine
+	if err := setPoint(&A, setup); err != nil {
+		return nil, nil, err
+	}
+
+	msg = make([]byte, 0, len(choices)*SizePoint)
+	keys = make([]ot.Key, len(choices))
+	for i, c := range choices {
+		b, err := randomScalar(rand)
+		if err != nil {
+			return nil, nil, err
+		}
+		// B = b⋅G + c⋅A, k = H(A, B, b⋅A)
+		var B, P bls24317.G1Affine
+		B.ScalarMultiplicationBase(b)
+		if c {
+			B.Add(&B, &A)
+		}
+		encB := B.Bytes()
+		P.ScalarMultiplication(&A, b)
+		keys[i] = hashKey(uint64(i), setup, encB[:], &P)
+		msg = append(msg, encB[:]...)
+	}
+	return msg, keys, nil
+}
+
+// setPoint decodes p from buf, and checks that it is in G1 and not the infinity.
+func setPoint(p *bls24317.G1Affine, buf []byte) error {
+	if _, err := p.SetBytes(buf); err != nil || p.IsInfinity() {
+		return ErrInvalidPoint
+	}
+	return nil
+}
+
+// hashKey returns H(i, A, B, P).
+func hashKey(index uint64, encA, encB []byte, P *bls24317.G1Affine) ot.Key {
+	h := sha256.New()
+	h.Write([]byte(domain))
+	h.Write(binary.BigEndian.AppendUint64(nil, index))
+	h.Write(encA)
+	h.Write(encB)
+	encP := P.Bytes()
+	h.Write(encP[:])
+	var res ot.Key
+	copy(res[:], h.Sum(nil))
+	return res
+}
+
+// randomScalar returns a random scalar in [1, order-1].
+func randomScalar(rand io.Reader) (k *big.Int, err error) {
+	b := make([]byte, fr.Bits/8+8)
+	_, err = io.ReadFull(rand, b)
+	if err != nil {
+		return
+	}
+
+	k = new(big.Int).SetBytes(b)
+	n := new(big.Int).Sub(order, one)
+	k.Mod(k, n)
+	k.Add(k, one)
+	return
+}
@@ -130,8 +130,28 @@ func (sig *Signature) Bytes() []byte {
 // It returns the number of bytes read from buf.
 func (sig *Signature) SetBytes(buf []byte) (int, error) {
 	n := 0
+	if err := checkSignatureBytes(buf); err != nil {
+		return n, err
+	}
+
+	// deserialisation
+	if _, err := sig.R.SetBytes(buf[:sizeFr]); err != nil {
+		return 0, err
+	}
+	n += sizeFr
+	if !sig.R.IsOnCurve() {
+		return n, errNotOnCurve
+	}
+	subtle.ConstantTimeCopy(1, sig.S[:], buf[sizeFr:2*sizeFr])
+	n += sizeFr
+	return n, nil
+}
+
+// checkSignatureBytes checks the size of buf and that the encoded R and S are
+// in range, to avoid malleability.
+func checkSignatureBytes(buf []byte) error {
 	if len(buf) != sizeSignature {
-		return n, errWrongSize
+		return errWrongSize
 	}
 
 	// R < P_mod (to avoid malleability)
@@ -146,32 +166,22 @@ func (sig *Signature) SetBytes(buf []byte) (int, error) {
 	bufCopy[0] &= mUnmask
 	bufBigInt.SetBytes(bufCopy)
 	if bufBigInt.Cmp(zero) == 0 {
-		return 0, errZero
+		return errZero
 	}
 	if bufBigInt.Cmp(fpMod) != -1 {
-		return 0, errRBiggerThanPMod
+		return errRBiggerThanPMod
 	}
 
 	// S < R_mod (to avoid malleability)
 	// R_mod is the relevant group size of the twisted Edwards NOT the fr snark field so it's supposedly smaller
 	bufBigInt.SetBytes(buf[sizeFr : 2*sizeFr])
 	if bufBigInt.Cmp(zero) == 0 {
-		return 0, errZero
+		return errZero
 	}
 	cp := twistededwards.GetEdwardsCurve()
 	if bufBigInt.Cmp(&cp.Order) != -1 {
-		return 0, errSBiggerThanRMod
+		return errSBiggerThanRMod
 	}
 
-	// deserialisation
-	if _, err := sig.R.SetBytes(buf[:sizeFr]); err != nil {
-		return 0, err
-	}
-	n += sizeFr
-	if !sig.R.IsOnCurve() {
-		return n, errNotOnCurve
-	}
-	subtle.ConstantTimeCopy(1, sig.S[:], buf[sizeFr:2*sizeFr])
-	n += sizeFr
-	return n, nil
+	return nil
 }
@@ -19,7 +19,6 @@ package ecdsa
 import (
 	"crypto/aes"
 	"crypto/cipher"
-	"crypto/rand"
 	"crypto/sha512"
 	"crypto/subtle"
 	"errors"
@@ -175,7 +174,7 @@ const (
 	aesIV = "gnark-crypto IV." // must be 16 chars (equal block size)
 )
 
-func nonce(privateKey *PrivateKey, hash []byte) (csprng *cipher.StreamReader, err error) {
+func nonce(privateKey *PrivateKey, hash []byte, rand io.Reader) (csprng *cipher.StreamReader, err error) {
 	// This implementation derives the nonce from an AES-CTR CSPRNG keyed by:
 	//
 	//    SHA2-512(privateKey.scalar ∥ entropy ∥ hash)[:32]
@@ -189,7 +188,7 @@ func nonce(privateKey *PrivateKey, hash []byte) (csprng *cipher.StreamReader, er
 
 	// Get 256 bits of entropy from rand.
 	entropy := make([]byte, 32)
-	_, err = io.ReadFull(rand.Reader, entropy)
+	_, err = io.ReadFull(rand, entropy)
 	if err != nil {
 		return
 
@@ -216,6 +215,19 @@ func nonce(privateKey *PrivateKey, hash []byte) (csprng *cipher.StreamReader, er
 	return csprng, err
 }
 
+// hashMessage returns the hash of message by hFunc, or message itself if
+// hFunc is nil (pre-hashed message).
+func hashMessage(message []byte, hFunc hash.Hash) ([]byte, error) {
+	if hFunc == nil {
+		return message, nil
+	}
+	hFunc.Reset()
+	if _, err := hFunc.Write(message); err != nil {
+		return nil, err
+	}
+	return hFunc.Sum(nil), nil
+}
+
 // Equal compares 2 public keys
 func (pub *PublicKey) Equal(x signature.PublicKey) bool {
 	xx, ok := x.(*PublicKey)
@@ -244,25 +256,42 @@ func (privKey *PrivateKey) Public() signature.PublicKey {
 //
 // SEC 1, Version 2.0, Section 4.1.3
 func (privKey *PrivateKey) SignForRecover(message []byte, hFunc hash.Hash) (v uint, r, s *big.Int, err error) {
+	return privKey.SignForRecoverWithOptions(message, hFunc)
+}
+
+// SignForRecoverWithOptions is SignForRecover, with the derivation of the
+// nonce k selected by opts (see WithNonceMode), and optional blinding (see
+// WithBlinding).
+func (privKey *PrivateKey) SignForRecoverWithOptions(message []byte, hFunc hash.Hash, opts ...SignOption) (v uint, r, s *big.Int, err error) {
+	cfg, err := newSignConfig(opts)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	digest, err := hashMessage(message, hFunc)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	m := HashToInt(digest)
+	nonces, err := newNonceGenerator(privKey, message, digest, cfg)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
 	r, s = new(big.Int), new(big.Int)
 
-	scalar, kInv := new(big.Int), new(big.Int)
-	scalar.SetBytes(privKey.scalar[:sizeFr])
+	scalar := new(big.Int).SetBytes(privKey.scalar[:sizeFr])
 	for {
+		var k *big.Int
 		for {
-			csprng, err := nonce(privKey, message)
-			if err != nil {
+			if k, err = nonces.next(); err != nil {
 				return 0, nil, nil, err
 			}
-			k, err := randFieldElement(csprng)
+
+			P, err := cfg.mulBase(k)
 			if err != nil {
 				return 0, nil, nil, err
 			}
 
-			var P bn254.G1Affine
-			P.ScalarMultiplicationBase(k)
-			kInv.ModInverse(k, order)
-
 			P.X.BigInt(r)
 			// set how many times we overflow the scalar field
 			v |= (uint(new(big.Int).Div(r, order).Uint64())) << 1
@@ -274,27 +303,9 @@ func (privKey *PrivateKey) SignForRecover(message []byte, hFunc hash.Hash) (v ui
 				break
 			}
 		}
-		s.Mul(r, scalar)
-
-		var m *big.Int
-		if hFunc != nil {
-			// compute the hash of the message as an integer
-			dataToHash := make([]byte, len(message))
-			copy(dataToHash[:], message[:])
-			hFunc.Reset()
-			_, err := hFunc.Write(dataToHash[:])
-			if err != nil {
-				return 0, nil, nil, err
-			}
-			hramBin := hFunc.Sum(nil)
-			m = HashToInt(hramBin)
-		} else {
-			m = HashToInt(message)
+		if err := cfg.computeS(s, k, m, r, scalar); err != nil {
+			return 0, nil, nil, err
 		}
-
-		s.Add(m, s).
-			Mul(kInv, s).
-			Mod(s, order) // order != 0
 		if s.Sign() != 0 {
 			break
 		}
@@ -313,7 +324,13 @@ func (privKey *PrivateKey) SignForRecover(message []byte, hFunc hash.Hash) (v ui
 //
 // SEC 1, Version 2.0, Section 4.1.3
 func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error) {
-	_, r, s, err := privKey.SignForRecover(message, hFunc)
+	return privKey.SignWithOptions(message, hFunc)
+}
+
+// SignWithOptions is Sign, with the derivation of the nonce k selected by
+// opts (see WithNonceMode), and optional blinding (see WithBlinding).
+func (privKey *PrivateKey) SignWithOptions(message []byte, hFunc hash.Hash, opts ...SignOption) ([]byte, error) {
+	_, r, s, err := privKey.SignForRecoverWithOptions(message, hFunc, opts...)
 	if err != nil {
 		return nil, err
 	}
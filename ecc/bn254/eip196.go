@@ -0,0 +1,225 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bn254
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/internal/fptower"
+)
+
+// Encodings used by the ecAdd, ecMul (https://eips.ethereum.org/EIPS/eip-196) and
+// ecPairing (https://eips.ethereum.org/EIPS/eip-197) precompiles.
+//
+// A base field element is encoded on 32 bytes, big endian. A G1 point is encoded
+// as x ‖ y. An element a0 + a1⋅u of the quadratic extension is encoded as a1 ‖ a0
+// (imaginary part first), so a G2 point is encoded as x.A1 ‖ x.A0 ‖ y.A1 ‖ y.A0.
+// The point at infinity is encoded with all bytes set to zero.
+//
+// G1 points must be on the curve (the cofactor is 1). G2 points must be on the
+// curve and in the prime order subgroup.
+const (
+	SizeOfFpEIP196            = fp.Bytes
+	SizeOfG1AffineEIP196      = 2 * SizeOfFpEIP196
+	SizeOfScalarEIP196        = 32
+	SizeOfECAddInputEIP196    = 2 * SizeOfG1AffineEIP196
+	SizeOfECMulInputEIP196    = SizeOfG1AffineEIP196 + SizeOfScalarEIP196
+	SizeOfG2AffineEIP197      = 4 * SizeOfFpEIP196
+	SizeOfPairingPairEIP197   = SizeOfG1AffineEIP196 + SizeOfG2AffineEIP197
+	SizeOfPairingOutputEIP197 = 32
+)
+
+var (
+	ErrInvalidEIP196Size = errors.New("eip-196: invalid input size")
+	ErrInvalidEIP197Size = errors.New("eip-197: invalid input size")
+)
+
+// MarshalEIP196 returns the EIP-196 encoding of p.
+func (p *G1Affine) MarshalEIP196() (res [SizeOfG1AffineEIP196]byte) {
+	if p.IsInfinity() {
+		return
+	}
+	fp.BigEndian.PutElement((*[fp.Bytes]byte)(res[:SizeOfFpEIP196]), p.X)
+	fp.BigEndian.PutElement((*[fp.Bytes]byte)(res[SizeOfFpEIP196:]), p.Y)
+	return
+}
+
+// UnmarshalEIP196 sets p from its EIP-196 encoding. It returns an error if a
+// coordinate is not canonical or if the point is not on the curve.
+func (p *G1Affine) UnmarshalEIP196(buf []byte) error {
+	if len(buf) != SizeOfG1AffineEIP196 {
+		return ErrInvalidEIP196Size
+	}
+	var err error
+	if p.X, err = fp.BigEndian.Element((*[fp.Bytes]byte)(buf[:SizeOfFpEIP196])); err != nil {
+		return err
+	}
+	if p.Y, err = fp.BigEndian.Element((*[fp.Bytes]byte)(buf[SizeOfFpEIP196:])); err != nil {
+		return err
+	}
+	if p.IsInfinity() {
+		return nil
+	}
+	if !p.IsOnCurve() {
+		return errors.New("eip-196: point not on curve")
+	}
+	return nil
+}
+
+// MarshalEIP197 returns the EIP-197 encoding of p.
+func (p *G2Affine) MarshalEIP197() (res [SizeOfG2AffineEIP197]byte) {
+	if p.IsInfinity() {
+		return
+	}
+	putE2EIP197(res[:2*SizeOfFpEIP196], &p.X)
+	putE2EIP197(res[2*SizeOfFpEIP196:], &p.Y)
+	return
+}
+
+// UnmarshalEIP197 sets p from its EIP-197 encoding. It returns an error if a
+// coordinate is not canonical, or if the point is not on the curve or not in the
+// prime order subgroup.
+func (p *G2Affine) UnmarshalEIP197(buf []byte) error {
+	if len(buf) != SizeOfG2AffineEIP197 {
+		return ErrInvalidEIP197Size
+	}
+	if err := setE2EIP197(&p.X, buf[:2*SizeOfFpEIP196]); err != nil {
+		return err
+	}
+	if err := setE2EIP197(&p.Y, buf[2*SizeOfFpEIP196:]); err != nil {
+		return err
+	}
+	if p.IsInfinity() {
+		return nil
+	}
+	if !p.IsOnCurve() {
+		return errors.New("eip-197: point not on curve")
+	}
+	if !p.IsInSubGroup() {
+		return errors.New("eip-197: point not in subgroup")
+	}
+	return nil
+}
+
+// ECAddInputEIP196 returns the input of the ecAdd precompile for p + q.
+func ECAddInputEIP196(p, q *G1Affine) (res [SizeOfECAddInputEIP196]byte) {
+	pb := p.MarshalEIP196()
+	qb := q.MarshalEIP196()
+	copy(res[:], pb[:])
+	copy(res[SizeOfG1AffineEIP196:], qb[:])
+	return
+}
+
+// DecodeECAddInputEIP196 parses the input of the ecAdd precompile. As the
+// precompile, it pads buf with zeros if it is shorter than
+// SizeOfECAddInputEIP196 and ignores the extra bytes otherwise.
+func DecodeECAddInputEIP196(buf []byte) (p, q G1Affine, err error) {
+	var in [SizeOfECAddInputEIP196]byte
+	copy(in[:], buf)
+	if err = p.UnmarshalEIP196(in[:SizeOfG1AffineEIP196]); err != nil {
+		return
+	}
+	err = q.UnmarshalEIP196(in[SizeOfG1AffineEIP196:])
+	return
+}
+
+// ECMulInputEIP196 returns the input of the ecMul precompile for [s]p. s must
+// fit on 256 bits.
+func ECMulInputEIP196(p *G1Affine, s *big.Int) (res [SizeOfECMulInputEIP196]byte, err error) {
+	if s.Sign() < 0 || s.BitLen() > 8*SizeOfScalarEIP196 {
+		return res, errors.New("eip-196: invalid scalar")
+	}
+	pb := p.MarshalEIP196()
+	copy(res[:], pb[:])
+	s.FillBytes(res[SizeOfG1AffineEIP196:])
+	return
+}
+
+// DecodeECMulInputEIP196 parses the input of the ecMul precompile. As the
+// precompile, it pads buf with zeros if it is shorter than
+// SizeOfECMulInputEIP196 and ignores the extra bytes otherwise. The scalar is
+// not reduced modulo the group order.
+func DecodeECMulInputEIP196(buf []byte) (p G1Affine, s big.Int, err error) {
+	var in [SizeOfECMulInputEIP196]byte
+	copy(in[:], buf)
+	if err = p.UnmarshalEIP196(in[:SizeOfG1AffineEIP196]); err != nil {
+		return
+	}
+	s.SetBytes(in[SizeOfG1AffineEIP196:])
+	return
+}
+
+// PairingCheckInputEIP197 returns the input of the ecPairing precompile for
+// ∏ᵢ e(Pᵢ, Qᵢ) == 1.
+func PairingCheckInputEIP197(P []G1Affine, Q []G2Affine) ([]byte, error) {
+	if len(P) != len(Q) {
+		return nil, errors.New("invalid inputs sizes")
+	}
+	res := make([]byte, 0, len(P)*SizeOfPairingPairEIP197)
+	for i := range P {
+		p := P[i].MarshalEIP196()
+		q := Q[i].MarshalEIP197()
+		res = append(res, p[:]...)
+		res = append(res, q[:]...)
+	}
+	return res, nil
+}
+
+// DecodePairingCheckInputEIP197 parses the input of the ecPairing precompile.
+// The empty input is valid, the product of no pairings being 1. The result can
+// be fed to [PairingCheck] when it is not empty.
+func DecodePairingCheckInputEIP197(buf []byte) ([]G1Affine, []G2Affine, error) {
+	if len(buf)%SizeOfPairingPairEIP197 != 0 {
+		return nil, nil, ErrInvalidEIP197Size
+	}
+	n := len(buf) / SizeOfPairingPairEIP197
+	P := make([]G1Affine, n)
+	Q := make([]G2Affine, n)
+	for i := 0; i < n; i++ {
+		pair := buf[i*SizeOfPairingPairEIP197 : (i+1)*SizeOfPairingPairEIP197]
+		if err := P[i].UnmarshalEIP196(pair[:SizeOfG1AffineEIP196]); err != nil {
+			return nil, nil, err
+		}
+		if err := Q[i].UnmarshalEIP197(pair[SizeOfG1AffineEIP196:]); err != nil {
+			return nil, nil, err
+		}
+	}
+	return P, Q, nil
+}
+
+// PairingCheckOutputEIP197 returns the output of the ecPairing precompile: the
+// 32 bytes big endian encoding of 1 if ok, of 0 otherwise.
+func PairingCheckOutputEIP197(ok bool) (res [SizeOfPairingOutputEIP197]byte) {
+	if ok {
+		res[SizeOfPairingOutputEIP197-1] = 1
+	}
+	return
+}
+
+func putE2EIP197(buf []byte, x *fptower.E2) {
+	fp.BigEndian.PutElement((*[fp.Bytes]byte)(buf[:SizeOfFpEIP196]), x.A1)
+	fp.BigEndian.PutElement((*[fp.Bytes]byte)(buf[SizeOfFpEIP196:]), x.A0)
+}
+
+func setE2EIP197(x *fptower.E2, buf []byte) error {
+	var err error
+	if x.A1, err = fp.BigEndian.Element((*[fp.Bytes]byte)(buf[:SizeOfFpEIP196])); err != nil {
+		return err
+	}
+	x.A0, err = fp.BigEndian.Element((*[fp.Bytes]byte)(buf[SizeOfFpEIP196:]))
+	return err
+}
@@ -0,0 +1,140 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bn254
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEIP196RoundTrip(t *testing.T) {
+	assert := require.New(t)
+
+	var s big.Int
+	s.SetUint64(0xdeadbeef)
+	var p G1Affine
+	var q G2Affine
+	p.ScalarMultiplication(&g1GenAff, &s)
+	q.ScalarMultiplication(&g2GenAff, &s)
+
+	pb := p.MarshalEIP196()
+	qb := q.MarshalEIP197()
+
+	var p2 G1Affine
+	var q2 G2Affine
+	assert.NoError(p2.UnmarshalEIP196(pb[:]))
+	assert.NoError(q2.UnmarshalEIP197(qb[:]))
+	assert.True(p.Equal(&p2))
+	assert.True(q.Equal(&q2))
+
+	// the generator of G1 is (1, 2)
+	gb := g1GenAff.MarshalEIP196()
+	assert.Equal(byte(1), gb[SizeOfFpEIP196-1])
+	assert.Equal(byte(2), gb[SizeOfG1AffineEIP196-1])
+
+	// the imaginary part of the coordinates of G2 comes first
+	gb2 := g2GenAff.MarshalEIP197()
+	assert.Equal("198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c2", hex.EncodeToString(gb2[:SizeOfFpEIP196]))
+
+	// point at infinity is all zeros
+	var inf G2Affine
+	infb := inf.MarshalEIP197()
+	assert.Equal([SizeOfG2AffineEIP197]byte{}, infb)
+	assert.NoError(q2.UnmarshalEIP197(infb[:]))
+	assert.True(q2.IsInfinity())
+
+	// points off the curve are rejected
+	pb[SizeOfG1AffineEIP196-1] ^= 1
+	assert.Error(p2.UnmarshalEIP196(pb[:]))
+
+	// non canonical coordinates are rejected
+	for i := 0; i < SizeOfFpEIP196; i++ {
+		pb[i] = 0xff
+	}
+	assert.Error(p2.UnmarshalEIP196(pb[:]))
+
+	// wrong sizes are rejected
+	assert.ErrorIs(p2.UnmarshalEIP196(pb[:10]), ErrInvalidEIP196Size)
+	assert.ErrorIs(q2.UnmarshalEIP197(qb[:10]), ErrInvalidEIP197Size)
+}
+
+func TestECAddMulInputEIP196(t *testing.T) {
+	assert := require.New(t)
+
+	// 2G = G + G
+	var double G1Affine
+	double.Double(&g1GenAff)
+	expected := "030644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd315ed738c0e0a7c92e7845f96b2ae9c0a68a6a449e3538fc7ff3ebf7a5a18a2c4"
+	db := double.MarshalEIP196()
+	assert.Equal(expected, hex.EncodeToString(db[:]))
+
+	input := ECAddInputEIP196(&g1GenAff, &g1GenAff)
+	p, q, err := DecodeECAddInputEIP196(input[:])
+	assert.NoError(err)
+	assert.True(p.Equal(&g1GenAff))
+	assert.True(q.Equal(&g1GenAff))
+
+	// short inputs are padded with zeros
+	p, q, err = DecodeECAddInputEIP196(input[:SizeOfG1AffineEIP196])
+	assert.NoError(err)
+	assert.True(p.Equal(&g1GenAff))
+	assert.True(q.IsInfinity())
+
+	s := big.NewInt(2)
+	mulInput, err := ECMulInputEIP196(&g1GenAff, s)
+	assert.NoError(err)
+	p, s2, err := DecodeECMulInputEIP196(mulInput[:])
+	assert.NoError(err)
+	assert.True(p.Equal(&g1GenAff))
+	assert.Equal(0, s.Cmp(&s2))
+
+	// scalars larger than 256 bits are rejected
+	_, err = ECMulInputEIP196(&g1GenAff, new(big.Int).Lsh(big.NewInt(1), 256))
+	assert.Error(err)
+}
+
+func TestPairingCheckInputEIP197(t *testing.T) {
+	assert := require.New(t)
+
+	// e(P, Q).e(-P, Q) == 1
+	var negP G1Affine
+	negP.Neg(&g1GenAff)
+	input, err := PairingCheckInputEIP197([]G1Affine{g1GenAff, negP}, []G2Affine{g2GenAff, g2GenAff})
+	assert.NoError(err)
+	assert.Len(input, 2*SizeOfPairingPairEIP197)
+
+	P, Q, err := DecodePairingCheckInputEIP197(input)
+	assert.NoError(err)
+	ok, err := PairingCheck(P, Q)
+	assert.NoError(err)
+	assert.True(ok)
+	out := PairingCheckOutputEIP197(ok)
+	assert.Equal(byte(1), out[SizeOfPairingOutputEIP197-1])
+
+	// empty input
+	P, Q, err = DecodePairingCheckInputEIP197(nil)
+	assert.NoError(err)
+	assert.Empty(P)
+	assert.Empty(Q)
+
+	// wrong sizes are rejected
+	_, _, err = DecodePairingCheckInputEIP197(input[1:])
+	assert.ErrorIs(err, ErrInvalidEIP197Size)
+	_, err = PairingCheckInputEIP197([]G1Affine{g1GenAff}, nil)
+	assert.Error(err)
+}
@@ -0,0 +1,89 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package elgamal
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+// DLogTable is a baby-step giant-step table to compute the discrete logarithms in
+// [0, bound) of points of G1 in base G.
+//
+// It holds the ⌈√bound⌉ baby steps j⋅G, and a discrete logarithm is found in at most
+// ⌈√bound⌉ giant steps. It is safe for concurrent use.
+type DLogTable struct {
+	bound     uint64
+	m         uint64                    // number of baby steps
+	babySteps map[bn254.G1Affine]uint64 // j⋅G → j
+	giantStep bn254.G1Affine            // -m⋅G
+}
+
+// NewDLogTable precomputes a table for the discrete logarithms in [0, bound).
+func NewDLogTable(bound uint64) *DLogTable {
+	m := uint64(math.Ceil(math.Sqrt(float64(bound))))
+	if m == 0 {
+		m = 1
+	}
+	for m*m < bound {
+		m++
+	}
+	_, _, g, _ := bn254.Generators()
+
+	// baby steps, converted to affine in a batch
+	steps := make([]bn254.G1Jac, m) // steps[0] is the infinity
+	for j := uint64(1); j < m; j++ {
+		steps[j].Set(&steps[j-1]).AddMixed(&g)
+	}
+	affine := bn254.BatchJacobianToAffineG1(steps)
+
+	t := &DLogTable{
+		bound:     bound,
+		m:         m,
+		babySteps: make(map[bn254.G1Affine]uint64, m),
+	}
+	for j := range affine {
+		t.babySteps[affine[j]] = uint64(j)
+	}
+	t.giantStep.ScalarMultiplicationBase(new(big.Int).SetUint64(m))
+	t.giantStep.Neg(&t.giantStep)
+	return t
+}
+
+// Bound returns the bound of the table: it finds the discrete logarithms in [0, Bound()).
+func (t *DLogTable) Bound() uint64 {
+	return t.bound
+}
+
+// DLog returns d in [0, Bound()) such that p = d⋅G, or ErrMessageTooLarge.
+func (t *DLogTable) DLog(p *bn254.G1Affine) (uint64, error) {
+	// p - i⋅m⋅G = j⋅G for some baby step j
+	var y bn254.G1Affine
+	y.Set(p)
+	for i := uint64(0); i < t.m; i++ {
+		if j, ok := t.babySteps[y]; ok {
+			if d := i*t.m + j; d < t.bound {
+				return d, nil
+			}
+			return 0, ErrMessageTooLarge
+		}
+		y.Add(&y, &t.giantStep)
+	}
+	return 0, ErrMessageTooLarge
+}
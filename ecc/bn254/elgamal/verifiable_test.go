@@ -0,0 +1,141 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package elgamal
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+func randomBasis(t *testing.T) []bn254.G1Affine {
+	basis := make([]bn254.G1Affine, 2)
+	for i := range basis {
+		k, err := randomScalar(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		basis[i].ScalarMultiplicationBase(k)
+	}
+	return basis
+}
+
+func TestVerifiableEncryption(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := privKey.Public()
+	basis := randomBasis(t)
+
+	var bid, blinding fr.Element
+	bid.SetUint64(1234)
+	blinding.SetRandom()
+	commitment := commit(basis, &bid, &blinding)
+	data := []byte("auction")
+
+	ct, proof, err := pub.EncryptVerifiable(&bid, &blinding, basis, sha256.New(), rand.Reader, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pub.VerifyEncryption(&ct, &commitment, basis, &proof, sha256.New(), data); err != nil {
+		t.Fatal(err)
+	}
+
+	// the plaintext is the bid
+	m, err := privKey.DecryptExp(&ct, NewDLogTable(1<<12))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m != 1234 {
+		t.Fatalf("decrypted %d, expected 1234", m)
+	}
+
+	// serialization
+	var decoded EncryptionProof
+	if _, err := decoded.SetBytes(proof.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if err := pub.VerifyEncryption(&ct, &commitment, basis, &decoded, sha256.New(), data); err != nil {
+		t.Fatal(err)
+	}
+
+	// another transcript
+	if err := pub.VerifyEncryption(&ct, &commitment, basis, &proof, sha256.New()); !errors.Is(err, ErrInvalidEncryptionProof) {
+		t.Fatalf("expected ErrInvalidEncryptionProof, got %v", err)
+	}
+
+	// the commitment to another value
+	var other fr.Element
+	other.SetUint64(1235)
+	otherCommitment := commit(basis, &other, &blinding)
+	if err := pub.VerifyEncryption(&ct, &otherCommitment, basis, &proof, sha256.New(), data); !errors.Is(err, ErrInvalidEncryptionProof) {
+		t.Fatalf("expected ErrInvalidEncryptionProof, got %v", err)
+	}
+
+	// the encryption of another value
+	otherCt, _, err := pub.EncryptVerifiable(&other, &blinding, basis, sha256.New(), rand.Reader, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pub.VerifyEncryption(&otherCt, &commitment, basis, &proof, sha256.New(), data); !errors.Is(err, ErrInvalidEncryptionProof) {
+		t.Fatalf("expected ErrInvalidEncryptionProof, got %v", err)
+	}
+
+	// a commitment to a value encrypted under another key
+	otherKey, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := otherKey.Public().VerifyEncryption(&ct, &commitment, basis, &proof, sha256.New(), data); !errors.Is(err, ErrInvalidEncryptionProof) {
+		t.Fatalf("expected ErrInvalidEncryptionProof, got %v", err)
+	}
+
+	if _, _, err := pub.EncryptVerifiable(&bid, &blinding, basis[:1], sha256.New(), rand.Reader); !errors.Is(err, ErrInvalidCommitmentBasis) {
+		t.Fatalf("expected ErrInvalidCommitmentBasis, got %v", err)
+	}
+}
+
+func BenchmarkVerifyEncryption(b *testing.B) {
+	privKey, _ := GenerateKey(rand.Reader)
+	pub := privKey.Public()
+	var basis [2]bn254.G1Affine
+	for i := range basis {
+		k, _ := randomScalar(rand.Reader)
+		basis[i].ScalarMultiplicationBase(k)
+	}
+	var m, blinding fr.Element
+	m.SetUint64(42)
+	blinding.SetRandom()
+	commitment := commit(basis[:], &m, &blinding)
+	ct, proof, err := pub.EncryptVerifiable(&m, &blinding, basis[:], sha256.New(), rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := pub.VerifyEncryption(&ct, &commitment, basis[:], &proof, sha256.New()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
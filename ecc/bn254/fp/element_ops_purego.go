@@ -19,7 +19,14 @@
 
 package fp
 
-import "math/bits"
+import (
+	"math/bits"
+	"runtime"
+)
+
+// use32BitLimbs is set on the platforms where bits.Mul64 is emulated: 32-bit architectures,
+// and wasm which has no 64×64→128 multiplication. Mul and Square then run on 32-bit limbs.
+const use32BitLimbs = bits.UintSize == 32 || runtime.GOARCH == "wasm"
 
 // MulBy3 x *= 3 (mod q)
 func MulBy3(x *Element) {
@@ -59,29 +66,46 @@ func fromMont(z *Element) {
 func reduce(z *Element) {
 	_reduceGeneric(z)
 }
+func addVecChunk(res, a, b Vector) {
+	addVecGeneric(res, a, b)
+}
+
+func subVecChunk(res, a, b Vector) {
+	subVecGeneric(res, a, b)
+}
+
+func scalarMulVecChunk(res, a Vector, b *Element) {
+	scalarMulVecGeneric(res, a, b)
+}
 
-// Add adds two vectors element-wise and stores the result in self.
-// It panics if the vectors don't have the same length.
-func (vector *Vector) Add(a, b Vector) {
-	addVecGeneric(*vector, a, b)
+func mulAccVecChunk(res, a Vector, b *Element) {
+	mulAccVecGeneric(res, a, b)
 }
 
-// Sub subtracts two vectors element-wise and stores the result in self.
-// It panics if the vectors don't have the same length.
-func (vector *Vector) Sub(a, b Vector) {
-	subVecGeneric(*vector, a, b)
+func mulVecChunk(res, a, b Vector) {
+	mulVecGeneric(res, a, b)
 }
 
-// ScalarMul multiplies a vector by a scalar element-wise and stores the result in self.
-// It panics if the vectors don't have the same length.
-func (vector *Vector) ScalarMul(a Vector, b *Element) {
-	scalarMulVecGeneric(*vector, a, b)
+func sumVecChunk(res *Element, a Vector) {
+	sumVecGeneric(res, a)
+}
+
+func innerProductVecChunk(res *Element, a, b Vector) {
+	innerProductVecGeneric(res, a, b)
+}
+
+func fromMontVecChunk(a Vector) {
+	fromMontVecGeneric(a)
 }
 
 // Mul z = x * y (mod q)
 //
 // x and y must be less than q
 func (z *Element) Mul(x, y *Element) *Element {
+	if use32BitLimbs {
+		mul32(z, x, y)
+		return z
+	}
 
 	// Implements CIOS multiplication -- section 2.3.2 of Tolga Acar's thesis
 	// https://www.microsoft.com/en-us/research/wp-content/uploads/1998/06/97Acar.pdf
@@ -295,6 +319,10 @@ func (z *Element) Mul(x, y *Element) *Element {
 // x must be less than q
 func (z *Element) Square(x *Element) *Element {
 	// see Mul for algorithm documentation
+	if use32BitLimbs {
+		mul32(z, x, x)
+		return z
+	}
 
 	var t0, t1, t2, t3 uint64
 	var u0, u1, u2, u3 uint64
@@ -0,0 +1,269 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package rs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+)
+
+var (
+	ErrInvalidParameters = errors.New("the message size and the rate must be powers of two, the rate at least 2")
+	ErrMessageSize       = errors.New("the message is longer than the dimension of the code")
+	ErrCodewordSize      = errors.New("the codeword doesn't have the length of the code")
+	ErrNotACodeword      = errors.New("the evaluations are not a codeword")
+	ErrInvalidErasures   = errors.New("the erasures must be distinct positions of the codeword")
+	ErrTooManyErasures   = errors.New("too many erasures to decode")
+)
+
+// Code is the Reed-Solomon code of dimension k and length n = ρ·k: the evaluations
+// of the polynomials of degree less than k on the domain shift·<ω>, ω a primitive
+// n-th root of unity. Codewords are in natural order: c[i] = p(shift·ωⁱ).
+//
+// The message of the systematic encoding is read at the positions multiple of ρ,
+// that is on the subdomain shift·<ω^ρ>.
+type Code struct {
+	k, n  uint64
+	coset bool
+
+	domain    *fft.Domain // domain of size n, shifted
+	subdomain *fft.Domain // domain of size k, with the same shift
+}
+
+// Option configures a Code.
+type Option func(*config)
+
+type config struct {
+	shift *fr.Element
+}
+
+// WithShift evaluates the codewords on the coset shift·<ω> instead of the subgroup <ω>.
+func WithShift(shift fr.Element) Option {
+	return func(c *config) {
+		c.shift = &shift
+	}
+}
+
+// NewCode returns the Reed-Solomon code of dimension k and length rate·k.
+func NewCode(k, rate uint64, opts ...Option) (*Code, error) {
+	if k == 0 || k != ecc.NextPowerOfTwo(k) || rate < 2 || rate != ecc.NextPowerOfTwo(rate) {
+		return nil, fmt.Errorf("%w: k = %d, rate = %d", ErrInvalidParameters, k, rate)
+	}
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	n := k * rate
+	if n/rate != k {
+		return nil, fmt.Errorf("%w: k = %d, rate = %d", ErrInvalidParameters, k, rate)
+	}
+	if _, err := fft.Generator(n); err != nil {
+		return nil, err
+	}
+
+	c := &Code{k: k, n: n}
+	var domainOpts []fft.DomainOption
+	if cfg.shift != nil {
+		c.coset = true
+		domainOpts = append(domainOpts, fft.WithShift(*cfg.shift))
+	}
+	c.domain = fft.NewDomain(n, domainOpts...)
+	c.subdomain = fft.NewDomain(k, domainOpts...)
+	return c, nil
+}
+
+// Dimension returns k, the size of the messages.
+func (c *Code) Dimension() uint64 {
+	return c.k
+}
+
+// Length returns n, the size of the codewords.
+func (c *Code) Length() uint64 {
+	return c.n
+}
+
+// Domain returns the evaluation domain of the codewords.
+func (c *Code) Domain() *fft.Domain {
+	return c.domain
+}
+
+// Encode returns the evaluations of the polynomial p, given by at most k coefficients.
+func (c *Code) Encode(p []fr.Element) ([]fr.Element, error) {
+	if uint64(len(p)) > c.k {
+		return nil, fmt.Errorf("%w: %d > %d", ErrMessageSize, len(p), c.k)
+	}
+	res := make([]fr.Element, c.n)
+	copy(res, p)
+	c.domain.FFT(res, fft.DIF, c.options()...)
+	fft.BitReverse(res)
+	return res, nil
+}
+
+// EncodeSystematic returns the codeword c such that c[i·ρ] = msg[i], msg of size k.
+func (c *Code) EncodeSystematic(msg []fr.Element) ([]fr.Element, error) {
+	if uint64(len(msg)) != c.k {
+		return nil, fmt.Errorf("%w: %d != %d", ErrMessageSize, len(msg), c.k)
+	}
+	p := make([]fr.Element, c.k)
+	copy(p, msg)
+	fft.BitReverse(p)
+	c.subdomain.FFTInverse(p, fft.DIT, c.options()...)
+	return c.Encode(p)
+}
+
+// Decode returns the coefficients of the polynomial of degree less than k whose
+// evaluations are codeword, or ErrNotACodeword.
+func (c *Code) Decode(codeword []fr.Element) ([]fr.Element, error) {
+	if uint64(len(codeword)) != c.n {
+		return nil, fmt.Errorf("%w: %d != %d", ErrCodewordSize, len(codeword), c.n)
+	}
+	p := make([]fr.Element, c.n)
+	copy(p, codeword)
+	fft.BitReverse(p)
+	c.domain.FFTInverse(p, fft.DIT, c.options()...)
+	for i := c.k; i < c.n; i++ {
+		if !p[i].IsZero() {
+			return nil, ErrNotACodeword
+		}
+	}
+	return p[:c.k], nil
+}
+
+// Check returns an error if evals is not a codeword.
+func (c *Code) Check(evals []fr.Element) error {
+	_, err := c.Decode(evals)
+	return err
+}
+
+// ErasureDecode fills the erased positions of a codeword, at the indices in missing,
+// from the other ones, and returns the full codeword. The values of evals at the
+// missing positions are ignored. It can recover up to n-k erasures; it returns
+// ErrNotACodeword if the known values are not consistent with a codeword.
+//
+// With Z the vanishing polynomial of the erased points and E the received word with
+// zeros at the erasures, E·Z is the product P·Z, P the encoded polynomial, on the whole
+// domain. At a root x of Z, (P·Z)'(x) = P(x)·Z'(x), so that the erased values are
+// P(x) = (E·Z)'(x) / Z'(x).
+func (c *Code) ErasureDecode(evals []fr.Element, missing []int) ([]fr.Element, error) {
+	if uint64(len(evals)) != c.n {
+		return nil, fmt.Errorf("%w: %d != %d", ErrCodewordSize, len(evals), c.n)
+	}
+	if uint64(len(missing)) > c.n-c.k {
+		return nil, fmt.Errorf("%w: %d > %d", ErrTooManyErasures, len(missing), c.n-c.k)
+	}
+	erased := make([]bool, c.n)
+	for _, i := range missing {
+		if i < 0 || uint64(i) >= c.n || erased[i] {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidErasures, i)
+		}
+		erased[i] = true
+	}
+	res := make([]fr.Element, c.n)
+	copy(res, evals)
+	if len(missing) == 0 {
+		return res, c.Check(res)
+	}
+
+	// the points of the domain, and Z = ∏ (X - xᵢ) for the erased xᵢ
+	points := make([]fr.Element, c.n)
+	points[0] = c.domain.FrMultiplicativeGen
+	if !c.coset {
+		points[0].SetOne()
+	}
+	for i := 1; i < len(points); i++ {
+		points[i].Mul(&points[i-1], &c.domain.Generator)
+	}
+	z := make([]fr.Element, c.n)
+	z[0].SetOne()
+	for d, i := range missing {
+		// z ← z·(X - xᵢ), z of degree d
+		for j := d + 1; j > 0; j-- {
+			var t fr.Element
+			t.Mul(&z[j], &points[i])
+			z[j].Sub(&z[j-1], &t)
+		}
+		z[0].Mul(&z[0], &points[i]).Neg(&z[0])
+	}
+
+	// E·Z, on the domain then in coefficients; its degree is less than k+len(missing) ≤ n
+	zEvals := c.evaluate(z)
+	ez := make([]fr.Element, c.n)
+	for i := range ez {
+		if !erased[i] {
+			ez[i].Mul(&evals[i], &zEvals[i])
+		}
+	}
+	fft.BitReverse(ez)
+	c.domain.FFTInverse(ez, fft.DIT, c.options()...)
+
+	// (E·Z)' and Z' at the erased points
+	dez := c.evaluate(derivative(ez))
+	dz := c.evaluate(derivative(z))
+	den := make([]fr.Element, len(missing))
+	for j, i := range missing {
+		den[j] = dz[i]
+	}
+	den = fr.BatchInvert(den)
+	for j, i := range missing {
+		res[i].Mul(&dez[i], &den[j])
+	}
+
+	return res, c.Check(res)
+}
+
+// evaluate returns the evaluations of p, of degree less than n, on the domain in
+// natural order. p is not modified.
+func (c *Code) evaluate(p []fr.Element) []fr.Element {
+	res := make([]fr.Element, c.n)
+	copy(res, p)
+	c.domain.FFT(res, fft.DIF, c.options()...)
+	fft.BitReverse(res)
+	return res
+}
+
+func (c *Code) options() []fft.Option {
+	if c.coset {
+		return []fft.Option{fft.OnCoset()}
+	}
+	return nil
+}
+
+// derivative returns the formal derivative of p, with the size of p.
+func derivative(p []fr.Element) []fr.Element {
+	res := make([]fr.Element, len(p))
+	var j fr.Element
+	for i := 1; i < len(p); i++ {
+		j.SetUint64(uint64(i))
+		res[i-1].Mul(&p[i], &j)
+	}
+	return res
+}
+
+// Encode returns the evaluations of the polynomial p on the subgroup of size
+// rate·NextPowerOfTwo(len(p)), in natural order. See Code for the other operations.
+func Encode(p []fr.Element, rate uint64) ([]fr.Element, error) {
+	code, err := NewCode(ecc.NextPowerOfTwo(uint64(len(p))), rate)
+	if err != nil {
+		return nil, err
+	}
+	return code.Encode(p)
+}
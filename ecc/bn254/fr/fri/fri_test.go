@@ -17,12 +17,20 @@
 package fri
 
 import (
+	"bytes"
 	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
 	"fmt"
+	"hash"
 	"math/big"
+	"reflect"
 	"testing"
 
+	"github.com/consensys/gnark-crypto/accumulator/merkletree"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/gen"
 	"github.com/leanovate/gopter/prop"
@@ -50,15 +58,15 @@ func randomPolynomial(size uint64, seed int32) []fr.Element {
 	return p
 }
 
-// convertOrderCanonical convert the index i, an entry in a
-// sorted polynomial, to the corresponding entry in canonical
-// representation. n is the size of the polynomial.
-func convertSortedCanonical(i, n int) int {
-	if i%2 == 0 {
-		return i / 2
-	} else {
-		l := (n - 1 - i) / 2
-		return n - 1 - l
+func TestFingerprint(t *testing.T) {
+	a := RADIX_2_FRI.New(1024, sha256.New())
+	b := RADIX_2_FRI.New(1024, sha256.New())
+	c := RADIX_2_FRI.New(2048, sha256.New())
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Fatal("fingerprint is not deterministic")
+	}
+	if a.Fingerprint() == c.Fingerprint() {
+		t.Fatal("different sizes should have different fingerprints")
 	}
 }
 
@@ -95,7 +103,7 @@ func TestFRI(t *testing.T) {
 			return err != nil
 
 		},
-		gen.Int32Range(1, int32(rho*size)),
+		gen.Int32Range(1, int32(defaultRho*size)),
 	))
 
 	properties.Property("verifying correct opening should succeed", prop.ForAll(
@@ -121,7 +129,7 @@ func TestFRI(t *testing.T) {
 			return err == nil
 
 		},
-		gen.Int32Range(0, int32(rho*size)),
+		gen.Int32Range(0, int32(defaultRho*size)),
 	))
 
 	properties.Property("The claimed value of a polynomial should match P(x)", prop.ForAll(
@@ -152,7 +160,7 @@ func TestFRI(t *testing.T) {
 			return openingProof.ClaimedValue.Equal(&val)
 
 		},
-		gen.Int32Range(0, int32(rho*size)),
+		gen.Int32Range(0, int32(defaultRho*size)),
 	))
 
 	properties.Property("Derive queries position: points should belong the correct fiber", prop.ForAll(
@@ -176,7 +184,7 @@ func TestFRI(t *testing.T) {
 				var g1, g2, g3 fr.Element
 				g1.Exp(g, &u).Square(&g1)
 				g2.Exp(g, &v).Square(&g2)
-				nextPos := convertSortedCanonical(pos[i+1], n/2)
+				nextPos := SortedToCanonical(pos[i+1], n/2)
 				g3.Square(&g).Exp(g3, big.NewInt(int64(nextPos)))
 
 				if !g1.Equal(&g2) || !g1.Equal(&g3) {
@@ -187,7 +195,7 @@ func TestFRI(t *testing.T) {
 			}
 			return true
 		},
-		gen.Int32Range(0, int32(rho*size)),
+		gen.Int32Range(0, int32(defaultRho*size)),
 	))
 
 	properties.Property("verifying a correctly formed proof should succeed", prop.ForAll(
@@ -205,13 +213,866 @@ func TestFRI(t *testing.T) {
 			err = iop.VerifyProofOfProximity(proof)
 			return err == nil
 		},
-		gen.Int32Range(0, int32(rho*size)),
+		gen.Int32Range(0, int32(defaultRho*size)),
 	))
 
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 
 }
 
+func TestFRIInstance(t *testing.T) {
+
+	size := uint64(1024)
+	p := randomPolynomial(size, 5)
+
+	prover := RADIX_2_FRI.NewWithInstance(size, sha256.New(), []byte("instance 1"))
+	proof, err := prover.BuildProofOfProximity(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifier := RADIX_2_FRI.NewWithInstance(size, sha256.New(), []byte("instance 1"))
+	if err := verifier.VerifyProofOfProximity(proof); err != nil {
+		t.Fatal(err)
+	}
+
+	verifier = RADIX_2_FRI.NewWithInstance(size, sha256.New(), []byte("instance 2"))
+	if err := verifier.VerifyProofOfProximity(proof); err == nil {
+		t.Fatal("verifying a proof bound to another instance should fail")
+	}
+
+	verifier = RADIX_2_FRI.New(size, sha256.New())
+	if err := verifier.VerifyProofOfProximity(proof); err == nil {
+		t.Fatal("verifying a proof bound to an instance without it should fail")
+	}
+}
+
+func TestQueriesPositions(t *testing.T) {
+
+	// the conversions between natural and sorted orders are inverse of each other
+	for _, n := range []int{2, 8, 128} {
+		for i := 0; i < n; i++ {
+			j := CanonicalToSorted(i, n)
+			if j < 0 || j >= n || SortedToCanonical(j, n) != i {
+				t.Fatalf("n=%d: %d -> %d -> %d", n, i, j, SortedToCanonical(j, n))
+			}
+		}
+	}
+
+	// test vectors: polynomials of size 16, ρ=8, so the domain has 128 elements and
+	// there are 4 steps.
+	iop := RADIX_2_FRI.New(16, sha256.New())
+	vectors := []struct {
+		seed     []byte
+		expected []int
+	}{
+		{[]byte{0x00}, []int{0, 0, 0, 0}},
+		{[]byte{0x4b}, []int{75, 11, 10, 10}},
+		{[]byte{0x01, 0x2c}, []int{44, 44, 13, 12}},
+		{bytes.Repeat([]byte{0xff}, 32), []int{127, 63, 31, 15}},
+	}
+	for _, v := range vectors {
+		if got := iop.QueriesPositions(v.seed); !reflect.DeepEqual(got, v.expected) {
+			t.Fatalf("seed %x: expected %v, got %v", v.seed, v.expected, got)
+		}
+	}
+
+	// the positions derived from a proof are the ones opened by the prover
+	p := randomPolynomial(16, 3)
+	proof, err := iop.BuildProofOfProximity(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for r, round := range proof.Rounds {
+		xi, si, err := iop.DeriveQueries(r, round)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(xi) != 4 || len(si) != 4 {
+			t.Fatalf("round %d: expected 4 challenges and positions, got %d and %d", r, len(xi), len(si))
+		}
+		for i := range si {
+			c := si[i] % 2
+			mp := round.Interactions[i][c]
+			if !merkletree.VerifyProof(sha256.New(), mp.MerkleRoot, mp.ProofSet, uint64(si[i]), mp.numLeaves) {
+				t.Fatalf("round %d, step %d: position %d is not the opened one", r, i, si[i])
+			}
+		}
+	}
+}
+
+func TestFRITranscriptRecorder(t *testing.T) {
+
+	size := uint64(256)
+	p := randomPolynomial(size, 7)
+
+	newIopp := func(r *fiatshamir.Recorder, opts ...Option) Iopp {
+		iop, err := RADIX_2_FRI.NewWithOptions(size, sha256.New(), append(opts, WithTranscriptRecorder(r))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return iop
+	}
+
+	proverTrace := fiatshamir.NewRecorder()
+	proof, err := newIopp(proverTrace).BuildProofOfProximity(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proverTrace.Events()) == 0 {
+		t.Fatal("the prover transcript was not recorded")
+	}
+
+	// the transcripts of an honest prover and verifier match
+	verifierTrace := fiatshamir.NewRecorder()
+	if err := newIopp(verifierTrace).VerifyProofOfProximity(proof); err != nil {
+		t.Fatal(err)
+	}
+	if err := fiatshamir.Diff(proverTrace.Events(), verifierTrace.Events()); err != nil {
+		t.Fatal(err)
+	}
+
+	// a tampered Merkle root is the first diverging binding
+	proof.Rounds[0].Interactions[1][0].MerkleRoot[0] ^= 1
+	verifierTrace.Reset()
+	if err := newIopp(verifierTrace).VerifyProofOfProximity(proof); err == nil {
+		t.Fatal("verifying a tampered proof should fail")
+	}
+	var m *fiatshamir.Mismatch
+	if err := fiatshamir.Diff(proverTrace.Events(), verifierTrace.Events()); !errors.As(err, &m) {
+		t.Fatalf("expected a mismatch, got %v", err)
+	}
+	if m.Verifier == nil || m.Verifier.Kind != fiatshamir.EventBind || m.Verifier.Label != "x1" {
+		t.Fatalf("unexpected mismatch: %v", m)
+	}
+	proof.Rounds[0].Interactions[1][0].MerkleRoot[0] ^= 1
+
+	// a verifier bound to another instance diverges on the first challenge
+	verifierTrace.Reset()
+	if err := newIopp(verifierTrace, WithInstanceID([]byte("other"))).VerifyProofOfProximity(proof); err == nil {
+		t.Fatal("verifying a proof bound to another instance should fail")
+	}
+	if err := fiatshamir.Diff(proverTrace.Events(), verifierTrace.Events()); !errors.As(err, &m) {
+		t.Fatalf("expected a mismatch, got %v", err)
+	}
+	if m.Verifier == nil || m.Verifier.Kind != fiatshamir.EventChallenge || m.Verifier.Label != "x0" {
+		t.Fatalf("unexpected mismatch: %v", m)
+	}
+}
+
+func TestFRIOptions(t *testing.T) {
+
+	size := uint64(256)
+	p := randomPolynomial(size, 3)
+
+	prover, err := RADIX_2_FRI.NewWithOptions(size, sha256.New(), WithRho(4), WithNbRounds(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := prover.(radixTwoFri).domain.Cardinality; c != 4*size {
+		t.Fatalf("domain size: expected %d, got %d", 4*size, c)
+	}
+	proof, err := prover.BuildProofOfProximity(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proof.Rounds) != 3 {
+		t.Fatalf("expected 3 rounds, got %d", len(proof.Rounds))
+	}
+	if err := prover.VerifyProofOfProximity(proof); err != nil {
+		t.Fatal(err)
+	}
+
+	// a verifier with different parameters rejects the proof
+	verifier, err := RADIX_2_FRI.NewWithOptions(size, sha256.New(), WithRho(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := verifier.VerifyProofOfProximity(proof); err == nil {
+		t.Fatal("verifying a proof with a different number of rounds should fail")
+	}
+	a := prover.(radixTwoFri).Fingerprint()
+	b := verifier.(radixTwoFri).Fingerprint()
+	if a == b {
+		t.Fatal("instances with different parameters should have different fingerprints")
+	}
+
+	// invalid configurations
+	invalid := []struct {
+		size uint64
+		h    hash.Hash
+		opts []Option
+	}{
+		{size, nil, nil},
+		{0, sha256.New(), nil},
+		{size, sha256.New(), []Option{WithRho(1)}},
+		{size, sha256.New(), []Option{WithRho(6)}},
+		{size, sha256.New(), []Option{WithNbRounds(0)}},
+		{1 << 62, sha256.New(), []Option{WithRho(8)}},
+		{size, sha256.New(), []Option{WithMerkleCapHeight(-1)}},
+		{size, sha256.New(), []Option{WithRho(4), WithMerkleCapHeight(3)}},
+	}
+	for i, c := range invalid {
+		if _, err := RADIX_2_FRI.NewWithOptions(c.size, c.h, c.opts...); !errors.Is(err, ErrInvalidConfig) {
+			t.Fatalf("case %d: expected ErrInvalidConfig, got %v", i, err)
+		}
+	}
+}
+
+func TestEstimateProofSize(t *testing.T) {
+	for _, c := range []struct {
+		size     uint64
+		rho      uint64
+		nbRounds int
+	}{
+		{64, 8, 1},
+		{100, 2, 3},
+		{256, 16, 2},
+	} {
+		iop, err := RADIX_2_FRI.NewWithOptions(c.size, sha256.New(), WithRho(c.rho), WithNbRounds(c.nbRounds))
+		if err != nil {
+			t.Fatal(err)
+		}
+		proof, err := iop.BuildProofOfProximity(randomPolynomial(c.size, 42))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// field elements and digests of the proof, each root counted once
+		var actual uint64
+		for _, round := range proof.Rounds {
+			for _, interaction := range round.Interactions {
+				actual += uint64(len(interaction[0].MerkleRoot))
+				for _, p := range interaction {
+					for _, b := range p.ProofSet {
+						actual += uint64(len(b))
+					}
+				}
+			}
+			actual += fr.Bytes
+		}
+
+		estimate, err := EstimateProofSize(c.size, c.rho, c.nbRounds, sha256.Size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if estimate != actual {
+			t.Fatalf("size %d, rho %d, %d rounds: estimated %d bytes, got %d", c.size, c.rho, c.nbRounds, estimate, actual)
+		}
+	}
+
+	if _, err := EstimateProofSize(64, 3, 1, sha256.Size); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatal("expected ErrInvalidConfig for rho = 3")
+	}
+	if _, err := EstimateProofSize(64, 8, 1, 0); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatal("expected ErrInvalidConfig for hashSize = 0")
+	}
+}
+
+func TestParametersForSecurity(t *testing.T) {
+	const size = 1 << 10
+
+	// no size bound: the smallest blow-up factor
+	p, err := ParametersForSecurity(size, sha256.Size, 80, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Rho != 2 || p.NbRounds != 160 || p.SecurityBits < 80 {
+		t.Fatalf("unexpected parameters %+v", p)
+	}
+
+	// a size bound forces a larger blow-up factor
+	bounded, err := ParametersForSecurity(size, sha256.Size, 80, p.ProofSize/2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bounded.Rho <= p.Rho || bounded.ProofSize > p.ProofSize/2 || bounded.SecurityBits < 80 {
+		t.Fatalf("unexpected parameters %+v", bounded)
+	}
+	expected, err := EstimateProofSize(size, bounded.Rho, bounded.NbRounds, sha256.Size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bounded.ProofSize != expected {
+		t.Fatal("the proof size doesn't match EstimateProofSize")
+	}
+
+	// the parameters can be used to build a FRI instance
+	iop, err := RADIX_2_FRI.NewWithOptions(size, sha256.New(), bounded.Options()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, err := iop.BuildProofOfProximity(randomPolynomial(size, 42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proof.Rounds) != bounded.NbRounds {
+		t.Fatal("wrong number of rounds")
+	}
+
+	if _, err := ParametersForSecurity(size, sha256.Size, 80, 1024); !errors.Is(err, ErrNoParameters) {
+		t.Fatal("expected ErrNoParameters")
+	}
+	if _, err := ParametersForSecurity(size, sha256.Size, 0, 0); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatal("expected ErrInvalidConfig")
+	}
+}
+
+func TestVerificationError(t *testing.T) {
+
+	size := uint64(64)
+	p := randomPolynomial(size, 11)
+
+	iopp := RADIX_2_FRI.New(size, sha256.New())
+	proof, err := iopp.BuildProofOfProximity(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// tamper with the final evaluation: the challenges change, so the verification
+	// fails in the first step of the round
+	tampered := proof
+	tampered.Rounds = []Round{proof.Rounds[0]}
+	tampered.Rounds[0].Evaluation.SetOne()
+	err = iopp.VerifyProofOfProximity(tampered)
+	var vErr *VerificationError
+	if !errors.As(err, &vErr) {
+		t.Fatalf("expected a VerificationError, got %v", err)
+	}
+	if !errors.Is(err, vErr.Err) || vErr.Round != 0 || vErr.Query < 0 {
+		t.Fatalf("wrong location: %v", vErr)
+	}
+
+	// truncated proof
+	tampered.Rounds = nil
+	err = iopp.VerifyProofOfProximity(tampered)
+	if !errors.Is(err, ErrProofShape) || !errors.As(err, &vErr) {
+		t.Fatalf("expected ErrProofShape, got %v", err)
+	}
+	if vErr.Expected != "1" || vErr.Actual != "0" {
+		t.Fatalf("wrong values: %v", vErr)
+	}
+
+	// missing interaction
+	tampered.Rounds = []Round{proof.Rounds[0]}
+	tampered.Rounds[0].Interactions = proof.Rounds[0].Interactions[1:]
+	err = iopp.VerifyProofOfProximity(tampered)
+	if !errors.Is(err, ErrProofShape) || !errors.As(err, &vErr) {
+		t.Fatalf("expected ErrProofShape, got %v", err)
+	}
+	if vErr.Round != 0 || vErr.Actual != fmt.Sprint(len(proof.Rounds[0].Interactions)-1) {
+		t.Fatalf("wrong location: %v", vErr)
+	}
+	if err := iopp.VerifyOpening(0, OpeningProof{}, ProofOfProximity{}); !errors.Is(err, ErrProofShape) {
+		t.Fatalf("expected ErrProofShape, got %v", err)
+	}
+
+	// the values are dropped if errors are redacted
+	redacted, err := RADIX_2_FRI.NewWithOptions(size, sha256.New(), WithRedactedErrors())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = redacted.VerifyProofOfProximity(tampered); !errors.As(err, &vErr) {
+		t.Fatalf("expected a VerificationError, got %v", err)
+	}
+	if vErr.Expected != "" || vErr.Actual != "" {
+		t.Fatalf("the values should be redacted: %v", vErr)
+	}
+	if err := redacted.VerifyProofOfProximity(proof); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProverMemory(t *testing.T) {
+
+	size := uint64(512)
+	iopp := RADIX_2_FRI.New(size, sha256.New())
+	mem := NewProverMemory()
+
+	var proofs []ProofOfProximity
+	var polynomials [][]fr.Element
+	for i := 0; i < 3; i++ {
+		p := randomPolynomial(size, int32(i))
+		mem.Reset()
+		proof, err := iopp.BuildProofOfProximityWithMemory(p, mem)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proofs = append(proofs, proof)
+		polynomials = append(polynomials, p)
+	}
+	if mem.Arena().Cap() == 0 {
+		t.Fatal("the prover memory should have been used")
+	}
+
+	// the proofs are the ones built without memory, and don't depend on the
+	// memory after it is reused
+	for i := range proofs {
+		expected, err := iopp.BuildProofOfProximity(polynomials[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(expected, proofs[i]) {
+			t.Fatalf("proof %d differs from the proof built without memory", i)
+		}
+		if err := iopp.VerifyProofOfProximity(proofs[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFRIFromEvaluations(t *testing.T) {
+
+	size := uint64(1024)
+	p := randomPolynomial(size, 7)
+
+	iopp := RADIX_2_FRI.New(size, sha256.New())
+	s := iopp.(radixTwoFri)
+
+	// evaluate p on the evaluation domain, in natural order
+	evals := make([]fr.Element, s.domain.Cardinality)
+	copy(evals, p)
+	s.domain.FFT(evals, fft.DIF)
+	fft.BitReverse(evals)
+
+	proof, err := iopp.BuildProofOfProximityFromEvaluations(evals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := iopp.VerifyProofOfProximity(proof); err != nil {
+		t.Fatal(err)
+	}
+
+	// same proof as from the coefficients
+	expected, err := iopp.BuildProofOfProximity(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(proof, expected) {
+		t.Fatal("proofs from evaluations and from coefficients differ")
+	}
+
+	if _, err := iopp.BuildProofOfProximityFromEvaluations(evals[:size]); err != ErrEvaluationsSize {
+		t.Fatal("evaluations of the wrong size should be rejected")
+	}
+}
+
+func TestProofOfProximitySerialization(t *testing.T) {
+
+	size := uint64(256)
+	p := randomPolynomial(size, 11)
+
+	iopp, err := RADIX_2_FRI.NewWithOptions(size, sha256.New(), WithNbRounds(2), WithInstanceID([]byte("serialization")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, err := iopp.BuildProofOfProximity(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	written, err := proof.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written != int64(buf.Len()) {
+		t.Fatalf("WriteTo returned %d, wrote %d bytes", written, buf.Len())
+	}
+	data := buf.Bytes()
+
+	var decoded ProofOfProximity
+	read, err := decoded.ReadFrom(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read != written {
+		t.Fatalf("ReadFrom read %d bytes, expected %d", read, written)
+	}
+	if !reflect.DeepEqual(decoded, proof) {
+		t.Fatal("decoded proof differs")
+	}
+	if err := iopp.VerifyProofOfProximity(decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	// truncated encodings are rejected
+	for _, n := range []int{0, 3, len(data) / 2, len(data) - 1} {
+		if _, err := decoded.ReadFrom(bytes.NewReader(data[:n])); err == nil {
+			t.Fatalf("truncated encoding of %d bytes accepted", n)
+		}
+	}
+
+	// the number of leaves is checked by the verifier
+	decoded.Rounds[0].Interactions[1][0].numLeaves *= 2
+	if err := iopp.VerifyProofOfProximity(decoded); !errors.Is(err, ErrProofShape) {
+		t.Fatalf("expected ErrProofShape, got %v", err)
+	}
+}
+
+func TestMerkleTree(t *testing.T) {
+
+	evals := randomPolynomial(64, 3)
+	tree := newMerkleTree(sha256.New(), nil, evals, 0)
+
+	for _, index := range []uint64{0, 1, 17, 63} {
+		expected := merkletree.New(sha256.New())
+		if err := expected.SetIndex(index); err != nil {
+			t.Fatal(err)
+		}
+		for i := range evals {
+			expected.Push(evals[i].Marshal())
+		}
+		root, proofSet, _, _ := expected.Prove()
+		if !bytes.Equal(root, tree.root()) {
+			t.Fatal("wrong merkle root")
+		}
+		if !reflect.DeepEqual(proofSet, tree.prove(index)) {
+			t.Fatal("wrong merkle proof")
+		}
+	}
+}
+
+func TestParallelHashing(t *testing.T) {
+
+	evals := randomPolynomial(1024, 3)
+	serial := newMerkleTree(sha256.New(), nil, evals, 1)
+	parallel := newMerkleTree(sha256.New(), sha256.New, evals, 1)
+	if !reflect.DeepEqual(serial, parallel) {
+		t.Fatal("hashing in parallel should give the same tree")
+	}
+
+	size := uint64(256)
+	p := randomPolynomial(size, 5)
+	iopp := RADIX_2_FRI.New(size, sha256.New())
+	withParallelHashing, err := RADIX_2_FRI.NewWithOptions(size, sha256.New(), WithParallelHashing(sha256.New))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if iopp.Fingerprint() != withParallelHashing.Fingerprint() {
+		t.Fatal("parallel hashing shouldn't change the fingerprint")
+	}
+	proof, err := withParallelHashing.BuildProofOfProximity(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := iopp.VerifyProofOfProximity(proof); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = RADIX_2_FRI.NewWithOptions(size, sha256.New(), WithParallelHashing(sha512.New))
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig, got %v", err)
+	}
+}
+
+func TestMerkleCap(t *testing.T) {
+
+	size := uint64(256)
+	p := randomPolynomial(size, 5)
+
+	// cap of the tree
+	evals := randomPolynomial(64, 3)
+	full := newMerkleTree(sha256.New(), nil, evals, 0)
+	capped := newMerkleTree(sha256.New(), nil, evals, 2)
+	if !bytes.Equal(capped.root(), bytes.Join(full.nodes[len(full.nodes)-3], nil)) {
+		t.Fatal("the cap should be the nodes at height 2")
+	}
+	if proofSet := capped.prove(17); len(proofSet) != len(full.prove(17))-2 {
+		t.Fatalf("the proof set should be 2 nodes shorter, got %d nodes", len(proofSet))
+	}
+
+	noCap := RADIX_2_FRI.New(size, sha256.New())
+	for _, h := range []int{1, 3} {
+		iopp, err := RADIX_2_FRI.NewWithOptions(size, sha256.New(), WithMerkleCapHeight(h))
+		if err != nil {
+			t.Fatal(err)
+		}
+		proof, err := iopp.BuildProofOfProximity(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := iopp.VerifyProofOfProximity(proof); err != nil {
+			t.Fatal(err)
+		}
+		if l := len(proof.Rounds[0].Interactions[0][0].MerkleRoot); l != sha256.Size<<h {
+			t.Fatalf("cap height %d: expected a cap of %d bytes, got %d", h, sha256.Size<<h, l)
+		}
+
+		// the verifier must use the same cap height
+		if err := noCap.VerifyProofOfProximity(proof); err == nil {
+			t.Fatalf("cap height %d: verifying without cap should fail", h)
+		}
+		if iopp.Fingerprint() == noCap.Fingerprint() {
+			t.Fatalf("cap height %d: the fingerprint should depend on the cap height", h)
+		}
+
+		// tampered cap
+		proof.Rounds[0].Interactions[1][0].MerkleRoot[len(proof.Rounds[0].Interactions[1][0].MerkleRoot)-1] ^= 1
+		if err := iopp.VerifyProofOfProximity(proof); err == nil {
+			t.Fatalf("cap height %d: verifying a tampered cap should fail", h)
+		}
+		proof.Rounds[0].Interactions[1][0].MerkleRoot[len(proof.Rounds[0].Interactions[1][0].MerkleRoot)-1] ^= 1
+
+		// openings
+		for _, position := range []uint64{0, 3, 1000, 2047} {
+			openingProof, err := iopp.Open(p, position)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := iopp.VerifyOpening(position, openingProof, proof); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// DEEP-FRI
+		committer, err := NewCommitter(iopp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		deepProof, err := committer.BuildDeepProofOfProximity(committer.Commit(p))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := iopp.VerifyDeepProofOfProximity(deepProof); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestWideChallenges(t *testing.T) {
+
+	size := uint64(256)
+	p := randomPolynomial(size, 7)
+
+	narrow := RADIX_2_FRI.New(size, sha256.New())
+	wide, err := RADIX_2_FRI.NewWithOptions(size, sha256.New(), WithWideChallenges())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wide.Fingerprint() == narrow.Fingerprint() {
+		t.Fatal("the fingerprint should depend on the challenge derivation")
+	}
+
+	proof, err := wide.BuildProofOfProximity(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := wide.VerifyProofOfProximity(proof); err != nil {
+		t.Fatal(err)
+	}
+	if err := narrow.VerifyProofOfProximity(proof); err == nil {
+		t.Fatal("verifying a proof with the default challenges should fail")
+	}
+
+	// the folding challenges are the wide reductions of the transcript challenges
+	xi, _, err := wide.(radixTwoFri).DeriveQueries(0, proof.Rounds[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs, xis, err := narrow.(radixTwoFri).roundTranscript(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Bind(xis[0], proof.Rounds[0].Interactions[0][0].MerkleRoot); err != nil {
+		t.Fatal(err)
+	}
+	expected, err := fiatshamir.ComputeChallengeFr[fr.Element](fs, xis[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !xi[0].Equal(&expected) {
+		t.Fatal("the folding challenge should be derived with ComputeChallengeFr")
+	}
+
+	// DEEP-FRI
+	committer, err := NewCommitter(wide)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deepProof, err := committer.BuildDeepProofOfProximity(committer.Commit(p))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := wide.VerifyDeepProofOfProximity(deepProof); err != nil {
+		t.Fatal(err)
+	}
+	if err := narrow.VerifyDeepProofOfProximity(deepProof); err == nil {
+		t.Fatal("verifying a DEEP proof with the default challenges should fail")
+	}
+
+	// the default derivation is read when the instance is created
+	defer fiatshamir.SetWideChallenges(fiatshamir.WideChallenges())
+	fiatshamir.SetWideChallenges(true)
+	migrated := RADIX_2_FRI.New(size, sha256.New())
+	fiatshamir.SetWideChallenges(false)
+	if migrated.Fingerprint() != wide.Fingerprint() {
+		t.Fatal("SetWideChallenges(true) should imply WithWideChallenges")
+	}
+	if err := migrated.VerifyProofOfProximity(proof); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCommitter(t *testing.T) {
+
+	size := uint64(1024)
+	p := randomPolynomial(size, 11)
+
+	iopp := RADIX_2_FRI.NewWithInstance(size, sha256.New(), []byte("committer"))
+	committer, err := NewCommitter(iopp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cm := committer.Commit(p)
+
+	proof, err := committer.BuildProofOfProximity(cm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := iopp.VerifyProofOfProximity(proof); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(cm.Root(), proof.Rounds[0].Interactions[0][0].MerkleRoot) {
+		t.Fatal("the root of the commitment should be the root of the first interaction")
+	}
+
+	// same proofs as without a committer
+	expected, err := iopp.BuildProofOfProximity(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(proof, expected) {
+		t.Fatal("proofs of proximity with and without committer differ")
+	}
+
+	for _, position := range []uint64{0, 5, 1023, 8191} {
+		openingProof, err := committer.Open(cm, position)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectedOpening, err := iopp.Open(p, position)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(openingProof, expectedOpening) {
+			t.Fatal("openings with and without committer differ")
+		}
+		if err := iopp.VerifyOpening(position, openingProof, proof); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := committer.Open(cm, 8192); err != ErrRangePosition {
+		t.Fatal("opening out of the domain should fail")
+	}
+
+	// commitment from the evaluations
+	evals := make([]fr.Element, 8192)
+	copy(evals, p)
+	iopp.(radixTwoFri).domain.FFT(evals, fft.DIF)
+	fft.BitReverse(evals)
+	cmEvals, err := committer.CommitEvaluations(evals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(cm.Root(), cmEvals.Root()) {
+		t.Fatal("commitments from coefficients and evaluations differ")
+	}
+	if _, err := committer.CommitEvaluations(evals[1:]); err != ErrEvaluationsSize {
+		t.Fatal("evaluations of the wrong size should be rejected")
+	}
+}
+
+func TestDeepFRI(t *testing.T) {
+
+	size := uint64(1024)
+	p := randomPolynomial(size, 13)
+
+	iopp := RADIX_2_FRI.New(size, sha256.New())
+	committer, err := NewCommitter(iopp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cm := committer.Commit(p)
+
+	proof, err := committer.BuildDeepProofOfProximity(cm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := iopp.VerifyDeepProofOfProximity(proof); err != nil {
+		t.Fatal(err)
+	}
+
+	// the evaluation is p(z)
+	var expected fr.Element
+	for i := len(p) - 1; i >= 0; i-- {
+		expected.Mul(&expected, &proof.Point).Add(&expected, &p[i])
+	}
+	if !expected.Equal(&proof.Evaluation) {
+		t.Fatal("wrong evaluation at the out of domain point")
+	}
+
+	// wrong evaluation
+	tampered := proof
+	tampered.Evaluation.SetOne()
+	if err := iopp.VerifyDeepProofOfProximity(tampered); err == nil {
+		t.Fatal("verifying a wrong evaluation should fail")
+	}
+
+	// wrong point
+	tampered = proof
+	tampered.Point.SetOne()
+	if err := iopp.VerifyDeepProofOfProximity(tampered); err == nil {
+		t.Fatal("verifying a wrong point should fail")
+	}
+
+	// quotient of another polynomial, consistent with its own commitment only
+	other, err := committer.BuildDeepProofOfProximity(committer.Commit(randomPolynomial(size, 17)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered = proof
+	tampered.Quotient = other.Quotient
+	if err := iopp.VerifyDeepProofOfProximity(tampered); err == nil {
+		t.Fatal("verifying the quotient of another polynomial should fail")
+	}
+
+	// the proof is bound to the instance
+	verifier := RADIX_2_FRI.NewWithInstance(size, sha256.New(), []byte("instance"))
+	if err := verifier.VerifyDeepProofOfProximity(proof); err == nil {
+		t.Fatal("verifying a proof bound to another instance should fail")
+	}
+
+	// opening at a given point
+	var z fr.Element
+	z.SetUint64(42)
+	proof, err = committer.BuildDeepProofOfProximityAt(cm, z)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := iopp.VerifyDeepProofOfProximityAt(proof, z); err != nil {
+		t.Fatal(err)
+	}
+	expected.SetZero()
+	for i := len(p) - 1; i >= 0; i-- {
+		expected.Mul(&expected, &z).Add(&expected, &p[i])
+	}
+	if !expected.Equal(&proof.Evaluation) {
+		t.Fatal("wrong evaluation at the given point")
+	}
+	if err := iopp.VerifyDeepProofOfProximity(proof); err == nil {
+		t.Fatal("the point should be derived from the commitment")
+	}
+	z.SetUint64(43)
+	if err := iopp.VerifyDeepProofOfProximityAt(proof, z); err == nil {
+		t.Fatal("verifying at another point should fail")
+	}
+	z.SetOne()
+	if _, err := committer.BuildDeepProofOfProximityAt(cm, z); !errors.Is(err, ErrDeepPoint) {
+		t.Fatal("opening in the evaluation domain should fail")
+	}
+}
+
 // Benchmarks
 
 func BenchmarkProximityVerification(b *testing.B) {
@@ -238,3 +1099,25 @@ func BenchmarkProximityVerification(b *testing.B) {
 
 	}
 }
+func BenchmarkBuildProofOfProximity(b *testing.B) {
+
+	const size = 1 << 12
+	p := randomPolynomial(size, 1)
+	iop := RADIX_2_FRI.New(size, sha256.New())
+
+	b.Run("without memory", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			iop.BuildProofOfProximity(p)
+		}
+	})
+
+	b.Run("with memory", func(b *testing.B) {
+		b.ReportAllocs()
+		mem := NewProverMemory()
+		for i := 0; i < b.N; i++ {
+			mem.Reset()
+			iop.BuildProofOfProximityWithMemory(p, mem)
+		}
+	})
+}
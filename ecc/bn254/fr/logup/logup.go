@@ -0,0 +1,483 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package logup
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+var (
+	ErrNotInTable         = errors.New("some row of the queried columns is not in the lookup table")
+	ErrIncompatibleSize   = errors.New("the queried columns and the table should have the same number of non empty columns")
+	ErrLogupVerification  = errors.New("logup verification failed")
+	ErrGenerator          = errors.New("wrong generator")
+	ErrChallengeCollision = errors.New("the challenge γ is a value of the folded columns")
+)
+
+// Proof logUp proof that the rows of the committed queried columns f are rows of
+// the committed table t.
+type Proof struct {
+
+	// size of the fft domain
+	size uint64
+
+	// generator of the fft domain, used for shifting the evaluation point
+	g fr.Element
+
+	// commitments to the queried columns and to the columns of the table
+	f, t []kzg.Digest
+
+	// commitments to the multiplicities of the rows of the table, to the running
+	// sum φ and to the quotient h
+	m, phi, h kzg.Digest
+
+	// opening proofs of f, t, m, φ, h (in that order) at ζ
+	batchedProof kzg.BatchOpeningProof
+
+	// opening proof of φ at ω⋅ζ
+	shiftedProof kzg.OpeningProof
+}
+
+// ProveLookupVector returns a proof that the values in f are in t.
+func ProveLookupVector(pk kzg.ProvingKey, f, t fr.Vector) (Proof, error) {
+	return ProveLookupTables(pk, []fr.Vector{f}, []fr.Vector{t})
+}
+
+// ProveLookupTables returns a proof that the rows of the columns f are rows of the
+// table whose columns are t. Columns are folded with a random challenge, so that
+// a row is looked up as a whole.
+//
+// The commitments to t are part of the proof: if the table is committed somewhere,
+// the caller should check they match.
+func ProveLookupTables(pk kzg.ProvingKey, f, t []fr.Vector) (Proof, error) {
+
+	var proof Proof
+	var err error
+
+	nbColumns := len(f)
+	if nbColumns == 0 || nbColumns != len(t) {
+		return proof, ErrIncompatibleSize
+	}
+	for i := 1; i < nbColumns; i++ {
+		if len(f[i]) != len(f[0]) || len(t[i]) != len(t[0]) {
+			return proof, ErrIncompatibleSize
+		}
+	}
+	if len(f[0]) == 0 || len(t[0]) == 0 {
+		return proof, ErrIncompatibleSize
+	}
+
+	// hash function used for Fiat Shamir
+	hFunc := sha256.New()
+
+	// transcript to derive the challenges
+	fs := fiatshamir.NewTranscript(hFunc, "alpha", "gamma", "zeta")
+
+	// create the domain
+	size := len(f[0])
+	if len(t[0]) > size {
+		size = len(t[0])
+	}
+	domain := fft.NewDomain(uint64(size))
+	n := int(domain.Cardinality)
+	proof.size = domain.Cardinality
+	proof.g.Set(&domain.Generator)
+
+	// resize f and t: f is padded with the first row of t, and t with its last row,
+	// which doesn't change the set of rows of t
+	lf := make([][]fr.Element, nbColumns)
+	lt := make([][]fr.Element, nbColumns)
+	for k := 0; k < nbColumns; k++ {
+		lf[k] = make([]fr.Element, n)
+		lt[k] = make([]fr.Element, n)
+		copy(lf[k], f[k])
+		copy(lt[k], t[k])
+		for i := len(f[k]); i < n; i++ {
+			lf[k][i].Set(&t[k][0])
+		}
+		for i := len(t[k]); i < n; i++ {
+			lt[k][i].Set(&t[k][len(t[k])-1])
+		}
+	}
+
+	// compute the multiplicities, counting each row of f at the first occurrence
+	// of the row in t
+	lm, err := computeMultiplicities(lf, lt)
+	if err != nil {
+		return proof, err
+	}
+
+	// commit to f, t, m
+	cf := make([][]fr.Element, nbColumns)
+	ct := make([][]fr.Element, nbColumns)
+	proof.f = make([]kzg.Digest, nbColumns)
+	proof.t = make([]kzg.Digest, nbColumns)
+	for k := 0; k < nbColumns; k++ {
+		cf[k] = toCanonical(lf[k], domain)
+		if proof.f[k], err = kzg.Commit(cf[k], pk); err != nil {
+			return proof, err
+		}
+		ct[k] = toCanonical(lt[k], domain)
+		if proof.t[k], err = kzg.Commit(ct[k], pk); err != nil {
+			return proof, err
+		}
+	}
+	cm := toCanonical(lm, domain)
+	if proof.m, err = kzg.Commit(cm, pk); err != nil {
+		return proof, err
+	}
+
+	// derive α, γ
+	toBind := make([]*bn254.G1Affine, 0, 2*nbColumns+1)
+	for k := 0; k < nbColumns; k++ {
+		toBind = append(toBind, &proof.f[k])
+	}
+	for k := 0; k < nbColumns; k++ {
+		toBind = append(toBind, &proof.t[k])
+	}
+	toBind = append(toBind, &proof.m)
+	alpha, err := deriveRandomness(fs, "alpha", toBind...)
+	if err != nil {
+		return proof, err
+	}
+	gamma, err := deriveRandomness(fs, "gamma")
+	if err != nil {
+		return proof, err
+	}
+
+	// compute the running sum φ, φ(ωⁱ⁺¹) = φ(ωⁱ) + 1/(γ-f̃(ωⁱ)) - m(ωⁱ)/(γ-t̃(ωⁱ))
+	lphi, err := evaluateRunningSum(fold(lf, alpha), fold(lt, alpha), lm, gamma)
+	if err != nil {
+		return proof, err
+	}
+	cphi := toCanonical(lphi, domain)
+	if proof.phi, err = kzg.Commit(cphi, pk); err != nil {
+		return proof, err
+	}
+
+	// compute the quotient
+	domainBig := fft.NewDomain(4 * domain.Cardinality)
+	ef := make([][]fr.Element, nbColumns)
+	et := make([][]fr.Element, nbColumns)
+	for k := 0; k < nbColumns; k++ {
+		ef[k] = evaluateOnCoset(cf[k], domainBig)
+		et[k] = evaluateOnCoset(ct[k], domainBig)
+	}
+	ch := computeQuotientCanonical(
+		fold(ef, alpha),
+		fold(et, alpha),
+		evaluateOnCoset(cm, domainBig),
+		evaluateOnCoset(cphi, domainBig),
+		gamma, domain, domainBig,
+	)
+	if proof.h, err = kzg.Commit(ch, pk); err != nil {
+		return proof, err
+	}
+
+	// build the opening proofs
+	zeta, err := deriveRandomness(fs, "zeta", &proof.phi, &proof.h)
+	if err != nil {
+		return proof, err
+	}
+	polynomials := make([][]fr.Element, 0, 2*nbColumns+3)
+	polynomials = append(polynomials, cf...)
+	polynomials = append(polynomials, ct...)
+	polynomials = append(polynomials, cm, cphi, ch)
+	proof.batchedProof, err = kzg.BatchOpenSinglePoint(polynomials, proof.digests(), zeta, hFunc, pk)
+	if err != nil {
+		return proof, err
+	}
+
+	zeta.Mul(&zeta, &domain.Generator)
+	proof.shiftedProof, err = kzg.Open(cphi, zeta, pk)
+	if err != nil {
+		return proof, err
+	}
+
+	return proof, nil
+}
+
+// Verify verifies that a logUp proof is correct.
+func Verify(vk kzg.VerifyingKey, proof Proof) error {
+
+	nbColumns := len(proof.f)
+	if nbColumns == 0 || nbColumns != len(proof.t) || len(proof.batchedProof.ClaimedValues) != 2*nbColumns+3 {
+		return ErrIncompatibleSize
+	}
+
+	// hash function that is used for Fiat Shamir
+	hFunc := sha256.New()
+
+	// transcript to derive the challenges
+	fs := fiatshamir.NewTranscript(hFunc, "alpha", "gamma", "zeta")
+
+	// derive the various challenges
+	digests := proof.digests()
+	toBind := make([]*bn254.G1Affine, 2*nbColumns+1)
+	for i := range toBind {
+		toBind[i] = &digests[i]
+	}
+	alpha, err := deriveRandomness(fs, "alpha", toBind...)
+	if err != nil {
+		return err
+	}
+	gamma, err := deriveRandomness(fs, "gamma")
+	if err != nil {
+		return err
+	}
+	zeta, err := deriveRandomness(fs, "zeta", &proof.phi, &proof.h)
+	if err != nil {
+		return err
+	}
+
+	// check opening proofs
+	err = kzg.BatchVerifySinglePoint(digests, &proof.batchedProof, zeta, hFunc, vk)
+	if err != nil {
+		return err
+	}
+	var shiftedZeta fr.Element
+	shiftedZeta.Mul(&zeta, &proof.g)
+	err = kzg.Verify(&proof.phi, &proof.shiftedProof, shiftedZeta, vk)
+	if err != nil {
+		return err
+	}
+
+	// check the generator is correct
+	var checkOrder, one fr.Element
+	one.SetOne()
+	if proof.size == 0 || proof.size&(proof.size-1) != 0 {
+		return ErrGenerator
+	}
+	if proof.size > 1 {
+		checkOrder.Exp(proof.g, big.NewInt(int64(proof.size/2)))
+		if checkOrder.Equal(&one) {
+			return ErrGenerator
+		}
+		checkOrder.Square(&checkOrder)
+	} else {
+		checkOrder.Set(&proof.g)
+	}
+	if !checkOrder.Equal(&one) {
+		return ErrGenerator
+	}
+
+	// check the polynomial relation using Schwartz Zippel
+	claimedValues := proof.batchedProof.ClaimedValues
+	var ff, ft fr.Element
+	for k := nbColumns - 1; k >= 0; k-- {
+		ff.Mul(&ff, &alpha).Add(&ff, &claimedValues[k])
+		ft.Mul(&ft, &alpha).Add(&ft, &claimedValues[nbColumns+k])
+	}
+	m := claimedValues[2*nbColumns]
+	phi := claimedValues[2*nbColumns+1]
+	h := claimedValues[2*nbColumns+2]
+	phiShifted := proof.shiftedProof.ClaimedValue
+
+	// (φ(ωζ)-φ(ζ))(γ-f̃(ζ))(γ-t̃(ζ)) - (γ-t̃(ζ)) + m(ζ)(γ-f̃(ζ))
+	var lhs, gammaMinusF, gammaMinusT, u fr.Element
+	gammaMinusF.Sub(&gamma, &ff)
+	gammaMinusT.Sub(&gamma, &ft)
+	lhs.Sub(&phiShifted, &phi).
+		Mul(&lhs, &gammaMinusF).
+		Mul(&lhs, &gammaMinusT).
+		Sub(&lhs, &gammaMinusT)
+	u.Mul(&m, &gammaMinusF)
+	lhs.Add(&lhs, &u)
+
+	// = (ζⁿ-1)h(ζ)
+	var rhs fr.Element
+	rhs.Exp(zeta, big.NewInt(int64(proof.size))).
+		Sub(&rhs, &one).
+		Mul(&rhs, &h)
+	if !lhs.Equal(&rhs) {
+		return ErrLogupVerification
+	}
+
+	return nil
+}
+
+// digests returns the commitments to f, t, m, φ, h, in that order
+func (proof *Proof) digests() []kzg.Digest {
+	res := make([]kzg.Digest, 0, len(proof.f)+len(proof.t)+3)
+	res = append(res, proof.f...)
+	res = append(res, proof.t...)
+	return append(res, proof.m, proof.phi, proof.h)
+}
+
+// computeMultiplicities returns m, in Lagrange basis, where m[j] is the number of rows
+// of lf equal to the row j of lt, if j is the first occurrence of this row in lt (0 otherwise).
+// It returns ErrNotInTable if some row of lf is not in lt.
+func computeMultiplicities(lf, lt [][]fr.Element) ([]fr.Element, error) {
+
+	rowKey := func(columns [][]fr.Element, i int) string {
+		key := make([]byte, 0, len(columns)*fr.Bytes)
+		for k := range columns {
+			b := columns[k][i].Bytes()
+			key = append(key, b[:]...)
+		}
+		return string(key)
+	}
+
+	n := len(lt[0])
+	index := make(map[string]int, n)
+	for j := n - 1; j >= 0; j-- {
+		index[rowKey(lt, j)] = j
+	}
+
+	counts := make([]uint64, n)
+	for i := 0; i < len(lf[0]); i++ {
+		j, ok := index[rowKey(lf, i)]
+		if !ok {
+			return nil, ErrNotInTable
+		}
+		counts[j]++
+	}
+
+	res := make([]fr.Element, n)
+	for j := range res {
+		res[j].SetUint64(counts[j])
+	}
+	return res, nil
+}
+
+// fold returns ∑ⱼ αʲ columns[j]
+func fold(columns [][]fr.Element, alpha fr.Element) []fr.Element {
+	res := make([]fr.Element, len(columns[0]))
+	copy(res, columns[len(columns)-1])
+	for k := len(columns) - 2; k >= 0; k-- {
+		for i := range res {
+			res[i].Mul(&res[i], &alpha).Add(&res[i], &columns[k][i])
+		}
+	}
+	return res
+}
+
+// evaluateRunningSum returns φ in Lagrange basis, where φ(1) = 0 and
+// φ(ωⁱ⁺¹) = φ(ωⁱ) + 1/(γ-f̃(ωⁱ)) - m(ωⁱ)/(γ-t̃(ωⁱ)).
+// If the lookup is correct, the sum over the whole domain is 0 so φ(ωⁿ) = φ(1).
+func evaluateRunningSum(lf, lt, lm []fr.Element, gamma fr.Element) ([]fr.Element, error) {
+
+	n := len(lf)
+	den := make([]fr.Element, 2*n)
+	for i := 0; i < n; i++ {
+		den[i].Sub(&gamma, &lf[i])
+		den[n+i].Sub(&gamma, &lt[i])
+		if den[i].IsZero() || den[n+i].IsZero() {
+			return nil, ErrChallengeCollision
+		}
+	}
+	den = fr.BatchInvert(den)
+
+	res := make([]fr.Element, n)
+	var u fr.Element
+	for i := 0; i < n-1; i++ {
+		u.Mul(&lm[i], &den[n+i])
+		res[i+1].Add(&res[i], &den[i]).Sub(&res[i+1], &u)
+	}
+	return res, nil
+}
+
+// toCanonical returns the canonical coefficients, in regular order, of the polynomial
+// whose evaluations on domain are values, in regular order.
+func toCanonical(values []fr.Element, domain *fft.Domain) []fr.Element {
+	res := make([]fr.Element, len(values))
+	copy(res, values)
+	domain.FFTInverse(res, fft.DIF)
+	fft.BitReverse(res)
+	return res
+}
+
+// evaluateOnCoset returns the evaluations, in regular order, of the polynomial whose canonical
+// coefficients are p on the coset FrMultiplicativeGen⋅<ω> of domainBig.
+func evaluateOnCoset(p []fr.Element, domainBig *fft.Domain) []fr.Element {
+	res := make([]fr.Element, domainBig.Cardinality)
+	copy(res, p)
+	domainBig.FFT(res, fft.DIF, fft.OnCoset())
+	fft.BitReverse(res)
+	return res
+}
+
+// computeQuotientCanonical computes h, in canonical basis, where
+// h⋅(Xⁿ-1) = (φ(ωX)-φ)(γ-f̃)(γ-t̃) - (γ-t̃) + m(γ-f̃)
+//
+// * ef, et, em, ephi are f̃, t̃, m, φ evaluated on the coset of domainBig (regular order)
+// * domainBig is of size 4n, so that ω = ω_big⁴
+func computeQuotientCanonical(ef, et, em, ephi []fr.Element, gamma fr.Element, domain, domainBig *fft.Domain) []fr.Element {
+
+	s := int(domainBig.Cardinality)
+	ratio := s / int(domain.Cardinality)
+
+	var one fr.Element
+	one.SetOne()
+
+	// 1/(xⁿ-1) on the coset only takes ratio values
+	n := big.NewInt(int64(domain.Cardinality))
+	xnMinusOneInv := make([]fr.Element, ratio)
+	var acc, step fr.Element
+	acc.Exp(domainBig.FrMultiplicativeGen, n)
+	step.Exp(domainBig.Generator, n)
+	for i := 0; i < ratio; i++ {
+		xnMinusOneInv[i].Sub(&acc, &one)
+		acc.Mul(&acc, &step)
+	}
+	xnMinusOneInv = fr.BatchInvert(xnMinusOneInv)
+
+	res := make([]fr.Element, s)
+	var gammaMinusF, gammaMinusT, u fr.Element
+	for i := 0; i < s; i++ {
+		gammaMinusF.Sub(&gamma, &ef[i])
+		gammaMinusT.Sub(&gamma, &et[i])
+
+		res[i].Sub(&ephi[(i+ratio)%s], &ephi[i]).
+			Mul(&res[i], &gammaMinusF).
+			Mul(&res[i], &gammaMinusT).
+			Sub(&res[i], &gammaMinusT)
+		u.Mul(&em[i], &gammaMinusF)
+		res[i].Add(&res[i], &u).
+			Mul(&res[i], &xnMinusOneInv[i%ratio])
+	}
+
+	domainBig.FFTInverse(res, fft.DIF, fft.OnCoset())
+	fft.BitReverse(res)
+
+	// h is of degree < 2n-2
+	return res[:2*domain.Cardinality]
+}
+
+// deriveRandomness computes the challenge from the transcript, binding the given points.
+func deriveRandomness(fs *fiatshamir.Transcript, challenge string, points ...*bn254.G1Affine) (fr.Element, error) {
+
+	var buf [bn254.SizeOfG1AffineUncompressed]byte
+	var r fr.Element
+
+	for _, p := range points {
+		buf = p.RawBytes()
+		if err := fs.Bind(challenge, buf[:]); err != nil {
+			return r, err
+		}
+	}
+
+	return fiatshamir.DeriveChallengeFr[fr.Element](fs, challenge)
+}
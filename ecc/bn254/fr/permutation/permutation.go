@@ -25,6 +25,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/grandproduct"
 	"github.com/consensys/gnark-crypto/ecc/bn254/kzg"
 	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
 )
@@ -61,28 +62,22 @@ type Proof struct {
 }
 
 // evaluateAccumulationPolynomialBitReversed returns the accumulation polynomial in Lagrange basis.
-func evaluateAccumulationPolynomialBitReversed(lt1, lt2 []fr.Element, epsilon fr.Element) []fr.Element {
+func evaluateAccumulationPolynomialBitReversed(lt1, lt2 []fr.Element, epsilon fr.Element) ([]fr.Element, error) {
 
 	s := len(lt1)
-	z := make([]fr.Element, s)
-	d := make([]fr.Element, s)
-	z[0].SetOne()
-	d[0].SetOne()
-	nn := uint64(64 - bits.TrailingZeros64(uint64(s)))
-	var t fr.Element
-	for i := 0; i < s-1; i++ {
-		_i := int(bits.Reverse64(uint64(i)) >> nn)
-		_ii := int(bits.Reverse64(uint64((i+1)%s)) >> nn)
-		z[_ii].Mul(&z[_i], t.Sub(&epsilon, &lt1[i]))
-		d[i+1].Mul(&d[i], t.Sub(&epsilon, &lt2[i]))
+	num := make([]fr.Element, s)
+	den := make([]fr.Element, s)
+	for i := 0; i < s; i++ {
+		num[i].Sub(&epsilon, &lt1[i])
+		den[i].Sub(&epsilon, &lt2[i])
 	}
-	d = fr.BatchInvert(d)
-	for i := 0; i < s-1; i++ {
-		_ii := int(bits.Reverse64(uint64((i+1)%s)) >> nn)
-		z[_ii].Mul(&z[_ii], &d[i+1])
+	z, err := grandproduct.Build(num, den)
+	if err != nil {
+		return nil, err
 	}
+	fft.BitReverse(z)
 
-	return z
+	return z, nil
 }
 
 // evaluateFirstPartNumReverse computes lt2*z(gx) - lt1*z
@@ -183,7 +178,10 @@ func Prove(pk kzg.ProvingKey, t1, t2 []fr.Element) (Proof, error) {
 	}
 
 	// compute Z and commit it
-	cz := evaluateAccumulationPolynomialBitReversed(t1, t2, epsilon)
+	cz, err := evaluateAccumulationPolynomialBitReversed(t1, t2, epsilon)
+	if err != nil {
+		return proof, err
+	}
 	d.FFTInverse(cz, fft.DIT)
 	proof.z, err = kzg.Commit(cz, pk)
 	if err != nil {
@@ -370,10 +368,5 @@ func deriveRandomness(fs *fiatshamir.Transcript, challenge string, points ...*bn
 		}
 	}
 
-	b, err := fs.ComputeChallenge(challenge)
-	if err != nil {
-		return r, err
-	}
-	r.SetBytes(b)
-	return r, nil
+	return fiatshamir.DeriveChallengeFr[fr.Element](fs, challenge)
 }
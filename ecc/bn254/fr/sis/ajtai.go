@@ -0,0 +1,135 @@
+// Copyright 2023 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sis
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+)
+
+var (
+	ErrOpeningSize = errors.New("the opening is longer than the key")
+	ErrNormBound   = errors.New("the opening exceeds the norm bound")
+	ErrOpening     = errors.New("the opening doesn't match the commitment")
+)
+
+// Commitment is an Ajtai commitment to a short vector m: the polynomial ∑ᵢ A[i]·mᵢ mod
+// Xᵈ+1, where mᵢ is the i-th chunk of Degree coefficients of m.
+//
+// It is binding for vectors of small infinity norm under the ring-SIS assumption, and
+// additively homomorphic: the sum of the commitments to m and m' is the commitment to
+// m+m', whose norm is at most the sum of their norms. It is not hiding.
+type Commitment []fr.Element
+
+// Commit returns the Ajtai commitment to m, a vector of at most len(A)·Degree
+// coefficients. The binding property holds for ‖m‖∞ ≤ NormBound().
+//
+// Unlike Sum, which decomposes bytes into limbs, Commit takes the coefficients of m
+// as integers.
+func (r *RSis) Commit(m []int64) (Commitment, error) {
+	if len(m) > len(r.Ag)*r.Degree {
+		return nil, fmt.Errorf("%w: %d coefficients, at most %d", ErrOpeningSize, len(m), len(r.Ag)*r.Degree)
+	}
+	res := make(Commitment, r.Degree)
+	k := make(fr.Vector, r.Degree)
+	for i := 0; i*r.Degree < len(m); i++ {
+		chunk := m[i*r.Degree : min((i+1)*r.Degree, len(m))]
+		zero := true
+		for j := range k {
+			k[j].SetZero()
+			if j < len(chunk) && chunk[j] != 0 {
+				k[j].SetInt64(chunk[j])
+				zero = false
+			}
+		}
+		if zero {
+			continue
+		}
+		r.Domain.FFT(k, fft.DIF, fft.OnCoset(), fft.WithNbTasks(1))
+		mulModAcc(res, r.Ag[i], k)
+	}
+	r.Domain.FFTInverse(res, fft.DIT, fft.OnCoset(), fft.WithNbTasks(1))
+	return res, nil
+}
+
+// VerifyOpening checks that m opens c and that ‖m‖∞ ≤ normBound. normBound is
+// NormBound() for a fresh commitment, and grows with the homomorphic operations.
+func (r *RSis) VerifyOpening(c Commitment, m []int64, normBound uint64) error {
+	if norm := InfinityNorm(m); norm > normBound {
+		return fmt.Errorf("%w: %d > %d", ErrNormBound, norm, normBound)
+	}
+	expected, err := r.Commit(m)
+	if err != nil {
+		return err
+	}
+	if !expected.Equal(c) {
+		return ErrOpening
+	}
+	return nil
+}
+
+// NormBound returns the bound on the infinity norm of the committed vectors,
+// 2^LogTwoBound - 1, that of the limbs hashed by Sum.
+func (r *RSis) NormBound() uint64 {
+	return 1<<r.LogTwoBound - 1
+}
+
+// Add returns c + other, the commitment to the sum of the committed vectors.
+func (c Commitment) Add(other Commitment) Commitment {
+	res := make(Commitment, len(c))
+	for i := range res {
+		res[i].Add(&c[i], &other[i])
+	}
+	return res
+}
+
+// Equal returns true if c and other are the same commitment.
+func (c Commitment) Equal(other Commitment) bool {
+	if len(c) != len(other) {
+		return false
+	}
+	for i := range c {
+		if !c[i].Equal(&other[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// AddOpenings returns m + m', the opening of the sum of the commitments to m and m'.
+func AddOpenings(m, mPrime []int64) []int64 {
+	res := make([]int64, max(len(m), len(mPrime)))
+	copy(res, m)
+	for i := range mPrime {
+		res[i] += mPrime[i]
+	}
+	return res
+}
+
+// InfinityNorm returns ‖m‖∞ = maxᵢ |mᵢ|.
+func InfinityNorm(m []int64) uint64 {
+	var res uint64
+	for _, c := range m {
+		a := uint64(c)
+		if c < 0 {
+			a = -a
+		}
+		res = max(res, a)
+	}
+	return res
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sis
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAjtaiCommitment(t *testing.T) {
+	assert := require.New(t)
+
+	const logTwoDegree, logTwoBound = 4, 4
+	r, err := NewRSis(5, logTwoDegree, logTwoBound, 4)
+	assert.NoError(err)
+
+	rnd := rand.New(rand.NewSource(42)) //#nosec G404 -- test only
+	randomOpening := func(n int) []int64 {
+		m := make([]int64, n)
+		for i := range m {
+			m[i] = rnd.Int63n(int64(2*r.NormBound()+1)) - int64(r.NormBound())
+		}
+		return m
+	}
+
+	m := randomOpening(len(r.A)*r.Degree - 3)
+	c, err := r.Commit(m)
+	assert.NoError(err)
+
+	// schoolbook ∑ᵢ A[i]·mᵢ mod Xᵈ+1
+	expected := make(Commitment, r.Degree)
+	for i := 0; i*r.Degree < len(m); i++ {
+		for j := 0; j < r.Degree && i*r.Degree+j < len(m); j++ {
+			var mij fr.Element
+			mij.SetInt64(m[i*r.Degree+j])
+			for k := 0; k < r.Degree; k++ {
+				var t fr.Element
+				t.Mul(&r.A[i][k], &mij)
+				if j+k < r.Degree {
+					expected[j+k].Add(&expected[j+k], &t)
+				} else {
+					expected[j+k-r.Degree].Sub(&expected[j+k-r.Degree], &t)
+				}
+			}
+		}
+	}
+	assert.True(expected.Equal(c), "wrong commitment")
+
+	assert.NoError(r.VerifyOpening(c, m, r.NormBound()))
+	tampered := append([]int64{}, m...)
+	tampered[1]++
+	assert.True(errors.Is(r.VerifyOpening(c, tampered, r.NormBound()+1), ErrOpening))
+	tampered[1] = int64(r.NormBound()) + 1
+	assert.True(errors.Is(r.VerifyOpening(c, tampered, r.NormBound()), ErrNormBound))
+
+	// homomorphism: the norm bound doubles
+	mPrime := randomOpening(r.Degree)
+	cPrime, err := r.Commit(mPrime)
+	assert.NoError(err)
+	sum := AddOpenings(m, mPrime)
+	assert.NoError(r.VerifyOpening(c.Add(cPrime), sum, 2*r.NormBound()))
+
+	_, err = r.Commit(make([]int64, len(r.A)*r.Degree+1))
+	assert.True(errors.Is(err, ErrOpeningSize))
+}
@@ -19,7 +19,6 @@ import (
 	"encoding/binary"
 	"errors"
 	"hash"
-	"math/big"
 	"math/bits"
 
 	"github.com/bits-and-blooms/bitset"
@@ -109,17 +108,18 @@ func NewRSis(seed int64, logTwoDegree, logTwoBound, maxNbElementsToHash int) (*R
 		n++
 	}
 
-	// domains (shift is √{gen} )
-	var shift fr.Element
-	shift.SetString("19103219067921713944291392827692070036145651957329286315305642004821462161904") // -> 2²⁸-th root of unity of bn254
-	e := int64(1 << (28 - (logTwoDegree + 1)))
-	shift.Exp(shift, big.NewInt(e))
+	// the ring multiplication is done in the negacyclic domain: the roots of Xᵈ+1
+	// are the coset ψ·<ψ²>, ψ a primitive 2d-th root of unity
+	negacyclic, err := fft.NewNegacyclicDomain(uint64(degree))
+	if err != nil {
+		return nil, err
+	}
 
 	r := &RSis{
 		LogTwoBound:         logTwoBound,
 		capacity:            capacity,
 		Degree:              degree,
-		Domain:              fft.NewDomain(uint64(degree), fft.WithShift(shift)),
+		Domain:              negacyclic.Domain(),
 		A:                   make([][]fr.Element, n),
 		Ag:                  make([][]fr.Element, n),
 		bufM:                make(fr.Vector, degree*n),
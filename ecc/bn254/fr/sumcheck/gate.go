@@ -0,0 +1,248 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package sumcheck
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/polynomial"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+	"github.com/consensys/gnark-crypto/utils/concurrency"
+)
+
+// Gate is a low-degree function combining the evaluations of multilinear
+// polynomials. Gates of the gkr package satisfy this interface.
+type Gate interface {
+	Evaluate(...fr.Element) fr.Element
+	Degree() int
+}
+
+// GateFunc turns a function of the given total degree into a [Gate].
+type GateFunc struct {
+	F func(...fr.Element) fr.Element
+	D int
+}
+
+func (g GateFunc) Evaluate(x ...fr.Element) fr.Element {
+	return g.F(x...)
+}
+
+func (g GateFunc) Degree() int {
+	return g.D
+}
+
+// GateClaims is the prover side of the claim
+//
+//	∑_{x ∈ {0,1}ⁿ} gate(P₁(x), …, Pₖ(x)) = c
+//
+// where the Pᵢ are multilinear polynomials given by their evaluations on the
+// hypercube. The round polynomials are evaluated in parallel.
+type GateClaims struct {
+	gate    Gate
+	inputs  []polynomial.MultiLin
+	nbTasks int
+}
+
+// NewGateClaims returns the prover side of the claim that gate, applied to the
+// inputs, sums to some value over the hypercube. The inputs must all have the
+// same power of 2 length and are folded in place during the proof: callers
+// needing them afterwards must provide clones.
+func NewGateClaims(gate Gate, inputs ...polynomial.MultiLin) (*GateClaims, error) {
+	if len(inputs) == 0 {
+		return nil, errors.New("no input")
+	}
+	n := len(inputs[0])
+	if n < 2 || n&(n-1) != 0 {
+		return nil, errors.New("inputs length must be a power of 2 greater than 1")
+	}
+	for i := range inputs {
+		if len(inputs[i]) != n {
+			return nil, errors.New("inputs must have the same length")
+		}
+	}
+	return &GateClaims{gate: gate, inputs: inputs, nbTasks: concurrency.MaxTasks()}, nil
+}
+
+// Sum returns ∑_{x ∈ {0,1}ⁿ} gate(P₁(x), …, Pₖ(x)).
+func (c *GateClaims) Sum() fr.Element {
+	var sum fr.Element
+	x := make([]fr.Element, len(c.inputs))
+	for i := range c.inputs[0] {
+		for j := range c.inputs {
+			x[j] = c.inputs[j][i]
+		}
+		y := c.gate.Evaluate(x...)
+		sum.Add(&sum, &y)
+	}
+	return sum
+}
+
+func (c *GateClaims) VarsNum() int {
+	return polynomial.MultiLin(c.inputs[0]).NumVars()
+}
+
+func (c *GateClaims) ClaimsNum() int {
+	return 1
+}
+
+func (c *GateClaims) Combine(fr.Element) polynomial.Polynomial {
+	return c.roundPolynomial()
+}
+
+func (c *GateClaims) Next(r fr.Element) polynomial.Polynomial {
+	tasks := make([]func(int, int), len(c.inputs))
+	for i := range c.inputs {
+		tasks[i] = c.inputs[i].FoldParallel(r)
+	}
+	parallel.Execute(len(c.inputs[0]), func(start, end int) {
+		for _, task := range tasks {
+			task(start, end)
+		}
+	}, c.nbTasks)
+	return c.roundPolynomial()
+}
+
+// ProveFinalEval returns the evaluations of the inputs at r, that the verifier
+// checks with its [InputsChecker].
+func (c *GateClaims) ProveFinalEval(r []fr.Element) interface{} {
+	// the last fold was not performed by Next
+	evals := make([]fr.Element, len(c.inputs))
+	var t fr.Element
+	last := r[len(r)-1]
+	for i := range c.inputs {
+		t.Sub(&c.inputs[i][1], &c.inputs[i][0])
+		t.Mul(&t, &last)
+		evals[i].Add(&c.inputs[i][0], &t)
+		c.inputs[i] = c.inputs[i][:1]
+		c.inputs[i][0] = evals[i]
+	}
+	return evals
+}
+
+// roundPolynomial returns the evaluations at 1, …, d of
+// g(X) = ∑_{x ∈ {0,1}ⁿ⁻¹} gate(P₁(X, x), …, Pₖ(X, x)).
+func (c *GateClaims) roundPolynomial() polynomial.Polynomial {
+	degree := c.gate.Degree()
+	mid := len(c.inputs[0]) / 2
+	res := make(polynomial.Polynomial, degree)
+
+	var lock sync.Mutex
+	parallel.Execute(mid, func(start, end int) {
+		partial := make([]fr.Element, degree)
+		x := make([]fr.Element, len(c.inputs))
+		step := make([]fr.Element, len(c.inputs))
+		for i := start; i < end; i++ {
+			// P(1, i) and the step P(t+1, i) - P(t, i)
+			for j := range c.inputs {
+				x[j] = c.inputs[j][mid+i]
+				step[j].Sub(&c.inputs[j][mid+i], &c.inputs[j][i])
+			}
+			for t := 0; t < degree; t++ {
+				if t != 0 {
+					for j := range x {
+						x[j].Add(&x[j], &step[j])
+					}
+				}
+				y := c.gate.Evaluate(x...)
+				partial[t].Add(&partial[t], &y)
+			}
+		}
+		lock.Lock()
+		for t := range res {
+			res[t].Add(&res[t], &partial[t])
+		}
+		lock.Unlock()
+	}, c.nbTasks)
+
+	return res
+}
+
+// InputsChecker checks that evals are the evaluations at r of the inputs of
+// a [GateLazyClaims], for instance by verifying polynomial commitment openings.
+type InputsChecker func(r []fr.Element, evals []fr.Element) error
+
+// GateLazyClaims is the verifier side of the claim proven by [GateClaims].
+type GateLazyClaims struct {
+	gate        Gate
+	nbVars      int
+	nbInputs    int
+	claimedSum  fr.Element
+	checkInputs InputsChecker
+}
+
+// NewGateLazyClaims returns the verifier side of the claim
+//
+//	∑_{x ∈ {0,1}ⁿ} gate(P₁(x), …, Pₖ(x)) = claimedSum
+//
+// with n = nbVars and k = nbInputs. The verifier does not need the Pᵢ: at the
+// end of the protocol the prover provides their evaluations at a random point,
+// which are checked with checkInputs.
+func NewGateLazyClaims(gate Gate, nbVars, nbInputs int, claimedSum fr.Element, checkInputs InputsChecker) *GateLazyClaims {
+	return &GateLazyClaims{
+		gate:        gate,
+		nbVars:      nbVars,
+		nbInputs:    nbInputs,
+		claimedSum:  claimedSum,
+		checkInputs: checkInputs,
+	}
+}
+
+// EvaluateInputs returns an [InputsChecker] for a verifier knowing the inputs.
+func EvaluateInputs(inputs ...polynomial.MultiLin) InputsChecker {
+	return func(r []fr.Element, evals []fr.Element) error {
+		if len(evals) != len(inputs) {
+			return errors.New("wrong number of evaluations")
+		}
+		for i := range inputs {
+			expected := inputs[i].Evaluate(r, nil)
+			if !expected.Equal(&evals[i]) {
+				return fmt.Errorf("input %d: evaluation mismatch", i)
+			}
+		}
+		return nil
+	}
+}
+
+func (c *GateLazyClaims) ClaimsNum() int {
+	return 1
+}
+
+func (c *GateLazyClaims) VarsNum() int {
+	return c.nbVars
+}
+
+func (c *GateLazyClaims) CombinedSum(fr.Element) fr.Element {
+	return c.claimedSum
+}
+
+func (c *GateLazyClaims) Degree(int) int {
+	return c.gate.Degree()
+}
+
+func (c *GateLazyClaims) VerifyFinalEval(r []fr.Element, _ fr.Element, purportedValue fr.Element, proof interface{}) error {
+	evals, ok := proof.([]fr.Element)
+	if !ok || len(evals) != c.nbInputs {
+		return errors.New("malformed final evaluation proof")
+	}
+	if y := c.gate.Evaluate(evals...); !y.Equal(&purportedValue) {
+		return errors.New("gate evaluation mismatch")
+	}
+	return c.checkInputs(r, evals)
+}
@@ -0,0 +1,345 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bn254
+
+import (
+	"crypto/subtle"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// ctWindow is the window size of the constant-time scalar multiplication
+const ctWindow = 4
+
+// g1Proj point in homogeneous projective coordinates (X:Y:Z), (x,y) = (X/Z,Y/Z).
+// The point at infinity is (0:1:0).
+//
+// It is only used with the complete formulas of the constant-time scalar
+// multiplication.
+type g1Proj struct {
+	x, y, z fp.Element
+}
+
+// setInfinity sets p to the point at infinity (0:1:0) and returns p
+func (p *g1Proj) setInfinity() *g1Proj {
+	p.x.SetZero()
+	p.y.SetOne()
+	p.z.SetZero()
+	return p
+}
+
+// fromAffine sets p = a and returns p.
+// a is not secret, so that we can branch on it being the point at infinity.
+func (p *g1Proj) fromAffine(a *G1Affine) *g1Proj {
+	if a.IsInfinity() {
+		return p.setInfinity()
+	}
+	p.x.Set(&a.X)
+	p.y.Set(&a.Y)
+	p.z.SetOne()
+	return p
+}
+
+// add sets p = q + r using the complete addition formulas for short Weierstrass
+// curves with a=0 (Renes, Costello and Batina, Algorithm 7) and returns p.
+// b3 is 3·b, where b is the curve coefficient.
+//
+// https://eprint.iacr.org/2015/1060.pdf
+func (p *g1Proj) add(q, r *g1Proj, b3 *fp.Element) *g1Proj {
+	var t0, t1, t2, t3, t4, x3, y3, z3 fp.Element
+
+	ctMul(&t0, &q.x, &r.x)
+	ctMul(&t1, &q.y, &r.y)
+	ctMul(&t2, &q.z, &r.z)
+	ctAdd(&t3, &q.x, &q.y)
+	ctAdd(&t4, &r.x, &r.y)
+	ctMul(&t3, &t3, &t4)
+	ctAdd(&t4, &t0, &t1)
+	ctSub(&t3, &t3, &t4)
+	ctAdd(&t4, &q.y, &q.z)
+	ctAdd(&x3, &r.y, &r.z)
+	ctMul(&t4, &t4, &x3)
+	ctAdd(&x3, &t1, &t2)
+	ctSub(&t4, &t4, &x3)
+	ctAdd(&x3, &q.x, &q.z)
+	ctAdd(&y3, &r.x, &r.z)
+	ctMul(&x3, &x3, &y3)
+	ctAdd(&y3, &t0, &t2)
+	ctSub(&y3, &x3, &y3)
+	ctAdd(&x3, &t0, &t0)
+	ctAdd(&t0, &x3, &t0)
+	ctMul(&t2, &t2, b3)
+	ctAdd(&z3, &t1, &t2)
+	ctSub(&t1, &t1, &t2)
+	ctMul(&y3, &y3, b3)
+	ctMul(&x3, &t4, &y3)
+	ctMul(&t2, &t3, &t1)
+	ctSub(&x3, &t2, &x3)
+	ctMul(&y3, &y3, &t0)
+	ctMul(&t1, &t1, &z3)
+	ctAdd(&y3, &t1, &y3)
+	ctMul(&t0, &t0, &t3)
+	ctMul(&z3, &z3, &t4)
+	ctAdd(&z3, &z3, &t0)
+
+	p.x.Set(&x3)
+	p.y.Set(&y3)
+	p.z.Set(&z3)
+	return p
+}
+
+// double sets p = [2]q using the complete doubling formulas for short
+// Weierstrass curves with a=0 (Renes, Costello and Batina, Algorithm 9) and
+// returns p. b3 is 3·b, where b is the curve coefficient.
+//
+// https://eprint.iacr.org/2015/1060.pdf
+func (p *g1Proj) double(q *g1Proj, b3 *fp.Element) *g1Proj {
+	var t0, t1, t2, x3, y3, z3 fp.Element
+
+	ctMul(&t0, &q.y, &q.y)
+	ctAdd(&z3, &t0, &t0)
+	ctAdd(&z3, &z3, &z3)
+	ctAdd(&z3, &z3, &z3)
+	ctMul(&t1, &q.y, &q.z)
+	ctMul(&t2, &q.z, &q.z)
+	ctMul(&t2, &t2, b3)
+	ctMul(&x3, &t2, &z3)
+	ctAdd(&y3, &t0, &t2)
+	ctMul(&z3, &t1, &z3)
+	ctAdd(&t1, &t2, &t2)
+	ctAdd(&t2, &t1, &t2)
+	ctSub(&t0, &t0, &t2)
+	ctMul(&y3, &t0, &y3)
+	ctAdd(&y3, &x3, &y3)
+	ctMul(&t1, &q.x, &q.y)
+	ctMul(&x3, &t0, &t1)
+	ctAdd(&x3, &x3, &x3)
+
+	p.x.Set(&x3)
+	p.y.Set(&y3)
+	p.z.Set(&z3)
+	return p
+}
+
+// selectFrom sets p = table[idx] and returns p, reading all the entries of
+// table so that the memory access pattern doesn't depend on idx.
+func (p *g1Proj) selectFrom(table []g1Proj, idx uint64) *g1Proj {
+	p.setInfinity()
+	for i := range table {
+		c := subtle.ConstantTimeEq(int32(i), int32(idx))
+		p.x.Select(c, &p.x, &table[i].x)
+		p.y.Select(c, &p.y, &table[i].y)
+		p.z.Select(c, &p.z, &table[i].z)
+	}
+	return p
+}
+
+// ScalarMultiplicationConstantTime computes and returns p = [s]a, where a must
+// be in the prime order subgroup (or be the point at infinity).
+//
+// Unlike ScalarMultiplication, the sequence of field operations and the memory
+// access pattern don't depend on the value of s (fixed ctWindow-bit windows
+// over all the fr.Bits bits, table lookups reading every entry, and complete
+// addition formulas with no exceptional case). The field arithmetic is the
+// branch-free one of ctField rather than the one of the fp package, whose
+// final reductions are conditional. It is meant for long-term secret scalars
+// and is noticeably slower than ScalarMultiplication.
+func (p *G1Affine) ScalarMultiplicationConstantTime(a *G1Affine, s *fr.Element) *G1Affine {
+	var b3 fp.Element
+	ctAdd(&b3, &bCurveCoeff, &bCurveCoeff)
+	ctAdd(&b3, &b3, &bCurveCoeff)
+
+	var table [1 << ctWindow]g1Proj
+	table[0].setInfinity()
+	table[1].fromAffine(a)
+	for i := 2; i < len(table); i++ {
+		table[i].add(&table[i-1], &table[1], &b3)
+	}
+
+	// k = s out of the Montgomery form, s⋅R⁻¹ = s⋅1⋅R⁻¹
+	var k, one fr.Element
+	one[0] = 1
+	ctFr.mul(k[:], s[:], one[:])
+	const nbWindows = (fr.Bits + ctWindow - 1) / ctWindow
+	const mask = 1<<ctWindow - 1
+
+	var res, tmp g1Proj
+	res.setInfinity()
+	for i := nbWindows - 1; i >= 0; i-- {
+		for j := 0; j < ctWindow; j++ {
+			res.double(&res, &b3)
+		}
+		// ctWindow divides 64, so that windows don't straddle limbs
+		bit := i * ctWindow
+		digit := (k[bit/64] >> (bit % 64)) & mask
+		tmp.selectFrom(table[:], digit)
+		res.add(&res, &tmp, &b3)
+	}
+
+	// z⁻¹ = z^(p-2) by square and multiply over the bits of the public exponent,
+	// rather than with the (variable-time) binary GCD of Inverse. This maps the
+	// point at infinity to (0,0).
+	var e big.Int
+	e.Sub(fp.Modulus(), big.NewInt(2))
+	var zInv fp.Element
+	zInv.SetOne()
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		ctMul(&zInv, &zInv, &zInv)
+		if e.Bit(i) == 1 {
+			ctMul(&zInv, &zInv, &res.z)
+		}
+	}
+	ctMul(&p.X, &res.x, &zInv)
+	ctMul(&p.Y, &res.y, &zInv)
+	return p
+}
+
+// ScalarMultiplicationBaseConstantTime computes and returns p = [s]g, where g
+// is the prime subgroup generator.
+//
+// See ScalarMultiplicationConstantTime.
+func (p *G1Affine) ScalarMultiplicationBaseConstantTime(s *fr.Element) *G1Affine {
+	return p.ScalarMultiplicationConstantTime(&g1GenAff, s)
+}
+
+// ctField holds an odd modulus q on at most fp.Limbs words and -q⁻¹ mod 2⁶⁴,
+// for the Montgomery arithmetic of the constant-time scalar multiplication.
+// Unlike the arithmetic of the fp and fr packages, the final reductions select
+// their result with masks instead of branching on it.
+type ctField struct {
+	q       []uint64
+	qInvNeg uint64
+}
+
+var (
+	ctFp = newCTField(fp.Modulus(), fp.Limbs)
+	ctFr = newCTField(fr.Modulus(), fr.Limbs)
+)
+
+func newCTField(q *big.Int, nbWords int) ctField {
+	m := ctField{q: make([]uint64, nbWords)}
+	words := q.Bits()
+	for i := range words {
+		m.q[i] = uint64(words[i])
+	}
+	// q⁻¹ mod 2⁶⁴ by Newton iteration, each step doubles the number of correct bits
+	inv := m.q[0]
+	for i := 0; i < 5; i++ {
+		inv *= 2 - m.q[0]*inv
+	}
+	m.qInvNeg = -inv
+	return m
+}
+
+// add sets z = x + y mod q, for x, y < q
+func (m *ctField) add(z, x, y []uint64) {
+	var s, r [fp.Limbs]uint64
+	var carry, borrow uint64
+	for i := range m.q {
+		s[i], carry = bits.Add64(x[i], y[i], carry)
+	}
+	for i := range m.q {
+		r[i], borrow = bits.Sub64(s[i], m.q[i], borrow)
+	}
+	// x + y < q iff the subtraction borrows from the carry
+	_, borrow = bits.Sub64(carry, 0, borrow)
+	m.selectWords(z, borrow, s[:], r[:])
+}
+
+// sub sets z = x - y mod q, for x, y < q
+func (m *ctField) sub(z, x, y []uint64) {
+	var borrow, carry uint64
+	for i := range m.q {
+		z[i], borrow = bits.Sub64(x[i], y[i], borrow)
+	}
+	// add q back if x < y
+	mask := -borrow
+	for i := range m.q {
+		z[i], carry = bits.Add64(z[i], m.q[i]&mask, carry)
+	}
+}
+
+// mul sets z = x⋅y⋅2⁻⁶⁴ⁿ mod q (Montgomery multiplication, CIOS), for x, y < q
+func (m *ctField) mul(z, x, y []uint64) {
+	n := len(m.q)
+	var t [fp.Limbs + 2]uint64
+	for i := 0; i < n; i++ {
+		// t += x⋅y[i]
+		var c, hi, lo, b uint64
+		for j := 0; j < n; j++ {
+			hi, lo = bits.Mul64(x[j], y[i])
+			lo, b = bits.Add64(lo, t[j], 0)
+			hi += b
+			lo, b = bits.Add64(lo, c, 0)
+			hi += b
+			t[j], c = lo, hi
+		}
+		t[n], b = bits.Add64(t[n], c, 0)
+		t[n+1] = b
+
+		// t = (t + k⋅q) / 2⁶⁴, where k makes the division exact
+		k := t[0] * m.qInvNeg
+		hi, lo = bits.Mul64(k, m.q[0])
+		_, b = bits.Add64(lo, t[0], 0)
+		c = hi + b
+		for j := 1; j < n; j++ {
+			hi, lo = bits.Mul64(k, m.q[j])
+			lo, b = bits.Add64(lo, t[j], 0)
+			hi += b
+			lo, b = bits.Add64(lo, c, 0)
+			hi += b
+			t[j-1], c = lo, hi
+		}
+		t[n-1], b = bits.Add64(t[n], c, 0)
+		t[n] = t[n+1] + b
+	}
+
+	// t < 2q
+	var r [fp.Limbs]uint64
+	var borrow uint64
+	for i := 0; i < n; i++ {
+		r[i], borrow = bits.Sub64(t[i], m.q[i], borrow)
+	}
+	_, borrow = bits.Sub64(t[n], 0, borrow)
+	m.selectWords(z, borrow, t[:n], r[:n])
+}
+
+// selectWords sets z = a if c == 1, z = b if c == 0
+func (m *ctField) selectWords(z []uint64, c uint64, a, b []uint64) {
+	mask := -c
+	for i := range m.q {
+		z[i] = b[i] ^ (mask & (a[i] ^ b[i]))
+	}
+}
+
+// ctAdd sets z = x + y in fp, see ctField
+func ctAdd(z, x, y *fp.Element) {
+	ctFp.add(z[:], x[:], y[:])
+}
+
+// ctSub sets z = x - y in fp, see ctField
+func ctSub(z, x, y *fp.Element) {
+	ctFp.sub(z[:], x[:], y[:])
+}
+
+// ctMul sets z = x⋅y in fp, see ctField
+func ctMul(z, x, y *fp.Element) {
+	ctFp.mul(z[:], x[:], y[:])
+}
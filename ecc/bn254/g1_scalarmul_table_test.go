@@ -0,0 +1,128 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bn254
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestG1ScalarMulTable(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[BN254] G1ScalarMulTable.Mul should output the same result as ScalarMultiplication", prop.ForAll(
+		func(s, u fr.Element) bool {
+			var sInt, uInt big.Int
+			s.BigInt(&sInt)
+			u.BigInt(&uInt)
+
+			var a, expected G1Affine
+			a.ScalarMultiplication(&g1GenAff, &uInt)
+			expected.ScalarMultiplication(&a, &sInt)
+
+			var expectedNeg G1Affine
+			expectedNeg.Neg(&expected)
+			var sNeg big.Int
+			sNeg.Neg(&sInt)
+
+			for _, w := range []int{2, 4, 5, 8} {
+				table, err := a.NewScalarMulTable(w)
+				if err != nil {
+					return false
+				}
+				res := table.Mul(&sInt)
+				resNeg := table.Mul(&sNeg)
+				if !res.Equal(&expected) || !resNeg.Equal(&expectedNeg) {
+					return false
+				}
+			}
+			return true
+		},
+		GenFr(),
+		GenFr(),
+	))
+
+	properties.Property("[BN254] G1ScalarMulTable edge cases", prop.ForAll(
+		func(s fr.Element) bool {
+			var sInt big.Int
+			s.BigInt(&sInt)
+
+			table, err := g1GenAff.NewScalarMulTable(5)
+			if err != nil {
+				return false
+			}
+			zero := table.Mul(big.NewInt(0))
+			one := table.Mul(big.NewInt(1))
+			// s + r
+			sInt.Add(&sInt, fr.Modulus())
+			var expected G1Affine
+			expected.ScalarMultiplication(&g1GenAff, &sInt)
+			res := table.Mul(&sInt)
+
+			var inf G1Affine
+			infTable, err := inf.NewScalarMulTable(3)
+			if err != nil {
+				return false
+			}
+			resInf := infTable.Mul(&sInt)
+
+			_, errSmall := g1GenAff.NewScalarMulTable(1)
+			_, errLarge := g1GenAff.NewScalarMulTable(17)
+
+			return zero.IsInfinity() && one.Equal(&g1GenAff) && res.Equal(&expected) &&
+				resInf.IsInfinity() && errSmall != nil && errLarge != nil
+		},
+		GenFr(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func BenchmarkG1ScalarMulTable(b *testing.B) {
+	var s fr.Element
+	s.SetRandom()
+	var sInt big.Int
+	s.BigInt(&sInt)
+
+	var p G1Affine
+	b.Run("ScalarMultiplication", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p.ScalarMultiplication(&g1GenAff, &sInt)
+		}
+	})
+	for _, w := range []int{4, 6, 8} {
+		table, _ := g1GenAff.NewScalarMulTable(w)
+		b.Run(fmt.Sprintf("table/w=%d", w), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				p = table.Mul(&sInt)
+			}
+		})
+	}
+}
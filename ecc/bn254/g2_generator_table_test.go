@@ -0,0 +1,155 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bn254
+
+import (
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestG2GeneratorTable(t *testing.T) {
+	const path = "g2_generator_table.bin"
+
+	table := computeG2GeneratorTable()
+	if *updateGeneratorTables {
+		data := make([]byte, 0, len(table)*SizeOfG2AffineUncompressed)
+		for i := range table {
+			b := table[i].RawBytes()
+			data = append(data, b[:]...)
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the embedded table must match the generator
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := decodeG2GeneratorTable(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range table {
+		if !decoded[i].Equal(&table[i]) {
+			t.Fatalf("g2GeneratorTable differs from the generator multiples at %d, regenerate it with -update-generator-tables", i)
+		}
+	}
+	if _, err := decodeG2GeneratorTable(data[1:]); err == nil {
+		t.Fatal("decoding a truncated table should fail")
+	}
+
+	defer func(saved []G2Affine) {
+		g2GeneratorTable = saved
+	}(g2GeneratorTable)
+	g2GeneratorTable = decoded
+
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[BN254] ScalarMultiplicationBase with the generator table should match the GLV method", prop.ForAll(
+		func(s fr.Element) bool {
+			var sInt, sNeg big.Int
+			s.BigInt(&sInt)
+			sNeg.Neg(&sInt)
+
+			for _, k := range []*big.Int{&sInt, &sNeg} {
+				var expected, res G2Jac
+				expected.mulGLV(&g2Gen, k)
+				res.ScalarMultiplicationBase(k)
+				if !res.Equal(&expected) {
+					return false
+				}
+				var expectedAff, resAff G2Affine
+				expectedAff.FromJacobian(&expected)
+				resAff.ScalarMultiplicationBase(k)
+				if !resAff.Equal(&expectedAff) {
+					return false
+				}
+			}
+			return true
+		},
+		GenFr(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// edge cases
+	r := fr.Modulus()
+	for _, s := range []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(generatorTableHalf),
+		big.NewInt(generatorTableHalf + 1),
+		new(big.Int).Sub(r, big.NewInt(1)),
+		r,
+		new(big.Int).Add(r, big.NewInt(1)),
+		new(big.Int).Lsh(big.NewInt(1), fr.Bits),
+	} {
+		var expected, res G2Jac
+		expected.mulGLV(&g2Gen, s)
+		res.mulGeneratorTable(s)
+		if !res.Equal(&expected) {
+			t.Fatalf("mulGeneratorTable(%s) differs from the GLV method", s)
+		}
+	}
+}
+
+func BenchmarkG2GeneratorTable(b *testing.B) {
+	data, err := os.ReadFile("g2_generator_table.bin")
+	if err != nil {
+		b.Fatal(err)
+	}
+	table, err := decodeG2GeneratorTable(data)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var s fr.Element
+	s.SetRandom()
+	var sInt big.Int
+	s.BigInt(&sInt)
+
+	var p G2Jac
+	b.Run("GLV", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p.mulGLV(&g2Gen, &sInt)
+		}
+	})
+	b.Run("table", func(b *testing.B) {
+		defer func(saved []G2Affine) {
+			g2GeneratorTable = saved
+		}(g2GeneratorTable)
+		g2GeneratorTable = table
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			p.mulGeneratorTable(&sInt)
+		}
+	})
+}
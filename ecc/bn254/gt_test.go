@@ -0,0 +1,224 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bn254
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestGTCompression(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := GenE12()
+
+	properties.Property("[BN254] SetBytesCompressed(BytesCompressed()) should stay constant in GT", prop.ForAll(
+		func(a GT) bool {
+			a = FinalExponentiation(&a)
+			buf, err := a.BytesCompressed()
+			if err != nil {
+				return false
+			}
+			var b GT
+			if err := b.SetBytesCompressed(buf[:]); err != nil {
+				return false
+			}
+			return a.Equal(&b)
+		},
+		genA,
+	))
+
+	properties.Property("[BN254] BatchDecompressGT(BatchCompressGT()) should stay constant in GT", prop.ForAll(
+		func(a, b GT) bool {
+			a = FinalExponentiation(&a)
+			b = FinalExponentiation(&b)
+			var one GT
+			one.SetOne()
+			elements := []GT{a, one, b}
+
+			buf, err := BatchCompressGT(elements)
+			if err != nil || len(buf) != len(elements)*SizeOfGTCompressed {
+				return false
+			}
+			for i := range elements {
+				single, err := elements[i].BytesCompressed()
+				if err != nil || string(single[:]) != string(buf[i*SizeOfGTCompressed:(i+1)*SizeOfGTCompressed]) {
+					return false
+				}
+			}
+			decoded, err := BatchDecompressGT(buf)
+			if err != nil || len(decoded) != len(elements) {
+				return false
+			}
+			for i := range elements {
+				if !decoded[i].Equal(&elements[i]) {
+					return false
+				}
+			}
+			return true
+		},
+		genA,
+		genA,
+	))
+
+	properties.Property("[BN254] BatchInvertGT should be consistent with Inverse", prop.ForAll(
+		func(a, b GT) bool {
+			inv := BatchInvertGT([]GT{a, b})
+			var c, d GT
+			c.Inverse(&a)
+			d.Inverse(&b)
+			return inv[0].Equal(&c) && inv[1].Equal(&d)
+		},
+		genA,
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestGTExpFixedBase(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := GenE12()
+	genR := GenFr()
+
+	properties.Property("[BN254] ExpFixedBase should be consistent with Exp", prop.ForAll(
+		func(a GT, e fr.Element) bool {
+			a = FinalExponentiation(&a)
+			table := NewGTFixedBaseTable(&a)
+
+			var k big.Int
+			e.BigInt(&k)
+			exponents := []*big.Int{
+				&k,
+				new(big.Int).Neg(&k),
+				new(big.Int).Add(&k, fr.Modulus()),
+				new(big.Int).Sub(fr.Modulus(), big.NewInt(1)),
+				big.NewInt(0),
+				big.NewInt(1),
+			}
+			for _, k := range exponents {
+				var expected, actual GT
+				expected.Exp(a, k)
+				actual.ExpFixedBase(table, k)
+				if !expected.Equal(&actual) {
+					return false
+				}
+			}
+			return true
+		},
+		genA,
+		genR,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestGTCompressionIdentity(t *testing.T) {
+	var one, b GT
+	one.SetOne()
+	buf, err := one.BytesCompressed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf != [SizeOfGTCompressed]byte{} {
+		t.Fatal("identity should be encoded as zero")
+	}
+	if err := b.SetBytesCompressed(buf[:]); err != nil {
+		t.Fatal(err)
+	}
+	if !b.IsOne() {
+		t.Fatal("zero should decode to the identity")
+	}
+
+	// -1 is not in GT
+	var minusOne GT
+	minusOne.SetOne()
+	minusOne.C0.Neg(&minusOne.C0)
+	if _, err := minusOne.BytesCompressed(); err == nil {
+		t.Fatal("-1 should not be compressible")
+	}
+}
+
+func BenchmarkGTCompression(b *testing.B) {
+	var a GT
+	a.SetRandom()
+	a = FinalExponentiation(&a)
+	buf, _ := a.BytesCompressed()
+
+	b.Run("compress", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = a.BytesCompressed()
+		}
+	})
+	b.Run("decompress", func(b *testing.B) {
+		var c GT
+		for i := 0; i < b.N; i++ {
+			_ = c.SetBytesCompressed(buf[:])
+		}
+	})
+}
+
+func BenchmarkGTExpFixedBase(b *testing.B) {
+	var a GT
+	a.SetRandom()
+	a = FinalExponentiation(&a)
+	var e fr.Element
+	e.SetRandom()
+	var k big.Int
+	e.BigInt(&k)
+
+	b.Run("table", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = NewGTFixedBaseTable(&a)
+		}
+	})
+	table := NewGTFixedBaseTable(&a)
+	b.Run("fixed base", func(b *testing.B) {
+		var c GT
+		for i := 0; i < b.N; i++ {
+			c.ExpFixedBase(table, &k)
+		}
+	})
+	b.Run("GLV", func(b *testing.B) {
+		var c GT
+		for i := 0; i < b.N; i++ {
+			c.ExpGLV(a, &k)
+		}
+	})
+}
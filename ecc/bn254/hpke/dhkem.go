@@ -0,0 +1,133 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package hpke
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/hpke"
+)
+
+// KEMID is the identifier of DHKEM(bn254 G1, HKDF-SHA256). It is not registered
+// with IANA: both parties must use this implementation.
+const KEMID = 0xff54
+
+const (
+	sizeScalar = fr.Bytes
+	sizePoint  = 1 + 2*fp.Bytes
+)
+
+// Group is the G1 group of bn254, on which the DHKEM is built. Secret keys are
+// scalars of fr.Bytes bytes in big endian, public keys are uncompressed SEC 1 encodings
+// of points, 0x04 ‖ x ‖ y, and the shared secret is the x coordinate of the shared point.
+type Group struct{}
+
+// NewKEM returns the DHKEM on the G1 group of bn254, with HKDF-SHA256.
+func NewKEM() *hpke.DHKEM {
+	return hpke.NewDHKEM(Group{}, hpke.HKDFSHA256)
+}
+
+// KEMID implements hpke.DHGroup.
+func (Group) KEMID() uint16 {
+	return KEMID
+}
+
+// PrivateKeySize implements hpke.DHGroup.
+func (Group) PrivateKeySize() int {
+	return sizeScalar
+}
+
+// PublicKeySize implements hpke.DHGroup.
+func (Group) PublicKeySize() int {
+	return sizePoint
+}
+
+// Bitmask implements hpke.DHGroup.
+func (Group) Bitmask() byte {
+	// the order of G1 has 254 bits
+	return 0x3f
+}
+
+// PublicKey implements hpke.DHGroup.
+func (Group) PublicKey(sk []byte) ([]byte, error) {
+	s, err := parseScalar(sk)
+	if err != nil {
+		return nil, err
+	}
+	var P bn254.G1Affine
+	P.ScalarMultiplicationBase(s)
+	return marshalPoint(&P), nil
+}
+
+// DH implements hpke.DHGroup.
+func (Group) DH(sk, pk []byte) ([]byte, error) {
+	s, err := parseScalar(sk)
+	if err != nil {
+		return nil, err
+	}
+	P, err := parsePoint(pk)
+	if err != nil {
+		return nil, err
+	}
+	P.ScalarMultiplication(P, s)
+	if P.IsInfinity() {
+		return nil, hpke.ErrInvalidSharedPoint
+	}
+	x := P.X.Bytes()
+	return x[:], nil
+}
+
+// parseScalar returns the scalar encoded in sk, which must be in [1, r-1].
+func parseScalar(sk []byte) (*big.Int, error) {
+	if len(sk) != sizeScalar {
+		return nil, hpke.ErrInvalidPrivateKey
+	}
+	s := new(big.Int).SetBytes(sk)
+	if s.Sign() == 0 || s.Cmp(fr.Modulus()) >= 0 {
+		return nil, hpke.ErrInvalidPrivateKey
+	}
+	return s, nil
+}
+
+// parsePoint returns the point of G1 encoded in pk, which must not be the infinity.
+func parsePoint(pk []byte) (*bn254.G1Affine, error) {
+	if len(pk) != sizePoint || pk[0] != 0x04 {
+		return nil, hpke.ErrInvalidPublicKey
+	}
+	var P bn254.G1Affine
+	if err := P.X.SetBytesCanonical(pk[1 : 1+fp.Bytes]); err != nil {
+		return nil, hpke.ErrInvalidPublicKey
+	}
+	if err := P.Y.SetBytesCanonical(pk[1+fp.Bytes:]); err != nil {
+		return nil, hpke.ErrInvalidPublicKey
+	}
+	if P.IsInfinity() || !P.IsOnCurve() || !P.IsInSubGroup() {
+		return nil, hpke.ErrInvalidPublicKey
+	}
+	return &P, nil
+}
+
+func marshalPoint(P *bn254.G1Affine) []byte {
+	res := make([]byte, sizePoint)
+	res[0] = 0x04
+	fp.BigEndian.PutElement((*[fp.Bytes]byte)(res[1:1+fp.Bytes]), P.X)
+	fp.BigEndian.PutElement((*[fp.Bytes]byte)(res[1+fp.Bytes:]), P.Y)
+	return res
+}
@@ -636,6 +636,69 @@ func (z *E12) ExpGLV(x E12, k *big.Int) *E12 {
 	return z
 }
 
+const (
+	fixedBaseWindow    = 5
+	nbFixedBaseWindows = fr.Bits/fixedBaseWindow + 1
+)
+
+// FixedBaseTable holds precomputed powers of a fixed element of GT,
+// for use with ExpFixedBase.
+type FixedBaseTable struct {
+	// table[i][j] = base^((j+1)·2^(fixedBaseWindow·i))
+	table [nbFixedBaseWindows][1 << (fixedBaseWindow - 1)]E12
+}
+
+// NewFixedBaseTable precomputes the powers of base needed by ExpFixedBase.
+// base must be in GT.
+func NewFixedBaseTable(base *E12) *FixedBaseTable {
+	t := new(FixedBaseTable)
+	var b E12
+	b.Set(base)
+	for i := range t.table {
+		t.table[i][0].Set(&b)
+		for j := 1; j < len(t.table[i]); j++ {
+			t.table[i][j].Mul(&t.table[i][j-1], &b)
+		}
+		for j := 0; j < fixedBaseWindow; j++ {
+			b.CyclotomicSquare(&b)
+		}
+	}
+	return t
+}
+
+// ExpFixedBase sets z=baseᵏ and returns it, where base is the element t was built from.
+// It uses signed fixed windows over the precomputed table and performs no squaring.
+// k is reduced modulo r, the order of GT.
+func (z *E12) ExpFixedBase(t *FixedBaseTable, k *big.Int) *E12 {
+	e := bigIntPool.Get().(*big.Int)
+	defer bigIntPool.Put(e)
+	e.Mod(k, fr.Modulus())
+
+	const mask = 1<<fixedBaseWindow - 1
+	var res, tmp E12
+	res.SetOne()
+	carry := 0
+	for i := 0; i < nbFixedBaseWindows; i++ {
+		digit := carry
+		for j := 0; j < fixedBaseWindow; j++ {
+			digit += int(e.Bit(i*fixedBaseWindow+j)) << j
+		}
+		// recode the digit in [-2^(w-1), 2^(w-1)]
+		carry = 0
+		if digit > mask>>1+1 {
+			digit -= mask + 1
+			carry = 1
+		}
+		if digit > 0 {
+			res.Mul(&res, &t.table[i][digit-1])
+		} else if digit < 0 {
+			tmp.Conjugate(&t.table[i][-digit-1])
+			res.Mul(&res, &tmp)
+		}
+	}
+	return z.Set(&res)
+}
+
 // InverseUnitary inverses a unitary element
 func (z *E12) InverseUnitary(x *E12) *E12 {
 	return z.Conjugate(x)
@@ -648,6 +711,29 @@ func (z *E12) Conjugate(x *E12) *E12 {
 	return z
 }
 
+// FrobeniusPower sets z to x^(p^k) and returns z.
+// k can be any integer, as the Frobenius map has order 12.
+func (z *E12) FrobeniusPower(x *E12, k int) *E12 {
+	k %= 12
+	if k < 0 {
+		k += 12
+	}
+	var r E12
+	r.Set(x)
+	// x^(p⁶) is the conjugate of x over E6
+	if k >= 6 {
+		r.Conjugate(&r)
+		k -= 6
+	}
+	for ; k >= 2; k -= 2 {
+		r.FrobeniusSquare(&r)
+	}
+	if k == 1 {
+		r.Frobenius(&r)
+	}
+	return z.Set(&r)
+}
+
 // SizeOfGT represents the size in bytes that a GT element need in binary form
 const SizeOfGT = 32 * 12
 
@@ -732,6 +818,10 @@ func (z *E12) SetBytes(e []byte) error {
 
 // IsInSubGroup ensures GT/E12 is in correct subgroup
 func (z *E12) IsInSubGroup() bool {
+	// the checks below are only valid in the cyclotomic subgroup
+	if !z.isInCyclotomicSubGroup() {
+		return false
+	}
 	var a, b, _b E12
 
 	a.Frobenius(z)
@@ -842,6 +932,165 @@ func BatchDecompressTorus(x []E6) ([]E12, error) {
 	return res, nil
 }
 
+// SizeOfGTCompressed represents the size in bytes that a torus-compressed GT element needs in binary form
+const SizeOfGTCompressed = SizeOfGT / 2
+
+// BytesCompressed returns the torus-compressed encoding of z (see CompressTorus)
+// as a big-endian byte array: y.B2.A1 | y.B2.A0 | y.B1.A1 | ...
+//
+// z must be in GT. The identity, which has no torus representation, is encoded as 0.
+func (z *E12) BytesCompressed() ([SizeOfGTCompressed]byte, error) {
+	if z.IsOne() {
+		return [SizeOfGTCompressed]byte{}, nil
+	}
+	y, err := z.CompressTorus()
+	if err != nil {
+		return [SizeOfGTCompressed]byte{}, err
+	}
+	return y.bytes(), nil
+}
+
+// SetBytesCompressed interprets e as a torus-compressed GT element, as encoded by
+// BytesCompressed, and sets z to the decompressed value.
+// It returns an error if the decompressed value is not in GT.
+func (z *E12) SetBytesCompressed(e []byte) error {
+	var y E6
+	if err := y.setBytes(e); err != nil {
+		return err
+	}
+	if y.IsZero() {
+		z.SetOne()
+		return nil
+	}
+	r := y.DecompressTorus()
+	if !r.IsInSubGroup() {
+		return errors.New("invalid compressed GT element: not in subgroup")
+	}
+	*z = r
+	return nil
+}
+
+// BatchBytesCompressed returns the concatenated torus-compressed encodings of x
+// (see BytesCompressed), using a single inversion.
+func BatchBytesCompressed(x []E12) ([]byte, error) {
+	res := make([]byte, len(x)*SizeOfGTCompressed)
+
+	// the identity is encoded as 0 and is not compressed
+	toCompress := make([]E12, 0, len(x))
+	indexes := make([]int, 0, len(x))
+	for i := range x {
+		if !x[i].IsOne() {
+			toCompress = append(toCompress, x[i])
+			indexes = append(indexes, i)
+		}
+	}
+	if len(toCompress) == 0 {
+		return res, nil
+	}
+
+	y, err := BatchCompressTorus(toCompress)
+	if err != nil {
+		return nil, err
+	}
+	for j, i := range indexes {
+		b := y[j].bytes()
+		copy(res[i*SizeOfGTCompressed:], b[:])
+	}
+	return res, nil
+}
+
+// BatchSetBytesCompressed decodes the concatenated torus-compressed GT elements in e
+// (see SetBytesCompressed), using a single inversion.
+// It returns an error if any of the decompressed values is not in GT.
+func BatchSetBytesCompressed(e []byte) ([]E12, error) {
+	if len(e)%SizeOfGTCompressed != 0 {
+		return nil, errors.New("invalid buffer size")
+	}
+	n := len(e) / SizeOfGTCompressed
+	res := make([]E12, n)
+
+	y := make([]E6, 0, n)
+	indexes := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		var t E6
+		if err := t.setBytes(e[i*SizeOfGTCompressed : (i+1)*SizeOfGTCompressed]); err != nil {
+			return nil, err
+		}
+		if t.IsZero() {
+			res[i].SetOne()
+			continue
+		}
+		y = append(y, t)
+		indexes = append(indexes, i)
+	}
+	if len(y) == 0 {
+		return res, nil
+	}
+
+	r, err := BatchDecompressTorus(y)
+	if err != nil {
+		return nil, err
+	}
+	for j, i := range indexes {
+		if !r[j].IsInSubGroup() {
+			return nil, errors.New("invalid compressed GT element: not in subgroup")
+		}
+		res[i] = r[j]
+	}
+	return res, nil
+}
+
+// isInCyclotomicSubGroup returns true if z^(p⁴-p²+1) == 1
+func (z *E12) isInCyclotomicSubGroup() bool {
+	var a, b E12
+	a.FrobeniusSquare(z)
+	b.FrobeniusSquare(&a).Mul(&b, z)
+	return a.Equal(&b)
+}
+
+// bytes returns the regular (non montgomery) value
+// of z as a big-endian byte array.
+// z.B2.A1 | z.B2.A0 | z.B1.A1 | ...
+func (z *E6) bytes() (r [SizeOfGTCompressed]byte) {
+	fp.BigEndian.PutElement((*[fp.Bytes]byte)(r[160:160+fp.Bytes]), z.B0.A0)
+	fp.BigEndian.PutElement((*[fp.Bytes]byte)(r[128:128+fp.Bytes]), z.B0.A1)
+	fp.BigEndian.PutElement((*[fp.Bytes]byte)(r[96:96+fp.Bytes]), z.B1.A0)
+	fp.BigEndian.PutElement((*[fp.Bytes]byte)(r[64:64+fp.Bytes]), z.B1.A1)
+	fp.BigEndian.PutElement((*[fp.Bytes]byte)(r[32:32+fp.Bytes]), z.B2.A0)
+	fp.BigEndian.PutElement((*[fp.Bytes]byte)(r[0:0+fp.Bytes]), z.B2.A1)
+
+	return
+}
+
+// setBytes interprets e as the bytes of a big-endian E6
+// and sets z to that value (in Montgomery form).
+// z.B2.A1 | z.B2.A0 | z.B1.A1 | ...
+func (z *E6) setBytes(e []byte) error {
+	if len(e) != SizeOfGTCompressed {
+		return errors.New("invalid buffer size")
+	}
+	if err := z.B0.A0.SetBytesCanonical(e[160 : 160+fp.Bytes]); err != nil {
+		return err
+	}
+	if err := z.B0.A1.SetBytesCanonical(e[128 : 128+fp.Bytes]); err != nil {
+		return err
+	}
+	if err := z.B1.A0.SetBytesCanonical(e[96 : 96+fp.Bytes]); err != nil {
+		return err
+	}
+	if err := z.B1.A1.SetBytesCanonical(e[64 : 64+fp.Bytes]); err != nil {
+		return err
+	}
+	if err := z.B2.A0.SetBytesCanonical(e[32 : 32+fp.Bytes]); err != nil {
+		return err
+	}
+	if err := z.B2.A1.SetBytesCanonical(e[0 : 0+fp.Bytes]); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // Select is conditional move.
 // If cond = 0, it sets z to caseZ and returns it. otherwise caseNz.
 func (z *E12) Select(cond int, caseZ *E12, caseNz *E12) *E12 {
@@ -22,6 +22,7 @@ import (
 
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
 	"github.com/leanovate/gopter/prop"
 )
 
@@ -289,6 +290,51 @@ func TestE12Ops(t *testing.T) {
 		genA,
 	))
 
+	properties.Property("[BN254] FrobeniusPower(k) should be consistent with k applications of Frobenius", prop.ForAll(
+		func(a *E12, k int) bool {
+			var b, c E12
+			b.FrobeniusPower(a, k)
+			c.Set(a)
+			for i := 0; i < ((k%12)+12)%12; i++ {
+				c.Frobenius(&c)
+			}
+			return b.Equal(&c)
+		},
+		genA,
+		gen.IntRange(-24, 24),
+	))
+
+	properties.Property("[BN254] FrobeniusPower(1) should equal x^p", prop.ForAll(
+		func(a *E12) bool {
+			var b, c E12
+			b.FrobeniusPower(a, 1)
+			c.Exp(*a, fp.Modulus())
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.Property("[BN254] SetBytesCompressed should reject cyclotomic elements not in GT", prop.ForAll(
+		func(a *E12) bool {
+			var b E12
+			b.Conjugate(a)
+			a.Inverse(a)
+			b.Mul(&b, a)
+			a.FrobeniusSquare(&b).Mul(a, &b)
+
+			if a.IsInSubGroup() {
+				return true
+			}
+			buf, err := a.BytesCompressed()
+			if err != nil {
+				return false
+			}
+			var c E12
+			return c.SetBytesCompressed(buf[:]) != nil
+		},
+		genA,
+	))
+
 	properties.Property("[BN254] pi**12=id", prop.ForAll(
 		func(a *E12) bool {
 			var b E12
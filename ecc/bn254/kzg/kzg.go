@@ -18,6 +18,7 @@ package kzg
 
 import (
 	"errors"
+	"fmt"
 	"hash"
 	"math/big"
 	"sync"
@@ -25,6 +26,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
 	"github.com/consensys/gnark-crypto/fiat-shamir"
 
 	"github.com/consensys/gnark-crypto/internal/parallel"
@@ -37,6 +39,7 @@ var (
 	ErrVerifyOpeningProof            = errors.New("can't verify opening proof")
 	ErrVerifyBatchOpeningSinglePoint = errors.New("can't verify batch opening proof at single point")
 	ErrMinSRSSize                    = errors.New("minimum srs size is 2")
+	ErrInvalidSRSSize                = errors.New("invalid srs size")
 )
 
 // Digest commitment of a polynomial.
@@ -143,6 +146,48 @@ func NewSRS(size uint64, bAlpha *big.Int) (*SRS, error) {
 	return &srs, nil
 }
 
+// Truncate returns the proving key for polynomials of size at most n, made of the n
+// first points of pk. The points are copied, so that the result can be serialized and
+// shipped independently of pk (e.g. the full output of a ceremony).
+func (pk *ProvingKey) Truncate(n int) (ProvingKey, error) {
+	if n < 2 || n > len(pk.G1) {
+		return ProvingKey{}, fmt.Errorf("%w: can't truncate a proving key of size %d to %d", ErrInvalidSRSSize, len(pk.G1), n)
+	}
+	var res ProvingKey
+	res.G1 = make([]bn254.G1Affine, n)
+	copy(res.G1, pk.G1[:n])
+	return res, nil
+}
+
+// ExtractLagrange returns the proving key in Lagrange form for domain: its i-th point
+// is [Lᵢ(α)]G₁, where Lᵢ is the i-th Lagrange polynomial of the subgroup {ωⁱ} of
+// size domain.Cardinality (the coset shift of the domain is ignored).
+//
+// Committing with the result to the evaluations of a polynomial on the subgroup gives
+// the same digest as committing with pk to its coefficients. The result can't be used
+// to open commitments.
+func (pk *ProvingKey) ExtractLagrange(domain *fft.Domain) (ProvingKey, error) {
+	n := int(domain.Cardinality)
+	if n > len(pk.G1) {
+		return ProvingKey{}, fmt.Errorf("%w: domain of size %d larger than the proving key (%d)", ErrInvalidSRSSize, n, len(pk.G1))
+	}
+	lagrange, err := ToLagrangeG1(pk.G1[:n])
+	if err != nil {
+		return ProvingKey{}, err
+	}
+	return ProvingKey{G1: lagrange}, nil
+}
+
+// Truncate returns the SRS for polynomials of size at most n, see ProvingKey.Truncate.
+// The verifying key doesn't depend on the size and is copied as is.
+func (srs *SRS) Truncate(n int) (*SRS, error) {
+	pk, err := srs.Pk.Truncate(n)
+	if err != nil {
+		return nil, err
+	}
+	return &SRS{Pk: pk, Vk: srs.Vk}, nil
+}
+
 // OpeningProof KZG proof for opening at a single point.
 //
 // implements io.ReaderFrom and io.WriterTo
@@ -175,6 +220,19 @@ func Commit(p []fr.Element, pk ProvingKey, nbTasks ...int) (Digest, error) {
 
 	var res bn254.G1Affine
 
+	if len(p) == 1 {
+		// constant polynomial: a single scalar multiplication, by the generator
+		// (which may use precomputed tables) unless the SRS is unusual.
+		var c big.Int
+		p[0].BigInt(&c)
+		if _, _, gen1Aff, _ := bn254.Generators(); pk.G1[0].Equal(&gen1Aff) {
+			res.ScalarMultiplicationBase(&c)
+		} else {
+			res.ScalarMultiplication(&pk.G1[0], &c)
+		}
+		return res, nil
+	}
+
 	config := ecc.MultiExpConfig{}
 	if len(nbTasks) > 0 {
 		config.NbTasks = nbTasks[0]
@@ -186,6 +244,45 @@ func Commit(p []fr.Element, pk ProvingKey, nbTasks ...int) (Digest, error) {
 	return res, nil
 }
 
+// CommitSparse commits to the polynomial ∑ᵢ values[i]·X^{indices[i]}, like Commit does to
+// its dense coefficients, with a multi exponentiation over the points of the SRS at
+// indices only. Repeated indices add up. The commitment to the zero polynomial (no
+// indices) is the point at infinity.
+func CommitSparse(indices []uint64, values []fr.Element, pk ProvingKey, nbTasks ...int) (Digest, error) {
+	if len(indices) != len(values) {
+		return Digest{}, fmt.Errorf("%w: %d indices for %d values", ErrInvalidPolynomialSize, len(indices), len(values))
+	}
+
+	var res bn254.G1Affine
+	if len(indices) == 0 {
+		return res, nil
+	}
+
+	points := make([]bn254.G1Affine, len(indices))
+	for i, idx := range indices {
+		if idx >= uint64(len(pk.G1)) {
+			return Digest{}, fmt.Errorf("%w: index %d, SRS of size %d", ErrInvalidPolynomialSize, idx, len(pk.G1))
+		}
+		points[i] = pk.G1[idx]
+	}
+
+	if len(indices) == 1 {
+		var c big.Int
+		values[0].BigInt(&c)
+		res.ScalarMultiplication(&points[0], &c)
+		return res, nil
+	}
+
+	config := ecc.MultiExpConfig{}
+	if len(nbTasks) > 0 {
+		config.NbTasks = nbTasks[0]
+	}
+	if _, err := res.MultiExp(points, values, config); err != nil {
+		return Digest{}, err
+	}
+	return res, nil
+}
+
 // Open computes an opening proof of polynomial p at given point.
 // fft.Domain Cardinality must be larger than p.Degree()
 func Open(p []fr.Element, point fr.Element, pk ProvingKey) (OpeningProof, error) {
@@ -254,7 +351,14 @@ func Verify(commitment *Digest, proof *OpeningProof, point fr.Element, vk Verify
 // * digests is the list of committed polynomials to open, need to derive the challenge using Fiat Shamir.
 // * polynomials is the list of polynomials to open, they are supposed to be of the same size.
 // * dataTranscript extra data that might be needed to derive the challenge used for folding
+//
+// To bind the proof to a unique instance, pass fiatshamir.InstanceHash(hf, instanceID) as hf;
+// the verifier must then do the same.
 func BatchOpenSinglePoint(polynomials [][]fr.Element, digests []Digest, point fr.Element, hf hash.Hash, pk ProvingKey, dataTranscript ...[]byte) (BatchOpeningProof, error) {
+	return batchOpenSinglePoint(polynomials, digests, point, hf, pk, 0, dataTranscript...)
+}
+
+func batchOpenSinglePoint(polynomials [][]fr.Element, digests []Digest, point fr.Element, hf hash.Hash, pk ProvingKey, nbTasks int, dataTranscript ...[]byte) (BatchOpeningProof, error) {
 
 	// check for invalid sizes
 	nbDigests := len(digests)
@@ -334,7 +438,7 @@ func BatchOpenSinglePoint(polynomials [][]fr.Element, digests []Digest, point fr
 	h := dividePolyByXminusA(foldedPolynomials, foldedEvaluations, point)
 	foldedPolynomials = nil // same memory as h
 
-	res.H, err = Commit(h, pk)
+	res.H, err = Commit(h, pk, nbTasks)
 	if err != nil {
 		return BatchOpeningProof{}, err
 	}
@@ -393,6 +497,8 @@ func FoldProof(digests []Digest, batchOpeningProof *BatchOpeningProof, point fr.
 // * digests list of digests on which opening proof is done
 // * batchOpeningProof proof of correct opening on the digests
 // * dataTranscript extra data that might be needed to derive the challenge used for the folding
+//
+// If the proof was bound to an instance, hf must be fiatshamir.InstanceHash(hf, instanceID).
 func BatchVerifySinglePoint(digests []Digest, batchOpeningProof *BatchOpeningProof, point fr.Element, hf hash.Hash, vk VerifyingKey, dataTranscript ...[]byte) error {
 
 	// fold the proof
@@ -440,15 +546,135 @@ func BatchVerifyMultiPoints(digests []Digest, proofs []OpeningProof, points []fr
 		}
 	}
 
+	foldedDigests, foldedQuotients, err := foldMultiPoints(digests, proofs, points, randomNumbers, &vk)
+	if err != nil {
+		return err
+	}
+
+	// pairing check
+	// e([∑ᵢλᵢ(fᵢ(α) - fᵢ(pᵢ) + pᵢHᵢ(α))]G₁, G₂).e([-∑ᵢλᵢ[Hᵢ(α)]G₁), [α]G₂)
+	check, err := bn254.PairingCheckFixedQ(
+		[]bn254.G1Affine{foldedDigests, foldedQuotients},
+		vk.Lines[:],
+	)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+
+}
+
+// BatchVerifyMultiPointsMultiKeys is like BatchVerifyMultiPoints, but proofs[i] is verified
+// against vks[i]. This allows verifying in one batch proofs generated with SRS of different
+// sizes, e.g. truncated from the same ceremony, or from different ceremonies.
+//
+// The proofs are grouped by verifying key, and verified with a single multi-pairing
+// of two pairs per distinct verifying key.
+func BatchVerifyMultiPointsMultiKeys(digests []Digest, proofs []OpeningProof, points []fr.Element, vks []VerifyingKey) error {
+
+	// check consistency nb proofs vs nb digests vs nb verifying keys
+	if len(digests) != len(proofs) || len(digests) != len(points) || len(digests) != len(vks) {
+		return ErrInvalidNbDigests
+	}
+
+	// len(digests) should be nonzero because of randomNumbers
+	if len(digests) == 0 {
+		return ErrZeroNbDigests
+	}
+
+	// group the proofs by verifying key
+	var keys []int     // index in vks of the first occurrence of each distinct verifying key
+	var groups [][]int // indices of the proofs verified against each distinct verifying key
+	for i := range vks {
+		k := 0
+		for ; k < len(keys); k++ {
+			if vks[i].equal(&vks[keys[k]]) {
+				break
+			}
+		}
+		if k == len(keys) {
+			keys = append(keys, i)
+			groups = append(groups, nil)
+		}
+		groups[k] = append(groups[k], i)
+	}
+
+	// if only one verifying key, call BatchVerifyMultiPoints
+	if len(keys) == 1 {
+		return BatchVerifyMultiPoints(digests, proofs, points, vks[0])
+	}
+
+	// sample random numbers λᵢ for sampling; they must be random across groups
+	// so that the pairing equations of the groups can't compensate each other.
+	randomNumbers := make([]fr.Element, len(digests))
+	randomNumbers[0].SetOne()
+	for i := 1; i < len(randomNumbers); i++ {
+		_, err := randomNumbers[i].SetRandom()
+		if err != nil {
+			return err
+		}
+	}
+
+	// fold each group, and gather the pairs of the final pairing check
+	P := make([]bn254.G1Affine, 0, 2*len(keys))
+	lines := make([][2][len(vks[0].Lines[0][0])]bn254.LineEvaluationAff, 0, 2*len(keys))
+	for k, group := range groups {
+		gDigests := make([]Digest, len(group))
+		gProofs := make([]OpeningProof, len(group))
+		gPoints := make([]fr.Element, len(group))
+		gRandomNumbers := make([]fr.Element, len(group))
+		for j, i := range group {
+			gDigests[j] = digests[i]
+			gProofs[j] = proofs[i]
+			gPoints[j] = points[i]
+			gRandomNumbers[j] = randomNumbers[i]
+		}
+		foldedDigests, foldedQuotients, err := foldMultiPoints(gDigests, gProofs, gPoints, gRandomNumbers, &vks[keys[k]])
+		if err != nil {
+			return err
+		}
+		P = append(P, foldedDigests, foldedQuotients)
+		lines = append(lines, vks[keys[k]].Lines[:]...)
+	}
+
+	// pairing check
+	// ∏ₖ e([∑ᵢλᵢ(fᵢ(α) - fᵢ(pᵢ) + pᵢHᵢ(α))]G₁, G₂ₖ).e([-∑ᵢλᵢ[Hᵢ(α)]G₁), [αₖ]G₂ₖ)
+	check, err := bn254.PairingCheckFixedQ(P, lines)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// equal returns true if vk and other are the same verifying key. The precomputed lines
+// are derived from G2, and not compared.
+func (vk *VerifyingKey) equal(other *VerifyingKey) bool {
+	return vk.G1.Equal(&other.G1) && vk.G2[0].Equal(&other.G2[0]) && vk.G2[1].Equal(&other.G2[1])
+}
+
+// foldMultiPoints folds the opening proofs with the random numbers λᵢ. It returns
+// [∑ᵢλᵢ(fᵢ(α) - fᵢ(pᵢ) + pᵢHᵢ(α))]G₁ and -[∑ᵢλᵢHᵢ(α)]G₁, the proofs being valid if
+// e([∑ᵢλᵢ(fᵢ(α) - fᵢ(pᵢ) + pᵢHᵢ(α))]G₁, G₂).e(-[∑ᵢλᵢHᵢ(α)]G₁, [α]G₂) == 1
+func foldMultiPoints(digests []Digest, proofs []OpeningProof, points []fr.Element, lambdas []fr.Element, vk *VerifyingKey) (foldedDigests, foldedQuotients bn254.G1Affine, err error) {
+
+	// the random numbers are scaled by the points below
+	randomNumbers := make([]fr.Element, len(lambdas))
+	copy(randomNumbers, lambdas)
+
 	// fold the committed quotients compute ∑ᵢλᵢ[Hᵢ(α)]G₁
-	var foldedQuotients bn254.G1Affine
 	quotients := make([]bn254.G1Affine, len(proofs))
 	for i := 0; i < len(randomNumbers); i++ {
 		quotients[i].Set(&proofs[i].H)
 	}
 	config := ecc.MultiExpConfig{}
-	if _, err := foldedQuotients.MultiExp(quotients, randomNumbers, config); err != nil {
-		return err
+	if _, err = foldedQuotients.MultiExp(quotients, randomNumbers, config); err != nil {
+		return
 	}
 
 	// fold digests and evals
@@ -461,7 +687,7 @@ func BatchVerifyMultiPoints(digests []Digest, proofs []OpeningProof, points []fr
 	// fold the evals  : ∑ᵢλᵢfᵢ(aᵢ)
 	foldedDigests, foldedEvals, err := fold(digests, evals, randomNumbers)
 	if err != nil {
-		return err
+		return
 	}
 
 	// compute commitment to folded Eval  [∑ᵢλᵢfᵢ(aᵢ)]G₁
@@ -481,7 +707,7 @@ func BatchVerifyMultiPoints(digests []Digest, proofs []OpeningProof, points []fr
 	}
 	_, err = foldedPointsQuotients.MultiExp(quotients, randomNumbers, config)
 	if err != nil {
-		return err
+		return
 	}
 
 	// ∑ᵢλᵢ[f_i(α)]G₁ - [∑ᵢλᵢfᵢ(aᵢ)]G₁ + ∑ᵢλᵢ[p_i]([Hᵢ(α)]G₁)
@@ -491,20 +717,7 @@ func BatchVerifyMultiPoints(digests []Digest, proofs []OpeningProof, points []fr
 	// -∑ᵢλᵢ[Qᵢ(α)]G₁
 	foldedQuotients.Neg(&foldedQuotients)
 
-	// pairing check
-	// e([∑ᵢλᵢ(fᵢ(α) - fᵢ(pᵢ) + pᵢHᵢ(α))]G₁, G₂).e([-∑ᵢλᵢ[Hᵢ(α)]G₁), [α]G₂)
-	check, err := bn254.PairingCheckFixedQ(
-		[]bn254.G1Affine{foldedDigests, foldedQuotients},
-		vk.Lines[:],
-	)
-	if err != nil {
-		return err
-	}
-	if !check {
-		return ErrVerifyOpeningProof
-	}
-	return nil
-
+	return foldedDigests, foldedQuotients, nil
 }
 
 // fold folds digests and evaluations using the list of factors as random numbers.
@@ -563,14 +776,7 @@ func deriveGamma(point fr.Element, digests []Digest, claimedValues []fr.Element,
 		}
 	}
 
-	gammaByte, err := fs.ComputeChallenge("gamma")
-	if err != nil {
-		return fr.Element{}, err
-	}
-	var gamma fr.Element
-	gamma.SetBytes(gammaByte)
-
-	return gamma, nil
+	return fiatshamir.DeriveChallengeFr[fr.Element](fs, "gamma")
 }
 
 // dividePolyByXminusA computes (f-f(a))/(x-a), in canonical basis, in regular form
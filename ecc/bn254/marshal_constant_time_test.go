@@ -0,0 +1,329 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bn254
+
+import (
+	"bytes"
+	"math/big"
+	"math/bits"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/internal/fptower"
+)
+
+// adversarialG1Encodings returns a corpus of encodings that SetBytesConstantTime
+// must reject, built from the valid point p (not the point at infinity).
+func adversarialG1Encodings(t *testing.T, p *G1Affine) map[string][]byte {
+	corpus := make(map[string][]byte)
+	valid := p.RawBytes()
+	with := func(name string, f func(b []byte)) {
+		b := make([]byte, len(valid))
+		copy(b, valid[:])
+		f(b)
+		corpus[name] = b
+	}
+
+	// lengths
+	corpus["empty"] = nil
+	corpus["truncated"] = valid[:len(valid)-1]
+	corpus["extra byte"] = append(valid[:], 0)
+	compressed := p.Bytes()
+	corpus["compressed"] = compressed[:]
+
+	// flags
+	step := 1 << bits.TrailingZeros8(mMask)
+	for m := 0; m < 256; m += step {
+		switch byte(m) {
+		case mUncompressed:
+			continue
+		}
+		with("flags "+string(rune('0'+m/step)), func(b []byte) {
+			b[0] = (b[0] &^ mMask) | byte(m)
+		})
+	}
+
+	// non canonical coordinates
+	for i := 0; i < len(valid)/fp.Bytes; i++ {
+		chunk := func(b []byte) []byte { return b[i*fp.Bytes : (i+1)*fp.Bytes] }
+		name := "coordinate " + string(rune('0'+i))
+		with(name+" = q", func(b []byte) {
+			fp.Modulus().FillBytes(chunk(b))
+		})
+		with(name+" = q+1", func(b []byte) {
+			new(big.Int).Add(fp.Modulus(), big.NewInt(1)).FillBytes(chunk(b))
+		})
+		with(name+" all ones", func(b []byte) {
+			c := chunk(b)
+			for j := range c {
+				c[j] = 0xff
+			}
+			b[0] &^= mMask
+		})
+	}
+
+	// not on the curve
+	var one fp.Element
+	one.SetOne()
+	var tampered G1Affine
+	tampered.Set(p)
+	tampered.Y.Add(&tampered.Y, &one)
+	b := tampered.RawBytes()
+	corpus["tampered Y"] = b[:]
+
+	// invalid curve: random coordinates lie on another curve y² = x³ + b'
+	for i := 0; i < 4; i++ {
+		var q G1Affine
+		if _, err := q.X.SetRandom(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := q.Y.SetRandom(); err != nil {
+			t.Fatal(err)
+		}
+		b := q.RawBytes()
+		corpus["invalid curve "+string(rune('0'+i))] = b[:]
+	}
+
+	// on the curve, outside of the prime order subgroup (when the cofactor isn't 1)
+	for i := 0; i < 4; i++ {
+		var q G1Affine
+		var ySquare fp.Element
+		for {
+			if _, err := q.X.SetRandom(); err != nil {
+				t.Fatal(err)
+			}
+			ySquare.Square(&q.X).Mul(&ySquare, &q.X).Add(&ySquare, &bCurveCoeff)
+			if ySquare.Legendre() == 1 {
+				break
+			}
+		}
+		q.Y.Sqrt(&ySquare)
+		if !q.IsOnCurve() {
+			t.Fatal("the point should be on the curve")
+		}
+		if !q.IsInSubGroup() {
+			b := q.RawBytes()
+			corpus["wrong subgroup "+string(rune('0'+i))] = b[:]
+		}
+	}
+
+	// point at infinity
+	var infinity [SizeOfG1AffineUncompressed]byte
+	with("infinity with a non zero byte", func(b []byte) {
+		copy(b, infinity[:])
+		b[len(b)-1] = 1
+	})
+
+	return corpus
+}
+
+func TestG1SetBytesConstantTime(t *testing.T) {
+	t.Parallel()
+
+	// valid encodings
+	points := make([]G1Affine, 8)
+	points[0].Set(&g1GenAff)
+	for i := 2; i < len(points); i++ {
+		var s big.Int
+		s.SetUint64(uint64(i*i+7)).Lsh(&s, 200).Add(&s, big.NewInt(int64(i)))
+		points[i].ScalarMultiplication(&g1GenAff, &s)
+	}
+	// points[1] is the point at infinity
+	for i := range points {
+		b := points[i].RawBytes()
+		var q G1Affine
+		if err := q.SetBytesConstantTime(b[:]); err != nil {
+			t.Fatalf("point %d: %v", i, err)
+		}
+		if !q.Equal(&points[i]) {
+			t.Fatalf("point %d: decoded point differs", i)
+		}
+	}
+
+	// adversarial encodings
+	for i := 2; i < len(points); i++ {
+		for name, b := range adversarialG1Encodings(t, &points[i]) {
+			q := points[0]
+			err := q.SetBytesConstantTime(b)
+			if err != ErrInvalidEncoding {
+				t.Fatalf("%s: expected ErrInvalidEncoding, got %v", name, err)
+			}
+			if !q.Equal(&points[0]) {
+				t.Fatalf("%s: the receiver should be left unchanged", name)
+			}
+			// SetBytes must not accept an uncompressed encoding that SetBytesConstantTime
+			// rejects either, but for its non-strict encoding of the point at infinity
+			if len(b) == SizeOfG1AffineUncompressed && b[0]&mMask == mUncompressed && !bytes.Equal(b, make([]byte, len(b))) {
+				var r G1Affine
+				if _, err := r.SetBytes(b); err == nil && !r.IsInfinity() {
+					t.Fatalf("%s: accepted by SetBytes", name)
+				}
+			}
+		}
+	}
+}
+
+// adversarialG2Encodings returns a corpus of encodings that SetBytesConstantTime
+// must reject, built from the valid point p (not the point at infinity).
+func adversarialG2Encodings(t *testing.T, p *G2Affine) map[string][]byte {
+	corpus := make(map[string][]byte)
+	valid := p.RawBytes()
+	with := func(name string, f func(b []byte)) {
+		b := make([]byte, len(valid))
+		copy(b, valid[:])
+		f(b)
+		corpus[name] = b
+	}
+
+	// lengths
+	corpus["empty"] = nil
+	corpus["truncated"] = valid[:len(valid)-1]
+	corpus["extra byte"] = append(valid[:], 0)
+	compressed := p.Bytes()
+	corpus["compressed"] = compressed[:]
+
+	// flags
+	step := 1 << bits.TrailingZeros8(mMask)
+	for m := 0; m < 256; m += step {
+		switch byte(m) {
+		case mUncompressed:
+			continue
+		}
+		with("flags "+string(rune('0'+m/step)), func(b []byte) {
+			b[0] = (b[0] &^ mMask) | byte(m)
+		})
+	}
+
+	// non canonical coordinates
+	for i := 0; i < len(valid)/fp.Bytes; i++ {
+		chunk := func(b []byte) []byte { return b[i*fp.Bytes : (i+1)*fp.Bytes] }
+		name := "coordinate " + string(rune('0'+i))
+		with(name+" = q", func(b []byte) {
+			fp.Modulus().FillBytes(chunk(b))
+		})
+		with(name+" = q+1", func(b []byte) {
+			new(big.Int).Add(fp.Modulus(), big.NewInt(1)).FillBytes(chunk(b))
+		})
+		with(name+" all ones", func(b []byte) {
+			c := chunk(b)
+			for j := range c {
+				c[j] = 0xff
+			}
+			b[0] &^= mMask
+		})
+	}
+
+	// not on the curve
+	var one fptower.E2
+	one.SetOne()
+	var tampered G2Affine
+	tampered.Set(p)
+	tampered.Y.Add(&tampered.Y, &one)
+	b := tampered.RawBytes()
+	corpus["tampered Y"] = b[:]
+
+	// invalid curve: random coordinates lie on another curve y² = x³ + b'
+	for i := 0; i < 4; i++ {
+		var q G2Affine
+		if _, err := q.X.SetRandom(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := q.Y.SetRandom(); err != nil {
+			t.Fatal(err)
+		}
+		b := q.RawBytes()
+		corpus["invalid curve "+string(rune('0'+i))] = b[:]
+	}
+
+	// on the curve, outside of the prime order subgroup (when the cofactor isn't 1)
+	for i := 0; i < 4; i++ {
+		var q G2Affine
+		var ySquare fptower.E2
+		for {
+			if _, err := q.X.SetRandom(); err != nil {
+				t.Fatal(err)
+			}
+			ySquare.Square(&q.X).Mul(&ySquare, &q.X).Add(&ySquare, &bTwistCurveCoeff)
+			if ySquare.Legendre() == 1 {
+				break
+			}
+		}
+		q.Y.Sqrt(&ySquare)
+		if !q.IsOnCurve() {
+			t.Fatal("the point should be on the curve")
+		}
+		if !q.IsInSubGroup() {
+			b := q.RawBytes()
+			corpus["wrong subgroup "+string(rune('0'+i))] = b[:]
+		}
+	}
+
+	// point at infinity
+	var infinity [SizeOfG2AffineUncompressed]byte
+	with("infinity with a non zero byte", func(b []byte) {
+		copy(b, infinity[:])
+		b[len(b)-1] = 1
+	})
+
+	return corpus
+}
+
+func TestG2SetBytesConstantTime(t *testing.T) {
+	t.Parallel()
+
+	// valid encodings
+	points := make([]G2Affine, 8)
+	points[0].Set(&g2GenAff)
+	for i := 2; i < len(points); i++ {
+		var s big.Int
+		s.SetUint64(uint64(i*i+7)).Lsh(&s, 200).Add(&s, big.NewInt(int64(i)))
+		points[i].ScalarMultiplication(&g2GenAff, &s)
+	}
+	// points[1] is the point at infinity
+	for i := range points {
+		b := points[i].RawBytes()
+		var q G2Affine
+		if err := q.SetBytesConstantTime(b[:]); err != nil {
+			t.Fatalf("point %d: %v", i, err)
+		}
+		if !q.Equal(&points[i]) {
+			t.Fatalf("point %d: decoded point differs", i)
+		}
+	}
+
+	// adversarial encodings
+	for i := 2; i < len(points); i++ {
+		for name, b := range adversarialG2Encodings(t, &points[i]) {
+			q := points[0]
+			err := q.SetBytesConstantTime(b)
+			if err != ErrInvalidEncoding {
+				t.Fatalf("%s: expected ErrInvalidEncoding, got %v", name, err)
+			}
+			if !q.Equal(&points[0]) {
+				t.Fatalf("%s: the receiver should be left unchanged", name)
+			}
+			// SetBytes must not accept an uncompressed encoding that SetBytesConstantTime
+			// rejects either, but for its non-strict encoding of the point at infinity
+			if len(b) == SizeOfG2AffineUncompressed && b[0]&mMask == mUncompressed && !bytes.Equal(b, make([]byte, len(b))) {
+				var r G2Affine
+				if _, err := r.SetBytes(b); err == nil && !r.IsInfinity() {
+					t.Fatalf("%s: accepted by SetBytes", name)
+				}
+			}
+		}
+	}
+}
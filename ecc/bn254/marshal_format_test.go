@@ -0,0 +1,178 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bn254
+
+import (
+	"encoding/hex"
+	"math/big"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalFormatVectors(t *testing.T) {
+	assert := require.New(t)
+
+	// arkworks (ark-bn254) encodings of the generator of G1, (1, 2), and of the point at infinity
+	b, err := g1GenAff.MarshalFormat(FormatArkworks, true)
+	assert.NoError(err)
+	assert.Equal("0100000000000000000000000000000000000000000000000000000000000000", hex.EncodeToString(b))
+	b, err = g1GenAff.MarshalFormat(FormatArkworks, false)
+	assert.NoError(err)
+	assert.Equal("0100000000000000000000000000000000000000000000000000000000000000"+
+		"0200000000000000000000000000000000000000000000000000000000000000", hex.EncodeToString(b))
+
+	var inf G1Affine
+	b, err = inf.MarshalFormat(FormatArkworks, true)
+	assert.NoError(err)
+	assert.Equal("0000000000000000000000000000000000000000000000000000000000000040", hex.EncodeToString(b))
+}
+
+func TestMarshalFormatG1Affine(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	points := make([]G1Affine, 10)
+	for i := 1; i < len(points); i++ { // points[0] is the point at infinity
+		points[i].ScalarMultiplication(&g1GenAff, new(big.Int).SetUint64(rand.Uint64())) //#nosec G404 weak rng is fine here
+	}
+
+	for _, format := range []PointFormat{FormatGnark, FormatArkworks} {
+		for _, compressed := range []bool{true, false} {
+			for i := range points {
+				b, err := points[i].MarshalFormat(format, compressed)
+				assert.NoError(err)
+				if compressed {
+					assert.Len(b, SizeOfG1AffineCompressed)
+				} else {
+					assert.Len(b, SizeOfG1AffineUncompressed)
+				}
+				var p G1Affine
+				assert.NoError(p.UnmarshalFormat(b, format), "format %d compressed %v", format, compressed)
+				assert.True(p.Equal(&points[i]), "format %d compressed %v", format, compressed)
+
+				// truncated or extended encodings are rejected
+				assert.Error(p.UnmarshalFormat(b[:len(b)-1], format))
+				assert.Error(p.UnmarshalFormat(append(b, 0), format))
+			}
+		}
+	}
+
+	// the gnark format is Bytes / RawBytes
+	b, err := points[1].MarshalFormat(FormatGnark, true)
+	assert.NoError(err)
+	expected := points[1].Bytes()
+	assert.Equal(expected[:], b)
+	b, err = points[1].MarshalFormat(FormatGnark, false)
+	assert.NoError(err)
+	expectedRaw := points[1].RawBytes()
+	assert.Equal(expectedRaw[:], b)
+
+	// arkworks: the sign flag is in the most significant bit of the last byte
+	var neg G1Affine
+	neg.Neg(&points[1])
+	b, err = points[1].MarshalFormat(FormatArkworks, true)
+	assert.NoError(err)
+	bNeg, err := neg.MarshalFormat(FormatArkworks, true)
+	assert.NoError(err)
+	assert.Equal(b[:len(b)-1], bNeg[:len(bNeg)-1])
+	assert.Equal(byte(0x80), (b[len(b)-1]^bNeg[len(bNeg)-1])&0xc0)
+
+	// both flags set, or the infinity flag with a non zero coordinate, are invalid
+	var p G1Affine
+	b[len(b)-1] |= 0xc0
+	assert.Error(p.UnmarshalFormat(b, FormatArkworks))
+	b[len(b)-1] &^= 0x80
+	assert.ErrorIs(p.UnmarshalFormat(b, FormatArkworks), ErrInvalidInfinityEncoding)
+
+	// uncompressed (0, 0) without the infinity flag is invalid
+	assert.ErrorIs(p.UnmarshalFormat(make([]byte, SizeOfG1AffineUncompressed), FormatArkworks), ErrInvalidInfinityEncoding)
+
+	// unknown format
+	_, err = points[1].MarshalFormat(PointFormat(42), true)
+	assert.ErrorIs(err, ErrUnknownPointFormat)
+	var q G1Affine
+	assert.ErrorIs(q.UnmarshalFormat(b, PointFormat(42)), ErrUnknownPointFormat)
+}
+
+func TestMarshalFormatG2Affine(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	points := make([]G2Affine, 10)
+	for i := 1; i < len(points); i++ { // points[0] is the point at infinity
+		points[i].ScalarMultiplication(&g2GenAff, new(big.Int).SetUint64(rand.Uint64())) //#nosec G404 weak rng is fine here
+	}
+
+	for _, format := range []PointFormat{FormatGnark, FormatArkworks} {
+		for _, compressed := range []bool{true, false} {
+			for i := range points {
+				b, err := points[i].MarshalFormat(format, compressed)
+				assert.NoError(err)
+				if compressed {
+					assert.Len(b, SizeOfG2AffineCompressed)
+				} else {
+					assert.Len(b, SizeOfG2AffineUncompressed)
+				}
+				var p G2Affine
+				assert.NoError(p.UnmarshalFormat(b, format), "format %d compressed %v", format, compressed)
+				assert.True(p.Equal(&points[i]), "format %d compressed %v", format, compressed)
+
+				// truncated or extended encodings are rejected
+				assert.Error(p.UnmarshalFormat(b[:len(b)-1], format))
+				assert.Error(p.UnmarshalFormat(append(b, 0), format))
+			}
+		}
+	}
+
+	// the gnark format is Bytes / RawBytes
+	b, err := points[1].MarshalFormat(FormatGnark, true)
+	assert.NoError(err)
+	expected := points[1].Bytes()
+	assert.Equal(expected[:], b)
+	b, err = points[1].MarshalFormat(FormatGnark, false)
+	assert.NoError(err)
+	expectedRaw := points[1].RawBytes()
+	assert.Equal(expectedRaw[:], b)
+
+	// arkworks: the sign flag is in the most significant bit of the last byte
+	var neg G2Affine
+	neg.Neg(&points[1])
+	b, err = points[1].MarshalFormat(FormatArkworks, true)
+	assert.NoError(err)
+	bNeg, err := neg.MarshalFormat(FormatArkworks, true)
+	assert.NoError(err)
+	assert.Equal(b[:len(b)-1], bNeg[:len(bNeg)-1])
+	assert.Equal(byte(0x80), (b[len(b)-1]^bNeg[len(bNeg)-1])&0xc0)
+
+	// both flags set, or the infinity flag with a non zero coordinate, are invalid
+	var p G2Affine
+	b[len(b)-1] |= 0xc0
+	assert.Error(p.UnmarshalFormat(b, FormatArkworks))
+	b[len(b)-1] &^= 0x80
+	assert.ErrorIs(p.UnmarshalFormat(b, FormatArkworks), ErrInvalidInfinityEncoding)
+
+	// uncompressed (0, 0) without the infinity flag is invalid
+	assert.ErrorIs(p.UnmarshalFormat(make([]byte, SizeOfG2AffineUncompressed), FormatArkworks), ErrInvalidInfinityEncoding)
+
+	// unknown format
+	_, err = points[1].MarshalFormat(PointFormat(42), true)
+	assert.ErrorIs(err, ErrUnknownPointFormat)
+	var q G2Affine
+	assert.ErrorIs(q.UnmarshalFormat(b, PointFormat(42)), ErrUnknownPointFormat)
+}
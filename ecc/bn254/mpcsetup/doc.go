@@ -0,0 +1,41 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package mpcsetup implements the phase 1 of a multi-party computation of the
+// structured reference strings of the bn254 curve: the "powers of tau" ceremony.
+//
+// The transcript holds [τⁱ]₁ for i < 2n-1, [ατⁱ]₁, [βτⁱ]₁ and [τⁱ]₂ for i < n, and
+// [β]₂. Each participant multiplies τ, α and β by secrets of their own and proves the
+// knowledge of these secrets; the result is secure as long as one participant
+// destroys them. A ceremony:
+//
+//	c0 := NewPhase1(n)
+//	c1 := c0.Clone()
+//	c1.Contribute(rand.Reader) // participant 1
+//	c2 := c1.Clone()
+//	c2.Contribute(rand.Reader) // participant 2
+//	…
+//	final := cN.Clone()
+//	final.Seal(beacon)         // public random beacon, unknown to the participants
+//
+// Each contribution hashes the previous one: VerifyPhase1 checks the chain from the
+// initial transcript, and VerifySeal the last contribution from the beacon. The powers
+// of τ give a KZG SRS (see Phase1.SRS); α and β are the ones of the Groth16 phase 2.
+//
+// Documentation:
+// - Bowe, Gabizon, Miers, "Scalable Multi-party Computation for zk-SNARK Parameters in
+// the Random Beacon Model", https://eprint.iacr.org/2017/1050
+package mpcsetup
@@ -280,6 +280,33 @@ func TestCrossMultiExpG1(t *testing.T) {
 		}
 	}
 
+	// the batch affine modes give the same result, with the smallest window size
+	// where they apply
+	var c uint64
+	for _, ic := range implementedCsG1 {
+		if ic > 9 {
+			c = ic
+			break
+		}
+	}
+	for _, mode := range []ecc.BatchAffineMode{ecc.BatchAffineAuto, ecc.BatchAffineOn, ecc.BatchAffineOff} {
+		var p G1Affine
+		if _, err := p.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{C: c, BatchAffine: mode}); err != nil {
+			t.Fatal(err)
+		}
+		if !expected.Equal(&p) {
+			t.Fatalf("cross msm failed with c=%d and batch affine mode %d", c, mode)
+		}
+	}
+
+	// invalid knobs are rejected
+	var p G1Affine
+	if _, err := p.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{C: 3}); err == nil {
+		t.Fatal("expected an error with an unimplemented window size")
+	}
+	if _, err := p.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{BatchAffine: ecc.BatchAffineOff + 1}); err == nil {
+		t.Fatal("expected an error with an unknown batch affine mode")
+	}
 }
 
 // _innerMsmG1Reference always do ext jacobian with c == 16
@@ -374,6 +401,49 @@ func BenchmarkMultiExpG1(b *testing.B) {
 	}
 }
 
+func BenchmarkMultiExpG1Knobs(b *testing.B) {
+	const (
+		pow       = (bits.UintSize / 2) - (bits.UintSize / 8) // 24 on 64 bits arch, 12 on 32 bits
+		nbSamples = 1 << pow
+	)
+
+	var (
+		samplePoints  [nbSamples]G1Affine
+		sampleScalars [nbSamples]fr.Element
+	)
+
+	fillBenchScalars(sampleScalars[:])
+	fillBenchBasesG1(samplePoints[:])
+
+	var testPoint G1Affine
+	modes := []struct {
+		name string
+		mode ecc.BatchAffineMode
+	}{
+		{"auto", ecc.BatchAffineAuto},
+		{"batchAffine", ecc.BatchAffineOn},
+		{"jacobian", ecc.BatchAffineOff},
+	}
+
+	for i := pow - 4; i <= pow; i += 2 {
+		using := 1 << i
+		for _, c := range implementedCsG1 {
+			if c < 10 {
+				continue
+			}
+			for _, m := range modes {
+				config := ecc.MultiExpConfig{C: c, BatchAffine: m.mode}
+				b.Run(fmt.Sprintf("%d points/c=%d/%s", using, c, m.name), func(b *testing.B) {
+					b.ResetTimer()
+					for j := 0; j < b.N; j++ {
+						testPoint.MultiExp(samplePoints[:using], sampleScalars[:using], config)
+					}
+				})
+			}
+		}
+	}
+}
+
 func BenchmarkMultiExpG1Reference(b *testing.B) {
 	const nbSamples = 1 << 20
 
@@ -690,6 +760,33 @@ func TestCrossMultiExpG2(t *testing.T) {
 		}
 	}
 
+	// the batch affine modes give the same result, with the smallest window size
+	// where they apply
+	var c uint64
+	for _, ic := range implementedCsG2 {
+		if ic > 9 {
+			c = ic
+			break
+		}
+	}
+	for _, mode := range []ecc.BatchAffineMode{ecc.BatchAffineAuto, ecc.BatchAffineOn, ecc.BatchAffineOff} {
+		var p G2Affine
+		if _, err := p.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{C: c, BatchAffine: mode}); err != nil {
+			t.Fatal(err)
+		}
+		if !expected.Equal(&p) {
+			t.Fatalf("cross msm failed with c=%d and batch affine mode %d", c, mode)
+		}
+	}
+
+	// invalid knobs are rejected
+	var p G2Affine
+	if _, err := p.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{C: 3}); err == nil {
+		t.Fatal("expected an error with an unimplemented window size")
+	}
+	if _, err := p.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{BatchAffine: ecc.BatchAffineOff + 1}); err == nil {
+		t.Fatal("expected an error with an unknown batch affine mode")
+	}
 }
 
 // _innerMsmG2Reference always do ext jacobian with c == 16
@@ -784,6 +881,49 @@ func BenchmarkMultiExpG2(b *testing.B) {
 	}
 }
 
+func BenchmarkMultiExpG2Knobs(b *testing.B) {
+	const (
+		pow       = (bits.UintSize / 2) - (bits.UintSize / 8) // 24 on 64 bits arch, 12 on 32 bits
+		nbSamples = 1 << pow
+	)
+
+	var (
+		samplePoints  [nbSamples]G2Affine
+		sampleScalars [nbSamples]fr.Element
+	)
+
+	fillBenchScalars(sampleScalars[:])
+	fillBenchBasesG2(samplePoints[:])
+
+	var testPoint G2Affine
+	modes := []struct {
+		name string
+		mode ecc.BatchAffineMode
+	}{
+		{"auto", ecc.BatchAffineAuto},
+		{"batchAffine", ecc.BatchAffineOn},
+		{"jacobian", ecc.BatchAffineOff},
+	}
+
+	for i := pow - 4; i <= pow; i += 2 {
+		using := 1 << i
+		for _, c := range implementedCsG2 {
+			if c < 10 {
+				continue
+			}
+			for _, m := range modes {
+				config := ecc.MultiExpConfig{C: c, BatchAffine: m.mode}
+				b.Run(fmt.Sprintf("%d points/c=%d/%s", using, c, m.name), func(b *testing.B) {
+					b.ResetTimer()
+					for j := 0; j < b.N; j++ {
+						testPoint.MultiExp(samplePoints[:using], sampleScalars[:using], config)
+					}
+				})
+			}
+		}
+	}
+}
+
 func BenchmarkMultiExpG2Reference(b *testing.B) {
 	const nbSamples = 1 << 20
 
@@ -855,6 +995,53 @@ func fillBenchBasesG2(samplePoints []G2Affine) {
 	}
 }
 
+func TestCalibrateMultiExp(t *testing.T) {
+	const nbPoints = 1 << 9
+	tuning := ecc.NewMSMTuning()
+	CalibrateMultiExp(tuning, nbPoints)
+	for _, group := range []string{"G1", "G2"} {
+		if !tuning.Has(ecc.BN254, group, nbPoints) {
+			t.Fatalf("%s: missing window sizes", group)
+		}
+		if _, ok := tuning.WindowSize(ecc.BN254, group, nbPoints); !ok {
+			t.Fatalf("%s: missing window size", group)
+		}
+	}
+
+	scalars := make([]fr.Element, nbPoints)
+	for i := range scalars {
+		scalars[i].SetRandom()
+	}
+	points := BatchScalarMultiplicationG1(&g1GenAff, scalars)
+	for i := range scalars {
+		scalars[i].SetRandom()
+	}
+	var expected G1Jac
+	if _, err := expected.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the calibrated window sizes, and an arbitrary one, give the same result
+	defer ecc.SetMSMTuning(nil)
+	ecc.SetMSMTuning(tuning)
+	var p G1Jac
+	if _, err := p.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if !p.Equal(&expected) {
+		t.Fatal("calibrated MultiExp differs")
+	}
+	tuning = ecc.NewMSMTuning()
+	tuning.Set(ecc.BN254, "G1", []ecc.MSMWindow{{NbPoints: 1, C: 5}})
+	ecc.SetMSMTuning(tuning)
+	if _, err := p.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if !p.Equal(&expected) {
+		t.Fatal("tuned MultiExp differs")
+	}
+}
+
 func fillBenchScalars(sampleScalars []fr.Element) {
 	// ensure every words of the scalars are filled
 	for i := 0; i < len(sampleScalars); i++ {
@@ -61,22 +61,40 @@ func PairingCheck(P []G1Affine, Q []G2Affine) (bool, error) {
 // we use instead d=s ⋅ (p⁶-1)(p²+1)(p⁴ - p² +1)/r
 // where s is the cofactor 2x₀(6x₀²+3x₀+1)
 func FinalExponentiation(z *GT, _z ...*GT) GT {
+	result := FinalExponentiationEasy(z, _z...)
+	return FinalExponentiationHard(&result)
+}
 
-	var result GT
+// FinalExponentiationEasy computes the easy part (∏ᵢ zᵢ)^((p⁶-1)(p²+1)) of the
+// final exponentiation. The result lies in the cyclotomic subgroup, so that
+// easy parts of several Miller loops can be multiplied together before a
+// single call to FinalExponentiationHard.
+func FinalExponentiationEasy(z *GT, _z ...*GT) GT {
+	var result, t GT
 	result.Set(z)
 
 	for _, e := range _z {
 		result.Mul(&result, e)
 	}
 
-	var t [5]GT
-
 	// Easy part
 	// (p⁶-1)(p²+1)
-	t[0].Conjugate(&result)
+	t.Conjugate(&result)
 	result.Inverse(&result)
-	t[0].Mul(&t[0], &result)
-	result.FrobeniusSquare(&t[0]).Mul(&result, &t[0])
+	t.Mul(&t, &result)
+	result.FrobeniusSquare(&t).Mul(&result, &t)
+
+	return result
+}
+
+// FinalExponentiationHard computes the hard part of the final exponentiation.
+//
+// z must be in the cyclotomic subgroup, e.g. the output of FinalExponentiationEasy.
+func FinalExponentiationHard(z *GT) GT {
+	var result GT
+	result.Set(z)
+
+	var t [5]GT
 
 	var one GT
 	one.SetOne()
@@ -0,0 +1,107 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bn254
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// PairMSM computes the reduced multi-pairing with scalar multipliers
+// ∏ᵢ e([aᵢ]Pᵢ, Qᵢ).
+//
+// See MillerLoopMSM.
+//
+// This function doesn't check that the inputs are in the correct subgroup. See IsInSubGroup.
+func PairMSM(P []G1Affine, Q []G2Affine, a []fr.Element) (GT, error) {
+	f, err := MillerLoopMSM(P, Q, a)
+	if err != nil {
+		return GT{}, err
+	}
+	return FinalExponentiation(&f), nil
+}
+
+// PairingCheckMSM returns true if ∏ᵢ e([aᵢ]Pᵢ, Qᵢ) = 1.
+//
+// See MillerLoopMSM.
+//
+// This function doesn't check that the inputs are in the correct subgroup. See IsInSubGroup.
+func PairingCheckMSM(P []G1Affine, Q []G2Affine, a []fr.Element) (bool, error) {
+	f, err := PairMSM(P, Q, a)
+	if err != nil {
+		return false, err
+	}
+	var one GT
+	one.SetOne()
+	return f.Equal(&one), nil
+}
+
+// MillerLoopMSM computes the multi-Miller loop of ∏ᵢ e([aᵢ]Pᵢ, Qᵢ).
+//
+// The scalar multiplications are folded before the Miller loop: pairs sharing
+// the same G2 point are merged with a single multi-scalar multiplication in G1
+//
+//	∏ⱼ e([aⱼ]Pⱼ, Q) = e(∑ⱼ [aⱼ]Pⱼ, Q)
+//
+// so that only one Miller loop iteration per distinct Qᵢ is computed. This is
+// the shape of verification equations (e.g. PLONK or batched KZG openings)
+// where many G1 terms are paired against a handful of fixed G2 points.
+//
+// The result is not reduced, hence it differs from MillerLoop on the scaled
+// points, but both agree after FinalExponentiation.
+func MillerLoopMSM(P []G1Affine, Q []G2Affine, a []fr.Element) (GT, error) {
+	n := len(P)
+	if n == 0 || n != len(Q) || n != len(a) {
+		return GT{}, errors.New("invalid inputs sizes")
+	}
+
+	// group the G1 points and scalars by G2 point
+	groups := make(map[G2Affine]int, n)
+	q := make([]G2Affine, 0, n)
+	var points [][]G1Affine
+	var scalars [][]fr.Element
+	for i := 0; i < n; i++ {
+		j, ok := groups[Q[i]]
+		if !ok {
+			j = len(q)
+			groups[Q[i]] = j
+			q = append(q, Q[i])
+			points = append(points, nil)
+			scalars = append(scalars, nil)
+		}
+		points[j] = append(points[j], P[i])
+		scalars[j] = append(scalars[j], a[i])
+	}
+
+	p := make([]G1Affine, len(q))
+	for j := range q {
+		if len(points[j]) == 1 {
+			var s big.Int
+			scalars[j][0].BigInt(&s)
+			p[j].ScalarMultiplication(&points[j][0], &s)
+			continue
+		}
+		if _, err := p[j].MultiExp(points[j], scalars[j], ecc.MultiExpConfig{}); err != nil {
+			return GT{}, err
+		}
+	}
+
+	return MillerLoop(p, q)
+}
@@ -604,6 +604,1304 @@ func (z *Element) Select(c int, x0 *Element, x1 *Element) *Element {
 	return z
 }
 
+// MulAdd z = x * y + c (mod q)
+//
+// c is added to the unreduced CIOS product x⋅y, which is then reduced once, instead of
+// reducing x⋅y and then adding c.
+func (z *Element) MulAdd(x, y, c *Element) *Element {
+
+	var t [11]uint64
+	var D, m, C uint64
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = bits.Mul64(y[0], x[0])
+	C, t[1] = madd1(y[0], x[1], C)
+	C, t[2] = madd1(y[0], x[2], C)
+	C, t[3] = madd1(y[0], x[3], C)
+	C, t[4] = madd1(y[0], x[4], C)
+	C, t[5] = madd1(y[0], x[5], C)
+	C, t[6] = madd1(y[0], x[6], C)
+	C, t[7] = madd1(y[0], x[7], C)
+	C, t[8] = madd1(y[0], x[8], C)
+	C, t[9] = madd1(y[0], x[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(y[1], x[0], t[0])
+	C, t[1] = madd2(y[1], x[1], t[1], C)
+	C, t[2] = madd2(y[1], x[2], t[2], C)
+	C, t[3] = madd2(y[1], x[3], t[3], C)
+	C, t[4] = madd2(y[1], x[4], t[4], C)
+	C, t[5] = madd2(y[1], x[5], t[5], C)
+	C, t[6] = madd2(y[1], x[6], t[6], C)
+	C, t[7] = madd2(y[1], x[7], t[7], C)
+	C, t[8] = madd2(y[1], x[8], t[8], C)
+	C, t[9] = madd2(y[1], x[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(y[2], x[0], t[0])
+	C, t[1] = madd2(y[2], x[1], t[1], C)
+	C, t[2] = madd2(y[2], x[2], t[2], C)
+	C, t[3] = madd2(y[2], x[3], t[3], C)
+	C, t[4] = madd2(y[2], x[4], t[4], C)
+	C, t[5] = madd2(y[2], x[5], t[5], C)
+	C, t[6] = madd2(y[2], x[6], t[6], C)
+	C, t[7] = madd2(y[2], x[7], t[7], C)
+	C, t[8] = madd2(y[2], x[8], t[8], C)
+	C, t[9] = madd2(y[2], x[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(y[3], x[0], t[0])
+	C, t[1] = madd2(y[3], x[1], t[1], C)
+	C, t[2] = madd2(y[3], x[2], t[2], C)
+	C, t[3] = madd2(y[3], x[3], t[3], C)
+	C, t[4] = madd2(y[3], x[4], t[4], C)
+	C, t[5] = madd2(y[3], x[5], t[5], C)
+	C, t[6] = madd2(y[3], x[6], t[6], C)
+	C, t[7] = madd2(y[3], x[7], t[7], C)
+	C, t[8] = madd2(y[3], x[8], t[8], C)
+	C, t[9] = madd2(y[3], x[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(y[4], x[0], t[0])
+	C, t[1] = madd2(y[4], x[1], t[1], C)
+	C, t[2] = madd2(y[4], x[2], t[2], C)
+	C, t[3] = madd2(y[4], x[3], t[3], C)
+	C, t[4] = madd2(y[4], x[4], t[4], C)
+	C, t[5] = madd2(y[4], x[5], t[5], C)
+	C, t[6] = madd2(y[4], x[6], t[6], C)
+	C, t[7] = madd2(y[4], x[7], t[7], C)
+	C, t[8] = madd2(y[4], x[8], t[8], C)
+	C, t[9] = madd2(y[4], x[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(y[5], x[0], t[0])
+	C, t[1] = madd2(y[5], x[1], t[1], C)
+	C, t[2] = madd2(y[5], x[2], t[2], C)
+	C, t[3] = madd2(y[5], x[3], t[3], C)
+	C, t[4] = madd2(y[5], x[4], t[4], C)
+	C, t[5] = madd2(y[5], x[5], t[5], C)
+	C, t[6] = madd2(y[5], x[6], t[6], C)
+	C, t[7] = madd2(y[5], x[7], t[7], C)
+	C, t[8] = madd2(y[5], x[8], t[8], C)
+	C, t[9] = madd2(y[5], x[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(y[6], x[0], t[0])
+	C, t[1] = madd2(y[6], x[1], t[1], C)
+	C, t[2] = madd2(y[6], x[2], t[2], C)
+	C, t[3] = madd2(y[6], x[3], t[3], C)
+	C, t[4] = madd2(y[6], x[4], t[4], C)
+	C, t[5] = madd2(y[6], x[5], t[5], C)
+	C, t[6] = madd2(y[6], x[6], t[6], C)
+	C, t[7] = madd2(y[6], x[7], t[7], C)
+	C, t[8] = madd2(y[6], x[8], t[8], C)
+	C, t[9] = madd2(y[6], x[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(y[7], x[0], t[0])
+	C, t[1] = madd2(y[7], x[1], t[1], C)
+	C, t[2] = madd2(y[7], x[2], t[2], C)
+	C, t[3] = madd2(y[7], x[3], t[3], C)
+	C, t[4] = madd2(y[7], x[4], t[4], C)
+	C, t[5] = madd2(y[7], x[5], t[5], C)
+	C, t[6] = madd2(y[7], x[6], t[6], C)
+	C, t[7] = madd2(y[7], x[7], t[7], C)
+	C, t[8] = madd2(y[7], x[8], t[8], C)
+	C, t[9] = madd2(y[7], x[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(y[8], x[0], t[0])
+	C, t[1] = madd2(y[8], x[1], t[1], C)
+	C, t[2] = madd2(y[8], x[2], t[2], C)
+	C, t[3] = madd2(y[8], x[3], t[3], C)
+	C, t[4] = madd2(y[8], x[4], t[4], C)
+	C, t[5] = madd2(y[8], x[5], t[5], C)
+	C, t[6] = madd2(y[8], x[6], t[6], C)
+	C, t[7] = madd2(y[8], x[7], t[7], C)
+	C, t[8] = madd2(y[8], x[8], t[8], C)
+	C, t[9] = madd2(y[8], x[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(y[9], x[0], t[0])
+	C, t[1] = madd2(y[9], x[1], t[1], C)
+	C, t[2] = madd2(y[9], x[2], t[2], C)
+	C, t[3] = madd2(y[9], x[3], t[3], C)
+	C, t[4] = madd2(y[9], x[4], t[4], C)
+	C, t[5] = madd2(y[9], x[5], t[5], C)
+	C, t[6] = madd2(y[9], x[6], t[6], C)
+	C, t[7] = madd2(y[9], x[7], t[7], C)
+	C, t[8] = madd2(y[9], x[8], t[8], C)
+	C, t[9] = madd2(y[9], x[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+
+	t[0], C = bits.Add64(t[0], c[0], 0)
+	t[1], C = bits.Add64(t[1], c[1], C)
+	t[2], C = bits.Add64(t[2], c[2], C)
+	t[3], C = bits.Add64(t[3], c[3], C)
+	t[4], C = bits.Add64(t[4], c[4], C)
+	t[5], C = bits.Add64(t[5], c[5], C)
+	t[6], C = bits.Add64(t[6], c[6], C)
+	t[7], C = bits.Add64(t[7], c[7], C)
+	t[8], C = bits.Add64(t[8], c[8], C)
+	t[9], C = bits.Add64(t[9], c[9], C)
+	t[10] += C
+
+	z[0] = t[0]
+	z[1] = t[1]
+	z[2] = t[2]
+	z[3] = t[3]
+	z[4] = t[4]
+	z[5] = t[5]
+	z[6] = t[6]
+	z[7] = t[7]
+	z[8] = t[8]
+	z[9] = t[9]
+	top := t[10]
+	for top != 0 || !z.smallerThanModulus() {
+		var b uint64
+		z[0], b = bits.Sub64(z[0], q0, 0)
+		z[1], b = bits.Sub64(z[1], q1, b)
+		z[2], b = bits.Sub64(z[2], q2, b)
+		z[3], b = bits.Sub64(z[3], q3, b)
+		z[4], b = bits.Sub64(z[4], q4, b)
+		z[5], b = bits.Sub64(z[5], q5, b)
+		z[6], b = bits.Sub64(z[6], q6, b)
+		z[7], b = bits.Sub64(z[7], q7, b)
+		z[8], b = bits.Sub64(z[8], q8, b)
+		z[9], b = bits.Sub64(z[9], q9, b)
+		top -= b
+	}
+
+	return z
+}
+
+// AddMul z = (x + y) * c (mod q)
+//
+// x + y is kept unreduced, on Limbs+1 words, and the product is reduced once.
+func (z *Element) AddMul(x, y, c *Element) *Element {
+	var s Element
+	var carry uint64
+	s[0], carry = bits.Add64(x[0], y[0], carry)
+	s[1], carry = bits.Add64(x[1], y[1], carry)
+	s[2], carry = bits.Add64(x[2], y[2], carry)
+	s[3], carry = bits.Add64(x[3], y[3], carry)
+	s[4], carry = bits.Add64(x[4], y[4], carry)
+	s[5], carry = bits.Add64(x[5], y[5], carry)
+	s[6], carry = bits.Add64(x[6], y[6], carry)
+	s[7], carry = bits.Add64(x[7], y[7], carry)
+	s[8], carry = bits.Add64(x[8], y[8], carry)
+	s[9], carry = bits.Add64(x[9], y[9], carry)
+	mask := -carry
+
+	var t [11]uint64
+	var D, m, C uint64
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = bits.Mul64(c[0], s[0])
+	C, t[1] = madd1(c[0], s[1], C)
+	C, t[2] = madd1(c[0], s[2], C)
+	C, t[3] = madd1(c[0], s[3], C)
+	C, t[4] = madd1(c[0], s[4], C)
+	C, t[5] = madd1(c[0], s[5], C)
+	C, t[6] = madd1(c[0], s[6], C)
+	C, t[7] = madd1(c[0], s[7], C)
+	C, t[8] = madd1(c[0], s[8], C)
+	C, t[9] = madd1(c[0], s[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+	t[10], C = bits.Add64(t[10], c[0]&mask, 0)
+	D += C
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(c[1], s[0], t[0])
+	C, t[1] = madd2(c[1], s[1], t[1], C)
+	C, t[2] = madd2(c[1], s[2], t[2], C)
+	C, t[3] = madd2(c[1], s[3], t[3], C)
+	C, t[4] = madd2(c[1], s[4], t[4], C)
+	C, t[5] = madd2(c[1], s[5], t[5], C)
+	C, t[6] = madd2(c[1], s[6], t[6], C)
+	C, t[7] = madd2(c[1], s[7], t[7], C)
+	C, t[8] = madd2(c[1], s[8], t[8], C)
+	C, t[9] = madd2(c[1], s[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+	t[10], C = bits.Add64(t[10], c[1]&mask, 0)
+	D += C
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(c[2], s[0], t[0])
+	C, t[1] = madd2(c[2], s[1], t[1], C)
+	C, t[2] = madd2(c[2], s[2], t[2], C)
+	C, t[3] = madd2(c[2], s[3], t[3], C)
+	C, t[4] = madd2(c[2], s[4], t[4], C)
+	C, t[5] = madd2(c[2], s[5], t[5], C)
+	C, t[6] = madd2(c[2], s[6], t[6], C)
+	C, t[7] = madd2(c[2], s[7], t[7], C)
+	C, t[8] = madd2(c[2], s[8], t[8], C)
+	C, t[9] = madd2(c[2], s[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+	t[10], C = bits.Add64(t[10], c[2]&mask, 0)
+	D += C
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(c[3], s[0], t[0])
+	C, t[1] = madd2(c[3], s[1], t[1], C)
+	C, t[2] = madd2(c[3], s[2], t[2], C)
+	C, t[3] = madd2(c[3], s[3], t[3], C)
+	C, t[4] = madd2(c[3], s[4], t[4], C)
+	C, t[5] = madd2(c[3], s[5], t[5], C)
+	C, t[6] = madd2(c[3], s[6], t[6], C)
+	C, t[7] = madd2(c[3], s[7], t[7], C)
+	C, t[8] = madd2(c[3], s[8], t[8], C)
+	C, t[9] = madd2(c[3], s[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+	t[10], C = bits.Add64(t[10], c[3]&mask, 0)
+	D += C
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(c[4], s[0], t[0])
+	C, t[1] = madd2(c[4], s[1], t[1], C)
+	C, t[2] = madd2(c[4], s[2], t[2], C)
+	C, t[3] = madd2(c[4], s[3], t[3], C)
+	C, t[4] = madd2(c[4], s[4], t[4], C)
+	C, t[5] = madd2(c[4], s[5], t[5], C)
+	C, t[6] = madd2(c[4], s[6], t[6], C)
+	C, t[7] = madd2(c[4], s[7], t[7], C)
+	C, t[8] = madd2(c[4], s[8], t[8], C)
+	C, t[9] = madd2(c[4], s[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+	t[10], C = bits.Add64(t[10], c[4]&mask, 0)
+	D += C
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(c[5], s[0], t[0])
+	C, t[1] = madd2(c[5], s[1], t[1], C)
+	C, t[2] = madd2(c[5], s[2], t[2], C)
+	C, t[3] = madd2(c[5], s[3], t[3], C)
+	C, t[4] = madd2(c[5], s[4], t[4], C)
+	C, t[5] = madd2(c[5], s[5], t[5], C)
+	C, t[6] = madd2(c[5], s[6], t[6], C)
+	C, t[7] = madd2(c[5], s[7], t[7], C)
+	C, t[8] = madd2(c[5], s[8], t[8], C)
+	C, t[9] = madd2(c[5], s[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+	t[10], C = bits.Add64(t[10], c[5]&mask, 0)
+	D += C
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(c[6], s[0], t[0])
+	C, t[1] = madd2(c[6], s[1], t[1], C)
+	C, t[2] = madd2(c[6], s[2], t[2], C)
+	C, t[3] = madd2(c[6], s[3], t[3], C)
+	C, t[4] = madd2(c[6], s[4], t[4], C)
+	C, t[5] = madd2(c[6], s[5], t[5], C)
+	C, t[6] = madd2(c[6], s[6], t[6], C)
+	C, t[7] = madd2(c[6], s[7], t[7], C)
+	C, t[8] = madd2(c[6], s[8], t[8], C)
+	C, t[9] = madd2(c[6], s[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+	t[10], C = bits.Add64(t[10], c[6]&mask, 0)
+	D += C
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(c[7], s[0], t[0])
+	C, t[1] = madd2(c[7], s[1], t[1], C)
+	C, t[2] = madd2(c[7], s[2], t[2], C)
+	C, t[3] = madd2(c[7], s[3], t[3], C)
+	C, t[4] = madd2(c[7], s[4], t[4], C)
+	C, t[5] = madd2(c[7], s[5], t[5], C)
+	C, t[6] = madd2(c[7], s[6], t[6], C)
+	C, t[7] = madd2(c[7], s[7], t[7], C)
+	C, t[8] = madd2(c[7], s[8], t[8], C)
+	C, t[9] = madd2(c[7], s[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+	t[10], C = bits.Add64(t[10], c[7]&mask, 0)
+	D += C
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(c[8], s[0], t[0])
+	C, t[1] = madd2(c[8], s[1], t[1], C)
+	C, t[2] = madd2(c[8], s[2], t[2], C)
+	C, t[3] = madd2(c[8], s[3], t[3], C)
+	C, t[4] = madd2(c[8], s[4], t[4], C)
+	C, t[5] = madd2(c[8], s[5], t[5], C)
+	C, t[6] = madd2(c[8], s[6], t[6], C)
+	C, t[7] = madd2(c[8], s[7], t[7], C)
+	C, t[8] = madd2(c[8], s[8], t[8], C)
+	C, t[9] = madd2(c[8], s[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+	t[10], C = bits.Add64(t[10], c[8]&mask, 0)
+	D += C
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(c[9], s[0], t[0])
+	C, t[1] = madd2(c[9], s[1], t[1], C)
+	C, t[2] = madd2(c[9], s[2], t[2], C)
+	C, t[3] = madd2(c[9], s[3], t[3], C)
+	C, t[4] = madd2(c[9], s[4], t[4], C)
+	C, t[5] = madd2(c[9], s[5], t[5], C)
+	C, t[6] = madd2(c[9], s[6], t[6], C)
+	C, t[7] = madd2(c[9], s[7], t[7], C)
+	C, t[8] = madd2(c[9], s[8], t[8], C)
+	C, t[9] = madd2(c[9], s[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+	t[10], C = bits.Add64(t[10], c[9]&mask, 0)
+	D += C
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+
+	z[0] = t[0]
+	z[1] = t[1]
+	z[2] = t[2]
+	z[3] = t[3]
+	z[4] = t[4]
+	z[5] = t[5]
+	z[6] = t[6]
+	z[7] = t[7]
+	z[8] = t[8]
+	z[9] = t[9]
+	top := t[10]
+	for top != 0 || !z.smallerThanModulus() {
+		var b uint64
+		z[0], b = bits.Sub64(z[0], q0, 0)
+		z[1], b = bits.Sub64(z[1], q1, b)
+		z[2], b = bits.Sub64(z[2], q2, b)
+		z[3], b = bits.Sub64(z[3], q3, b)
+		z[4], b = bits.Sub64(z[4], q4, b)
+		z[5], b = bits.Sub64(z[5], q5, b)
+		z[6], b = bits.Sub64(z[6], q6, b)
+		z[7], b = bits.Sub64(z[7], q7, b)
+		z[8], b = bits.Sub64(z[8], q8, b)
+		z[9], b = bits.Sub64(z[9], q9, b)
+		top -= b
+	}
+
+	return z
+}
+
+// Lincomb z = a * x + b * y (mod q)
+//
+// The two products are accumulated in the same CIOS loop and reduced once, instead of
+// being reduced separately and then added.
+func (z *Element) Lincomb(a, b, x, y *Element) *Element {
+
+	var t [11]uint64
+	var D, m, C uint64
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = bits.Mul64(x[0], a[0])
+	C, t[1] = madd1(x[0], a[1], C)
+	C, t[2] = madd1(x[0], a[2], C)
+	C, t[3] = madd1(x[0], a[3], C)
+	C, t[4] = madd1(x[0], a[4], C)
+	C, t[5] = madd1(x[0], a[5], C)
+	C, t[6] = madd1(x[0], a[6], C)
+	C, t[7] = madd1(x[0], a[7], C)
+	C, t[8] = madd1(x[0], a[8], C)
+	C, t[9] = madd1(x[0], a[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+	C, t[0] = madd1(y[0], b[0], t[0])
+	C, t[1] = madd2(y[0], b[1], t[1], C)
+	C, t[2] = madd2(y[0], b[2], t[2], C)
+	C, t[3] = madd2(y[0], b[3], t[3], C)
+	C, t[4] = madd2(y[0], b[4], t[4], C)
+	C, t[5] = madd2(y[0], b[5], t[5], C)
+	C, t[6] = madd2(y[0], b[6], t[6], C)
+	C, t[7] = madd2(y[0], b[7], t[7], C)
+	C, t[8] = madd2(y[0], b[8], t[8], C)
+	C, t[9] = madd2(y[0], b[9], t[9], C)
+	t[10], C = bits.Add64(t[10], C, 0)
+	D += C
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(x[1], a[0], t[0])
+	C, t[1] = madd2(x[1], a[1], t[1], C)
+	C, t[2] = madd2(x[1], a[2], t[2], C)
+	C, t[3] = madd2(x[1], a[3], t[3], C)
+	C, t[4] = madd2(x[1], a[4], t[4], C)
+	C, t[5] = madd2(x[1], a[5], t[5], C)
+	C, t[6] = madd2(x[1], a[6], t[6], C)
+	C, t[7] = madd2(x[1], a[7], t[7], C)
+	C, t[8] = madd2(x[1], a[8], t[8], C)
+	C, t[9] = madd2(x[1], a[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+	C, t[0] = madd1(y[1], b[0], t[0])
+	C, t[1] = madd2(y[1], b[1], t[1], C)
+	C, t[2] = madd2(y[1], b[2], t[2], C)
+	C, t[3] = madd2(y[1], b[3], t[3], C)
+	C, t[4] = madd2(y[1], b[4], t[4], C)
+	C, t[5] = madd2(y[1], b[5], t[5], C)
+	C, t[6] = madd2(y[1], b[6], t[6], C)
+	C, t[7] = madd2(y[1], b[7], t[7], C)
+	C, t[8] = madd2(y[1], b[8], t[8], C)
+	C, t[9] = madd2(y[1], b[9], t[9], C)
+	t[10], C = bits.Add64(t[10], C, 0)
+	D += C
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(x[2], a[0], t[0])
+	C, t[1] = madd2(x[2], a[1], t[1], C)
+	C, t[2] = madd2(x[2], a[2], t[2], C)
+	C, t[3] = madd2(x[2], a[3], t[3], C)
+	C, t[4] = madd2(x[2], a[4], t[4], C)
+	C, t[5] = madd2(x[2], a[5], t[5], C)
+	C, t[6] = madd2(x[2], a[6], t[6], C)
+	C, t[7] = madd2(x[2], a[7], t[7], C)
+	C, t[8] = madd2(x[2], a[8], t[8], C)
+	C, t[9] = madd2(x[2], a[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+	C, t[0] = madd1(y[2], b[0], t[0])
+	C, t[1] = madd2(y[2], b[1], t[1], C)
+	C, t[2] = madd2(y[2], b[2], t[2], C)
+	C, t[3] = madd2(y[2], b[3], t[3], C)
+	C, t[4] = madd2(y[2], b[4], t[4], C)
+	C, t[5] = madd2(y[2], b[5], t[5], C)
+	C, t[6] = madd2(y[2], b[6], t[6], C)
+	C, t[7] = madd2(y[2], b[7], t[7], C)
+	C, t[8] = madd2(y[2], b[8], t[8], C)
+	C, t[9] = madd2(y[2], b[9], t[9], C)
+	t[10], C = bits.Add64(t[10], C, 0)
+	D += C
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(x[3], a[0], t[0])
+	C, t[1] = madd2(x[3], a[1], t[1], C)
+	C, t[2] = madd2(x[3], a[2], t[2], C)
+	C, t[3] = madd2(x[3], a[3], t[3], C)
+	C, t[4] = madd2(x[3], a[4], t[4], C)
+	C, t[5] = madd2(x[3], a[5], t[5], C)
+	C, t[6] = madd2(x[3], a[6], t[6], C)
+	C, t[7] = madd2(x[3], a[7], t[7], C)
+	C, t[8] = madd2(x[3], a[8], t[8], C)
+	C, t[9] = madd2(x[3], a[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+	C, t[0] = madd1(y[3], b[0], t[0])
+	C, t[1] = madd2(y[3], b[1], t[1], C)
+	C, t[2] = madd2(y[3], b[2], t[2], C)
+	C, t[3] = madd2(y[3], b[3], t[3], C)
+	C, t[4] = madd2(y[3], b[4], t[4], C)
+	C, t[5] = madd2(y[3], b[5], t[5], C)
+	C, t[6] = madd2(y[3], b[6], t[6], C)
+	C, t[7] = madd2(y[3], b[7], t[7], C)
+	C, t[8] = madd2(y[3], b[8], t[8], C)
+	C, t[9] = madd2(y[3], b[9], t[9], C)
+	t[10], C = bits.Add64(t[10], C, 0)
+	D += C
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(x[4], a[0], t[0])
+	C, t[1] = madd2(x[4], a[1], t[1], C)
+	C, t[2] = madd2(x[4], a[2], t[2], C)
+	C, t[3] = madd2(x[4], a[3], t[3], C)
+	C, t[4] = madd2(x[4], a[4], t[4], C)
+	C, t[5] = madd2(x[4], a[5], t[5], C)
+	C, t[6] = madd2(x[4], a[6], t[6], C)
+	C, t[7] = madd2(x[4], a[7], t[7], C)
+	C, t[8] = madd2(x[4], a[8], t[8], C)
+	C, t[9] = madd2(x[4], a[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+	C, t[0] = madd1(y[4], b[0], t[0])
+	C, t[1] = madd2(y[4], b[1], t[1], C)
+	C, t[2] = madd2(y[4], b[2], t[2], C)
+	C, t[3] = madd2(y[4], b[3], t[3], C)
+	C, t[4] = madd2(y[4], b[4], t[4], C)
+	C, t[5] = madd2(y[4], b[5], t[5], C)
+	C, t[6] = madd2(y[4], b[6], t[6], C)
+	C, t[7] = madd2(y[4], b[7], t[7], C)
+	C, t[8] = madd2(y[4], b[8], t[8], C)
+	C, t[9] = madd2(y[4], b[9], t[9], C)
+	t[10], C = bits.Add64(t[10], C, 0)
+	D += C
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(x[5], a[0], t[0])
+	C, t[1] = madd2(x[5], a[1], t[1], C)
+	C, t[2] = madd2(x[5], a[2], t[2], C)
+	C, t[3] = madd2(x[5], a[3], t[3], C)
+	C, t[4] = madd2(x[5], a[4], t[4], C)
+	C, t[5] = madd2(x[5], a[5], t[5], C)
+	C, t[6] = madd2(x[5], a[6], t[6], C)
+	C, t[7] = madd2(x[5], a[7], t[7], C)
+	C, t[8] = madd2(x[5], a[8], t[8], C)
+	C, t[9] = madd2(x[5], a[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+	C, t[0] = madd1(y[5], b[0], t[0])
+	C, t[1] = madd2(y[5], b[1], t[1], C)
+	C, t[2] = madd2(y[5], b[2], t[2], C)
+	C, t[3] = madd2(y[5], b[3], t[3], C)
+	C, t[4] = madd2(y[5], b[4], t[4], C)
+	C, t[5] = madd2(y[5], b[5], t[5], C)
+	C, t[6] = madd2(y[5], b[6], t[6], C)
+	C, t[7] = madd2(y[5], b[7], t[7], C)
+	C, t[8] = madd2(y[5], b[8], t[8], C)
+	C, t[9] = madd2(y[5], b[9], t[9], C)
+	t[10], C = bits.Add64(t[10], C, 0)
+	D += C
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(x[6], a[0], t[0])
+	C, t[1] = madd2(x[6], a[1], t[1], C)
+	C, t[2] = madd2(x[6], a[2], t[2], C)
+	C, t[3] = madd2(x[6], a[3], t[3], C)
+	C, t[4] = madd2(x[6], a[4], t[4], C)
+	C, t[5] = madd2(x[6], a[5], t[5], C)
+	C, t[6] = madd2(x[6], a[6], t[6], C)
+	C, t[7] = madd2(x[6], a[7], t[7], C)
+	C, t[8] = madd2(x[6], a[8], t[8], C)
+	C, t[9] = madd2(x[6], a[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+	C, t[0] = madd1(y[6], b[0], t[0])
+	C, t[1] = madd2(y[6], b[1], t[1], C)
+	C, t[2] = madd2(y[6], b[2], t[2], C)
+	C, t[3] = madd2(y[6], b[3], t[3], C)
+	C, t[4] = madd2(y[6], b[4], t[4], C)
+	C, t[5] = madd2(y[6], b[5], t[5], C)
+	C, t[6] = madd2(y[6], b[6], t[6], C)
+	C, t[7] = madd2(y[6], b[7], t[7], C)
+	C, t[8] = madd2(y[6], b[8], t[8], C)
+	C, t[9] = madd2(y[6], b[9], t[9], C)
+	t[10], C = bits.Add64(t[10], C, 0)
+	D += C
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(x[7], a[0], t[0])
+	C, t[1] = madd2(x[7], a[1], t[1], C)
+	C, t[2] = madd2(x[7], a[2], t[2], C)
+	C, t[3] = madd2(x[7], a[3], t[3], C)
+	C, t[4] = madd2(x[7], a[4], t[4], C)
+	C, t[5] = madd2(x[7], a[5], t[5], C)
+	C, t[6] = madd2(x[7], a[6], t[6], C)
+	C, t[7] = madd2(x[7], a[7], t[7], C)
+	C, t[8] = madd2(x[7], a[8], t[8], C)
+	C, t[9] = madd2(x[7], a[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+	C, t[0] = madd1(y[7], b[0], t[0])
+	C, t[1] = madd2(y[7], b[1], t[1], C)
+	C, t[2] = madd2(y[7], b[2], t[2], C)
+	C, t[3] = madd2(y[7], b[3], t[3], C)
+	C, t[4] = madd2(y[7], b[4], t[4], C)
+	C, t[5] = madd2(y[7], b[5], t[5], C)
+	C, t[6] = madd2(y[7], b[6], t[6], C)
+	C, t[7] = madd2(y[7], b[7], t[7], C)
+	C, t[8] = madd2(y[7], b[8], t[8], C)
+	C, t[9] = madd2(y[7], b[9], t[9], C)
+	t[10], C = bits.Add64(t[10], C, 0)
+	D += C
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(x[8], a[0], t[0])
+	C, t[1] = madd2(x[8], a[1], t[1], C)
+	C, t[2] = madd2(x[8], a[2], t[2], C)
+	C, t[3] = madd2(x[8], a[3], t[3], C)
+	C, t[4] = madd2(x[8], a[4], t[4], C)
+	C, t[5] = madd2(x[8], a[5], t[5], C)
+	C, t[6] = madd2(x[8], a[6], t[6], C)
+	C, t[7] = madd2(x[8], a[7], t[7], C)
+	C, t[8] = madd2(x[8], a[8], t[8], C)
+	C, t[9] = madd2(x[8], a[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+	C, t[0] = madd1(y[8], b[0], t[0])
+	C, t[1] = madd2(y[8], b[1], t[1], C)
+	C, t[2] = madd2(y[8], b[2], t[2], C)
+	C, t[3] = madd2(y[8], b[3], t[3], C)
+	C, t[4] = madd2(y[8], b[4], t[4], C)
+	C, t[5] = madd2(y[8], b[5], t[5], C)
+	C, t[6] = madd2(y[8], b[6], t[6], C)
+	C, t[7] = madd2(y[8], b[7], t[7], C)
+	C, t[8] = madd2(y[8], b[8], t[8], C)
+	C, t[9] = madd2(y[8], b[9], t[9], C)
+	t[10], C = bits.Add64(t[10], C, 0)
+	D += C
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+	// -----------------------------------
+	// First loop
+
+	C, t[0] = madd1(x[9], a[0], t[0])
+	C, t[1] = madd2(x[9], a[1], t[1], C)
+	C, t[2] = madd2(x[9], a[2], t[2], C)
+	C, t[3] = madd2(x[9], a[3], t[3], C)
+	C, t[4] = madd2(x[9], a[4], t[4], C)
+	C, t[5] = madd2(x[9], a[5], t[5], C)
+	C, t[6] = madd2(x[9], a[6], t[6], C)
+	C, t[7] = madd2(x[9], a[7], t[7], C)
+	C, t[8] = madd2(x[9], a[8], t[8], C)
+	C, t[9] = madd2(x[9], a[9], t[9], C)
+
+	t[10], D = bits.Add64(t[10], C, 0)
+	C, t[0] = madd1(y[9], b[0], t[0])
+	C, t[1] = madd2(y[9], b[1], t[1], C)
+	C, t[2] = madd2(y[9], b[2], t[2], C)
+	C, t[3] = madd2(y[9], b[3], t[3], C)
+	C, t[4] = madd2(y[9], b[4], t[4], C)
+	C, t[5] = madd2(y[9], b[5], t[5], C)
+	C, t[6] = madd2(y[9], b[6], t[6], C)
+	C, t[7] = madd2(y[9], b[7], t[7], C)
+	C, t[8] = madd2(y[9], b[8], t[8], C)
+	C, t[9] = madd2(y[9], b[9], t[9], C)
+	t[10], C = bits.Add64(t[10], C, 0)
+	D += C
+
+	// m = t[0]n'[0] mod W
+	m = t[0] * qInvNeg
+
+	// -----------------------------------
+	// Second loop
+	C = madd0(m, q0, t[0])
+	C, t[0] = madd2(m, q1, t[1], C)
+	C, t[1] = madd2(m, q2, t[2], C)
+	C, t[2] = madd2(m, q3, t[3], C)
+	C, t[3] = madd2(m, q4, t[4], C)
+	C, t[4] = madd2(m, q5, t[5], C)
+	C, t[5] = madd2(m, q6, t[6], C)
+	C, t[6] = madd2(m, q7, t[7], C)
+	C, t[7] = madd2(m, q8, t[8], C)
+	C, t[8] = madd2(m, q9, t[9], C)
+
+	t[9], C = bits.Add64(t[10], C, 0)
+	t[10], _ = bits.Add64(0, D, C)
+
+	z[0] = t[0]
+	z[1] = t[1]
+	z[2] = t[2]
+	z[3] = t[3]
+	z[4] = t[4]
+	z[5] = t[5]
+	z[6] = t[6]
+	z[7] = t[7]
+	z[8] = t[8]
+	z[9] = t[9]
+	top := t[10]
+	for top != 0 || !z.smallerThanModulus() {
+		var b uint64
+		z[0], b = bits.Sub64(z[0], q0, 0)
+		z[1], b = bits.Sub64(z[1], q1, b)
+		z[2], b = bits.Sub64(z[2], q2, b)
+		z[3], b = bits.Sub64(z[3], q3, b)
+		z[4], b = bits.Sub64(z[4], q4, b)
+		z[5], b = bits.Sub64(z[5], q5, b)
+		z[6], b = bits.Sub64(z[6], q6, b)
+		z[7], b = bits.Sub64(z[7], q7, b)
+		z[8], b = bits.Sub64(z[8], q8, b)
+		z[9], b = bits.Sub64(z[9], q9, b)
+		top -= b
+	}
+
+	return z
+}
+
 // _mulGeneric is unoptimized textbook CIOS
 // it is a fallback solution on x86 when ADX instruction set is not available
 // and is used for testing purposes.
@@ -1024,6 +2322,55 @@ func _mulGeneric(z, x, y *Element) {
 	}
 }
 
+// mul32 sets z = x * y (mod q) with CIOS on 32-bit limbs. It replaces the 64-bit limbs
+// multiplication on the platforms where bits.Mul64 is emulated (see use32BitLimbs).
+// The Montgomery form is the same, as R = 2^(64·Limbs) = 2^(32·2·Limbs).
+func mul32(z, x, y *Element) {
+	const n = 2 * Limbs
+	const qInvNeg32 = uint32(qInvNeg & 0xffffffff) // - q⁻¹ mod 2³²
+
+	var a, b, q [n]uint32
+	for i := 0; i < Limbs; i++ {
+		a[2*i], a[2*i+1] = uint32(x[i]), uint32(x[i]>>32)
+		b[2*i], b[2*i+1] = uint32(y[i]), uint32(y[i]>>32)
+		q[2*i], q[2*i+1] = uint32(qElement[i]), uint32(qElement[i]>>32)
+	}
+
+	// the products fit in a uint64: (2³²-1)² + 2·(2³²-1) = 2⁶⁴-1
+	var t [n + 2]uint32
+	for i := 0; i < n; i++ {
+		// t += a · b[i]
+		var c uint64
+		for j := 0; j < n; j++ {
+			p := uint64(a[j])*uint64(b[i]) + uint64(t[j]) + c
+			t[j], c = uint32(p), p>>32
+		}
+		p := uint64(t[n]) + c
+		t[n], t[n+1] = uint32(p), uint32(p>>32)
+
+		// t = (t + m · q) / 2³²
+		m := t[0] * qInvNeg32
+		c = (uint64(m)*uint64(q[0]) + uint64(t[0])) >> 32
+		for j := 1; j < n; j++ {
+			p := uint64(m)*uint64(q[j]) + uint64(t[j]) + c
+			t[j-1], c = uint32(p), p>>32
+		}
+		p = uint64(t[n]) + c
+		t[n-1], t[n] = uint32(p), t[n+1]+uint32(p>>32)
+	}
+
+	// t < 2q
+	for i := 0; i < Limbs; i++ {
+		z[i] = uint64(t[2*i]) | uint64(t[2*i+1])<<32
+	}
+	if t[n] != 0 || !z.smallerThanModulus() {
+		var borrow uint64
+		for i := 0; i < Limbs; i++ {
+			z[i], borrow = bits.Sub64(z[i], qElement[i], borrow)
+		}
+	}
+}
+
 func _fromMontGeneric(z *Element) {
 	// the following lines implement z = z * 1
 	// with a modified CIOS montgomery multiplication
@@ -1217,8 +2564,26 @@ func _reduceGeneric(z *Element) {
 // Uses Montgomery batch inversion trick
 func BatchInvert(a []Element) []Element {
 	res := make([]Element, len(a))
+	batchInvert(res, a)
+	return res
+}
+
+// BatchInvertParallel returns a new slice with every element inverted.
+// a is split in chunks, inverted in parallel with the Montgomery batch inversion trick,
+// at the cost of one field inversion per chunk.
+// nbTasks sets the max number of go routines to spawn; default is concurrency.MaxTasks().
+func BatchInvertParallel(a []Element, nbTasks ...int) []Element {
+	res := make([]Element, len(a))
+	execute(len(a), func(start, end int) {
+		batchInvert(res[start:end], a[start:end])
+	}, nbTasks...)
+	return res
+}
+
+// batchInvert sets res[i] = a[i]⁻¹ (0 if a[i] == 0); res and a must not overlap.
+func batchInvert(res, a []Element) {
 	if len(a) == 0 {
-		return res
+		return
 	}
 
 	zeroes := bitset.New(uint(len(a)))
@@ -1227,6 +2592,7 @@ func BatchInvert(a []Element) []Element {
 	for i := 0; i < len(a); i++ {
 		if a[i].IsZero() {
 			zeroes.Set(uint(i))
+			res[i].SetZero()
 			continue
 		}
 		res[i] = accumulator
@@ -1242,8 +2608,6 @@ func BatchInvert(a []Element) []Element {
 		res[i].Mul(&res[i], &accumulator)
 		accumulator.Mul(&accumulator, &a[i])
 	}
-
-	return res
 }
 
 func _butterflyGeneric(a, b *Element) {
@@ -1299,18 +2663,11 @@ func Hash(msg, dst []byte, count int) ([]Element, error) {
 		return nil, err
 	}
 
-	// get temporary big int from the pool
-	vv := pool.BigInt.Get()
-
 	res := make([]Element, count)
 	for i := 0; i < count; i++ {
-		vv.SetBytes(pseudoRandomBytes[i*L : (i+1)*L])
-		res[i].SetBigInt(vv)
+		res[i].setBytesWide(pseudoRandomBytes[i*L : (i+1)*L])
 	}
 
-	// release object into pool
-	pool.BigInt.Put(vv)
-
 	return res, nil
 }
 
@@ -1478,19 +2835,60 @@ func (z *Element) SetBytes(e []byte) *Element {
 	}
 
 	// slow path.
-	// get a big int from our pool
-	vv := pool.BigInt.Get()
-	vv.SetBytes(e)
+	return z.setBytesWide(e)
+}
 
-	// set big int
-	z.SetBigInt(vv)
+// SetBytesWide interprets e as the bytes of a big-endian 512-bit unsigned integer,
+// sets z to that value modulo q, and returns z.
+//
+// If q has at most 384 bits and e is uniformly random, such as the output of a
+// 512-bit hash function, z is statistically uniform (the bias is below 2⁻¹²⁸),
+// unlike a reduction of the first Bytes bytes of e.
+func (z *Element) SetBytesWide(e [64]byte) *Element {
+	return z.setBytesWide(e[:])
+}
 
-	// put temporary object back in pool
-	pool.BigInt.Put(vv)
+// wideChunkBytes is the size of the chunks reduced by setBytesWide: since
+// 2^(8·wideChunkBytes) ⩽ 2^(Bits-1) < q, a chunk is always smaller than q.
+const wideChunkBytes = (Bits - 1) / 8
 
+// wideChunkShift = 2^(8·wideChunkBytes) mod q, in Montgomery form
+var wideChunkShift = func() Element {
+	z := One()
+	for i := 0; i < 8*wideChunkBytes; i++ {
+		z.Double(&z)
+	}
+	return z
+}()
+
+// setBytesWide sets z to the big-endian unsigned integer e modulo q and returns z.
+//
+// e is split in chunks of wideChunkBytes bytes, smaller than q, which are converted to
+// Montgomery form and combined with a Horner scheme: there is no big.Int arithmetic
+// nor allocation.
+func (z *Element) setBytesWide(e []byte) *Element {
+	// the first chunk holds the leading len(e) mod wideChunkBytes bytes, if any
+	first := len(e) % wideChunkBytes
+	if first == 0 && len(e) != 0 {
+		first = wideChunkBytes
+	}
+	z.setWideChunk(e[:first])
+	for e = e[first:]; len(e) != 0; e = e[wideChunkBytes:] {
+		var c Element
+		c.setWideChunk(e[:wideChunkBytes])
+		z.Mul(z, &wideChunkShift).Add(z, &c)
+	}
 	return z
 }
 
+// setWideChunk sets z to the big-endian unsigned integer e, of at most wideChunkBytes bytes.
+func (z *Element) setWideChunk(e []byte) {
+	var b [Bytes]byte
+	copy(b[Bytes-len(e):], e)
+	// e < q: no error
+	*z, _ = BigEndian.Element(&b)
+}
+
 // SetBytesCanonical interprets e as the bytes of a big-endian 80-byte integer.
 // If e is not a 80-byte slice or encodes a value higher than q,
 // SetBytesCanonical returns an error.
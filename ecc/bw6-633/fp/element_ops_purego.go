@@ -19,7 +19,14 @@
 
 package fp
 
-import "math/bits"
+import (
+	"math/bits"
+	"runtime"
+)
+
+// use32BitLimbs is set on the platforms where bits.Mul64 is emulated: 32-bit architectures,
+// and wasm which has no 64×64→128 multiplication. Mul and Square then run on 32-bit limbs.
+const use32BitLimbs = bits.UintSize == 32 || runtime.GOARCH == "wasm"
 
 // MulBy3 x *= 3 (mod q)
 func MulBy3(x *Element) {
@@ -35,19 +42,9 @@ func MulBy5(x *Element) {
 
 // MulBy13 x *= 13 (mod q)
 func MulBy13(x *Element) {
-	var y = Element{
-		4881606927653498122,
-		47978232019095094,
-		8555661377410121478,
-		17849732488791568215,
-		5227097555314997552,
-		839611732066804726,
-		5234648925333584632,
-		11936054402769696488,
-		1228498468693814883,
-		2857848702739380,
-	}
-	x.Mul(x, &y)
+	// 13 = ((2+1)⋅2⋅2)+1: 5 additions are cheaper than a multiplication on 5 words or more
+	_x := *x
+	x.Double(x).Add(x, &_x).Double(x).Double(x).Add(x, &_x)
 }
 
 // Butterfly sets
@@ -70,6 +67,10 @@ func reduce(z *Element) {
 //
 // x and y must be less than q
 func (z *Element) Mul(x, y *Element) *Element {
+	if use32BitLimbs {
+		mul32(z, x, y)
+		return z
+	}
 
 	// Implements CIOS multiplication -- section 2.3.2 of Tolga Acar's thesis
 	// https://www.microsoft.com/en-us/research/wp-content/uploads/1998/06/97Acar.pdf
@@ -877,6 +878,10 @@ func (z *Element) Mul(x, y *Element) *Element {
 // x must be less than q
 func (z *Element) Square(x *Element) *Element {
 	// see Mul for algorithm documentation
+	if use32BitLimbs {
+		mul32(z, x, x)
+		return z
+	}
 
 	var t0, t1, t2, t3, t4, t5, t6, t7, t8, t9 uint64
 	var u0, u1, u2, u3, u4, u5, u6, u7, u8, u9 uint64
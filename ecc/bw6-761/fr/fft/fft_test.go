@@ -252,6 +252,83 @@ func TestFFT(t *testing.T) {
 // --------------------------------------------------------------------
 // benches
 
+func TestArena(t *testing.T) {
+	arena := NewArena(4)
+	sizes := []int{3, 5, 17, 2}
+	alloc := func() [][]fr.Element {
+		res := make([][]fr.Element, len(sizes))
+		for i, n := range sizes {
+			res[i] = arena.Alloc(n)
+		}
+		return res
+	}
+
+	bufs := alloc()
+	for i, b := range bufs {
+		if len(b) != sizes[i] || cap(b) != sizes[i] {
+			t.Fatalf("buffer %d: expected length and capacity %d, got %d, %d", i, sizes[i], len(b), cap(b))
+		}
+		for j := range b {
+			b[j].SetUint64(uint64(i + 1))
+		}
+	}
+	// buffers don't overlap
+	for i, b := range bufs {
+		for j := range b {
+			if !b[j].Equal(new(fr.Element).SetUint64(uint64(i + 1))) {
+				t.Fatal("arena buffers overlap")
+			}
+		}
+	}
+
+	// after a reset, the same pattern fits in the arena and the buffers are zeroed
+	arena.Reset()
+	if allocs := testing.AllocsPerRun(10, func() {
+		arena.Reset()
+		for _, n := range sizes {
+			for _, e := range arena.Alloc(n) {
+				if !e.IsZero() {
+					t.Fatal("buffer is not zeroed")
+				}
+			}
+		}
+	}); allocs != 0 {
+		t.Fatalf("expected no allocation after a reset, got %v", allocs)
+	}
+
+	// a nil arena allocates on the heap
+	var nilArena *Arena
+	if len(nilArena.Alloc(3)) != 3 {
+		t.Fatal("nil arena should allocate")
+	}
+	nilArena.Reset()
+
+	// FFTs using an arena give the same results
+	const size = 1 << 8
+	domain := NewDomain(size, WithoutPrecompute())
+	pol := make([]fr.Element, size)
+	for i := range pol {
+		pol[i].SetRandom()
+	}
+	expected := make([]fr.Element, size)
+	copy(expected, pol)
+	domain.FFT(expected, DIT, OnCoset())
+	domain.FFTInverse(expected, DIF, OnCoset())
+
+	for i := 0; i < 2; i++ {
+		arena.Reset()
+		got := make([]fr.Element, size)
+		copy(got, pol)
+		domain.FFT(got, DIT, OnCoset(), WithArena(arena))
+		domain.FFTInverse(got, DIF, OnCoset(), WithArena(arena))
+		for j := range got {
+			if !got[j].Equal(&expected[j]) {
+				t.Fatal("FFT with an arena is inconsistent")
+			}
+		}
+	}
+}
+
 func BenchmarkFFT(b *testing.B) {
 
 	const maxSize = 1 << 20
@@ -0,0 +1,29 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package logup provides an API to build lookup proofs using the logUp
+// (logarithmic derivative) argument, with KZG commitments.
+//
+// To prove that the rows of the queried columns f = (f₁, .., fₖ) are rows of the
+// table t = (t₁, .., tₖ), the prover commits to the multiplicities m of the rows of t
+// in f and shows that
+//
+//	∑ᵢ 1/(γ - f̃ᵢ) = ∑ⱼ mⱼ/(γ - t̃ⱼ)
+//
+// where f̃ = ∑ αʲ⁻¹ fⱼ and t̃ = ∑ αʲ⁻¹ tⱼ are the columns folded with a random challenge α,
+// and γ is a random challenge. The identity is proven with a running sum φ on the fft
+// domain, see https://eprint.iacr.org/2022/1530.pdf.
+package logup
@@ -27,6 +27,7 @@ type Option func(*mimcConfig)
 
 type mimcConfig struct {
 	byteOrder fr.ByteOrder
+	params    *Params
 }
 
 // default options
@@ -48,3 +49,11 @@ func WithByteOrder(byteOrder fr.ByteOrder) Option {
 		opt.byteOrder = byteOrder
 	}
 }
+
+// WithParams sets the round constants (and thus the number of rounds) of the
+// hasher, see NewParams. Default are the constants derived from "seed".
+func WithParams(params *Params) Option {
+	return func(opt *mimcConfig) {
+		opt.params = params
+	}
+}
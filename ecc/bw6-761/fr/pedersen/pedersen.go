@@ -43,8 +43,20 @@ func randomFrSizedBytes() ([]byte, error) {
 	return res, err
 }
 
+// randomScalar returns a uniformly random scalar in [1, r-1] read from rnd.
+func randomScalar(rnd io.Reader) (*big.Int, error) {
+	var modMinusOne big.Int
+	modMinusOne.Sub(fr.Modulus(), big.NewInt(1))
+	s, err := rand.Int(rnd, &modMinusOne)
+	if err != nil {
+		return nil, err
+	}
+	return s.Add(s, big.NewInt(1)), nil
+}
+
 type setupConfig struct {
 	g2Gen *curve.G2Affine
+	rand  io.Reader
 }
 
 // SetupOption allows to customize Pedersen vector commitment setup.
@@ -58,6 +70,15 @@ func WithG2Point(g2 curve.G2Affine) SetupOption {
 	}
 }
 
+// WithRandomness sets the source of the randomness of the setup (crypto/rand by
+// default). It allows reproducible setups in tests; in production the randomness
+// must be discarded, see [Setup].
+func WithRandomness(r io.Reader) SetupOption {
+	return func(cfg *setupConfig) {
+		cfg.rand = r
+	}
+}
+
 // Setup generates the proving keys for Pedersen commitments over the given
 // bases allowing for batch proving. The common verifying key can be used to
 // verify the batched proof of knowledge.
@@ -77,21 +98,30 @@ func Setup(bases [][]curve.G1Affine, options ...SetupOption) (pk []ProvingKey, v
 	for _, o := range options {
 		o(&cfg)
 	}
-	if cfg.g2Gen == nil {
+	switch {
+	case cfg.g2Gen != nil:
+		vk.G = *cfg.g2Gen
+	case cfg.rand != nil:
+		// G = [s]G₂ for a random s
+		var s *big.Int
+		if s, err = randomScalar(cfg.rand); err != nil {
+			return
+		}
+		_, _, _, g2 := curve.Generators()
+		vk.G.ScalarMultiplication(&g2, s)
+	default:
 		if vk.G, err = curve.RandomOnG2(); err != nil {
 			return
 		}
-	} else {
-		vk.G = *cfg.g2Gen
+	}
+	if cfg.rand == nil {
+		cfg.rand = rand.Reader
 	}
 
-	var modMinusOne big.Int
-	modMinusOne.Sub(fr.Modulus(), big.NewInt(1))
 	var sigma *big.Int
-	if sigma, err = rand.Int(rand.Reader, &modMinusOne); err != nil {
+	if sigma, err = randomScalar(cfg.rand); err != nil {
 		return
 	}
-	sigma.Add(sigma, big.NewInt(1))
 
 	sigmaNeg := new(big.Int).Neg(sigma)
 	vk.GSigma.ScalarMultiplication(&vk.G, sigmaNeg)
@@ -31,7 +31,7 @@ import (
 	"math/big"
 	"strings"
 
-	"github.com/consensys/gnark-crypto/internal/generator/config"
+	"github.com/consensys/gnark-crypto/utils/concurrency"
 )
 
 // ID represent a unique ID for a curve
@@ -66,58 +66,128 @@ func IDFromString(s string) (ID, error) {
 }
 
 func (id ID) String() string {
-	cfg := id.config()
-	return strings.ToLower(cfg.EnumID)
+	return id.info().name
 }
 
 // ScalarField returns the scalar field of the curve
 func (id ID) ScalarField() *big.Int {
-	cfg := id.config()
-	return modulus(cfg, true)
+	return modulus(id.info().frModulus)
 }
 
 // BaseField returns the base field of the curve
 func (id ID) BaseField() *big.Int {
-	cfg := id.config()
-	return modulus(cfg, false)
+	return modulus(id.info().fpModulus)
 }
 
-func (id ID) config() *config.Curve {
-	// note to avoid circular dependency these are hard coded
-	// values are checked for non regression in code generation
+// curveInfo holds the few parameters of a curve exposed through its ID.
+type curveInfo struct {
+	name                 string
+	frModulus, fpModulus string
+}
+
+func (id ID) info() curveInfo {
+	// note to avoid a dependency on the code generator (and its templating engine) these are hard coded
+	// values are checked for non regression against internal/generator/config in tests
 	switch id {
 	case BLS12_377:
-		return &config.BLS12_377
+		return curveInfo{
+			name:      "bls12_377",
+			frModulus: "8444461749428370424248824938781546531375899335154063827935233455917409239041",
+			fpModulus: "258664426012969094010652733694893533536393512754914660539884262666720468348340822774968888139573360124440321458177",
+		}
 	case BLS12_381:
-		return &config.BLS12_381
+		return curveInfo{
+			name:      "bls12_381",
+			frModulus: "52435875175126190479447740508185965837690552500527637822603658699938581184513",
+			fpModulus: "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559787",
+		}
 	case BN254:
-		return &config.BN254
+		return curveInfo{
+			name:      "bn254",
+			frModulus: "21888242871839275222246405745257275088548364400416034343698204186575808495617",
+			fpModulus: "21888242871839275222246405745257275088696311157297823662689037894645226208583",
+		}
 	case BW6_761:
-		return &config.BW6_761
+		return curveInfo{
+			name:      "bw6_761",
+			frModulus: "258664426012969094010652733694893533536393512754914660539884262666720468348340822774968888139573360124440321458177",
+			fpModulus: "6891450384315732539396789682275657542479668912536150109513790160209623422243491736087683183289411687640864567753786613451161759120554247759349511699125301598951605099378508850372543631423596795951899700429969112842764913119068299",
+		}
 	case BW6_633:
-		return &config.BW6_633
+		return curveInfo{
+			name:      "bw6_633",
+			frModulus: "39705142709513438335025689890408969744933502416914749335064285505637884093126342347073617133569",
+			fpModulus: "20494478644167774678813387386538961497669590920908778075528754551012016751717791778743535050360001387419576570244406805463255765034468441182772056330021723098661967429339971741066259394985997",
+		}
 	case BLS24_315:
-		return &config.BLS24_315
+		return curveInfo{
+			name:      "bls24_315",
+			frModulus: "11502027791375260645628074404575422495959608200132055716665986169834464870401",
+			fpModulus: "39705142709513438335025689890408969744933502416914749335064285505637884093126342347073617133569",
+		}
 	case BLS24_317:
-		return &config.BLS24_317
+		return curveInfo{
+			name:      "bls24_317",
+			frModulus: "30869589236456844204538189757527902584594726589286811523515204428962673459201",
+			fpModulus: "136393071104295911515099765908274057061945112121419593977210139303905973197232025618026156731051",
+		}
 	case STARK_CURVE:
-		return &config.STARK_CURVE
+		return curveInfo{
+			name:      "stark_curve",
+			frModulus: "3618502788666131213697322783095070105526743751716087489154079457884512865583",
+			fpModulus: "3618502788666131213697322783095070105623107215331596699973092056135872020481",
+		}
 	case SECP256K1:
-		return &config.SECP256K1
+		return curveInfo{
+			name:      "secp256k1",
+			frModulus: "115792089237316195423570985008687907852837564279074904382605163141518161494337",
+			fpModulus: "115792089237316195423570985008687907853269984665640564039457584007908834671663",
+		}
 	default:
 		panic("unimplemented ecc ID")
 	}
 }
 
-func modulus(c *config.Curve, scalarField bool) *big.Int {
-	if scalarField {
-		return new(big.Int).Set(c.FrInfo.Modulus())
+func modulus(s string) *big.Int {
+	q, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("invalid modulus " + s)
 	}
-
-	return new(big.Int).Set(c.FpInfo.Modulus())
+	return q
 }
 
 // MultiExpConfig enables to set optional configuration attribute to a call to MultiExp
 type MultiExpConfig struct {
-	NbTasks int // go routines to be used in the multiexp. can be larger than num cpus.
+	NbTasks     int             // go routines to be used in the multiexp. can be larger than num cpus. 0 uses the default, see SetNbTasks.
+	C           uint64          // window size of the bucket method. 0 selects it from the number of points (see SetMSMTuning), else it must be implemented for the curve (4 <= C <= 16, 15 for secp256k1).
+	BatchAffine BatchAffineMode // additions of the points into the buckets, see BatchAffineMode.
+}
+
+// BatchAffineMode selects how the MultiExp adds the points into the buckets of the
+// windows larger than 9 bits. For smaller windows, the buckets are few and
+// extended Jacobian additions are always used.
+type BatchAffineMode uint8
+
+const (
+	// BatchAffineAuto uses batched affine additions, sharing a single inversion, if
+	// enough buckets of the window are hit. This is the default.
+	BatchAffineAuto BatchAffineMode = iota
+
+	// BatchAffineOn always uses batched affine additions.
+	BatchAffineOn
+
+	// BatchAffineOff always uses extended Jacobian additions.
+	BatchAffineOff
+)
+
+// SetNbTasks limits the number of go routines spawned by default by the parallel
+// algorithms of gnark-crypto (FFT, MultiExp, vector operations, …). A number of tasks
+// set explicitly in a call overrides it. n <= 0 restores the default, runtime.NumCPU().
+func SetNbTasks(n int) {
+	concurrency.SetMaxTasks(n)
+}
+
+// NbTasks returns the default number of go routines of the parallel algorithms.
+func NbTasks() int {
+	return concurrency.MaxTasks()
 }
@@ -0,0 +1,44 @@
+package ecc_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func TestCurveParameters(t *testing.T) {
+	curves := map[ecc.ID]config.Curve{
+		ecc.BN254:       config.BN254,
+		ecc.BLS12_377:   config.BLS12_377,
+		ecc.BLS12_381:   config.BLS12_381,
+		ecc.BLS24_315:   config.BLS24_315,
+		ecc.BLS24_317:   config.BLS24_317,
+		ecc.BW6_761:     config.BW6_761,
+		ecc.BW6_633:     config.BW6_633,
+		ecc.STARK_CURVE: config.STARK_CURVE,
+		ecc.SECP256K1:   config.SECP256K1,
+	}
+	if len(curves) != len(ecc.Implemented()) {
+		t.Fatal("missing curves in the non-regression test")
+	}
+	for _, id := range ecc.Implemented() {
+		c, ok := curves[id]
+		if !ok {
+			t.Fatalf("no generator config for %s", id)
+		}
+		if id.String() != strings.ToLower(c.EnumID) {
+			t.Errorf("%s: name mismatch with the generator config (%s)", id, c.EnumID)
+		}
+		if id.ScalarField().Cmp(c.FrInfo.Modulus()) != 0 {
+			t.Errorf("%s: scalar field mismatch with the generator config", id)
+		}
+		if id.BaseField().Cmp(c.FpInfo.Modulus()) != 0 {
+			t.Errorf("%s: base field mismatch with the generator config", id)
+		}
+		if parsed, err := ecc.IDFromString(id.String()); err != nil || parsed != id {
+			t.Errorf("%s: IDFromString round trip failed", id)
+		}
+	}
+}
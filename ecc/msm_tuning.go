@@ -0,0 +1,207 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync/atomic"
+
+	"golang.org/x/sys/cpu"
+)
+
+// ErrMSMTuningMachine is returned when loading the tuning of another machine.
+var ErrMSMTuningMachine = errors.New("msm tuning was calibrated on another machine")
+
+// MSMWindow is the window size C of the MultiExp of at least NbPoints points.
+type MSMWindow struct {
+	NbPoints int    `json:"nbPoints"`
+	C        uint64 `json:"c"`
+}
+
+// MSMTuning holds the window sizes of the MultiExp measured on a machine by the
+// CalibrateMultiExp functions of the curve packages. Once set with SetMSMTuning, they
+// replace the heuristic choice of the window size for the calibrated sizes.
+type MSMTuning struct {
+	// Machine identifies the hardware of the calibration, see MachineID.
+	Machine string `json:"machine"`
+
+	// Windows are the window sizes by curve and group ("bn254/G1", …), sorted by
+	// increasing number of points.
+	Windows map[string][]MSMWindow `json:"windows"`
+}
+
+// NewMSMTuning returns an empty tuning for this machine.
+func NewMSMTuning() *MSMTuning {
+	return &MSMTuning{
+		Machine: MachineID(),
+		Windows: make(map[string][]MSMWindow),
+	}
+}
+
+func msmTuningKey(id ID, group string) string {
+	return id.String() + "/" + group
+}
+
+// Set sets the window sizes of the MultiExp in the group of the curve id.
+func (t *MSMTuning) Set(id ID, group string, windows []MSMWindow) {
+	windows = append([]MSMWindow(nil), windows...)
+	sort.Slice(windows, func(i, j int) bool { return windows[i].NbPoints < windows[j].NbPoints })
+	t.Windows[msmTuningKey(id, group)] = windows
+}
+
+// Has returns true if the MultiExp in the group of the curve id is calibrated up
+// to nbPoints points.
+func (t *MSMTuning) Has(id ID, group string, nbPoints int) bool {
+	windows := t.Windows[msmTuningKey(id, group)]
+	return len(windows) != 0 && windows[len(windows)-1].NbPoints >= nbPoints
+}
+
+// WindowSize returns the window size of the MultiExp of nbPoints points in the group
+// of the curve id: the one calibrated for the largest number of points <= nbPoints.
+// It returns false if nbPoints is outside of the calibrated range, that is smaller
+// than the smallest calibrated size, or larger than twice the largest one.
+func (t *MSMTuning) WindowSize(id ID, group string, nbPoints int) (uint64, bool) {
+	windows := t.Windows[msmTuningKey(id, group)]
+	if len(windows) == 0 || nbPoints < windows[0].NbPoints || nbPoints >= 2*windows[len(windows)-1].NbPoints {
+		return 0, false
+	}
+	i := sort.Search(len(windows), func(i int) bool { return windows[i].NbPoints > nbPoints })
+	return windows[i-1].C, true
+}
+
+// WriteTo writes the tuning to w, in JSON.
+func (t *MSMTuning) WriteTo(w io.Writer) (int64, error) {
+	b, err := json.MarshalIndent(t, "", "\t")
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// ReadFrom reads a tuning written by WriteTo from r.
+func (t *MSMTuning) ReadFrom(r io.Reader) (int64, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return int64(len(b)), err
+	}
+	var res MSMTuning
+	if err := json.Unmarshal(b, &res); err != nil {
+		return int64(len(b)), err
+	}
+	if res.Windows == nil {
+		res.Windows = make(map[string][]MSMWindow)
+	}
+	*t = res
+	return int64(len(b)), nil
+}
+
+// Save writes the tuning to the cache file of the machine, see MSMTuningCachePath.
+func (t *MSMTuning) Save() error {
+	path, err := MSMTuningCachePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// write to a temporary file first, so that a concurrent LoadMSMTuning never reads
+	// a partial file
+	f, err := os.CreateTemp(filepath.Dir(path), "msm-*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := t.WriteTo(f); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// LoadMSMTuning reads the tuning saved in the cache file of the machine. It doesn't
+// set it, see SetMSMTuning.
+func LoadMSMTuning() (*MSMTuning, error) {
+	path, err := MSMTuningCachePath()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	t := new(MSMTuning)
+	if _, err := t.ReadFrom(f); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	if t.Machine != MachineID() {
+		return nil, ErrMSMTuningMachine
+	}
+	return t, nil
+}
+
+// MSMTuningCachePath returns the path of the tuning of this machine, in the user
+// cache directory (see os.UserCacheDir).
+func MSMTuningCachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	h := sha256.Sum256([]byte(MachineID()))
+	return filepath.Join(dir, "gnark-crypto", "msm-"+hex.EncodeToString(h[:8])+".json"), nil
+}
+
+// MachineID describes the hardware the performance of the MultiExp depends on: the
+// architecture, the number of CPUs and the instruction set extensions used by the
+// field arithmetic.
+func MachineID() string {
+	id := fmt.Sprintf("%s/%s/%dcpu", runtime.GOOS, runtime.GOARCH, runtime.NumCPU())
+	if runtime.GOARCH == "amd64" {
+		id += fmt.Sprintf("/adx=%t/bmi2=%t/avx512=%t", cpu.X86.HasADX, cpu.X86.HasBMI2, cpu.X86.HasAVX512F)
+	}
+	return id
+}
+
+var msmTuning atomic.Pointer[MSMTuning]
+
+// SetMSMTuning sets the window sizes used by the MultiExp of all curves. nil restores
+// the heuristic choice. The tuning must not be modified afterwards.
+func SetMSMTuning(t *MSMTuning) {
+	msmTuning.Store(t)
+}
+
+// MSMWindowSize returns the window size of the MultiExp of nbPoints points in the
+// group of the curve id set with SetMSMTuning, if any.
+func MSMWindowSize(id ID, group string, nbPoints int) (uint64, bool) {
+	t := msmTuning.Load()
+	if t == nil {
+		return 0, false
+	}
+	return t.WindowSize(id, group, nbPoints)
+}
@@ -0,0 +1,90 @@
+package ecc
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMSMTuning(t *testing.T) {
+	tuning := NewMSMTuning()
+	tuning.Set(BN254, "G1", []MSMWindow{{NbPoints: 1 << 10, C: 8}, {NbPoints: 1 << 8, C: 6}, {NbPoints: 1 << 9, C: 7}})
+
+	for _, tc := range []struct {
+		nbPoints int
+		c        uint64
+		ok       bool
+	}{
+		{1<<8 - 1, 0, false},
+		{1 << 8, 6, true},
+		{1<<9 - 1, 6, true},
+		{1 << 9, 7, true},
+		{1<<11 - 1, 8, true},
+		{1 << 11, 0, false},
+	} {
+		c, ok := tuning.WindowSize(BN254, "G1", tc.nbPoints)
+		if c != tc.c || ok != tc.ok {
+			t.Errorf("%d points: got (%d, %t), expected (%d, %t)", tc.nbPoints, c, ok, tc.c, tc.ok)
+		}
+	}
+	if _, ok := tuning.WindowSize(BN254, "G2", 1<<9); ok {
+		t.Error("G2 is not calibrated")
+	}
+	if !tuning.Has(BN254, "G1", 1<<10) || tuning.Has(BN254, "G1", 1<<10+1) || tuning.Has(BLS12_381, "G1", 1) {
+		t.Error("unexpected calibrated sizes")
+	}
+
+	// global tuning
+	defer SetMSMTuning(nil)
+	SetMSMTuning(tuning)
+	if c, ok := MSMWindowSize(BN254, "G1", 1<<9); !ok || c != 7 {
+		t.Errorf("got (%d, %t)", c, ok)
+	}
+	SetMSMTuning(nil)
+	if _, ok := MSMWindowSize(BN254, "G1", 1<<9); ok {
+		t.Error("tuning not reset")
+	}
+
+	// serialization
+	var buf bytes.Buffer
+	if _, err := tuning.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var decoded MSMTuning
+	if _, err := decoded.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&decoded, tuning) {
+		t.Fatal("decoded tuning differs")
+	}
+}
+
+func TestMSMTuningCache(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	if _, err := LoadMSMTuning(); err == nil {
+		t.Fatal("expected an error without a saved tuning")
+	}
+	tuning := NewMSMTuning()
+	tuning.Set(BLS12_381, "G2", []MSMWindow{{NbPoints: 1 << 8, C: 5}})
+	if err := tuning.Save(); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadMSMTuning()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, tuning) {
+		t.Fatal("loaded tuning differs")
+	}
+
+	tuning.Machine = "another machine"
+	if err := tuning.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadMSMTuning(); !errors.Is(err, ErrMSMTuningMachine) {
+		t.Fatalf("expected ErrMSMTuningMachine, got %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdsa
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+)
+
+// NonceMode selects how the per-signature secret nonce k is derived.
+type NonceMode uint8
+
+const (
+	// NonceRandom derives k from an AES-CTR CSPRNG keyed by
+	// SHA2-512(privateKey ∥ entropy ∥ message). This is the default.
+	NonceRandom NonceMode = iota
+	// NonceDeterministic derives k from the private key and the message
+	// hash only, following RFC 6979, Section 3.2. Signatures are then
+	// reproducible and don't depend on the quality of the system RNG.
+	NonceDeterministic
+	// NonceHedged derives k as in NonceDeterministic, mixing fresh entropy
+	// as additional data k', following RFC 6979, Section 3.6.
+	NonceHedged
+)
+
+// SignOption configures the signing of a message.
+type SignOption func(*signConfig)
+
+type signConfig struct {
+	mode     NonceMode
+	newHash  func() hash.Hash
+	rand     io.Reader
+	blinding bool
+}
+
+// WithNonceMode selects the derivation of the nonce (NonceRandom by default).
+func WithNonceMode(mode NonceMode) SignOption {
+	return func(c *signConfig) {
+		c.mode = mode
+	}
+}
+
+// WithNonceHash sets the hash function instantiating HMAC in the RFC 6979
+// nonce derivation (SHA-256 by default). It should be the hash function used
+// to hash the message.
+func WithNonceHash(newHash func() hash.Hash) SignOption {
+	return func(c *signConfig) {
+		c.newHash = newHash
+	}
+}
+
+// WithNonceEntropy sets the source of the entropy of the NonceRandom nonce
+// derivation, and of the additional entropy mixed in the NonceHedged one
+// (crypto/rand by default). With a seeded reader, the signatures are reproducible.
+func WithNonceEntropy(r io.Reader) SignOption {
+	return func(c *signConfig) {
+		c.rand = r
+	}
+}
+
+func newSignConfig(opts []SignOption) (*signConfig, error) {
+	c := &signConfig{
+		mode:    NonceRandom,
+		newHash: sha256.New,
+		rand:    rand.Reader,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.mode > NonceHedged {
+		return nil, errors.New("unknown nonce mode")
+	}
+	if c.newHash == nil || c.rand == nil {
+		return nil, errors.New("nil nonce hash or entropy source")
+	}
+	return c, nil
+}
+
+// nonceGenerator yields the successive candidate nonces of a signature.
+type nonceGenerator struct {
+	mode    NonceMode
+	privKey *PrivateKey
+	message []byte
+	rand    io.Reader
+
+	// RFC 6979 HMAC_DRBG state
+	newHash func() hash.Hash
+	k, v    []byte
+}
+
+// newNonceGenerator returns the nonce generator for signing message under
+// privKey. digest is the hash of the message, as used in the signature.
+func newNonceGenerator(privKey *PrivateKey, message, digest []byte, c *signConfig) (*nonceGenerator, error) {
+	g := &nonceGenerator{
+		mode:    c.mode,
+		privKey: privKey,
+		message: message,
+		rand:    c.rand,
+	}
+	if c.mode == NonceRandom {
+		return g, nil
+	}
+
+	var extra []byte
+	if c.mode == NonceHedged {
+		extra = make([]byte, 32)
+		if _, err := io.ReadFull(c.rand, extra); err != nil {
+			return nil, err
+		}
+	}
+
+	// RFC 6979, Section 3.2, steps b. to g.
+	g.newHash = c.newHash
+	hLen := c.newHash().Size()
+	g.v = make([]byte, hLen)
+	for i := range g.v {
+		g.v[i] = 0x01
+	}
+	g.k = make([]byte, hLen)
+
+	x := int2octets(new(big.Int).SetBytes(privKey.scalar[:sizeFr]))
+	h := bits2int(digest)
+	if h.Cmp(order) >= 0 {
+		h.Sub(h, order)
+	}
+	h1 := int2octets(h)
+
+	g.k = g.mac(g.v, []byte{0x00}, x, h1, extra)
+	g.v = g.mac(g.v)
+	g.k = g.mac(g.v, []byte{0x01}, x, h1, extra)
+	g.v = g.mac(g.v)
+
+	return g, nil
+}
+
+// next returns the next candidate nonce in [1, order-1].
+func (g *nonceGenerator) next() (*big.Int, error) {
+	if g.mode == NonceRandom {
+		csprng, err := nonce(g.privKey, g.message, g.rand)
+		if err != nil {
+			return nil, err
+		}
+		return randFieldElement(csprng)
+	}
+
+	// RFC 6979, Section 3.2, step h.
+	qLen := order.BitLen()
+	for {
+		var t []byte
+		for len(t)*8 < qLen {
+			g.v = g.mac(g.v)
+			t = append(t, g.v...)
+		}
+		k := bits2int(t)
+
+		// update the state for the next candidate
+		g.k = g.mac(g.v, []byte{0x00})
+		g.v = g.mac(g.v)
+
+		if k.Sign() > 0 && k.Cmp(order) < 0 {
+			return k, nil
+		}
+	}
+}
+
+// mac returns HMAC_K(data[0] ∥ data[1] ∥ ...)
+func (g *nonceGenerator) mac(data ...[]byte) []byte {
+	m := hmac.New(g.newHash, g.k)
+	for _, d := range data {
+		m.Write(d)
+	}
+	return m.Sum(nil)
+}
+
+// bits2int converts a byte string to an integer of at most order.BitLen() bits,
+// keeping the left-most bits (RFC 6979, Section 2.3.2).
+func bits2int(b []byte) *big.Int {
+	x := new(big.Int).SetBytes(b)
+	if excess := len(b)*8 - order.BitLen(); excess > 0 {
+		x.Rsh(x, uint(excess))
+	}
+	return x
+}
+
+// int2octets converts an integer in [0, order) to a big-endian byte string of
+// ⌈order.BitLen()/8⌉ bytes (RFC 6979, Section 2.3.3).
+func int2octets(x *big.Int) []byte {
+	return x.FillBytes(make([]byte, (order.BitLen()+7)/8))
+}
@@ -0,0 +1,26 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+// Mul sets p to p1 * p2 and returns p, using Karatsuba's algorithm, or Toom-3 for large operands.
+// The result has len(p1) + len(p2) - 1 coefficients.
+//
+// fr has no large 2-adic subgroup, so FFT-based multiplication is not available.
+func (p *Polynomial) Mul(p1, p2 Polynomial) *Polynomial {
+	*p = mulKaratsuba(p1, p2)
+	return p
+}
@@ -0,0 +1,163 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package hpke
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/hpke"
+)
+
+func TestHPKE(t *testing.T) {
+	t.Parallel()
+
+	kem := NewKEM()
+	skR, pkR, err := kem.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	skS, pkS, err := kem.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, aad := []byte("info"), []byte("aad")
+	psk, pskID := []byte("a pre-shared key of 32 bytes...."), []byte("psk id")
+	msgs := [][]byte{[]byte("first message"), []byte("second message"), {}}
+
+	for _, aead := range []hpke.AEAD{hpke.AES128GCM, hpke.AES256GCM, hpke.ChaCha20Poly1305} {
+		suite := hpke.NewSuite(kem, hpke.HKDFSHA256, aead)
+
+		type setup struct {
+			name   string
+			sender func() ([]byte, *hpke.Sender, error)
+			recv   func(enc []byte) (*hpke.Receiver, error)
+		}
+		setups := []setup{
+			{"base",
+				func() ([]byte, *hpke.Sender, error) { return suite.SetupBaseS(rand.Reader, pkR, info) },
+				func(enc []byte) (*hpke.Receiver, error) { return suite.SetupBaseR(enc, skR, info) }},
+			{"psk",
+				func() ([]byte, *hpke.Sender, error) { return suite.SetupPSKS(rand.Reader, pkR, info, psk, pskID) },
+				func(enc []byte) (*hpke.Receiver, error) { return suite.SetupPSKR(enc, skR, info, psk, pskID) }},
+			{"auth",
+				func() ([]byte, *hpke.Sender, error) { return suite.SetupAuthS(rand.Reader, pkR, info, skS) },
+				func(enc []byte) (*hpke.Receiver, error) { return suite.SetupAuthR(enc, skR, info, pkS) }},
+			{"auth_psk",
+				func() ([]byte, *hpke.Sender, error) {
+					return suite.SetupAuthPSKS(rand.Reader, pkR, info, psk, pskID, skS)
+				},
+				func(enc []byte) (*hpke.Receiver, error) { return suite.SetupAuthPSKR(enc, skR, info, psk, pskID, pkS) }},
+		}
+
+		for _, s := range setups {
+			enc, sender, err := s.sender()
+			if err != nil {
+				t.Fatal(s.name, err)
+			}
+			receiver, err := s.recv(enc)
+			if err != nil {
+				t.Fatal(s.name, err)
+			}
+			for _, msg := range msgs {
+				ct, err := sender.Seal(aad, msg)
+				if err != nil {
+					t.Fatal(s.name, err)
+				}
+				if _, err := receiver.Open([]byte("wrong aad"), ct); err != hpke.ErrOpen {
+					t.Fatal(s.name, "opened with the wrong aad")
+				}
+				pt, err := receiver.Open(aad, ct)
+				if err != nil {
+					t.Fatal(s.name, err)
+				}
+				if !bytes.Equal(pt, msg) {
+					t.Fatal(s.name, "decrypted message differs")
+				}
+			}
+			e1, _ := sender.Export([]byte("context"), 42)
+			e2, _ := receiver.Export([]byte("context"), 42)
+			if len(e1) != 42 || !bytes.Equal(e1, e2) {
+				t.Fatal(s.name, "exported secrets differ")
+			}
+		}
+	}
+
+	// single shot
+	suite := hpke.NewSuite(kem, hpke.HKDFSHA256, hpke.AES128GCM)
+	msg := []byte("single shot")
+	enc, ct, err := suite.Seal(rand.Reader, pkR, info, aad, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pt, err := suite.Open(enc, skR, info, aad, ct)
+	if err != nil || !bytes.Equal(pt, msg) {
+		t.Fatal("single shot decryption failed")
+	}
+	if _, err := suite.Open(enc, skS, info, aad, ct); err == nil {
+		t.Fatal("opened with the wrong key")
+	}
+	if _, err := suite.Open(enc, skR, []byte("wrong info"), aad, ct); err == nil {
+		t.Fatal("opened with the wrong info")
+	}
+}
+
+func TestDHKEM(t *testing.T) {
+	t.Parallel()
+
+	kem := NewKEM()
+	var g Group
+
+	// deterministic key derivation
+	ikm := []byte("input keying material of 32 byte")
+	sk1, pk1, err := kem.DeriveKeyPair(ikm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sk2, pk2, _ := kem.DeriveKeyPair(ikm)
+	if !bytes.Equal(sk1, sk2) || !bytes.Equal(pk1, pk2) {
+		t.Fatal("DeriveKeyPair is not deterministic")
+	}
+	if len(sk1) != g.PrivateKeySize() || len(pk1) != g.PublicKeySize() {
+		t.Fatal("unexpected key sizes")
+	}
+
+	// invalid public keys are rejected
+	invalid := [][]byte{
+		nil,
+		pk1[1:],
+		append([]byte{0x02}, pk1[1:]...),
+		append([]byte{0x04}, make([]byte, len(pk1)-1)...),
+	}
+	tampered := append([]byte{}, pk1...)
+	tampered[len(tampered)-1] ^= 1
+	invalid = append(invalid, tampered)
+	for i := range invalid {
+		if _, err := g.DH(sk1, invalid[i]); err != hpke.ErrInvalidPublicKey {
+			t.Fatalf("invalid public key %d accepted", i)
+		}
+	}
+
+	// invalid private keys are rejected
+	if _, err := g.PublicKey(make([]byte, g.PrivateKeySize())); err != hpke.ErrInvalidPrivateKey {
+		t.Fatal("zero private key accepted")
+	}
+	if _, err := g.PublicKey(bytes.Repeat([]byte{0xff}, g.PrivateKeySize())); err != hpke.ErrInvalidPrivateKey {
+		t.Fatal("private key larger than the order accepted")
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fiatshamir
+
+import (
+	"encoding/binary"
+	"hash"
+)
+
+// instanceDomain separates the instance prefix from any other data hashed in a transcript.
+const instanceDomain = "fiat-shamir/instance"
+
+// instanceHash is a hash function whose state, after each Reset, already contains the
+// instance prefix.
+type instanceHash struct {
+	hash.Hash
+	prefix []byte
+}
+
+// InstanceHash wraps h so that everything it hashes is bound to instanceID, a unique
+// identifier of the proof instance (e.g. a proof ID, a chain ID or a block height).
+//
+// A transcript built on the returned hash, or a protocol taking a hash function for
+// Fiat-Shamir (kzg.BatchOpenSinglePoint, fri, …), then derives challenges that are
+// specific to the instance: proofs for different instances never share challenges,
+// even when all the other transcript data (e.g. the public inputs) is identical.
+// The prover and the verifier must use the same instanceID.
+func InstanceHash(h hash.Hash, instanceID []byte) hash.Hash {
+	prefix := make([]byte, 0, len(instanceDomain)+8+len(instanceID))
+	prefix = append(prefix, instanceDomain...)
+	prefix = binary.BigEndian.AppendUint64(prefix, uint64(len(instanceID)))
+	prefix = append(prefix, instanceID...)
+
+	res := &instanceHash{Hash: h, prefix: prefix}
+	res.Reset()
+	return res
+}
+
+// Reset resets the wrapped hash and writes the instance prefix into it.
+func (h *instanceHash) Reset() {
+	h.Hash.Reset()
+	h.Hash.Write(h.prefix)
+}
+
+// NewInstanceTranscript returns a new transcript whose challenges are all bound to
+// instanceID. See InstanceHash.
+func NewInstanceTranscript(h hash.Hash, instanceID []byte, challengesID ...string) *Transcript {
+	return NewTranscript(InstanceHash(h, instanceID), challengesID...)
+}
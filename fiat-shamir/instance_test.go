@@ -0,0 +1,60 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fiatshamir
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+)
+
+func TestInstanceTranscript(t *testing.T) {
+
+	challenge := func(fs *Transcript) []byte {
+		if err := fs.Bind("alpha", []byte("public input")); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fs.ComputeChallenge("alpha"); err != nil {
+			t.Fatal(err)
+		}
+		res, err := fs.ComputeChallenge("beta")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+
+	a := challenge(NewInstanceTranscript(sha256.New(), []byte("proof 1"), "alpha", "beta"))
+	b := challenge(NewInstanceTranscript(sha256.New(), []byte("proof 1"), "alpha", "beta"))
+	c := challenge(NewInstanceTranscript(sha256.New(), []byte("proof 2"), "alpha", "beta"))
+	d := challenge(NewTranscript(sha256.New(), "alpha", "beta"))
+	e := challenge(NewInstanceTranscript(sha256.New(), nil, "alpha", "beta"))
+
+	if !bytes.Equal(a, b) {
+		t.Fatal("challenges should be deterministic")
+	}
+	if bytes.Equal(a, c) || bytes.Equal(a, d) || bytes.Equal(d, e) {
+		t.Fatal("challenges should depend on the instance")
+	}
+
+	// the prefix is length-delimited
+	h1 := InstanceHash(sha256.New(), []byte("ab"))
+	h1.Write([]byte("c"))
+	h2 := InstanceHash(sha256.New(), []byte("a"))
+	h2.Write([]byte("bc"))
+	if bytes.Equal(h1.Sum(nil), h2.Sum(nil)) {
+		t.Fatal("instance prefix is ambiguous")
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fiatshamir
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// EventKind is the kind of operation recorded in a transcript trace.
+type EventKind string
+
+const (
+	// EventBind records a value bound to a challenge.
+	EventBind EventKind = "bind"
+	// EventChallenge records the value of a challenge when it is computed.
+	EventChallenge EventKind = "challenge"
+	// EventFork records the creation of a fork; the events of the fork follow
+	// with the label of the fork appended to their scope.
+	EventFork EventKind = "fork"
+)
+
+// Event is an operation on a transcript, as recorded by a Recorder.
+type Event struct {
+	// Scope locates the transcript: empty for the recorded transcript, and the
+	// labels of the forks separated by '/' for its forks.
+	Scope string    `json:"scope,omitempty"`
+	Kind  EventKind `json:"kind"`
+	// Label is the challenge ID, or the label of the fork.
+	Label string `json:"label"`
+	// Data is the bound value or the challenge value, nil for a fork.
+	Data []byte `json:"data,omitempty"`
+}
+
+// Equal returns true if e and other record the same operation.
+func (e *Event) Equal(other *Event) bool {
+	return e.Scope == other.Scope && e.Kind == other.Kind && e.Label == other.Label && bytes.Equal(e.Data, other.Data)
+}
+
+func (e Event) String() string {
+	var buf bytes.Buffer
+	if e.Scope != "" {
+		buf.WriteString(e.Scope)
+		buf.WriteString(": ")
+	}
+	fmt.Fprintf(&buf, "%s %q", e.Kind, e.Label)
+	if e.Data != nil {
+		buf.WriteByte(' ')
+		buf.WriteString(hex.EncodeToString(e.Data))
+	}
+	return buf.String()
+}
+
+// Recorder logs the operations on one or several transcripts, in order. It is meant
+// for debugging: when a verifier rejects a valid proof because its transcript doesn't
+// match the prover's, recording both and comparing the traces with Diff shows the
+// first binding or challenge where they diverge.
+//
+// A Recorder is safe for concurrent use, but the events of transcripts used
+// concurrently are interleaved in a non-deterministic order.
+type Recorder struct {
+	lock   sync.Mutex
+	events []Event
+}
+
+// NewRecorder returns an empty recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Events returns a copy of the recorded events.
+func (r *Recorder) Events() []Event {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]Event(nil), r.events...)
+}
+
+// Reset discards the recorded events.
+func (r *Recorder) Reset() {
+	r.lock.Lock()
+	r.events = nil
+	r.lock.Unlock()
+}
+
+// WriteTo writes the recorded events to w as JSON, one event per line. The trace can
+// be read back with ReadTrace.
+func (r *Recorder) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	enc := json.NewEncoder(cw)
+	for _, e := range r.Events() {
+		if err := enc.Encode(e); err != nil {
+			return cw.n, err
+		}
+	}
+	return cw.n, nil
+}
+
+// ReadTrace reads the events written by Recorder.WriteTo.
+func ReadTrace(r io.Reader) ([]Event, error) {
+	var res []Event
+	dec := json.NewDecoder(bufio.NewReader(r))
+	for {
+		var e Event
+		if err := dec.Decode(&e); err == io.EOF {
+			return res, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("event %d: %w", len(res), err)
+		}
+		res = append(res, e)
+	}
+}
+
+func (r *Recorder) add(e Event) {
+	r.lock.Lock()
+	r.events = append(r.events, e)
+	r.lock.Unlock()
+}
+
+// Record logs the subsequent operations on t, its clones and its forks into r, and
+// returns t. A nil r stops the recording.
+//
+// Only the first computation of a challenge is recorded, so that the traces of a
+// prover and a verifier reading the same challenge several times can be compared.
+func (t *Transcript) Record(r *Recorder) *Transcript {
+	t.recorder = r
+	return t
+}
+
+func (t *Transcript) record(kind EventKind, label string, data []byte) {
+	if t.recorder == nil {
+		return
+	}
+	var d []byte
+	if data != nil {
+		d = append([]byte{}, data...)
+	}
+	t.recorder.add(Event{Scope: t.scope, Kind: kind, Label: label, Data: d})
+}
+
+func forkScope(scope, label string) string {
+	if scope == "" {
+		return label
+	}
+	return scope + "/" + label
+}
+
+// Mismatch is returned by Diff when two traces diverge.
+type Mismatch struct {
+	// Index of the first event that differs.
+	Index int
+	// Prover and Verifier are the events at Index in each trace, nil if the
+	// trace is shorter.
+	Prover, Verifier *Event
+}
+
+func (m *Mismatch) Error() string {
+	describe := func(e *Event) string {
+		if e == nil {
+			return "<end of trace>"
+		}
+		return e.String()
+	}
+	return fmt.Sprintf("transcripts diverge at event %d:\n\tprover:   %s\n\tverifier: %s", m.Index, describe(m.Prover), describe(m.Verifier))
+}
+
+// Diff replays the prover and verifier traces side by side and returns a *Mismatch
+// locating the first event that differs, or nil if the traces are identical.
+//
+// The first differing event is usually a binding: the challenge that follows, and
+// all the subsequent ones, then differ as well.
+func Diff(prover, verifier []Event) error {
+	for i := 0; i < len(prover) || i < len(verifier); i++ {
+		m := &Mismatch{Index: i}
+		if i < len(prover) {
+			m.Prover = &prover[i]
+		}
+		if i < len(verifier) {
+			m.Verifier = &verifier[i]
+		}
+		if m.Prover == nil || m.Verifier == nil || !m.Prover.Equal(m.Verifier) {
+			return m
+		}
+	}
+	return nil
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
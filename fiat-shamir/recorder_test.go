@@ -0,0 +1,126 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fiatshamir
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// runProtocol binds values to alpha and beta, computes them twice, and forks the
+// transcript to compute gamma.
+func runProtocol(t *testing.T, r *Recorder, beta []byte) {
+	fs := NewTranscript(sha256.New(), "alpha", "beta").Record(r)
+	if err := fs.Bind("alpha", []byte("v1")); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := fs.ComputeChallenge("alpha"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := fs.Bind("beta", beta); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fs.ComputeChallenge("beta"); err != nil {
+		t.Fatal(err)
+	}
+	fork, err := fs.Fork("sub", nil, "gamma")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fork.ComputeChallenge("gamma"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	prover := NewRecorder()
+	runProtocol(t, prover, []byte("v2"))
+
+	events := prover.Events()
+	expected := []struct {
+		scope string
+		kind  EventKind
+		label string
+	}{
+		{"", EventBind, "alpha"},
+		{"", EventChallenge, "alpha"},
+		{"", EventBind, "beta"},
+		{"", EventChallenge, "beta"},
+		{"", EventFork, "sub"},
+		{"sub", EventBind, "gamma"},
+		{"sub", EventChallenge, "gamma"},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("expected %d events, got %d: %v", len(expected), len(events), events)
+	}
+	for i, e := range expected {
+		if events[i].Scope != e.scope || events[i].Kind != e.kind || events[i].Label != e.label {
+			t.Fatalf("event %d: expected %s %s %q, got %v", i, e.scope, e.kind, e.label, events[i])
+		}
+	}
+
+	// the trace round-trips through its JSON encoding
+	var buf bytes.Buffer
+	if _, err := prover.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadTrace(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(read, events) {
+		t.Fatal("trace read differs from trace written")
+	}
+
+	// same protocol, same trace
+	verifier := NewRecorder()
+	runProtocol(t, verifier, []byte("v2"))
+	if err := Diff(prover.Events(), verifier.Events()); err != nil {
+		t.Fatal(err)
+	}
+
+	// a different binding is located
+	verifier.Reset()
+	runProtocol(t, verifier, []byte("v3"))
+	var m *Mismatch
+	if err := Diff(prover.Events(), verifier.Events()); !errors.As(err, &m) {
+		t.Fatalf("expected a mismatch, got %v", err)
+	}
+	if m.Index != 2 || m.Prover.Kind != EventBind || m.Prover.Label != "beta" {
+		t.Fatalf("unexpected mismatch: %v", m)
+	}
+
+	// a truncated trace is located
+	if err := Diff(events, events[:4]); !errors.As(err, &m) || m.Index != 4 || m.Verifier != nil {
+		t.Fatalf("unexpected mismatch: %v", err)
+	}
+
+	// recording stops with a nil recorder
+	prover.Reset()
+	fs := NewTranscript(sha256.New(), "alpha").Record(prover).Record(nil)
+	if err := fs.Bind("alpha", []byte("v1")); err != nil {
+		t.Fatal(err)
+	}
+	if len(prover.Events()) != 0 {
+		t.Fatal("no event should be recorded")
+	}
+}
@@ -7,6 +7,11 @@ type Settings struct {
 	Prefix         string
 	BaseChallenges [][]byte
 	Hash           hash.Hash
+
+	// WideChallenges makes the protocols expand the challenges to twice the
+	// size of the field before reducing them, see ComputeChallengeWide. It is
+	// implied by SetWideChallenges(true).
+	WideChallenges bool
 }
 
 func WithTranscript(transcript *Transcript, prefix string, baseChallenges ...[]byte) Settings {
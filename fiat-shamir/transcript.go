@@ -15,9 +15,11 @@
 package fiatshamir
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"hash"
+	"sync/atomic"
 )
 
 // errChallengeNotFound is returned when a wrong challenge name is provided.
@@ -25,8 +27,12 @@ var (
 	errChallengeNotFound            = errors.New("challenge not recorded in the transcript")
 	errChallengeAlreadyComputed     = errors.New("challenge already computed, cannot be binded to other values")
 	errPreviousChallengeNotComputed = errors.New("the previous challenge is needed and has not been computed")
+	errNoChallenge                  = errors.New("a transcript needs at least one challenge")
 )
 
+// forkDomain separates the fork seed from any other data bound in a transcript.
+const forkDomain = "fiat-shamir/fork"
+
 // Transcript handles the creation of challenges for Fiat Shamir.
 type Transcript struct {
 	// hash function that is used.
@@ -34,6 +40,10 @@ type Transcript struct {
 
 	challenges map[string]challenge
 	previous   *challenge
+
+	// recorder, if not nil, logs the bindings and challenges, see Record.
+	recorder *Recorder
+	scope    string
 }
 
 type challenge struct {
@@ -77,6 +87,7 @@ func (t *Transcript) Bind(challengeID string, bValue []byte) error {
 	copy(bCopy, bValue)
 	currentChallenge.bindings = append(currentChallenge.bindings, bCopy)
 	t.challenges[challengeID] = currentChallenge
+	t.record(EventBind, challengeID, bCopy)
 
 	return nil
 
@@ -132,7 +143,170 @@ func (t *Transcript) ComputeChallenge(challengeID string) ([]byte, error) {
 
 	t.challenges[challengeID] = challenge
 	t.previous = &challenge
+	t.record(EventChallenge, challengeID, challenge.value)
 
 	return res, nil
 
 }
+
+// ComputeChallengeWide computes the challenge corresponding to the given name,
+// then expands it to nbBytes bytes:
+// H(challenge || I2OSP(0, 4)) || H(challenge || I2OSP(1, 4)) || ...
+//
+// Reducing twice as many bytes as the size of the field modulo q gives a challenge
+// statistically close to uniform, see ComputeChallengeFr.
+func (t *Transcript) ComputeChallengeWide(challengeID string, nbBytes int) ([]byte, error) {
+	value, err := t.ComputeChallenge(challengeID)
+	if err != nil {
+		return nil, err
+	}
+
+	t.h.Reset()
+	defer t.h.Reset()
+
+	res := make([]byte, 0, nbBytes+t.h.Size())
+	var counter [4]byte
+	for i := uint32(0); len(res) < nbBytes; i++ {
+		t.h.Reset()
+		binary.BigEndian.PutUint32(counter[:], i)
+		if _, err := t.h.Write(value); err != nil {
+			return nil, err
+		}
+		if _, err := t.h.Write(counter[:]); err != nil {
+			return nil, err
+		}
+		res = append(res, t.h.Sum(nil)...)
+	}
+	return res[:nbBytes], nil
+}
+
+// FieldElement is a pointer to a field element, such as *fr.Element, which can be
+// set from a big-endian byte slice of any size, reducing it modulo the field order.
+type FieldElement[E any] interface {
+	*E
+	SetBytes([]byte) *E
+	Marshal() []byte
+}
+
+// ComputeChallengeFr computes the challenge corresponding to the given name as
+// a field element, e.g. fiatshamir.ComputeChallengeFr[fr.Element](t, "gamma").
+//
+// Unlike reducing the output of ComputeChallenge, the result is statistically
+// uniform: the challenge is expanded to twice the size of the field elements
+// before being reduced. The two derivations give different challenges, protocols
+// keep using ComputeChallenge unless configured otherwise, see SetWideChallenges.
+func ComputeChallengeFr[E any, PE FieldElement[E]](t *Transcript, challengeID string) (E, error) {
+	var res E
+	nbBytes := 2 * len(PE(&res).Marshal())
+	b, err := t.ComputeChallengeWide(challengeID, nbBytes)
+	if err != nil {
+		return res, err
+	}
+	PE(&res).SetBytes(b)
+	return res, nil
+}
+
+// wideChallenges is the default challenge derivation of the protocols, see
+// SetWideChallenges.
+var wideChallenges atomic.Bool
+
+// SetWideChallenges sets the default derivation of the field challenges of the
+// protocols in gnark-crypto (kzg, pedersen, permutation, plookup, logup, fri, sumcheck
+// and gkr). When enable is set, they use ComputeChallengeFr; otherwise, the default,
+// they reduce the output of ComputeChallenge, which is slightly biased but keeps the
+// transcripts of the previous releases.
+//
+// This is the migration path to wide challenges: proofs produced with one setting
+// don't verify with the other, so provers and verifiers must agree on it. FRI
+// instances read the setting when they are created (see fri.WithWideChallenges),
+// the other protocols when a proof is computed or verified.
+func SetWideChallenges(enable bool) {
+	wideChallenges.Store(enable)
+}
+
+// WideChallenges reports whether the protocols derive their challenges with
+// ComputeChallengeFr by default, see SetWideChallenges.
+func WideChallenges() bool {
+	return wideChallenges.Load()
+}
+
+// DeriveChallengeFr computes the challenge corresponding to the given name as a
+// field element, with ComputeChallengeFr if WideChallenges is set, or by reducing
+// the output of ComputeChallenge otherwise.
+func DeriveChallengeFr[E any, PE FieldElement[E]](t *Transcript, challengeID string) (E, error) {
+	if WideChallenges() {
+		return ComputeChallengeFr[E, PE](t, challengeID)
+	}
+	var res E
+	b, err := t.ComputeChallenge(challengeID)
+	if err != nil {
+		return res, err
+	}
+	PE(&res).SetBytes(b)
+	return res, nil
+}
+
+// Clone returns an independent copy of t: binding values or computing challenges
+// in the copy doesn't affect t, and vice versa.
+//
+// The copy uses h, which must compute the same function as the hash of t, so that
+// t and the copy can be used concurrently. If h is nil, the copy shares the hash of
+// t and must not be used concurrently with it.
+func (t *Transcript) Clone(h hash.Hash) *Transcript {
+	if h == nil {
+		h = t.h
+	}
+	res := &Transcript{
+		h:          h,
+		challenges: make(map[string]challenge, len(t.challenges)),
+		recorder:   t.recorder,
+		scope:      t.scope,
+	}
+	for id, c := range t.challenges {
+		// copy the slice header so that appending to one doesn't write in the other
+		c.bindings = append([][]byte(nil), c.bindings...)
+		res.challenges[id] = c
+	}
+	if t.previous != nil {
+		previous := *t.previous
+		res.previous = &previous
+	}
+	return res
+}
+
+// Fork returns a new transcript for the given challenges, whose first challenge
+// is bound to label and to the last challenge computed in t (if any). t is not
+// modified.
+//
+// The same label always gives the same fork, while forks with different labels
+// derive independent challenges: this is how a prover derives sub-challenges for
+// independent repetitions of a protocol (e.g. the rounds of FRI).
+//
+// The fork uses h, or shares the hash of t if h is nil (see Clone).
+func (t *Transcript) Fork(label string, h hash.Hash, challengesID ...string) (*Transcript, error) {
+	if len(challengesID) == 0 {
+		return nil, errNoChallenge
+	}
+	if h == nil {
+		h = t.h
+	}
+
+	seed := make([]byte, 0, len(forkDomain)+8+len(label))
+	seed = append(seed, forkDomain...)
+	seed = binary.BigEndian.AppendUint64(seed, uint64(len(label)))
+	seed = append(seed, label...)
+	if t.previous != nil {
+		seed = append(seed, t.previous.value...)
+	}
+
+	res := NewTranscript(h, challengesID...)
+	if t.recorder != nil {
+		t.record(EventFork, label, nil)
+		res.recorder = t.recorder
+		res.scope = forkScope(t.scope, label)
+	}
+	if err := res.Bind(challengesID[0], seed); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -17,7 +17,10 @@ package fiatshamir
 import (
 	"bytes"
 	"crypto/sha256"
+	"math/big"
 	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 )
 
 func initTranscript() *Transcript {
@@ -139,3 +142,217 @@ func TestBindToComputedChallenge(t *testing.T) {
 	}
 
 }
+
+func TestComputeChallengeWide(t *testing.T) {
+	t.Parallel()
+
+	fs := initTranscript()
+	wide, err := fs.ComputeChallengeWide("alpha", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(wide) != 100 {
+		t.Fatal("wrong length")
+	}
+
+	// same transcript, shorter expansion: prefix of the longer one
+	fs = initTranscript()
+	short, err := fs.ComputeChallengeWide("alpha", 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(short, wide[:40]) {
+		t.Fatal("expansion should be deterministic")
+	}
+
+	// the transcript goes on as with ComputeChallenge
+	beta, err := fs.ComputeChallenge("beta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := initTranscript()
+	if _, err := expected.ComputeChallenge("alpha"); err != nil {
+		t.Fatal(err)
+	}
+	expectedBeta, err := expected.ComputeChallenge("beta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(beta, expectedBeta) {
+		t.Fatal("ComputeChallengeWide should not alter the transcript")
+	}
+
+	if _, err := fs.ComputeChallengeWide("unknown", 32); err == nil {
+		t.Fatal("expected an error for an unknown challenge")
+	}
+}
+
+func TestComputeChallengeFr(t *testing.T) {
+	t.Parallel()
+
+	fs := initTranscript()
+	alpha, err := ComputeChallengeFr[fr.Element](fs, "alpha")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs = initTranscript()
+	wide, err := fs.ComputeChallengeWide("alpha", 2*fr.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected fr.Element
+	expected.SetBigInt(new(big.Int).SetBytes(wide))
+	if !alpha.Equal(&expected) {
+		t.Fatal("challenge should be the wide expansion reduced modulo r")
+	}
+
+	if _, err := ComputeChallengeFr[fr.Element](fs, "gamma"); err == nil {
+		t.Fatal("expected an error when the previous challenge is not computed")
+	}
+}
+
+func TestDeriveChallengeFr(t *testing.T) {
+	// not parallel: the test changes the default derivation
+	defer SetWideChallenges(WideChallenges())
+
+	SetWideChallenges(false)
+	fs := initTranscript()
+	narrow, err := DeriveChallengeFr[fr.Element](fs, "alpha")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := initTranscript().ComputeChallenge("alpha")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected fr.Element
+	expected.SetBytes(b)
+	if !narrow.Equal(&expected) {
+		t.Fatal("by default, the challenge should be the output of the hash reduced modulo r")
+	}
+
+	SetWideChallenges(true)
+	wide, err := DeriveChallengeFr[fr.Element](initTranscript(), "alpha")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err = ComputeChallengeFr[fr.Element](initTranscript(), "alpha")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !wide.Equal(&expected) {
+		t.Fatal("with SetWideChallenges, the challenge should be derived with ComputeChallengeFr")
+	}
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	fs := initTranscript()
+	if _, err := fs.ComputeChallenge("alpha"); err != nil {
+		t.Fatal(err)
+	}
+
+	clone := fs.Clone(sha256.New())
+	if err := clone.Bind("beta", []byte("only in the clone")); err != nil {
+		t.Fatal(err)
+	}
+	cloneBeta, err := clone.ComputeChallenge("beta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	beta, err := fs.ComputeChallenge("beta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(beta, cloneBeta) {
+		t.Fatal("binding in the clone should not affect the original")
+	}
+
+	// the original matches a fresh transcript
+	expected := initTranscript()
+	if _, err := expected.ComputeChallenge("alpha"); err != nil {
+		t.Fatal(err)
+	}
+	expectedBeta, err := expected.ComputeChallenge("beta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(beta, expectedBeta) {
+		t.Fatal("the original transcript was modified")
+	}
+
+	// an untouched clone gives the same challenges
+	clone = expected.Clone(nil)
+	gamma, err := clone.ComputeChallenge("gamma")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedGamma, err := expected.ComputeChallenge("gamma")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(gamma, expectedGamma) {
+		t.Fatal("clone and original should derive the same challenges")
+	}
+}
+
+func TestFork(t *testing.T) {
+	t.Parallel()
+
+	fork := func(fs *Transcript, label string) []byte {
+		f, err := fs.Fork(label, sha256.New(), "x", "y")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.ComputeChallenge("x"); err != nil {
+			t.Fatal(err)
+		}
+		y, err := f.ComputeChallenge("y")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return y
+	}
+
+	fs := initTranscript()
+	if _, err := fs.ComputeChallenge("alpha"); err != nil {
+		t.Fatal(err)
+	}
+	a0, a1 := fork(fs, "round 0"), fork(fs, "round 1")
+	if bytes.Equal(a0, a1) {
+		t.Fatal("forks with different labels should be independent")
+	}
+	if !bytes.Equal(a0, fork(fs, "round 0")) {
+		t.Fatal("forks with the same label should be identical")
+	}
+
+	// the fork depends on the state of the parent
+	if _, err := fs.ComputeChallenge("beta"); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(a0, fork(fs, "round 0")) {
+		t.Fatal("forks should be bound to the parent transcript")
+	}
+
+	// the parent is not modified
+	expected := initTranscript()
+	for _, id := range []string{"alpha", "beta", "gamma"} {
+		if _, err := expected.ComputeChallenge(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expectedGamma, _ := expected.ComputeChallenge("gamma")
+	gamma, err := fs.ComputeChallenge("gamma")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(gamma, expectedGamma) {
+		t.Fatal("forking should not modify the parent transcript")
+	}
+
+	if _, err := fs.Fork("empty", nil); err == nil {
+		t.Fatal("expected an error for a fork without challenges")
+	}
+}
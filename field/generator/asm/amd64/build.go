@@ -163,6 +163,11 @@ func Generate(w io.Writer, F *config.FieldConfig) error {
 		f.generateAddVec()
 		f.generateSubVec()
 		f.generateScalarMulVec()
+		f.generateMulAccVec()
+		f.generateMulVec()
+		f.generateSumVec()
+		f.generateSumVecAVX512()
+		f.generateFromMontVec()
 	}
 
 	return nil
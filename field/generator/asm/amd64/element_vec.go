@@ -14,7 +14,11 @@
 
 package amd64
 
-import "github.com/consensys/bavard/amd64"
+import (
+	"fmt"
+
+	"github.com/consensys/bavard/amd64"
+)
 
 // addVec res = a + b
 // func addVec(res, a, b *{{.ElementName}}, n uint64)
@@ -240,3 +244,380 @@ func (f *FFAmd64) generateScalarMulVec() {
 	f.RET()
 
 }
+
+// mulAccVec res = res + a * b
+// func mulAccVec(res, a, b *{{.ElementName}}, n uint64)
+func (f *FFAmd64) generateMulAccVec() {
+	f.Comment("mulAccVec(res, a, b *Element, n uint64) res[0...n] += a[0...n] * b")
+
+	const argSize = 4 * 8
+	const minStackSize = 7 * 8 // 2 slices (3 words each) + pointer to the scalar
+	stackSize := f.StackSize(f.NbWords*2+3, 2, minStackSize)
+	reserved := []amd64.Register{amd64.DX, amd64.AX}
+	registers := f.FnHeader("mulAccVec", stackSize, argSize, reserved...)
+	defer f.AssertCleanStack(stackSize, minStackSize)
+
+	// labels & registers we need
+	noAdx := f.NewLabel("noAdx")
+	loop := f.NewLabel("loop")
+	done := f.NewLabel("done")
+
+	t := registers.PopN(f.NbWords)
+	scalar := registers.PopN(f.NbWords)
+
+	addrB := registers.Pop()
+	addrA := registers.Pop()
+	addrRes := addrB
+	len := registers.Pop()
+
+	// check ADX instruction support
+	f.CMPB("·supportAdx(SB)", 1)
+	f.JNE(noAdx)
+
+	f.MOVQ("a+8(FP)", addrA)
+	f.MOVQ("b+16(FP)", addrB)
+	f.MOVQ("n+24(FP)", len)
+
+	// we store b, the scalar, fully in registers
+	f.LabelRegisters("scalar", scalar...)
+	f.Mov(addrB, scalar)
+
+	xat := func(i int) string {
+		return string(scalar[i])
+	}
+
+	f.MOVQ("res+0(FP)", addrRes)
+
+	f.LABEL(loop)
+	f.TESTQ(len, len)
+	f.JEQ(done, "n == 0, we are done")
+
+	yat := func(i int) string {
+		return addrA.At(i)
+	}
+
+	f.MulADX(&registers, xat, yat, t)
+
+	// reduce; we need at least 4 extra registers
+	registers.Push(amd64.AX, amd64.DX)
+	f.Comment("reduce t mod q")
+	f.Reduce(&registers, t)
+
+	f.Comment("t = t + res")
+	f.Add(addrRes, t)
+	f.Reduce(&registers, t)
+	f.Mov(t, addrRes)
+
+	f.Comment("increment pointers to visit next element")
+	f.ADDQ("$32", addrA)
+	f.ADDQ("$32", addrRes)
+	f.DECQ(len, "decrement n")
+	f.JMP(loop)
+
+	f.LABEL(done)
+	f.RET()
+
+	// no ADX support
+	f.LABEL(noAdx)
+
+	f.MOVQ("n+24(FP)", amd64.DX)
+
+	f.MOVQ("res+0(FP)", amd64.AX)
+	f.MOVQ(amd64.AX, "(SP)")
+	f.MOVQ(amd64.DX, "8(SP)")  // len
+	f.MOVQ(amd64.DX, "16(SP)") // cap
+	f.MOVQ("a+8(FP)", amd64.AX)
+	f.MOVQ(amd64.AX, "24(SP)")
+	f.MOVQ(amd64.DX, "32(SP)") // len
+	f.MOVQ(amd64.DX, "40(SP)") // cap
+	f.MOVQ("b+16(FP)", amd64.AX)
+	f.MOVQ(amd64.AX, "48(SP)")
+	f.WriteLn("CALL ·mulAccVecGeneric(SB)")
+	f.RET()
+
+}
+
+// mulVec res = a * b
+// func mulVec(res, a, b *{{.ElementName}}, n uint64)
+func (f *FFAmd64) generateMulVec() {
+	f.Comment("mulVec(res, a, b *Element, n uint64) res[0...n] = a[0...n] * b[0...n]")
+
+	const argSize = 4 * 8
+	const minStackSize = 9 * 8 // 3 slices (3 words each)
+	stackSize := f.StackSize(f.NbWords+4, 2, minStackSize)
+	reserved := []amd64.Register{amd64.DX, amd64.AX}
+	registers := f.FnHeader("mulVec", stackSize, argSize, reserved...)
+	defer f.AssertCleanStack(stackSize, minStackSize)
+
+	// labels & registers we need
+	noAdx := f.NewLabel("noAdx")
+	loop := f.NewLabel("loop")
+	done := f.NewLabel("done")
+
+	t := registers.PopN(f.NbWords)
+
+	addrA := registers.Pop()
+	addrB := registers.Pop()
+	addrRes := registers.Pop()
+	len := registers.Pop()
+
+	// check ADX instruction support
+	f.CMPB("·supportAdx(SB)", 1)
+	f.JNE(noAdx)
+
+	f.MOVQ("res+0(FP)", addrRes)
+	f.MOVQ("a+8(FP)", addrA)
+	f.MOVQ("b+16(FP)", addrB)
+	f.MOVQ("n+24(FP)", len)
+
+	f.LABEL(loop)
+	f.TESTQ(len, len)
+	f.JEQ(done, "n == 0, we are done")
+
+	xat := func(i int) string {
+		return addrA.At(i)
+	}
+	yat := func(i int) string {
+		return addrB.At(i)
+	}
+
+	f.MulADX(&registers, xat, yat, t)
+
+	// reduce; we need at least 4 extra registers
+	registers.Push(amd64.AX, amd64.DX)
+	f.Comment("reduce t mod q")
+	f.Reduce(&registers, t)
+	f.Mov(t, addrRes)
+
+	f.Comment("increment pointers to visit next element")
+	f.ADDQ("$32", addrA)
+	f.ADDQ("$32", addrB)
+	f.ADDQ("$32", addrRes)
+	f.DECQ(len, "decrement n")
+	f.JMP(loop)
+
+	f.LABEL(done)
+	f.RET()
+
+	// no ADX support
+	f.LABEL(noAdx)
+
+	f.MOVQ("n+24(FP)", amd64.DX)
+
+	f.MOVQ("res+0(FP)", amd64.AX)
+	f.MOVQ(amd64.AX, "(SP)")
+	f.MOVQ(amd64.DX, "8(SP)")  // len
+	f.MOVQ(amd64.DX, "16(SP)") // cap
+	f.MOVQ("a+8(FP)", amd64.AX)
+	f.MOVQ(amd64.AX, "24(SP)")
+	f.MOVQ(amd64.DX, "32(SP)") // len
+	f.MOVQ(amd64.DX, "40(SP)") // cap
+	f.MOVQ("b+16(FP)", amd64.AX)
+	f.MOVQ(amd64.AX, "48(SP)")
+	f.MOVQ(amd64.DX, "56(SP)") // len
+	f.MOVQ(amd64.DX, "64(SP)") // cap
+	f.WriteLn("CALL ·mulVecGeneric(SB)")
+	f.RET()
+
+}
+
+// sumVec res = a[0] + ... + a[n-1]
+// func sumVec(res, a *{{.ElementName}}, n uint64)
+func (f *FFAmd64) generateSumVec() {
+	f.Comment("sumVec(res, a *Element, n uint64) res = a[0] + ... + a[n-1]")
+
+	const argSize = 3 * 8
+	stackSize := f.StackSize(f.NbWords*2+2, 0, 0)
+	registers := f.FnHeader("sumVec", stackSize, argSize)
+	defer f.AssertCleanStack(stackSize, 0)
+
+	// registers & labels we need
+	addrA := f.Pop(&registers)
+	len := f.Pop(&registers)
+
+	acc := f.PopN(&registers)
+	t := f.PopN(&registers)
+
+	loop := f.NewLabel("loop")
+	done := f.NewLabel("done")
+
+	// load arguments
+	f.MOVQ("a+8(FP)", addrA)
+	f.MOVQ("n+16(FP)", len)
+
+	// acc = 0
+	f.LabelRegisters("acc", acc...)
+	for i := 0; i < f.NbWords; i++ {
+		f.XORQ(acc[i], acc[i])
+	}
+
+	f.LABEL(loop)
+
+	f.TESTQ(len, len)
+	f.JEQ(done, "n == 0, we are done")
+
+	// acc = acc + a
+	f.Add(addrA, acc)
+
+	// reduce acc
+	f.ReduceElement(acc, t)
+
+	f.Comment("increment pointer to visit next element")
+	f.ADDQ("$32", addrA)
+	f.DECQ(len, "decrement n")
+	f.JMP(loop)
+
+	f.LABEL(done)
+
+	// save acc into res
+	f.MOVQ("res+0(FP)", addrA)
+	f.Mov(acc, addrA)
+
+	f.RET()
+
+	f.Push(&registers, acc...)
+	f.Push(&registers, t...)
+	f.Push(&registers, addrA, len)
+
+}
+
+// fromMontVec a = a * R⁻¹, in place
+// func fromMontVec(a *{{.ElementName}}, n uint64)
+func (f *FFAmd64) generateFromMontVec() {
+	f.Comment("fromMontVec(a *Element, n uint64) a[0...n] = a[0...n] * R⁻¹")
+
+	const argSize = 2 * 8
+	const minStackSize = 3 * 8 // 1 slice (3 words)
+	stackSize := f.StackSize(f.NbWords*2+2, 3, minStackSize)
+	// R15 is clobbered when dynamic linking, see generateFromMont
+	reserved := []amd64.Register{amd64.DX, amd64.AX, amd64.R15}
+	registers := f.FnHeader("fromMontVec", stackSize, argSize, reserved...)
+	defer f.AssertCleanStack(stackSize, minStackSize)
+
+	// labels & registers we need
+	noAdx := f.NewLabel("noAdx")
+	loop := f.NewLabel("loop")
+	done := f.NewLabel("done")
+
+	t := registers.PopN(f.NbWords)
+	addrA := registers.Pop()
+	len := registers.Pop()
+
+	// check ADX instruction support
+	f.CMPB("·supportAdx(SB)", 1)
+	f.JNE(noAdx)
+
+	f.MOVQ("a+0(FP)", addrA)
+	f.MOVQ("n+8(FP)", len)
+
+	f.LABEL(loop)
+	f.TESTQ(len, len)
+	f.JEQ(done, "n == 0, we are done")
+
+	// same as fromMont, with y = 1
+	f.Mov(addrA, t)
+	for i := 0; i < f.NbWords; i++ {
+		f.Comment("m := t[0]*q'[0] mod W")
+		m := amd64.DX
+		f.MOVQ(f.qInv0(), m)
+		f.IMULQ(t[0], m)
+
+		// clear the carry flags
+		f.XORQ(amd64.AX, amd64.AX)
+
+		f.Comment("C,_ := t[0] + m*q[0]")
+		f.MULXQ(f.qAt(0), amd64.AX, amd64.BP)
+		f.ADCXQ(t[0], amd64.AX)
+		f.MOVQ(amd64.BP, t[0])
+
+		for j := 1; j < f.NbWords; j++ {
+			f.Comment(fmt.Sprintf("(C,t[%[1]d]) := t[%[2]d] + m*q[%[2]d] + C", j-1, j))
+			f.ADCXQ(t[j], t[j-1])
+			f.MULXQ(f.qAt(j), amd64.AX, t[j])
+			f.ADOXQ(amd64.AX, t[j-1])
+		}
+		f.MOVQ(0, amd64.AX)
+		f.ADCXQ(amd64.AX, t[f.NbWordsLastIndex])
+		f.ADOXQ(amd64.AX, t[f.NbWordsLastIndex])
+	}
+
+	f.Comment("reduce t mod q")
+	f.Reduce(&registers, t)
+	f.Mov(t, addrA)
+
+	f.Comment("increment pointer to visit next element")
+	f.ADDQ("$32", addrA)
+	f.DECQ(len, "decrement n")
+	f.JMP(loop)
+
+	f.LABEL(done)
+	f.RET()
+
+	// no ADX support
+	f.LABEL(noAdx)
+
+	f.MOVQ("n+8(FP)", amd64.DX)
+
+	f.MOVQ("a+0(FP)", amd64.AX)
+	f.MOVQ(amd64.AX, "(SP)")
+	f.MOVQ(amd64.DX, "8(SP)")  // len
+	f.MOVQ(amd64.DX, "16(SP)") // cap
+	f.WriteLn("CALL ·fromMontVecGeneric(SB)")
+	f.RET()
+}
+
+// sumVecAVX512 t[i] = Σⱼ a[j]ᵢ, where a[j]ᵢ is the i-th 32-bit limb of a[j]
+// func sumVecAVX512(t *[8]uint64, a *{{.ElementName}}, n uint64)
+//
+// each element is zero extended from 8 32-bit limbs to 8 64-bit lanes of a ZMM register,
+// such that the lanes can be accumulated without carry propagation as long as n < 2³².
+// the caller recombines and reduces the 8 lanes.
+func (f *FFAmd64) generateSumVecAVX512() {
+	f.Comment("sumVecAVX512(t *[8]uint64, a *Element, n uint64) t[i] = a[0]ᵢ + ... + a[n-1]ᵢ (32-bit limbs)")
+
+	const argSize = 3 * 8
+	registers := f.FnHeader("sumVecAVX512", 0, argSize)
+
+	addrA := registers.Pop()
+	len := registers.Pop()
+	addrT := registers.Pop()
+
+	loop := f.NewLabel("loop")
+	tail := f.NewLabel("tail")
+	done := f.NewLabel("done")
+
+	f.MOVQ("a+8(FP)", addrA)
+	f.MOVQ("n+16(FP)", len)
+
+	f.Comment("two accumulators to break the dependency chain")
+	f.WriteLn("    VPXORQ Z0, Z0, Z0")
+	f.WriteLn("    VPXORQ Z1, Z1, Z1")
+
+	f.LABEL(loop)
+	f.CMPQ(len, "$2")
+	f.JCS(tail, "n < 2")
+	f.WriteLn(fmt.Sprintf("    VPMOVZXDQ 0(%s), Z2", addrA))
+	f.WriteLn(fmt.Sprintf("    VPMOVZXDQ 32(%s), Z3", addrA))
+	f.WriteLn("    VPADDQ Z2, Z0, Z0")
+	f.WriteLn("    VPADDQ Z3, Z1, Z1")
+
+	f.Comment("increment pointer to visit next elements")
+	f.ADDQ("$64", addrA)
+	f.SUBQ("$2", len)
+	f.JMP(loop)
+
+	f.LABEL(tail)
+	f.TESTQ(len, len)
+	f.JEQ(done, "n == 0, we are done")
+	f.WriteLn(fmt.Sprintf("    VPMOVZXDQ 0(%s), Z2", addrA))
+	f.WriteLn("    VPADDQ Z2, Z0, Z0")
+
+	f.LABEL(done)
+	f.WriteLn("    VPADDQ Z1, Z0, Z0")
+	f.MOVQ("t+0(FP)", addrT)
+	f.WriteLn(fmt.Sprintf("    VMOVDQU64 Z0, 0(%s)", addrT))
+	f.WriteLn("    VZEROUPPER")
+	f.RET()
+
+	registers.Push(addrA, len, addrT)
+}
@@ -50,6 +50,7 @@ type FieldConfig struct {
 	IsMSWSaturated            bool // indicates if the most significant word is 0xFFFFF...FFFF
 	Q                         []uint64
 	QInverse                  []uint64
+	BarrettMu                 []uint64 // ⌊2^(128·NbWords) / q⌋, on NbWords+1 words
 	QMinusOneHalvedP          []uint64 // ((q-1) / 2 ) + 1
 	ASM                       bool
 	RSquare                   []uint64
@@ -117,6 +118,11 @@ func NewFieldConfig(packageName, elementName, modulus string, useAddChain bool)
 	_qInv.Mod(_qInv, _r)
 	F.QInverse = toUint64Slice(_qInv, F.NbWords)
 
+	// Barrett constant, for the canonical (non-Montgomery) element
+	_mu := big.NewInt(1)
+	_mu.Lsh(_mu, uint(F.NbWords)*128).Div(_mu, &bModulus)
+	F.BarrettMu = toUint64Slice(_mu, F.NbWords+1)
+
 	// Pornin20 inversion correction factors
 	k := 32 // Optimized for 64 bit machines, still works for 32
 
@@ -16,13 +16,34 @@ import (
 	"github.com/consensys/gnark-crypto/field/generator/internal/templates/element"
 )
 
+// Option modifies the set of generated files of GenerateFF.
+type Option func(*generatorConfig)
+
+type generatorConfig struct {
+	canonical bool
+}
+
+// WithCanonical also generates Canonical<ElementName>, an element stored in canonical
+// (non-Montgomery) form with Barrett multiplication, for workloads dominated by
+// conversions to and from bytes.
+func WithCanonical() Option {
+	return func(c *generatorConfig) {
+		c.canonical = true
+	}
+}
+
 // GenerateFF will generate go (and .s) files in outputDir for modulus (in base 10)
 //
 // Example usage
 //
 //	fp, _ = config.NewField("fp", "Element", fpModulus")
 //	generator.GenerateFF(fp, filepath.Join(baseDir, "fp"))
-func GenerateFF(F *config.FieldConfig, outputDir string) error {
+func GenerateFF(F *config.FieldConfig, outputDir string, opts ...Option) error {
+	var cfg generatorConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	// source file templates
 	sourceFiles := []string{
 		element.Base,
@@ -32,6 +53,7 @@ func GenerateFF(F *config.FieldConfig, outputDir string) error {
 		element.MulDoc,
 		element.MulCIOS,
 		element.MulNoCarry,
+		element.MulCIOSSum,
 		element.Sqrt,
 		element.Inverse,
 		element.BigNum,
@@ -44,6 +66,7 @@ func GenerateFF(F *config.FieldConfig, outputDir string) error {
 		element.Reduce,
 		element.Test,
 		element.InverseTests,
+		element.FixedExpRuntimeTests,
 	}
 	// output files
 	eName := strings.ToLower(F.ElementName)
@@ -51,9 +74,12 @@ func GenerateFF(F *config.FieldConfig, outputDir string) error {
 	pathSrc := filepath.Join(outputDir, eName+".go")
 	pathSrcVector := filepath.Join(outputDir, "vector.go")
 	pathSrcFixedExp := filepath.Join(outputDir, eName+"_exp.go")
+	pathSrcFixedExpRuntime := filepath.Join(outputDir, "fixedexp.go")
 	pathSrcArith := filepath.Join(outputDir, "arith.go")
 	pathTest := filepath.Join(outputDir, eName+"_test.go")
 	pathTestVector := filepath.Join(outputDir, "vector_test.go")
+	pathSrcCanonical := filepath.Join(outputDir, eName+"_canonical.go")
+	pathTestCanonical := filepath.Join(outputDir, eName+"_canonical_test.go")
 
 	// remove old format generated files
 	oldFiles := []string{"_mul.go", "_mul_amd64.go",
@@ -103,6 +129,11 @@ func GenerateFF(F *config.FieldConfig, outputDir string) error {
 		return err
 	}
 
+	// generate runtime fixed exponentiation
+	if err := bavard.GenerateFromString(pathSrcFixedExpRuntime, []string{element.FixedExpRuntime}, F, bavardOpts...); err != nil {
+		return err
+	}
+
 	// generate arithmetics source file
 	if err := bavard.GenerateFromString(pathSrcArith, []string{element.Arith}, F, bavardOpts...); err != nil {
 		return err
@@ -124,6 +155,19 @@ func GenerateFF(F *config.FieldConfig, outputDir string) error {
 		return err
 	}
 
+	// generate canonical element
+	if cfg.canonical {
+		if err := bavard.GenerateFromString(pathSrcCanonical, []string{element.Canonical}, F, bavardOpts...); err != nil {
+			return err
+		}
+		if err := bavard.GenerateFromString(pathTestCanonical, []string{element.TestCanonical}, F, bavardOpts...); err != nil {
+			return err
+		}
+	} else {
+		_ = os.Remove(pathSrcCanonical)
+		_ = os.Remove(pathTestCanonical)
+	}
+
 	// if we generate assembly code
 	if F.ASM {
 		// generate ops.s
@@ -7,6 +7,8 @@ import 	"golang.org/x/sys/cpu"
 var (
 	supportAdx = cpu.X86.HasADX && cpu.X86.HasBMI2
 	_ = supportAdx
+	supportAvx512 = supportAdx && cpu.X86.HasAVX512F
+	_ = supportAvx512
 )
 `
 
@@ -19,5 +21,7 @@ const AsmNoAdx = `
 var (
 	supportAdx = false
 	_ = supportAdx
+	supportAvx512 = false
+	_ = supportAvx512
 )
 `
@@ -524,6 +524,46 @@ func (z *{{.ElementName}}) Select(c int, x0 *{{.ElementName}}, x1 *{{.ElementNam
 	return z
 }
 
+// MulAdd z = x * y + c (mod q)
+//
+// c is added to the unreduced CIOS product x⋅y, which is then reduced once, instead of
+// reducing x⋅y and then adding c.
+func (z *{{.ElementName}}) MulAdd(x, y, c *{{.ElementName}}) *{{.ElementName}} {
+	{{ template "mul_cios_sum" dict "all" . "V1" "x" "V2" "y"}}
+	{{- range $i := .NbWordsIndexesFull}}
+	t[{{$i}}], C = bits.Add64(t[{{$i}}], c[{{$i}}], {{if $i}}C{{else}}0{{end}})
+	{{- end}}
+	t[{{.NbWords}}] += C
+	{{ template "mul_cios_sum_reduce" . }}
+	return z
+}
+
+// AddMul z = (x + y) * c (mod q)
+//
+// x + y is kept unreduced, on Limbs+1 words, and the product is reduced once.
+func (z *{{.ElementName}}) AddMul(x, y, c *{{.ElementName}}) *{{.ElementName}} {
+	var s {{.ElementName}}
+	var carry uint64
+	{{- range $i := .NbWordsIndexesFull}}
+	s[{{$i}}], carry = bits.Add64(x[{{$i}}], y[{{$i}}], carry)
+	{{- end}}
+	mask := -carry
+
+	{{ template "mul_cios_sum" dict "all" . "V1" "s" "V2" "c" "Mask" "mask"}}
+	{{ template "mul_cios_sum_reduce" . }}
+	return z
+}
+
+// Lincomb z = a * x + b * y (mod q)
+//
+// The two products are accumulated in the same CIOS loop and reduced once, instead of
+// being reduced separately and then added.
+func (z *{{.ElementName}}) Lincomb(a, b, x, y *{{.ElementName}}) *{{.ElementName}} {
+	{{ template "mul_cios_sum" dict "all" . "V1" "a" "V2" "x" "V3" "b" "V4" "y"}}
+	{{ template "mul_cios_sum_reduce" . }}
+	return z
+}
+
 // _mulGeneric is unoptimized textbook CIOS
 // it is a fallback solution on x86 when ADX instruction set is not available
 // and is used for testing purposes.
@@ -534,6 +574,55 @@ func _mulGeneric(z,x,y *{{.ElementName}}) {
 }
 
 
+// mul32 sets z = x * y (mod q) with CIOS on 32-bit limbs. It replaces the 64-bit limbs
+// multiplication on the platforms where bits.Mul64 is emulated (see use32BitLimbs).
+// The Montgomery form is the same, as R = 2^(64·Limbs) = 2^(32·2·Limbs).
+func mul32(z, x, y *{{.ElementName}}) {
+	const n = 2 * Limbs
+	const qInvNeg32 = uint32(qInvNeg & 0xffffffff) // - q⁻¹ mod 2³²
+
+	var a, b, q [n]uint32
+	for i := 0; i < Limbs; i++ {
+		a[2*i], a[2*i+1] = uint32(x[i]), uint32(x[i]>>32)
+		b[2*i], b[2*i+1] = uint32(y[i]), uint32(y[i]>>32)
+		q[2*i], q[2*i+1] = uint32(q{{.ElementName}}[i]), uint32(q{{.ElementName}}[i]>>32)
+	}
+
+	// the products fit in a uint64: (2³²-1)² + 2·(2³²-1) = 2⁶⁴-1
+	var t [n + 2]uint32
+	for i := 0; i < n; i++ {
+		// t += a · b[i]
+		var c uint64
+		for j := 0; j < n; j++ {
+			p := uint64(a[j])*uint64(b[i]) + uint64(t[j]) + c
+			t[j], c = uint32(p), p>>32
+		}
+		p := uint64(t[n]) + c
+		t[n], t[n+1] = uint32(p), uint32(p>>32)
+
+		// t = (t + m · q) / 2³²
+		m := t[0] * qInvNeg32
+		c = (uint64(m)*uint64(q[0]) + uint64(t[0])) >> 32
+		for j := 1; j < n; j++ {
+			p := uint64(m)*uint64(q[j]) + uint64(t[j]) + c
+			t[j-1], c = uint32(p), p>>32
+		}
+		p = uint64(t[n]) + c
+		t[n-1], t[n] = uint32(p), t[n+1]+uint32(p>>32)
+	}
+
+	// t < 2q
+	for i := 0; i < Limbs; i++ {
+		z[i] = uint64(t[2*i]) | uint64(t[2*i+1])<<32
+	}
+	if t[n] != 0 || !z.smallerThanModulus() {
+		var borrow uint64
+		for i := 0; i < Limbs; i++ {
+			z[i], borrow = bits.Sub64(z[i], q{{.ElementName}}[i], borrow)
+		}
+	}
+}
+
 func _fromMontGeneric(z *{{.ElementName}}) {
 	// the following lines implement z = z * 1
 	// with a modified CIOS montgomery multiplication
@@ -561,8 +650,26 @@ func _reduceGeneric(z *{{.ElementName}})  {
 // Uses Montgomery batch inversion trick
 func BatchInvert(a []{{.ElementName}}) []{{.ElementName}} {
 	res := make([]{{.ElementName}}, len(a))
+	batchInvert(res, a)
+	return res
+}
+
+// BatchInvertParallel returns a new slice with every element inverted.
+// a is split in chunks, inverted in parallel with the Montgomery batch inversion trick,
+// at the cost of one field inversion per chunk.
+// nbTasks sets the max number of go routines to spawn; default is concurrency.MaxTasks().
+func BatchInvertParallel(a []{{.ElementName}}, nbTasks ...int) []{{.ElementName}} {
+	res := make([]{{.ElementName}}, len(a))
+	execute(len(a), func(start, end int) {
+		batchInvert(res[start:end], a[start:end])
+	}, nbTasks...)
+	return res
+}
+
+// batchInvert sets res[i] = a[i]⁻¹ (0 if a[i] == 0); res and a must not overlap.
+func batchInvert(res, a []{{.ElementName}}) {
 	if len(a) == 0 {
-		return res
+		return
 	}
 
 	zeroes := bitset.New(uint(len(a)))
@@ -571,6 +678,7 @@ func BatchInvert(a []{{.ElementName}}) []{{.ElementName}} {
 	for i:=0; i < len(a); i++ {
 		if a[i].IsZero() {
 			zeroes.Set(uint(i))
+			res[i].SetZero()
 			continue
 		}
 		res[i] = accumulator
@@ -586,8 +694,6 @@ func BatchInvert(a []{{.ElementName}}) []{{.ElementName}} {
 		res[i].Mul(&res[i], &accumulator)
 		accumulator.Mul(&accumulator, &a[i])
 	}
-
-	return res
 }
 
 func _butterflyGeneric(a, b *{{.ElementName}}) {
@@ -621,18 +727,11 @@ func Hash(msg, dst []byte, count int) ([]{{.ElementName}}, error) {
 		return nil, err
 	}
 
-	// get temporary big int from the pool
-	vv := pool.BigInt.Get()
-
 	res := make([]{{.ElementName}}, count)
 	for i := 0; i < count; i++ {
-		vv.SetBytes(pseudoRandomBytes[i*L : (i+1)*L])
-		res[i].SetBigInt(vv)
+		res[i].setBytesWide(pseudoRandomBytes[i*L : (i+1)*L])
 	}
 
-	// release object into pool
-	pool.BigInt.Put(vv)
-
 	return res, nil
 }
 
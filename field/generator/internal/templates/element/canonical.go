@@ -0,0 +1,249 @@
+package element
+
+const Canonical = `
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/field/pool"
+)
+
+// Canonical{{.ElementName}} is a field element stored in canonical (non-Montgomery) form, in [0, q).
+//
+// Setting it from bytes or a big.Int and encoding it are plain copies, with no Montgomery
+// conversion; in exchange, Mul uses a Barrett reduction, slower than the Montgomery
+// multiplication of {{.ElementName}}. It suits workloads dominated by encoding and
+// decoding, such as hashing or transcripts; SetElement and Element convert from and to
+// {{.ElementName}} when heavier arithmetic is needed.
+type Canonical{{.ElementName}} [Limbs]uint64
+
+// barrettMu = ⌊2^(128·Limbs) / q⌋
+var barrettMu = [Limbs + 1]uint64{
+	{{- range $w := .BarrettMu}}
+	{{$w}},
+	{{- end}}
+}
+
+// SetElement sets z to the canonical form of x and returns z
+func (z *Canonical{{.ElementName}}) SetElement(x *{{.ElementName}}) *Canonical{{.ElementName}} {
+	*z = x.Bits()
+	return z
+}
+
+// Element returns z in Montgomery form
+func (z *Canonical{{.ElementName}}) Element() {{.ElementName}} {
+	res := {{.ElementName}}(*z)
+	res.toMont()
+	return res
+}
+
+// SetUint64 sets z to v and returns z
+func (z *Canonical{{.ElementName}}) SetUint64(v uint64) *Canonical{{.ElementName}} {
+	{{- if eq .NbWords 1}}
+	*z = Canonical{{.ElementName}}{v % q0}
+	{{- else}}
+	*z = Canonical{{.ElementName}}{v}
+	{{- end}}
+	return z
+}
+
+// SetZero z = 0
+func (z *Canonical{{.ElementName}}) SetZero() *Canonical{{.ElementName}} {
+	*z = Canonical{{.ElementName}}{}
+	return z
+}
+
+// SetOne z = 1
+func (z *Canonical{{.ElementName}}) SetOne() *Canonical{{.ElementName}} {
+	*z = Canonical{{.ElementName}}{1}
+	return z
+}
+
+// IsZero returns z == 0
+func (z *Canonical{{.ElementName}}) IsZero() bool {
+	return (*{{.ElementName}})(z).IsZero()
+}
+
+// Equal returns z == x
+func (z *Canonical{{.ElementName}}) Equal(x *Canonical{{.ElementName}}) bool {
+	return *z == *x
+}
+
+// Add z = x + y (mod q)
+func (z *Canonical{{.ElementName}}) Add(x, y *Canonical{{.ElementName}}) *Canonical{{.ElementName}} {
+	// modular addition does not depend on the Montgomery form
+	(*{{.ElementName}})(z).Add((*{{.ElementName}})(x), (*{{.ElementName}})(y))
+	return z
+}
+
+// Double z = x + x (mod q)
+func (z *Canonical{{.ElementName}}) Double(x *Canonical{{.ElementName}}) *Canonical{{.ElementName}} {
+	(*{{.ElementName}})(z).Double((*{{.ElementName}})(x))
+	return z
+}
+
+// Sub z = x - y (mod q)
+func (z *Canonical{{.ElementName}}) Sub(x, y *Canonical{{.ElementName}}) *Canonical{{.ElementName}} {
+	(*{{.ElementName}})(z).Sub((*{{.ElementName}})(x), (*{{.ElementName}})(y))
+	return z
+}
+
+// Neg z = q - x
+func (z *Canonical{{.ElementName}}) Neg(x *Canonical{{.ElementName}}) *Canonical{{.ElementName}} {
+	(*{{.ElementName}})(z).Neg((*{{.ElementName}})(x))
+	return z
+}
+
+// Mul z = x * y (mod q), with a Barrett reduction
+func (z *Canonical{{.ElementName}}) Mul(x, y *Canonical{{.ElementName}}) *Canonical{{.ElementName}} {
+	var t [2 * Limbs]uint64
+	mulWide(t[:], x[:], y[:])
+	z.barrettReduce(&t)
+	return z
+}
+
+// Square z = x * x (mod q), with a Barrett reduction
+func (z *Canonical{{.ElementName}}) Square(x *Canonical{{.ElementName}}) *Canonical{{.ElementName}} {
+	return z.Mul(x, x)
+}
+
+// Inverse z = x⁻¹ (mod q), computed in Montgomery form.
+//
+// if x == 0, sets and returns z = x
+func (z *Canonical{{.ElementName}}) Inverse(x *Canonical{{.ElementName}}) *Canonical{{.ElementName}} {
+	e := x.Element()
+	e.Inverse(&e)
+	return z.SetElement(&e)
+}
+
+// SetBytes interprets e as the bytes of a big-endian unsigned integer,
+// sets z to that value (in canonical form), and returns z.
+func (z *Canonical{{.ElementName}}) SetBytes(e []byte) *Canonical{{.ElementName}} {
+	if len(e) == Bytes {
+		// fast path
+		if err := z.SetBytesCanonical(e); err == nil {
+			return z
+		}
+	}
+
+	// slow path.
+	vv := pool.BigInt.Get()
+	vv.SetBytes(e)
+	z.SetBigInt(vv)
+	pool.BigInt.Put(vv)
+
+	return z
+}
+
+// SetBytesCanonical interprets e as the bytes of a big-endian {{.NbBytes}}-byte integer.
+// If e is not a {{.NbBytes}}-byte slice or encodes a value higher than q,
+// SetBytesCanonical returns an error.
+func (z *Canonical{{.ElementName}}) SetBytesCanonical(e []byte) error {
+	if len(e) != Bytes {
+		return errors.New("invalid {{.PackageName}}.Canonical{{.ElementName}} encoding")
+	}
+	var v Canonical{{.ElementName}}
+	for i := 0; i < Limbs; i++ {
+		v[i] = binary.BigEndian.Uint64(e[(Limbs-1-i)*8:])
+	}
+	if !(*{{.ElementName}})(&v).smallerThanModulus() {
+		return errors.New("invalid {{.PackageName}}.Canonical{{.ElementName}} encoding")
+	}
+	*z = v
+	return nil
+}
+
+// Bytes returns the value of z as a big-endian byte array
+func (z *Canonical{{.ElementName}}) Bytes() (res [Bytes]byte) {
+	for i := 0; i < Limbs; i++ {
+		binary.BigEndian.PutUint64(res[(Limbs-1-i)*8:], z[i])
+	}
+	return
+}
+
+// Marshal returns the value of z as a big-endian byte slice
+func (z *Canonical{{.ElementName}}) Marshal() []byte {
+	b := z.Bytes()
+	return b[:]
+}
+
+// SetBigInt sets z to v (mod q) and returns z
+func (z *Canonical{{.ElementName}}) SetBigInt(v *big.Int) *Canonical{{.ElementName}} {
+	vv := pool.BigInt.Get()
+	vv.Mod(v, &_modulus)
+	var b [Bytes]byte
+	vv.FillBytes(b[:])
+	pool.BigInt.Put(vv)
+
+	for i := 0; i < Limbs; i++ {
+		z[i] = binary.BigEndian.Uint64(b[(Limbs-1-i)*8:])
+	}
+	return z
+}
+
+// BigInt sets and returns res to the value of z
+func (z *Canonical{{.ElementName}}) BigInt(res *big.Int) *big.Int {
+	b := z.Bytes()
+	return res.SetBytes(b[:])
+}
+
+// String returns the decimal representation of z
+func (z *Canonical{{.ElementName}}) String() string {
+	var b big.Int
+	return z.BigInt(&b).String()
+}
+
+// barrettReduce sets z = t mod q, for t < 2^(128·Limbs) (Handbook of Applied Cryptography, Algorithm 14.42)
+func (z *Canonical{{.ElementName}}) barrettReduce(t *[2 * Limbs]uint64) {
+	// q̂ = ⌊⌊t / 2^(64·(Limbs-1))⌋ · μ / 2^(64·(Limbs+1))⌋ satisfies ⌊t/q⌋-2 ⩽ q̂ ⩽ ⌊t/q⌋
+	var q2 [2*Limbs + 2]uint64
+	mulWide(q2[:], t[Limbs-1:], barrettMu[:])
+	var r2 [2*Limbs + 1]uint64
+	mulWide(r2[:], q2[Limbs+1:], q{{.ElementName}}[:])
+
+	// r = t - q̂·q mod 2^(64·(Limbs+1)), and 0 ⩽ r < 3q
+	var r [Limbs + 1]uint64
+	var b uint64
+	for i := range r {
+		r[i], b = bits.Sub64(t[i], r2[i], b)
+	}
+
+	for {
+		var s [Limbs + 1]uint64
+		b = 0
+		for i := 0; i < Limbs; i++ {
+			s[i], b = bits.Sub64(r[i], q{{.ElementName}}[i], b)
+		}
+		s[Limbs], b = bits.Sub64(r[Limbs], 0, b)
+		if b != 0 {
+			break
+		}
+		r = s
+	}
+	copy(z[:], r[:Limbs])
+}
+
+// mulWide sets res = x · y; len(res) must be len(x) + len(y)
+func mulWide(res, x, y []uint64) {
+	for i := range res {
+		res[i] = 0
+	}
+	for i := range x {
+		var c uint64
+		for j := range y {
+			hi, lo := bits.Mul64(x[i], y[j])
+			var cc uint64
+			lo, cc = bits.Add64(lo, res[i+j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			res[i+j], c = lo, hi
+		}
+		res[i+len(y)] = c
+	}
+}
+
+`
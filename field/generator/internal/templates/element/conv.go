@@ -142,6 +142,23 @@ func (z *{{.ElementName}}) SetBytes(e []byte) *{{.ElementName}} {
 	}
 
 	// slow path.
+	return z.setBytesWide(e)
+}
+
+// SetBytesWide interprets e as the bytes of a big-endian 512-bit unsigned integer,
+// sets z to that value modulo q, and returns z.
+//
+// If q has at most 384 bits and e is uniformly random, such as the output of a
+// 512-bit hash function, z is statistically uniform (the bias is below 2⁻¹²⁸),
+// unlike a reduction of the first Bytes bytes of e.
+func (z *{{.ElementName}}) SetBytesWide(e [64]byte) *{{.ElementName}} {
+	return z.setBytesWide(e[:])
+}
+
+{{- if lt .NbBits 9}}
+
+// setBytesWide sets z to the big-endian unsigned integer e modulo q and returns z.
+func (z *{{.ElementName}}) setBytesWide(e []byte) *{{.ElementName}} {
 	// get a big int from our pool
 	vv := pool.BigInt.Get()
 	vv.SetBytes(e)
@@ -152,9 +169,52 @@ func (z *{{.ElementName}}) SetBytes(e []byte) *{{.ElementName}} {
 	// put temporary object back in pool
 	pool.BigInt.Put(vv)
 
-	return z 
+	return z
+}
+{{- else}}
+
+// wideChunkBytes is the size of the chunks reduced by setBytesWide: since
+// 2^(8·wideChunkBytes) ⩽ 2^(Bits-1) < q, a chunk is always smaller than q.
+const wideChunkBytes = (Bits - 1) / 8
+
+// wideChunkShift = 2^(8·wideChunkBytes) mod q, in Montgomery form
+var wideChunkShift = func() {{.ElementName}} {
+	z := One()
+	for i := 0; i < 8*wideChunkBytes; i++ {
+		z.Double(&z)
+	}
+	return z
+}()
+
+// setBytesWide sets z to the big-endian unsigned integer e modulo q and returns z.
+//
+// e is split in chunks of wideChunkBytes bytes, smaller than q, which are converted to
+// Montgomery form and combined with a Horner scheme: there is no big.Int arithmetic
+// nor allocation.
+func (z *{{.ElementName}}) setBytesWide(e []byte) *{{.ElementName}} {
+	// the first chunk holds the leading len(e) mod wideChunkBytes bytes, if any
+	first := len(e) % wideChunkBytes
+	if first == 0 && len(e) != 0 {
+		first = wideChunkBytes
+	}
+	z.setWideChunk(e[:first])
+	for e = e[first:]; len(e) != 0; e = e[wideChunkBytes:] {
+		var c {{.ElementName}}
+		c.setWideChunk(e[:wideChunkBytes])
+		z.Mul(z, &wideChunkShift).Add(z, &c)
+	}
+	return z
 }
 
+// setWideChunk sets z to the big-endian unsigned integer e, of at most wideChunkBytes bytes.
+func (z *{{.ElementName}}) setWideChunk(e []byte) {
+	var b [Bytes]byte
+	copy(b[Bytes-len(e):], e)
+	// e < q: no error
+	*z, _ = BigEndian.Element(&b)
+}
+{{- end}}
+
 // SetBytesCanonical interprets e as the bytes of a big-endian {{.NbBytes}}-byte integer.
 // If e is not a {{.NbBytes}}-byte slice or encodes a value higher than q, 
 // SetBytesCanonical returns an error.
@@ -0,0 +1,176 @@
+package element
+
+const FixedExpRuntime = `
+
+import (
+	"math/big"
+)
+
+// FixedExp computes xᵉ for a fixed public exponent e, by sliding window
+// exponentiation: the windows of e are computed once in [NewFixedExp]. It is the
+// runtime counterpart of the addition chains generated for the square root and
+// inversion exponents, and is useful for protocol constants known only at runtime.
+//
+// A FixedExp is immutable and safe for concurrent use.
+type FixedExp struct {
+	negative  bool
+	zero      bool
+	tableSize int             // number of odd powers x, x³, … to precompute
+	steps     []fixedExpStep
+}
+
+// fixedExpStep squares the accumulator nbSquares times then multiplies it by
+// the odd power x^(2⋅index+1), if index >= 0.
+type fixedExpStep struct {
+	nbSquares int
+	index     int
+}
+
+// NewFixedExp returns an exponentiator by e. e may be negative, in which case Exp
+// computes (x⁻¹)⁻ᵉ.
+//
+// The windows are computed at each call; callers exponentiating by the same e
+// repeatedly should keep the returned FixedExp.
+func NewFixedExp(e *big.Int) *FixedExp {
+	f := &FixedExp{negative: e.Sign() == -1}
+	if e.Sign() == 0 {
+		f.zero = true
+		return f
+	}
+
+	// xᵉ = x^(((e-1) mod (q-1)) + 1) for all x, including 0
+	var k, qMinusOne big.Int
+	qMinusOne.Sub(&_modulus, big.NewInt(1))
+	k.Abs(e).Sub(&k, big.NewInt(1)).Mod(&k, &qMinusOne).Add(&k, big.NewInt(1))
+
+	// choose the window minimizing the number of multiplications
+	nbBits := k.BitLen()
+	window, best := 1, nbBits
+	for w := 2; w <= 8; w++ {
+		if cost := (1 << (w - 1)) + nbBits/(w+1); cost < best {
+			window, best = w, cost
+		}
+	}
+
+	nbSquares := 0
+	for i := nbBits - 1; i >= 0; {
+		if k.Bit(i) == 0 {
+			nbSquares++
+			i--
+			continue
+		}
+		// longest window [j, i] ending with a 1
+		j := i - window + 1
+		if j < 0 {
+			j = 0
+		}
+		for k.Bit(j) == 0 {
+			j++
+		}
+		value := 0
+		for l := i; l >= j; l-- {
+			value = value<<1 | int(k.Bit(l))
+		}
+		nbSquares += i - j + 1
+		f.steps = append(f.steps, fixedExpStep{nbSquares: nbSquares, index: value >> 1})
+		if value>>1 >= f.tableSize {
+			f.tableSize = value>>1 + 1
+		}
+		nbSquares = 0
+		i = j - 1
+	}
+	if nbSquares != 0 {
+		f.steps = append(f.steps, fixedExpStep{nbSquares: nbSquares, index: -1})
+	}
+
+	return f
+}
+
+// Exp sets z = xᵉ and returns z.
+func (f *FixedExp) Exp(z *{{.ElementName}}, x {{.ElementName}}) *{{.ElementName}} {
+	if f.zero {
+		return z.SetOne()
+	}
+	if f.negative {
+		x.Inverse(&x)
+	}
+
+	// table[i] = x^(2i+1)
+	table := make([]{{.ElementName}}, f.tableSize)
+	table[0] = x
+	if f.tableSize > 1 {
+		var x2 {{.ElementName}}
+		x2.Square(&x)
+		for i := 1; i < len(table); i++ {
+			table[i].Mul(&table[i-1], &x2)
+		}
+	}
+
+	// the first step starts from 1, no need to square
+	res := table[f.steps[0].index]
+	for _, s := range f.steps[1:] {
+		for i := 0; i < s.nbSquares; i++ {
+			res.Square(&res)
+		}
+		if s.index >= 0 {
+			res.Mul(&res, &table[s.index])
+		}
+	}
+
+	return z.Set(&res)
+}
+`
+
+const FixedExpRuntimeTests = `
+
+func Test{{toTitle .ElementName}}NewFixedExp(t *testing.T) {
+	assert := require.New(t)
+
+	qMinusOne := Modulus()
+	qMinusOne.Sub(qMinusOne, big.NewInt(1))
+	exponents := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(2),
+		big.NewInt(5),
+		big.NewInt(-7),
+		big.NewInt(65537),
+		new(big.Int).Lsh(big.NewInt(1), 200),
+		qMinusOne,
+		Modulus(),
+		new(big.Int).Mul(Modulus(), big.NewInt(3)),
+	}
+	for i := 0; i < 5; i++ {
+		e, err := rand.Int(rand.Reader, Modulus())
+		assert.NoError(err)
+		exponents = append(exponents, e, new(big.Int).Neg(e))
+	}
+
+	var x, zero, expected, got {{.ElementName}}
+	for _, e := range exponents {
+		f := NewFixedExp(e)
+		for i := 0; i < 10; i++ {
+			x.SetRandom()
+			expected.Exp(x, e)
+			f.Exp(&got, x)
+			assert.True(expected.Equal(&got), "x^%s", e.String())
+		}
+		if e.Sign() > 0 {
+			f.Exp(&got, zero)
+			assert.True(got.IsZero(), "0^%s", e.String())
+		}
+	}
+}
+
+func Benchmark{{toTitle .ElementName}}NewFixedExp(b *testing.B) {
+	e := Modulus()
+	e.Sub(e, big.NewInt(2))
+	f := NewFixedExp(e)
+	var x {{.ElementName}}
+	x.SetRandom()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.Exp(&benchRes{{.ElementName}}, x)
+	}
+}
+`
@@ -0,0 +1,71 @@
+package element
+
+// MulCIOSSum is a variation of the CIOS multiplication (see MulCIOS) used by the fused operations
+// (MulAdd, AddMul, Lincomb): the products are accumulated in the same CIOS loop, and the
+// result is reduced once.
+//
+// At row j, t += V1 ⋅ V2[j] (+ V3 ⋅ V4[j]) (+ V2[j]&Mask ⋅ 2⁶⁴ᴺ), then t = (t + m⋅q) / 2⁶⁴.
+// With t < 3q and the products < 2q⋅2⁶⁴, t stays ⩽ 3q and fits on NbWords+1 words;
+// "mul_cios_sum_reduce" then subtracts q until z < q.
+const MulCIOSSum = `
+{{ define "mul_cios_sum" }}
+	var t [{{add .all.NbWords 1}}]uint64
+	var D, m, C uint64
+
+	{{- range $j := .all.NbWordsIndexesFull}}
+		// -----------------------------------
+		// First loop
+		{{ if eq $j 0}}
+			C, t[0] = bits.Mul64({{$.V2}}[{{$j}}], {{$.V1}}[0])
+			{{- range $i := $.all.NbWordsIndexesNoZero}}
+				C, t[{{$i}}] = madd1({{$.V2}}[{{$j}}], {{$.V1}}[{{$i}}], C)
+			{{- end}}
+		{{ else }}
+			C, t[0] = madd1({{$.V2}}[{{$j}}], {{$.V1}}[0], t[0])
+			{{- range $i := $.all.NbWordsIndexesNoZero}}
+				C, t[{{$i}}] = madd2({{$.V2}}[{{$j}}], {{$.V1}}[{{$i}}], t[{{$i}}], C)
+			{{- end}}
+		{{ end }}
+		t[{{$.all.NbWords}}], D = bits.Add64(t[{{$.all.NbWords}}], C, 0)
+		{{- if $.V3}}
+			C, t[0] = madd1({{$.V4}}[{{$j}}], {{$.V3}}[0], t[0])
+			{{- range $i := $.all.NbWordsIndexesNoZero}}
+				C, t[{{$i}}] = madd2({{$.V4}}[{{$j}}], {{$.V3}}[{{$i}}], t[{{$i}}], C)
+			{{- end}}
+			t[{{$.all.NbWords}}], C = bits.Add64(t[{{$.all.NbWords}}], C, 0)
+			D += C
+		{{- end}}
+		{{- if $.Mask}}
+			t[{{$.all.NbWords}}], C = bits.Add64(t[{{$.all.NbWords}}], {{$.V2}}[{{$j}}]&{{$.Mask}}, 0)
+			D += C
+		{{- end}}
+
+		// m = t[0]n'[0] mod W
+		m = t[0] * qInvNeg
+
+		// -----------------------------------
+		// Second loop
+		C = madd0(m, q0, t[0])
+		{{- range $i := $.all.NbWordsIndexesNoZero}}
+				C, t[{{sub $i 1}}] = madd2(m, q{{$i}}, t[{{$i}}], C)
+		{{- end}}
+
+		 t[{{sub $.all.NbWords 1}}], C = bits.Add64(t[{{$.all.NbWords}}], C, 0)
+		 t[{{$.all.NbWords}}], _ = bits.Add64(0, D, C)
+	{{- end}}
+{{ end }}
+
+{{ define "mul_cios_sum_reduce" }}
+	{{- range $i := .NbWordsIndexesFull}}
+	z[{{$i}}] = t[{{$i}}]
+	{{- end}}
+	top := t[{{.NbWords}}]
+	for top != 0 || !z.smallerThanModulus() {
+		var b uint64
+		{{- range $i := .NbWordsIndexesFull}}
+		z[{{$i}}], b = bits.Sub64(z[{{$i}}], q{{$i}}, {{if $i}}b{{else}}0{{end}})
+		{{- end}}
+		top -= b
+	}
+{{ end }}
+`
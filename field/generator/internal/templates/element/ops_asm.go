@@ -30,41 +30,115 @@ func reduce(res *{{.ElementName}})
 func Butterfly(a, b *{{.ElementName}})
 
 {{- if eq .NbWords 4}}
-// Add adds two vectors element-wise and stores the result in self.
-// It panics if the vectors don't have the same length.
-func (vector *Vector) Add(a, b Vector) {
-	if len(a) != len(b) || len(a) != len(*vector) {
-		panic("vector.Add: vectors don't have the same length")
+// the vector operations below are scalar loops using ADX/BMI2 (with a generic fallback),
+// only the sum has a vectorized path, on CPUs supporting AVX-512F.
+// The inner product goes through mulVec and sumVec, and Vector.ToMont through scalarMulVec.
+func addVecChunk(res, a, b Vector) {
+	if len(a) == 0 {
+		return
 	}
-	addVec(&(*vector)[0], &a[0], &b[0], uint64(len(a)))
+	addVec(&res[0], &a[0], &b[0], uint64(len(a)))
 }
 
 //go:noescape
 func addVec(res, a, b *{{.ElementName}}, n uint64)
 
-// Sub subtracts two vectors element-wise and stores the result in self.
-// It panics if the vectors don't have the same length.
-func (vector *Vector) Sub(a, b Vector) {
-	if len(a) != len(b) || len(a) != len(*vector) {
-		panic("vector.Sub: vectors don't have the same length")
+func subVecChunk(res, a, b Vector) {
+	if len(a) == 0 {
+		return
 	}
-	subVec(&(*vector)[0], &a[0], &b[0], uint64(len(a)))
+	subVec(&res[0], &a[0], &b[0], uint64(len(a)))
 }
 
 //go:noescape
 func subVec(res, a, b *{{.ElementName}}, n uint64)
 
-// ScalarMul multiplies a vector by a scalar element-wise and stores the result in self.
-// It panics if the vectors don't have the same length.
-func (vector *Vector) ScalarMul(a Vector, b *{{.ElementName}}) {
-	if len(a) != len(*vector) {
-		panic("vector.ScalarMul: vectors don't have the same length")
+func scalarMulVecChunk(res, a Vector, b *{{.ElementName}}) {
+	if len(a) == 0 {
+		return
 	}
-	scalarMulVec(&(*vector)[0], &a[0], b, uint64(len(a)))
+	scalarMulVec(&res[0], &a[0], b, uint64(len(a)))
 }
 
 //go:noescape
 func scalarMulVec(res, a, b *{{.ElementName}}, n uint64)
+
+func mulAccVecChunk(res, a Vector, b *{{.ElementName}}) {
+	if len(a) == 0 {
+		return
+	}
+	mulAccVec(&res[0], &a[0], b, uint64(len(a)))
+}
+
+//go:noescape
+func mulAccVec(res, a, b *{{.ElementName}}, n uint64)
+
+func mulVecChunk(res, a, b Vector) {
+	if len(a) == 0 {
+		return
+	}
+	mulVec(&res[0], &a[0], &b[0], uint64(len(a)))
+}
+
+//go:noescape
+func mulVec(res, a, b *{{.ElementName}}, n uint64)
+
+func sumVecChunk(res *{{.ElementName}}, a Vector) {
+	if len(a) == 0 {
+		res.SetZero()
+		return
+	}
+	if !supportAvx512 || uint64(len(a)) >= 1<<32 {
+		sumVec(res, &a[0], uint64(len(a)))
+		return
+	}
+
+	// t[i] is the sum of the i-th 32-bit limbs of the Montgomery representations;
+	// since (x+y)R = xR + yR, Σ t[i]⋅2³²ⁱ mod q is the Montgomery representation of the sum.
+	// {{.ElementName}}{t[i]} has Montgomery representation t[i] and multiplying by (2³² in Montgomery form)
+	// shifts a Montgomery representation by 32 bits, hence the Horner evaluation below.
+	var t [8]uint64
+	sumVecAVX512(&t, &a[0], uint64(len(a)))
+	var shift {{.ElementName}}
+	shift.SetUint64(1 << 32)
+	*res = {{.ElementName}}{t[7]}
+	for i := 6; i >= 0; i-- {
+		res.Mul(res, &shift)
+		res.Add(res, &{{.ElementName}}{t[i]})
+	}
+}
+
+// innerProductVecBlock is the number of products computed by mulVec before they are summed by sumVec.
+const innerProductVecBlock = 256
+
+func innerProductVecChunk(res *{{.ElementName}}, a, b Vector) {
+	res.SetZero()
+	var buf [innerProductVecBlock]{{.ElementName}}
+	var partial {{.ElementName}}
+	for start := 0; start < len(a); start += innerProductVecBlock {
+		end := min(start+innerProductVecBlock, len(a))
+		t := buf[:end-start]
+		mulVecChunk(t, a[start:end], b[start:end])
+		sumVecChunk(&partial, t)
+		res.Add(res, &partial)
+	}
+}
+
+//go:noescape
+func sumVec(res, a *{{.ElementName}}, n uint64)
+
+//go:noescape
+func sumVecAVX512(t *[8]uint64, a *{{.ElementName}}, n uint64)
+
+func fromMontVecChunk(a Vector) {
+	if len(a) == 0 {
+		return
+	}
+	fromMontVec(&a[0], uint64(len(a)))
+}
+
+//go:noescape
+func fromMontVec(a *{{.ElementName}}, n uint64)
 {{- end}}
 
 // Mul z = x * y (mod q)
@@ -2,7 +2,14 @@ package element
 
 const OpsNoAsm = `
 
-import "math/bits"
+import (
+	"math/bits"
+	"runtime"
+)
+
+// use32BitLimbs is set on the platforms where bits.Mul64 is emulated: 32-bit architectures,
+// and wasm which has no 64×64→128 multiplication. Mul and Square then run on 32-bit limbs.
+const use32BitLimbs = bits.UintSize == 32 || runtime.GOARCH == "wasm"
 
 {{ $mulConsts := list 3 5 13 }}
 {{- range $i := $mulConsts }}
@@ -20,6 +27,10 @@ func MulBy{{$i}}(x *{{$.ElementName}}) {
 		{{- else if eq $i 5}}
 			_x := *x
 			x.Double(x).Double(x).Add(x, &_x)
+		{{- else if and (eq $i 13) (gt $.NbWords 4)}}
+			// 13 = ((2+1)⋅2⋅2)+1: 5 additions are cheaper than a multiplication on 5 words or more
+			_x := *x
+			x.Double(x).Add(x, &_x).Double(x).Double(x).Add(x, &_x)
 		{{- else if eq $i 13}}
 			var y = {{$.ElementName}}{
 				{{- range $i := $.Thirteen}}
@@ -51,22 +62,36 @@ func reduce(z *{{.ElementName}})  {
 }
 
 {{- if eq .NbWords 4}}
-// Add adds two vectors element-wise and stores the result in self.
-// It panics if the vectors don't have the same length.
-func (vector *Vector) Add(a, b Vector) {
-	addVecGeneric(*vector, a, b)
+func addVecChunk(res, a, b Vector) {
+	addVecGeneric(res, a, b)
+}
+
+func subVecChunk(res, a, b Vector) {
+	subVecGeneric(res, a, b)
+}
+
+func scalarMulVecChunk(res, a Vector, b *{{.ElementName}}) {
+	scalarMulVecGeneric(res, a, b)
+}
+
+func mulAccVecChunk(res, a Vector, b *{{.ElementName}}) {
+	mulAccVecGeneric(res, a, b)
+}
+
+func mulVecChunk(res, a, b Vector) {
+	mulVecGeneric(res, a, b)
+}
+
+func sumVecChunk(res *{{.ElementName}}, a Vector) {
+	sumVecGeneric(res, a)
 }
 
-// Sub subtracts two vectors element-wise and stores the result in self.
-// It panics if the vectors don't have the same length.
-func (vector *Vector) Sub(a, b Vector) {
-	subVecGeneric(*vector, a, b)
+func innerProductVecChunk(res *{{.ElementName}}, a, b Vector) {
+	innerProductVecGeneric(res, a, b)
 }
 
-// ScalarMul multiplies a vector by a scalar element-wise and stores the result in self.
-// It panics if the vectors don't have the same length.
-func (vector *Vector) ScalarMul(a Vector, b *{{.ElementName}}) {
-	scalarMulVecGeneric(*vector, a, b)
+func fromMontVecChunk(a Vector) {
+	fromMontVecGeneric(a)
 }
 {{- end}}
 
@@ -76,6 +101,10 @@ func (vector *Vector) ScalarMul(a Vector, b *{{.ElementName}}) {
 // x and y must be less than q
 {{- end }}
 func (z *{{.ElementName}}) Mul(x, y *{{.ElementName}}) *{{.ElementName}} {
+	if use32BitLimbs {
+		mul32(z, x, y)
+		return z
+	}
 	{{- if eq $.NbWords 1}}
 		{{ template "mul_cios_one_limb" dict "all" . "V1" "x" "V2" "y" }}
 	{{- else }}
@@ -97,6 +126,10 @@ func (z *{{.ElementName}}) Mul(x, y *{{.ElementName}}) *{{.ElementName}} {
 {{- end }}
 func (z *{{.ElementName}}) Square(x *{{.ElementName}}) *{{.ElementName}} {
 	// see Mul for algorithm documentation
+	if use32BitLimbs {
+		mul32(z, x, x)
+		return z
+	}
 	{{- if eq $.NbWords 1}}
 		{{ template "mul_cios_one_limb" dict "all" . "V1" "x" "V2" "x" }}
 	{{- else }}
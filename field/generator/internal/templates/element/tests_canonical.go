@@ -0,0 +1,167 @@
+package element
+
+const TestCanonical = `
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+	ggen "github.com/leanovate/gopter/gen"
+)
+
+func TestCanonical{{.ElementName}}(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+	genB := gen()
+
+	properties.Property("Canonical: conversion round trip", prop.ForAll(
+		func(p testPair{{.ElementName}}) bool {
+			a := p.element
+			var c Canonical{{.ElementName}}
+			c.SetElement(&a)
+			e := c.Element()
+			return e.Equal(&a) && c == Canonical{{.ElementName}}(a.Bits())
+		},
+		genA,
+	))
+
+	properties.Property("Canonical: arithmetic must match {{.ElementName}}", prop.ForAll(
+		func(pa, pb testPair{{.ElementName}}) bool {
+			a, b := pa.element, pb.element
+			var ca, cb, c Canonical{{.ElementName}}
+			ca.SetElement(&a)
+			cb.SetElement(&b)
+
+			var e {{.ElementName}}
+			ok := true
+			check := func() {
+				r := c.Element()
+				ok = ok && r.Equal(&e)
+			}
+
+			c.Mul(&ca, &cb)
+			e.Mul(&a, &b)
+			check()
+			c.Square(&ca)
+			e.Square(&a)
+			check()
+			c.Add(&ca, &cb)
+			e.Add(&a, &b)
+			check()
+			c.Sub(&ca, &cb)
+			e.Sub(&a, &b)
+			check()
+			c.Double(&ca)
+			e.Double(&a)
+			check()
+			c.Neg(&ca)
+			e.Neg(&a)
+			check()
+			c.Inverse(&ca)
+			e.Inverse(&a)
+			check()
+			return ok
+		},
+		genA, genB,
+	))
+
+	properties.Property("Canonical: bytes must match {{.ElementName}}", prop.ForAll(
+		func(p testPair{{.ElementName}}) bool {
+			a := p.element
+			var c, d Canonical{{.ElementName}}
+			c.SetElement(&a)
+			if c.Bytes() != a.Bytes() {
+				return false
+			}
+			if err := d.SetBytesCanonical(c.Marshal()); err != nil || d != c {
+				return false
+			}
+			var b big.Int
+			return c.BigInt(&b).Cmp(a.BigInt(new(big.Int))) == 0 && c.String() == a.String()
+		},
+		genA,
+	))
+
+	properties.Property("Canonical: SetBytes must reduce like {{.ElementName}}", prop.ForAll(
+		func(buf []byte) bool {
+			var c Canonical{{.ElementName}}
+			var e {{.ElementName}}
+			c.SetBytes(buf)
+			e.SetBytes(buf)
+			r := c.Element()
+			return r.Equal(&e)
+		},
+		ggen.SliceOfN(2*Bytes, ggen.UInt8()),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestCanonical{{.ElementName}}EdgeCases(t *testing.T) {
+	var qMinusOne, c Canonical{{.ElementName}}
+	m := Modulus()
+	m.Sub(m, big.NewInt(1))
+	qMinusOne.SetBigInt(m)
+
+	// (q-1)² = 1, the largest product Barrett has to reduce
+	c.Mul(&qMinusOne, &qMinusOne)
+	var one Canonical{{.ElementName}}
+	one.SetOne()
+	if !c.Equal(&one) {
+		t.Fatal("(q-1)² != 1")
+	}
+
+	q := Modulus()
+	b := make([]byte, Bytes)
+	q.FillBytes(b)
+	if err := c.SetBytesCanonical(b); err == nil {
+		t.Fatal("SetBytesCanonical accepted q")
+	}
+	if c.SetBytes(b); !c.IsZero() {
+		t.Fatal("SetBytes(q) != 0")
+	}
+	if c.SetUint64(42); c.String() != "42" {
+		t.Fatal("SetUint64 failed")
+	}
+}
+
+func BenchmarkCanonical{{.ElementName}}(b *testing.B) {
+	var x {{.ElementName}}
+	x.SetRandom()
+	var c Canonical{{.ElementName}}
+	c.SetElement(&x)
+	buf := x.Bytes()
+
+	b.Run("Mul", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			c.Mul(&c, &c)
+		}
+	})
+
+	b.Run("SetBytes+Bytes", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			c.SetBytes(buf[:])
+			buf = c.Bytes()
+		}
+	})
+
+	b.Run("{{.ElementName}}/SetBytes+Bytes", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x.SetBytes(buf[:])
+			buf = x.Bytes()
+		}
+	})
+}
+
+`
@@ -0,0 +1,55 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goldilocks
+
+import "math/bits"
+
+// epsilon = 2³² - 1 = 2⁶⁴ mod q
+const epsilon = 1<<32 - 1
+
+// SetUint128 sets z to hi·2⁶⁴ + lo (mod q) and returns z.
+//
+// It uses the special form of q = 2⁶⁴ - 2³² + 1: 2⁶⁴ ≡ 2³² - 1 and 2⁹⁶ ≡ -1 (mod q), so that
+// the reduction of a 128-bit integer only takes a few additions and a 32x32-bit multiplication.
+// It is suited to reduce sums of products of canonical values, or hash outputs.
+func (z *Element) SetUint128(hi, lo uint64) *Element {
+	z[0] = reduce128(hi, lo)
+	return z.toMont()
+}
+
+// reduce128 returns hi·2⁶⁴ + lo (mod q), in canonical form.
+func reduce128(hi, lo uint64) uint64 {
+	hiHi, hiLo := hi>>32, hi&epsilon
+
+	// lo - hiHi·2⁹⁶
+	t0, borrow := bits.Sub64(lo, hiHi, 0)
+	if borrow != 0 {
+		// t0 wrapped around 2⁶⁴ ≡ epsilon; it is larger than epsilon
+		t0 -= epsilon
+	}
+
+	// + hiLo·2⁶⁴
+	t1 := hiLo * epsilon
+	t2, carry := bits.Add64(t0, t1, 0)
+	if carry != 0 {
+		// t2 < 2⁶⁴ - epsilon since t1 < 2⁶⁴ - 2·epsilon
+		t2 += epsilon
+	}
+
+	if t2 >= q {
+		t2 -= q
+	}
+	return t2
+}
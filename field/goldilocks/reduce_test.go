@@ -0,0 +1,54 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goldilocks
+
+import (
+	"math"
+	"math/big"
+	mrand "math/rand"
+	"testing"
+)
+
+func TestSetUint128(t *testing.T) {
+	check := func(hi, lo uint64) {
+		var z, expected Element
+		z.SetUint128(hi, lo)
+
+		v := new(big.Int).SetUint64(hi)
+		v.Lsh(v, 64).Add(v, new(big.Int).SetUint64(lo))
+		expected.SetBigInt(v)
+		if !z.Equal(&expected) {
+			t.Fatalf("SetUint128(%d, %d): got %s, expected %s", hi, lo, z.String(), expected.String())
+		}
+	}
+
+	edges := []uint64{0, 1, epsilon - 1, epsilon, epsilon + 1, q - 1, q, q + 1, math.MaxUint64}
+	for _, hi := range edges {
+		for _, lo := range edges {
+			check(hi, lo)
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		check(mrand.Uint64(), mrand.Uint64())
+	}
+}
+
+func BenchmarkSetUint128(b *testing.B) {
+	var z Element
+	hi, lo := mrand.Uint64(), mrand.Uint64()
+	for i := 0; i < b.N; i++ {
+		z.SetUint128(hi, lo)
+	}
+}
@@ -3,14 +3,89 @@ package hash
 import (
 	"crypto/sha256"
 	"errors"
+	"hash"
+	"io"
+
+	"golang.org/x/crypto/sha3"
 )
 
-// ExpandMsgXmd expands msg to a slice of lenInBytes bytes.
+// Expander derives lenInBytes pseudo-random bytes from msg and the domain separation tag dst.
+// https://datatracker.ietf.org/doc/html/rfc9380#name-expand_message
+type Expander func(msg, dst []byte, lenInBytes int) ([]byte, error)
+
+var (
+	// ExpanderXmdSHA256 is expand_message_xmd with SHA-256.
+	ExpanderXmdSHA256 = NewExpanderXmd(sha256.New)
+	// ExpanderXofSHAKE128 is expand_message_xof with SHAKE128.
+	ExpanderXofSHAKE128 = NewExpanderXof(func() XOF { return sha3.NewShake128() })
+	// ExpanderXofSHAKE256 is expand_message_xof with SHAKE256.
+	ExpanderXofSHAKE256 = NewExpanderXof(func() XOF { return sha3.NewShake256() })
+)
+
+// NewExpanderXmd returns an Expander using expand_message_xmd with the hash
+// functions returned by newHash.
+func NewExpanderXmd(newHash func() hash.Hash) Expander {
+	return func(msg, dst []byte, lenInBytes int) ([]byte, error) {
+		return expandMsgXmd(newHash(), msg, dst, lenInBytes)
+	}
+}
+
+// NewExpanderXof returns an Expander using expand_message_xof with the
+// extendable-output functions returned by newXOF, e.g. SHAKE128 or BLAKE3.
+func NewExpanderXof(newXOF func() XOF) Expander {
+	return func(msg, dst []byte, lenInBytes int) ([]byte, error) {
+		return ExpandMsgXof(newXOF(), msg, dst, lenInBytes)
+	}
+}
+
+// XOF is an extendable-output function: the input is written to it, then an
+// output of arbitrary length is read from it.
+type XOF interface {
+	io.Writer
+	io.Reader
+}
+
+// ExpandMsgXof expands msg to a slice of lenInBytes bytes, using the freshly
+// created extendable-output function h.
+// https://datatracker.ietf.org/doc/html/rfc9380#name-expand_message_xof
+func ExpandMsgXof(h XOF, msg, dst []byte, lenInBytes int) ([]byte, error) {
+	if lenInBytes > 0xffff {
+		return nil, errors.New("invalid lenInBytes")
+	}
+	if len(dst) > 255 {
+		return nil, errors.New("invalid domain size (>255 bytes)")
+	}
+
+	// msg_prime = msg ∥ I2OSP(len_in_bytes, 2) ∥ DST ∥ I2OSP(len(DST), 1)
+	if _, err := h.Write(msg); err != nil {
+		return nil, err
+	}
+	if _, err := h.Write([]byte{uint8(lenInBytes >> 8), uint8(lenInBytes)}); err != nil {
+		return nil, err
+	}
+	if _, err := h.Write(dst); err != nil {
+		return nil, err
+	}
+	if _, err := h.Write([]byte{uint8(len(dst))}); err != nil {
+		return nil, err
+	}
+
+	res := make([]byte, lenInBytes)
+	if _, err := io.ReadFull(h, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ExpandMsgXmd expands msg to a slice of lenInBytes bytes, using SHA-256.
 // https://datatracker.ietf.org/doc/html/rfc9380#name-expand_message_xmd
 // https://datatracker.ietf.org/doc/html/rfc9380#name-utility-functions (I2OSP/O2ISP)
 func ExpandMsgXmd(msg, dst []byte, lenInBytes int) ([]byte, error) {
+	return expandMsgXmd(sha256.New(), msg, dst, lenInBytes)
+}
+
+func expandMsgXmd(h hash.Hash, msg, dst []byte, lenInBytes int) ([]byte, error) {
 
-	h := sha256.New()
 	ell := (lenInBytes + h.Size() - 1) / h.Size() // ceil(len_in_bytes / b_in_bytes)
 	if ell > 255 {
 		return nil, errors.New("invalid lenInBytes")
@@ -59,7 +134,7 @@ func ExpandMsgXmd(msg, dst []byte, lenInBytes int) ([]byte, error) {
 	b1 := h.Sum(nil)
 
 	res := make([]byte, lenInBytes)
-	copy(res[:h.Size()], b1)
+	copy(res, b1)
 
 	for i := 2; i <= ell; i++ {
 		// b_i = H(strxor(b₀, b_(i - 1)) ∥ I2OSP(i, 1) ∥ DST_prime)
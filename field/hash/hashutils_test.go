@@ -105,6 +105,12 @@ func TestExpandMsgXmd(t *testing.T) {
 			0x30,
 			"1aaee90016547a85ab4dc55e4f78a364c2e239c0e58b05753453c63e6e818334005e90d9ce8f047bddab9fbb315f8722",
 		},
+		// shorter than the hash output, as for 64-bit fields
+		{
+			"abc",
+			0x18,
+			"f060a8a2b3ffde1d8bf22b59a6b1b07502a7eb0cc0e3ff0e",
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -130,3 +136,60 @@ func TestExpandMsgXmd(t *testing.T) {
 		}
 	}
 }
+
+// Test vectors from https://datatracker.ietf.org/doc/html/rfc9380#name-expand_message_xofshake128
+func TestExpandMsgXof(t *testing.T) {
+	dst := "QUUX-V01-CS02-with-expander-SHAKE128"
+
+	testCases := []expandMsgXmdTestCase{
+		{
+			"",
+			0x20,
+			"86518c9cd86581486e9485aa74ab35ba150d1c75c88e26b7043e44e2acd735a2",
+		},
+		{
+			"abc",
+			0x20,
+			"8696af52a4d862417c0763556073f47bc9b9ba43c99b505305cb1ec04a9ab468",
+		},
+		{
+			"abcdef0123456789",
+			0x20,
+			"912c58deac4821c3509dbefa094df54b34b8f5d01a191d1d3108a2c89077acca",
+		},
+		{
+			"abc",
+			0x80,
+			"c952f0c8e529ca8824acc6a4cab0e782fc3648c563ddb00da7399f2ae35654f4860ec671db2356ba7baa55a34a9d7f79197b60ddae6e64768a37d699a78323496db3878c8d64d909d0f8a7de4927dcab0d3dbbc26cb20a49eceb0530b431cdf47bc8c0fa3e0d88f53b318b6739fbed7d7634974f1b5c386d6230c76260d5337a",
+		},
+	}
+
+	for _, testCase := range testCases {
+		uniformBytes, err := ExpanderXofSHAKE128([]byte(testCase.msg), []byte(dst), testCase.lenInBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(uniformBytes) != testCase.uniformBytesHex {
+			t.Errorf("expected \"%s\" got \"%x\"", testCase.uniformBytesHex, uniformBytes)
+		}
+	}
+
+	if _, err := ExpanderXofSHAKE128(nil, make([]byte, 256), 0x20); err == nil {
+		t.Error("expected an error for a domain larger than 255 bytes")
+	}
+}
+
+func TestExpanderXmdSHA256(t *testing.T) {
+	msg, dst := []byte("abc"), []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	expected, err := ExpandMsgXmd(msg, dst, 0x80)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := ExpanderXmdSHA256(msg, dst, 0x80)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(expected, actual) {
+		t.Error("ExpanderXmdSHA256 and ExpandMsgXmd should match")
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circle
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/field/m31"
+)
+
+func TestGenerator(t *testing.T) {
+	g := Generator()
+	if !g.IsOnCircle() {
+		t.Fatal("generator is not on the circle")
+	}
+
+	// the point of order 2 is (-1, 0)
+	var minusOne, p Point
+	minusOne.SetIdentity().Antipode(&minusOne)
+	p.ScalarMul(&g, 1<<(MaxLogOrder-1))
+	if !p.Equal(&minusOne) {
+		t.Fatalf("2³⁰·g = %s, expected %s", p.String(), minusOne.String())
+	}
+
+	for logOrder := uint64(0); logOrder <= MaxLogOrder; logOrder++ {
+		h, err := SubgroupGenerator(logOrder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var id Point
+		id.SetIdentity()
+		p.ScalarMul(&h, 1<<logOrder)
+		if !p.Equal(&id) {
+			t.Fatalf("generator of order 2^%d: wrong order", logOrder)
+		}
+		if logOrder > 0 {
+			p.ScalarMul(&h, 1<<(logOrder-1))
+			if p.Equal(&id) {
+				t.Fatalf("generator of order 2^%d: order too small", logOrder)
+			}
+		}
+	}
+	if _, err := SubgroupGenerator(MaxLogOrder + 1); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestPointArithmetic(t *testing.T) {
+	g := Generator()
+	var a, b, c, d Point
+	a.ScalarMul(&g, 12345)
+	b.ScalarMul(&g, 67890)
+
+	c.Add(&a, &b)
+	d.ScalarMul(&g, 12345+67890)
+	if !c.Equal(&d) || !c.IsOnCircle() {
+		t.Fatal("Add doesn't match ScalarMul")
+	}
+
+	c.Double(&a)
+	d.Add(&a, &a)
+	if !c.Equal(&d) {
+		t.Fatal("Double doesn't match Add")
+	}
+
+	var id Point
+	id.SetIdentity()
+	c.Neg(&a).Add(&c, &a)
+	if !c.Equal(&id) {
+		t.Fatal("a - a != 0")
+	}
+}
+
+func TestDomain(t *testing.T) {
+	for logN := 1; logN <= 8; logN++ {
+		d := NewDomain(1 << logN)
+		half := d.Cardinality / 2
+
+		seen := make(map[Point]struct{})
+		for i := uint64(0); i < d.Cardinality; i++ {
+			p := d.Point(i)
+			if !p.IsOnCircle() {
+				t.Fatalf("n=%d: point %d is not on the circle", d.Cardinality, i)
+			}
+			seen[p] = struct{}{}
+		}
+		if uint64(len(seen)) != d.Cardinality {
+			t.Fatalf("n=%d: points are not distinct", d.Cardinality)
+		}
+
+		// conjugates and antipodes
+		for i := uint64(0); i < half; i++ {
+			p, q := d.Point(i), d.Point(i+half)
+			q.Neg(&q)
+			if !p.Equal(&q) {
+				t.Fatalf("n=%d: point %d is not the conjugate of point %d", d.Cardinality, i+half, i)
+			}
+			if i < half/2 {
+				q = d.Point(i + half/2)
+				q.Antipode(&q)
+				if !p.Equal(&q) {
+					t.Fatalf("n=%d: point %d is not the antipode of point %d", d.Cardinality, i+half/2, i)
+				}
+			}
+		}
+	}
+}
+
+func TestFFT(t *testing.T) {
+	for logN := 1; logN <= 8; logN++ {
+		d := NewDomain(1 << logN)
+
+		coeffs := make([]m31.Element, d.Cardinality)
+		for i := range coeffs {
+			coeffs[i].SetRandom()
+		}
+
+		evals := append([]m31.Element(nil), coeffs...)
+		d.FFT(evals)
+		for i := range evals {
+			p := d.Point(uint64(i))
+			expected := Evaluate(coeffs, &p)
+			if !evals[i].Equal(&expected) {
+				t.Fatalf("n=%d: wrong evaluation at point %d", d.Cardinality, i)
+			}
+		}
+
+		d.FFTInverse(evals)
+		for i := range evals {
+			if !evals[i].Equal(&coeffs[i]) {
+				t.Fatalf("n=%d: FFTInverse(FFT(a)) != a", d.Cardinality)
+			}
+		}
+	}
+}
+
+func TestFold(t *testing.T) {
+	const logN = 6
+	d := NewDomain(1 << logN)
+
+	coeffs := make([]m31.Element, d.Cardinality)
+	for i := range coeffs {
+		coeffs[i].SetRandom()
+	}
+	evals := append([]m31.Element(nil), coeffs...)
+	d.FFT(evals)
+
+	// after l folds, the polynomial only has coefficients at multiples of 2ˡ, and its values at
+	// the projections of Point(i) are those of the polynomial on the domain.
+	spacing := 1
+	for l := 0; l < logN; l++ {
+		var alpha m31.Element
+		alpha.SetRandom()
+		evals = d.Fold(evals, &alpha)
+
+		var tmp m31.Element
+		for j := 0; j < len(coeffs); j += 2 * spacing {
+			tmp.Mul(&coeffs[j+spacing], &alpha)
+			coeffs[j].Add(&coeffs[j], &tmp)
+			coeffs[j+spacing].SetZero()
+		}
+		spacing *= 2
+
+		for i := range evals {
+			p := d.Point(uint64(i))
+			expected := Evaluate(coeffs, &p)
+			if !evals[i].Equal(&expected) {
+				t.Fatalf("fold %d: wrong evaluation at point %d", l, i)
+			}
+		}
+	}
+	if len(evals) != 1 || !evals[0].Equal(&coeffs[0]) {
+		t.Fatal("folding down to a constant should give the folded constant coefficient")
+	}
+}
+
+func BenchmarkFFT(b *testing.B) {
+	for _, logN := range []int{10, 16, 20} {
+		d := NewDomain(1 << logN)
+		a := make([]m31.Element, d.Cardinality)
+		for i := range a {
+			a[i].SetRandom()
+		}
+		b.Run(fmt.Sprintf("FFT/%d", logN), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				d.FFT(a)
+			}
+		})
+		b.Run(fmt.Sprintf("FFTInverse/%d", logN), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				d.FFTInverse(a)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circle
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/field/m31"
+)
+
+// Domain is a canonic coset of the circle group: the coset Q + G of the subgroup G of order
+// Cardinality, by a point Q of order 2·Cardinality. It is stable by conjugation, which makes
+// it suitable for the circle FFT.
+//
+// The points are ordered as the half coset Q + iH, i < Cardinality/2, with H of order
+// Cardinality/2, followed by their conjugates: Point(i + Cardinality/2) = -Point(i).
+type Domain struct {
+	Cardinality    uint64
+	CardinalityInv m31.Element
+	Initial        Point // Q, of order 2·Cardinality
+	Step           Point // H, of order Cardinality/2
+
+	// twiddles[0][i] is the y-coordinate of Point(i), and twiddles[l][i] for l ≥ 1 the
+	// x-coordinate of 2ˡ⁻¹·Point(i): the values by which the polynomials are divided in
+	// the successive layers of the FFT.
+	twiddles    [][]m31.Element
+	twiddlesInv [][]m31.Element
+}
+
+// NewDomain returns a domain of cardinality the smallest power of 2 ≥ max(m, 2).
+// It panics if the cardinality exceeds 2^(MaxLogOrder-1).
+func NewDomain(m uint64) *Domain {
+	if m < 2 {
+		m = 2
+	}
+	n := ecc.NextPowerOfTwo(m)
+	logN := uint64(bits.TrailingZeros64(n))
+	if logN > MaxLogOrder-1 {
+		panic(fmt.Sprintf("circle: domain of cardinality %d is too large", n))
+	}
+
+	d := &Domain{Cardinality: n}
+	d.CardinalityInv.SetUint64(n).Inverse(&d.CardinalityInv)
+	d.Initial, _ = SubgroupGenerator(logN + 1)
+	d.Step, _ = SubgroupGenerator(logN - 1)
+
+	// layer 0: y-coordinates of the half coset
+	half := make([]Point, n/2)
+	half[0] = d.Initial
+	for i := 1; i < len(half); i++ {
+		half[i].Add(&half[i-1], &d.Step)
+	}
+	d.twiddles = make([][]m31.Element, logN)
+	d.twiddles[0] = make([]m31.Element, n/2)
+	for i := range half {
+		d.twiddles[0][i] = half[i].Y
+	}
+
+	// layer l ≥ 1: x-coordinates of 2ˡ⁻¹·(Q + iH), i < n/2ˡ⁺¹
+	for l := 1; l < int(logN); l++ {
+		d.twiddles[l] = make([]m31.Element, n>>(l+1))
+		for i := range d.twiddles[l] {
+			if l == 1 {
+				d.twiddles[l][i] = half[i].X
+			} else {
+				d.twiddles[l][i] = d.twiddles[l-1][i]
+				double(&d.twiddles[l][i])
+			}
+		}
+	}
+
+	d.twiddlesInv = make([][]m31.Element, logN)
+	for l := range d.twiddles {
+		d.twiddlesInv[l] = m31.BatchInvert(d.twiddles[l])
+	}
+
+	return d
+}
+
+// Point returns the i-th point of the domain.
+func (d *Domain) Point(i uint64) Point {
+	if i >= d.Cardinality {
+		panic("circle: point index out of range")
+	}
+	half := d.Cardinality / 2
+	var res Point
+	if i >= half {
+		res.ScalarMul(&d.Step, i-half).Add(&res, &d.Initial)
+		return *res.Neg(&res)
+	}
+	res.ScalarMul(&d.Step, i).Add(&res, &d.Initial)
+	return res
+}
+
+// FFT evaluates in place the polynomial of coefficients a on the domain.
+//
+// The coefficients are in natural order, in the circle FFT basis
+// bⱼ(x, y) = y^j₀ · v₁(x)^j₁ · … · vₙ₋₁(x)^jₙ₋₁, where jₖ is the k-th bit of j, v₁(x) = x and
+// vₖ₊₁(x) = 2vₖ(x)² - 1. The evaluations are in the order of the domain points.
+func (d *Domain) FFT(a []m31.Element) {
+	if uint64(len(a)) != d.Cardinality {
+		panic("circle: FFT input size must match the domain cardinality")
+	}
+	bitReverse(a)
+	for l := len(d.twiddles) - 1; l >= 0; l-- {
+		ditLayer(a, d.twiddles[l])
+	}
+}
+
+// FFTInverse interpolates in place the evaluations a on the domain, ordered as the domain
+// points, and returns the coefficients of the polynomial in natural order, see FFT.
+func (d *Domain) FFTInverse(a []m31.Element) {
+	if uint64(len(a)) != d.Cardinality {
+		panic("circle: FFTInverse input size must match the domain cardinality")
+	}
+	for l := range d.twiddlesInv {
+		difLayer(a, d.twiddlesInv[l])
+	}
+	bitReverse(a)
+	for i := range a {
+		a[i].Mul(&a[i], &d.CardinalityInv)
+	}
+}
+
+// Evaluate returns the evaluation at p of the polynomial of coefficients a, in the basis
+// of FFT. len(a) must be a power of 2.
+func Evaluate(a []m31.Element, p *Point) m31.Element {
+	if len(a) == 0 {
+		return m31.Element{}
+	}
+	if len(a)&(len(a)-1) != 0 {
+		panic("circle: the number of coefficients must be a power of 2")
+	}
+	// values of y, v₁(x), v₂(x), …
+	logN := bits.TrailingZeros(uint(len(a)))
+	v := make([]m31.Element, logN)
+	if logN > 0 {
+		v[0] = p.Y
+	}
+	x := p.X
+	for k := 1; k < logN; k++ {
+		v[k] = x
+		double(&x)
+	}
+	return evaluate(a, v)
+}
+
+// evaluate returns a₀(v₁, …) + v₀·a₁(v₁, …), with a₀, a₁ the even and odd coefficients
+func evaluate(a []m31.Element, v []m31.Element) m31.Element {
+	if len(a) == 1 {
+		return a[0]
+	}
+	even := make([]m31.Element, len(a)/2)
+	odd := make([]m31.Element, len(a)/2)
+	for i := range even {
+		even[i] = a[2*i]
+		odd[i] = a[2*i+1]
+	}
+	e := evaluate(even, v[1:])
+	o := evaluate(odd, v[1:])
+	o.Mul(&o, &v[0])
+	return *e.Add(&e, &o)
+}
+
+// difLayer splits each block of a as f(t) = f₀ + t·f₁ with the twiddles t⁻¹, storing 2f₀ in
+// the first half of the block and 2f₁ in the second half.
+func difLayer(a, twiddlesInv []m31.Element) {
+	m := 2 * len(twiddlesInv)
+	for start := 0; start < len(a); start += m {
+		b := a[start : start+m]
+		for i := range twiddlesInv {
+			u, v := b[i], b[i+m/2]
+			b[i].Add(&u, &v)
+			b[i+m/2].Sub(&u, &v).Mul(&b[i+m/2], &twiddlesInv[i])
+		}
+	}
+}
+
+// ditLayer is the inverse of difLayer up to a factor 2: f(±t) = f₀ ± t·f₁.
+func ditLayer(a, twiddles []m31.Element) {
+	m := 2 * len(twiddles)
+	for start := 0; start < len(a); start += m {
+		b := a[start : start+m]
+		for i := range twiddles {
+			var v m31.Element
+			u := b[i]
+			v.Mul(&b[i+m/2], &twiddles[i])
+			b[i].Add(&u, &v)
+			b[i+m/2].Sub(&u, &v)
+		}
+	}
+}
+
+// bitReverse applies the bit-reversal permutation to a; len(a) must be a power of 2.
+func bitReverse(a []m31.Element) {
+	n := uint64(len(a))
+	if n < 2 {
+		return
+	}
+	nn := uint64(64 - bits.TrailingZeros64(n))
+	for i := uint64(0); i < n; i++ {
+		iRev := bits.Reverse64(i) >> nn
+		if iRev > i {
+			a[i], a[iRev] = a[iRev], a[i]
+		}
+	}
+}
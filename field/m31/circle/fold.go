@@ -0,0 +1,54 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circle
+
+import (
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/field/m31"
+)
+
+// Fold is the folding step of the circle FRI protocol.
+//
+// evals are either the evaluations of f on the domain (len(evals) == Cardinality), or its
+// evaluations on the l-th projection of the domain on the x-axis, as returned by l successive
+// calls to Fold (len(evals) == Cardinality >> l). Writing f = f₀ + t·f₁, where t is y for the
+// domain and x for its projections, Fold returns the evaluations of f₀ + alpha·f₁ on the next
+// projection, of half the size.
+//
+// In the basis of FFT, the coefficients of the folded polynomial are a₂ⱼ + alpha·a₂ⱼ₊₁.
+func (d *Domain) Fold(evals []m31.Element, alpha *m31.Element) []m31.Element {
+	n := uint64(len(evals))
+	if n < 2 || n > d.Cardinality || n&(n-1) != 0 {
+		panic("circle: invalid number of evaluations to fold")
+	}
+	layer := bits.TrailingZeros64(d.Cardinality) - bits.TrailingZeros64(n)
+	twiddlesInv := d.twiddlesInv[layer]
+
+	var halfAlpha, twoInv m31.Element
+	twoInv.SetUint64(2).Inverse(&twoInv)
+	halfAlpha.Mul(alpha, &twoInv)
+
+	res := make([]m31.Element, n/2)
+	for i := range res {
+		// (u + v)/2 + alpha·(u - v)/(2t)
+		var f0, f1 m31.Element
+		u, v := &evals[i], &evals[i+len(res)]
+		f0.Add(u, v).Mul(&f0, &twoInv)
+		f1.Sub(u, v).Mul(&f1, &twiddlesInv[i]).Mul(&f1, &halfAlpha)
+		res[i].Add(&f0, &f1)
+	}
+	return res
+}
@@ -0,0 +1,149 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package circle provides the circle FFT and FRI folding over the Mersenne-31 field, as
+// described in "Circle STARKs" (Haböck, Levit, Papini — https://eprint.iacr.org/2024/278).
+//
+// The multiplicative group of 𝔽q, q = 2³¹ - 1, has no large two-adic subgroup. Instead, the
+// points of the circle x² + y² = 1 over 𝔽q form a cyclic group of order q + 1 = 2³¹, on which
+// the FFT domains are defined.
+package circle
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/field/m31"
+)
+
+// MaxLogOrder is the base 2 logarithm of the order of the circle group.
+const MaxLogOrder = 31
+
+// Point is a point (X, Y) of the circle X² + Y² = 1.
+//
+// The group law is (x₀, y₀)·(x₁, y₁) = (x₀x₁ - y₀y₁, x₀y₁ + y₀x₁), with identity (1, 0);
+// it is written additively.
+type Point struct {
+	X, Y m31.Element
+}
+
+var generator Point
+
+func init() {
+	generator.X.SetUint64(2)
+	generator.Y.SetUint64(1268011823)
+}
+
+// Generator returns a generator of the circle group, of order 2³¹.
+func Generator() Point {
+	return generator
+}
+
+// SubgroupGenerator returns a generator of the subgroup of order 2^logOrder
+// or an error if logOrder > MaxLogOrder.
+func SubgroupGenerator(logOrder uint64) (Point, error) {
+	if logOrder > MaxLogOrder {
+		return Point{}, errors.New("circle: subgroup order is too large")
+	}
+	res := generator
+	for i := logOrder; i < MaxLogOrder; i++ {
+		res.Double(&res)
+	}
+	return res, nil
+}
+
+// SetIdentity sets p to the identity (1, 0) and returns p.
+func (p *Point) SetIdentity() *Point {
+	p.X.SetOne()
+	p.Y.SetZero()
+	return p
+}
+
+// Equal returns true if p equals q, false otherwise.
+func (p *Point) Equal(q *Point) bool {
+	return p.X.Equal(&q.X) && p.Y.Equal(&q.Y)
+}
+
+// IsOnCircle returns true if X² + Y² = 1.
+func (p *Point) IsOnCircle() bool {
+	var x2, y2 m31.Element
+	x2.Square(&p.X)
+	y2.Square(&p.Y)
+	return x2.Add(&x2, &y2).IsOne()
+}
+
+// Add sets p = a + b and returns p.
+func (p *Point) Add(a, b *Point) *Point {
+	var x, y, t m31.Element
+	x.Mul(&a.X, &b.X)
+	t.Mul(&a.Y, &b.Y)
+	x.Sub(&x, &t)
+	y.Mul(&a.X, &b.Y)
+	t.Mul(&a.Y, &b.X)
+	y.Add(&y, &t)
+	p.X, p.Y = x, y
+	return p
+}
+
+// Double sets p = 2·a and returns p.
+func (p *Point) Double(a *Point) *Point {
+	var y m31.Element
+	y.Mul(&a.X, &a.Y).Double(&y)
+	p.X.Set(&a.X)
+	double(&p.X)
+	p.Y = y
+	return p
+}
+
+// Neg sets p = -a, the conjugate (x, -y) of a, and returns p.
+func (p *Point) Neg(a *Point) *Point {
+	p.X = a.X
+	p.Y.Neg(&a.Y)
+	return p
+}
+
+// Antipode sets p to the antipode (-x, -y) of a, that is a plus the point of order 2,
+// and returns p.
+func (p *Point) Antipode(a *Point) *Point {
+	p.X.Neg(&a.X)
+	p.Y.Neg(&a.Y)
+	return p
+}
+
+// ScalarMul sets p = k·a and returns p.
+func (p *Point) ScalarMul(a *Point, k uint64) *Point {
+	res := Point{}
+	res.SetIdentity()
+	base := *a
+	for ; k != 0; k >>= 1 {
+		if k&1 == 1 {
+			res.Add(&res, &base)
+		}
+		base.Double(&base)
+	}
+	*p = res
+	return p
+}
+
+// String returns the decimal representation of p.
+func (p *Point) String() string {
+	return "(" + p.X.String() + ", " + p.Y.String() + ")"
+}
+
+// double sets x to the x-coordinate 2x² - 1 of the double of a point of x-coordinate x.
+func double(x *m31.Element) {
+	x.Square(x).Double(x)
+	var one m31.Element
+	one.SetOne()
+	x.Sub(x, &one)
+}
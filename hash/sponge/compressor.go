@@ -0,0 +1,99 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sponge
+
+import (
+	"hash"
+)
+
+// Compressor is a 2-to-1 compression function on a permutation P of width at least 2:
+//
+//	Compress(a, b) = P(a, b, 0, …, 0)[0] + a
+//
+// The feed-forward of a makes it non-invertible. It costs a single permutation call,
+// which makes it cheaper than a sponge to hash the nodes of a Merkle tree.
+type Compressor[E any, PE Element[E]] struct {
+	perm Permutation[E]
+}
+
+// NewCompressor returns a compressor on perm.
+func NewCompressor[E any, PE Element[E]](perm Permutation[E]) (*Compressor[E, PE], error) {
+	if perm.Width() < 2 {
+		return nil, ErrInvalidWidth
+	}
+	return &Compressor[E, PE]{perm: perm}, nil
+}
+
+// Compress compresses a and b into a single element.
+func (c *Compressor[E, PE]) Compress(a, b E) E {
+	state := make([]E, c.perm.Width())
+	state[0], state[1] = a, b
+	c.perm.Permute(state)
+	PE(&state[0]).Add(&state[0], &a)
+	return state[0]
+}
+
+// Hasher returns a hash.Hash on the compressor, usable as the hash of a merkletree.Tree.
+//
+// The written input is decoded as for NewHasher, as x₀, …, xₙ₋₁, and chained as
+// h = Compress(…Compress(Compress(x₀, x₁), x₂)…, xₙ₋₁). In particular the digest of
+// two elements is Compress(x₀, x₁), the digest of a single element is the element
+// itself, and the digest of an empty input is zero. Inputs of different lengths are
+// not separated: it is meant for trees whose leaves are already field elements.
+func (c *Compressor[E, PE]) Hasher() hash.Hash {
+	var e E
+	return &compressorHasher[E, PE]{c: c, size: len(PE(&e).Marshal())}
+}
+
+type compressorHasher[E any, PE Element[E]] struct {
+	c    *Compressor[E, PE]
+	size int
+	acc  E
+	n    int
+}
+
+func (h *compressorHasher[E, PE]) Write(p []byte) (int, error) {
+	elems, err := decode[E, PE](p, h.size)
+	if err != nil {
+		return 0, err
+	}
+	for i := range elems {
+		if h.n == 0 {
+			h.acc = elems[i]
+		} else {
+			h.acc = h.c.Compress(h.acc, elems[i])
+		}
+		h.n++
+	}
+	return len(p), nil
+}
+
+func (h *compressorHasher[E, PE]) Sum(b []byte) []byte {
+	return append(b, PE(&h.acc).Marshal()...)
+}
+
+func (h *compressorHasher[E, PE]) Reset() {
+	var zero E
+	h.acc = zero
+	h.n = 0
+}
+
+func (h *compressorHasher[E, PE]) Size() int {
+	return h.size
+}
+
+func (h *compressorHasher[E, PE]) BlockSize() int {
+	return h.size
+}
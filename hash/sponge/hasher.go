@@ -0,0 +1,90 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sponge
+
+import (
+	"errors"
+	"hash"
+)
+
+type hasher[E any, PE Element[E]] struct {
+	sponge *Sponge[E, PE]
+	size   int
+}
+
+// NewHasher returns a hash.Hash built on a sponge, see New for the parameters.
+//
+// As for MiMC, the input is a sequence of field elements in canonical big-endian
+// encoding: each Write must be a multiple of the size of an element, except for writes
+// shorter than an element, which are left-padded with zeros. The digest is the first
+// squeezed element.
+func NewHasher[E any, PE Element[E]](perm Permutation[E], rate, capacity int, opts ...Option) (hash.Hash, error) {
+	s, err := New[E, PE](perm, rate, capacity, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var e E
+	return &hasher[E, PE]{sponge: s, size: len(PE(&e).Marshal())}, nil
+}
+
+func (h *hasher[E, PE]) Write(p []byte) (int, error) {
+	elems, err := decode[E, PE](p, h.size)
+	if err != nil {
+		return 0, err
+	}
+	if err := h.sponge.Absorb(elems...); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (h *hasher[E, PE]) Sum(b []byte) []byte {
+	res := h.sponge.clone().Squeeze(1)
+	return append(b, PE(&res[0]).Marshal()...)
+}
+
+func (h *hasher[E, PE]) Reset() {
+	h.sponge.Reset()
+}
+
+func (h *hasher[E, PE]) Size() int {
+	return h.size
+}
+
+func (h *hasher[E, PE]) BlockSize() int {
+	return h.size
+}
+
+// decode reads the field elements encoded in p.
+func decode[E any, PE Element[E]](p []byte, size int) ([]E, error) {
+	if len(p) == 0 {
+		return nil, nil
+	}
+	if len(p) < size {
+		buf := make([]byte, size)
+		copy(buf[size-len(p):], p)
+		p = buf
+	}
+	if len(p)%size != 0 {
+		return nil, errors.New("invalid input length: must represent a list of field elements, expects a []byte of len m*BlockSize()")
+	}
+	res := make([]E, len(p)/size)
+	for i := range res {
+		if err := PE(&res[i]).SetBytesCanonical(p[i*size : (i+1)*size]); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,194 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sponge implements the sponge construction over a prime field, on top of any
+// permutation of a fixed-size state of field elements (MiMC, Poseidon2, Anemoi, Rescue, …).
+//
+// A Sponge absorbs field elements into the first rate elements of the state and
+// squeezes any number of field elements out of them. It is wrapped as a hash.Hash by
+// NewHasher, and a 2-to-1 compression function suited to Merkle trees is provided by
+// Compressor.
+package sponge
+
+import (
+	"errors"
+)
+
+var (
+	ErrInvalidParameters  = errors.New("rate and capacity must be positive and add up to the permutation width")
+	ErrAbsorbAfterSqueeze = errors.New("cannot absorb after squeezing, call Reset first")
+	ErrInvalidWidth       = errors.New("the permutation width must be at least 2")
+)
+
+// Permutation is a permutation of a state of Width() field elements.
+type Permutation[E any] interface {
+	// Width returns the number of field elements of the state.
+	Width() int
+
+	// Permute applies the permutation to state, in place. len(state) is Width().
+	Permute(state []E)
+}
+
+// Element is the set of methods a field element must implement to be used in a sponge.
+type Element[E any] interface {
+	*E
+	Add(a, b *E) *E
+	SetOne() *E
+	SetUint64(uint64) *E
+	SetBytesCanonical([]byte) error
+	Marshal() []byte
+}
+
+// Padding selects how the absorbed input is completed to a multiple of the rate.
+type Padding uint8
+
+const (
+	// Padding10 appends a one followed by as many zeros as needed (10* padding).
+	// It makes the sponge injective on inputs of any length.
+	Padding10 Padding = iota
+
+	// PaddingNone appends zeros only, up to the end of the last block. The sponge is then only secure for inputs of a
+	// fixed length, or when the length is bound by the domain.
+	PaddingNone
+)
+
+type config struct {
+	padding Padding
+	domain  uint64
+}
+
+// Option configures a sponge.
+type Option func(*config)
+
+// WithPadding sets the padding rule. Default is Padding10.
+func WithPadding(p Padding) Option {
+	return func(c *config) {
+		c.padding = p
+	}
+}
+
+// WithDomain sets the first capacity element of the initial state to domain, to
+// separate the different uses of a permutation. Default is 0.
+func WithDomain(domain uint64) Option {
+	return func(c *config) {
+		c.domain = domain
+	}
+}
+
+// Sponge is a duplex-free sponge over a field: a sequence of Absorb calls followed by
+// a sequence of Squeeze calls.
+type Sponge[E any, PE Element[E]] struct {
+	perm      Permutation[E]
+	rate      int
+	cfg       config
+	state     []E
+	pos       int  // next position in the rate part of the state
+	absorbed  bool // at least one element was absorbed since the last Reset
+	squeezing bool
+}
+
+// New returns a sponge on perm, absorbing and squeezing rate elements per permutation
+// call. rate+capacity must be perm.Width().
+func New[E any, PE Element[E]](perm Permutation[E], rate, capacity int, opts ...Option) (*Sponge[E, PE], error) {
+	if rate <= 0 || capacity <= 0 || rate+capacity != perm.Width() {
+		return nil, ErrInvalidParameters
+	}
+	s := &Sponge[E, PE]{
+		perm:  perm,
+		rate:  rate,
+		state: make([]E, rate+capacity),
+	}
+	for _, opt := range opts {
+		opt(&s.cfg)
+	}
+	s.Reset()
+	return s, nil
+}
+
+// Reset sets the sponge back to its initial state.
+func (s *Sponge[E, PE]) Reset() {
+	var zero E
+	for i := range s.state {
+		s.state[i] = zero
+	}
+	PE(&s.state[s.rate]).SetUint64(s.cfg.domain)
+	s.pos = 0
+	s.absorbed = false
+	s.squeezing = false
+}
+
+// Rate returns the number of elements absorbed or squeezed per permutation call.
+func (s *Sponge[E, PE]) Rate() int {
+	return s.rate
+}
+
+// Absorb adds elems to the state. It fails once Squeeze has been called.
+func (s *Sponge[E, PE]) Absorb(elems ...E) error {
+	if s.squeezing {
+		return ErrAbsorbAfterSqueeze
+	}
+	if len(elems) > 0 {
+		s.absorbed = true
+	}
+	for i := range elems {
+		PE(&s.state[s.pos]).Add(&s.state[s.pos], &elems[i])
+		s.pos++
+		if s.pos == s.rate {
+			s.perm.Permute(s.state)
+			s.pos = 0
+		}
+	}
+	return nil
+}
+
+// Squeeze returns the next n output elements. The first call pads the absorbed input.
+func (s *Sponge[E, PE]) Squeeze(n int) []E {
+	if !s.squeezing {
+		s.pad()
+		s.squeezing = true
+	}
+	res := make([]E, n)
+	for i := range res {
+		if s.pos == s.rate {
+			s.perm.Permute(s.state)
+			s.pos = 0
+		}
+		res[i] = s.state[s.pos]
+		s.pos++
+	}
+	return res
+}
+
+// pad completes the last absorbed block and permutes it. Without padding, a complete
+// last block has already been permuted.
+func (s *Sponge[E, PE]) pad() {
+	switch {
+	case s.cfg.padding == Padding10:
+		var one E
+		PE(&one).SetOne()
+		PE(&s.state[s.pos]).Add(&s.state[s.pos], &one)
+	case s.pos == 0 && s.absorbed:
+		return
+	}
+	s.perm.Permute(s.state)
+	s.pos = 0
+}
+
+// clone returns an independent copy of the sponge.
+func (s *Sponge[E, PE]) clone() *Sponge[E, PE] {
+	res := *s
+	res.state = make([]E, len(s.state))
+	copy(res.state, s.state)
+	return &res
+}
@@ -0,0 +1,245 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sponge
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/accumulator/merkletree"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+)
+
+// feistelMiMC is a generalized Feistel permutation whose round function is the MiMC
+// cipher: each round adds MiMC(s₁+…+sₙ₋₁, 0) to s₀, then rotates the state.
+type feistelMiMC struct {
+	width int
+}
+
+func (p feistelMiMC) Width() int {
+	return p.width
+}
+
+func (p feistelMiMC) Permute(state []fr.Element) {
+	for r := 0; r < 2*p.width; r++ {
+		var sum, zero fr.Element
+		for i := 1; i < len(state); i++ {
+			sum.Add(&sum, &state[i])
+		}
+		f := mimc.Encrypt(sum, zero)
+		state[0].Add(&state[0], &f)
+		first := state[0]
+		copy(state, state[1:])
+		state[len(state)-1] = first
+	}
+}
+
+func randomElements(n int) []fr.Element {
+	res := make([]fr.Element, n)
+	for i := range res {
+		res[i].SetRandom()
+	}
+	return res
+}
+
+func TestSpongeParameters(t *testing.T) {
+	perm := feistelMiMC{3}
+	for _, rc := range [][2]int{{0, 3}, {3, 0}, {2, 2}, {1, 1}} {
+		if _, err := New[fr.Element](perm, rc[0], rc[1]); err != ErrInvalidParameters {
+			t.Fatalf("rate %d capacity %d: expected ErrInvalidParameters, got %v", rc[0], rc[1], err)
+		}
+	}
+	if _, err := NewCompressor[fr.Element](feistelMiMC{1}); err != ErrInvalidWidth {
+		t.Fatal("expected ErrInvalidWidth")
+	}
+}
+
+func TestSpongeAbsorbSqueeze(t *testing.T) {
+	perm := feistelMiMC{3}
+	s, err := New[fr.Element](perm, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := randomElements(5)
+
+	// absorbing in one or several calls is the same
+	if err := s.Absorb(input...); err != nil {
+		t.Fatal(err)
+	}
+	out := s.Squeeze(7)
+	if err := s.Absorb(input[0]); err != ErrAbsorbAfterSqueeze {
+		t.Fatal("expected ErrAbsorbAfterSqueeze")
+	}
+	s.Reset()
+	for i := range input {
+		if err := s.Absorb(input[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// squeezing in one or several calls is the same
+	out2 := append(s.Squeeze(3), s.Squeeze(4)...)
+	for i := range out {
+		if !out[i].Equal(&out2[i]) {
+			t.Fatal("output depends on how the input is absorbed or the output squeezed")
+		}
+	}
+
+	// 10* padding separates inputs ending with zeros
+	s.Reset()
+	var zero fr.Element
+	if err := s.Absorb(append(input, zero)...); err != nil {
+		t.Fatal(err)
+	}
+	if out3 := s.Squeeze(1); out3[0].Equal(&out[0]) {
+		t.Fatal("padding does not separate trailing zeros")
+	}
+
+	// so does the domain
+	s, err = New[fr.Element](perm, 2, 1, WithDomain(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Absorb(input...); err != nil {
+		t.Fatal(err)
+	}
+	if out3 := s.Squeeze(1); out3[0].Equal(&out[0]) {
+		t.Fatal("domain does not separate outputs")
+	}
+}
+
+func TestSpongePaddingNone(t *testing.T) {
+	perm := feistelMiMC{3}
+	s, err := New[fr.Element](perm, 2, 1, WithPadding(PaddingNone))
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := randomElements(3)
+	if err := s.Absorb(input...); err != nil {
+		t.Fatal(err)
+	}
+	out := s.Squeeze(1)
+
+	// without padding, the input is completed with zeros
+	s.Reset()
+	var zero fr.Element
+	if err := s.Absorb(append(input, zero)...); err != nil {
+		t.Fatal(err)
+	}
+	if out2 := s.Squeeze(1); !out2[0].Equal(&out[0]) {
+		t.Fatal("unexpected output without padding")
+	}
+}
+
+func TestHasher(t *testing.T) {
+	perm := feistelMiMC{3}
+	h, err := NewHasher[fr.Element](perm, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := New[fr.Element](perm, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := randomElements(3)
+	for i := range input {
+		b := input[i].Bytes()
+		h.Write(b[:])
+	}
+	s.Absorb(input...)
+	expected := s.Squeeze(1)[0].Bytes()
+
+	// Sum does not change the state
+	if !bytes.Equal(h.Sum(nil), expected[:]) || !bytes.Equal(h.Sum(nil), expected[:]) {
+		t.Fatal("hasher and sponge disagree")
+	}
+
+	// short writes are left-padded
+	h.Reset()
+	h.Write([]byte{1, 2})
+	var e fr.Element
+	e.SetUint64(0x0102)
+	s.Reset()
+	s.Absorb(e)
+	expected = s.Squeeze(1)[0].Bytes()
+	if !bytes.Equal(h.Sum(nil), expected[:]) {
+		t.Fatal("short write is not left-padded")
+	}
+
+	// non canonical and misaligned inputs are rejected
+	h.Reset()
+	if _, err := h.Write(bytes.Repeat([]byte{0xff}, fr.Bytes)); err == nil {
+		t.Fatal("non canonical input accepted")
+	}
+	if _, err := h.Write(make([]byte, fr.Bytes+1)); err == nil {
+		t.Fatal("misaligned input accepted")
+	}
+}
+
+func TestCompressorMerkleTree(t *testing.T) {
+	c, err := NewCompressor[fr.Element](feistelMiMC{3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaves := randomElements(8)
+	h := c.Hasher()
+
+	// hash of two elements
+	b0, b1 := leaves[0].Bytes(), leaves[1].Bytes()
+	h.Write(b0[:])
+	h.Write(b1[:])
+	expected := c.Compress(leaves[0], leaves[1])
+	if !bytes.Equal(h.Sum(nil), expected.Marshal()) {
+		t.Fatal("hasher and compressor disagree")
+	}
+	if swapped := c.Compress(leaves[1], leaves[0]); swapped.Equal(&expected) {
+		t.Fatal("compression should not be symmetric")
+	}
+
+	// merkle tree of the leaves
+	h.Reset()
+	tree := merkletree.New(h)
+	if err := tree.SetIndex(3); err != nil {
+		t.Fatal(err)
+	}
+	for i := range leaves {
+		tree.Push(leaves[i].Marshal())
+	}
+	root, proofSet, proofIndex, numLeaves := tree.Prove()
+	if !merkletree.VerifyProof(h, root, proofSet, proofIndex, numLeaves) {
+		t.Fatal("invalid merkle proof")
+	}
+
+	// the root chains the compressions
+	var nodes [4]fr.Element
+	for i := range nodes {
+		nodes[i] = c.Compress(leaves[2*i], leaves[2*i+1])
+	}
+	expected = c.Compress(c.Compress(nodes[0], nodes[1]), c.Compress(nodes[2], nodes[3]))
+	if !bytes.Equal(root, expected.Marshal()) {
+		t.Fatal("unexpected merkle root")
+	}
+}
+
+func BenchmarkSponge(b *testing.B) {
+	s, _ := New[fr.Element](feistelMiMC{3}, 2, 1)
+	input := randomElements(16)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Reset()
+		s.Absorb(input...)
+		s.Squeeze(1)
+	}
+}
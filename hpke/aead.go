@@ -0,0 +1,53 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hpke
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// AEAD is an authenticated encryption scheme of HPKE.
+type AEAD struct {
+	id        uint16
+	keySize   int // Nk
+	nonceSize int // Nn
+	new       func(key []byte) (cipher.AEAD, error)
+}
+
+var (
+	AES128GCM        = AEAD{id: 0x0001, keySize: 16, nonceSize: 12, new: newGCM}
+	AES256GCM        = AEAD{id: 0x0002, keySize: 32, nonceSize: 12, new: newGCM}
+	ChaCha20Poly1305 = AEAD{id: 0x0003, keySize: chacha20poly1305.KeySize, nonceSize: chacha20poly1305.NonceSize, new: chacha20poly1305.New}
+
+	// ExportOnly is the AEAD of contexts only used to export secrets: they cannot Seal
+	// nor Open.
+	ExportOnly = AEAD{id: 0xffff}
+)
+
+// ID returns the identifier of the AEAD in the HPKE registry.
+func (a AEAD) ID() uint16 {
+	return a.id
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
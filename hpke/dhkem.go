@@ -0,0 +1,152 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hpke
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+)
+
+// DHGroup is a prime order group on which a DHKEM is built. Secret keys are scalars
+// and public keys are points, both serialized.
+type DHGroup interface {
+	// KEMID returns the identifier of the DHKEM on the group.
+	KEMID() uint16
+
+	// PrivateKeySize returns Nsk, the size of a serialized scalar.
+	PrivateKeySize() int
+
+	// PublicKeySize returns Npk, the size of a serialized point.
+	PublicKeySize() int
+
+	// Bitmask returns the mask applied to the first byte of the candidate scalars in
+	// DeriveKeyPair.
+	Bitmask() byte
+
+	// PublicKey returns the public key of the secret key sk, or an error if sk is not a
+	// valid scalar (zero or larger than the group order).
+	PublicKey(sk []byte) ([]byte, error)
+
+	// DH returns the shared secret of sk and pk, or an error if pk is not a valid point
+	// of the group or the result is the identity.
+	DH(sk, pk []byte) ([]byte, error)
+}
+
+// DHKEM is the Diffie-Hellman based KEM of RFC 9180, section 4.1, on any DHGroup.
+type DHKEM struct {
+	group   DHGroup
+	kdf     KDF
+	suiteID []byte
+}
+
+// NewDHKEM returns the DHKEM on group with kdf.
+func NewDHKEM(group DHGroup, kdf KDF) *DHKEM {
+	return &DHKEM{
+		group:   group,
+		kdf:     kdf,
+		suiteID: binary.BigEndian.AppendUint16([]byte("KEM"), group.KEMID()),
+	}
+}
+
+// ID returns the identifier of the KEM.
+func (k *DHKEM) ID() uint16 {
+	return k.group.KEMID()
+}
+
+// GenerateKeyPair returns a random key pair.
+func (k *DHKEM) GenerateKeyPair(r io.Reader) (sk, pk []byte, err error) {
+	if r == nil {
+		r = rand.Reader
+	}
+	ikm := make([]byte, k.group.PrivateKeySize())
+	if _, err := io.ReadFull(r, ikm); err != nil {
+		return nil, nil, err
+	}
+	return k.DeriveKeyPair(ikm)
+}
+
+// DeriveKeyPair deterministically derives a key pair from the input keying material
+// ikm, which must have at least as much entropy as a secret key.
+func (k *DHKEM) DeriveKeyPair(ikm []byte) (sk, pk []byte, err error) {
+	dkpPRK := k.kdf.labeledExtract(k.suiteID, nil, "dkp_prk", ikm)
+	for counter := 0; counter < 256; counter++ {
+		sk, err = k.kdf.labeledExpand(k.suiteID, dkpPRK, "candidate", []byte{byte(counter)}, k.group.PrivateKeySize())
+		if err != nil {
+			return nil, nil, err
+		}
+		sk[0] &= k.group.Bitmask()
+		if pk, err = k.group.PublicKey(sk); err == nil {
+			return sk, pk, nil
+		}
+	}
+	return nil, nil, ErrDeriveKeyPair
+}
+
+// encap returns a shared secret and its encapsulation for pkR, authenticated with skS
+// if not nil.
+func (k *DHKEM) encap(r io.Reader, pkR, skS []byte) (sharedSecret, enc []byte, err error) {
+	skE, pkE, err := k.GenerateKeyPair(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	dh, err := k.group.DH(skE, pkR)
+	if err != nil {
+		return nil, nil, err
+	}
+	kemContext := append(append([]byte{}, pkE...), pkR...)
+	if skS != nil {
+		dhS, err := k.group.DH(skS, pkR)
+		if err != nil {
+			return nil, nil, err
+		}
+		pkS, err := k.group.PublicKey(skS)
+		if err != nil {
+			return nil, nil, err
+		}
+		dh = append(dh, dhS...)
+		kemContext = append(kemContext, pkS...)
+	}
+	sharedSecret, err = k.extractAndExpand(dh, kemContext)
+	return sharedSecret, pkE, err
+}
+
+// decap returns the shared secret encapsulated in enc for skR, authenticated by pkS if
+// not nil.
+func (k *DHKEM) decap(enc, skR, pkS []byte) ([]byte, error) {
+	dh, err := k.group.DH(skR, enc)
+	if err != nil {
+		return nil, err
+	}
+	pkR, err := k.group.PublicKey(skR)
+	if err != nil {
+		return nil, err
+	}
+	kemContext := append(append([]byte{}, enc...), pkR...)
+	if pkS != nil {
+		dhS, err := k.group.DH(skR, pkS)
+		if err != nil {
+			return nil, err
+		}
+		dh = append(dh, dhS...)
+		kemContext = append(kemContext, pkS...)
+	}
+	return k.extractAndExpand(dh, kemContext)
+}
+
+func (k *DHKEM) extractAndExpand(dh, kemContext []byte) ([]byte, error) {
+	eaePRK := k.kdf.labeledExtract(k.suiteID, nil, "eae_prk", dh)
+	return k.kdf.labeledExpand(k.suiteID, eaePRK, "shared_secret", kemContext, k.kdf.size())
+}
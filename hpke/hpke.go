@@ -0,0 +1,259 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hpke implements Hybrid Public Key Encryption (RFC 9180) with DHKEMs on the
+// curves of gnark-crypto.
+//
+// A Suite composes a KEM (a DHKEM on a curve, e.g. on secp256k1 or on the G1 group of
+// bn254, see the hpke packages of these curves), a KDF and an AEAD. The sender sets up
+// an encryption context from the public key of the receiver, and sends the
+// encapsulated key with the ciphertexts; the receiver sets up the matching decryption
+// context from the encapsulated key and its secret key. The four modes of RFC 9180
+// (base, psk, auth, auth_psk) are supported.
+package hpke
+
+import (
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+var (
+	ErrInvalidLength      = errors.New("hpke: invalid length")
+	ErrDeriveKeyPair      = errors.New("hpke: could not derive a key pair")
+	ErrInconsistentPSK    = errors.New("hpke: psk and psk id must be both set or both empty")
+	ErrMessageLimit       = errors.New("hpke: message limit reached")
+	ErrOpen               = errors.New("hpke: could not open the ciphertext")
+	ErrExportOnly         = errors.New("hpke: the context is export only")
+	ErrInvalidPublicKey   = errors.New("hpke: invalid public key")
+	ErrInvalidPrivateKey  = errors.New("hpke: invalid private key")
+	ErrInvalidSharedPoint = errors.New("hpke: the shared point is the identity")
+)
+
+// Mode is the mode of HPKE.
+type Mode uint8
+
+const (
+	ModeBase    Mode = 0x00
+	ModePSK     Mode = 0x01
+	ModeAuth    Mode = 0x02
+	ModeAuthPSK Mode = 0x03
+)
+
+// Suite is a cipher suite of HPKE.
+type Suite struct {
+	kem     *DHKEM
+	kdf     KDF
+	aead    AEAD
+	suiteID []byte
+}
+
+// NewSuite returns the suite composing kem, kdf and aead.
+func NewSuite(kem *DHKEM, kdf KDF, aead AEAD) *Suite {
+	suiteID := []byte("HPKE")
+	suiteID = binary.BigEndian.AppendUint16(suiteID, kem.ID())
+	suiteID = binary.BigEndian.AppendUint16(suiteID, kdf.ID())
+	suiteID = binary.BigEndian.AppendUint16(suiteID, aead.ID())
+	return &Suite{kem: kem, kdf: kdf, aead: aead, suiteID: suiteID}
+}
+
+// KEM returns the KEM of the suite.
+func (s *Suite) KEM() *DHKEM {
+	return s.kem
+}
+
+// Sender is an encryption context.
+type Sender struct {
+	context
+}
+
+// Receiver is a decryption context.
+type Receiver struct {
+	context
+}
+
+type context struct {
+	aead           cipher.AEAD // nil if export only
+	baseNonce      []byte
+	seq            uint64
+	exporterSecret []byte
+	suite          *Suite
+}
+
+// SetupBaseS sets up an encryption context for pkR. It returns the encapsulated key to
+// send to the receiver.
+func (s *Suite) SetupBaseS(r io.Reader, pkR, info []byte) (enc []byte, sender *Sender, err error) {
+	return s.setupS(r, ModeBase, pkR, info, nil, nil, nil)
+}
+
+// SetupBaseR sets up the decryption context of the encapsulated key enc.
+func (s *Suite) SetupBaseR(enc, skR, info []byte) (*Receiver, error) {
+	return s.setupR(ModeBase, enc, skR, info, nil, nil, nil)
+}
+
+// SetupPSKS sets up an encryption context for pkR, authenticated with a pre-shared key.
+func (s *Suite) SetupPSKS(r io.Reader, pkR, info, psk, pskID []byte) (enc []byte, sender *Sender, err error) {
+	return s.setupS(r, ModePSK, pkR, info, psk, pskID, nil)
+}
+
+// SetupPSKR sets up the decryption context of enc, authenticated with a pre-shared key.
+func (s *Suite) SetupPSKR(enc, skR, info, psk, pskID []byte) (*Receiver, error) {
+	return s.setupR(ModePSK, enc, skR, info, psk, pskID, nil)
+}
+
+// SetupAuthS sets up an encryption context for pkR, authenticated with the secret key
+// skS of the sender.
+func (s *Suite) SetupAuthS(r io.Reader, pkR, info, skS []byte) (enc []byte, sender *Sender, err error) {
+	return s.setupS(r, ModeAuth, pkR, info, nil, nil, skS)
+}
+
+// SetupAuthR sets up the decryption context of enc, authenticated with the public key
+// pkS of the sender.
+func (s *Suite) SetupAuthR(enc, skR, info, pkS []byte) (*Receiver, error) {
+	return s.setupR(ModeAuth, enc, skR, info, nil, nil, pkS)
+}
+
+// SetupAuthPSKS sets up an encryption context for pkR, authenticated with both a
+// pre-shared key and the secret key skS of the sender.
+func (s *Suite) SetupAuthPSKS(r io.Reader, pkR, info, psk, pskID, skS []byte) (enc []byte, sender *Sender, err error) {
+	return s.setupS(r, ModeAuthPSK, pkR, info, psk, pskID, skS)
+}
+
+// SetupAuthPSKR sets up the decryption context of enc, authenticated with both a
+// pre-shared key and the public key pkS of the sender.
+func (s *Suite) SetupAuthPSKR(enc, skR, info, psk, pskID, pkS []byte) (*Receiver, error) {
+	return s.setupR(ModeAuthPSK, enc, skR, info, psk, pskID, pkS)
+}
+
+// Seal encrypts pt for pkR in base mode, in a single shot.
+func (s *Suite) Seal(r io.Reader, pkR, info, aad, pt []byte) (enc, ct []byte, err error) {
+	enc, sender, err := s.SetupBaseS(r, pkR, info)
+	if err != nil {
+		return nil, nil, err
+	}
+	ct, err = sender.Seal(aad, pt)
+	return enc, ct, err
+}
+
+// Open decrypts ct encrypted with Seal, in a single shot.
+func (s *Suite) Open(enc, skR, info, aad, ct []byte) ([]byte, error) {
+	receiver, err := s.SetupBaseR(enc, skR, info)
+	if err != nil {
+		return nil, err
+	}
+	return receiver.Open(aad, ct)
+}
+
+func (s *Suite) setupS(r io.Reader, mode Mode, pkR, info, psk, pskID, skS []byte) ([]byte, *Sender, error) {
+	sharedSecret, enc, err := s.kem.encap(r, pkR, skS)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, err := s.keySchedule(mode, sharedSecret, info, psk, pskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return enc, &Sender{ctx}, nil
+}
+
+func (s *Suite) setupR(mode Mode, enc, skR, info, psk, pskID, pkS []byte) (*Receiver, error) {
+	sharedSecret, err := s.kem.decap(enc, skR, pkS)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err := s.keySchedule(mode, sharedSecret, info, psk, pskID)
+	if err != nil {
+		return nil, err
+	}
+	return &Receiver{ctx}, nil
+}
+
+// keySchedule is KeySchedule of RFC 9180, section 5.1.
+func (s *Suite) keySchedule(mode Mode, sharedSecret, info, psk, pskID []byte) (context, error) {
+	var ctx context
+	withPSK := mode == ModePSK || mode == ModeAuthPSK
+	if (len(psk) == 0) != (len(pskID) == 0) || withPSK != (len(psk) != 0) {
+		return ctx, ErrInconsistentPSK
+	}
+
+	pskIDHash := s.kdf.labeledExtract(s.suiteID, nil, "psk_id_hash", pskID)
+	infoHash := s.kdf.labeledExtract(s.suiteID, nil, "info_hash", info)
+	keyScheduleContext := append(append([]byte{byte(mode)}, pskIDHash...), infoHash...)
+
+	secret := s.kdf.labeledExtract(s.suiteID, sharedSecret, "secret", psk)
+
+	var err error
+	ctx.suite = s
+	if ctx.exporterSecret, err = s.kdf.labeledExpand(s.suiteID, secret, "exp", keyScheduleContext, s.kdf.size()); err != nil {
+		return ctx, err
+	}
+	if s.aead.id == ExportOnly.id {
+		return ctx, nil
+	}
+	key, err := s.kdf.labeledExpand(s.suiteID, secret, "key", keyScheduleContext, s.aead.keySize)
+	if err != nil {
+		return ctx, err
+	}
+	if ctx.baseNonce, err = s.kdf.labeledExpand(s.suiteID, secret, "base_nonce", keyScheduleContext, s.aead.nonceSize); err != nil {
+		return ctx, err
+	}
+	ctx.aead, err = s.aead.new(key)
+	return ctx, err
+}
+
+// nonce returns the nonce of the current message, base_nonce xor seq.
+func (c *context) nonce() []byte {
+	nonce := make([]byte, len(c.baseNonce))
+	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], c.seq)
+	for i := range nonce {
+		nonce[i] ^= c.baseNonce[i]
+	}
+	return nonce
+}
+
+// Export returns a secret of length bytes, derived from the context and exporterContext.
+func (c *context) Export(exporterContext []byte, length int) ([]byte, error) {
+	return c.suite.kdf.labeledExpand(c.suite.suiteID, c.exporterSecret, "sec", exporterContext, length)
+}
+
+// Seal encrypts and authenticates pt, and authenticates aad. The messages must be
+// opened in the same order they are sealed.
+func (s *Sender) Seal(aad, pt []byte) ([]byte, error) {
+	if s.aead == nil {
+		return nil, ErrExportOnly
+	}
+	if s.seq == ^uint64(0) {
+		return nil, ErrMessageLimit
+	}
+	ct := s.aead.Seal(nil, s.nonce(), pt, aad)
+	s.seq++
+	return ct, nil
+}
+
+// Open decrypts ct and authenticates ct and aad.
+func (r *Receiver) Open(aad, ct []byte) ([]byte, error) {
+	if r.aead == nil {
+		return nil, ErrExportOnly
+	}
+	if r.seq == ^uint64(0) {
+		return nil, ErrMessageLimit
+	}
+	pt, err := r.aead.Open(nil, r.nonce(), ct, aad)
+	if err != nil {
+		return nil, ErrOpen
+	}
+	r.seq++
+	return pt, nil
+}
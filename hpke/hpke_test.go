@@ -0,0 +1,365 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hpke
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+)
+
+// p256 is DHKEM(P-256, HKDF-SHA256) of RFC 9180, on crypto/ecdh.
+type p256 struct{}
+
+func (p256) KEMID() uint16       { return 0x0010 }
+func (p256) PrivateKeySize() int { return 32 }
+func (p256) PublicKeySize() int  { return 65 }
+func (p256) Bitmask() byte       { return 0xff }
+
+func (p256) PublicKey(sk []byte) ([]byte, error) {
+	k, err := ecdh.P256().NewPrivateKey(sk)
+	if err != nil {
+		return nil, ErrInvalidPrivateKey
+	}
+	return k.PublicKey().Bytes(), nil
+}
+
+func (p256) DH(sk, pk []byte) ([]byte, error) {
+	k, err := ecdh.P256().NewPrivateKey(sk)
+	if err != nil {
+		return nil, ErrInvalidPrivateKey
+	}
+	p, err := ecdh.P256().NewPublicKey(pk)
+	if err != nil {
+		return nil, ErrInvalidPublicKey
+	}
+	return k.ECDH(p)
+}
+
+func TestSuite(t *testing.T) {
+	kem := NewDHKEM(p256{}, HKDFSHA256)
+	skR, pkR, err := kem.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, kdf := range []KDF{HKDFSHA256, HKDFSHA384, HKDFSHA512} {
+		suite := NewSuite(kem, kdf, ChaCha20Poly1305)
+		enc, sender, err := suite.SetupBaseS(rand.Reader, pkR, []byte("info"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		receiver, err := suite.SetupBaseR(enc, skR, []byte("info"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// messages must be opened in order
+		ct1, _ := sender.Seal(nil, []byte("1"))
+		ct2, _ := sender.Seal(nil, []byte("2"))
+		if _, err := receiver.Open(nil, ct2); err != ErrOpen {
+			t.Fatal("opened a message out of order")
+		}
+		if pt, err := receiver.Open(nil, ct1); err != nil || !bytes.Equal(pt, []byte("1")) {
+			t.Fatal("could not open the first message")
+		}
+		if pt, err := receiver.Open(nil, ct2); err != nil || !bytes.Equal(pt, []byte("2")) {
+			t.Fatal("could not open the second message")
+		}
+	}
+
+	// export only
+	suite := NewSuite(kem, HKDFSHA256, ExportOnly)
+	enc, sender, err := suite.SetupBaseS(rand.Reader, pkR, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sender.Seal(nil, []byte("msg")); err != ErrExportOnly {
+		t.Fatal("export only context sealed a message")
+	}
+	receiver, err := suite.SetupBaseR(enc, skR, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e1, _ := sender.Export(nil, 32)
+	e2, _ := receiver.Export(nil, 32)
+	if !bytes.Equal(e1, e2) {
+		t.Fatal("exported secrets differ")
+	}
+
+	// psk and psk id must be consistent with the mode
+	suite = NewSuite(kem, HKDFSHA256, AES128GCM)
+	if _, _, err := suite.SetupPSKS(rand.Reader, pkR, nil, []byte("psk"), nil); err != ErrInconsistentPSK {
+		t.Fatal("expected ErrInconsistentPSK")
+	}
+	if _, _, err := suite.SetupPSKS(rand.Reader, pkR, nil, nil, nil); err != ErrInconsistentPSK {
+		t.Fatal("expected ErrInconsistentPSK")
+	}
+}
+
+type rfc9180Encryption struct {
+	seq                uint64
+	pt, aad, nonce, ct string
+}
+
+type rfc9180Export struct {
+	context string
+	length  int
+	value   string
+}
+
+// rfc9180Vectors are the test vectors of RFC 9180, appendix A.3:
+// DHKEM(P-256, HKDF-SHA256), HKDF-SHA256, AES-128-GCM. The encryptions are those
+// listed in the RFC, at the sequence numbers 0, 1, 2, 4, 255 and 256.
+var rfc9180Vectors = []struct {
+	mode                                    Mode
+	info, ikmE, ikmR, ikmS                  string
+	skE, skR, skS, pkE, pkR, pkS, enc       string
+	psk, pskID                              string
+	sharedSecret, baseNonce, exporterSecret string
+	encryptions                             []rfc9180Encryption
+	exports                                 []rfc9180Export
+}{
+	{ // A.3.1, base setup
+		mode:           ModeBase,
+		info:           "4f6465206f6e2061204772656369616e2055726e",
+		ikmE:           "4270e54ffd08d79d5928020af4686d8f6b7d35dbe470265f1f5aa22816ce860e",
+		ikmR:           "668b37171f1072f3cf12ea8a236a45df23fc13b82af3609ad1e354f6ef817550",
+		skE:            "4995788ef4b9d6132b249ce59a77281493eb39af373d236a1fe415cb0c2d7beb",
+		skR:            "f3ce7fdae57e1a310d87f1ebbde6f328be0a99cdbcadf4d6589cf29de4b8ffd2",
+		pkE:            "04a92719c6195d5085104f469a8b9814d5838ff72b60501e2c4466e5e67b325ac98536d7b61a1af4b78e5b7f951c0900be863c403ce65c9bfcb9382657222d18c4",
+		pkR:            "04fe8c19ce0905191ebc298a9245792531f26f0cece2460639e8bc39cb7f706a826a779b4cf969b8a0e539c7f62fb3d30ad6aa8f80e30f1d128aafd68a2ce72ea0",
+		enc:            "04a92719c6195d5085104f469a8b9814d5838ff72b60501e2c4466e5e67b325ac98536d7b61a1af4b78e5b7f951c0900be863c403ce65c9bfcb9382657222d18c4",
+		sharedSecret:   "c0d26aeab536609a572b07695d933b589dcf363ff9d93c93adea537aeabb8cb8",
+		baseNonce:      "4e0bc5018beba4bf004cca59",
+		exporterSecret: "14ad94af484a7ad3ef40e9f3be99ecc6fa9036df9d4920548424df127ee0d99f",
+		encryptions: []rfc9180Encryption{
+			{seq: 0, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d30", nonce: "4e0bc5018beba4bf004cca59", ct: "5ad590bb8baa577f8619db35a36311226a896e7342a6d836d8b7bcd2f20b6c7f9076ac232e3ab2523f39513434"},
+			{seq: 1, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d31", nonce: "4e0bc5018beba4bf004cca58", ct: "fa6f037b47fc21826b610172ca9637e82d6e5801eb31cbd3748271affd4ecb06646e0329cbdf3c3cd655b28e82"},
+			{seq: 2, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d32", nonce: "4e0bc5018beba4bf004cca5b", ct: "895cabfac50ce6c6eb02ffe6c048bf53b7f7be9a91fc559402cbc5b8dcaeb52b2ccc93e466c28fb55fed7a7fec"},
+			{seq: 4, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d34", nonce: "4e0bc5018beba4bf004cca5d", ct: "8787491ee8df99bc99a246c4b3216d3d57ab5076e18fa27133f520703bc70ec999dd36ce042e44f0c3169a6a8f"},
+			{seq: 255, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d323535", nonce: "4e0bc5018beba4bf004ccaa6", ct: "2ad71c85bf3f45c6eca301426289854b31448bcf8a8ccb1deef3ebd87f60848aa53c538c30a4dac71d619ee2cd"},
+			{seq: 256, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d323536", nonce: "4e0bc5018beba4bf004ccb59", ct: "10f179686aa2caec1758c8e554513f16472bd0a11e2a907dde0b212cbe87d74f367f8ffe5e41cd3e9962a6afb2"},
+		},
+		exports: []rfc9180Export{
+			{context: "", length: 32, value: "5e9bc3d236e1911d95e65b576a8a86d478fb827e8bdfe77b741b289890490d4d"},
+			{context: "00", length: 32, value: "6cff87658931bda83dc857e6353efe4987a201b849658d9b047aab4cf216e796"},
+			{context: "54657374436f6e74657874", length: 32, value: "d8f1ea7942adbba7412c6d431c62d01371ea476b823eb697e1f6e6cae1dab85a"},
+		},
+	},
+	{ // A.3.2, PSK setup
+		mode:           ModePSK,
+		info:           "4f6465206f6e2061204772656369616e2055726e",
+		ikmE:           "2afa611d8b1a7b321c761b483b6a053579afa4f767450d3ad0f84a39fda587a6",
+		ikmR:           "d42ef874c1913d9568c9405407c805baddaffd0898a00f1e84e154fa787b2429",
+		skE:            "57427244f6cc016cddf1c19c8973b4060aa13579b4c067fd5d93a5d74e32a90f",
+		skR:            "438d8bcef33b89e0e9ae5eb0957c353c25a94584b0dd59c991372a75b43cb661",
+		pkE:            "04305d35563527bce037773d79a13deabed0e8e7cde61eecee403496959e89e4d0ca701726696d1485137ccb5341b3c1c7aaee90a4a02449725e744b1193b53b5f",
+		pkR:            "040d97419ae99f13007a93996648b2674e5260a8ebd2b822e84899cd52d87446ea394ca76223b76639eccdf00e1967db10ade37db4e7db476261fcc8df97c5ffd1",
+		enc:            "04305d35563527bce037773d79a13deabed0e8e7cde61eecee403496959e89e4d0ca701726696d1485137ccb5341b3c1c7aaee90a4a02449725e744b1193b53b5f",
+		psk:            "0247fd33b913760fa1fa51e1892d9f307fbe65eb171e8132c2af18555a738b82",
+		pskID:          "456e6e796e20447572696e206172616e204d6f726961",
+		sharedSecret:   "2e783ad86a1beae03b5749e0f3f5e9bb19cb7eb382f2fb2dd64c99f15ae0661b",
+		baseNonce:      "b595dc6b2d7e2ed23af529b1",
+		exporterSecret: "895a723a1eab809804973a53c0ee18ece29b25a7555a4808277ad2651d66d705",
+		encryptions: []rfc9180Encryption{
+			{seq: 0, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d30", nonce: "b595dc6b2d7e2ed23af529b1", ct: "90c4deb5b75318530194e4bb62f890b019b1397bbf9d0d6eb918890e1fb2be1ac2603193b60a49c2126b75d0eb"},
+			{seq: 1, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d31", nonce: "b595dc6b2d7e2ed23af529b0", ct: "9e223384a3620f4a75b5a52f546b7262d8826dea18db5a365feb8b997180b22d72dc1287f7089a1073a7102c27"},
+			{seq: 2, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d32", nonce: "b595dc6b2d7e2ed23af529b3", ct: "adf9f6000773035023be7d415e13f84c1cb32a24339a32eb81df02be9ddc6abc880dd81cceb7c1d0c7781465b2"},
+			{seq: 4, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d34", nonce: "b595dc6b2d7e2ed23af529b5", ct: "1f4cc9b7013d65511b1f69c050b7bd8bbd5a5c16ece82b238fec4f30ba2400e7ca8ee482ac5253cffb5c3dc577"},
+			{seq: 255, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d323535", nonce: "b595dc6b2d7e2ed23af5294e", ct: "cdc541253111ed7a424eea5134dc14fc5e8293ab3b537668b8656789628e45894e5bb873c968e3b7cdcbb654a4"},
+			{seq: 256, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d323536", nonce: "b595dc6b2d7e2ed23af528b1", ct: "faf985208858b1253b97b60aecd28bc18737b58d1242370e7703ec33b73a4c31a1afee300e349adef9015bbbfd"},
+		},
+		exports: []rfc9180Export{
+			{context: "", length: 32, value: "a115a59bf4dd8dc49332d6a0093af8efca1bcbfd3627d850173f5c4a55d0c185"},
+			{context: "00", length: 32, value: "4517eaede0669b16aac7c92d5762dd459c301fa10e02237cd5aeb9be969430c4"},
+			{context: "54657374436f6e74657874", length: 32, value: "164e02144d44b607a7722e58b0f4156e67c0c2874d74cf71da6ca48a4cbdc5e0"},
+		},
+	},
+	{ // A.3.3, auth setup
+		mode:           ModeAuth,
+		info:           "4f6465206f6e2061204772656369616e2055726e",
+		ikmE:           "798d82a8d9ea19dbc7f2c6dfa54e8a6706f7cdc119db0813dacf8440ab37c857",
+		ikmR:           "7bc93bde8890d1fb55220e7f3b0c107ae7e6eda35ca4040bb6651284bf0747ee",
+		ikmS:           "874baa0dcf93595a24a45a7f042e0d22d368747daaa7e19f80a802af19204ba8",
+		skE:            "6b8de0873aed0c1b2d09b8c7ed54cbf24fdf1dfc7a47fa501f918810642d7b91",
+		skR:            "d929ab4be2e59f6954d6bedd93e638f02d4046cef21115b00cdda2acb2a4440e",
+		skS:            "1120ac99fb1fccc1e8230502d245719d1b217fe20505c7648795139d177f0de9",
+		pkE:            "042224f3ea800f7ec55c03f29fc9865f6ee27004f818fcbdc6dc68932c1e52e15b79e264a98f2c535ef06745f3d308624414153b22c7332bc1e691cb4af4d53454",
+		pkR:            "04423e363e1cd54ce7b7573110ac121399acbc9ed815fae03b72ffbd4c18b01836835c5a09513f28fc971b7266cfde2e96afe84bb0f266920e82c4f53b36e1a78d",
+		pkS:            "04a817a0902bf28e036d66add5d544cc3a0457eab150f104285df1e293b5c10eef8651213e43d9cd9086c80b309df22cf37609f58c1127f7607e85f210b2804f73",
+		enc:            "042224f3ea800f7ec55c03f29fc9865f6ee27004f818fcbdc6dc68932c1e52e15b79e264a98f2c535ef06745f3d308624414153b22c7332bc1e691cb4af4d53454",
+		sharedSecret:   "d4aea336439aadf68f9348880aa358086f1480e7c167b6ef15453ba69b94b44f",
+		baseNonce:      "b390052d26b67a5b8a8fcaa4",
+		exporterSecret: "f152759972660eb0e1db880835abd5de1c39c8e9cd269f6f082ed80e28acb164",
+		encryptions: []rfc9180Encryption{
+			{seq: 0, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d30", nonce: "b390052d26b67a5b8a8fcaa4", ct: "82ffc8c44760db691a07c5627e5fc2c08e7a86979ee79b494a17cc3405446ac2bdb8f265db4a099ed3289ffe19"},
+			{seq: 1, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d31", nonce: "b390052d26b67a5b8a8fcaa5", ct: "b0a705a54532c7b4f5907de51c13dffe1e08d55ee9ba59686114b05945494d96725b239468f1229e3966aa1250"},
+			{seq: 2, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d32", nonce: "b390052d26b67a5b8a8fcaa6", ct: "8dc805680e3271a801790833ed74473710157645584f06d1b53ad439078d880b23e25256663178271c80ee8b7c"},
+			{seq: 4, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d34", nonce: "b390052d26b67a5b8a8fcaa0", ct: "04c8f7aae1584b61aa5816382cb0b834a5d744f420e6dffb5ddcec633a21b8b3472820930c1ea9258b035937a2"},
+			{seq: 255, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d323535", nonce: "b390052d26b67a5b8a8fca5b", ct: "4a319462eaedee37248b4d985f64f4f863d31913fe9e30b6e13136053b69fe5d70853c84c60a84bb5495d5a678"},
+			{seq: 256, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d323536", nonce: "b390052d26b67a5b8a8fcba4", ct: "28e874512f8940fafc7d06135e7589f6b4198bc0f3a1c64702e72c9e6abaf9f05cb0d2f11b03a517898815c934"},
+		},
+		exports: []rfc9180Export{
+			{context: "", length: 32, value: "837e49c3ff629250c8d80d3c3fb957725ed481e59e2feb57afd9fe9a8c7c4497"},
+			{context: "00", length: 32, value: "594213f9018d614b82007a7021c3135bda7b380da4acd9ab27165c508640dbda"},
+			{context: "54657374436f6e74657874", length: 32, value: "14fe634f95ca0d86e15247cca7de7ba9b73c9b9deb6437e1c832daf7291b79d5"},
+		},
+	},
+	{ // A.3.4, AuthPSK setup
+		mode:           ModeAuthPSK,
+		info:           "4f6465206f6e2061204772656369616e2055726e",
+		ikmE:           "3c1fceb477ec954c8d58ef3249e4bb4c38241b5925b95f7486e4d9f1d0d35fbb",
+		ikmR:           "abcc2da5b3fa81d8aabd91f7f800a8ccf60ec37b1b585a5d1d1ac77f258b6cca",
+		ikmS:           "6262031f040a9db853edd6f91d2272596eabbc78a2ed2bd643f770ecd0f19b82",
+		skE:            "36f771e411cf9cf72f0701ef2b991ce9743645b472e835fe234fb4d6eb2ff5a0",
+		skR:            "bdf4e2e587afdf0930644a0c45053889ebcadeca662d7c755a353d5b4e2a8394",
+		skS:            "b0ed8721db6185435898650f7a677affce925aba7975a582653c4cb13c72d240",
+		pkE:            "046a1de3fc26a3d43f4e4ba97dbe24f7e99181136129c48fbe872d4743e2b131357ed4f29a7b317dc22509c7b00991ae990bf65f8b236700c82ab7c11a84511401",
+		pkR:            "04d824d7e897897c172ac8a9e862e4bd820133b8d090a9b188b8233a64dfbc5f725aa0aa52c8462ab7c9188f1c4872f0c99087a867e8a773a13df48a627058e1b3",
+		pkS:            "049f158c750e55d8d5ad13ede66cf6e79801634b7acadcad72044eac2ae1d0480069133d6488bf73863fa988c4ba8bde1c2e948b761274802b4d8012af4f13af9e",
+		enc:            "046a1de3fc26a3d43f4e4ba97dbe24f7e99181136129c48fbe872d4743e2b131357ed4f29a7b317dc22509c7b00991ae990bf65f8b236700c82ab7c11a84511401",
+		psk:            "0247fd33b913760fa1fa51e1892d9f307fbe65eb171e8132c2af18555a738b82",
+		pskID:          "456e6e796e20447572696e206172616e204d6f726961",
+		sharedSecret:   "d4c27698391db126f1612d9e91a767f10b9b19aa17e1695549203f0df7d9aebe",
+		baseNonce:      "67c9d05330ca21e5116ecda6",
+		exporterSecret: "3f479020ae186788e4dfd4a42a21d24f3faabb224dd4f91c2b2e5e9524ca27b2",
+		encryptions: []rfc9180Encryption{
+			{seq: 0, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d30", nonce: "67c9d05330ca21e5116ecda6", ct: "b9f36d58d9eb101629a3e5a7b63d2ee4af42b3644209ab37e0a272d44365407db8e655c72e4fa46f4ff81b9246"},
+			{seq: 1, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d31", nonce: "67c9d05330ca21e5116ecda7", ct: "51788c4e5d56276771032749d015d3eea651af0c7bb8e3da669effffed299ea1f641df621af65579c10fc09736"},
+			{seq: 2, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d32", nonce: "67c9d05330ca21e5116ecda4", ct: "3b5a2be002e7b29927f06442947e1cf709b9f8508b03823127387223d712703471c266efc355f1bc2036f3027c"},
+			{seq: 4, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d34", nonce: "67c9d05330ca21e5116ecda2", ct: "8ddbf1242fe5c7d61e1675496f3bfdb4d90205b3dfbc1b12aab41395d71a82118e095c484103107cf4face5123"},
+			{seq: 255, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d323535", nonce: "67c9d05330ca21e5116ecd59", ct: "6de25ceadeaec572fbaa25eda2558b73c383fe55106abaec24d518ef6724a7ce698f83ecdc53e640fe214d2f42"},
+			{seq: 256, pt: "4265617574792069732074727574682c20747275746820626561757479", aad: "436f756e742d323536", nonce: "67c9d05330ca21e5116ecca6", ct: "f380e19d291e12c5e378b51feb5cd50f6d00df6cb2af8393794c4df342126c2e29633fe7e8ce49587531affd4d"},
+		},
+		exports: []rfc9180Export{
+			{context: "", length: 32, value: "595ce0eff405d4b3bb1d08308d70a4e77226ce11766e0a94c4fdb5d90025c978"},
+			{context: "00", length: 32, value: "110472ee0ae328f57ef7332a9886a1992d2c45b9b8d5abc9424ff68630f7d38d"},
+			{context: "54657374436f6e74657874", length: 32, value: "18ee4d001a9d83a4c67e76f88dd747766576cac438723bad0700a910a4d717e6"},
+		},
+	},
+}
+
+func decodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	if s == "" {
+		return nil
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestRFC9180Vectors(t *testing.T) {
+	kem := NewDHKEM(p256{}, HKDFSHA256)
+	suite := NewSuite(kem, HKDFSHA256, AES128GCM)
+
+	for _, v := range rfc9180Vectors {
+		// key derivation
+		skE, pkE, err := kem.DeriveKeyPair(decodeHex(t, v.ikmE))
+		if err != nil || !bytes.Equal(skE, decodeHex(t, v.skE)) || !bytes.Equal(pkE, decodeHex(t, v.pkE)) {
+			t.Fatalf("mode %d: wrong ephemeral key pair", v.mode)
+		}
+		skR, pkR, err := kem.DeriveKeyPair(decodeHex(t, v.ikmR))
+		if err != nil || !bytes.Equal(skR, decodeHex(t, v.skR)) || !bytes.Equal(pkR, decodeHex(t, v.pkR)) {
+			t.Fatalf("mode %d: wrong receiver key pair", v.mode)
+		}
+		var skS, pkS []byte
+		if v.ikmS != "" {
+			skS, pkS, err = kem.DeriveKeyPair(decodeHex(t, v.ikmS))
+			if err != nil || !bytes.Equal(skS, decodeHex(t, v.skS)) || !bytes.Equal(pkS, decodeHex(t, v.pkS)) {
+				t.Fatalf("mode %d: wrong sender key pair", v.mode)
+			}
+		}
+
+		// shared secret, the ephemeral key is derived from ikmE
+		sharedSecret, enc, err := kem.encap(bytes.NewReader(decodeHex(t, v.ikmE)), pkR, skS)
+		if err != nil || !bytes.Equal(enc, decodeHex(t, v.enc)) || !bytes.Equal(sharedSecret, decodeHex(t, v.sharedSecret)) {
+			t.Fatalf("mode %d: wrong encapsulation", v.mode)
+		}
+		if sharedSecret, err = kem.decap(enc, skR, pkS); err != nil || !bytes.Equal(sharedSecret, decodeHex(t, v.sharedSecret)) {
+			t.Fatalf("mode %d: wrong decapsulation", v.mode)
+		}
+
+		// key schedule
+		info, psk, pskID := decodeHex(t, v.info), decodeHex(t, v.psk), decodeHex(t, v.pskID)
+		r := bytes.NewReader(decodeHex(t, v.ikmE))
+		var sender *Sender
+		var receiver *Receiver
+		switch v.mode {
+		case ModeBase:
+			enc, sender, err = suite.SetupBaseS(r, pkR, info)
+			if err == nil {
+				receiver, err = suite.SetupBaseR(enc, skR, info)
+			}
+		case ModePSK:
+			enc, sender, err = suite.SetupPSKS(r, pkR, info, psk, pskID)
+			if err == nil {
+				receiver, err = suite.SetupPSKR(enc, skR, info, psk, pskID)
+			}
+		case ModeAuth:
+			enc, sender, err = suite.SetupAuthS(r, pkR, info, skS)
+			if err == nil {
+				receiver, err = suite.SetupAuthR(enc, skR, info, pkS)
+			}
+		case ModeAuthPSK:
+			enc, sender, err = suite.SetupAuthPSKS(r, pkR, info, psk, pskID, skS)
+			if err == nil {
+				receiver, err = suite.SetupAuthPSKR(enc, skR, info, psk, pskID, pkS)
+			}
+		}
+		if err != nil {
+			t.Fatalf("mode %d: %v", v.mode, err)
+		}
+		if !bytes.Equal(enc, decodeHex(t, v.enc)) {
+			t.Fatalf("mode %d: wrong encapsulated key", v.mode)
+		}
+		for _, c := range []*context{&sender.context, &receiver.context} {
+			if !bytes.Equal(c.baseNonce, decodeHex(t, v.baseNonce)) || !bytes.Equal(c.exporterSecret, decodeHex(t, v.exporterSecret)) {
+				t.Fatalf("mode %d: wrong key schedule", v.mode)
+			}
+		}
+
+		// encryptions, the sequence numbers are set directly to skip the other messages
+		for _, e := range v.encryptions {
+			sender.seq, receiver.seq = e.seq, e.seq
+			if !bytes.Equal(sender.nonce(), decodeHex(t, e.nonce)) {
+				t.Fatalf("mode %d, seq %d: wrong nonce", v.mode, e.seq)
+			}
+			ct, err := sender.Seal(decodeHex(t, e.aad), decodeHex(t, e.pt))
+			if err != nil || !bytes.Equal(ct, decodeHex(t, e.ct)) {
+				t.Fatalf("mode %d, seq %d: wrong ciphertext", v.mode, e.seq)
+			}
+			pt, err := receiver.Open(decodeHex(t, e.aad), ct)
+			if err != nil || !bytes.Equal(pt, decodeHex(t, e.pt)) {
+				t.Fatalf("mode %d, seq %d: wrong plaintext", v.mode, e.seq)
+			}
+		}
+
+		// exports
+		for _, e := range v.exports {
+			for _, c := range []*context{&sender.context, &receiver.context} {
+				value, err := c.Export(decodeHex(t, e.context), e.length)
+				if err != nil || !bytes.Equal(value, decodeHex(t, e.value)) {
+					t.Fatalf("mode %d: wrong exported value for context %q", v.mode, e.context)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hpke
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"hash"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const versionLabel = "HPKE-v1"
+
+// KDF is a key derivation function of HPKE.
+type KDF struct {
+	id   uint16
+	hash func() hash.Hash
+}
+
+var (
+	HKDFSHA256 = KDF{id: 0x0001, hash: sha256.New}
+	HKDFSHA384 = KDF{id: 0x0002, hash: sha512.New384}
+	HKDFSHA512 = KDF{id: 0x0003, hash: sha512.New}
+)
+
+// ID returns the identifier of the KDF in the HPKE registry.
+func (k KDF) ID() uint16 {
+	return k.id
+}
+
+// size returns Nh, the output size of the extraction.
+func (k KDF) size() int {
+	return k.hash().Size()
+}
+
+// labeledExtract is LabeledExtract of RFC 9180, section 4.
+func (k KDF) labeledExtract(suiteID []byte, salt []byte, label string, ikm []byte) []byte {
+	labeledIKM := make([]byte, 0, len(versionLabel)+len(suiteID)+len(label)+len(ikm))
+	labeledIKM = append(labeledIKM, versionLabel...)
+	labeledIKM = append(labeledIKM, suiteID...)
+	labeledIKM = append(labeledIKM, label...)
+	labeledIKM = append(labeledIKM, ikm...)
+	return hkdf.Extract(k.hash, labeledIKM, salt)
+}
+
+// labeledExpand is LabeledExpand of RFC 9180, section 4.
+func (k KDF) labeledExpand(suiteID []byte, prk []byte, label string, info []byte, length int) ([]byte, error) {
+	if length > 0xffff {
+		return nil, ErrInvalidLength
+	}
+	labeledInfo := make([]byte, 0, 2+len(versionLabel)+len(suiteID)+len(label)+len(info))
+	labeledInfo = binary.BigEndian.AppendUint16(labeledInfo, uint16(length))
+	labeledInfo = append(labeledInfo, versionLabel...)
+	labeledInfo = append(labeledInfo, suiteID...)
+	labeledInfo = append(labeledInfo, label...)
+	labeledInfo = append(labeledInfo, info...)
+
+	res := make([]byte, length)
+	if _, err := hkdf.Expand(k.hash, prk, labeledInfo).Read(res); err != nil {
+		return nil, ErrInvalidLength
+	}
+	return res, nil
+}
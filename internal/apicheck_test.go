@@ -37,4 +37,10 @@ func init() {
 	gtbls381 = bls381.FinalExponentiation(&gtbls381)
 	gtbn254 = bn254.FinalExponentiation(&gtbn254)
 	gtbw761 = bw761.FinalExponentiation(&gtbw761)
+
+	// FinalExp split in easy and hard parts
+	gtbls381 = bls381.FinalExponentiationHard(&gtbls381)
+	gtbls381 = bls381.FinalExponentiationEasy(&gtbls381)
+	gtbn254 = bn254.FinalExponentiationHard(&gtbn254)
+	gtbn254 = bn254.FinalExponentiationEasy(&gtbn254)
 }
@@ -15,6 +15,9 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
 		{File: filepath.Join(baseDir, "mimc.go"), Templates: []string{"mimc.go.tmpl"}},
 		{File: filepath.Join(baseDir, "options.go"), Templates: []string{"options.go.tmpl"}},
+		{File: filepath.Join(baseDir, "field_hasher.go"), Templates: []string{"field_hasher.go.tmpl"}},
+		{File: filepath.Join(baseDir, "field_hasher_test.go"), Templates: []string{"field_hasher.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "params_test.go"), Templates: []string{"params.test.go.tmpl"}},
 	}
 	os.Remove(filepath.Join(baseDir, "utils.go"))
 	os.Remove(filepath.Join(baseDir, "utils_test.go"))
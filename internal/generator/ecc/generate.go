@@ -49,6 +49,12 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 	entries = []bavard.Entry{
 		{File: filepath.Join(baseDir, "g1.go"), Templates: []string{"point.go.tmpl"}},
 		{File: filepath.Join(baseDir, "g1_test.go"), Templates: []string{"tests/point.go.tmpl"}},
+		{File: filepath.Join(baseDir, "g1_reduce.go"), Templates: []string{"reduce.go.tmpl"}},
+		{File: filepath.Join(baseDir, "g1_reduce_test.go"), Templates: []string{"tests/reduce.go.tmpl"}},
+		{File: filepath.Join(baseDir, "g1_constant_time.go"), Templates: []string{"constant_time.go.tmpl"}},
+		{File: filepath.Join(baseDir, "g1_constant_time_test.go"), Templates: []string{"tests/constant_time.go.tmpl"}},
+		{File: filepath.Join(baseDir, "g1_scalarmul_table.go"), Templates: []string{"scalarmul_table.go.tmpl"}},
+		{File: filepath.Join(baseDir, "g1_scalarmul_table_test.go"), Templates: []string{"tests/scalarmul_table.go.tmpl"}},
 	}
 	// if not secp256k1, generate the lagrange transform
 	if conf.Name != config.SECP256K1.Name {
@@ -58,6 +64,22 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		// entries = append(entries, bavard.Entry{File: filepath.Join(baseDir, "g1_lagrange_test.go"), Templates: []string{"tests/lagrange.go.tmpl"}})
 	}
 
+	// precomputed generator tables, embedded with the generatortables build tag
+	generatorTables := conf.Name == config.BN254.Name || conf.Name == config.BLS12_381.Name
+	if generatorTables {
+		entries = append(entries,
+			bavard.Entry{File: filepath.Join(baseDir, "g1_generator_table.go"), Templates: []string{"generator_table.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "g1_generator_table_test.go"), Templates: []string{"tests/generator_table.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "generator_tables_embed.go"), Templates: []string{"generator_tables_embed.go.tmpl"}, BuildTag: "generatortables"},
+		)
+	} else {
+		os.Remove(filepath.Join(baseDir, "g1_generator_table.go"))
+		os.Remove(filepath.Join(baseDir, "g1_generator_table_test.go"))
+		os.Remove(filepath.Join(baseDir, "g2_generator_table.go"))
+		os.Remove(filepath.Join(baseDir, "g2_generator_table_test.go"))
+		os.Remove(filepath.Join(baseDir, "generator_tables_embed.go"))
+	}
+
 	g1 := pconf{conf, conf.G1}
 	if err := bgen.Generate(g1, packageName, "./ecc/template", entries...); err != nil {
 		return err
@@ -182,6 +204,12 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 	entries = []bavard.Entry{
 		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
 		{File: filepath.Join(baseDir, "marshal_test.go"), Templates: []string{"tests/marshal.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal_batch.go"), Templates: []string{"marshal_batch.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal_batch_test.go"), Templates: []string{"tests/marshal_batch.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal_format.go"), Templates: []string{"marshal_format.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal_format_test.go"), Templates: []string{"tests/marshal_format.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal_constant_time.go"), Templates: []string{"marshal_constant_time.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal_constant_time_test.go"), Templates: []string{"tests/marshal_constant_time.go.tmpl"}},
 	}
 
 	marshal := []func(*bavard.Bavard) error{bavard.Funcs(funcs)}
@@ -193,6 +221,14 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 	entries = []bavard.Entry{
 		{File: filepath.Join(baseDir, "g2.go"), Templates: []string{"point.go.tmpl"}},
 		{File: filepath.Join(baseDir, "g2_test.go"), Templates: []string{"tests/point.go.tmpl"}},
+		{File: filepath.Join(baseDir, "g2_reduce.go"), Templates: []string{"reduce.go.tmpl"}},
+		{File: filepath.Join(baseDir, "g2_reduce_test.go"), Templates: []string{"tests/reduce.go.tmpl"}},
+	}
+	if generatorTables {
+		entries = append(entries,
+			bavard.Entry{File: filepath.Join(baseDir, "g2_generator_table.go"), Templates: []string{"generator_table.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "g2_generator_table_test.go"), Templates: []string{"tests/generator_table.go.tmpl"}},
+		)
 	}
 	g2 := pconf{conf, conf.G2}
 	return bgen.Generate(g2, packageName, "./ecc/template", entries...)
@@ -0,0 +1,26 @@
+package elgamal
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+	// elgamal
+	conf.Package = "elgamal"
+	baseDir = filepath.Join(baseDir, conf.Package)
+
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "elgamal.go"), Templates: []string{"elgamal.go.tmpl"}},
+		{File: filepath.Join(baseDir, "elgamal_test.go"), Templates: []string{"elgamal.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "dlog.go"), Templates: []string{"dlog.go.tmpl"}},
+		{File: filepath.Join(baseDir, "verifiable.go"), Templates: []string{"verifiable.go.tmpl"}},
+		{File: filepath.Join(baseDir, "verifiable_test.go"), Templates: []string{"verifiable.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./elgamal/template", entries...)
+
+}
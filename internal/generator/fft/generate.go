@@ -21,6 +21,13 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		{File: filepath.Join(baseDir, "fft.go"), Templates: []string{"fft.go.tmpl", "imports.go.tmpl"}},
 		{File: filepath.Join(baseDir, "bitreverse.go"), Templates: []string{"bitreverse.go.tmpl", "imports.go.tmpl"}},
 		{File: filepath.Join(baseDir, "options.go"), Templates: []string{"options.go.tmpl", "imports.go.tmpl"}},
+		{File: filepath.Join(baseDir, "negacyclic.go"), Templates: []string{"negacyclic.go.tmpl", "imports.go.tmpl"}},
+		{File: filepath.Join(baseDir, "negacyclic_test.go"), Templates: []string{"tests/negacyclic.go.tmpl", "imports.go.tmpl"}},
+		{File: filepath.Join(baseDir, "sixstep.go"), Templates: []string{"sixstep.go.tmpl", "imports.go.tmpl"}},
+		{File: filepath.Join(baseDir, "sixstep_test.go"), Templates: []string{"tests/sixstep.go.tmpl", "imports.go.tmpl"}},
+		{File: filepath.Join(baseDir, "arena.go"), Templates: []string{"arena.go.tmpl", "imports.go.tmpl"}},
+		{File: filepath.Join(baseDir, "backend.go"), Templates: []string{"backend.go.tmpl", "imports.go.tmpl"}},
+		{File: filepath.Join(baseDir, "backend_test.go"), Templates: []string{"tests/backend.go.tmpl", "imports.go.tmpl"}},
 	}
 
 	funcs := make(map[string]interface{})
@@ -54,6 +61,25 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		return err
 	}
 
+	// the goldilocks field is not the scalar field of a curve: its generator lives in the
+	// field package itself, and it has no Reed-Solomon codes
+	if conf.Name == "goldilocks" {
+		entries = []bavard.Entry{
+			{File: filepath.Join(filepath.Dir(baseDir), "generator.go"), Templates: []string{"fr.generator.go.tmpl"}},
+		}
+		return bgen.GenerateWithOptions(conf, "goldilocks", "./fft/template/", bavardOpts, entries...)
+	}
+
+	// Reed-Solomon codes on the fft domains
+	rsEntries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "rs", "doc.go"), Templates: []string{"rs/doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "rs", "rs.go"), Templates: []string{"rs/rs.go.tmpl", "imports.go.tmpl"}},
+		{File: filepath.Join(baseDir, "rs", "rs_test.go"), Templates: []string{"rs/rs.test.go.tmpl", "imports.go.tmpl"}},
+	}
+	if err := bgen.GenerateWithOptions(conf, "rs", "./fft/template/", bavardOpts, rsEntries...); err != nil {
+		return err
+	}
+
 	// put the generator in the parent dir (fr)
 	frDir := filepath.Dir(baseDir)
 	entries = []bavard.Entry{
@@ -14,6 +14,13 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 	entries := []bavard.Entry{
 		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
 		{File: filepath.Join(baseDir, "fri.go"), Templates: []string{"fri.go.tmpl"}},
+		{File: filepath.Join(baseDir, "options.go"), Templates: []string{"options.go.tmpl"}},
+		{File: filepath.Join(baseDir, "params.go"), Templates: []string{"params.go.tmpl"}},
+		{File: filepath.Join(baseDir, "errors.go"), Templates: []string{"errors.go.tmpl"}},
+		{File: filepath.Join(baseDir, "memory.go"), Templates: []string{"memory.go.tmpl"}},
+		{File: filepath.Join(baseDir, "committer.go"), Templates: []string{"committer.go.tmpl"}},
+		{File: filepath.Join(baseDir, "deep.go"), Templates: []string{"deep.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
 		{File: filepath.Join(baseDir, "fri_test.go"), Templates: []string{"fri.test.go.tmpl"}},
 	}
 	return bgen.Generate(conf, conf.Package, "./fri/template/", entries...)
@@ -0,0 +1,21 @@
+package grandproduct
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	// grand product accumulator
+	conf.Package = "grandproduct"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "grandproduct.go"), Templates: []string{"grandproduct.go.tmpl"}},
+		{File: filepath.Join(baseDir, "grandproduct_test.go"), Templates: []string{"grandproduct.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./grandproduct/template/", entries...)
+
+}
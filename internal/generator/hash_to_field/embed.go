@@ -0,0 +1,9 @@
+package hash_to_field
+
+import "embed"
+
+// Templates holds the templates of the package, for generators that don't run from
+// internal/generator (see cmd/gnark-crypto-gen).
+//
+//go:embed template
+var Templates embed.FS
@@ -0,0 +1,21 @@
+package hpke
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+	// hpke
+	conf.Package = "hpke"
+	baseDir = filepath.Join(baseDir, conf.Package)
+
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "dhkem.go"), Templates: []string{"dhkem.go.tmpl"}},
+		{File: filepath.Join(baseDir, "dhkem_test.go"), Templates: []string{"dhkem.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./hpke/template", entries...)
+
+}
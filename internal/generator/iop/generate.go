@@ -25,6 +25,9 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		{File: filepath.Join(baseDir, "expressions.go"), Templates: []string{"expressions.go.tmpl"}},
 		{File: filepath.Join(baseDir, "expressions_test.go"), Templates: []string{"expressions.test.go.tmpl"}},
 
+		{File: filepath.Join(baseDir, "typed.go"), Templates: []string{"typed.go.tmpl"}},
+		{File: filepath.Join(baseDir, "typed_test.go"), Templates: []string{"typed.test.go.tmpl"}},
+
 		{File: filepath.Join(baseDir, "utils.go"), Templates: []string{"utils.go.tmpl"}},
 	}
 
@@ -15,6 +15,11 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
 		{File: filepath.Join(baseDir, "kzg.go"), Templates: []string{"kzg.go.tmpl"}},
 		{File: filepath.Join(baseDir, "kzg_test.go"), Templates: []string{"kzg.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "kzg_g2.go"), Templates: []string{"kzg_g2.go.tmpl"}},
+		{File: filepath.Join(baseDir, "kzg_g2_test.go"), Templates: []string{"kzg_g2.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "kzg_mmap.go"), Templates: []string{"kzg_mmap.go.tmpl"}},
+		{File: filepath.Join(baseDir, "kzg_mmap_test.go"), Templates: []string{"kzg_mmap.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "options.go"), Templates: []string{"options.go.tmpl"}},
 		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
 		{File: filepath.Join(baseDir, "utils.go"), Templates: []string{"utils.go.tmpl"}},
 	}
@@ -16,17 +16,26 @@ import (
 	"github.com/consensys/gnark-crypto/internal/generator/ecdsa"
 	"github.com/consensys/gnark-crypto/internal/generator/edwards"
 	"github.com/consensys/gnark-crypto/internal/generator/edwards/eddsa"
+	"github.com/consensys/gnark-crypto/internal/generator/elgamal"
 	"github.com/consensys/gnark-crypto/internal/generator/fft"
 	fri "github.com/consensys/gnark-crypto/internal/generator/fri/template"
 	"github.com/consensys/gnark-crypto/internal/generator/gkr"
+	"github.com/consensys/gnark-crypto/internal/generator/grandproduct"
 	"github.com/consensys/gnark-crypto/internal/generator/hash_to_field"
+	"github.com/consensys/gnark-crypto/internal/generator/hpke"
 	"github.com/consensys/gnark-crypto/internal/generator/iop"
 	"github.com/consensys/gnark-crypto/internal/generator/kzg"
+	"github.com/consensys/gnark-crypto/internal/generator/logup"
+	"github.com/consensys/gnark-crypto/internal/generator/mle"
+	"github.com/consensys/gnark-crypto/internal/generator/mpcsetup"
+	"github.com/consensys/gnark-crypto/internal/generator/ot"
 	"github.com/consensys/gnark-crypto/internal/generator/pairing"
+	"github.com/consensys/gnark-crypto/internal/generator/pcs"
 	"github.com/consensys/gnark-crypto/internal/generator/pedersen"
 	"github.com/consensys/gnark-crypto/internal/generator/permutation"
 	"github.com/consensys/gnark-crypto/internal/generator/plookup"
 	"github.com/consensys/gnark-crypto/internal/generator/polynomial"
+	"github.com/consensys/gnark-crypto/internal/generator/secretsharing"
 	"github.com/consensys/gnark-crypto/internal/generator/sis"
 	"github.com/consensys/gnark-crypto/internal/generator/sumcheck"
 	"github.com/consensys/gnark-crypto/internal/generator/test_vector_utils"
@@ -63,12 +72,21 @@ func main() {
 
 			conf.FpUnusedBits = 64 - (conf.Fp.NbBits % 64)
 
-			assertNoError(generator.GenerateFF(conf.Fr, filepath.Join(curveDir, "fr")))
+			assertNoError(generator.GenerateFF(conf.Fr, filepath.Join(curveDir, "fr"), generator.WithCanonical()))
 			assertNoError(generator.GenerateFF(conf.Fp, filepath.Join(curveDir, "fp")))
 
 			// generate ecdsa
 			assertNoError(ecdsa.Generate(conf, curveDir, bgen))
 
+			if conf.Equal(config.STARK_CURVE) || conf.Equal(config.SECP256K1) {
+				// fr has no large 2-adic subgroup: polynomial arithmetic without FFT
+				assertNoError(polynomial.Generate(config.FieldDependency{
+					FieldPackagePath: "github.com/consensys/gnark-crypto/ecc/" + conf.Name + "/fr",
+					FieldPackageName: "fr",
+					ElementType:      "fr.Element",
+				}, filepath.Join(curveDir, "fr", "polynomial"), true, false, bgen))
+			}
+
 			if conf.Equal(config.STARK_CURVE) {
 				return // TODO @yelhousni
 			}
@@ -76,6 +94,17 @@ func main() {
 			// generate G1, G2, multiExp, ...
 			assertNoError(ecc.Generate(conf, curveDir, bgen))
 
+			// generate elgamal
+			assertNoError(elgamal.Generate(conf, curveDir, bgen))
+
+			// generate base oblivious transfers
+			assertNoError(ot.Generate(conf, curveDir, bgen))
+
+			if conf.Equal(config.BN254) || conf.Equal(config.SECP256K1) {
+				// generate DHKEM for hpke
+				assertNoError(hpke.Generate(conf, curveDir, bgen))
+			}
+
 			if conf.Equal(config.SECP256K1) {
 				return
 			}
@@ -99,12 +128,30 @@ func main() {
 			// generate kzg on fr
 			assertNoError(kzg.Generate(conf, filepath.Join(curveDir, "kzg"), bgen))
 
+			// generate the powers of tau ceremony
+			assertNoError(mpcsetup.Generate(conf, filepath.Join(curveDir, "mpcsetup"), bgen))
+
+			// generate the polynomial commitment interface, on kzg and fri
+			assertNoError(pcs.Generate(conf, filepath.Join(curveDir, "pcs"), bgen))
+
 			// generate pedersen on fr
 			assertNoError(pedersen.Generate(conf, filepath.Join(curveDir, "fr", "pedersen"), bgen))
 
+			// generate secret sharing on fr
+			assertNoError(secretsharing.Generate(conf, filepath.Join(curveDir, "fr", "secretsharing"), bgen))
+
+			// generate multilinear extension utilities on fr
+			assertNoError(mle.Generate(conf, filepath.Join(curveDir, "fr", "mle"), bgen))
+
+			// generate grand product accumulator on fr
+			assertNoError(grandproduct.Generate(conf, filepath.Join(curveDir, "fr", "grandproduct"), bgen))
+
 			// generate plookup on fr
 			assertNoError(plookup.Generate(conf, filepath.Join(curveDir, "fr", "plookup"), bgen))
 
+			// generate logUp lookup argument on fr
+			assertNoError(logup.Generate(conf, filepath.Join(curveDir, "fr", "logup"), bgen))
+
 			// generate permutation on fr
 			assertNoError(permutation.Generate(conf, filepath.Join(curveDir, "fr", "permutation"), bgen))
 
@@ -118,7 +165,7 @@ func main() {
 			}
 
 			// generate polynomial on fr
-			assertNoError(polynomial.Generate(frInfo, filepath.Join(curveDir, "fr", "polynomial"), true, bgen))
+			assertNoError(polynomial.Generate(frInfo, filepath.Join(curveDir, "fr", "polynomial"), true, true, bgen))
 
 			// generate eddsa on companion curves
 			assertNoError(fri.Generate(conf, filepath.Join(curveDir, "fr", "fri"), bgen))
@@ -177,6 +224,13 @@ func main() {
 		defer wg.Done()
 		assertNoError(test_vector_utils.GenerateRationals(bgen))
 	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// generate fft on the goldilocks field
+		assertNoError(fft.Generate(config.Curve{Name: "goldilocks"}, filepath.Join(baseDir, "field", "goldilocks", "fft"), bgen))
+	}()
 	wg.Wait()
 
 	// format the whole directory
@@ -0,0 +1,21 @@
+package mle
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	// multilinear extension utilities
+	conf.Package = "mle"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "mle.go"), Templates: []string{"mle.go.tmpl"}},
+		{File: filepath.Join(baseDir, "mle_test.go"), Templates: []string{"mle.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./mle/template/", entries...)
+
+}
@@ -0,0 +1,22 @@
+package mpcsetup
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+	// powers of tau ceremony
+	conf.Package = "mpcsetup"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "phase1.go"), Templates: []string{"phase1.go.tmpl"}},
+		{File: filepath.Join(baseDir, "phase1_test.go"), Templates: []string{"phase1.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
+		{File: filepath.Join(baseDir, "utils.go"), Templates: []string{"utils.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./mpcsetup/template/", entries...)
+
+}
@@ -0,0 +1,22 @@
+package ot
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+	// ot
+	conf.Package = "ot"
+	baseDir = filepath.Join(baseDir, conf.Package)
+
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "simplest.go"), Templates: []string{"simplest.go.tmpl"}},
+		{File: filepath.Join(baseDir, "simplest_test.go"), Templates: []string{"simplest.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./ot/template", entries...)
+
+}
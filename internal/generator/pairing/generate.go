@@ -11,8 +11,17 @@ import (
 func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
 
 	packageName := strings.ReplaceAll(conf.Name, "-", "")
-	return bgen.Generate(conf, packageName, "./pairing/template", bavard.Entry{
-		File: filepath.Join(baseDir, "pairing_test.go"), Templates: []string{"tests/pairing.go.tmpl"},
-	})
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "pairing_test.go"), Templates: []string{"tests/pairing.go.tmpl"}},
+		{File: filepath.Join(baseDir, "pairing_msm.go"), Templates: []string{"pairing_msm.go.tmpl"}},
+	}
+	// GT helpers for the 2-3-2 towers (E12 over E6 over E2)
+	if conf.Equal(config.BN254) || conf.Equal(config.BLS12_381) || conf.Equal(config.BLS12_377) {
+		entries = append(entries,
+			bavard.Entry{File: filepath.Join(baseDir, "gt.go"), Templates: []string{"gt.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "gt_test.go"), Templates: []string{"tests/gt.go.tmpl"}},
+		)
+	}
+	return bgen.Generate(conf, packageName, "./pairing/template", entries...)
 
 }
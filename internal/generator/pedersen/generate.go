@@ -15,6 +15,8 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
 		{File: filepath.Join(baseDir, "pedersen.go"), Templates: []string{"pedersen.go.tmpl"}},
 		{File: filepath.Join(baseDir, "pedersen_test.go"), Templates: []string{"pedersen.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "opening.go"), Templates: []string{"opening.go.tmpl"}},
+		{File: filepath.Join(baseDir, "opening_test.go"), Templates: []string{"opening.test.go.tmpl"}},
 		{File: filepath.Join(baseDir, "example_test.go"), Templates: []string{"example_test.go.tmpl"}},
 	}
 	return bgen.Generate(conf, conf.Package, "./pedersen/template/", entries...)
@@ -15,6 +15,8 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
 		{File: filepath.Join(baseDir, "permutation.go"), Templates: []string{"permutation.go.tmpl"}},
 		{File: filepath.Join(baseDir, "permutation_test.go"), Templates: []string{"permutation.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "copy.go"), Templates: []string{"copy.go.tmpl"}},
+		{File: filepath.Join(baseDir, "copy_test.go"), Templates: []string{"copy.test.go.tmpl"}},
 	}
 	return bgen.Generate(conf, conf.Package, "./permutation/template/", entries...)
 
@@ -7,7 +7,7 @@ import (
 	"github.com/consensys/gnark-crypto/internal/generator/config"
 )
 
-func Generate(conf config.FieldDependency, baseDir string, generateTests bool, bgen *bavard.BatchGenerator) error {
+func Generate(conf config.FieldDependency, baseDir string, generateTests, withFFT bool, bgen *bavard.BatchGenerator) error {
 
 	entries := []bavard.Entry{
 		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
@@ -16,6 +16,23 @@ func Generate(conf config.FieldDependency, baseDir string, generateTests bool, b
 		{File: filepath.Join(baseDir, "pool.go"), Templates: []string{"pool.go.tmpl"}},
 	}
 
+	// univariate arithmetic is only generated for prime fields; it is FFT-backed when
+	// the field has an fft package, and relies on Karatsuba and subproduct trees otherwise
+	if conf.FieldPackageName == "fr" {
+		entries = append(entries, bavard.Entry{File: filepath.Join(baseDir, "arithmetic.go"), Templates: []string{"arithmetic.go.tmpl"}})
+		if withFFT {
+			entries = append(entries, bavard.Entry{File: filepath.Join(baseDir, "arithmetic_fft.go"), Templates: []string{"arithmetic_fft.go.tmpl"}})
+		} else {
+			entries = append(entries, bavard.Entry{File: filepath.Join(baseDir, "arithmetic_nofft.go"), Templates: []string{"arithmetic_nofft.go.tmpl"}})
+		}
+		if generateTests {
+			entries = append(entries, bavard.Entry{File: filepath.Join(baseDir, "arithmetic_test.go"), Templates: []string{"arithmetic.test.go.tmpl"}})
+			if withFFT {
+				entries = append(entries, bavard.Entry{File: filepath.Join(baseDir, "arithmetic_fft_test.go"), Templates: []string{"arithmetic_fft.test.go.tmpl"}})
+			}
+		}
+	}
+
 	if generateTests {
 		entries = append(entries,
 			bavard.Entry{File: filepath.Join(baseDir, "polynomial_test.go"), Templates: []string{"polynomial.test.go.tmpl"}},
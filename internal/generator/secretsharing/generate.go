@@ -0,0 +1,22 @@
+package secretsharing
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	// shamir secret sharing and pedersen verifiable secret sharing
+	conf.Package = "secretsharing"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "secretsharing.go"), Templates: []string{"secretsharing.go.tmpl"}},
+		{File: filepath.Join(baseDir, "verifiable.go"), Templates: []string{"verifiable.go.tmpl"}},
+		{File: filepath.Join(baseDir, "secretsharing_test.go"), Templates: []string{"secretsharing.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./secretsharing/template/", entries...)
+
+}
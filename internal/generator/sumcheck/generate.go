@@ -10,6 +10,11 @@ func Generate(conf config.FieldDependency, baseDir string, bgen *bavard.BatchGen
 	entries := []bavard.Entry{
 		{File: filepath.Join(baseDir, "sumcheck.go"), Templates: []string{"sumcheck.go.tmpl"}},
 		{File: filepath.Join(baseDir, "sumcheck_test.go"), Templates: []string{"sumcheck.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "gate.go"), Templates: []string{"gate.go.tmpl"}},
+	}
+	// small rationals overflow with random challenges
+	if conf.FieldPackageName != "small_rational" {
+		entries = append(entries, bavard.Entry{File: filepath.Join(baseDir, "gate_test.go"), Templates: []string{"gate.test.go.tmpl"}})
 	}
 	return bgen.Generate(conf, "sumcheck", "./sumcheck/template/", entries...)
 }
@@ -27,7 +27,7 @@ func GenerateRationals(bgen *bavard.BatchGenerator) error {
 	}
 
 	baseDir := "./test_vector_utils/small_rational/"
-	if err := polynomial.Generate(gkrConf.FieldDependency, baseDir+"polynomial", false, bgen); err != nil {
+	if err := polynomial.Generate(gkrConf.FieldDependency, baseDir+"polynomial", false, false, bgen); err != nil {
 		return err
 	}
 	if err := sumcheck.Generate(gkrConf.FieldDependency, baseDir+"sumcheck", bgen); err != nil {
@@ -1,14 +1,16 @@
 package parallel
 
 import (
-	"runtime"
 	"sync"
+
+	"github.com/consensys/gnark-crypto/utils/concurrency"
 )
 
-// Execute process in parallel the work function
+// Execute process in parallel the work function. The number of tasks is maxCpus if
+// set, concurrency.MaxTasks() otherwise.
 func Execute(nbIterations int, work func(int, int), maxCpus ...int) {
 
-	nbTasks := runtime.NumCPU()
+	nbTasks := concurrency.MaxTasks()
 	if len(maxCpus) == 1 {
 		nbTasks = maxCpus[0]
 		if nbTasks < 1 {
@@ -18,7 +20,7 @@ func Execute(nbIterations int, work func(int, int), maxCpus ...int) {
 		}
 	}
 
-	if nbTasks == 1 {
+	if nbTasks == 1 || singleThreaded {
 		// no go routines
 		work(0, nbIterations)
 		return
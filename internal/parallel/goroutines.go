@@ -0,0 +1,6 @@
+//go:build !tinygo
+
+package parallel
+
+// singleThreaded is set when go routines can't run concurrently; Execute then runs the work inline.
+const singleThreaded = false
@@ -0,0 +1,8 @@
+//go:build tinygo
+
+package parallel
+
+// singleThreaded is set when go routines can't run concurrently; Execute then runs the work inline.
+// TinyGo schedules go routines cooperatively on a single thread (and wasm has no threads), so
+// splitting the work would only add scheduling overhead.
+const singleThreaded = true
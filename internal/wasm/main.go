@@ -0,0 +1,116 @@
+// Command wasm exercises the verification-only wasm profile: KZG opening, FRI proximity
+// and Merkle proof verification.
+//
+// It is built and run by the CI for GOOS=js GOARCH=wasm, and built with TinyGo, to make sure
+// these verifiers can be embedded in a browser (e.g. by light clients):
+//
+//	GOOS=js GOARCH=wasm go run -exec="$(go env GOROOT)/lib/wasm/go_js_wasm_exec" ./internal/wasm
+//	tinygo build -target=wasm -o verifier.wasm ./internal/wasm
+//
+// Proofs are produced in the same binary since the provers are available in the same packages;
+// only the verification code paths are what this command is about.
+package main
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/consensys/gnark-crypto/accumulator/merkletree"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fri"
+	"github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+)
+
+const size = 64
+
+func main() {
+	for _, c := range []struct {
+		name  string
+		check func() error
+	}{
+		{"kzg", checkKZG},
+		{"fri", checkFRI},
+		{"merkletree", checkMerkleTree},
+	} {
+		if err := c.check(); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", c.name, err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s: ok\n", c.name)
+	}
+}
+
+func polynomial() []fr.Element {
+	p := make([]fr.Element, size)
+	for i := range p {
+		p[i].SetUint64(uint64(i*i + 1))
+	}
+	return p
+}
+
+func checkKZG() error {
+	srs, err := kzg.NewSRS(size, big.NewInt(42))
+	if err != nil {
+		return err
+	}
+	p := polynomial()
+	digest, err := kzg.Commit(p, srs.Pk)
+	if err != nil {
+		return err
+	}
+	var point fr.Element
+	point.SetUint64(7)
+	proof, err := kzg.Open(p, point, srs.Pk)
+	if err != nil {
+		return err
+	}
+	if err := kzg.Verify(&digest, &proof, point, srs.Vk); err != nil {
+		return err
+	}
+	proof.ClaimedValue.Double(&proof.ClaimedValue)
+	if kzg.Verify(&digest, &proof, point, srs.Vk) == nil {
+		return errors.New("wrong opening accepted")
+	}
+	return nil
+}
+
+func checkFRI() error {
+	iopp := fri.RADIX_2_FRI.New(size, sha256.New())
+	p := polynomial()
+	pp, err := iopp.BuildProofOfProximity(p)
+	if err != nil {
+		return err
+	}
+	if err := iopp.VerifyProofOfProximity(pp); err != nil {
+		return err
+	}
+	const position = 5
+	proof, err := iopp.Open(p, position)
+	if err != nil {
+		return err
+	}
+	return iopp.VerifyOpening(position, proof, pp)
+}
+
+func checkMerkleTree() error {
+	h := sha256.New()
+	tree := merkletree.New(h)
+	const index = 3
+	if err := tree.SetIndex(index); err != nil {
+		return err
+	}
+	for i := 0; i < 10; i++ {
+		tree.Push([]byte(fmt.Sprintf("leaf %d", i)))
+	}
+	root, proofSet, proofIndex, numLeaves := tree.Prove()
+	if !merkletree.VerifyProof(h, root, proofSet, proofIndex, numLeaves) {
+		return errors.New("valid proof rejected")
+	}
+	if merkletree.VerifyProof(h, root, proofSet, proofIndex+1, numLeaves) {
+		return errors.New("proof accepted at the wrong index")
+	}
+	return nil
+}
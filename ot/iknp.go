@@ -0,0 +1,175 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ot
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// domainIKNP separates the hashes of the OT extension.
+const domainIKNP = "ot/iknp"
+
+// ExtensionSender is the sender of the IKNP OT extension. It is the receiver of the
+// Kappa base OTs, with random choice bits Δ.
+type ExtensionSender struct {
+	delta [KeySize]byte // Δ, the choice bits of the base OTs
+	prgs  [Kappa]cipher.Stream
+	count uint64 // number of extended OTs
+}
+
+// ExtensionReceiver is the receiver of the IKNP OT extension. It is the sender of the
+// Kappa base OTs.
+type ExtensionReceiver struct {
+	prgs  [Kappa][2]cipher.Stream
+	count uint64
+}
+
+// NewExtensionSender returns the sender of the OT extension, from the choice bits delta
+// of the Kappa base OTs and the keys it received. delta must be random.
+func NewExtensionSender(delta []bool, baseKeys []Key) (*ExtensionSender, error) {
+	if len(delta) != Kappa || len(baseKeys) != Kappa {
+		return nil, ErrInvalidLength
+	}
+	s := new(ExtensionSender)
+	for j := 0; j < Kappa; j++ {
+		if delta[j] {
+			s.delta[j/8] |= 1 << (j % 8)
+		}
+		s.prgs[j] = newPRG(baseKeys[j])
+	}
+	return s, nil
+}
+
+// NewExtensionReceiver returns the receiver of the OT extension, from the keys it sent
+// in the Kappa base OTs.
+func NewExtensionReceiver(baseKeys [][2]Key) (*ExtensionReceiver, error) {
+	if len(baseKeys) != Kappa {
+		return nil, ErrInvalidLength
+	}
+	r := new(ExtensionReceiver)
+	for j := 0; j < Kappa; j++ {
+		r.prgs[j][0] = newPRG(baseKeys[j][0])
+		r.prgs[j][1] = newPRG(baseKeys[j][1])
+	}
+	return r, nil
+}
+
+// Extend returns the keys of len(choices) new random OTs with the given choice bits,
+// and the message u to send to the sender, who derives its keys with
+// ExtensionSender.Extend. Successive calls must be matched by the sender in the same
+// order.
+func (r *ExtensionReceiver) Extend(choices []bool) (u []byte, keys []Key) {
+	m := len(choices)
+	rowSize := (m + 7) / 8
+	packed := make([]byte, rowSize)
+	for i, c := range choices {
+		if c {
+			packed[i/8] |= 1 << (i % 8)
+		}
+	}
+
+	// tʲ = G(kʲ₀), uʲ = tʲ ⊕ G(kʲ₁) ⊕ r
+	t := make([]byte, Kappa*rowSize)
+	u = make([]byte, Kappa*rowSize)
+	for j := 0; j < Kappa; j++ {
+		tj, uj := t[j*rowSize:(j+1)*rowSize], u[j*rowSize:(j+1)*rowSize]
+		r.prgs[j][0].XORKeyStream(tj, tj)
+		r.prgs[j][1].XORKeyStream(uj, uj)
+		for i := range uj {
+			uj[i] ^= tj[i] ^ packed[i]
+		}
+	}
+
+	// the key of the i-th OT is H(i, tᵢ), tᵢ being the i-th row of the transposed matrix
+	rows := transpose(t, m)
+	keys = make([]Key, m)
+	for i := range keys {
+		keys[i] = hashRow(r.count+uint64(i), &rows[i])
+	}
+	r.count += uint64(m)
+	return u, keys
+}
+
+// Extend returns the pairs of keys of m new random OTs, from the message u of the
+// receiver.
+func (s *ExtensionSender) Extend(m int, u []byte) ([][2]Key, error) {
+	rowSize := (m + 7) / 8
+	if len(u) != Kappa*rowSize {
+		return nil, ErrInvalidLength
+	}
+
+	// qʲ = G(kʲ_Δⱼ) ⊕ Δⱼ⋅uʲ = tʲ ⊕ Δⱼ⋅r
+	q := make([]byte, Kappa*rowSize)
+	for j := 0; j < Kappa; j++ {
+		qj := q[j*rowSize : (j+1)*rowSize]
+		s.prgs[j].XORKeyStream(qj, qj)
+		if s.delta[j/8]>>(j%8)&1 == 1 {
+			uj := u[j*rowSize : (j+1)*rowSize]
+			for i := range qj {
+				qj[i] ^= uj[i]
+			}
+		}
+	}
+
+	// qᵢ = tᵢ ⊕ rᵢ⋅Δ: the keys are H(i, qᵢ) and H(i, qᵢ ⊕ Δ)
+	rows := transpose(q, m)
+	keys := make([][2]Key, m)
+	for i := range keys {
+		keys[i][0] = hashRow(s.count+uint64(i), &rows[i])
+		for b := range rows[i] {
+			rows[i][b] ^= s.delta[b]
+		}
+		keys[i][1] = hashRow(s.count+uint64(i), &rows[i])
+	}
+	s.count += uint64(m)
+	return keys, nil
+}
+
+// transpose returns the m rows of Kappa bits of the matrix given by its Kappa columns
+// of m bits.
+func transpose(columns []byte, m int) [][KeySize]byte {
+	rowSize := (m + 7) / 8
+	rows := make([][KeySize]byte, m)
+	for j := 0; j < Kappa; j++ {
+		column := columns[j*rowSize : (j+1)*rowSize]
+		for i := 0; i < m; i++ {
+			rows[i][j/8] |= (column[i/8] >> (i % 8) & 1) << (j % 8)
+		}
+	}
+	return rows
+}
+
+// hashRow is the correlation robust hash of the OT extension.
+func hashRow(index uint64, row *[KeySize]byte) Key {
+	h := sha256.New()
+	h.Write([]byte(domainIKNP))
+	h.Write(binary.BigEndian.AppendUint64(nil, index))
+	h.Write(row[:])
+	var res Key
+	copy(res[:], h.Sum(nil))
+	return res
+}
+
+// newPRG returns AES-CTR keyed with seed, as a pseudo-random generator.
+func newPRG(seed Key) cipher.Stream {
+	block, err := aes.NewCipher(seed[:])
+	if err != nil {
+		panic(err) // the key size is valid
+	}
+	return cipher.NewCTR(block, make([]byte, aes.BlockSize))
+}
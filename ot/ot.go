@@ -0,0 +1,103 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ot implements oblivious transfer primitives for secure multi-party
+// computation.
+//
+// In a 1-out-of-2 oblivious transfer, a sender holds two messages (x₀, x₁) and a receiver
+// a choice bit c: the receiver learns x_c and nothing about x₁₋c, the sender learns
+// nothing about c. This package implements random OTs, in which the messages are random
+// keys, and their use to transfer chosen messages (Encrypt, Decrypt).
+//
+// Base OTs, based on public key cryptography, are provided by the ot packages of the
+// curves (the "simplest OT" of Chou and Orlandi). They are extended to any number of OTs
+// with symmetric cryptography only, with the OT extension of Ishai, Kilian, Nissim and
+// Petrank (IKNP), secure against semi-honest adversaries.
+package ot
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+)
+
+// Kappa is the computational security parameter, and the number of base OTs needed by
+// the OT extension.
+const Kappa = 128
+
+// KeySize is the size of the keys output by random OTs.
+const KeySize = Kappa / 8
+
+// Key is the output of a random OT.
+type Key [KeySize]byte
+
+var (
+	ErrInvalidLength = errors.New("ot: invalid length")
+)
+
+// Encrypt encrypts the pairs of messages with the keys of as many random OTs, on the
+// sender side: the receiver can only decrypt the message of its choice. The two
+// messages of a pair must have the same length.
+func Encrypt(keys [][2]Key, messages [][2][]byte) ([][2][]byte, error) {
+	if len(keys) != len(messages) {
+		return nil, ErrInvalidLength
+	}
+	res := make([][2][]byte, len(messages))
+	for i := range messages {
+		if len(messages[i][0]) != len(messages[i][1]) {
+			return nil, ErrInvalidLength
+		}
+		for b := 0; b < 2; b++ {
+			res[i][b] = xorStream(keys[i][b], uint64(i), messages[i][b])
+		}
+	}
+	return res, nil
+}
+
+// Decrypt decrypts the chosen messages from the output of Encrypt, on the receiver side,
+// with the keys of the random OTs and the choice bits.
+func Decrypt(keys []Key, choices []bool, ciphertexts [][2][]byte) ([][]byte, error) {
+	if len(keys) != len(choices) || len(keys) != len(ciphertexts) {
+		return nil, ErrInvalidLength
+	}
+	res := make([][]byte, len(ciphertexts))
+	for i := range ciphertexts {
+		res[i] = xorStream(keys[i], uint64(i), ciphertexts[i][bit(choices[i])])
+	}
+	return res, nil
+}
+
+// xorStream returns msg xored with a key stream derived from key and index.
+func xorStream(key Key, index uint64, msg []byte) []byte {
+	res := make([]byte, len(msg))
+	var buf [KeySize + 16]byte
+	copy(buf[:], key[:])
+	binary.BigEndian.PutUint64(buf[KeySize:], index)
+	for block := uint64(0); int(block)*sha256.Size < len(msg); block++ {
+		binary.BigEndian.PutUint64(buf[KeySize+8:], block)
+		stream := sha256.Sum256(buf[:])
+		start := int(block) * sha256.Size
+		for j := 0; j < sha256.Size && start+j < len(msg); j++ {
+			res[start+j] = msg[start+j] ^ stream[j]
+		}
+	}
+	return res
+}
+
+func bit(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
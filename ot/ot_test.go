@@ -0,0 +1,118 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ot
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+// randomBaseOTs simulates Kappa random base OTs, the extension sender being their
+// receiver.
+func randomBaseOTs(t testing.TB) (delta []bool, received []Key, sent [][2]Key) {
+	delta = randomChoices(t, Kappa)
+	sent = make([][2]Key, Kappa)
+	received = make([]Key, Kappa)
+	for j := range sent {
+		for b := 0; b < 2; b++ {
+			if _, err := rand.Read(sent[j][b][:]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		received[j] = sent[j][bit(delta[j])]
+	}
+	return
+}
+
+func randomChoices(t testing.TB, n int) []bool {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		t.Fatal(err)
+	}
+	res := make([]bool, n)
+	for i := range res {
+		res[i] = buf[i]&1 == 1
+	}
+	return res
+}
+
+func TestIKNP(t *testing.T) {
+	delta, received, sent := randomBaseOTs(t)
+	sender, err := NewExtensionSender(delta, received)
+	if err != nil {
+		t.Fatal(err)
+	}
+	receiver, err := NewExtensionReceiver(sent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// successive extensions, of sizes that are not multiples of 8
+	for _, m := range []int{1, 13, 1000} {
+		choices := randomChoices(t, m)
+		u, keys := receiver.Extend(choices)
+		senderKeys, err := sender.Extend(m, u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < m; i++ {
+			if keys[i] != senderKeys[i][bit(choices[i])] {
+				t.Fatalf("m=%d: receiver key %d differs from the chosen sender key", m, i)
+			}
+			if keys[i] == senderKeys[i][1-bit(choices[i])] || senderKeys[i][0] == senderKeys[i][1] {
+				t.Fatalf("m=%d: receiver learns both keys of OT %d", m, i)
+			}
+		}
+
+		// chosen messages
+		messages := make([][2][]byte, m)
+		for i := range messages {
+			messages[i] = [2][]byte{make([]byte, 40), make([]byte, 40)}
+			rand.Read(messages[i][0])
+			rand.Read(messages[i][1])
+		}
+		ciphertexts, err := Encrypt(senderKeys, messages)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decrypted, err := Decrypt(keys, choices, ciphertexts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range decrypted {
+			if !bytes.Equal(decrypted[i], messages[i][bit(choices[i])]) {
+				t.Fatalf("m=%d: message %d not decrypted", m, i)
+			}
+		}
+	}
+
+	if _, err := sender.Extend(10, make([]byte, 3)); err != ErrInvalidLength {
+		t.Fatal("expected ErrInvalidLength")
+	}
+}
+
+func BenchmarkIKNP(b *testing.B) {
+	const m = 1 << 14
+	delta, received, sent := randomBaseOTs(b)
+	sender, _ := NewExtensionSender(delta, received)
+	receiver, _ := NewExtensionReceiver(sent)
+	choices := randomChoices(b, m)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		u, _ := receiver.Extend(choices)
+		sender.Extend(m, u)
+	}
+}
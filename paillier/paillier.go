@@ -0,0 +1,356 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package paillier implements the additively homomorphic encryption schemes of Paillier,
+// and of Damgård and Jurik, its generalization to a plaintext space ℤ_{Nˢ} with
+// ciphertexts in ℤ*_{Nˢ⁺¹} (s = 1 is Paillier).
+//
+// A message m is encrypted with randomness r as c = (1+N)ᵐ·r^(Nˢ) mod Nˢ⁺¹: the product
+// of two ciphertexts is an encryption of the sum of their messages, and cᵏ is an
+// encryption of k·m. Such operations are needed by the multiplicative-to-additive share
+// conversions of threshold ECDSA.
+//
+// Messages are integers modulo Nˢ, which can be represented in the centered range
+// (-Nˢ/2, Nˢ/2]: DecryptBounded decrypts to this range and checks a bound on the
+// absolute value of the message.
+//
+// References:
+// - Paillier, "Public-Key Cryptosystems Based on Composite Degree Residuosity Classes", Eurocrypt 1999
+// - Damgård, Jurik, "A Generalisation, a Simplification and Some Applications of Paillier's Probabilistic Public-Key System", PKC 2001
+package paillier
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrInvalidParameters = errors.New("paillier: the modulus must have at least 1024 bits, and s must be positive")
+	ErrInvalidCiphertext = errors.New("paillier: invalid ciphertext")
+	ErrOutOfRange        = errors.New("paillier: the message is out of range")
+)
+
+var one = big.NewInt(1)
+
+// PublicKey is a Damgård-Jurik public key: the modulus N and the exponent s.
+type PublicKey struct {
+	N *big.Int
+	S int
+
+	ns  *big.Int // Nˢ, the plaintext modulus
+	ns1 *big.Int // Nˢ⁺¹, the ciphertext modulus
+	g   *big.Int // 1+N
+}
+
+// PrivateKey is a Damgård-Jurik private key.
+type PrivateKey struct {
+	PublicKey
+	p, q *big.Int
+	d    *big.Int // d ≡ 0 mod λ, d ≡ 1 mod Nˢ
+}
+
+// Ciphertext is an encryption of a message, an element of ℤ*_{Nˢ⁺¹}.
+type Ciphertext struct {
+	C *big.Int
+}
+
+// GenerateKey generates a Paillier key pair whose modulus has nbBits bits.
+func GenerateKey(r io.Reader, nbBits int) (*PrivateKey, error) {
+	return GenerateKeyDJ(r, nbBits, 1)
+}
+
+// GenerateKeyDJ generates a Damgård-Jurik key pair whose modulus has nbBits bits, for
+// messages modulo Nˢ.
+func GenerateKeyDJ(r io.Reader, nbBits, s int) (*PrivateKey, error) {
+	if nbBits < 1024 || s < 1 {
+		return nil, ErrInvalidParameters
+	}
+	if r == nil {
+		r = rand.Reader
+	}
+	for {
+		p, err := rand.Prime(r, nbBits/2)
+		if err != nil {
+			return nil, err
+		}
+		q, err := rand.Prime(r, nbBits-nbBits/2)
+		if err != nil {
+			return nil, err
+		}
+		if p.Cmp(q) == 0 {
+			continue
+		}
+		if n := new(big.Int).Mul(p, q); n.BitLen() == nbBits {
+			return newPrivateKey(p, q, s)
+		}
+	}
+}
+
+// newPrivateKey returns the private key of the primes p and q, of the same size.
+func newPrivateKey(p, q *big.Int, s int) (*PrivateKey, error) {
+	n := new(big.Int).Mul(p, q)
+	pk, err := NewPublicKey(n, s)
+	if err != nil {
+		return nil, err
+	}
+
+	// λ = lcm(p-1, q-1)
+	p1, q1 := new(big.Int).Sub(p, one), new(big.Int).Sub(q, one)
+	lambda := new(big.Int).Mul(p1, q1)
+	lambda.Quo(lambda, new(big.Int).GCD(nil, nil, p1, q1))
+
+	// d = λ⋅(λ⁻¹ mod Nˢ)
+	d := new(big.Int).ModInverse(lambda, pk.ns)
+	if d == nil {
+		return nil, ErrInvalidParameters
+	}
+	d.Mul(d, lambda)
+
+	return &PrivateKey{PublicKey: *pk, p: p, q: q, d: d}, nil
+}
+
+// NewPublicKey returns the Damgård-Jurik public key of modulus n and exponent s.
+func NewPublicKey(n *big.Int, s int) (*PublicKey, error) {
+	if n.BitLen() < 1024 || n.Bit(0) != 1 || s < 1 {
+		return nil, ErrInvalidParameters
+	}
+	ns := new(big.Int).Exp(n, big.NewInt(int64(s)), nil)
+	return &PublicKey{
+		N:   new(big.Int).Set(n),
+		S:   s,
+		ns:  ns,
+		ns1: new(big.Int).Mul(ns, n),
+		g:   new(big.Int).Add(n, one),
+	}, nil
+}
+
+// PlaintextModulus returns Nˢ.
+func (pk *PublicKey) PlaintextModulus() *big.Int {
+	return new(big.Int).Set(pk.ns)
+}
+
+// Encrypt encrypts m mod Nˢ, with randomness from r. m can be negative.
+func (pk *PublicKey) Encrypt(r io.Reader, m *big.Int) (*Ciphertext, error) {
+	rn, err := pk.randomMask(r)
+	if err != nil {
+		return nil, err
+	}
+	return pk.encrypt(m, rn), nil
+}
+
+// EncryptWithNonce encrypts m mod Nˢ with the randomness nonce, in ℤ*_N. It is
+// deterministic, to prove statements on the ciphertext.
+func (pk *PublicKey) EncryptWithNonce(m, nonce *big.Int) (*Ciphertext, error) {
+	if nonce.Sign() <= 0 || nonce.Cmp(pk.N) >= 0 || new(big.Int).GCD(nil, nil, nonce, pk.N).Cmp(one) != 0 {
+		return nil, ErrInvalidParameters
+	}
+	return pk.encrypt(m, new(big.Int).Exp(nonce, pk.ns, pk.ns1)), nil
+}
+
+// EncryptBatch encrypts messages in parallel, with randomness from r.
+func (pk *PublicKey) EncryptBatch(r io.Reader, messages []*big.Int) ([]*Ciphertext, error) {
+	// the nonces are drawn sequentially, r may not be safe for concurrent use
+	nonces := make([]*big.Int, len(messages))
+	for i := range nonces {
+		var err error
+		if nonces[i], err = pk.randomNonce(r); err != nil {
+			return nil, err
+		}
+	}
+	res := make([]*Ciphertext, len(messages))
+	parallel.Execute(len(messages), func(start, end int) {
+		for i := start; i < end; i++ {
+			res[i] = pk.encrypt(messages[i], new(big.Int).Exp(nonces[i], pk.ns, pk.ns1))
+		}
+	})
+	return res, nil
+}
+
+// encrypt returns (1+N)ᵐ⋅rn mod Nˢ⁺¹, rn being the mask r^(Nˢ).
+func (pk *PublicKey) encrypt(m, rn *big.Int) *Ciphertext {
+	c := new(big.Int).Mod(m, pk.ns)
+	c.Exp(pk.g, c, pk.ns1)
+	c.Mul(c, rn).Mod(c, pk.ns1)
+	return &Ciphertext{C: c}
+}
+
+// randomNonce returns a random element of ℤ*_N.
+func (pk *PublicKey) randomNonce(r io.Reader) (*big.Int, error) {
+	if r == nil {
+		r = rand.Reader
+	}
+	for {
+		nonce, err := rand.Int(r, pk.N)
+		if err != nil {
+			return nil, err
+		}
+		if nonce.Sign() > 0 && new(big.Int).GCD(nil, nil, nonce, pk.N).Cmp(one) == 0 {
+			return nonce, nil
+		}
+	}
+}
+
+// randomMask returns r^(Nˢ) mod Nˢ⁺¹ for a random r in ℤ*_N.
+func (pk *PublicKey) randomMask(r io.Reader) (*big.Int, error) {
+	nonce, err := pk.randomNonce(r)
+	if err != nil {
+		return nil, err
+	}
+	return nonce.Exp(nonce, pk.ns, pk.ns1), nil
+}
+
+// Validate returns an error if ct is not an element of ℤ*_{Nˢ⁺¹}.
+func (pk *PublicKey) Validate(ct *Ciphertext) error {
+	if ct == nil || ct.C == nil || ct.C.Sign() <= 0 || ct.C.Cmp(pk.ns1) >= 0 {
+		return ErrInvalidCiphertext
+	}
+	if new(big.Int).GCD(nil, nil, ct.C, pk.N).Cmp(one) != 0 {
+		return ErrInvalidCiphertext
+	}
+	return nil
+}
+
+// The homomorphic operations below return ErrInvalidCiphertext if one of their
+// ciphertexts is not an element of ℤ*_{Nˢ⁺¹}, see Validate.
+
+// Add returns an encryption of the sum of the messages of a and b.
+func (pk *PublicKey) Add(a, b *Ciphertext) (*Ciphertext, error) {
+	if err := pk.Validate(a); err != nil {
+		return nil, err
+	}
+	if err := pk.Validate(b); err != nil {
+		return nil, err
+	}
+	c := new(big.Int).Mul(a.C, b.C)
+	return &Ciphertext{C: c.Mod(c, pk.ns1)}, nil
+}
+
+// Sub returns an encryption of the difference of the messages of a and b.
+func (pk *PublicKey) Sub(a, b *Ciphertext) (*Ciphertext, error) {
+	nb, err := pk.Neg(b)
+	if err != nil {
+		return nil, err
+	}
+	return pk.Add(a, nb)
+}
+
+// Neg returns an encryption of the opposite of the message of a.
+func (pk *PublicKey) Neg(a *Ciphertext) (*Ciphertext, error) {
+	if err := pk.Validate(a); err != nil {
+		return nil, err
+	}
+	return &Ciphertext{C: new(big.Int).ModInverse(a.C, pk.ns1)}, nil
+}
+
+// AddPlain returns an encryption of the message of a plus m.
+func (pk *PublicKey) AddPlain(a *Ciphertext, m *big.Int) (*Ciphertext, error) {
+	if err := pk.Validate(a); err != nil {
+		return nil, err
+	}
+	gm := new(big.Int).Mod(m, pk.ns)
+	gm.Exp(pk.g, gm, pk.ns1)
+	gm.Mul(gm, a.C).Mod(gm, pk.ns1)
+	return &Ciphertext{C: gm}, nil
+}
+
+// MulPlain returns an encryption of k times the message of a. k can be negative.
+func (pk *PublicKey) MulPlain(a *Ciphertext, k *big.Int) (*Ciphertext, error) {
+	if err := pk.Validate(a); err != nil {
+		return nil, err
+	}
+	e := new(big.Int).Mod(k, pk.ns)
+	return &Ciphertext{C: e.Exp(a.C, e, pk.ns1)}, nil
+}
+
+// Rerandomize returns a fresh encryption of the message of a, with randomness from r.
+func (pk *PublicKey) Rerandomize(r io.Reader, a *Ciphertext) (*Ciphertext, error) {
+	if err := pk.Validate(a); err != nil {
+		return nil, err
+	}
+	rn, err := pk.randomMask(r)
+	if err != nil {
+		return nil, err
+	}
+	rn.Mul(rn, a.C).Mod(rn, pk.ns1)
+	return &Ciphertext{C: rn}, nil
+}
+
+// Public returns the public key of the private key.
+func (sk *PrivateKey) Public() *PublicKey {
+	pk := sk.PublicKey
+	return &pk
+}
+
+// Decrypt returns the message of ct, in [0, Nˢ).
+func (sk *PrivateKey) Decrypt(ct *Ciphertext) (*big.Int, error) {
+	if err := sk.Validate(ct); err != nil {
+		return nil, err
+	}
+	// cᵈ = (1+N)ᵐ mod Nˢ⁺¹
+	a := new(big.Int).Exp(ct.C, sk.d, sk.ns1)
+	return sk.dlog(a), nil
+}
+
+// DecryptBounded returns the message m of ct in the centered range (-Nˢ/2, Nˢ/2], and
+// checks that |m| < bound, or returns ErrOutOfRange.
+func (sk *PrivateKey) DecryptBounded(ct *Ciphertext, bound *big.Int) (*big.Int, error) {
+	m, err := sk.Decrypt(ct)
+	if err != nil {
+		return nil, err
+	}
+	if m.Cmp(new(big.Int).Rsh(sk.ns, 1)) > 0 {
+		m.Sub(m, sk.ns)
+	}
+	if m.CmpAbs(bound) >= 0 {
+		return nil, ErrOutOfRange
+	}
+	return m, nil
+}
+
+// dlog returns m in [0, Nˢ) such that a = (1+N)ᵐ mod Nˢ⁺¹ (Damgård-Jurik, Theorem 1).
+func (sk *PrivateKey) dlog(a *big.Int) *big.Int {
+	// L(x) = (x-1)/N
+	L := func(x *big.Int) *big.Int {
+		res := new(big.Int).Sub(x, one)
+		return res.Quo(res, sk.N)
+	}
+
+	i := new(big.Int)
+	nj := new(big.Int).Set(sk.N) // Nʲ
+	for j := 1; j <= sk.S; j++ {
+		nj1 := new(big.Int).Mul(nj, sk.N) // Nʲ⁺¹
+		t1 := L(new(big.Int).Mod(a, nj1))
+		t2 := new(big.Int).Set(i)
+		nk1 := new(big.Int).Set(one)   // Nᵏ⁻¹
+		kFact := new(big.Int).Set(one) // k!
+		for k := 2; k <= j; k++ {
+			i.Sub(i, one)
+			t2.Mul(t2, i).Mod(t2, nj)
+			nk1.Mul(nk1, sk.N)
+			kFact.Mul(kFact, big.NewInt(int64(k)))
+
+			// t₁ = t₁ - t₂⋅Nᵏ⁻¹/k! mod Nʲ
+			t := new(big.Int).Mul(t2, nk1)
+			t.Mul(t, new(big.Int).ModInverse(kFact, nj))
+			t1.Sub(t1, t).Mod(t1, nj)
+		}
+		i.Set(t1)
+		nj = nj1
+	}
+	return i
+}
@@ -0,0 +1,175 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paillier
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestPaillier(t *testing.T) {
+	for _, s := range []int{1, 2, 3} {
+		sk, err := GenerateKeyDJ(rand.Reader, 1024, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pk := sk.Public()
+		ns := pk.PlaintextModulus()
+
+		a, _ := rand.Int(rand.Reader, ns)
+		b, _ := rand.Int(rand.Reader, ns)
+		k := big.NewInt(-12345)
+
+		ca, err := pk.Encrypt(rand.Reader, a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cb, err := pk.Encrypt(rand.Reader, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		check := func(name string, ct *Ciphertext, err error, expected *big.Int) {
+			t.Helper()
+			if err != nil {
+				t.Fatal(s, name, err)
+			}
+			m, err := sk.Decrypt(ct)
+			if err != nil {
+				t.Fatal(s, name, err)
+			}
+			if m.Cmp(new(big.Int).Mod(expected, ns)) != 0 {
+				t.Fatalf("s=%d: %s: unexpected decryption", s, name)
+			}
+		}
+		check("encrypt", ca, nil, a)
+		sum, err := pk.Add(ca, cb)
+		check("add", sum, err, new(big.Int).Add(a, b))
+		diff, err := pk.Sub(ca, cb)
+		check("sub", diff, err, new(big.Int).Sub(a, b))
+		neg, err := pk.Neg(ca)
+		check("neg", neg, err, new(big.Int).Neg(a))
+		sumPlain, err := pk.AddPlain(ca, k)
+		check("add plain", sumPlain, err, new(big.Int).Add(a, k))
+		prod, err := pk.MulPlain(ca, k)
+		check("mul plain", prod, err, new(big.Int).Mul(a, k))
+
+		rerandomized, err := pk.Rerandomize(rand.Reader, ca)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rerandomized.C.Cmp(ca.C) == 0 {
+			t.Fatal("ciphertext was not rerandomized")
+		}
+		check("rerandomize", rerandomized, nil, a)
+
+		nonce := big.NewInt(42)
+		c1, _ := pk.EncryptWithNonce(a, nonce)
+		c2, _ := pk.EncryptWithNonce(a, nonce)
+		if c1.C.Cmp(c2.C) != 0 {
+			t.Fatal("EncryptWithNonce is not deterministic")
+		}
+		check("encrypt with nonce", c1, nil, a)
+	}
+}
+
+func TestEncryptBatch(t *testing.T) {
+	sk, err := GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages := make([]*big.Int, 10)
+	for i := range messages {
+		messages[i] = big.NewInt(int64(i*i - 20))
+	}
+	cts, err := sk.EncryptBatch(rand.Reader, messages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bound := big.NewInt(100)
+	for i := range cts {
+		m, err := sk.DecryptBounded(cts[i], bound)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m.Cmp(messages[i]) != 0 {
+			t.Fatalf("message %d: expected %s, got %s", i, messages[i], m)
+		}
+	}
+	if _, err := sk.DecryptBounded(cts[9], big.NewInt(61)); err != ErrOutOfRange {
+		t.Fatal("expected ErrOutOfRange")
+	}
+	if _, err := sk.DecryptBounded(cts[0], big.NewInt(20)); err != ErrOutOfRange {
+		t.Fatal("expected ErrOutOfRange")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	sk, err := GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalid := []*Ciphertext{
+		nil,
+		{},
+		{C: big.NewInt(0)},
+		{C: new(big.Int).Set(sk.ns1)},
+		{C: new(big.Int).Set(sk.p)},
+	}
+	valid, err := sk.Encrypt(rand.Reader, big.NewInt(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := big.NewInt(3)
+	for i := range invalid {
+		if _, err := sk.Decrypt(invalid[i]); err != ErrInvalidCiphertext {
+			t.Fatalf("invalid ciphertext %d accepted", i)
+		}
+		ops := map[string]func() (*Ciphertext, error){
+			"add":         func() (*Ciphertext, error) { return sk.Add(valid, invalid[i]) },
+			"sub":         func() (*Ciphertext, error) { return sk.Sub(invalid[i], valid) },
+			"neg":         func() (*Ciphertext, error) { return sk.Neg(invalid[i]) },
+			"add plain":   func() (*Ciphertext, error) { return sk.AddPlain(invalid[i], k) },
+			"mul plain":   func() (*Ciphertext, error) { return sk.MulPlain(invalid[i], k) },
+			"rerandomize": func() (*Ciphertext, error) { return sk.Rerandomize(rand.Reader, invalid[i]) },
+		}
+		for name, op := range ops {
+			if _, err := op(); err != ErrInvalidCiphertext {
+				t.Fatalf("%s: invalid ciphertext %d accepted", name, i)
+			}
+		}
+	}
+	if _, err := GenerateKey(rand.Reader, 512); err != ErrInvalidParameters {
+		t.Fatal("expected ErrInvalidParameters")
+	}
+}
+
+func BenchmarkEncrypt(b *testing.B) {
+	sk, _ := GenerateKey(rand.Reader, 2048)
+	m := big.NewInt(42)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sk.Encrypt(rand.Reader, m)
+	}
+}
+
+func BenchmarkDecrypt(b *testing.B) {
+	sk, _ := GenerateKey(rand.Reader, 2048)
+	ct, _ := sk.Encrypt(rand.Reader, big.NewInt(42))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sk.Decrypt(ct)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ring
+
+import "math/bits"
+
+// arithmetic modulo q < 2⁶², on residues in [0, q)
+
+func addMod(a, b, q uint64) uint64 {
+	s := a + b
+	if s >= q {
+		s -= q
+	}
+	return s
+}
+
+func subMod(a, b, q uint64) uint64 {
+	if a >= b {
+		return a - b
+	}
+	return a + q - b
+}
+
+func mulMod(a, b, q uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	_, rem := bits.Div64(hi, lo, q)
+	return rem
+}
+
+func expMod(a, e, q uint64) uint64 {
+	res := uint64(1)
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			res = mulMod(res, a, q)
+		}
+		a = mulMod(a, a, q)
+	}
+	return res
+}
+
+// reduceInt64 returns c mod q, in [0, q).
+func reduceInt64(c int64, q uint64) uint64 {
+	if c >= 0 {
+		return uint64(c) % q
+	}
+	return subMod(0, uint64(-(c+1))%q+1, q)
+}
+
+// shoup returns ⌊w·2⁶⁴/q⌋, to multiply by the constant w with mulShoup.
+func shoup(w, q uint64) uint64 {
+	quo, _ := bits.Div64(w, 0, q)
+	return quo
+}
+
+// mulShoup returns a·w mod q, with wShoup = shoup(w, q).
+func mulShoup(a, w, wShoup, q uint64) uint64 {
+	hi, _ := bits.Mul64(a, wShoup)
+	r := a*w - hi*q
+	if r >= q {
+		r -= q
+	}
+	return r
+}
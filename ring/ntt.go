@@ -0,0 +1,103 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ring
+
+import "math/bits"
+
+// nttTable holds the precomputed powers of ψ, a primitive 2n-th root of unity modulo
+// q, for the negacyclic NTT.
+type nttTable struct {
+	q uint64
+
+	// psi[i] = ψ^{bitReverse(i)}, psiInv[i] = ψ^{-bitReverse(i)}, and their Shoup
+	// precomputations
+	psi, psiShoup       []uint64
+	psiInv, psiInvShoup []uint64
+
+	// nInv = n⁻¹ mod q
+	nInv, nInvShoup uint64
+}
+
+func newNTTTable(n int, q uint64) nttTable {
+	t := nttTable{
+		q:           q,
+		psi:         make([]uint64, n),
+		psiShoup:    make([]uint64, n),
+		psiInv:      make([]uint64, n),
+		psiInvShoup: make([]uint64, n),
+	}
+	psi := primitiveRoot(uint64(2*n), q)
+	psiInv := expMod(psi, q-2, q)
+	logN := bits.TrailingZeros(uint(n))
+	pw, pwInv := uint64(1), uint64(1)
+	for i := 0; i < n; i++ {
+		j := bits.Reverse64(uint64(i)) >> (64 - logN)
+		t.psi[j], t.psiInv[j] = pw, pwInv
+		t.psiShoup[j], t.psiInvShoup[j] = shoup(pw, q), shoup(pwInv, q)
+		pw, pwInv = mulMod(pw, psi, q), mulMod(pwInv, psiInv, q)
+	}
+	t.nInv = expMod(uint64(n), q-2, q)
+	t.nInvShoup = shoup(t.nInv, q)
+	return t
+}
+
+// primitiveRoot returns a primitive m-th root of unity modulo the prime q, m a power
+// of two dividing q-1.
+func primitiveRoot(m, q uint64) uint64 {
+	for g := uint64(2); ; g++ {
+		// g^((q-1)/m) has order m iff its m/2-th power is -1
+		w := expMod(g, (q-1)/m, q)
+		if expMod(w, m/2, q) == q-1 {
+			return w
+		}
+	}
+}
+
+// forward is the Cooley-Tukey negacyclic NTT, from natural order to bit-reversed order.
+func (t *nttTable) forward(a []uint64) {
+	n := len(a)
+	q := t.q
+	for m, h := 1, n/2; m < n; m, h = 2*m, h/2 {
+		for i := 0; i < m; i++ {
+			w, wShoup := t.psi[m+i], t.psiShoup[m+i]
+			x, y := a[2*i*h:2*i*h+h], a[2*i*h+h:2*i*h+2*h]
+			for j := range x {
+				v := mulShoup(y[j], w, wShoup, q)
+				x[j], y[j] = addMod(x[j], v, q), subMod(x[j], v, q)
+			}
+		}
+	}
+}
+
+// inverse is the Gentleman-Sande negacyclic inverse NTT, from bit-reversed order to
+// natural order.
+func (t *nttTable) inverse(a []uint64) {
+	n := len(a)
+	q := t.q
+	for m, h := n/2, 1; m >= 1; m, h = m/2, 2*h {
+		for i := 0; i < m; i++ {
+			w, wShoup := t.psiInv[m+i], t.psiInvShoup[m+i]
+			x, y := a[2*i*h:2*i*h+h], a[2*i*h+h:2*i*h+2*h]
+			for j := range x {
+				u, v := x[j], y[j]
+				x[j] = addMod(u, v, q)
+				y[j] = mulShoup(subMod(u, v, q), w, wShoup, q)
+			}
+		}
+	}
+	for j := range a {
+		a[j] = mulShoup(a[j], t.nInv, t.nInvShoup, q)
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ring implements the polynomial ring R_Q = ℤ_Q[X]/(Xⁿ+1), n a power of two,
+// which underlies ring-SIS, ring-LWE and the lattice-based commitments (Ajtai, Labrador, …).
+//
+// The modulus Q = q₀·q₁·…·q_{k-1} is a product of distinct word-sized primes with
+// qᵢ ≡ 1 mod 2n, and a polynomial is stored in residue number system (RNS): by its
+// reductions modulo each qᵢ, see Poly. Each qᵢ has a primitive 2n-th root of unity, so
+// that products are computed coefficient-wise after a negacyclic NTT. SetBigInt and
+// BigInt convert from and to coefficients modulo Q, by the Chinese remainder theorem.
+//
+// Samplers draw polynomials with uniform, ternary and discrete Gaussian coefficients.
+//
+// For the NTT over the scalar fields of the curves, see fft.NegacyclicDomain.
+package ring
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+var (
+	ErrInvalidDegree  = errors.New("ring: the degree must be a power of two, at least 2")
+	ErrInvalidModulus = errors.New("ring: the moduli must be distinct primes less than 2⁶², congruent to 1 mod 2n")
+	ErrNoPrime        = errors.New("ring: not enough NTT-friendly primes of this size")
+)
+
+// maxLogModulus bounds the moduli, so that sums of two residues don't overflow and
+// lazy reductions stay in [0, 2q).
+const maxLogModulus = 62
+
+// Ring is the ring ℤ_Q[X]/(Xⁿ+1) with Q the product of Moduli.
+type Ring struct {
+	N      int
+	Moduli []uint64
+
+	tables []nttTable
+
+	q   *big.Int   // product of the moduli
+	crt []*big.Int // CRT coefficients (Q/qᵢ)·((Q/qᵢ)⁻¹ mod qᵢ)
+}
+
+// Poly is a polynomial of a Ring, in RNS: Coeffs[i][j] is the j-th coefficient modulo
+// Moduli[i], or the j-th evaluation after NTT.
+type Poly struct {
+	Coeffs [][]uint64
+}
+
+// NewRing returns the ring ℤ_Q[X]/(Xⁿ+1), with Q the product of moduli. See
+// GenerateNTTPrimes to find suitable moduli.
+func NewRing(n int, moduli []uint64) (*Ring, error) {
+	if n < 2 || n&(n-1) != 0 {
+		return nil, fmt.Errorf("%w, got %d", ErrInvalidDegree, n)
+	}
+	if len(moduli) == 0 {
+		return nil, fmt.Errorf("%w: no modulus", ErrInvalidModulus)
+	}
+	r := &Ring{
+		N:      n,
+		Moduli: append([]uint64{}, moduli...),
+		tables: make([]nttTable, len(moduli)),
+		q:      big.NewInt(1),
+		crt:    make([]*big.Int, len(moduli)),
+	}
+	seen := make(map[uint64]bool, len(moduli))
+	for i, q := range moduli {
+		if seen[q] || bits.Len64(q) > maxLogModulus || q%uint64(2*n) != 1 || !new(big.Int).SetUint64(q).ProbablyPrime(20) {
+			return nil, fmt.Errorf("%w, got %d", ErrInvalidModulus, q)
+		}
+		seen[q] = true
+		r.tables[i] = newNTTTable(n, q)
+		r.q.Mul(r.q, new(big.Int).SetUint64(q))
+	}
+	for i, q := range moduli {
+		bq := new(big.Int).SetUint64(q)
+		qi := new(big.Int).Quo(r.q, bq)
+		r.crt[i] = new(big.Int).ModInverse(qi, bq)
+		r.crt[i].Mul(r.crt[i], qi)
+	}
+	return r, nil
+}
+
+// GenerateNTTPrimes returns count distinct primes q ≡ 1 mod 2n of logQ bits, the
+// largest ones, in decreasing order. Their product is a suitable modulus for NewRing.
+func GenerateNTTPrimes(logQ, n, count int) ([]uint64, error) {
+	if n < 2 || n&(n-1) != 0 {
+		return nil, fmt.Errorf("%w, got %d", ErrInvalidDegree, n)
+	}
+	if logQ < 2 || logQ > maxLogModulus || 1<<logQ <= 2*n {
+		return nil, fmt.Errorf("%w: %d bits", ErrInvalidModulus, logQ)
+	}
+	step := uint64(2 * n)
+	lower := uint64(1) << (logQ - 1)
+	res := make([]uint64, 0, count)
+	// largest q < 2^logQ with q ≡ 1 mod 2n
+	for q := (uint64(1)<<logQ-1)/step*step + 1; q > lower && len(res) < count; q -= step {
+		if new(big.Int).SetUint64(q).ProbablyPrime(20) {
+			res = append(res, q)
+		}
+	}
+	if len(res) < count {
+		return nil, fmt.Errorf("%w: %d of %d bits", ErrNoPrime, count, logQ)
+	}
+	return res, nil
+}
+
+// Modulus returns Q, the product of the moduli.
+func (r *Ring) Modulus() *big.Int {
+	return new(big.Int).Set(r.q)
+}
+
+// NewPoly returns the zero polynomial.
+func (r *Ring) NewPoly() *Poly {
+	p := &Poly{Coeffs: make([][]uint64, len(r.Moduli))}
+	buf := make([]uint64, r.N*len(r.Moduli))
+	for i := range p.Coeffs {
+		p.Coeffs[i] = buf[i*r.N : (i+1)*r.N]
+	}
+	return p
+}
+
+// Copy returns a copy of p.
+func (p *Poly) Copy() *Poly {
+	res := &Poly{Coeffs: make([][]uint64, len(p.Coeffs))}
+	for i := range p.Coeffs {
+		res.Coeffs[i] = append([]uint64{}, p.Coeffs[i]...)
+	}
+	return res
+}
+
+// Equal returns true if p and other have the same residues.
+func (p *Poly) Equal(other *Poly) bool {
+	if len(p.Coeffs) != len(other.Coeffs) {
+		return false
+	}
+	for i := range p.Coeffs {
+		if len(p.Coeffs[i]) != len(other.Coeffs[i]) {
+			return false
+		}
+		for j := range p.Coeffs[i] {
+			if p.Coeffs[i][j] != other.Coeffs[i][j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Add sets res = a + b.
+func (r *Ring) Add(res, a, b *Poly) {
+	for i, q := range r.Moduli {
+		for j := 0; j < r.N; j++ {
+			res.Coeffs[i][j] = addMod(a.Coeffs[i][j], b.Coeffs[i][j], q)
+		}
+	}
+}
+
+// Sub sets res = a - b.
+func (r *Ring) Sub(res, a, b *Poly) {
+	for i, q := range r.Moduli {
+		for j := 0; j < r.N; j++ {
+			res.Coeffs[i][j] = subMod(a.Coeffs[i][j], b.Coeffs[i][j], q)
+		}
+	}
+}
+
+// Neg sets res = -a.
+func (r *Ring) Neg(res, a *Poly) {
+	for i, q := range r.Moduli {
+		for j := 0; j < r.N; j++ {
+			res.Coeffs[i][j] = subMod(0, a.Coeffs[i][j], q)
+		}
+	}
+}
+
+// MulScalar sets res = c·a, for a signed integer c.
+func (r *Ring) MulScalar(res, a *Poly, c int64) {
+	for i, q := range r.Moduli {
+		ci := reduceInt64(c, q)
+		for j := 0; j < r.N; j++ {
+			res.Coeffs[i][j] = mulMod(a.Coeffs[i][j], ci, q)
+		}
+	}
+}
+
+// MulCoeffs sets res = a ⊙ b, the coefficient-wise product. For a and b in NTT form,
+// it is the NTT of their product in the ring.
+func (r *Ring) MulCoeffs(res, a, b *Poly) {
+	for i, q := range r.Moduli {
+		for j := 0; j < r.N; j++ {
+			res.Coeffs[i][j] = mulMod(a.Coeffs[i][j], b.Coeffs[i][j], q)
+		}
+	}
+}
+
+// Mul sets res = a·b mod Xⁿ+1, a and b given by their coefficients. res may alias a
+// or b.
+func (r *Ring) Mul(res, a, b *Poly) {
+	_a, _b := a.Copy(), b.Copy()
+	r.NTT(_a)
+	r.NTT(_b)
+	r.MulCoeffs(res, _a, _b)
+	r.InverseNTT(res)
+}
+
+// NTT replaces the coefficients of p with its evaluations at the roots of Xⁿ+1
+// modulo each qᵢ, in bit-reversed order.
+func (r *Ring) NTT(p *Poly) {
+	for i := range r.tables {
+		r.tables[i].forward(p.Coeffs[i])
+	}
+}
+
+// InverseNTT is the inverse of NTT.
+func (r *Ring) InverseNTT(p *Poly) {
+	for i := range r.tables {
+		r.tables[i].inverse(p.Coeffs[i])
+	}
+}
+
+// SetInt64 sets the coefficients of p to coeffs, len(coeffs) ≤ n, the others to zero.
+func (r *Ring) SetInt64(p *Poly, coeffs []int64) {
+	for i, q := range r.Moduli {
+		for j := 0; j < r.N; j++ {
+			p.Coeffs[i][j] = 0
+			if j < len(coeffs) {
+				p.Coeffs[i][j] = reduceInt64(coeffs[j], q)
+			}
+		}
+	}
+}
+
+// SetBigInt sets the coefficients of p to coeffs mod Q, len(coeffs) ≤ n, the others
+// to zero.
+func (r *Ring) SetBigInt(p *Poly, coeffs []*big.Int) {
+	var bq, t big.Int
+	for i, q := range r.Moduli {
+		bq.SetUint64(q)
+		for j := 0; j < r.N; j++ {
+			p.Coeffs[i][j] = 0
+			if j < len(coeffs) {
+				p.Coeffs[i][j] = t.Mod(coeffs[j], &bq).Uint64()
+			}
+		}
+	}
+}
+
+// BigInt returns the coefficients of p modulo Q, reconstructed by the Chinese remainder
+// theorem. If centered is true, they are in (-Q/2, Q/2], otherwise in [0, Q).
+func (r *Ring) BigInt(p *Poly, centered bool) []*big.Int {
+	half := new(big.Int).Rsh(r.q, 1)
+	res := make([]*big.Int, r.N)
+	var t big.Int
+	for j := range res {
+		res[j] = new(big.Int)
+		for i := range r.Moduli {
+			t.SetUint64(p.Coeffs[i][j])
+			t.Mul(&t, r.crt[i])
+			res[j].Add(res[j], &t)
+		}
+		res[j].Mod(res[j], r.q)
+		if centered && res[j].Cmp(half) > 0 {
+			res[j].Sub(res[j], r.q)
+		}
+	}
+	return res
+}
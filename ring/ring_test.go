@@ -0,0 +1,240 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ring
+
+import (
+	"crypto/rand"
+	"errors"
+	"math"
+	"math/big"
+	mrand "math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testN        = 64
+	testLogQ     = 50
+	testNbModuli = 3
+)
+
+func testRing(t testing.TB) *Ring {
+	moduli, err := GenerateNTTPrimes(testLogQ, testN, testNbModuli)
+	require.NoError(t, err)
+	r, err := NewRing(testN, moduli)
+	require.NoError(t, err)
+	return r
+}
+
+func randomBigInts(t testing.TB, r *Ring) []*big.Int {
+	res := make([]*big.Int, r.N)
+	for i := range res {
+		var err error
+		res[i], err = rand.Int(rand.Reader, r.q)
+		require.NoError(t, err)
+	}
+	return res
+}
+
+func TestGenerateNTTPrimes(t *testing.T) {
+	assert := require.New(t)
+	moduli, err := GenerateNTTPrimes(testLogQ, testN, testNbModuli)
+	assert.NoError(err)
+	assert.Len(moduli, testNbModuli)
+	for i, q := range moduli {
+		assert.Equal(uint64(1), q%(2*testN))
+		assert.Equal(testLogQ, big.NewInt(0).SetUint64(q).BitLen())
+		if i > 0 {
+			assert.Less(q, moduli[i-1])
+		}
+	}
+
+	_, err = GenerateNTTPrimes(10, 1024, 1)
+	assert.True(errors.Is(err, ErrInvalidModulus))
+	_, err = GenerateNTTPrimes(12, 1024, 10)
+	assert.True(errors.Is(err, ErrNoPrime))
+}
+
+func TestNewRing(t *testing.T) {
+	assert := require.New(t)
+	moduli, err := GenerateNTTPrimes(testLogQ, testN, 2)
+	assert.NoError(err)
+
+	_, err = NewRing(48, moduli)
+	assert.True(errors.Is(err, ErrInvalidDegree))
+	_, err = NewRing(testN, nil)
+	assert.True(errors.Is(err, ErrInvalidModulus))
+	_, err = NewRing(testN, []uint64{moduli[0], moduli[0]})
+	assert.True(errors.Is(err, ErrInvalidModulus), "the moduli must be distinct")
+	_, err = NewRing(testN, []uint64{moduli[0] + 2*testN*2}) // not prime
+	assert.True(errors.Is(err, ErrInvalidModulus))
+	_, err = NewRing(testN, []uint64{1<<61 - 1}) // prime, ≢ 1 mod 2n
+	assert.True(errors.Is(err, ErrInvalidModulus))
+}
+
+func TestCRT(t *testing.T) {
+	assert := require.New(t)
+	r := testRing(t)
+
+	coeffs := randomBigInts(t, r)
+	p := r.NewPoly()
+	r.SetBigInt(p, coeffs)
+	assert.Equal(coeffs, r.BigInt(p, false))
+
+	// centered representatives of small signed coefficients
+	small := make([]int64, r.N)
+	for i := range small {
+		small[i] = int64(i) - int64(r.N/2)
+	}
+	r.SetInt64(p, small)
+	for i, c := range r.BigInt(p, true) {
+		assert.Equal(small[i], c.Int64())
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	assert := require.New(t)
+	r := testRing(t)
+
+	ca, cb := randomBigInts(t, r), randomBigInts(t, r)
+	a, b, res := r.NewPoly(), r.NewPoly(), r.NewPoly()
+	r.SetBigInt(a, ca)
+	r.SetBigInt(b, cb)
+
+	check := func(op func(x, y *big.Int) *big.Int) {
+		expected := make([]*big.Int, r.N)
+		for i := range expected {
+			expected[i] = op(ca[i], cb[i])
+			expected[i].Mod(expected[i], r.q)
+		}
+		assert.Equal(expected, r.BigInt(res, false))
+	}
+
+	r.Add(res, a, b)
+	check(func(x, y *big.Int) *big.Int { return new(big.Int).Add(x, y) })
+	r.Sub(res, a, b)
+	check(func(x, y *big.Int) *big.Int { return new(big.Int).Sub(x, y) })
+	r.Neg(res, a)
+	check(func(x, _ *big.Int) *big.Int { return new(big.Int).Neg(x) })
+	r.MulScalar(res, a, -7)
+	check(func(x, _ *big.Int) *big.Int { return new(big.Int).Mul(x, big.NewInt(-7)) })
+
+	// schoolbook product mod Xⁿ+1
+	expected := make([]*big.Int, r.N)
+	for i := range expected {
+		expected[i] = new(big.Int)
+	}
+	for i := 0; i < r.N; i++ {
+		for j := 0; j < r.N; j++ {
+			t := new(big.Int).Mul(ca[i], cb[j])
+			if i+j < r.N {
+				expected[i+j].Add(expected[i+j], t)
+			} else {
+				expected[i+j-r.N].Sub(expected[i+j-r.N], t)
+			}
+		}
+	}
+	for i := range expected {
+		expected[i].Mod(expected[i], r.q)
+	}
+	r.Mul(a, a, b)
+	assert.Equal(expected, r.BigInt(a, false))
+}
+
+func TestNTT(t *testing.T) {
+	assert := require.New(t)
+	r := testRing(t)
+
+	p := r.NewPoly()
+	assert.NoError(r.SampleUniform(rand.Reader, p))
+	q := p.Copy()
+	r.NTT(q)
+	assert.False(p.Equal(q))
+	r.InverseNTT(q)
+	assert.True(p.Equal(q))
+
+	// the NTT of X is the list of the roots of Xⁿ+1
+	x := r.NewPoly()
+	r.SetInt64(x, []int64{0, 1})
+	r.NTT(x)
+	for i, q := range r.Moduli {
+		for _, root := range x.Coeffs[i] {
+			assert.Equal(q-1, expMod(root, uint64(r.N), q))
+		}
+	}
+}
+
+func TestSamplers(t *testing.T) {
+	assert := require.New(t)
+	r := testRing(t)
+	rnd := mrand.New(mrand.NewSource(42)) //#nosec G404 -- test only
+
+	// uniform: reduced, and deterministic for a seeded stream
+	p, other := r.NewPoly(), r.NewPoly()
+	assert.NoError(r.SampleUniform(mrand.New(mrand.NewSource(1)), p))     //#nosec G404 -- test only
+	assert.NoError(r.SampleUniform(mrand.New(mrand.NewSource(1)), other)) //#nosec G404 -- test only
+	assert.True(p.Equal(other))
+	for i, q := range r.Moduli {
+		for _, c := range p.Coeffs[i] {
+			assert.Less(c, q)
+		}
+	}
+
+	// ternary
+	assert.NoError(r.SampleTernary(rnd, p))
+	counts := make(map[int64]int)
+	for _, c := range r.BigInt(p, true) {
+		assert.True(c.IsInt64() && c.Int64() >= -1 && c.Int64() <= 1)
+		counts[c.Int64()]++
+	}
+	assert.Len(counts, 3)
+
+	// gaussian
+	const sigma = 3.2
+	const nbSamples = 64
+	var sum, sumSquares float64
+	for k := 0; k < nbSamples; k++ {
+		assert.NoError(r.SampleGaussian(rnd, p, sigma))
+		for _, c := range r.BigInt(p, true) {
+			assert.True(c.IsInt64())
+			x := float64(c.Int64())
+			assert.LessOrEqual(math.Abs(x), math.Ceil(gaussianTailCut*sigma))
+			sum += x
+			sumSquares += x * x
+		}
+	}
+	n := float64(nbSamples * r.N)
+	mean := sum / n
+	stddev := math.Sqrt(sumSquares/n - mean*mean)
+	assert.InDelta(0, mean, 0.2)
+	assert.InDelta(sigma, stddev, 0.2)
+
+	assert.True(errors.Is(r.SampleGaussian(rnd, p, 0), ErrInvalidSigma))
+}
+
+func BenchmarkNTT(b *testing.B) {
+	moduli, err := GenerateNTTPrimes(testLogQ, 1<<10, testNbModuli)
+	require.NoError(b, err)
+	r, err := NewRing(1<<10, moduli)
+	require.NoError(b, err)
+	p := r.NewPoly()
+	require.NoError(b, r.SampleUniform(rand.Reader, p))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.NTT(p)
+		r.InverseNTT(p)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ring
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"math/bits"
+)
+
+// ErrInvalidSigma is returned by SampleGaussian when the standard deviation is not positive.
+var ErrInvalidSigma = errors.New("ring: the standard deviation must be positive")
+
+// gaussianTailCut bounds the samples of SampleGaussian to [-tailCut·σ, tailCut·σ].
+const gaussianTailCut = 6
+
+// The samplers read their randomness from rnd, which is typically crypto/rand.Reader;
+// a seeded stream makes them deterministic. They are not constant time.
+
+// SampleUniform sets p to a polynomial with coefficients uniform modulo Q. The
+// coefficients are independent and uniform modulo each qᵢ, which is the same by the
+// Chinese remainder theorem.
+func (r *Ring) SampleUniform(rnd io.Reader, p *Poly) error {
+	var buf [8]byte
+	for i, q := range r.Moduli {
+		mask := uint64(1)<<bits.Len64(q-1) - 1
+		for j := 0; j < r.N; {
+			if _, err := io.ReadFull(rnd, buf[:]); err != nil {
+				return err
+			}
+			if v := binary.LittleEndian.Uint64(buf[:]) & mask; v < q {
+				p.Coeffs[i][j] = v
+				j++
+			}
+		}
+	}
+	return nil
+}
+
+// SampleTernary sets p to a polynomial with coefficients uniform in {-1, 0, 1}.
+func (r *Ring) SampleTernary(rnd io.Reader, p *Poly) error {
+	var buf [64]byte
+	coeffs := make([]int64, r.N)
+	for j, k := 0, len(buf)*4; j < r.N; {
+		if k == len(buf)*4 {
+			if _, err := io.ReadFull(rnd, buf[:]); err != nil {
+				return err
+			}
+			k = 0
+		}
+		// 2 bits per trial, 3 is rejected
+		if v := (buf[k/4] >> (2 * (k % 4))) & 3; v != 3 {
+			coeffs[j] = int64(v) - 1
+			j++
+		}
+		k++
+	}
+	r.SetInt64(p, coeffs)
+	return nil
+}
+
+// SampleGaussian sets p to a polynomial with coefficients drawn from the discrete
+// Gaussian distribution of center 0 and standard deviation sigma, cut at
+// 6·sigma. It uses rejection sampling.
+func (r *Ring) SampleGaussian(rnd io.Reader, p *Poly, sigma float64) error {
+	if !(sigma > 0) {
+		return ErrInvalidSigma
+	}
+	bound := int64(math.Ceil(gaussianTailCut * sigma))
+	width := uint64(2*bound + 1)
+	mask := uint64(1)<<bits.Len64(width-1) - 1
+	var buf [16]byte
+	coeffs := make([]int64, r.N)
+	for j := 0; j < r.N; {
+		if _, err := io.ReadFull(rnd, buf[:]); err != nil {
+			return err
+		}
+		// x uniform in [-bound, bound], accepted with probability exp(-x²/2σ²)
+		v := binary.LittleEndian.Uint64(buf[:8]) & mask
+		if v >= width {
+			continue
+		}
+		x := int64(v) - bound
+		u := float64(binary.LittleEndian.Uint64(buf[8:])>>11) / (1 << 53)
+		if u < math.Exp(-float64(x*x)/(2*sigma*sigma)) {
+			coeffs[j] = x
+			j++
+		}
+	}
+	r.SetInt64(p, coeffs)
+	return nil
+}
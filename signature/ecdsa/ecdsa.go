@@ -17,6 +17,7 @@ limitations under the License.
 package ecdsa
 
 import (
+	"errors"
 	"io"
 
 	"github.com/consensys/gnark-crypto/ecc"
@@ -57,3 +58,29 @@ func New(ss ecc.ID, r io.Reader) (signature.Signer, error) {
 		panic("not implemented")
 	}
 }
+
+// NewPublicKey returns an empty public key on the given curve, to be set with SetBytes
+func NewPublicKey(ss ecc.ID) (signature.PublicKey, error) {
+	switch ss {
+	case ecc.BN254:
+		return &ecdsa_bn254.PublicKey{}, nil
+	case ecc.BLS12_381:
+		return &ecdsa_bls12381.PublicKey{}, nil
+	case ecc.BLS12_377:
+		return &ecdsa_bls12377.PublicKey{}, nil
+	case ecc.BW6_761:
+		return &ecdsa_bw6761.PublicKey{}, nil
+	case ecc.BLS24_315:
+		return &ecdsa_bls24315.PublicKey{}, nil
+	case ecc.BLS24_317:
+		return &ecdsa_bls24317.PublicKey{}, nil
+	case ecc.BW6_633:
+		return &ecdsa_bw6633.PublicKey{}, nil
+	case ecc.SECP256K1:
+		return &ecdsa_secp256k1.PublicKey{}, nil
+	case ecc.STARK_CURVE:
+		return &ecdsa_starkcurve.PublicKey{}, nil
+	default:
+		return nil, errors.New("not implemented")
+	}
+}
@@ -0,0 +1,35 @@
+// Package concurrency holds the process-wide limit on the number of goroutines the
+// gnark-crypto algorithms (FFT, multi-exponentiations, vector operations, …) spawn
+// when the caller doesn't set a number of tasks explicitly.
+package concurrency
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+var maxTasks atomic.Int64
+
+// SetMaxTasks limits to n the default number of tasks of the parallel algorithms, so that
+// an embedding application can cap the CPU usage of the library. A number of tasks passed
+// explicitly to a function (e.g. fft.WithNbTasks, ecc.MultiExpConfig.NbTasks) overrides it.
+// n <= 0 removes the limit.
+func SetMaxTasks(n int) {
+	if n < 0 {
+		n = 0
+	}
+	maxTasks.Store(int64(n))
+}
+
+// MaxTasks returns the limit set by SetMaxTasks, or runtime.NumCPU() if there is none.
+func MaxTasks() int {
+	if n := maxTasks.Load(); n > 0 {
+		return int(n)
+	}
+	return runtime.NumCPU()
+}
+
+// IsLimited reports whether a limit was set with SetMaxTasks.
+func IsLimited() bool {
+	return maxTasks.Load() > 0
+}
@@ -0,0 +1,22 @@
+package concurrency
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestMaxTasks(t *testing.T) {
+	defer SetMaxTasks(0)
+
+	if IsLimited() || MaxTasks() != runtime.NumCPU() {
+		t.Fatal("by default, the number of tasks should be the number of CPUs")
+	}
+	SetMaxTasks(3)
+	if !IsLimited() || MaxTasks() != 3 {
+		t.Fatalf("expected 3 tasks, got %d", MaxTasks())
+	}
+	SetMaxTasks(-1)
+	if IsLimited() || MaxTasks() != runtime.NumCPU() {
+		t.Fatal("a negative limit should remove the limit")
+	}
+}
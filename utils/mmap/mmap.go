@@ -0,0 +1,30 @@
+// Package mmap provides read-only memory mapping of files.
+//
+// On platforms without memory mapping support, the file is read in memory.
+package mmap
+
+// File is a read-only memory-mapped file.
+type File struct {
+	data  []byte
+	close func() error
+}
+
+// Bytes returns the content of the file. It must not be modified, nor used after Close.
+func (f *File) Bytes() []byte {
+	return f.data
+}
+
+// Len returns the size of the file.
+func (f *File) Len() int {
+	return len(f.data)
+}
+
+// Close unmaps the file.
+func (f *File) Close() error {
+	if f.close == nil {
+		return nil
+	}
+	err := f.close()
+	f.data, f.close = nil, nil
+	return err
+}
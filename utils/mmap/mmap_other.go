@@ -0,0 +1,14 @@
+//go:build !unix
+
+package mmap
+
+import "os"
+
+// Open reads the file at path in memory, as memory mapping is not supported on this platform.
+func Open(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &File{data: data}, nil
+}
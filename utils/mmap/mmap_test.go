@@ -0,0 +1,38 @@
+package mmap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpen(t *testing.T) {
+	assert := require.New(t)
+	dir := t.TempDir()
+
+	content := []byte("memory mapped content")
+	path := filepath.Join(dir, "file")
+	assert.NoError(os.WriteFile(path, content, 0600))
+
+	f, err := Open(path)
+	assert.NoError(err)
+	assert.Equal(len(content), f.Len())
+	assert.Equal(content, f.Bytes())
+	assert.NoError(f.Close())
+	assert.Nil(f.Bytes())
+	assert.NoError(f.Close())
+
+	// empty file
+	empty := filepath.Join(dir, "empty")
+	assert.NoError(os.WriteFile(empty, nil, 0600))
+	f, err = Open(empty)
+	assert.NoError(err)
+	assert.Equal(0, f.Len())
+	assert.NoError(f.Close())
+
+	// missing file
+	_, err = Open(filepath.Join(dir, "missing"))
+	assert.Error(err)
+}
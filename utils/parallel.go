@@ -1,8 +1,9 @@
 package utils
 
 import (
-	"runtime"
 	"sync"
+
+	"github.com/consensys/gnark-crypto/utils/concurrency"
 )
 
 // Would normally put this in internal/parallel; but it may be desirable for it to be accessible from gnark
@@ -22,7 +23,7 @@ type WorkerPool struct {
 
 func NewWorkerPool() *WorkerPool {
 	p := &WorkerPool{}
-	p.nbWorkers = runtime.NumCPU() + 2
+	p.nbWorkers = concurrency.MaxTasks() + 2
 	p.chJobs = make(chan job, 40*p.nbWorkers)
 	for i := 0; i < p.nbWorkers; i++ {
 		go func() {
@@ -0,0 +1,116 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vdf implements the verifiable delay function of Wesolowski, "Efficient
+// verifiable delay functions" (https://eprint.iacr.org/2018/623), over a class group.
+//
+// Evaluating the VDF on x computes y = x^(2ᵀ) with T sequential squarings, and a proof
+// that is verified with two small exponentiations. The class group is derived from a
+// public seed, so that no trusted setup is needed.
+package vdf
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/accumulator/unknownorder"
+)
+
+const (
+	domainInput     = "vdf/input"
+	domainChallenge = "vdf/wesolowski"
+	domainOutput    = "vdf/output"
+)
+
+// Proof is a Wesolowski proof that y = x^(2ᵀ).
+type Proof struct {
+	Pi unknownorder.Form // x^⌊2ᵀ/ℓ⌋
+}
+
+// VDF is a verifiable delay function of T squarings in a class group.
+type VDF struct {
+	group *unknownorder.ClassGroup
+	t     uint64
+}
+
+// New returns the VDF of t squarings in group.
+func New(group *unknownorder.ClassGroup, t uint64) *VDF {
+	return &VDF{group: group, t: t}
+}
+
+// Input maps a challenge (e.g. a block hash or a VRF output) to an input of the VDF.
+func (v *VDF) Input(challenge []byte) unknownorder.Form {
+	return v.group.HashToForm(domainInput, challenge)
+}
+
+// Eval returns y = x^(2ᵀ).
+func (v *VDF) Eval(x unknownorder.Form) unknownorder.Form {
+	y := x
+	for i := uint64(0); i < v.t; i++ {
+		y = v.group.Mul(y, y)
+	}
+	return y
+}
+
+// Prove returns a proof that y = x^(2ᵀ). It costs about as much as Eval.
+func (v *VDF) Prove(x, y unknownorder.Form) Proof {
+	l := v.challenge(x, y)
+
+	// π = x^⌊2ᵀ/ℓ⌋, the quotient being computed bit by bit by long division
+	pi := v.group.Identity()
+	r := big.NewInt(1)
+	for i := uint64(0); i < v.t; i++ {
+		pi = v.group.Mul(pi, pi)
+		r.Lsh(r, 1)
+		if r.Cmp(l) >= 0 {
+			r.Sub(r, l)
+			pi = v.group.Mul(pi, x)
+		}
+	}
+	return Proof{Pi: pi}
+}
+
+// EvalAndProve returns y = x^(2ᵀ) and a proof of it.
+func (v *VDF) EvalAndProve(x unknownorder.Form) (unknownorder.Form, Proof) {
+	y := v.Eval(x)
+	return y, v.Prove(x, y)
+}
+
+// Verify returns true if proof proves that y = x^(2ᵀ), that is if πˡ·xʳ = y with
+// r = 2ᵀ mod ℓ.
+func (v *VDF) Verify(x, y unknownorder.Form, proof Proof) bool {
+	if !v.group.IsValid(x) || !v.group.IsValid(y) || !v.group.IsValid(proof.Pi) {
+		return false
+	}
+	l := v.challenge(x, y)
+	r := new(big.Int).Exp(big.NewInt(2), new(big.Int).SetUint64(v.t), l)
+	res := v.group.Mul(v.group.Exp(proof.Pi, l), v.group.Exp(x, r))
+	return v.group.Equal(res, y)
+}
+
+// Output returns a 32 bytes digest of y, e.g. to seed a randomness beacon or to be
+// hashed to a field element.
+func (v *VDF) Output(y unknownorder.Form) []byte {
+	h := sha256.New()
+	h.Write([]byte(domainOutput))
+	h.Write(v.group.Marshal(y))
+	return h.Sum(nil)
+}
+
+// challenge returns the prime ℓ = H(x, y, T).
+func (v *VDF) challenge(x, y unknownorder.Form) *big.Int {
+	t := binary.BigEndian.AppendUint64(nil, v.t)
+	return unknownorder.HashToPrime(domainChallenge, v.group.Marshal(x), v.group.Marshal(y), t)
+}
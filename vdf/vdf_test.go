@@ -0,0 +1,86 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vdf
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/accumulator/unknownorder"
+)
+
+func testVDF(t testing.TB, nbSquarings uint64) *VDF {
+	group, err := unknownorder.NewClassGroup([]byte("vdf test"), 512)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return New(group, nbSquarings)
+}
+
+func TestVDF(t *testing.T) {
+	const nbSquarings = 200
+	v := testVDF(t, nbSquarings)
+	x := v.Input([]byte("challenge"))
+
+	y, proof := v.EvalAndProve(x)
+	expected := v.group.Exp(x, new(big.Int).Lsh(big.NewInt(1), nbSquarings))
+	if !v.group.Equal(y, expected) {
+		t.Fatal("y ≠ x^(2ᵀ)")
+	}
+	if !v.Verify(x, y, proof) {
+		t.Fatal("invalid proof")
+	}
+
+	// wrong output, input, proof or delay
+	if v.Verify(x, v.group.Mul(y, y), proof) {
+		t.Fatal("proof verified for the wrong output")
+	}
+	if v.Verify(v.Input([]byte("other")), y, proof) {
+		t.Fatal("proof verified for the wrong input")
+	}
+	if v.Verify(x, y, Proof{Pi: v.group.Mul(proof.Pi, x)}) {
+		t.Fatal("tampered proof verified")
+	}
+	if New(v.group, nbSquarings+1).Verify(x, y, proof) {
+		t.Fatal("proof verified for the wrong delay")
+	}
+	if v.Verify(x, y, Proof{Pi: unknownorder.Form{A: big.NewInt(1), B: big.NewInt(1), C: big.NewInt(1)}}) {
+		t.Fatal("proof with the wrong discriminant verified")
+	}
+
+	if !bytes.Equal(v.Output(y), v.Output(expected)) || bytes.Equal(v.Output(y), v.Output(x)) {
+		t.Fatal("unexpected output")
+	}
+}
+
+func BenchmarkEval(b *testing.B) {
+	v := testVDF(b, 1000)
+	x := v.Input([]byte("challenge"))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.Eval(x)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	v := testVDF(b, 1000)
+	x := v.Input([]byte("challenge"))
+	y, proof := v.EvalAndProve(x)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.Verify(x, y, proof)
+	}
+}